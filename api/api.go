@@ -15,7 +15,6 @@ import (
 	"github.com/ONSdigital/dp-dataset-api/cloudflare"
 	"github.com/ONSdigital/dp-dataset-api/config"
 	"github.com/ONSdigital/dp-dataset-api/dimension"
-	"github.com/ONSdigital/dp-dataset-api/download"
 	"github.com/ONSdigital/dp-dataset-api/instance"
 	"github.com/ONSdigital/dp-dataset-api/models"
 	"github.com/ONSdigital/dp-dataset-api/pagination"
@@ -34,6 +33,9 @@ const (
 	downloadServiceToken = "X-Download-Service-Token"
 	updateVersionAction  = "updateVersion"
 	hasDownloads         = "has_downloads"
+	stateChangedBy       = "state_changed_by"
+	stateChangeComment   = "state_change_comment"
+	approvedOnly         = "approved_only"
 )
 
 var (
@@ -52,6 +54,8 @@ var (
 	datasetInstanceCreatePermission = "dataset-instances:create"
 	datasetInstanceReadPermission   = "dataset-instances:read"
 	datasetInstanceUpdatePermission = "dataset-instances:update"
+
+	auditEventsReadPermission = "dataset-audit-events:read"
 )
 
 // API provides an interface for the routes
@@ -64,65 +68,59 @@ type DownloadsGenerator interface {
 	Generate(ctx context.Context, datasetID, instanceID, edition, version string) error
 }
 
-type SearchContentUpdatedProducer struct {
-	Producer download.KafkaProducer
-}
-
 // DatasetAPI manages importing filters against a dataset
 type DatasetAPI struct {
-	Router                       *mux.Router
-	dataStore                    store.DataStore
-	urlBuilder                   *url.Builder
-	enableURLRewriting           bool
-	host                         string
-	downloadServiceToken         string
-	EnablePrePublishView         bool
-	downloadGenerators           map[models.DatasetType]DownloadsGenerator
-	enablePrivateEndpoints       bool
-	enableDetachDataset          bool
-	enableDeleteStaticVersion    bool
-	authMiddleware               auth.Middleware
-	instancePublishedChecker     *instance.PublishCheck
-	versionPublishedChecker      *PublishCheck
-	MaxRequestOptions            int
-	defaultLimit                 int
-	smDatasetAPI                 *application.StateMachineDatasetAPI
-	auditService                 application.AuditService
-	filesAPIClient               filesAPISDK.Clienter
-	authToken                    string
-	permissionsChecker           auth.PermissionsChecker
-	idClient                     *clientsidentity.Client
-	searchContentUpdatedProducer *SearchContentUpdatedProducer
-	cloudflareClient             cloudflare.Clienter
-	cloudflareEnabled            bool
+	Router                    *mux.Router
+	dataStore                 store.DataStore
+	urlBuilder                *url.Builder
+	enableURLRewriting        bool
+	host                      string
+	downloadServiceToken      string
+	EnablePrePublishView      bool
+	downloadGenerators        map[models.DatasetType]DownloadsGenerator
+	enablePrivateEndpoints    bool
+	enableDetachDataset       bool
+	enableDeleteStaticVersion bool
+	authMiddleware            auth.Middleware
+	instancePublishedChecker  *instance.PublishCheck
+	versionPublishedChecker   *PublishCheck
+	MaxRequestOptions         int
+	defaultLimit              int
+	smDatasetAPI              *application.StateMachineDatasetAPI
+	auditService              application.AuditService
+	filesAPIClient            filesAPISDK.Clienter
+	authToken                 string
+	permissionsChecker        auth.PermissionsChecker
+	idClient                  *clientsidentity.Client
+	cloudflareClient          cloudflare.Clienter
+	cloudflareEnabled         bool
 }
 
 // Setup creates a new Dataset API instance and register the API routes based on the application configuration.
-func Setup(ctx context.Context, cfg *config.Configuration, router *mux.Router, dataStore store.DataStore, urlBuilder *url.Builder, downloadGenerators map[models.DatasetType]DownloadsGenerator, authMiddleware auth.Middleware, enableURLRewriting bool, smDatasetAPI *application.StateMachineDatasetAPI, auditService application.AuditService, permissionsChecker auth.PermissionsChecker, idClient *clientsidentity.Client, searchContentUpdatedProducer *SearchContentUpdatedProducer, cloudflareClient cloudflare.Clienter) *DatasetAPI {
+func Setup(ctx context.Context, cfg *config.Configuration, router *mux.Router, dataStore store.DataStore, urlBuilder *url.Builder, downloadGenerators map[models.DatasetType]DownloadsGenerator, authMiddleware auth.Middleware, enableURLRewriting bool, smDatasetAPI *application.StateMachineDatasetAPI, auditService application.AuditService, permissionsChecker auth.PermissionsChecker, idClient *clientsidentity.Client, cloudflareClient cloudflare.Clienter) *DatasetAPI {
 	api := &DatasetAPI{
-		dataStore:                    dataStore,
-		host:                         cfg.DatasetAPIURL,
-		downloadServiceToken:         cfg.DownloadServiceSecretKey,
-		EnablePrePublishView:         cfg.EnablePrivateEndpoints,
-		Router:                       router,
-		urlBuilder:                   urlBuilder,
-		enableURLRewriting:           enableURLRewriting,
-		downloadGenerators:           downloadGenerators,
-		enablePrivateEndpoints:       cfg.EnablePrivateEndpoints,
-		enableDetachDataset:          cfg.EnableDetachDataset,
-		enableDeleteStaticVersion:    cfg.EnableDeleteStaticVersion,
-		authMiddleware:               authMiddleware,
-		versionPublishedChecker:      nil,
-		instancePublishedChecker:     nil,
-		MaxRequestOptions:            cfg.MaxRequestOptions,
-		defaultLimit:                 cfg.DefaultLimit,
-		smDatasetAPI:                 smDatasetAPI,
-		permissionsChecker:           permissionsChecker,
-		auditService:                 auditService,
-		idClient:                     idClient,
-		searchContentUpdatedProducer: searchContentUpdatedProducer,
-		cloudflareClient:             cloudflareClient,
-		cloudflareEnabled:            cfg.CloudflareEnabled,
+		dataStore:                 dataStore,
+		host:                      cfg.DatasetAPIURL,
+		downloadServiceToken:      cfg.DownloadServiceSecretKey,
+		EnablePrePublishView:      cfg.EnablePrivateEndpoints,
+		Router:                    router,
+		urlBuilder:                urlBuilder,
+		enableURLRewriting:        enableURLRewriting,
+		downloadGenerators:        downloadGenerators,
+		enablePrivateEndpoints:    cfg.EnablePrivateEndpoints,
+		enableDetachDataset:       cfg.EnableDetachDataset,
+		enableDeleteStaticVersion: cfg.EnableDeleteStaticVersion,
+		authMiddleware:            authMiddleware,
+		versionPublishedChecker:   nil,
+		instancePublishedChecker:  nil,
+		MaxRequestOptions:         cfg.MaxRequestOptions,
+		defaultLimit:              cfg.DefaultLimit,
+		smDatasetAPI:              smDatasetAPI,
+		permissionsChecker:        permissionsChecker,
+		auditService:              auditService,
+		idClient:                  idClient,
+		cloudflareClient:          cloudflareClient,
+		cloudflareEnabled:         cfg.CloudflareEnabled,
 	}
 
 	paginator := pagination.NewPaginator(cfg.DefaultLimit, cfg.DefaultOffset, cfg.DefaultMaxLimit)
@@ -144,6 +142,8 @@ func Setup(ctx context.Context, cfg *config.Configuration, router *mux.Router, d
 			EnableDetachDataset: api.enableDetachDataset,
 			URLBuilder:          api.urlBuilder,
 			EnableURLRewriting:  api.enableURLRewriting,
+			AuditService:        api.auditService,
+			RequestedBy:         api.getRequestedBy,
 		}
 
 		dimensionAPI := &dimension.Store{
@@ -152,6 +152,8 @@ func Setup(ctx context.Context, cfg *config.Configuration, router *mux.Router, d
 			MaxRequestOptions:  api.MaxRequestOptions,
 			URLBuilder:         api.urlBuilder,
 			EnableURLRewriting: api.enableURLRewriting,
+			AuditService:       api.auditService,
+			RequestedBy:        api.getRequestedBy,
 		}
 
 		api.enablePrivateDatasetEndpoints(paginator)
@@ -172,7 +174,7 @@ func (api *DatasetAPI) SetFilesAPIClient(client filesAPISDK.Clienter, authToken
 
 // enablePublicEndpoints register only the public GET endpoints.
 func (api *DatasetAPI) enablePublicEndpoints(paginator *pagination.Paginator) {
-	api.get("/datasets", paginator.Paginate(api.getDatasets))
+	api.get("/datasets", paginator.PaginateWithCursor(api.getDatasets))
 	api.get("/datasets/{dataset_id}", api.getDataset)
 	api.get("/datasets/{dataset_id}/editions", paginator.Paginate(api.getEditions))
 	api.get("/datasets/{dataset_id}/editions/{edition}", api.getEdition)
@@ -180,7 +182,7 @@ func (api *DatasetAPI) enablePublicEndpoints(paginator *pagination.Paginator) {
 	api.get("/datasets/{dataset_id}/editions/{edition}/versions/{version}", contextAndErrors(api.getVersion))
 	api.get("/datasets/{dataset_id}/editions/{edition}/versions/{version}/metadata", api.getMetadata)
 	api.get("/datasets/{dataset_id}/editions/{edition}/versions/{version}/dimensions", paginator.Paginate(api.getDimensions))
-	api.get("/datasets/{dataset_id}/editions/{edition}/versions/{version}/dimensions/{dimension}/options", paginator.Paginate(api.getDimensionOptions))
+	api.get("/datasets/{dataset_id}/editions/{edition}/versions/{version}/dimensions/{dimension}/options", paginator.PaginateWithCursor(api.getDimensionOptions))
 }
 
 func writeErrorResponse(w http.ResponseWriter, errorResponse *models.ErrorResponse) {
@@ -257,7 +259,7 @@ func contextAndErrors(h baseHandler) http.HandlerFunc {
 func (api *DatasetAPI) enablePrivateDatasetEndpoints(paginator *pagination.Paginator) {
 	api.get(
 		"/datasets",
-		api.authMiddleware.Require(datasetReadPermission, paginator.Paginate(api.getDatasets)),
+		api.authMiddleware.Require(datasetReadPermission, paginator.PaginateWithCursor(api.getDatasets)),
 	)
 
 	api.get(
@@ -290,6 +292,11 @@ func (api *DatasetAPI) enablePrivateDatasetEndpoints(paginator *pagination.Pagin
 		api.authMiddleware.RequireWithAttributes(datasetEditionVersionReadPermission, api.getMetadata, api.getPermissionAttributesFromRequest),
 	)
 
+	api.get(
+		"/datasets/{dataset_id}/editions/{edition}/versions/{version}/diff",
+		api.authMiddleware.RequireWithAttributes(datasetEditionVersionReadPermission, contextAndErrors(api.getVersionDiff), api.getPermissionAttributesFromRequest),
+	)
+
 	api.get(
 		"/datasets/{dataset_id}/editions/{edition}/versions/{version}/dimensions",
 		api.authMiddleware.Require(datasetEditionVersionReadPermission, paginator.Paginate(api.getDimensions)),
@@ -297,7 +304,7 @@ func (api *DatasetAPI) enablePrivateDatasetEndpoints(paginator *pagination.Pagin
 
 	api.get(
 		"/datasets/{dataset_id}/editions/{edition}/versions/{version}/dimensions/{dimension}/options",
-		api.authMiddleware.Require(datasetEditionVersionReadPermission, paginator.Paginate(api.getDimensionOptions)),
+		api.authMiddleware.Require(datasetEditionVersionReadPermission, paginator.PaginateWithCursor(api.getDimensionOptions)),
 	)
 
 	api.get(
@@ -325,6 +332,56 @@ func (api *DatasetAPI) enablePrivateDatasetEndpoints(paginator *pagination.Pagin
 		api.authMiddleware.Require(datasetDeletePermission, api.deleteDataset),
 	)
 
+	api.post(
+		"/datasets/{dataset_id}/restore",
+		api.authMiddleware.Require(datasetDeletePermission, api.restoreDataset),
+	)
+
+	api.get(
+		"/datasets/{dataset_id}/revisions",
+		api.authMiddleware.Require(datasetReadPermission, paginator.Paginate(api.getDatasetRevisions)),
+	)
+
+	api.get(
+		"/datasets/{dataset_id}/revisions/{revision}",
+		api.authMiddleware.Require(datasetReadPermission, api.getDatasetRevision),
+	)
+
+	api.get(
+		"/audit-events",
+		api.authMiddleware.Require(auditEventsReadPermission, paginator.Paginate(api.getAuditEvents)),
+	)
+
+	api.get(
+		"/audit-events/verify",
+		api.authMiddleware.Require(auditEventsReadPermission, api.verifyAuditChain),
+	)
+
+	api.get(
+		"/state-machine",
+		api.authMiddleware.Require(datasetEditionVersionReadPermission, contextAndErrors(api.getStateMachine)),
+	)
+
+	api.get(
+		"/state-machine/{dataset_type}",
+		api.authMiddleware.Require(datasetEditionVersionReadPermission, contextAndErrors(api.getStateMachineWorkflow)),
+	)
+
+	api.get(
+		"/scheduled-publishes",
+		api.authMiddleware.Require(datasetEditionVersionReadPermission, paginator.Paginate(api.getScheduledPublishes)),
+	)
+
+	api.get(
+		"/datasets/{dataset_id}/editions/{edition}/versions/{version}/allowed-transitions",
+		api.authMiddleware.RequireWithAttributes(datasetEditionVersionReadPermission, contextAndErrors(api.getAllowedTransitions), api.getPermissionAttributesFromRequest),
+	)
+
+	api.get(
+		"/datasets/{dataset_id}/editions/{edition}/versions/{version}/history",
+		api.authMiddleware.RequireWithAttributes(datasetEditionVersionReadPermission, contextAndErrors(api.getVersionStateHistory), api.getPermissionAttributesFromRequest),
+	)
+
 	api.put(
 		"/datasets/{dataset_id}/editions/{edition}/versions/{version}",
 		api.authMiddleware.Require(datasetEditionVersionUpdatePermission, api.isVersionPublished(updateVersionAction, api.putVersion)),
@@ -340,6 +397,16 @@ func (api *DatasetAPI) enablePrivateDatasetEndpoints(paginator *pagination.Pagin
 		api.authMiddleware.Require(datasetEditionVersionUpdatePermission, api.putState),
 	)
 
+	api.post(
+		"/datasets/{dataset_id}/editions/{edition}/versions/{version}/state:preflight",
+		api.authMiddleware.Require(datasetEditionVersionUpdatePermission, contextAndErrors(api.preflightVersionState)),
+	)
+
+	api.post(
+		"/collections/{collection_id}/publish",
+		api.authMiddleware.Require(datasetEditionVersionUpdatePermission, contextAndErrors(api.publishCollection)),
+	)
+
 	api.post(
 		"/datasets/{dataset_id}/editions/{edition}/versions",
 		api.authMiddleware.Require(datasetEditionVersionCreatePermission, contextAndErrors(api.addDatasetVersionCondensed)),
@@ -354,6 +421,16 @@ func (api *DatasetAPI) enablePrivateDatasetEndpoints(paginator *pagination.Pagin
 		"/datasets/{dataset_id}/editions/{edition}/versions/{version}",
 		api.authMiddleware.Require(datasetEditionVersionDeletePermission, api.deleteVersion),
 	)
+
+	api.post(
+		"/datasets/{dataset_id}/editions/{edition}/restore",
+		api.authMiddleware.Require(datasetEditionVersionDeletePermission, api.restoreEdition),
+	)
+
+	api.post(
+		"/datasets/{dataset_id}/editions/{edition}/versions/{version}/restore",
+		api.authMiddleware.Require(datasetEditionVersionDeletePermission, api.restoreVersion),
+	)
 }
 
 // enablePrivateInstancesEndpoints register the instance endpoints with the appropriate authentication and authorisation
@@ -361,7 +438,7 @@ func (api *DatasetAPI) enablePrivateDatasetEndpoints(paginator *pagination.Pagin
 func (api *DatasetAPI) enablePrivateInstancesEndpoints(instanceAPI *instance.Store, paginator *pagination.Paginator) {
 	api.get(
 		"/instances",
-		api.authMiddleware.Require(datasetInstanceReadPermission, paginator.Paginate(instanceAPI.GetList)),
+		api.authMiddleware.Require(datasetInstanceReadPermission, paginator.PaginateWithCursor(instanceAPI.GetList)),
 	)
 
 	api.post(
@@ -0,0 +1,100 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"time"
+
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	"github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// getAuditEvents returns the audit events that match the query parameters, most recent first
+func (api *DatasetAPI) getAuditEvents(w http.ResponseWriter, r *http.Request, limit, offset int) (interface{}, int, error) {
+	ctx := r.Context()
+	logData := log.Data{"query": r.URL.RawQuery}
+
+	filter, err := parseAuditEventFilter(r.URL.Query())
+	if err != nil {
+		log.Error(ctx, "getAuditEvents endpoint: invalid query parameter", err, logData)
+		handleDatasetAPIErr(ctx, errs.ErrInvalidQueryParameter, w, logData)
+		return nil, 0, err
+	}
+
+	events, totalCount, err := api.dataStore.Backend.GetAuditEvents(ctx, filter, offset, limit)
+	if err != nil {
+		log.Error(ctx, "getAuditEvents endpoint: failed to get audit events", err, logData)
+		handleDatasetAPIErr(ctx, err, w, logData)
+		return nil, 0, err
+	}
+
+	return events, totalCount, nil
+}
+
+// verifyAuditChain walks the chain of audit events and reports the first event whose link to the previous one is broken.
+// A broken chain is reported in the response body rather than as an error status.
+func (api *DatasetAPI) verifyAuditChain(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logData := log.Data{}
+
+	verification, err := api.auditService.VerifyAuditChain(ctx)
+	if err != nil {
+		log.Error(ctx, "verifyAuditChain endpoint: failed to verify the audit chain", err, logData)
+		handleDatasetAPIErr(ctx, err, w, logData)
+		return
+	}
+
+	logData["verification"] = verification
+	if !verification.Valid {
+		log.Warn(ctx, "verifyAuditChain endpoint: the audit chain is broken", logData)
+	}
+
+	b, err := json.Marshal(verification)
+	if err != nil {
+		log.Error(ctx, "verifyAuditChain endpoint: failed to marshal the verification into bytes", err, logData)
+		handleDatasetAPIErr(ctx, err, w, logData)
+		return
+	}
+
+	setJSONContentType(w)
+	if _, err = w.Write(b); err != nil {
+		log.Error(ctx, "verifyAuditChain endpoint: error writing bytes to response", err, logData)
+		handleDatasetAPIErr(ctx, err, w, logData)
+		return
+	}
+	log.Info(ctx, "verifyAuditChain endpoint: request successful", logData)
+}
+
+// parseAuditEventFilter returns the filter described by the resource, dataset_id, action, requested_by, from and to
+// query parameters. The from and to times are RFC3339 timestamps.
+func parseAuditEventFilter(query url.Values) (*models.AuditEventFilter, error) {
+	filter := &models.AuditEventFilter{
+		Resource:    query.Get("resource"),
+		DatasetID:   query.Get("dataset_id"),
+		Action:      models.Action(query.Get("action")),
+		RequestedBy: query.Get("requested_by"),
+	}
+
+	if filter.Action != "" && !filter.Action.IsValid() {
+		return nil, errs.ErrInvalidQueryParameter
+	}
+
+	for param, value := range map[string]**time.Time{"from": &filter.From, "to": &filter.To} {
+		if !query.Has(param) {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, query.Get(param))
+		if err != nil {
+			return nil, err
+		}
+		*value = &t
+	}
+
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return nil, errs.ErrInvalidQueryParameter
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,159 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	authMock "github.com/ONSdigital/dp-authorisation/v2/authorisation/mock"
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	applicationMocks "github.com/ONSdigital/dp-dataset-api/application/mock"
+	cloudflareMocks "github.com/ONSdigital/dp-dataset-api/cloudflare/mocks"
+	"github.com/ONSdigital/dp-dataset-api/mocks"
+	"github.com/ONSdigital/dp-dataset-api/models"
+	storetest "github.com/ONSdigital/dp-dataset-api/store/datastoretest"
+	permissionsAPISDK "github.com/ONSdigital/dp-permissions-api/sdk"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGetAuditEvents(t *testing.T) {
+	t.Parallel()
+
+	authorisationMock := &authMock.MiddlewareMock{
+		RequireFunc: func(permission string, handlerFunc http.HandlerFunc) http.HandlerFunc {
+			return handlerFunc
+		},
+		ParseFunc: func(token string) (*permissionsAPISDK.EntityData, error) {
+			return testEntityData, nil
+		},
+	}
+
+	newStore := func(err error) *storetest.StorerMock {
+		return &storetest.StorerMock{
+			GetAuditEventsFunc: func(context.Context, *models.AuditEventFilter, int, int) ([]*models.AuditEvent, int, error) {
+				if err != nil {
+					return nil, 0, err
+				}
+				return []*models.AuditEvent{
+					{Action: models.ActionUpdate, Resource: "/datasets/123", RequestedBy: models.RequestedBy{ID: "user-1"}},
+				}, 4, nil
+			},
+		}
+	}
+
+	Convey("A successful request to get audit events returns 200 OK with the events matching the query parameters", t, func() {
+		r := createRequestWithAuth("GET", "http://localhost:22000/audit-events?dataset_id=123&action=UPDATE&requested_by=user-1"+
+			"&resource=/datasets/123/editions&from=2025-01-01T00:00:00Z&to=2025-02-01T00:00:00Z&offset=2&limit=1", nil)
+		w := httptest.NewRecorder()
+
+		mockedDataStore := newStore(nil)
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api.Router.ServeHTTP(w, r)
+
+		So(w.Code, ShouldEqual, http.StatusOK)
+		permissions := []string{}
+		for _, call := range authorisationMock.RequireCalls() {
+			permissions = append(permissions, call.Permission)
+		}
+		So(permissions, ShouldContain, auditEventsReadPermission)
+		So(mockedDataStore.GetAuditEventsCalls(), ShouldHaveLength, 1)
+
+		call := mockedDataStore.GetAuditEventsCalls()[0]
+		from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+		So(call.Filter, ShouldResemble, &models.AuditEventFilter{
+			Resource: "/datasets/123/editions", DatasetID: "123", Action: models.ActionUpdate, RequestedBy: "user-1", From: &from, To: &to,
+		})
+		So(call.Offset, ShouldEqual, 2)
+		So(call.Limit, ShouldEqual, 1)
+
+		var page struct {
+			Items      []*models.AuditEvent `json:"items"`
+			TotalCount int                  `json:"total_count"`
+		}
+		So(json.Unmarshal(w.Body.Bytes(), &page), ShouldBeNil)
+		So(page.TotalCount, ShouldEqual, 4)
+		So(page.Items, ShouldHaveLength, 1)
+		So(page.Items[0].Resource, ShouldEqual, "/datasets/123")
+	})
+
+	Convey("Invalid query parameters return status bad request", t, func() {
+		for _, query := range []string{"action=PUBLISH", "from=yesterday", "from=2025-02-01T00:00:00Z&to=2025-01-01T00:00:00Z"} {
+			r := createRequestWithAuth("GET", "http://localhost:22000/audit-events?"+query, nil)
+			w := httptest.NewRecorder()
+
+			mockedDataStore := newStore(nil)
+			api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+			api.Router.ServeHTTP(w, r)
+
+			So(w.Code, ShouldEqual, http.StatusBadRequest)
+			So(w.Body.String(), ShouldContainSubstring, errs.ErrInvalidQueryParameter.Error())
+			So(mockedDataStore.GetAuditEventsCalls(), ShouldHaveLength, 0)
+		}
+	})
+
+	Convey("When the audit events cannot be read, return status internal server error", t, func() {
+		r := createRequestWithAuth("GET", "http://localhost:22000/audit-events", nil)
+		w := httptest.NewRecorder()
+
+		api := GetAPIWithCMDMocks(newStore(errors.New("mongo error")), &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api.Router.ServeHTTP(w, r)
+
+		So(w.Code, ShouldEqual, http.StatusInternalServerError)
+	})
+}
+
+func TestVerifyAuditChain(t *testing.T) {
+	t.Parallel()
+
+	authorisationMock := &authMock.MiddlewareMock{
+		RequireFunc: func(permission string, handlerFunc http.HandlerFunc) http.HandlerFunc {
+			return handlerFunc
+		},
+	}
+
+	Convey("A request to verify the audit chain returns 200 OK with the first broken link of the chain", t, func() {
+		r := createRequestWithAuth("GET", "http://localhost:22000/audit-events/verify", nil)
+		w := httptest.NewRecorder()
+
+		auditServiceMock := &applicationMocks.AuditServiceMock{
+			VerifyAuditChainFunc: func(context.Context) (*models.AuditChainVerification, error) {
+				return &models.AuditChainVerification{
+					VerifiedEvents:  3,
+					FirstBrokenLink: &models.AuditChainLink{Sequence: 4, Reason: "hash does not match the content of the event"},
+				}, nil
+			},
+		}
+		api := GetAPIWithCMDMocks(&storetest.StorerMock{}, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, auditServiceMock)
+		api.Router.ServeHTTP(w, r)
+
+		So(w.Code, ShouldEqual, http.StatusOK)
+		So(auditServiceMock.VerifyAuditChainCalls(), ShouldHaveLength, 1)
+
+		var verification models.AuditChainVerification
+		So(json.Unmarshal(w.Body.Bytes(), &verification), ShouldBeNil)
+		So(verification.Valid, ShouldBeFalse)
+		So(verification.VerifiedEvents, ShouldEqual, 3)
+		So(verification.FirstBrokenLink.Sequence, ShouldEqual, 4)
+	})
+
+	Convey("When the audit chain cannot be verified, a request returns status internal server error", t, func() {
+		r := createRequestWithAuth("GET", "http://localhost:22000/audit-events/verify", nil)
+		w := httptest.NewRecorder()
+
+		auditServiceMock := &applicationMocks.AuditServiceMock{
+			VerifyAuditChainFunc: func(context.Context) (*models.AuditChainVerification, error) {
+				return nil, errors.New("store error")
+			},
+		}
+		api := GetAPIWithCMDMocks(&storetest.StorerMock{}, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, auditServiceMock)
+		api.Router.ServeHTTP(w, r)
+
+		So(w.Code, ShouldEqual, http.StatusInternalServerError)
+		So(w.Body.String(), ShouldContainSubstring, errs.ErrInternalServer.Error())
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/ONSdigital/dp-dataset-api/models"
 	"github.com/ONSdigital/dp-net/v2/request"
 	dprequest "github.com/ONSdigital/dp-net/v3/request"
 	permissionsAPISDK "github.com/ONSdigital/dp-permissions-api/sdk"
@@ -29,6 +30,16 @@ func (api *DatasetAPI) getAuthEntityData(r *http.Request) (*AuthEntityData, erro
 	return CreateAuthEntityData(entityData, false), nil
 }
 
+// getRequestedBy returns the user or service that made the request, as recorded in audit events
+func (api *DatasetAPI) getRequestedBy(r *http.Request) (models.RequestedBy, error) {
+	authEntityData, err := api.getAuthEntityData(r)
+	if err != nil {
+		return models.RequestedBy{}, err
+	}
+	// ID and Email are the same as auth middleware can only provide userID
+	return models.RequestedBy{ID: authEntityData.EntityData.UserID, Email: authEntityData.EntityData.UserID}, nil
+}
+
 // getAccessTokenFromRequest extracts the access token from the Authorization header of the request
 func getAccessTokenFromRequest(r *http.Request) string {
 	return strings.TrimPrefix(r.Header.Get(dprequest.AuthHeaderKey), dprequest.BearerPrefix)
@@ -0,0 +1,200 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	"github.com/ONSdigital/dp-dataset-api/application"
+	"github.com/ONSdigital/dp-dataset-api/models"
+	dphttp "github.com/ONSdigital/dp-net/v3/http"
+	"github.com/ONSdigital/log.go/v2/log"
+	"github.com/gorilla/mux"
+)
+
+// collectionPublishStates are the states of the datasets and versions of a collection that are published with it
+var collectionPublishStates = []string{models.AssociatedState, models.ApprovedState}
+
+// publishCollection publishes every dataset and version of a collection as one unit, through the state machine. The
+// versions are all checked first, as they are by preflightVersionState, and the datasets are validated, and nothing is
+// published if any of them fails a check. Otherwise they are published within a single transaction, so that either all
+// of them are published or none are, and the publication of each version is then completed as it is by putState. The
+// outcome of publishing each dataset and version is returned.
+func (api *DatasetAPI) publishCollection(w http.ResponseWriter, r *http.Request) (*models.SuccessResponse, *models.ErrorResponse) {
+	defer dphttp.DrainBody(r)
+
+	ctx := r.Context()
+	collectionID := mux.Vars(r)["collection_id"]
+	logData := log.Data{"collection_id": collectionID}
+
+	authEntityData, err := api.getAuthEntityData(r)
+	if err != nil {
+		log.Error(ctx, "publishCollection endpoint: failed to get auth entity data from request", err, logData)
+		return nil, models.NewErrorResponse(http.StatusInternalServerError, nil, models.NewError(err, models.InternalError, models.InternalErrorDescription))
+	}
+	// ID and Email are the same as auth middleware can only provide userID
+	requestedBy := models.RequestedBy{ID: authEntityData.EntityData.UserID, Email: authEntityData.EntityData.UserID}
+	accessToken := fetchAccessTokenFromHeader(r)
+
+	versions, err := api.dataStore.Backend.GetVersionsByCollectionID(ctx, collectionID, collectionPublishStates)
+	if err != nil && !errors.Is(err, errs.ErrVersionsNotFound) {
+		log.Error(ctx, "publishCollection endpoint: failed to get the versions of the collection", err, logData)
+		return nil, models.NewErrorResponse(getVersionAPIErrStatusCode(err), nil, models.NewError(err, err.Error(), "internal error"))
+	}
+
+	datasets, err := api.dataStore.Backend.GetDatasetsByCollectionID(ctx, collectionID, collectionPublishStates)
+	if err != nil {
+		log.Error(ctx, "publishCollection endpoint: failed to get the datasets of the collection", err, logData)
+		return nil, models.NewErrorResponse(http.StatusInternalServerError, nil, models.NewError(err, models.InternalError, models.InternalErrorDescription))
+	}
+
+	if len(versions) == 0 && len(datasets) == 0 {
+		log.Info(ctx, "publishCollection endpoint: the collection has no datasets or versions to publish", logData)
+		return nil, models.NewErrorResponse(http.StatusNotFound, nil, models.NewError(errs.ErrCollectionNotFound, errs.ErrCollectionNotFound.Error(), "collection not found"))
+	}
+
+	// the items of the versions are followed by the items of the datasets, which are published after the versions
+	items := make([]models.CollectionPublishItem, len(versions)+len(datasets))
+	amendments := make([]*application.VersionAmendment, len(versions))
+	publications := make([]*application.DatasetPublication, len(datasets))
+	valid := true
+	for i, version := range versions {
+		items[i] = models.CollectionPublishItem{
+			Resource: models.CollectionItemVersion,
+			Edition:  version.Edition,
+			Version:  version.Version,
+			State:    version.State,
+			Outcome:  models.CollectionItemNotPublished,
+		}
+		if version.Links != nil && version.Links.Dataset != nil {
+			items[i].DatasetID = version.Links.Dataset.ID
+		}
+
+		amendments[i] = &application.VersionAmendment{
+			Vars: map[string]string{
+				"dataset_id":   items[i].DatasetID,
+				"edition":      version.Edition,
+				"version":      strconv.Itoa(version.Version),
+				stateChangedBy: requestedBy.ID,
+			},
+			Version: &models.Version{
+				ID:    version.ID,
+				State: models.PublishedState,
+				Type:  version.Type,
+			},
+		}
+
+		items[i].Checks = api.preflightCollectionVersion(ctx, version, amendments[i], accessToken, logData)
+		for _, check := range items[i].Checks {
+			if !check.Passed {
+				items[i].Outcome = models.CollectionItemInvalid
+				valid = false
+			}
+		}
+	}
+
+	for i, dataset := range datasets {
+		item := &items[len(versions)+i]
+		*item = models.CollectionPublishItem{
+			Resource:  models.CollectionItemDataset,
+			DatasetID: dataset.ID,
+			State:     dataset.Next.State,
+			Outcome:   models.CollectionItemNotPublished,
+			Checks:    []models.PreflightCheck{models.NewPreflightCheck("dataset", models.ValidateDataset(dataset.Next))},
+		}
+		if !item.Checks[0].Passed {
+			item.Outcome = models.CollectionItemInvalid
+			valid = false
+		}
+
+		publications[i] = &application.DatasetPublication{ID: dataset.ID}
+	}
+
+	if !valid {
+		log.Info(ctx, "publishCollection endpoint: items of the collection failed the checks, nothing was published", logData)
+		return collectionPublishResponse(ctx, collectionID, items, http.StatusConflict, logData)
+	}
+
+	if err = api.smDatasetAPI.AmendVersions(ctx, amendments, publications); err != nil {
+		log.Error(ctx, "publishCollection endpoint: failed to publish the items of the collection, nothing was published", err, logData)
+		for i, amendment := range amendments {
+			if amendment.Err != nil {
+				items[i].Outcome = models.CollectionItemFailed
+				items[i].Error = amendment.Err.Error()
+			}
+		}
+		for i, publication := range publications {
+			if publication.Err != nil {
+				items[len(versions)+i].Outcome = models.CollectionItemFailed
+				items[len(versions)+i].Error = publication.Err.Error()
+			}
+		}
+		return collectionPublishResponse(ctx, collectionID, items, getVersionAPIErrStatusCode(err), logData)
+	}
+
+	for i, amendment := range amendments {
+		items[i].Outcome = models.CollectionItemPublished
+		items[i].State = amendment.AmendedVersion.State
+
+		vars := amendment.Vars
+		endpoint := "/datasets/" + vars["dataset_id"] + "/editions/" + vars["edition"] + "/versions/" + vars["version"] + "/state"
+		itemLogData := log.Data{"collection_id": collectionID, "dataset_id": vars["dataset_id"], "edition": vars["edition"], "version": vars["version"]}
+		if err := api.completeVersionStateChange(ctx, endpoint, vars["dataset_id"], vars["edition"], vars["version"], amendment.CurrentVersion, amendment.AmendedVersion,
+			requestedBy, authEntityData.IsServiceAuth, accessToken, itemLogData); err != nil {
+			items[i].Error = err.Error()
+		}
+	}
+
+	for i, publication := range publications {
+		item := &items[len(versions)+i]
+		item.Outcome = models.CollectionItemPublished
+		item.State = publication.PublishedDataset.State
+
+		if err := api.auditService.RecordDatasetAuditEvent(ctx, requestedBy, models.ActionUpdate, "/datasets/"+publication.ID, datasets[i].Next, publication.PublishedDataset); err != nil {
+			log.Error(ctx, "publishCollection endpoint: failed to record dataset audit event", err, log.Data{"collection_id": collectionID, "dataset_id": publication.ID})
+			item.Error = err.Error()
+		}
+	}
+
+	log.Info(ctx, "publishCollection endpoint: request successful", logData)
+	return collectionPublishResponse(ctx, collectionID, items, http.StatusOK, logData)
+}
+
+// preflightCollectionVersion checks whether a version of a collection will publish cleanly with the provided amendment.
+// Static versions are checked as they are by preflightVersionState, and other versions by the state machine alone.
+func (api *DatasetAPI) preflightCollectionVersion(ctx context.Context, version *models.Version, amendment *application.VersionAmendment, accessToken string, logData log.Data) []models.PreflightCheck {
+	datasetID := amendment.Vars["dataset_id"]
+	if datasetID == "" {
+		return []models.PreflightCheck{models.NewPreflightCheck("version", errors.New("missing dataset link"))}
+	}
+
+	_, _, checks, err := api.smDatasetAPI.PreflightVersion(ctx, amendment.Vars, amendment.Version)
+	if err != nil {
+		return []models.PreflightCheck{models.NewPreflightCheck("version", err)}
+	}
+
+	if version.Type != models.Static.String() {
+		return checks
+	}
+
+	dataset, err := api.dataStore.Backend.GetDataset(ctx, datasetID)
+	if err != nil {
+		return append(checks, models.NewPreflightCheck("dataset", err))
+	}
+
+	return append(checks, api.preflightStaticVersion(ctx, dataset.Next, version, datasetID, version.Edition, version.Version, accessToken, logData)...)
+}
+
+// collectionPublishResponse returns the outcome of publishing the items of a collection with the provided status
+func collectionPublishResponse(ctx context.Context, collectionID string, items []models.CollectionPublishItem, status int, logData log.Data) (*models.SuccessResponse, *models.ErrorResponse) {
+	b, err := json.Marshal(models.NewCollectionPublish(collectionID, items))
+	if err != nil {
+		log.Error(ctx, "publishCollection endpoint: failed to marshal the outcome of publishing the collection into bytes", err, logData)
+		return nil, models.NewErrorResponse(http.StatusInternalServerError, nil, models.NewError(err, models.JSONMarshalError, models.ErrorMarshalFailedDescription))
+	}
+
+	return models.NewSuccessResponse(b, status, nil), nil
+}
@@ -0,0 +1,348 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	authMock "github.com/ONSdigital/dp-authorisation/v2/authorisation/mock"
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	"github.com/ONSdigital/dp-dataset-api/application"
+	applicationMocks "github.com/ONSdigital/dp-dataset-api/application/mock"
+	cloudflareMocks "github.com/ONSdigital/dp-dataset-api/cloudflare/mocks"
+	"github.com/ONSdigital/dp-dataset-api/mocks"
+	"github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/dp-dataset-api/store"
+	storetest "github.com/ONSdigital/dp-dataset-api/store/datastoretest"
+	"github.com/ONSdigital/dp-files-api/files"
+	filesAPISDK "github.com/ONSdigital/dp-files-api/sdk"
+	filesAPISDKMocks "github.com/ONSdigital/dp-files-api/sdk/mocks"
+	permissionsAPISDK "github.com/ONSdigital/dp-permissions-api/sdk"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestPublishCollection(t *testing.T) {
+	t.Parallel()
+
+	approvedVersion := func(version int) *models.Version {
+		versionPath := "http://localhost:22000/datasets/cpih01/editions/2025/versions/" + strconv.Itoa(version)
+		return &models.Version{
+			ID:           "version-" + strconv.Itoa(version),
+			CollectionID: "collection-1",
+			Edition:      "2025",
+			EditionTitle: "2025",
+			ReleaseDate:  "2025-01-15",
+			State:        models.ApprovedState,
+			Type:         models.Static.String(),
+			Version:      version,
+			Distributions: &[]models.Distribution{
+				{Title: "Full dataset", Format: models.DistributionFormatCSV, DownloadURL: "/cpih01/2025/" + strconv.Itoa(version) + "/cpih01.csv"},
+			},
+			Links: &models.VersionLinks{
+				Dataset: &models.LinkObject{ID: "cpih01", HRef: "http://localhost:22000/datasets/cpih01"},
+				Edition: &models.LinkObject{ID: "2025", HRef: "http://localhost:22000/datasets/cpih01/editions/2025"},
+				Version: &models.LinkObject{ID: strconv.Itoa(version), HRef: versionPath},
+				Self:    &models.LinkObject{HRef: versionPath},
+			},
+		}
+	}
+
+	staticDataset := func() *models.Dataset {
+		return &models.Dataset{
+			ID:          "cpih01",
+			Title:       "Consumer Prices Index including owner occupiers' housing costs",
+			Description: "Measures of inflation",
+			NextRelease: "2025-02-15",
+			License:     "Open Government Licence v3.0",
+			Keywords:    []string{"inflation"},
+			Contacts:    []models.ContactDetails{{Email: "cpih@ons.gov.uk"}},
+			Topics:      []string{"economy"},
+			Type:        models.Static.String(),
+			State:       models.AssociatedState,
+			Links:       &models.DatasetLinks{Self: &models.LinkObject{HRef: "http://localhost:22000/datasets/cpih01"}},
+		}
+	}
+
+	newStore := func() *storetest.StorerMock {
+		return &storetest.StorerMock{
+			RunTransactionFunc: runTransaction,
+			GetVersionsByCollectionIDFunc: func(context.Context, string, []string) ([]*models.Version, error) {
+				return []*models.Version{approvedVersion(1), approvedVersion(2)}, nil
+			},
+			GetDatasetsByCollectionIDFunc: func(context.Context, string, []string) ([]*models.DatasetUpdate, error) {
+				dataset := staticDataset()
+				dataset.ID = "mid-year-pop"
+				dataset.CollectionID = "collection-1"
+				return []*models.DatasetUpdate{{ID: dataset.ID, Next: dataset}}, nil
+			},
+			GetDatasetFunc: func(_ context.Context, id string) (*models.DatasetUpdate, error) {
+				dataset := staticDataset()
+				dataset.ID = id
+				return &models.DatasetUpdate{ID: id, Next: dataset}, nil
+			},
+			CheckEditionExistsStaticFunc: func(context.Context, string, string, string) error {
+				return nil
+			},
+			GetVersionStaticFunc: func(_ context.Context, _, _ string, version int, _ string) (*models.Version, error) {
+				return approvedVersion(version), nil
+			},
+			AcquireVersionsLockFunc: func(context.Context, string) (string, error) {
+				return testLockID, nil
+			},
+			UnlockVersionsFunc: func(context.Context, string) {},
+			UpdateVersionStaticFunc: func(context.Context, *models.Version, *models.Version, string) (string, error) {
+				return "", nil
+			},
+			GetDatasetTypeFunc: func(context.Context, string, bool) (string, error) {
+				return models.Static.String(), nil
+			},
+			UpsertVersionStaticFunc: func(context.Context, *models.Version) error {
+				return nil
+			},
+			UpsertDatasetFunc: func(context.Context, string, *models.DatasetUpdate) error {
+				return nil
+			},
+		}
+	}
+
+	newFilesAPIClient := func(state string) *filesAPISDKMocks.ClienterMock {
+		return &filesAPISDKMocks.ClienterMock{
+			GetFileFunc: func(_ context.Context, path string, _ filesAPISDK.Headers) (*files.StoredRegisteredMetaData, error) {
+				if strings.Contains(path, "/2/") {
+					return &files.StoredRegisteredMetaData{IsPublishable: true, State: state}, nil
+				}
+				return &files.StoredRegisteredMetaData{IsPublishable: true, State: filesAPIStateUploaded}, nil
+			},
+			MarkFilePublishedFunc: func(context.Context, string, filesAPISDK.Headers) error {
+				return nil
+			},
+		}
+	}
+
+	newAuditService := func() *applicationMocks.AuditServiceMock {
+		return &applicationMocks.AuditServiceMock{
+			RecordVersionAuditEventFunc: func(context.Context, models.RequestedBy, models.Action, string, *models.Version, *models.Version) error {
+				return nil
+			},
+			RecordDatasetAuditEventFunc: func(context.Context, models.RequestedBy, models.Action, string, *models.Dataset, *models.Dataset) error {
+				return nil
+			},
+		}
+	}
+
+	newAPI := func(mockedDataStore *storetest.StorerMock, filesAPIClient *filesAPISDKMocks.ClienterMock, auditServiceMock *applicationMocks.AuditServiceMock) *DatasetAPI {
+		searchContentUpdatedMock := &mocks.OutboxMock{
+			WriteFunc: func(context.Context, []byte) error {
+				return nil
+			},
+		}
+		cloudflareMock := &cloudflareMocks.ClienterMock{
+			PurgeByPrefixesFunc: func(context.Context, []string) error {
+				return nil
+			},
+		}
+		authorisationMock := &authMock.MiddlewareMock{
+			RequireFunc: func(permission string, handlerFunc http.HandlerFunc) http.HandlerFunc {
+				return handlerFunc
+			},
+			ParseFunc: func(token string) (*permissionsAPISDK.EntityData, error) {
+				return testEntityData, nil
+			},
+		}
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, searchContentUpdatedMock, cloudflareMock, auditServiceMock)
+		transitions, err := application.LoadWorkflows("")
+		So(err, ShouldBeNil)
+		api.smDatasetAPI.StateMachine = application.NewStateMachine(testContext, nil, transitions, store.DataStore{Backend: mockedDataStore})
+		api.filesAPIClient = filesAPIClient
+		return api
+	}
+
+	publish := func(api *DatasetAPI) (*httptest.ResponseRecorder, *models.CollectionPublish) {
+		r := createRequestWithAuth("POST", "http://localhost:22000/collections/collection-1/publish", http.NoBody)
+		w := httptest.NewRecorder()
+		api.Router.ServeHTTP(w, r)
+
+		var result models.CollectionPublish
+		if w.Code != http.StatusNotFound {
+			So(json.Unmarshal(w.Body.Bytes(), &result), ShouldBeNil)
+		}
+		return w, &result
+	}
+
+	outcomes := func(result *models.CollectionPublish) []string {
+		var outcomes []string
+		for _, item := range result.Items {
+			outcomes = append(outcomes, item.Outcome)
+		}
+		return outcomes
+	}
+
+	Convey("Given a collection whose datasets and versions are ready to publish", t, func() {
+		mockedDataStore := newStore()
+		filesAPIClient := newFilesAPIClient(filesAPIStateUploaded)
+		auditServiceMock := newAuditService()
+		api := newAPI(mockedDataStore, filesAPIClient, auditServiceMock)
+
+		Convey("When the collection is published", func() {
+			w, result := publish(api)
+
+			Convey("Then every dataset and version is published and its publication completed", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(result.CollectionID, ShouldEqual, "collection-1")
+				So(result.Published, ShouldBeTrue)
+				So(outcomes(result), ShouldResemble, []string{models.CollectionItemPublished, models.CollectionItemPublished, models.CollectionItemPublished})
+				So(result.Items[0].Resource, ShouldEqual, models.CollectionItemVersion)
+				So(result.Items[0].DatasetID, ShouldEqual, "cpih01")
+				So(result.Items[1].Version, ShouldEqual, 2)
+				So(result.Items[1].State, ShouldEqual, models.PublishedState)
+				So(result.Items[2].Resource, ShouldEqual, models.CollectionItemDataset)
+				So(result.Items[2].DatasetID, ShouldEqual, "mid-year-pop")
+				So(result.Items[2].State, ShouldEqual, models.PublishedState)
+
+				So(mockedDataStore.GetVersionsByCollectionIDCalls()[0].States, ShouldResemble, []string{models.AssociatedState, models.ApprovedState})
+				So(mockedDataStore.GetDatasetsByCollectionIDCalls()[0].States, ShouldResemble, []string{models.AssociatedState, models.ApprovedState})
+				So(mockedDataStore.AcquireVersionsLockCalls(), ShouldHaveLength, 2)
+				So(mockedDataStore.UnlockVersionsCalls(), ShouldHaveLength, 2)
+				So(mockedDataStore.UpdateVersionStaticCalls(), ShouldHaveLength, 2)
+				So(filesAPIClient.MarkFilePublishedCalls(), ShouldHaveLength, 2)
+				So(auditServiceMock.RecordVersionAuditEventCalls(), ShouldHaveLength, 2)
+				So(auditServiceMock.RecordVersionAuditEventCalls()[1].Resource, ShouldEqual, "/datasets/cpih01/editions/2025/versions/2/state")
+
+				upserts := mockedDataStore.UpsertDatasetCalls()
+				So(upserts[len(upserts)-1].ID, ShouldEqual, "mid-year-pop")
+				So(upserts[len(upserts)-1].DatasetDoc.Current.State, ShouldEqual, models.PublishedState)
+				So(auditServiceMock.RecordDatasetAuditEventCalls(), ShouldHaveLength, 1)
+				So(auditServiceMock.RecordDatasetAuditEventCalls()[0].Resource, ShouldEqual, "/datasets/mid-year-pop")
+			})
+
+			Convey("And the change of state is recorded on behalf of the requester", func() {
+				history := mockedDataStore.UpdateVersionStaticCalls()[0].VersionUpdate.StateHistory
+				So(history, ShouldHaveLength, 1)
+				So(history[0].To, ShouldEqual, models.PublishedState)
+				So(history[0].ChangedBy, ShouldEqual, testEntityData.UserID)
+			})
+		})
+	})
+
+	Convey("Given a collection with a version that is not ready to publish", t, func() {
+		mockedDataStore := newStore()
+		filesAPIClient := newFilesAPIClient("CREATED")
+		api := newAPI(mockedDataStore, filesAPIClient, newAuditService())
+
+		Convey("When the collection is published", func() {
+			w, result := publish(api)
+
+			Convey("Then a conflict is returned with the failing checks, and nothing is published", func() {
+				So(w.Code, ShouldEqual, http.StatusConflict)
+				So(result.Published, ShouldBeFalse)
+				So(outcomes(result), ShouldResemble, []string{models.CollectionItemNotPublished, models.CollectionItemInvalid, models.CollectionItemNotPublished})
+				So(result.Items[1].Checks, ShouldContain, models.PreflightCheck{Name: "file:/cpih01/2025/2/cpih01.csv", Reason: "file state is CREATED, not UPLOADED"})
+
+				So(mockedDataStore.AcquireVersionsLockCalls(), ShouldBeEmpty)
+				So(mockedDataStore.UpdateVersionStaticCalls(), ShouldBeEmpty)
+				So(filesAPIClient.MarkFilePublishedCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("Given a collection with a version that fails to publish", t, func() {
+		mockedDataStore := newStore()
+		mockedDataStore.UpdateVersionStaticFunc = func(_ context.Context, currentVersion, _ *models.Version, _ string) (string, error) {
+			if currentVersion.Version == 2 {
+				return "", errs.ErrInternalServer
+			}
+			return "", nil
+		}
+		filesAPIClient := newFilesAPIClient(filesAPIStateUploaded)
+		auditServiceMock := newAuditService()
+		api := newAPI(mockedDataStore, filesAPIClient, auditServiceMock)
+
+		Convey("When the collection is published", func() {
+			w, result := publish(api)
+
+			Convey("Then the failure is reported for the version, and the publication of none of the versions is completed", func() {
+				So(w.Code, ShouldEqual, http.StatusInternalServerError)
+				So(result.Published, ShouldBeFalse)
+				So(outcomes(result), ShouldResemble, []string{models.CollectionItemNotPublished, models.CollectionItemFailed, models.CollectionItemNotPublished})
+				So(result.Items[1].Error, ShouldEqual, errs.ErrInternalServer.Error())
+
+				So(mockedDataStore.UnlockVersionsCalls(), ShouldHaveLength, 2)
+				So(filesAPIClient.MarkFilePublishedCalls(), ShouldBeEmpty)
+				So(auditServiceMock.RecordVersionAuditEventCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("Given a collection with a dataset that is not valid", t, func() {
+		mockedDataStore := newStore()
+		mockedDataStore.GetDatasetsByCollectionIDFunc = func(context.Context, string, []string) ([]*models.DatasetUpdate, error) {
+			dataset := staticDataset()
+			dataset.ID = "mid-year-pop"
+			dataset.Keywords = nil
+			return []*models.DatasetUpdate{{ID: dataset.ID, Next: dataset}}, nil
+		}
+		filesAPIClient := newFilesAPIClient(filesAPIStateUploaded)
+		api := newAPI(mockedDataStore, filesAPIClient, newAuditService())
+
+		Convey("When the collection is published", func() {
+			w, result := publish(api)
+
+			Convey("Then a conflict is returned with the failing check of the dataset, and nothing is published", func() {
+				So(w.Code, ShouldEqual, http.StatusConflict)
+				So(result.Published, ShouldBeFalse)
+				So(outcomes(result), ShouldResemble, []string{models.CollectionItemNotPublished, models.CollectionItemNotPublished, models.CollectionItemInvalid})
+				So(result.Items[2].Checks, ShouldResemble, []models.PreflightCheck{{Name: "dataset", Reason: "invalid fields: [Keywords]"}})
+
+				So(mockedDataStore.AcquireVersionsLockCalls(), ShouldBeEmpty)
+				So(mockedDataStore.UpsertDatasetCalls(), ShouldBeEmpty)
+				So(filesAPIClient.MarkFilePublishedCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("Given a collection with datasets but without versions to publish", t, func() {
+		mockedDataStore := newStore()
+		mockedDataStore.GetVersionsByCollectionIDFunc = func(context.Context, string, []string) ([]*models.Version, error) {
+			return nil, errs.ErrVersionsNotFound
+		}
+		auditServiceMock := newAuditService()
+		api := newAPI(mockedDataStore, newFilesAPIClient(filesAPIStateUploaded), auditServiceMock)
+
+		Convey("When the collection is published", func() {
+			w, result := publish(api)
+
+			Convey("Then the datasets are published", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(result.Published, ShouldBeTrue)
+				So(outcomes(result), ShouldResemble, []string{models.CollectionItemPublished})
+				So(mockedDataStore.UpsertDatasetCalls(), ShouldHaveLength, 1)
+				So(auditServiceMock.RecordVersionAuditEventCalls(), ShouldBeEmpty)
+				So(auditServiceMock.RecordDatasetAuditEventCalls(), ShouldHaveLength, 1)
+			})
+		})
+	})
+
+	Convey("Given a collection without datasets or versions to publish", t, func() {
+		mockedDataStore := newStore()
+		mockedDataStore.GetVersionsByCollectionIDFunc = func(context.Context, string, []string) ([]*models.Version, error) {
+			return nil, errs.ErrVersionsNotFound
+		}
+		mockedDataStore.GetDatasetsByCollectionIDFunc = func(context.Context, string, []string) ([]*models.DatasetUpdate, error) {
+			return []*models.DatasetUpdate{}, nil
+		}
+		api := newAPI(mockedDataStore, newFilesAPIClient(filesAPIStateUploaded), newAuditService())
+
+		Convey("When the collection is published", func() {
+			w, _ := publish(api)
+
+			Convey("Then the collection is not found", func() {
+				So(w.Code, ShouldEqual, http.StatusNotFound)
+				So(w.Body.String(), ShouldContainSubstring, errs.ErrCollectionNotFound.Error())
+			})
+		})
+	})
+}
@@ -11,8 +11,8 @@ import (
 	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
 	"github.com/ONSdigital/dp-dataset-api/models"
 	"github.com/ONSdigital/dp-dataset-api/mongo"
+	"github.com/ONSdigital/dp-dataset-api/pagination"
 	"github.com/ONSdigital/dp-dataset-api/utils"
-	filesAPISDK "github.com/ONSdigital/dp-files-api/sdk"
 	dphttp "github.com/ONSdigital/dp-net/v3/http"
 	"github.com/ONSdigital/dp-net/v3/links"
 	"github.com/ONSdigital/log.go/v2/log"
@@ -27,6 +27,7 @@ var (
 		errs.ErrDatasetTypeInvalid:         true,
 		errs.ErrInvalidQueryParameter:      true,
 		errs.ErrSpacesNotAllowedInID:       true,
+		errs.ErrInvalidRevision:            true,
 	}
 
 	// errors that should return a 403 status
@@ -39,6 +40,7 @@ var (
 		errs.ErrDatasetNotFound:  true,
 		errs.ErrEditionsNotFound: true,
 		errs.ErrEditionNotFound:  true,
+		errs.ErrRevisionNotFound: true,
 	}
 
 	// errors that should return a 409 status
@@ -54,8 +56,8 @@ const DatasetType = "type"
 const SortOrder = "sort_order"
 const DatasetID = "id"
 
-// getDatasets returns a list of datasets, the total count of datasets and an error
-func (api *DatasetAPI) getDatasets(w http.ResponseWriter, r *http.Request, limit, offset int) (mappedDatasets interface{}, totalCount int, err error) {
+// getDatasets returns a list of datasets, the total count of datasets, the cursor of the next page and an error
+func (api *DatasetAPI) getDatasets(w http.ResponseWriter, r *http.Request, limit, offset int, cursor *pagination.Cursor) (mappedDatasets interface{}, totalCount int, nextCursor *pagination.Cursor, err error) {
 	ctx := r.Context()
 	logData := log.Data{}
 	authorised := api.checkUserPermission(r, logData, datasetReadPermission, nil)
@@ -76,46 +78,47 @@ func (api *DatasetAPI) getDatasets(w http.ResponseWriter, r *http.Request, limit
 		err := errs.ErrInvalidQueryParameter
 		log.Error(ctx, "malformed is_based_on parameter", err)
 		handleDatasetAPIErr(ctx, err, w, logData)
-		return nil, 0, err
+		return nil, 0, nil, err
 	}
 
 	if isDatasetTypeExists && datasetType == "" {
 		err := errs.ErrInvalidQueryParameter
 		log.Error(ctx, "malformed type parameter", err)
 		handleDatasetAPIErr(ctx, err, w, logData)
-		return nil, 0, err
+		return nil, 0, nil, err
 	}
 
 	if isSortOrderExists && sortOrder != mongo.ASCOrder && sortOrder != mongo.DESCOrder {
 		err := errs.ErrInvalidQueryParameter
 		log.Error(ctx, "malformed sort_order parameter", err)
 		handleDatasetAPIErr(ctx, err, w, logData)
-		return nil, 0, err
+		return nil, 0, nil, err
 	}
 
 	if isSearchByIDExist && datasetID == "" {
 		err := errs.ErrInvalidQueryParameter
 		log.Error(ctx, "malformed dataset_id parameter", err)
 		handleDatasetAPIErr(ctx, err, w, logData)
-		return nil, 0, err
+		return nil, 0, nil, err
 	}
 
 	var datasets []*models.DatasetUpdate
 
 	if isBasedOnExists || isDatasetTypeExists || isSortOrderExists || isSearchByIDExist {
-		datasets, totalCount, err = api.dataStore.Backend.GetDatasetsByQueryParams(ctx, isBasedOn, datasetType, sortOrder, datasetID, offset, limit, authorised)
+		datasets, totalCount, nextCursor, err = api.dataStore.Backend.GetDatasetsByQueryParams(ctx, isBasedOn, datasetType, sortOrder, datasetID, offset, limit, cursor, authorised)
 	} else {
-		datasets, totalCount, err = api.dataStore.Backend.GetDatasets(
+		datasets, totalCount, nextCursor, err = api.dataStore.Backend.GetDatasets(
 			ctx,
 			offset,
 			limit,
+			cursor,
 			authorised,
 		)
 	}
 	if err != nil {
 		log.Error(ctx, "api endpoint getDatasets datastore.GetDatasets returned an error", err)
 		handleDatasetAPIErr(ctx, err, w, logData)
-		return nil, 0, err
+		return nil, 0, nil, err
 	}
 
 	if api.enableURLRewriting {
@@ -126,27 +129,27 @@ func (api *DatasetAPI) getDatasets(w http.ResponseWriter, r *http.Request, limit
 			if err != nil {
 				log.Error(ctx, "getDatasets endpoint: failed to rewrite datasets with auth", err)
 				handleDatasetAPIErr(ctx, err, w, logData)
-				return nil, 0, err
+				return nil, 0, nil, err
 			}
 			log.Info(ctx, "getDatasets endpoint: get all datasets with auth", logData)
-			return datasetsResponse, totalCount, nil
+			return datasetsResponse, totalCount, nextCursor, nil
 		}
 
 		datasetsResponse, err := utils.RewriteDatasetsWithoutAuth(ctx, datasets, datasetLinksBuilder)
 		if err != nil {
 			log.Error(ctx, "getDatasets endpoint: failed to rewrite datasets without authorisation", err)
 			handleDatasetAPIErr(ctx, err, w, logData)
-			return nil, 0, err
+			return nil, 0, nil, err
 		}
 		log.Info(ctx, "getDatasets endpoint: get all datasets without auth", logData)
-		return datasetsResponse, totalCount, nil
+		return datasetsResponse, totalCount, nextCursor, nil
 	}
 
 	if authorised {
-		return datasets, totalCount, nil
+		return datasets, totalCount, nextCursor, nil
 	}
 
-	return mapResults(datasets), totalCount, nil
+	return mapResults(datasets), totalCount, nextCursor, nil
 }
 
 //nolint:gocognit,gocyclo // This handler has high complexity (46) because it contains logic for both static and non-static datasets, including permission checks, state-based document merging, and conditional URL rewriting.
@@ -192,7 +195,7 @@ func (api *DatasetAPI) getDataset(w http.ResponseWriter, r *http.Request) {
 			logAuthOption := log.Auth(identityType, authEntityData.EntityData.UserID)
 
 			// ID and Email are the same as auth middleware can only provide userID
-			if err := api.auditService.RecordDatasetAuditEvent(ctx, models.RequestedBy{ID: authEntityData.EntityData.UserID, Email: authEntityData.EntityData.UserID}, models.ActionRead, "/datasets/"+datasetID, dataset.Next); err != nil {
+			if err := api.auditService.RecordDatasetAuditEvent(ctx, models.RequestedBy{ID: authEntityData.EntityData.UserID, Email: authEntityData.EntityData.UserID}, models.ActionRead, "/datasets/"+datasetID, nil, dataset.Next); err != nil {
 				log.Info(ctx, "failed to create dataset audit event", log.Classification(log.ProtectiveMonitoring), logAuthOption, log.Data{
 					"action":   models.ActionRead,
 					"endpoint": "/datasets/" + datasetID,
@@ -485,7 +488,7 @@ func (api *DatasetAPI) addDatasetNew(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// ID and Email are the same as auth middleware can only provide userID
-	if err := api.auditService.RecordDatasetAuditEvent(ctx, models.RequestedBy{ID: authEntityData.EntityData.UserID, Email: authEntityData.EntityData.UserID}, models.ActionCreate, "/datasets/"+datasetID, dataset); err != nil {
+	if err := api.auditService.RecordDatasetAuditEvent(ctx, models.RequestedBy{ID: authEntityData.EntityData.UserID, Email: authEntityData.EntityData.UserID}, models.ActionCreate, "/datasets/"+datasetID, nil, dataset); err != nil {
 		log.Info(ctx, "failed to created dataset audit event", log.Classification(log.ProtectiveMonitoring), logAuthOption, log.Data{
 			"action":   models.ActionCreate,
 			"endpoint": "/datasets/" + datasetID,
@@ -560,6 +563,8 @@ func (api *DatasetAPI) putDataset(w http.ResponseWriter, r *http.Request) {
 			return nil, err
 		}
 
+		// publishing updates the current dataset, so keep its previous state for the audit event
+		previousDataset := *currentDataset.Next
 		dataset.Type = currentDataset.Next.Type
 
 		if dataset.Type == models.Static.String() && dataset.ID != "" {
@@ -607,7 +612,7 @@ func (api *DatasetAPI) putDataset(w http.ResponseWriter, r *http.Request) {
 		}
 
 		// ID and Email are the same as auth middleware can only provide userID
-		if err := api.auditService.RecordDatasetAuditEvent(ctx, models.RequestedBy{ID: authEntityData.EntityData.UserID, Email: authEntityData.EntityData.UserID}, models.ActionUpdate, "/datasets/"+datasetID, dataset); err != nil {
+		if err := api.auditService.RecordDatasetAuditEvent(ctx, models.RequestedBy{ID: authEntityData.EntityData.UserID, Email: authEntityData.EntityData.UserID}, models.ActionUpdate, "/datasets/"+datasetID, &previousDataset, dataset); err != nil {
 			log.Info(ctx, "failed to create dataset audit event", log.Classification(log.ProtectiveMonitoring), logAuthOption, log.Data{
 				"action":   models.ActionUpdate,
 				"endpoint": "/datasets/" + datasetID,
@@ -713,67 +718,62 @@ func (api *DatasetAPI) deleteDataset(w http.ResponseWriter, r *http.Request) {
 			return errs.ErrDeletePublishedDatasetForbidden
 		}
 
-		// Find any editions/versions associated with the dataset based on the type
-		if currentDataset.Next.Type == models.Static.String() {
-			// Limit is set to DEFAULT_LIMIT (20) to prevent unbounded queries.
-			// If a dataset has more than DEFAULT_LIMIT unpublished editions/versions, only the first DEFAULT_LIMIT will be deleted.
-			// Refactoring is required if more than DEFAULT_LIMIT editions/versions per dataset is a possibility.
-			versionDocs, _, err := api.dataStore.Backend.GetAllStaticVersions(ctx, currentDataset.ID, "", 0, api.defaultLimit)
-			if err != nil {
-				if err == errs.ErrVersionsNotFound {
-					log.Info(ctx, "deleteDataset endpoint: dataset didn't contain any versions, continuing to delete dataset", logData)
-				} else {
-					log.Error(ctx, "deleteDataset endpoint: failed to get versions for static dataset", err, logData)
-					return err
+		// the dataset and its editions or versions are deleted together, with the same deletion, so that they can be restored together.
+		// Their files are kept until they are purged.
+		deletion := models.NewDeletion(authEntityData.EntityData.UserID)
+		err = api.dataStore.Backend.RunTransaction(ctx, func(ctx context.Context) error {
+			// Find any editions/versions associated with the dataset based on the type
+			if currentDataset.Next.Type == models.Static.String() {
+				// Limit is set to DEFAULT_LIMIT (20) to prevent unbounded queries.
+				// If a dataset has more than DEFAULT_LIMIT unpublished editions/versions, only the first DEFAULT_LIMIT will be deleted.
+				// Refactoring is required if more than DEFAULT_LIMIT editions/versions per dataset is a possibility.
+				versionDocs, _, err := api.dataStore.Backend.GetAllStaticVersions(ctx, currentDataset.ID, "", 0, api.defaultLimit)
+				if err != nil {
+					if err == errs.ErrVersionsNotFound {
+						log.Info(ctx, "deleteDataset endpoint: dataset didn't contain any versions, continuing to delete dataset", logData)
+					} else {
+						log.Error(ctx, "deleteDataset endpoint: failed to get versions for static dataset", err, logData)
+						return err
+					}
 				}
-			}
 
-			for i := range versionDocs {
-				if versionDocs[i].Distributions != nil {
-					for _, distribution := range *versionDocs[i].Distributions {
-						logData["distribution_title"] = distribution.Title
-						logData["distribution_download_url"] = distribution.DownloadURL
-
-						err := api.filesAPIClient.DeleteFile(ctx, distribution.DownloadURL, filesAPISDK.Headers{Authorization: fetchAccessTokenFromHeader(r)})
-						if err != nil {
-							log.Error(ctx, "deleteDataset endpoint: failed to delete distribution file from files API", err, logData)
-							return err
-						}
-						log.Info(ctx, "deleteDataset endpoint: successfully deleted distribution file from files API", logData)
+				for i := range versionDocs {
+					err := api.dataStore.Backend.DeleteStaticDatasetVersion(ctx, currentDataset.ID, versionDocs[i].Edition, versionDocs[i].Version, deletion)
+					if err != nil {
+						log.Error(ctx, "deleteDataset endpoint: failed to delete version", err, logData)
+						return err
 					}
 				}
-
-				err := api.dataStore.Backend.DeleteStaticDatasetVersion(ctx, currentDataset.ID, versionDocs[i].Edition, versionDocs[i].Version)
-				if err != nil {
-					log.Error(ctx, "deleteDataset endpoint: failed to delete version", err, logData)
-					return err
+			} else {
+				editionDocs, _, err := api.dataStore.Backend.GetEditions(ctx, currentDataset.ID, "", 0, 0, true)
+				if err != nil && err != errs.ErrEditionNotFound {
+					return fmt.Errorf("failed to get editions: %w", err)
 				}
-			}
-		} else {
-			editionDocs, _, err := api.dataStore.Backend.GetEditions(ctx, currentDataset.ID, "", 0, 0, true)
-			if err != nil && err != errs.ErrEditionNotFound {
-				return fmt.Errorf("failed to get editions: %w", err)
-			}
 
-			if len(editionDocs) == 0 {
-				log.Info(ctx, "no editions found for dataset", logData)
-			}
+				if len(editionDocs) == 0 {
+					log.Info(ctx, "no editions found for dataset", logData)
+				}
 
-			for i := range editionDocs {
-				if err := api.dataStore.Backend.DeleteEdition(ctx, editionDocs[i].ID); err != nil {
-					log.Error(ctx, "failed to delete edition", err, logData)
-					return err
+				for i := range editionDocs {
+					if err := api.dataStore.Backend.DeleteEdition(ctx, editionDocs[i].ID, deletion); err != nil {
+						log.Error(ctx, "failed to delete edition", err, logData)
+						return err
+					}
 				}
 			}
-		}
 
-		if err := api.dataStore.Backend.DeleteDataset(ctx, datasetID); err != nil {
-			log.Error(ctx, "failed to delete dataset", err, logData)
+			if err := api.dataStore.Backend.DeleteDataset(ctx, datasetID, deletion); err != nil {
+				log.Error(ctx, "failed to delete dataset", err, logData)
+				return err
+			}
+			return nil
+		})
+		if err != nil {
 			return err
 		}
 
 		// ID and Email are the same as auth middleware can only provide userID
-		if err := api.auditService.RecordDatasetAuditEvent(ctx, models.RequestedBy{ID: authEntityData.EntityData.UserID, Email: authEntityData.EntityData.UserID}, models.ActionDelete, "/datasets/"+datasetID, currentDataset.Next); err != nil {
+		if err := api.auditService.RecordDatasetAuditEvent(ctx, models.RequestedBy{ID: authEntityData.EntityData.UserID, Email: authEntityData.EntityData.UserID}, models.ActionDelete, "/datasets/"+datasetID, nil, currentDataset.Next); err != nil {
 			log.Info(ctx, "failed to create dataset audit event", log.Classification(log.ProtectiveMonitoring), logAuthOption, log.Data{
 				"action":   models.ActionDelete,
 				"endpoint": "/datasets/" + datasetID,
@@ -802,6 +802,70 @@ func (api *DatasetAPI) deleteDataset(w http.ResponseWriter, r *http.Request) {
 	log.Info(ctx, "delete dataset", logData)
 }
 
+// restoreDataset restores a deleted dataset that has not been purged yet, along with the editions and versions that were deleted with it
+func (api *DatasetAPI) restoreDataset(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	datasetID := vars["dataset_id"]
+	logData := log.Data{"dataset_id": datasetID, "func": "restoreDataset"}
+	authEntityData, err := api.getAuthEntityData(r)
+	if err != nil {
+		log.Error(ctx, "restoreDataset endpoint: failed to get auth entity data from request", err, logData)
+		handleDatasetAPIErr(ctx, err, w, logData)
+		return
+	}
+
+	identityType := log.USER
+	if authEntityData.IsServiceAuth {
+		identityType = log.SERVICE
+	}
+	logAuthOption := log.Auth(identityType, authEntityData.EntityData.UserID)
+
+	err = func() error {
+		if err := api.dataStore.Backend.RestoreDataset(ctx, datasetID); err != nil {
+			if err == errs.ErrDatasetNotFound {
+				log.Info(ctx, "cannot restore dataset that is not deleted", logData)
+				return err
+			}
+			log.Error(ctx, "failed to restore dataset", err, logData)
+			return err
+		}
+
+		restoredDataset, err := api.dataStore.Backend.GetDataset(ctx, datasetID)
+		if err != nil {
+			log.Error(ctx, "failed to get restored dataset", err, logData)
+			return err
+		}
+
+		// ID and Email are the same as auth middleware can only provide userID
+		if err := api.auditService.RecordDatasetAuditEvent(ctx, models.RequestedBy{ID: authEntityData.EntityData.UserID, Email: authEntityData.EntityData.UserID}, models.ActionRestore, "/datasets/"+datasetID, nil, restoredDataset.Next); err != nil {
+			log.Info(ctx, "failed to create dataset audit event", log.Classification(log.ProtectiveMonitoring), logAuthOption, log.Data{
+				"action":   models.ActionRestore,
+				"endpoint": "/datasets/" + datasetID + "/restore",
+				"outcome":  "failure",
+				"reason":   err.Error(),
+			})
+			log.Error(ctx, "restoreDataset endpoint: failed to record dataset audit event", err, logData)
+			return err
+		}
+		log.Info(ctx, "successfully created dataset audit event", log.Classification(log.ProtectiveMonitoring), logAuthOption, log.Data{
+			"action":   models.ActionRestore,
+			"endpoint": "/datasets/" + datasetID + "/restore",
+			"outcome":  "success",
+		})
+
+		return nil
+	}()
+
+	if err != nil {
+		handleDatasetAPIErr(ctx, err, w, logData)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	log.Info(ctx, "restore dataset", logData)
+}
+
 func mapResults(results []*models.DatasetUpdate) []*models.Dataset {
 	items := []*models.Dataset{}
 	for _, item := range results {
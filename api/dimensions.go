@@ -8,6 +8,7 @@ import (
 
 	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
 	"github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/dp-dataset-api/pagination"
 	"github.com/ONSdigital/dp-dataset-api/utils"
 	"github.com/ONSdigital/dp-net/v3/links"
 	"github.com/ONSdigital/log.go/v2/log"
@@ -37,6 +38,7 @@ func (api *DatasetAPI) getDimensions(w http.ResponseWriter, r *http.Request, lim
 		return nil, 0, err
 	}
 
+	var tombstone *models.VersionTombstone
 	list, totalCount, err := func() ([]models.Dimension, int, error) {
 		authorised := api.checkUserPermission(r, logData, datasetEditionVersionReadPermission, nil)
 
@@ -48,6 +50,9 @@ func (api *DatasetAPI) getDimensions(w http.ResponseWriter, r *http.Request, lim
 		versionDoc, err := api.dataStore.Backend.GetVersion(ctx, datasetID, edition, versionNumber, state)
 		if err != nil {
 			log.Error(ctx, "datastore.getversion returned an error", err, logData)
+			if tombstone = api.getDatasetVersionTombstone(ctx, datasetID, edition, versionNumber, authorised, err); tombstone != nil {
+				return nil, 0, errs.ErrVersionWithdrawn
+			}
 			return nil, 0, err
 		}
 
@@ -81,6 +86,10 @@ func (api *DatasetAPI) getDimensions(w http.ResponseWriter, r *http.Request, lim
 
 		return slicedResults, len(dimensions), nil
 	}()
+	if tombstone != nil {
+		writeVersionTombstone(ctx, w, tombstone, logData)
+		return nil, 0, err
+	}
 	if err != nil {
 		handleDimensionsErr(ctx, w, "", err, logData)
 		return nil, 0, err
@@ -148,12 +157,12 @@ func convertBSONToDimensionOption(data interface{}) (*models.DimensionOption, er
 	return &dim, nil
 }
 
-// getDimensionOptions returns a list of options, the total count of options that match the query parameters and an error
+// getDimensionOptions returns a list of options, the total count of options that match the query parameters, the cursor of the next page and an error
 //
 // TODO: Refactor this to have named results
 //
 //nolint:gocritic
-func (api *DatasetAPI) getDimensionOptions(w http.ResponseWriter, r *http.Request, limit, offset int) (interface{}, int, error) {
+func (api *DatasetAPI) getDimensionOptions(w http.ResponseWriter, r *http.Request, limit, offset int, cursor *pagination.Cursor) (interface{}, int, *pagination.Cursor, error) {
 	ctx := r.Context()
 	vars := mux.Vars(r)
 	datasetID := vars["dataset_id"]
@@ -168,7 +177,7 @@ func (api *DatasetAPI) getDimensionOptions(w http.ResponseWriter, r *http.Reques
 	if err != nil {
 		log.Error(ctx, "invalid version requested", err, logData)
 		handleDimensionsErr(ctx, w, "invalid version", err, logData)
-		return nil, 0, err
+		return nil, 0, nil, err
 	}
 
 	var state string
@@ -181,38 +190,43 @@ func (api *DatasetAPI) getDimensionOptions(w http.ResponseWriter, r *http.Reques
 	if err != nil {
 		logData["query_params"] = r.URL.RawQuery
 		handleDimensionsErr(ctx, w, "failed to obtain list of IDs from request query parameters", err, logData)
-		return nil, 0, err
+		return nil, 0, nil, err
 	}
 
 	// get version for provided dataset, edition and versionID
 	version, err := api.dataStore.Backend.GetVersion(ctx, datasetID, edition, versionName, state)
 	if err != nil {
+		if tombstone := api.getDatasetVersionTombstone(ctx, datasetID, edition, versionName, authorised, err); tombstone != nil {
+			writeVersionTombstone(ctx, w, tombstone, logData)
+			return nil, 0, nil, errs.ErrVersionWithdrawn
+		}
 		handleDimensionsErr(ctx, w, "failed to get version", err, logData)
-		return nil, 0, err
+		return nil, 0, nil, err
 	}
 
 	// validate state
 	if err = models.CheckState("version", version.State); err != nil {
 		logData["version_state"] = version.State
 		handleDimensionsErr(ctx, w, "unpublished version has an invalid state", err, logData)
-		return nil, 0, err
+		return nil, 0, nil, err
 	}
 
 	var results []*models.PublicDimensionOption
 	var totalCount int
+	var nextCursor *pagination.Cursor
 	if len(ids) == 0 {
-		// get sorted dimension options, starting at offset index, with a limit on the number of items
-		results, totalCount, err = api.dataStore.Backend.GetDimensionOptions(ctx, version, dimension, offset, limit)
+		// get sorted dimension options, starting at offset index or after the cursor, with a limit on the number of items
+		results, totalCount, nextCursor, err = api.dataStore.Backend.GetDimensionOptions(ctx, version, dimension, offset, limit, cursor)
 		if err != nil {
 			handleDimensionsErr(ctx, w, "failed to get a list of dimension options", err, logData)
-			return nil, 0, err
+			return nil, 0, nil, err
 		}
 	} else {
 		// get dimension options from the provided list of IDs, sorted by option
 		results, totalCount, err = api.dataStore.Backend.GetDimensionOptionsFromIDs(ctx, version, dimension, ids)
 		if err != nil {
 			handleDimensionsErr(ctx, w, "failed to get a list of dimension options", err, logData)
-			return nil, 0, err
+			return nil, 0, nil, err
 		}
 	}
 
@@ -230,11 +244,11 @@ func (api *DatasetAPI) getDimensionOptions(w http.ResponseWriter, r *http.Reques
 		results, err = utils.RewritePublicDimensionOptions(ctx, results, datasetLinksBuilder, codeListLinksBuilder)
 		if err != nil {
 			handleDimensionsErr(ctx, w, "getDimensionOptions endpoint: failed to map dimension options and rewrite links", err, logData)
-			return nil, 0, err
+			return nil, 0, nil, err
 		}
 	}
 
-	return results, totalCount, nil
+	return results, totalCount, nextCursor, nil
 }
 
 // handleDimensionsErr maps the provided error to its corresponding status code.
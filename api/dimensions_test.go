@@ -13,6 +13,7 @@ import (
 	cloudflareMocks "github.com/ONSdigital/dp-dataset-api/cloudflare/mocks"
 	"github.com/ONSdigital/dp-dataset-api/mocks"
 	"github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/dp-dataset-api/pagination"
 	storetest "github.com/ONSdigital/dp-dataset-api/store/datastoretest"
 	permissionsAPISDK "github.com/ONSdigital/dp-permissions-api/sdk"
 	"github.com/gorilla/mux"
@@ -21,7 +22,7 @@ import (
 )
 
 func initAPIWithMockedStore(mockedStore *storetest.StorerMock, authorisationMock *authMock.MiddlewareMock, cloudflareMock *cloudflareMocks.ClienterMock, auditServiceMock *applicationMocks.AuditServiceMock) *DatasetAPI {
-	return GetAPIWithCMDMocks(mockedStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, SearchContentUpdatedProducer{}, cloudflareMock, auditServiceMock)
+	return GetAPIWithCMDMocks(mockedStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, cloudflareMock, auditServiceMock)
 }
 
 func TestGetDimensionsForbidden(t *testing.T) {
@@ -40,7 +41,7 @@ func TestGetDimensionsForbidden(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 		api.Router.ServeHTTP(w, r)
 
 		So(w.Code, ShouldEqual, http.StatusForbidden)
@@ -65,7 +66,7 @@ func TestGetDimensionsUnauthorised(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 		api.Router.ServeHTTP(w, r)
 
 		So(w.Code, ShouldEqual, http.StatusUnauthorized)
@@ -98,7 +99,7 @@ func TestGetDimensionsReturnsOk(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 		api.Router.ServeHTTP(w, r)
 
 		So(w.Code, ShouldEqual, http.StatusOK)
@@ -254,14 +255,14 @@ func TestGetDimensionOptionsReturnsOk(t *testing.T) {
 			GetVersionFunc: func(context.Context, string, string, int, string) (*models.Version, error) {
 				return &models.Version{State: models.AssociatedState, ID: "v1"}, nil
 			},
-			GetDimensionOptionsFunc: func(context.Context, *models.Version, string, int, int) ([]*models.PublicDimensionOption, int, error) {
+			GetDimensionOptionsFunc: func(context.Context, *models.Version, string, int, int, *pagination.Cursor) ([]*models.PublicDimensionOption, int, *pagination.Cursor, error) {
 				allItems := []*models.PublicDimensionOption{
 					{Option: "op1"},
 					{Option: "op2"},
 					{Option: "op3"},
 					{Option: "op4"},
 					{Option: "op5"}}
-				return allItems, 5, nil
+				return allItems, 5, nil, nil
 			},
 			GetDimensionOptionsFromIDsFunc: func(_ context.Context, _ *models.Version, _ string, ids []string) ([]*models.PublicDimensionOption, int, error) {
 				ret := []*models.PublicDimensionOption{}
@@ -294,16 +295,16 @@ func TestGetDimensionOptionsReturnsOk(t *testing.T) {
 		}
 
 		// func to perform a call
-		callOptions := func(r *http.Request) (interface{}, int, error) {
+		callOptions := func(r *http.Request) (interface{}, int, *pagination.Cursor, error) {
 			w := httptest.NewRecorder()
 			api := initAPIWithMockedStore(mockedDataStore, authorisationMock, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
-			return api.getDimensionOptions(w, r, 20, 0)
+			return api.getDimensionOptions(w, r, 20, 0, nil)
 		}
 
-		callOptionsWithIDs := func(r *http.Request) (interface{}, int, error) {
+		callOptionsWithIDs := func(r *http.Request) (interface{}, int, *pagination.Cursor, error) {
 			w := httptest.NewRecorder()
 			api := initAPIWithMockedStore(mockedDataStore, authorisationMock, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
-			return api.getDimensionOptions(w, r, 20, 0)
+			return api.getDimensionOptions(w, r, 20, 0, nil)
 		}
 
 		setExpectedURLVars := func(r *http.Request) *http.Request {
@@ -341,7 +342,7 @@ func TestGetDimensionOptionsReturnsOk(t *testing.T) {
 		Convey("When a valid dimension is provided without any query parameters", func() {
 			r := httptest.NewRequest("GET", "http://localhost:22000/datasets/123/editions/2017/versions/1/dimensions/age/options", http.NoBody)
 			r = setExpectedURLVars(r)
-			list, totalCount, err := callOptions(r)
+			list, totalCount, _, err := callOptions(r)
 
 			Convey("Then the call succeeds with 200 OK code, expected body and calls", func() {
 				expectedList := []*models.PublicDimensionOption{
@@ -361,7 +362,7 @@ func TestGetDimensionOptionsReturnsOk(t *testing.T) {
 		Convey("When a valid dimension and list of existing IDs is provided in more than one parameter, in comma-separated format", func() {
 			r := httptest.NewRequest("GET", "http://localhost:22000/datasets/123/editions/2017/versions/1/dimensions/age/options?id=op1,op3&id=op5", http.NoBody)
 			r = setExpectedURLVars(r)
-			list, totalCount, err := callOptionsWithIDs(r)
+			list, totalCount, _, err := callOptionsWithIDs(r)
 
 			Convey("Then the call succeeds with 200 OK code, expected body and calls", func() {
 				expectedList := []*models.PublicDimensionOption{
@@ -379,7 +380,7 @@ func TestGetDimensionOptionsReturnsOk(t *testing.T) {
 		Convey("When a valid offset, limit and dimension and list of existing IDs are provided", func() {
 			r := httptest.NewRequest("GET", "http://localhost:22000/datasets/123/editions/2017/versions/1/dimensions/age/options?id=op1,op3&offset=0&limit=1", http.NoBody)
 			r = setExpectedURLVars(r)
-			list, totalCount, err := callOptionsWithIDs(r)
+			list, totalCount, _, err := callOptionsWithIDs(r)
 
 			Convey("Then the call succeeds with 200 OK code, the list of IDs take precedence (offset and limit are ignored), and the expected body and calls are performed", func() {
 				expectedList := []*models.PublicDimensionOption{
@@ -510,8 +511,8 @@ func TestGetDimensionOptionsReturnsErrors(t *testing.T) {
 			GetVersionFunc: func(context.Context, string, string, int, string) (*models.Version, error) {
 				return &models.Version{State: models.AssociatedState}, nil
 			},
-			GetDimensionOptionsFunc: func(context.Context, *models.Version, string, int, int) ([]*models.PublicDimensionOption, int, error) {
-				return nil, 0, errs.ErrInternalServer
+			GetDimensionOptionsFunc: func(context.Context, *models.Version, string, int, int, *pagination.Cursor) ([]*models.PublicDimensionOption, int, *pagination.Cursor, error) {
+				return nil, 0, nil, errs.ErrInternalServer
 			},
 		}
 
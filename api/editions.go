@@ -295,7 +295,7 @@ func (api *DatasetAPI) getEdition(w http.ResponseWriter, r *http.Request) {
 			logAuthOption := log.Auth(identityType, authEntityData.EntityData.UserID)
 
 			if datasetType == models.Static.String() {
-				if err := api.auditService.RecordVersionAuditEvent(ctx, models.RequestedBy{ID: authEntityData.EntityData.UserID, Email: authEntityData.EntityData.UserID}, models.ActionRead, "/datasets/"+datasetID+"/editions/"+editionID, versionToAudit); err != nil {
+				if err := api.auditService.RecordVersionAuditEvent(ctx, models.RequestedBy{ID: authEntityData.EntityData.UserID, Email: authEntityData.EntityData.UserID}, models.ActionRead, "/datasets/"+datasetID+"/editions/"+editionID, nil, versionToAudit); err != nil {
 					log.Info(ctx, "failed to create version audit event", log.Classification(log.ProtectiveMonitoring), logAuthOption, log.Data{
 						"action":   models.ActionRead,
 						"endpoint": "/datasets/" + datasetID + "/editions/" + editionID,
@@ -307,7 +307,7 @@ func (api *DatasetAPI) getEdition(w http.ResponseWriter, r *http.Request) {
 				}
 			} else {
 				editionToAudit := edition.Next
-				if err := api.auditService.RecordEditionAuditEvent(ctx, models.RequestedBy{ID: authEntityData.EntityData.UserID, Email: authEntityData.EntityData.UserID}, models.ActionRead, "/datasets/"+datasetID+"/editions/"+editionID, editionToAudit); err != nil {
+				if err := api.auditService.RecordEditionAuditEvent(ctx, models.RequestedBy{ID: authEntityData.EntityData.UserID, Email: authEntityData.EntityData.UserID}, models.ActionRead, "/datasets/"+datasetID+"/editions/"+editionID, nil, editionToAudit); err != nil {
 					log.Info(ctx, "failed to create version audit event", log.Classification(log.ProtectiveMonitoring), logAuthOption, log.Data{
 						"action":   models.ActionRead,
 						"endpoint": "/datasets/" + datasetID + "/editions/" + editionID,
@@ -350,3 +350,75 @@ func (api *DatasetAPI) getEdition(w http.ResponseWriter, r *http.Request) {
 	}
 	log.Info(ctx, "getEdition endpoint: request successful", logData)
 }
+
+// restoreEdition restores a deleted edition of a dataset that has not been deleted. The editions of static datasets
+// are restored by restoring their versions.
+func (api *DatasetAPI) restoreEdition(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	datasetID := vars["dataset_id"]
+	editionID := vars["edition"]
+	logData := log.Data{"dataset_id": datasetID, "edition": editionID, "func": "restoreEdition"}
+	authEntityData, err := api.getAuthEntityData(r)
+	if err != nil {
+		log.Error(ctx, "restoreEdition endpoint: failed to get auth entity data from request", err, logData)
+		handleDatasetAPIErr(ctx, err, w, logData)
+		return
+	}
+
+	identityType := log.USER
+	if authEntityData.IsServiceAuth {
+		identityType = log.SERVICE
+	}
+	logAuthOption := log.Auth(identityType, authEntityData.EntityData.UserID)
+
+	err = func() error {
+		// the editions of a deleted dataset are restored with it
+		if err := api.dataStore.Backend.CheckDatasetExists(ctx, datasetID, ""); err != nil {
+			log.Info(ctx, "cannot restore edition of dataset that does not exist", logData)
+			return err
+		}
+
+		if err := api.dataStore.Backend.RestoreEdition(ctx, datasetID, editionID); err != nil {
+			if err == errs.ErrEditionNotFound {
+				log.Info(ctx, "cannot restore edition that is not deleted", logData)
+				return err
+			}
+			log.Error(ctx, "failed to restore edition", err, logData)
+			return err
+		}
+
+		restoredEdition, err := api.dataStore.Backend.GetEdition(ctx, datasetID, editionID, "")
+		if err != nil {
+			log.Error(ctx, "failed to get restored edition", err, logData)
+			return err
+		}
+
+		// ID and Email are the same as auth middleware can only provide userID
+		if err := api.auditService.RecordEditionAuditEvent(ctx, models.RequestedBy{ID: authEntityData.EntityData.UserID, Email: authEntityData.EntityData.UserID}, models.ActionRestore, "/datasets/"+datasetID+"/editions/"+editionID, nil, restoredEdition.Next); err != nil {
+			log.Info(ctx, "failed to create edition audit event", log.Classification(log.ProtectiveMonitoring), logAuthOption, log.Data{
+				"action":   models.ActionRestore,
+				"endpoint": "/datasets/" + datasetID + "/editions/" + editionID + "/restore",
+				"outcome":  "failure",
+				"reason":   err.Error(),
+			})
+			log.Error(ctx, "restoreEdition endpoint: failed to record edition audit event", err, logData)
+			return err
+		}
+		log.Info(ctx, "successfully created edition audit event", log.Classification(log.ProtectiveMonitoring), logAuthOption, log.Data{
+			"action":   models.ActionRestore,
+			"endpoint": "/datasets/" + datasetID + "/editions/" + editionID + "/restore",
+			"outcome":  "success",
+		})
+
+		return nil
+	}()
+
+	if err != nil {
+		handleDatasetAPIErr(ctx, err, w, logData)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	log.Info(ctx, "restore edition", logData)
+}
@@ -26,6 +26,7 @@ func (api *DatasetAPI) getMetadata(w http.ResponseWriter, r *http.Request) {
 	edition := vars["edition"]
 	version := vars["version"]
 	logData := log.Data{"dataset_id": datasetID, "edition": edition, "version": version}
+	var tombstone *models.VersionTombstone
 
 	b, err := func() ([]byte, error) {
 		versionID, err := models.ParseAndValidateVersionNumber(ctx, version)
@@ -82,6 +83,12 @@ func (api *DatasetAPI) getMetadata(w http.ResponseWriter, r *http.Request) {
 
 		state := versionDoc.State
 
+		// the public are given the tombstone of a withdrawn version in place of its metadata
+		if !authorised && versionDoc.State == models.WithdrawnState {
+			tombstone = models.NewVersionTombstone(datasetID, versionDoc)
+			return nil, errs.ErrVersionWithdrawn
+		}
+
 		// if the requested version is not yet published and the user is unauthorised, return a 404
 		if !authorised && versionDoc.State != models.PublishedState {
 			log.Error(ctx, "getMetadata endpoint: unauthorised user requested unpublished version, returning 404", errs.ErrUnauthorised, logData)
@@ -130,6 +137,11 @@ func (api *DatasetAPI) getMetadata(w http.ResponseWriter, r *http.Request) {
 			}
 		}
 
+		// the review comments of a rejected static version are kept with its metadata, and only shown to publishers
+		if isStaticDataset && authorised {
+			metaDataDoc.ReviewComments = versionDoc.ReviewComments
+		}
+
 		if api.enableURLRewriting {
 			datasetLinksBuilder := links.FromHeadersOrDefault(&r.Header, api.urlBuilder.GetDatasetAPIURL())
 			codeListLinksBuilder := links.FromHeadersOrDefault(&r.Header, api.urlBuilder.GetCodeListAPIURL())
@@ -190,7 +202,7 @@ func (api *DatasetAPI) getMetadata(w http.ResponseWriter, r *http.Request) {
 			logAuthOption := log.Auth(identityType, authEntityData.EntityData.UserID)
 
 			// ID and Email are the same as auth middleware can only provide userID
-			if err := api.auditService.RecordMetadataAuditEvent(ctx, models.RequestedBy{ID: authEntityData.EntityData.UserID, Email: authEntityData.EntityData.UserID}, models.ActionRead, "/datasets/"+datasetID+"/editions/"+edition+"/versions/"+version+"/metadata", metaDataDoc); err != nil {
+			if err := api.auditService.RecordMetadataAuditEvent(ctx, models.RequestedBy{ID: authEntityData.EntityData.UserID, Email: authEntityData.EntityData.UserID}, models.ActionRead, "/datasets/"+datasetID+"/editions/"+edition+"/versions/"+version+"/metadata", nil, metaDataDoc); err != nil {
 				log.Info(ctx, "failed to create metadata audit event", log.Classification(log.ProtectiveMonitoring), logAuthOption, log.Data{
 					"action":   models.ActionRead,
 					"endpoint": "/datasets/" + datasetID + "/editions/" + edition + "/versions/" + version + "/metadata",
@@ -210,6 +222,10 @@ func (api *DatasetAPI) getMetadata(w http.ResponseWriter, r *http.Request) {
 		return b, err
 	}()
 
+	if tombstone != nil {
+		writeVersionTombstone(ctx, w, tombstone, logData)
+		return
+	}
 	if err != nil {
 		log.Error(ctx, "received error", err, logData)
 		handleMetadataErr(w, err)
@@ -296,6 +312,7 @@ func (api *DatasetAPI) putMetadata(w http.ResponseWriter, r *http.Request) {
 			return errs.ErrExpectedResourceStateOfAssociated
 		}
 
+		previousVersion := *version
 		dataset.UpdateMetadata(metadata)
 		version.UpdateMetadata(metadata)
 
@@ -305,7 +322,7 @@ func (api *DatasetAPI) putMetadata(w http.ResponseWriter, r *http.Request) {
 		}
 
 		// ID and Email are the same as auth middleware can only provide userID
-		if err := api.auditService.RecordVersionAuditEvent(ctx, models.RequestedBy{ID: authEntityData.EntityData.UserID, Email: authEntityData.EntityData.UserID}, models.ActionUpdate, "/datasets/"+datasetID+"/editions/"+edition+"/versions/"+versionID+"/metadata", version); err != nil {
+		if err := api.auditService.RecordVersionAuditEvent(ctx, models.RequestedBy{ID: authEntityData.EntityData.UserID, Email: authEntityData.EntityData.UserID}, models.ActionUpdate, "/datasets/"+datasetID+"/editions/"+edition+"/versions/"+versionID+"/metadata", &previousVersion, version); err != nil {
 			log.Error(ctx, "putMetadata endpoint: failed to record version audit event", err, logData)
 			return err
 		}
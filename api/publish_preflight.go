@@ -0,0 +1,171 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ONSdigital/dp-dataset-api/models"
+	filesAPISDK "github.com/ONSdigital/dp-files-api/sdk"
+	dphttp "github.com/ONSdigital/dp-net/v3/http"
+	"github.com/ONSdigital/log.go/v2/log"
+	"github.com/gorilla/mux"
+)
+
+// filesAPIStateUploaded is the state that the Files API requires a file to be in before it can be published
+const filesAPIStateUploaded = "UPLOADED"
+
+// preflightVersionState checks, without changing anything, whether a static version will publish cleanly, and returns
+// the checklist of the results. The checks are those made when the version is published through putState: the state
+// machine transition and its guards, the validation of the published version and the state of its files in the Files
+// API, along with the links of the version and the completeness of its metadata.
+func (api *DatasetAPI) preflightVersionState(w http.ResponseWriter, r *http.Request) (*models.SuccessResponse, *models.ErrorResponse) {
+	defer dphttp.DrainBody(r)
+
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	datasetID := vars["dataset_id"]
+	edition := vars["edition"]
+	logData := log.Data{"dataset_id": datasetID, "edition": edition, "version": vars["version"]}
+
+	versionNumber, err := models.ParseAndValidateVersionNumber(ctx, vars["version"])
+	if err != nil {
+		log.Error(ctx, "preflightVersionState endpoint: invalid version request", err, logData)
+		return nil, models.NewErrorResponse(http.StatusBadRequest, nil, models.NewError(err, models.ErrInvalidQueryParameter, models.ErrInvalidQueryParameterDescription+": version"))
+	}
+
+	dataset, err := api.dataStore.Backend.GetDataset(ctx, datasetID)
+	if err != nil {
+		log.Error(ctx, "preflightVersionState endpoint: failed to get dataset", err, logData)
+		return nil, models.NewErrorResponse(getVersionAPIErrStatusCode(err), nil, models.NewError(err, err.Error(), "internal error"))
+	}
+	if dataset.Next == nil || dataset.Next.Type != models.Static.String() {
+		log.Error(ctx, "preflightVersionState endpoint: dataset is not static", errors.New(models.ErrTypeNotStaticDescription), logData)
+		return nil, models.NewErrorResponse(http.StatusBadRequest, nil, models.NewValidationError(models.ErrInvalidTypeError, models.ErrTypeNotStaticDescription))
+	}
+
+	currentVersion, err := api.dataStore.Backend.GetVersionStatic(ctx, datasetID, edition, versionNumber, "")
+	if err != nil {
+		log.Error(ctx, "preflightVersionState endpoint: failed to get version", err, logData)
+		return nil, models.NewErrorResponse(getVersionAPIErrStatusCode(err), nil, models.NewError(err, err.Error(), "internal error"))
+	}
+
+	versionUpdate := &models.Version{
+		ID:    currentVersion.ID,
+		State: models.PublishedState,
+		Type:  models.Static.String(),
+	}
+
+	_, _, checks, err := api.smDatasetAPI.PreflightVersion(ctx, vars, versionUpdate)
+	if err != nil {
+		log.Error(ctx, "preflightVersionState endpoint: failed to check the state machine transition", err, logData)
+		return nil, models.NewErrorResponse(getVersionAPIErrStatusCode(err), nil, models.NewError(err, err.Error(), "internal error"))
+	}
+
+	checks = append(checks, api.preflightStaticVersion(ctx, dataset.Next, currentVersion, datasetID, edition, versionNumber, fetchAccessTokenFromHeader(r), logData)...)
+
+	preflight := models.NewPublishPreflight(datasetID, edition, versionNumber, currentVersion.State, checks)
+
+	b, err := json.Marshal(preflight)
+	if err != nil {
+		log.Error(ctx, "preflightVersionState endpoint: failed to marshal publish preflight into bytes", err, logData)
+		return nil, models.NewErrorResponse(http.StatusInternalServerError, nil, models.NewError(err, models.JSONMarshalError, models.ErrorMarshalFailedDescription))
+	}
+
+	logData["passed"] = preflight.Passed
+	log.Info(ctx, "preflightVersionState endpoint: request successful", logData)
+	return models.NewSuccessResponse(b, http.StatusOK, nil), nil
+}
+
+// preflightStaticVersion checks the links and the metadata of a static version, and the files of its distributions. Publishing
+// only changes the state of the version, so they are checked as they are stored.
+func (api *DatasetAPI) preflightStaticVersion(ctx context.Context, dataset *models.Dataset, version *models.Version, datasetID, edition string, versionNumber int, accessToken string, logData log.Data) []models.PreflightCheck {
+	checks := []models.PreflightCheck{
+		models.NewPreflightCheck("links", validateVersionLinks(datasetID, edition, versionNumber, version.Links)),
+		models.NewPreflightCheck("metadata", validatePublishMetadata(dataset, version)),
+	}
+	return append(checks, api.preflightDistributionFiles(ctx, version, accessToken, logData)...)
+}
+
+// validateVersionLinks checks that the links of a version point at its dataset, its edition and itself. The links may
+// be absolute or relative, so they are matched by their path.
+func validateVersionLinks(datasetID, edition string, version int, links *models.VersionLinks) error {
+	if links == nil || links.Dataset == nil || links.Edition == nil || links.Version == nil {
+		return errors.New("missing dataset, edition or version links")
+	}
+
+	datasetPath := "/datasets/" + datasetID
+	editionPath := datasetPath + "/editions/" + edition
+	versionPath := editionPath + "/versions/" + strconv.Itoa(version)
+
+	var invalidLinks []string
+	if links.Dataset.ID != datasetID || !strings.HasSuffix(links.Dataset.HRef, datasetPath) {
+		invalidLinks = append(invalidLinks, "dataset")
+	}
+	if links.Edition.ID != edition || !strings.HasSuffix(links.Edition.HRef, editionPath) {
+		invalidLinks = append(invalidLinks, "edition")
+	}
+	if links.Version.ID != strconv.Itoa(version) || !strings.HasSuffix(links.Version.HRef, versionPath) {
+		invalidLinks = append(invalidLinks, "version")
+	}
+	if links.Self != nil && !strings.HasSuffix(links.Self.HRef, versionPath) {
+		invalidLinks = append(invalidLinks, "self")
+	}
+
+	if len(invalidLinks) > 0 {
+		return fmt.Errorf("invalid links: %v", invalidLinks)
+	}
+	return nil
+}
+
+// validatePublishMetadata checks that the dataset and the version have the metadata that a published static version needs
+func validatePublishMetadata(dataset *models.Dataset, version *models.Version) error {
+	var problems []string
+	if err := models.ValidateDataset(dataset); err != nil {
+		problems = append(problems, "dataset has "+err.Error())
+	}
+	if missingFields := validateVersionFields(version); len(missingFields) > 0 {
+		problems = append(problems, fmt.Sprintf("version has missing fields: %v", missingFields))
+	}
+
+	if len(problems) > 0 {
+		return errors.New(strings.Join(problems, ", "))
+	}
+	return nil
+}
+
+// preflightDistributionFiles checks that the file of each distribution of a version is registered with the Files API
+// and can be published, as publishDistributionFiles requires, without publishing it
+func (api *DatasetAPI) preflightDistributionFiles(ctx context.Context, version *models.Version, accessToken string, logData log.Data) []models.PreflightCheck {
+	if version.Distributions == nil || len(*version.Distributions) == 0 {
+		return nil
+	}
+
+	if api.filesAPIClient == nil {
+		return []models.PreflightCheck{models.NewPreflightCheck("files", errors.New("files API client not configured"))}
+	}
+
+	var checks []models.PreflightCheck
+	for _, distribution := range *version.Distributions {
+		if distribution.DownloadURL == "" {
+			continue
+		}
+
+		file, err := api.filesAPIClient.GetFile(ctx, distribution.DownloadURL, filesAPISDK.Headers{Authorization: accessToken})
+		switch {
+		case err != nil:
+			log.Error(ctx, "preflightDistributionFiles: failed to get file metadata", err, log.Data{"filepath": distribution.DownloadURL, "version": logData["version"]})
+		case !file.IsPublishable:
+			err = errors.New("file is not set as publishable")
+		case file.State != filesAPIStateUploaded:
+			err = fmt.Errorf("file state is %s, not %s", file.State, filesAPIStateUploaded)
+		}
+		checks = append(checks, models.NewPreflightCheck("file:"+distribution.DownloadURL, err))
+	}
+
+	return checks
+}
@@ -0,0 +1,243 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	"github.com/ONSdigital/dp-dataset-api/application"
+	applicationMocks "github.com/ONSdigital/dp-dataset-api/application/mock"
+	cloudflareMocks "github.com/ONSdigital/dp-dataset-api/cloudflare/mocks"
+	"github.com/ONSdigital/dp-dataset-api/mocks"
+	"github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/dp-dataset-api/store"
+	storetest "github.com/ONSdigital/dp-dataset-api/store/datastoretest"
+	"github.com/ONSdigital/dp-files-api/files"
+	filesAPISDK "github.com/ONSdigital/dp-files-api/sdk"
+	filesAPISDKMocks "github.com/ONSdigital/dp-files-api/sdk/mocks"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestPreflightVersionState(t *testing.T) {
+	t.Parallel()
+
+	approvedVersion := func() *models.Version {
+		return &models.Version{
+			ID:           "a1b2c3",
+			Edition:      "2025",
+			EditionTitle: "2025",
+			ReleaseDate:  "2025-01-15",
+			State:        models.ApprovedState,
+			Type:         models.Static.String(),
+			Version:      1,
+			Distributions: &[]models.Distribution{
+				{Title: "Full dataset", Format: models.DistributionFormatCSV, DownloadURL: "/cpih01/2025/1/cpih01.csv"},
+			},
+			Links: &models.VersionLinks{
+				Dataset: &models.LinkObject{ID: "cpih01", HRef: "http://localhost:22000/datasets/cpih01"},
+				Edition: &models.LinkObject{ID: "2025", HRef: "http://localhost:22000/datasets/cpih01/editions/2025"},
+				Version: &models.LinkObject{ID: "1", HRef: "http://localhost:22000/datasets/cpih01/editions/2025/versions/1"},
+				Self:    &models.LinkObject{HRef: "http://localhost:22000/datasets/cpih01/editions/2025/versions/1"},
+			},
+		}
+	}
+
+	staticDataset := func() *models.Dataset {
+		return &models.Dataset{
+			ID:          "cpih01",
+			Title:       "Consumer Prices Index including owner occupiers' housing costs",
+			Description: "Measures of inflation",
+			NextRelease: "2025-02-15",
+			License:     "Open Government Licence v3.0",
+			Keywords:    []string{"inflation"},
+			Contacts:    []models.ContactDetails{{Email: "cpih@ons.gov.uk"}},
+			Topics:      []string{"economy"},
+			Type:        models.Static.String(),
+		}
+	}
+
+	newStore := func(version *models.Version, dataset *models.Dataset) *storetest.StorerMock {
+		return &storetest.StorerMock{
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
+				return &models.DatasetUpdate{ID: "cpih01", Next: dataset}, nil
+			},
+			CheckEditionExistsStaticFunc: func(context.Context, string, string, string) error {
+				return nil
+			},
+			GetVersionStaticFunc: func(context.Context, string, string, int, string) (*models.Version, error) {
+				return version, nil
+			},
+		}
+	}
+
+	uploadedFiles := func() *filesAPISDKMocks.ClienterMock {
+		return &filesAPISDKMocks.ClienterMock{
+			GetFileFunc: func(context.Context, string, filesAPISDK.Headers) (*files.StoredRegisteredMetaData, error) {
+				return &files.StoredRegisteredMetaData{IsPublishable: true, State: "UPLOADED"}, nil
+			},
+		}
+	}
+
+	newAPI := func(mockedDataStore *storetest.StorerMock, filesAPIClient *filesAPISDKMocks.ClienterMock) *DatasetAPI {
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, newStateMachineAuthorisationMock(), nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		transitions, err := application.LoadWorkflows("")
+		So(err, ShouldBeNil)
+		api.smDatasetAPI.StateMachine = application.NewStateMachine(testContext, nil, transitions, store.DataStore{Backend: mockedDataStore})
+		api.filesAPIClient = filesAPIClient
+		return api
+	}
+
+	preflight := func(api *DatasetAPI) (*httptest.ResponseRecorder, *models.PublishPreflight) {
+		r := createRequestWithAuth("POST", "http://localhost:22000/datasets/cpih01/editions/2025/versions/1/state:preflight", http.NoBody)
+		w := httptest.NewRecorder()
+		api.Router.ServeHTTP(w, r)
+
+		var result models.PublishPreflight
+		if w.Code == http.StatusOK {
+			So(json.Unmarshal(w.Body.Bytes(), &result), ShouldBeNil)
+		}
+		return w, &result
+	}
+
+	failedChecks := func(result *models.PublishPreflight) map[string]string {
+		failed := map[string]string{}
+		for _, check := range result.Checks {
+			if !check.Passed {
+				failed[check.Name] = check.Reason
+			}
+		}
+		return failed
+	}
+
+	Convey("Given an approved static version that is ready to publish", t, func() {
+		mockedDataStore := newStore(approvedVersion(), staticDataset())
+		filesAPIClient := uploadedFiles()
+		api := newAPI(mockedDataStore, filesAPIClient)
+
+		Convey("When the publish preflight is run", func() {
+			w, result := preflight(api)
+
+			Convey("Then every check passes", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(result.Passed, ShouldBeTrue)
+				So(result.State, ShouldEqual, models.ApprovedState)
+				So(failedChecks(result), ShouldBeEmpty)
+
+				var names []string
+				for _, check := range result.Checks {
+					names = append(names, check.Name)
+				}
+				So(names, ShouldResemble, []string{"transition", "version", "links", "metadata", "file:/cpih01/2025/1/cpih01.csv"})
+			})
+
+			Convey("And nothing is changed", func() {
+				So(mockedDataStore.UpdateVersionStaticCalls(), ShouldBeEmpty)
+				So(mockedDataStore.UpsertDatasetCalls(), ShouldBeEmpty)
+				So(filesAPIClient.GetFileCalls(), ShouldHaveLength, 1)
+				So(filesAPIClient.MarkFilePublishedCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("Given a static version that is not ready to publish", t, func() {
+		version := approvedVersion()
+		version.State = models.AssociatedState
+		version.EditionTitle = ""
+		version.Links.Edition.HRef = "http://localhost:22000/datasets/cpih01/editions/2024"
+		dataset := staticDataset()
+		dataset.License = ""
+		filesAPIClient := &filesAPISDKMocks.ClienterMock{
+			GetFileFunc: func(context.Context, string, filesAPISDK.Headers) (*files.StoredRegisteredMetaData, error) {
+				return &files.StoredRegisteredMetaData{IsPublishable: true, State: "CREATED"}, nil
+			},
+		}
+		api := newAPI(newStore(version, dataset), filesAPIClient)
+
+		Convey("When the publish preflight is run", func() {
+			w, result := preflight(api)
+
+			Convey("Then the failing checks are reported with their reasons", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(result.Passed, ShouldBeFalse)
+
+				failed := failedChecks(result)
+				So(failed, ShouldHaveLength, 4)
+				So(failed["transition"], ShouldEqual, "state not allowed to transition from associated to published")
+				So(failed["links"], ShouldEqual, "invalid links: [edition]")
+				So(failed["metadata"], ShouldContainSubstring, "License")
+				So(failed["metadata"], ShouldContainSubstring, "edition_title")
+				So(failed["file:/cpih01/2025/1/cpih01.csv"], ShouldEqual, "file state is CREATED, not UPLOADED")
+			})
+		})
+	})
+
+	Convey("Given a static version whose file is not registered with the Files API", t, func() {
+		filesAPIClient := &filesAPISDKMocks.ClienterMock{
+			GetFileFunc: func(context.Context, string, filesAPISDK.Headers) (*files.StoredRegisteredMetaData, error) {
+				return nil, errors.New("file not registered")
+			},
+		}
+		api := newAPI(newStore(approvedVersion(), staticDataset()), filesAPIClient)
+
+		Convey("When the publish preflight is run", func() {
+			w, result := preflight(api)
+
+			Convey("Then the file check fails", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(result.Passed, ShouldBeFalse)
+				So(failedChecks(result), ShouldResemble, map[string]string{"file:/cpih01/2025/1/cpih01.csv": "file not registered"})
+			})
+		})
+	})
+
+	Convey("Given the Files API client is not configured", t, func() {
+		api := newAPI(newStore(approvedVersion(), staticDataset()), nil)
+		api.filesAPIClient = nil
+
+		Convey("When the publish preflight is run", func() {
+			w, result := preflight(api)
+
+			Convey("Then the files check fails", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(failedChecks(result), ShouldResemble, map[string]string{"files": "files API client not configured"})
+			})
+		})
+	})
+
+	Convey("Given a dataset that is not static", t, func() {
+		dataset := staticDataset()
+		dataset.Type = models.Filterable.String()
+		mockedDataStore := newStore(approvedVersion(), dataset)
+		api := newAPI(mockedDataStore, uploadedFiles())
+
+		Convey("When the publish preflight is run", func() {
+			w, _ := preflight(api)
+
+			Convey("Then a bad request is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+				So(w.Body.String(), ShouldContainSubstring, models.ErrTypeNotStaticDescription)
+				So(mockedDataStore.GetVersionStaticCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("Given a version that does not exist", t, func() {
+		mockedDataStore := newStore(nil, staticDataset())
+		mockedDataStore.GetVersionStaticFunc = func(context.Context, string, string, int, string) (*models.Version, error) {
+			return nil, errs.ErrVersionNotFound
+		}
+		api := newAPI(mockedDataStore, uploadedFiles())
+
+		Convey("When the publish preflight is run", func() {
+			w, _ := preflight(api)
+
+			Convey("Then a not found error is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusNotFound)
+			})
+		})
+	})
+}
@@ -0,0 +1,79 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	"github.com/ONSdigital/log.go/v2/log"
+	"github.com/gorilla/mux"
+)
+
+// getDatasetRevisions returns the revisions of the metadata of a dataset, most recent first
+func (api *DatasetAPI) getDatasetRevisions(w http.ResponseWriter, r *http.Request, limit, offset int) (interface{}, int, error) {
+	ctx := r.Context()
+	datasetID := mux.Vars(r)["dataset_id"]
+	logData := log.Data{"dataset_id": datasetID}
+
+	if _, err := api.dataStore.Backend.GetDataset(ctx, datasetID); err != nil {
+		log.Error(ctx, "getDatasetRevisions endpoint: dataStore.Backend.GetDataset returned an error", err, logData)
+		handleDatasetAPIErr(ctx, err, w, logData)
+		return nil, 0, err
+	}
+
+	revisions, totalCount, err := api.dataStore.Backend.GetDatasetRevisions(ctx, datasetID, offset, limit)
+	if err != nil {
+		log.Error(ctx, "getDatasetRevisions endpoint: failed to get dataset revisions", err, logData)
+		handleDatasetAPIErr(ctx, err, w, logData)
+		return nil, 0, err
+	}
+
+	return revisions, totalCount, nil
+}
+
+// getDatasetRevision returns a revision of the metadata of a dataset by its number
+func (api *DatasetAPI) getDatasetRevision(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	datasetID := vars["dataset_id"]
+	logData := log.Data{"dataset_id": datasetID, "revision": vars["revision"]}
+
+	b, err := func() ([]byte, error) {
+		number, err := strconv.Atoi(vars["revision"])
+		if err != nil || number < 1 {
+			log.Error(ctx, "getDatasetRevision endpoint: invalid revision requested", errs.ErrInvalidRevision, logData)
+			return nil, errs.ErrInvalidRevision
+		}
+
+		if _, err := api.dataStore.Backend.GetDataset(ctx, datasetID); err != nil {
+			log.Error(ctx, "getDatasetRevision endpoint: dataStore.Backend.GetDataset returned an error", err, logData)
+			return nil, err
+		}
+
+		revision, err := api.dataStore.Backend.GetDatasetRevision(ctx, datasetID, number)
+		if err != nil {
+			log.Error(ctx, "getDatasetRevision endpoint: failed to get dataset revision", err, logData)
+			return nil, err
+		}
+
+		b, err := json.Marshal(revision)
+		if err != nil {
+			log.Error(ctx, "getDatasetRevision endpoint: failed to marshal dataset revision into bytes", err, logData)
+			return nil, err
+		}
+		return b, nil
+	}()
+
+	if err != nil {
+		handleDatasetAPIErr(ctx, err, w, logData)
+		return
+	}
+
+	setJSONContentType(w)
+	if _, err = w.Write(b); err != nil {
+		log.Error(ctx, "getDatasetRevision endpoint: error writing bytes to response", err, logData)
+		handleDatasetAPIErr(ctx, err, w, logData)
+	}
+	log.Info(ctx, "getDatasetRevision endpoint: request successful", logData)
+}
@@ -0,0 +1,172 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	authMock "github.com/ONSdigital/dp-authorisation/v2/authorisation/mock"
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	applicationMocks "github.com/ONSdigital/dp-dataset-api/application/mock"
+	cloudflareMocks "github.com/ONSdigital/dp-dataset-api/cloudflare/mocks"
+	"github.com/ONSdigital/dp-dataset-api/mocks"
+	"github.com/ONSdigital/dp-dataset-api/models"
+	storetest "github.com/ONSdigital/dp-dataset-api/store/datastoretest"
+	permissionsAPISDK "github.com/ONSdigital/dp-permissions-api/sdk"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGetDatasetRevisions(t *testing.T) {
+	t.Parallel()
+
+	authorisationMock := &authMock.MiddlewareMock{
+		RequireFunc: func(permission string, handlerFunc http.HandlerFunc) http.HandlerFunc {
+			return handlerFunc
+		},
+		ParseFunc: func(token string) (*permissionsAPISDK.EntityData, error) {
+			return testEntityData, nil
+		},
+	}
+
+	Convey("A successful request to get the revisions of a dataset returns 200 OK with the revisions", t, func() {
+		r := createRequestWithAuth("GET", "http://localhost:22000/datasets/123/revisions?offset=1&limit=2", nil)
+		w := httptest.NewRecorder()
+
+		mockedDataStore := &storetest.StorerMock{
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
+				return &models.DatasetUpdate{ID: "123"}, nil
+			},
+			GetDatasetRevisionsFunc: func(context.Context, string, int, int) ([]*models.DatasetRevision, int, error) {
+				return []*models.DatasetRevision{
+					{DatasetID: "123", Revision: 2, Dataset: &models.Dataset{Title: "second title"}},
+				}, 3, nil
+			},
+		}
+
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api.Router.ServeHTTP(w, r)
+
+		So(w.Code, ShouldEqual, http.StatusOK)
+		So(mockedDataStore.GetDatasetRevisionsCalls(), ShouldHaveLength, 1)
+		So(mockedDataStore.GetDatasetRevisionsCalls()[0].DatasetID, ShouldEqual, "123")
+		So(mockedDataStore.GetDatasetRevisionsCalls()[0].Offset, ShouldEqual, 1)
+		So(mockedDataStore.GetDatasetRevisionsCalls()[0].Limit, ShouldEqual, 2)
+
+		var page struct {
+			Items      []*models.DatasetRevision `json:"items"`
+			TotalCount int                       `json:"total_count"`
+		}
+		So(json.Unmarshal(w.Body.Bytes(), &page), ShouldBeNil)
+		So(page.TotalCount, ShouldEqual, 3)
+		So(page.Items, ShouldHaveLength, 1)
+		So(page.Items[0].Dataset.Title, ShouldEqual, "second title")
+	})
+
+	Convey("When the dataset does not exist, return status not found", t, func() {
+		r := createRequestWithAuth("GET", "http://localhost:22000/datasets/123/revisions", nil)
+		w := httptest.NewRecorder()
+
+		mockedDataStore := &storetest.StorerMock{
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
+				return nil, errs.ErrDatasetNotFound
+			},
+		}
+
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api.Router.ServeHTTP(w, r)
+
+		So(w.Code, ShouldEqual, http.StatusNotFound)
+		So(mockedDataStore.GetDatasetRevisionsCalls(), ShouldHaveLength, 0)
+	})
+
+	Convey("When the revisions cannot be read, return an internal server error", t, func() {
+		r := createRequestWithAuth("GET", "http://localhost:22000/datasets/123/revisions", nil)
+		w := httptest.NewRecorder()
+
+		mockedDataStore := &storetest.StorerMock{
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
+				return &models.DatasetUpdate{ID: "123"}, nil
+			},
+			GetDatasetRevisionsFunc: func(context.Context, string, int, int) ([]*models.DatasetRevision, int, error) {
+				return nil, 0, errors.New("mongo error")
+			},
+		}
+
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api.Router.ServeHTTP(w, r)
+
+		So(w.Code, ShouldEqual, http.StatusInternalServerError)
+	})
+}
+
+func TestGetDatasetRevision(t *testing.T) {
+	t.Parallel()
+
+	authorisationMock := &authMock.MiddlewareMock{
+		RequireFunc: func(permission string, handlerFunc http.HandlerFunc) http.HandlerFunc {
+			return handlerFunc
+		},
+		ParseFunc: func(token string) (*permissionsAPISDK.EntityData, error) {
+			return testEntityData, nil
+		},
+	}
+
+	newDataStore := func(revisionErr error) *storetest.StorerMock {
+		return &storetest.StorerMock{
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
+				return &models.DatasetUpdate{ID: "123"}, nil
+			},
+			GetDatasetRevisionFunc: func(_ context.Context, datasetID string, revision int) (*models.DatasetRevision, error) {
+				if revisionErr != nil {
+					return nil, revisionErr
+				}
+				return &models.DatasetRevision{DatasetID: datasetID, Revision: revision, Dataset: &models.Dataset{Title: "first title"}}, nil
+			},
+		}
+	}
+
+	Convey("A successful request to get a revision of a dataset returns 200 OK with the revision", t, func() {
+		r := createRequestWithAuth("GET", "http://localhost:22000/datasets/123/revisions/1", nil)
+		w := httptest.NewRecorder()
+
+		mockedDataStore := newDataStore(nil)
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api.Router.ServeHTTP(w, r)
+
+		So(w.Code, ShouldEqual, http.StatusOK)
+		So(mockedDataStore.GetDatasetRevisionCalls()[0].Revision, ShouldEqual, 1)
+
+		var revision models.DatasetRevision
+		So(json.Unmarshal(w.Body.Bytes(), &revision), ShouldBeNil)
+		So(revision.Revision, ShouldEqual, 1)
+		So(revision.Dataset.Title, ShouldEqual, "first title")
+	})
+
+	Convey("When the revision is not a positive number, return status bad request", t, func() {
+		for _, revision := range []string{"first", "0"} {
+			r := createRequestWithAuth("GET", "http://localhost:22000/datasets/123/revisions/"+revision, nil)
+			w := httptest.NewRecorder()
+
+			mockedDataStore := newDataStore(nil)
+			api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+			api.Router.ServeHTTP(w, r)
+
+			So(w.Code, ShouldEqual, http.StatusBadRequest)
+			So(mockedDataStore.GetDatasetRevisionCalls(), ShouldHaveLength, 0)
+		}
+	})
+
+	Convey("When the revision does not exist, return status not found", t, func() {
+		r := createRequestWithAuth("GET", "http://localhost:22000/datasets/123/revisions/7", nil)
+		w := httptest.NewRecorder()
+
+		api := GetAPIWithCMDMocks(newDataStore(errs.ErrRevisionNotFound), &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api.Router.ServeHTTP(w, r)
+
+		So(w.Code, ShouldEqual, http.StatusNotFound)
+		So(w.Body.String(), ShouldContainSubstring, errs.ErrRevisionNotFound.Error())
+	})
+}
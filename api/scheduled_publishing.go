@@ -0,0 +1,40 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/dp-dataset-api/scheduler"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// scheduledPublishingIdentity is recorded as the requester of the versions published at their release date
+const scheduledPublishingIdentity = "dp-dataset-api-scheduled-publishing"
+
+// PublishScheduledVersion publishes an approved static version at its release date, with the same side effects as a
+// request to publish it, on behalf of the service. The version is only published, and the side effects only made, if
+// it is still approved, so a version published by another instance of the service returns
+// apierrors.ErrExpectedResourceStateOfApproved.
+func (api *DatasetAPI) PublishScheduledVersion(ctx context.Context, datasetID, edition string, version int) error {
+	logData := log.Data{"dataset_id": datasetID, "edition": edition, "version": strconv.Itoa(version)}
+	requestedBy := models.RequestedBy{ID: scheduledPublishingIdentity}
+
+	return api.setVersionState(ctx, datasetID, edition, version, true, models.StateUpdate{State: models.PublishedState}, requestedBy, true, true, api.authToken, logData)
+}
+
+// getScheduledPublishes returns the approved static versions that will be published at their release date, soonest first
+func (api *DatasetAPI) getScheduledPublishes(w http.ResponseWriter, r *http.Request, limit, offset int) (interface{}, int, error) {
+	ctx := r.Context()
+	logData := log.Data{}
+
+	scheduled, totalCount, err := scheduler.ScheduledPublishes(ctx, api.dataStore.Backend, offset, limit)
+	if err != nil {
+		log.Error(ctx, "getScheduledPublishes endpoint: failed to get scheduled publishes", err, logData)
+		handleDatasetAPIErr(ctx, err, w, logData)
+		return nil, 0, err
+	}
+
+	return scheduled, totalCount, nil
+}
@@ -0,0 +1,144 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	applicationMocks "github.com/ONSdigital/dp-dataset-api/application/mock"
+	cloudflareMocks "github.com/ONSdigital/dp-dataset-api/cloudflare/mocks"
+	"github.com/ONSdigital/dp-dataset-api/mocks"
+	"github.com/ONSdigital/dp-dataset-api/models"
+	storetest "github.com/ONSdigital/dp-dataset-api/store/datastoretest"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGetScheduledPublishes(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given approved static versions with release dates", t, func() {
+		versions := []*models.Version{
+			{ID: "v1", Edition: "2024", Version: 1, ReleaseDate: "2025-01-01", Links: &models.VersionLinks{Dataset: &models.LinkObject{ID: "gdp"}}},
+			{ID: "v2", Edition: "2025", Version: 2, ReleaseDate: "2025-06-01T09:30:00.000Z", Links: &models.VersionLinks{Dataset: &models.LinkObject{ID: "cpih"}}},
+			{ID: "v3", Edition: "2024", Version: 3, ReleaseDate: "next week", Links: &models.VersionLinks{Dataset: &models.LinkObject{ID: "gdp"}}},
+		}
+		mockedDataStore := &storetest.StorerMock{
+			GetScheduledVersionsFunc: func(_ context.Context, offset, limit int) ([]*models.Version, int, error) {
+				start := min(offset, len(versions))
+				end := min(offset+limit, len(versions))
+				return versions[start:end], len(versions), nil
+			},
+		}
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, newStateMachineAuthorisationMock(), nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+
+		Convey("When the scheduled publishes are requested", func() {
+			r := createRequestWithAuth("GET", "http://localhost:22000/scheduled-publishes?limit=10", http.NoBody)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then the versions are returned in the order of their release date, with the reason the ones whose release date is not valid cannot be scheduled", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+
+				var page struct {
+					Items      []models.ScheduledPublish `json:"items"`
+					TotalCount int                       `json:"total_count"`
+				}
+				So(json.Unmarshal(w.Body.Bytes(), &page), ShouldBeNil)
+				So(page.TotalCount, ShouldEqual, 3)
+				So(page.Items, ShouldHaveLength, 3)
+				So(page.Items[0].VersionID, ShouldEqual, "v1")
+				So(page.Items[0].DatasetID, ShouldEqual, "gdp")
+				So(page.Items[0].ReleaseDate, ShouldNotBeNil)
+				So(page.Items[1].VersionID, ShouldEqual, "v2")
+				So(page.Items[1].Version, ShouldEqual, 2)
+				So(page.Items[2].VersionID, ShouldEqual, "v3")
+				So(page.Items[2].ReleaseDate, ShouldBeNil)
+				So(page.Items[2].Error, ShouldEqual, models.ErrReleaseDateInvalid.Error())
+			})
+		})
+
+		Convey("When a page of the scheduled publishes is requested", func() {
+			r := createRequestWithAuth("GET", "http://localhost:22000/scheduled-publishes?offset=1&limit=1", http.NoBody)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then only that page is read from the store and returned", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(mockedDataStore.GetScheduledVersionsCalls(), ShouldHaveLength, 1)
+				So(mockedDataStore.GetScheduledVersionsCalls()[0].Offset, ShouldEqual, 1)
+				So(mockedDataStore.GetScheduledVersionsCalls()[0].Limit, ShouldEqual, 1)
+
+				var page struct {
+					Items []models.ScheduledPublish `json:"items"`
+				}
+				So(json.Unmarshal(w.Body.Bytes(), &page), ShouldBeNil)
+				So(page.Items, ShouldHaveLength, 1)
+				So(page.Items[0].VersionID, ShouldEqual, "v2")
+			})
+		})
+	})
+}
+
+func TestPublishScheduledVersion(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given a scheduled version that no longer exists", t, func() {
+		mockedDataStore := &storetest.StorerMock{
+			GetVersionStaticFunc: func(context.Context, string, string, int, string) (*models.Version, error) {
+				return nil, errs.ErrVersionNotFound
+			},
+		}
+		auditServiceMock := &applicationMocks.AuditServiceMock{}
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, newStateMachineAuthorisationMock(), nil, &cloudflareMocks.ClienterMock{}, auditServiceMock)
+
+		Convey("When it is published", func() {
+			err := api.PublishScheduledVersion(context.Background(), "cpih", "2025", 1)
+
+			Convey("Then the error is returned and no audit event is recorded", func() {
+				So(err, ShouldEqual, errs.ErrVersionNotFound)
+				So(mockedDataStore.GetVersionStaticCalls()[0].DatasetID, ShouldEqual, "cpih")
+				So(auditServiceMock.RecordVersionAuditEventCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("Given a scheduled version that has already been published by another instance of the service", t, func() {
+		mockedDataStore := &storetest.StorerMock{
+			GetVersionStaticFunc: func(context.Context, string, string, int, string) (*models.Version, error) {
+				return &models.Version{
+					ID:          "v1",
+					Edition:     "2025",
+					Version:     1,
+					State:       models.PublishedState,
+					Type:        models.Static.String(),
+					ReleaseDate: "2025-01-01",
+					ETag:        "published-etag",
+					Links:       &models.VersionLinks{Dataset: &models.LinkObject{ID: "cpih"}},
+				}, nil
+			},
+			CheckEditionExistsStaticFunc: func(context.Context, string, string, string) error {
+				return nil
+			},
+			AcquireVersionsLockFunc: func(context.Context, string) (string, error) {
+				return "lock-id", nil
+			},
+			UnlockVersionsFunc: func(context.Context, string) {},
+		}
+		auditServiceMock := &applicationMocks.AuditServiceMock{}
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, newStateMachineAuthorisationMock(), nil, &cloudflareMocks.ClienterMock{}, auditServiceMock)
+
+		Convey("When it is published", func() {
+			err := api.PublishScheduledVersion(context.Background(), "cpih", "2025", 1)
+
+			Convey("Then it is not published again and the side effects of publishing it are not repeated", func() {
+				So(err, ShouldEqual, errs.ErrExpectedResourceStateOfApproved)
+				So(mockedDataStore.UpdateVersionStaticCalls(), ShouldBeEmpty)
+				So(auditServiceMock.RecordVersionAuditEventCalls(), ShouldBeEmpty)
+				So(mockedDataStore.UnlockVersionsCalls(), ShouldHaveLength, 1)
+			})
+		})
+	})
+}
@@ -0,0 +1,179 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	"github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/log.go/v2/log"
+	"github.com/gorilla/mux"
+)
+
+// The formats that the state machine can be returned in, selected by the format query parameter
+const (
+	stateMachineFormatJSON    = "json"
+	stateMachineFormatMermaid = "mermaid"
+	stateMachineFormatDOT     = "dot"
+)
+
+// getStateMachine returns the transitions of the state machine for every type of dataset
+func (api *DatasetAPI) getStateMachine(w http.ResponseWriter, r *http.Request) (*models.SuccessResponse, *models.ErrorResponse) {
+	ctx := r.Context()
+	logData := log.Data{"format": r.URL.Query().Get("format")}
+
+	workflows := api.smDatasetAPI.StateMachine.Workflows()
+
+	response, errResponse := stateMachineResponse(r, workflows, models.StateMachineWorkflows{Workflows: workflows})
+	if errResponse != nil {
+		log.Error(ctx, "getStateMachine endpoint: failed to render the state machine", errResponse.Errors[0], logData)
+		return nil, errResponse
+	}
+
+	log.Info(ctx, "getStateMachine endpoint: request successful", logData)
+	return response, nil
+}
+
+// getStateMachineWorkflow returns the transitions of the state machine for a type of dataset
+func (api *DatasetAPI) getStateMachineWorkflow(w http.ResponseWriter, r *http.Request) (*models.SuccessResponse, *models.ErrorResponse) {
+	ctx := r.Context()
+	datasetType := mux.Vars(r)["dataset_type"]
+	logData := log.Data{"dataset_type": datasetType, "format": r.URL.Query().Get("format")}
+
+	workflow, ok := api.smDatasetAPI.StateMachine.Workflow(datasetType)
+	if !ok {
+		log.Error(ctx, "getStateMachineWorkflow endpoint: no workflow for dataset type", errs.ErrWorkflowNotFound, logData)
+		return nil, models.NewErrorResponse(http.StatusNotFound, nil, models.NewError(errs.ErrWorkflowNotFound, errs.ErrWorkflowNotFound.Error(), "workflow not found"))
+	}
+
+	response, errResponse := stateMachineResponse(r, []models.StateMachineWorkflow{*workflow}, workflow)
+	if errResponse != nil {
+		log.Error(ctx, "getStateMachineWorkflow endpoint: failed to render the workflow", errResponse.Errors[0], logData)
+		return nil, errResponse
+	}
+
+	log.Info(ctx, "getStateMachineWorkflow endpoint: request successful", logData)
+	return response, nil
+}
+
+// getAllowedTransitions returns the states that a version can be moved to from its current state
+func (api *DatasetAPI) getAllowedTransitions(w http.ResponseWriter, r *http.Request) (*models.SuccessResponse, *models.ErrorResponse) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	datasetID := vars["dataset_id"]
+	edition := vars["edition"]
+	logData := log.Data{"dataset_id": datasetID, "edition": edition, "version": vars["version"]}
+
+	version, err := func() (*models.Version, error) {
+		versionNumber, err := models.ParseAndValidateVersionNumber(ctx, vars["version"])
+		if err != nil {
+			return nil, err
+		}
+
+		dataset, err := api.dataStore.Backend.GetDataset(ctx, datasetID)
+		if err != nil {
+			log.Error(ctx, "getAllowedTransitions endpoint: failed to retrieve dataset details", err, logData)
+			return nil, err
+		}
+
+		if dataset.Next.Type == models.Static.String() {
+			return api.dataStore.Backend.GetVersionStatic(ctx, datasetID, edition, versionNumber, "")
+		}
+		return api.dataStore.Backend.GetVersion(ctx, datasetID, edition, versionNumber, "")
+	}()
+	if err != nil {
+		log.Error(ctx, "getAllowedTransitions endpoint: failed to get version", err, logData)
+		return nil, models.NewErrorResponse(getVersionAPIErrStatusCode(err), nil, models.NewError(err, err.Error(), "internal error"))
+	}
+
+	// versions without a type are moved through the v4 workflow, see application.StateMachine.Transition
+	datasetType := version.Type
+	if datasetType == "" {
+		datasetType = "v4"
+	}
+
+	allowed := models.AllowedTransitions{
+		Type:              datasetType,
+		State:             version.State,
+		AllowedNextStates: api.smDatasetAPI.StateMachine.AllowedTransitions(datasetType, version.State),
+	}
+
+	b, err := json.Marshal(allowed)
+	if err != nil {
+		log.Error(ctx, "getAllowedTransitions endpoint: failed to marshal allowed transitions into bytes", err, logData)
+		return nil, models.NewErrorResponse(http.StatusInternalServerError, nil, models.NewError(err, models.JSONMarshalError, models.ErrorMarshalFailedDescription))
+	}
+
+	log.Info(ctx, "getAllowedTransitions endpoint: request successful", logData)
+	return models.NewSuccessResponse(b, http.StatusOK, nil), nil
+}
+
+// stateMachineResponse renders the workflows in the format requested by the format query parameter. The JSON format,
+// which is the default, is the marshalled body.
+func stateMachineResponse(r *http.Request, workflows []models.StateMachineWorkflow, body interface{}) (*models.SuccessResponse, *models.ErrorResponse) {
+	switch r.URL.Query().Get("format") {
+	case "", stateMachineFormatJSON:
+		b, err := json.Marshal(body)
+		if err != nil {
+			return nil, models.NewErrorResponse(http.StatusInternalServerError, nil, models.NewError(err, models.JSONMarshalError, models.ErrorMarshalFailedDescription))
+		}
+		return models.NewSuccessResponse(b, http.StatusOK, nil), nil
+	case stateMachineFormatMermaid:
+		return models.NewSuccessResponse([]byte(models.RenderMermaid(workflows)), http.StatusOK, map[string]string{"Content-Type": "text/vnd.mermaid; charset=utf-8"}), nil
+	case stateMachineFormatDOT:
+		return models.NewSuccessResponse([]byte(models.RenderDOT(workflows)), http.StatusOK, map[string]string{"Content-Type": "text/vnd.graphviz; charset=utf-8"}), nil
+	default:
+		return nil, models.NewErrorResponse(http.StatusBadRequest, nil, models.NewError(errs.ErrInvalidQueryParameter, errs.ErrInvalidQueryParameter.Error(), "invalid format, expected json, mermaid or dot"))
+	}
+}
+
+// getVersionStateHistory returns the current state of a version, with every change of state made to it by the state machine
+func (api *DatasetAPI) getVersionStateHistory(w http.ResponseWriter, r *http.Request) (*models.SuccessResponse, *models.ErrorResponse) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	datasetID := vars["dataset_id"]
+	edition := vars["edition"]
+	logData := log.Data{"dataset_id": datasetID, "edition": edition, "version": vars["version"]}
+
+	version, err := func() (*models.Version, error) {
+		versionNumber, err := models.ParseAndValidateVersionNumber(ctx, vars["version"])
+		if err != nil {
+			return nil, err
+		}
+
+		dataset, err := api.dataStore.Backend.GetDataset(ctx, datasetID)
+		if err != nil {
+			log.Error(ctx, "getVersionStateHistory endpoint: failed to retrieve dataset details", err, logData)
+			return nil, err
+		}
+
+		if dataset.Next.Type == models.Static.String() {
+			return api.dataStore.Backend.GetVersionStatic(ctx, datasetID, edition, versionNumber, "")
+		}
+		return api.dataStore.Backend.GetVersion(ctx, datasetID, edition, versionNumber, "")
+	}()
+	if err != nil {
+		log.Error(ctx, "getVersionStateHistory endpoint: failed to get version", err, logData)
+		return nil, models.NewErrorResponse(getVersionAPIErrStatusCode(err), nil, models.NewError(err, err.Error(), "internal error"))
+	}
+
+	history := models.VersionStateHistory{
+		DatasetID:    datasetID,
+		Edition:      edition,
+		Version:      version.Version,
+		State:        version.State,
+		StateHistory: version.StateHistory,
+	}
+	if history.StateHistory == nil {
+		history.StateHistory = []models.StateChange{}
+	}
+
+	b, err := json.Marshal(history)
+	if err != nil {
+		log.Error(ctx, "getVersionStateHistory endpoint: failed to marshal state history into bytes", err, logData)
+		return nil, models.NewErrorResponse(http.StatusInternalServerError, nil, models.NewError(err, models.JSONMarshalError, models.ErrorMarshalFailedDescription))
+	}
+
+	log.Info(ctx, "getVersionStateHistory endpoint: request successful", logData)
+	return models.NewSuccessResponse(b, http.StatusOK, nil), nil
+}
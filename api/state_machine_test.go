@@ -0,0 +1,266 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-authorisation/v2/authorisation"
+	authMock "github.com/ONSdigital/dp-authorisation/v2/authorisation/mock"
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	applicationMocks "github.com/ONSdigital/dp-dataset-api/application/mock"
+	cloudflareMocks "github.com/ONSdigital/dp-dataset-api/cloudflare/mocks"
+	"github.com/ONSdigital/dp-dataset-api/mocks"
+	"github.com/ONSdigital/dp-dataset-api/models"
+	storetest "github.com/ONSdigital/dp-dataset-api/store/datastoretest"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func newStateMachineAuthorisationMock() *authMock.MiddlewareMock {
+	return &authMock.MiddlewareMock{
+		RequireFunc: func(permission string, handlerFunc http.HandlerFunc) http.HandlerFunc {
+			return handlerFunc
+		},
+		RequireWithAttributesFunc: func(permission string, handlerFunc http.HandlerFunc, getAttributes authorisation.GetAttributesFromRequest) http.HandlerFunc {
+			return handlerFunc
+		},
+	}
+}
+
+func TestGetStateMachine(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given the dataset API with a state machine", t, func() {
+		api := GetAPIWithCMDMocks(&storetest.StorerMock{}, &mocks.DownloadsGeneratorMock{}, newStateMachineAuthorisationMock(), nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+
+		Convey("When the state machine is requested", func() {
+			r := createRequestWithAuth("GET", "http://localhost:22000/state-machine", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then the workflow of every type of dataset is returned as JSON", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Header().Get("Content-Type"), ShouldEqual, "application/json")
+
+				var stateMachine models.StateMachineWorkflows
+				So(json.Unmarshal(w.Body.Bytes(), &stateMachine), ShouldBeNil)
+				var types []string
+				for _, workflow := range stateMachine.Workflows {
+					types = append(types, workflow.Type)
+				}
+				So(types, ShouldResemble, []string{"cantabular_flexible_table", "filterable", "static", "v4"})
+			})
+		})
+
+		Convey("When the workflow of a type of dataset is requested", func() {
+			r := createRequestWithAuth("GET", "http://localhost:22000/state-machine/cantabular_flexible_table", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then its transitions are returned, ordered by state", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+
+				var workflow models.StateMachineWorkflow
+				So(json.Unmarshal(w.Body.Bytes(), &workflow), ShouldBeNil)
+				So(workflow.Type, ShouldEqual, "cantabular_flexible_table")
+				So(workflow.Transitions, ShouldHaveLength, 3)
+				So(workflow.Transitions[0].State, ShouldEqual, "associated")
+				So(workflow.Transitions[0].AllowedSourceStates, ShouldResemble, []string{"edition-confirmed", "associated"})
+			})
+		})
+
+		Convey("When the workflow of a type of dataset is requested in the Mermaid format", func() {
+			r := createRequestWithAuth("GET", "http://localhost:22000/state-machine/cantabular_flexible_table?format=mermaid", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then a Mermaid state diagram is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Header().Get("Content-Type"), ShouldEqual, "text/vnd.mermaid; charset=utf-8")
+				So(w.Body.String(), ShouldStartWith, "stateDiagram-v2\n")
+				So(w.Body.String(), ShouldContainSubstring, "cantabular_flexible_table_edition_confirmed --> cantabular_flexible_table_associated")
+			})
+		})
+
+		Convey("When the state machine is requested in the DOT format", func() {
+			r := createRequestWithAuth("GET", "http://localhost:22000/state-machine?format=dot", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then a Graphviz graph is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Header().Get("Content-Type"), ShouldEqual, "text/vnd.graphviz; charset=utf-8")
+				So(w.Body.String(), ShouldStartWith, "digraph state_machine {\n")
+				So(w.Body.String(), ShouldContainSubstring, `"static/created" -> "static/associated";`)
+			})
+		})
+
+		Convey("When the state machine is requested in an unknown format", func() {
+			r := createRequestWithAuth("GET", "http://localhost:22000/state-machine?format=svg", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then a bad request status is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+				So(w.Body.String(), ShouldContainSubstring, errs.ErrInvalidQueryParameter.Error())
+			})
+		})
+
+		Convey("When the workflow of a type of dataset without one is requested", func() {
+			r := createRequestWithAuth("GET", "http://localhost:22000/state-machine/cantabular_blob", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then a not found status is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusNotFound)
+				So(w.Body.String(), ShouldContainSubstring, errs.ErrWorkflowNotFound.Error())
+			})
+		})
+	})
+}
+
+func TestGetAllowedTransitions(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given a cantabular version that has been edition-confirmed", t, func() {
+		mockedDataStore := &storetest.StorerMock{
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
+				return &models.DatasetUpdate{ID: "census", Next: &models.Dataset{Type: models.CantabularFlexibleTable.String()}}, nil
+			},
+			GetVersionFunc: func(context.Context, string, string, int, string) (*models.Version, error) {
+				return &models.Version{Version: 1, State: models.EditionConfirmedState, Type: models.CantabularFlexibleTable.String()}, nil
+			},
+		}
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, newStateMachineAuthorisationMock(), nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+
+		Convey("When its allowed transitions are requested", func() {
+			r := createRequestWithAuth("GET", "http://localhost:22000/datasets/census/editions/2021/versions/1/allowed-transitions", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then the states that it can be moved to are returned", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+
+				var allowed models.AllowedTransitions
+				So(json.Unmarshal(w.Body.Bytes(), &allowed), ShouldBeNil)
+				So(allowed, ShouldResemble, models.AllowedTransitions{
+					Type:              "cantabular_flexible_table",
+					State:             models.EditionConfirmedState,
+					AllowedNextStates: []string{"associated", "edition-confirmed", "published"},
+				})
+			})
+		})
+	})
+
+	Convey("Given a version that does not exist", t, func() {
+		mockedDataStore := &storetest.StorerMock{
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
+				return &models.DatasetUpdate{ID: "cpih01", Next: &models.Dataset{Type: models.Filterable.String()}}, nil
+			},
+			GetVersionFunc: func(context.Context, string, string, int, string) (*models.Version, error) {
+				return nil, errs.ErrVersionNotFound
+			},
+		}
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, newStateMachineAuthorisationMock(), nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+
+		Convey("When its allowed transitions are requested", func() {
+			r := createRequestWithAuth("GET", "http://localhost:22000/datasets/cpih01/editions/2021/versions/9/allowed-transitions", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then a not found status is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusNotFound)
+				So(w.Body.String(), ShouldContainSubstring, errs.ErrVersionNotFound.Error())
+			})
+		})
+	})
+}
+
+func TestGetVersionStateHistory(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given a static version that has been approved", t, func() {
+		changedAt := time.Date(2025, 1, 10, 14, 0, 0, 0, time.UTC)
+		stateHistory := []models.StateChange{
+			{From: models.CreatedState, To: models.AssociatedState, ChangedBy: "publisher@ons.gov.uk", ChangedAt: changedAt},
+			{From: models.AssociatedState, To: models.ApprovedState, ChangedBy: "reviewer@ons.gov.uk", ChangedAt: changedAt.Add(time.Hour), Comment: "looks good"},
+		}
+		mockedDataStore := &storetest.StorerMock{
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
+				return &models.DatasetUpdate{ID: "cpih01", Next: &models.Dataset{Type: models.Static.String()}}, nil
+			},
+			GetVersionStaticFunc: func(context.Context, string, string, int, string) (*models.Version, error) {
+				return &models.Version{Version: 1, State: models.ApprovedState, Type: models.Static.String(), StateHistory: stateHistory}, nil
+			},
+		}
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, newStateMachineAuthorisationMock(), nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+
+		Convey("When its state history is requested", func() {
+			r := createRequestWithAuth("GET", "http://localhost:22000/datasets/cpih01/editions/2025/versions/1/history", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then every change of state is returned, oldest first", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+
+				var history models.VersionStateHistory
+				So(json.Unmarshal(w.Body.Bytes(), &history), ShouldBeNil)
+				So(history, ShouldResemble, models.VersionStateHistory{
+					DatasetID:    "cpih01",
+					Edition:      "2025",
+					Version:      1,
+					State:        models.ApprovedState,
+					StateHistory: stateHistory,
+				})
+			})
+		})
+	})
+
+	Convey("Given a v4 version without a state history", t, func() {
+		mockedDataStore := &storetest.StorerMock{
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
+				return &models.DatasetUpdate{ID: "cpih01", Next: &models.Dataset{Type: models.Filterable.String()}}, nil
+			},
+			GetVersionFunc: func(context.Context, string, string, int, string) (*models.Version, error) {
+				return &models.Version{Version: 2, State: models.PublishedState}, nil
+			},
+		}
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, newStateMachineAuthorisationMock(), nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+
+		Convey("When its state history is requested", func() {
+			r := createRequestWithAuth("GET", "http://localhost:22000/datasets/cpih01/editions/time-series/versions/2/history", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then an empty state history is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Body.String(), ShouldContainSubstring, `"state_history":[]`)
+			})
+		})
+	})
+
+	Convey("Given a version that does not exist", t, func() {
+		mockedDataStore := &storetest.StorerMock{
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
+				return &models.DatasetUpdate{ID: "cpih01", Next: &models.Dataset{Type: models.Static.String()}}, nil
+			},
+			GetVersionStaticFunc: func(context.Context, string, string, int, string) (*models.Version, error) {
+				return nil, errs.ErrVersionNotFound
+			},
+		}
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, newStateMachineAuthorisationMock(), nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+
+		Convey("When its state history is requested", func() {
+			r := createRequestWithAuth("GET", "http://localhost:22000/datasets/cpih01/editions/2025/versions/9/history", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then a not found status is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusNotFound)
+			})
+		})
+	})
+}
@@ -203,7 +203,6 @@ func (api *DatasetAPI) addDatasetVersionCondensed(w http.ResponseWriter, r *http
 		return nil, models.NewErrorResponse(http.StatusInternalServerError, nil, models.NewError(err, "failed to check latest version", "internal error"))
 	}
 
-	var nextVersion int
 	versionRequest.Edition = edition
 
 	if errors.Is(err, errs.ErrVersionNotFound) {
@@ -228,9 +227,6 @@ func (api *DatasetAPI) addDatasetVersionCondensed(w http.ResponseWriter, r *http
 			log.Error(ctx, "failed to check edition title existence", checkErr, logData)
 			return nil, models.NewErrorResponse(http.StatusInternalServerError, nil, models.NewError(checkErr, "failed to check edition title", "internal error"))
 		}
-		nextVersion = 1
-	} else {
-		nextVersion = latestVersion.Version + 1
 	}
 
 	if err == nil && latestVersion.State != models.PublishedState {
@@ -240,6 +236,13 @@ func (api *DatasetAPI) addDatasetVersionCondensed(w http.ResponseWriter, r *http
 		return nil, models.NewErrorResponse(http.StatusBadRequest, nil, models.NewError(err, models.ErrVersionAlreadyExists, models.ErrUnpublishedVersionAlreadyExistsDescription+" - unpublished_version: "+strconv.Itoa(latestVersion.Version)))
 	}
 
+	// deleted versions are counted, so that a new version does not take the number of a version that can be restored
+	nextVersion, err := api.dataStore.Backend.GetNextVersionStatic(ctx, datasetID, edition)
+	if err != nil {
+		log.Error(ctx, "failed to get the next version number", err, logData)
+		return nil, models.NewErrorResponse(http.StatusInternalServerError, nil, models.NewError(err, "failed to get the next version number", "internal error"))
+	}
+
 	versionRequest.State = models.AssociatedState
 	versionRequest.Version = nextVersion
 	versionRequest.DatasetID = datasetID
@@ -247,7 +250,7 @@ func (api *DatasetAPI) addDatasetVersionCondensed(w http.ResponseWriter, r *http
 	versionRequest.Type = models.Static.String()
 
 	// ID and Email are the same as auth middleware can only provide userID
-	if err := api.auditService.RecordVersionAuditEvent(ctx, models.RequestedBy{ID: authEntityData.EntityData.UserID, Email: authEntityData.EntityData.UserID}, models.ActionCreate, "/datasets/"+datasetID+"/editions/"+edition+"/versions/"+strconv.Itoa(nextVersion), versionRequest); err != nil {
+	if err := api.auditService.RecordVersionAuditEvent(ctx, models.RequestedBy{ID: authEntityData.EntityData.UserID, Email: authEntityData.EntityData.UserID}, models.ActionCreate, "/datasets/"+datasetID+"/editions/"+edition+"/versions/"+strconv.Itoa(nextVersion), nil, versionRequest); err != nil {
 		log.Info(ctx, "failed to create version audit event", log.Classification(log.ProtectiveMonitoring), logAuthOption, log.Data{
 			"action":   models.ActionCreate,
 			"endpoint": "/datasets/" + datasetID + "/editions/" + edition + "/versions/" + strconv.Itoa(nextVersion),
@@ -437,7 +440,7 @@ func (api *DatasetAPI) createVersion(w http.ResponseWriter, r *http.Request) (*m
 	}
 
 	// ID and Email are the same as auth middleware can only provide userID
-	if err := api.auditService.RecordVersionAuditEvent(ctx, models.RequestedBy{ID: authEntityData.EntityData.UserID, Email: authEntityData.EntityData.UserID}, models.ActionCreate, "/datasets/"+datasetID+"/editions/"+edition+"/versions/"+strconv.Itoa(versionNumber), newVersion); err != nil {
+	if err := api.auditService.RecordVersionAuditEvent(ctx, models.RequestedBy{ID: authEntityData.EntityData.UserID, Email: authEntityData.EntityData.UserID}, models.ActionCreate, "/datasets/"+datasetID+"/editions/"+edition+"/versions/"+strconv.Itoa(versionNumber), nil, newVersion); err != nil {
 		log.Info(ctx, "failed to create version audit event", log.Classification(log.ProtectiveMonitoring), logAuthOption, log.Data{
 			"action":   models.ActionCreate,
 			"endpoint": "/datasets/" + datasetID + "/editions/" + edition + "/versions/" + strconv.Itoa(versionNumber),
@@ -37,7 +37,7 @@ func TestGetDatasetEditionsForbidden(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 
 		Convey("When getDatasetEditions is called", func() {
 			api.Router.ServeHTTP(w, r)
@@ -67,7 +67,7 @@ func TestGetDatasetEditionsUnauthorised(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 
 		Convey("When getDatasetEditions is called", func() {
 			api.Router.ServeHTTP(w, r)
@@ -138,7 +138,7 @@ func TestGetDatasetEditions_WithQueryParam_Success(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 
 		Convey("When getDatasetEditions is called", func() {
 			results, totalCount, err := api.getDatasetEditions(w, r, 20, 0)
@@ -221,7 +221,7 @@ func TestGetDatasetEditions_WithPublishedParam(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 
 		Convey("When getDatasetEditions is called", func() {
 			results, totalCount, err := api.getDatasetEditions(w, r, 20, 0)
@@ -266,7 +266,7 @@ func TestGetDatasetEditions_WithPublishedParam(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 
 		Convey("When getDatasetEditions is called", func() {
 			results, totalCount, err := api.getDatasetEditions(w, r, 20, 0)
@@ -292,7 +292,7 @@ func TestGetDatasetEditions_WithPublishedParam(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 
 		Convey("When getDatasetEditions is called", func() {
 			results, totalCount, err := api.getDatasetEditions(w, r, 20, 0)
@@ -380,7 +380,7 @@ func TestGetDatasetEditions_WithoutQueryParam_Success(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 
 		Convey("When getDatasetEditions is called", func() {
 			results, totalCount, err := api.getDatasetEditions(w, r, 20, 0)
@@ -454,7 +454,7 @@ func TestGetDatasetEditions_InvalidQueryParam_Failure(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 
 		Convey("When getDatasetEditions is called", func() {
 			results, totalCount, err := api.getDatasetEditions(w, r, 20, 0)
@@ -487,7 +487,7 @@ func TestGetDatasetEditions_GetStaticVersionsByState_Failure(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 
 		Convey("When getDatasetEditions is called and no versions are found", func() {
 			r := createRequestWithAuth("GET", "http://localhost:22000/dataset-editions?state=associated", http.NoBody)
@@ -540,7 +540,7 @@ func TestGetDatasetEditions_GetDataset_Failure(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 
 		Convey("When getDatasetEditions is called and the dataset is not found", func() {
 			r := createRequestWithAuth("GET", "http://localhost:22000/dataset-editions", http.NoBody)
@@ -577,7 +577,7 @@ func TestGetDatasetEditions_GetDataset_Failure(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 
 		Convey("When getDatasetEditions is called and the datastore fails", func() {
 			r := createRequestWithAuth("GET", "http://localhost:22000/dataset-editions", http.NoBody)
@@ -640,6 +640,9 @@ func TestAddDatasetVersionCondensed_Success(t *testing.T) {
 					State: models.PublishedState,
 				}, nil
 			},
+			GetNextVersionStaticFunc: func(context.Context, string, string) (int, error) {
+				return 2, nil
+			},
 			AddVersionStaticFunc: func(context.Context, *models.Version) (*models.Version, error) {
 				return &models.Version{Edition: "time-series", Type: models.Static.String()}, nil
 			},
@@ -661,12 +664,12 @@ func TestAddDatasetVersionCondensed_Success(t *testing.T) {
 		}
 
 		auditServiceMock := &applicationMocks.AuditServiceMock{
-			RecordVersionAuditEventFunc: func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, version *models.Version) error {
+			RecordVersionAuditEventFunc: func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, previous, version *models.Version) error {
 				return nil
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, auditServiceMock)
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, auditServiceMock)
 		successResponse, errorResponse := api.addDatasetVersionCondensed(w, r)
 		So(successResponse.Status, ShouldEqual, http.StatusCreated)
 		So(errorResponse, ShouldBeNil)
@@ -735,6 +738,9 @@ func TestAddDatasetVersionCondensed_Success(t *testing.T) {
 					State: models.PublishedState,
 				}, nil
 			},
+			GetNextVersionStaticFunc: func(context.Context, string, string) (int, error) {
+				return 2, nil
+			},
 			AddVersionStaticFunc: func(context.Context, *models.Version) (*models.Version, error) {
 				return &models.Version{Version: 2}, nil
 			},
@@ -756,12 +762,12 @@ func TestAddDatasetVersionCondensed_Success(t *testing.T) {
 		}
 
 		auditServiceMock := &applicationMocks.AuditServiceMock{
-			RecordVersionAuditEventFunc: func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, version *models.Version) error {
+			RecordVersionAuditEventFunc: func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, previous, version *models.Version) error {
 				return nil
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, auditServiceMock)
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, auditServiceMock)
 		successResponse, errorResponse := api.addDatasetVersionCondensed(w, r)
 		So(successResponse.Status, ShouldEqual, http.StatusCreated)
 		So(errorResponse, ShouldBeNil)
@@ -778,6 +784,11 @@ func TestAddDatasetVersionCondensed_Success(t *testing.T) {
 		err := json.Unmarshal(successResponse.Body, &response)
 		So(err, ShouldBeNil)
 		So(response.Version, ShouldEqual, 2)
+
+		Convey("Then the number of the version is the next number of the edition, counting deleted versions", func() {
+			So(mockedDataStore.GetNextVersionStaticCalls(), ShouldHaveLength, 1)
+			So(mockedDataStore.AddVersionStaticCalls()[0].Version.Version, ShouldEqual, 2)
+		})
 	})
 
 	Convey("When distribution format is valid, media_type is populated", t, func() {
@@ -800,6 +811,9 @@ func TestAddDatasetVersionCondensed_Success(t *testing.T) {
 			GetLatestVersionStaticFunc: func(context.Context, string, string, string) (*models.Version, error) {
 				return &models.Version{State: models.PublishedState}, nil
 			},
+			GetNextVersionStaticFunc: func(context.Context, string, string) (int, error) {
+				return 2, nil
+			},
 			AddVersionStaticFunc: func(context.Context, *models.Version) (*models.Version, error) {
 				return &models.Version{
 					Edition: "time-series",
@@ -831,13 +845,13 @@ func TestAddDatasetVersionCondensed_Success(t *testing.T) {
 		}
 
 		auditServiceMock := &applicationMocks.AuditServiceMock{
-			RecordVersionAuditEventFunc: func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, version *models.Version) error {
+			RecordVersionAuditEventFunc: func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, previous, version *models.Version) error {
 				return nil
 			},
 		}
 
 		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{},
-			authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, auditServiceMock)
+			authorisationMock, nil, &cloudflareMocks.ClienterMock{}, auditServiceMock)
 
 		success, failure := api.addDatasetVersionCondensed(w, r)
 		So(failure, ShouldBeNil)
@@ -874,7 +888,7 @@ func TestAddDatasetVersionCondensed_Failure(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(&storetest.StorerMock{}, &mocks.DownloadsGeneratorMock{}, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(&storetest.StorerMock{}, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 		successResponse, errorResponse := api.addDatasetVersionCondensed(w, r)
 
 		So(successResponse, ShouldBeNil)
@@ -909,6 +923,9 @@ func TestAddDatasetVersionCondensed_Failure(t *testing.T) {
 					State:   models.AssociatedState,
 				}, nil
 			},
+			GetNextVersionStaticFunc: func(context.Context, string, string) (int, error) {
+				return 2, nil
+			},
 		}
 
 		authorisationMock := &authMock.MiddlewareMock{
@@ -920,7 +937,7 @@ func TestAddDatasetVersionCondensed_Failure(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 		successResponse, errorResponse := api.addDatasetVersionCondensed(w, r)
 
 		castErr := errorResponse.Errors[0]
@@ -954,6 +971,9 @@ func TestAddDatasetVersionCondensed_Failure(t *testing.T) {
 			GetLatestVersionStaticFunc: func(context.Context, string, string, string) (*models.Version, error) {
 				return nil, errs.ErrInternalServer
 			},
+			GetNextVersionStaticFunc: func(context.Context, string, string) (int, error) {
+				return 2, nil
+			},
 		}
 
 		authorisationMock := &authMock.MiddlewareMock{
@@ -965,7 +985,7 @@ func TestAddDatasetVersionCondensed_Failure(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 		successResponse, errorResponse := api.addDatasetVersionCondensed(w, r)
 
 		So(errorResponse.Status, ShouldEqual, http.StatusInternalServerError)
@@ -1061,7 +1081,7 @@ func TestAddDatasetVersionCondensed_Failure(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 		successResponse, errorResponse := api.addDatasetVersionCondensed(w, r)
 
 		So(errorResponse.Status, ShouldEqual, http.StatusNotFound)
@@ -1092,6 +1112,9 @@ func TestAddDatasetVersionCondensed_Failure(t *testing.T) {
 			GetLatestVersionStaticFunc: func(ctx context.Context, datasetID, editionID, state string) (*models.Version, error) {
 				return nil, errs.ErrVersionNotFound
 			},
+			GetNextVersionStaticFunc: func(context.Context, string, string) (int, error) {
+				return 2, nil
+			},
 			CheckEditionExistsStaticFunc: func(ctx context.Context, datasetID, editionID, state string) error {
 				return errs.ErrEditionNotFound
 			},
@@ -1109,7 +1132,7 @@ func TestAddDatasetVersionCondensed_Failure(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 		successResponse, errorResponse := api.addDatasetVersionCondensed(w, r)
 
 		So(errorResponse.Status, ShouldEqual, http.StatusConflict)
@@ -1140,7 +1163,7 @@ func TestAddDatasetVersionCondensed_Failure(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(&storetest.StorerMock{}, &mocks.DownloadsGeneratorMock{}, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(&storetest.StorerMock{}, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 		successResponse, errorResponse := api.addDatasetVersionCondensed(w, r)
 
 		So(errorResponse.Status, ShouldEqual, http.StatusBadRequest)
@@ -1196,7 +1219,7 @@ func TestAddDatasetVersionCondensed_Failure(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(&storetest.StorerMock{}, &mocks.DownloadsGeneratorMock{}, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(&storetest.StorerMock{}, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 		successResponse, errorResponse := api.addDatasetVersionCondensed(w, r)
 
 		So(errorResponse.Status, ShouldEqual, http.StatusBadRequest)
@@ -1240,7 +1263,7 @@ func TestAddDatasetVersionCondensed_Failure(t *testing.T) {
 			},
 			&mocks.DownloadsGeneratorMock{},
 			authorisationMock,
-			SearchContentUpdatedProducer{},
+			nil,
 			&cloudflareMocks.ClienterMock{},
 			&applicationMocks.AuditServiceMock{},
 		)
@@ -1287,7 +1310,7 @@ func TestAddDatasetVersionCondensed_Failure(t *testing.T) {
 			},
 			&mocks.DownloadsGeneratorMock{},
 			authorisationMock,
-			SearchContentUpdatedProducer{},
+			nil,
 			&cloudflareMocks.ClienterMock{},
 			&applicationMocks.AuditServiceMock{},
 		)
@@ -1315,7 +1338,7 @@ func TestAddDatasetVersionCondensed_Failure(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(&storetest.StorerMock{}, &mocks.DownloadsGeneratorMock{}, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(&storetest.StorerMock{}, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 		api.Router.ServeHTTP(w, r)
 
 		So(w.Code, ShouldEqual, http.StatusBadRequest)
@@ -1358,7 +1381,7 @@ func TestAddDatasetVersionCondensed_Failure(t *testing.T) {
 			&storetest.StorerMock{},
 			&mocks.DownloadsGeneratorMock{},
 			authorisationMock,
-			SearchContentUpdatedProducer{},
+			nil,
 			&cloudflareMocks.ClienterMock{},
 			&applicationMocks.AuditServiceMock{},
 		)
@@ -1408,7 +1431,7 @@ func TestAddDatasetVersionCondensed_Failure(t *testing.T) {
 			&storetest.StorerMock{},
 			&mocks.DownloadsGeneratorMock{},
 			authorisationMock,
-			SearchContentUpdatedProducer{},
+			nil,
 			&cloudflareMocks.ClienterMock{},
 			&applicationMocks.AuditServiceMock{},
 		)
@@ -1442,6 +1465,9 @@ func TestAddDatasetVersionCondensed_Failure(t *testing.T) {
 					State: models.PublishedState,
 				}, nil
 			},
+			GetNextVersionStaticFunc: func(context.Context, string, string) (int, error) {
+				return 2, nil
+			},
 		}
 
 		authorisationMock := &authMock.MiddlewareMock{
@@ -1454,12 +1480,12 @@ func TestAddDatasetVersionCondensed_Failure(t *testing.T) {
 		}
 
 		auditServiceMock := &applicationMocks.AuditServiceMock{
-			RecordVersionAuditEventFunc: func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, version *models.Version) error {
+			RecordVersionAuditEventFunc: func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, previous, version *models.Version) error {
 				return errors.New("audit service failed")
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, auditServiceMock)
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, auditServiceMock)
 		successResponse, errorResponse := api.addDatasetVersionCondensed(w, r)
 
 		So(successResponse, ShouldBeNil)
@@ -1607,7 +1633,7 @@ func TestCreateVersion_Success(t *testing.T) {
 	}
 
 	auditServiceMock := &applicationMocks.AuditServiceMock{
-		RecordVersionAuditEventFunc: func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, version *models.Version) error {
+		RecordVersionAuditEventFunc: func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, previous, version *models.Version) error {
 			return nil
 		},
 	}
@@ -1619,7 +1645,7 @@ func TestCreateVersion_Success(t *testing.T) {
 		returnedVersionJSON, err := json.Marshal(expectedVersion)
 		So(err, ShouldBeNil)
 
-		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, auditServiceMock)
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, auditServiceMock)
 		r := createRequestWithAuth("POST", "http://localhost:22000/datasets/123/editions/edition1/versions/1", bytes.NewBuffer(validVersionJSON))
 		vars := map[string]string{
 			"dataset_id": "123",
@@ -1651,7 +1677,7 @@ func TestCreateVersion_Success(t *testing.T) {
 		returnedVersionJSON, err := json.Marshal(expectedVersion)
 		So(err, ShouldBeNil)
 
-		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, auditServiceMock)
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, auditServiceMock)
 		r := createRequestWithAuth("POST", "http://localhost:22000/datasets/123/editions/edition1/versions/1", bytes.NewBuffer(validVersionJSON))
 		vars := map[string]string{
 			"dataset_id": "123",
@@ -1742,7 +1768,7 @@ func TestCreateVersion_Failure(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(&storetest.StorerMock{}, &mocks.DownloadsGeneratorMock{}, failingAuthorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(&storetest.StorerMock{}, &mocks.DownloadsGeneratorMock{}, failingAuthorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 		r := createRequestWithAuth("POST", "http://localhost:22000/datasets/123/editions/edition1/versions/1", http.NoBody)
 		vars := map[string]string{
 			"dataset_id": "123",
@@ -1765,7 +1791,7 @@ func TestCreateVersion_Failure(t *testing.T) {
 	})
 
 	Convey("When the JSON body provided is invalid", t, func() {
-		api := GetAPIWithCMDMocks(&storetest.StorerMock{}, &mocks.DownloadsGeneratorMock{}, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(&storetest.StorerMock{}, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 		r := createRequestWithAuth("POST", "http://localhost:22000/datasets/123/editions/edition1/versions/invalid", http.NoBody)
 		vars := map[string]string{
 			"dataset_id": "123",
@@ -1791,7 +1817,7 @@ func TestCreateVersion_Failure(t *testing.T) {
 		validVersionJSON, err := json.Marshal(validVersion)
 		So(err, ShouldBeNil)
 
-		api := GetAPIWithCMDMocks(&storetest.StorerMock{}, &mocks.DownloadsGeneratorMock{}, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(&storetest.StorerMock{}, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 		r := createRequestWithAuth("POST", "http://localhost:22000/datasets/123/editions/edition1/versions/invalid", bytes.NewBuffer(validVersionJSON))
 		vars := map[string]string{
 			"dataset_id": "123",
@@ -1820,7 +1846,7 @@ func TestCreateVersion_Failure(t *testing.T) {
 		invalidTypeVersionJSON, err := json.Marshal(invalidTypeVersion)
 		So(err, ShouldBeNil)
 
-		api := GetAPIWithCMDMocks(&storetest.StorerMock{}, &mocks.DownloadsGeneratorMock{}, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(&storetest.StorerMock{}, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 		r := createRequestWithAuth("POST", "http://localhost:22000/datasets/123/editions/edition1/versions/1", bytes.NewBuffer(invalidTypeVersionJSON))
 		vars := map[string]string{
 			"dataset_id": "123",
@@ -1850,7 +1876,7 @@ func TestCreateVersion_Failure(t *testing.T) {
 		invalidVersionJSON, err := json.Marshal(invalidVersion)
 		So(err, ShouldBeNil)
 
-		api := GetAPIWithCMDMocks(&storetest.StorerMock{}, &mocks.DownloadsGeneratorMock{}, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(&storetest.StorerMock{}, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 		r := createRequestWithAuth("POST", "http://localhost:22000/datasets/123/editions/edition1/versions/100", bytes.NewBuffer(invalidVersionJSON))
 		vars := map[string]string{
 			"dataset_id": "123",
@@ -1888,7 +1914,7 @@ func TestCreateVersion_Failure(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 		r := createRequestWithAuth("POST", "http://localhost:22000/datasets/123/editions/edition1/versions/1", bytes.NewBuffer(validVersionJSON))
 		vars := map[string]string{
 			"dataset_id": "123",
@@ -1920,7 +1946,7 @@ func TestCreateVersion_Failure(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 		r := createRequestWithAuth("POST", "http://localhost:22000/datasets/123/editions/edition1/versions/1", bytes.NewBuffer(validVersionJSON))
 		vars := map[string]string{
 			"dataset_id": "123",
@@ -1955,7 +1981,7 @@ func TestCreateVersion_Failure(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 		r := createRequestWithAuth("POST", "http://localhost:22000/datasets/123/editions/edition1/versions/1", bytes.NewBuffer(validVersionJSON))
 		vars := map[string]string{
 			"dataset_id": "123",
@@ -1990,7 +2016,7 @@ func TestCreateVersion_Failure(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 		r := createRequestWithAuth("POST", "http://localhost:22000/datasets/123/editions/edition1/versions/1", bytes.NewBuffer(validVersionJSON))
 		vars := map[string]string{
 			"dataset_id": "123",
@@ -2029,12 +2055,12 @@ func TestCreateVersion_Failure(t *testing.T) {
 		}
 
 		auditServiceMock := &applicationMocks.AuditServiceMock{
-			RecordVersionAuditEventFunc: func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, version *models.Version) error {
+			RecordVersionAuditEventFunc: func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, previous, version *models.Version) error {
 				return nil
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, auditServiceMock)
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, auditServiceMock)
 		r := createRequestWithAuth("POST", "http://localhost:22000/datasets/123/editions/edition1/versions/1", bytes.NewBuffer(validVersionJSON))
 		vars := map[string]string{
 			"dataset_id": "123",
@@ -2070,12 +2096,12 @@ func TestCreateVersion_Failure(t *testing.T) {
 		}
 
 		auditServiceMock := &applicationMocks.AuditServiceMock{
-			RecordVersionAuditEventFunc: func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, version *models.Version) error {
+			RecordVersionAuditEventFunc: func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, previous, version *models.Version) error {
 				return errors.New("audit service failed")
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, auditServiceMock)
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, auditServiceMock)
 		r := createRequestWithAuth("POST", "http://localhost:22000/datasets/123/editions/edition1/versions/1", bytes.NewBuffer(validVersionJSON))
 		vars := map[string]string{
 			"dataset_id": "123",
@@ -2112,7 +2138,7 @@ func TestCreateVersion_Failure(t *testing.T) {
 		}`
 
 		api := GetAPIWithCMDMocks(&storetest.StorerMock{}, &mocks.DownloadsGeneratorMock{},
-			authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+			authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 
 		r := createRequestWithAuth(
 			"POST",
@@ -2163,7 +2189,7 @@ func TestCreateVersion_Failure(t *testing.T) {
 		w := httptest.NewRecorder()
 
 		api := GetAPIWithCMDMocks(&storetest.StorerMock{}, &mocks.DownloadsGeneratorMock{},
-			authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+			authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 
 		success, errResp := api.createVersion(w, r)
 
@@ -2181,7 +2207,7 @@ func TestCreateVersion_Failure(t *testing.T) {
 			&storetest.StorerMock{},
 			&mocks.DownloadsGeneratorMock{},
 			authorisationMock,
-			SearchContentUpdatedProducer{},
+			nil,
 			&cloudflareMocks.ClienterMock{},
 			&applicationMocks.AuditServiceMock{},
 		)
@@ -2219,7 +2245,7 @@ func TestCreateVersion_Failure(t *testing.T) {
 			&storetest.StorerMock{},
 			&mocks.DownloadsGeneratorMock{},
 			authorisationMock,
-			SearchContentUpdatedProducer{},
+			nil,
 			&cloudflareMocks.ClienterMock{},
 			&applicationMocks.AuditServiceMock{},
 		)
@@ -2257,7 +2283,7 @@ func TestCreateVersion_Failure(t *testing.T) {
 			&storetest.StorerMock{},
 			&mocks.DownloadsGeneratorMock{},
 			authorisationMock,
-			SearchContentUpdatedProducer{},
+			nil,
 			&cloudflareMocks.ClienterMock{},
 			&applicationMocks.AuditServiceMock{},
 		)
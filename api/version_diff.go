@@ -0,0 +1,110 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	"github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/dp-dataset-api/pagination"
+	"github.com/ONSdigital/log.go/v2/log"
+	"github.com/gorilla/mux"
+)
+
+// diffOptionsPageSize is the number of dimension options read at a time when comparing the options of two versions
+const diffOptionsPageSize = 1000
+
+// getVersionDiff returns the changes made to a version since the version provided by the against query parameter
+func (api *DatasetAPI) getVersionDiff(w http.ResponseWriter, r *http.Request) (*models.SuccessResponse, *models.ErrorResponse) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	datasetID := vars["dataset_id"]
+	edition := vars["edition"]
+	logData := log.Data{"dataset_id": datasetID, "edition": edition, "version": vars["version"], "against": r.URL.Query().Get("against")}
+
+	diff, err := func() (*models.VersionDiff, error) {
+		versionNumber, err := models.ParseAndValidateVersionNumber(ctx, vars["version"])
+		if err != nil {
+			return nil, err
+		}
+
+		againstNumber, err := models.ParseAndValidateVersionNumber(ctx, r.URL.Query().Get("against"))
+		if err != nil {
+			log.Error(ctx, "getVersionDiff endpoint: invalid against query parameter", err, logData)
+			return nil, errs.ErrInvalidQueryParameter
+		}
+
+		dataset, err := api.dataStore.Backend.GetDataset(ctx, datasetID)
+		if err != nil {
+			log.Error(ctx, "getVersionDiff endpoint: failed to retrieve dataset details", err, logData)
+			return nil, err
+		}
+		isStatic := dataset.Next.Type == models.Static.String()
+
+		version, options, err := api.getVersionForDiff(ctx, datasetID, edition, versionNumber, isStatic)
+		if err != nil {
+			log.Error(ctx, "getVersionDiff endpoint: failed to get version", err, logData)
+			return nil, err
+		}
+
+		against, againstOptions, err := api.getVersionForDiff(ctx, datasetID, edition, againstNumber, isStatic)
+		if err != nil {
+			log.Error(ctx, "getVersionDiff endpoint: failed to get version to compare against", err, logData)
+			return nil, err
+		}
+
+		return models.DiffVersions(version, against, options, againstOptions), nil
+	}()
+	if err != nil {
+		return nil, models.NewErrorResponse(getVersionAPIErrStatusCode(err), nil, models.NewError(err, err.Error(), "internal error"))
+	}
+
+	b, err := json.Marshal(diff)
+	if err != nil {
+		log.Error(ctx, "getVersionDiff endpoint: failed to marshal version diff into bytes", err, logData)
+		return nil, models.NewErrorResponse(http.StatusInternalServerError, nil, models.NewError(err, models.JSONMarshalError, models.ErrorMarshalFailedDescription))
+	}
+
+	log.Info(ctx, "getVersionDiff endpoint: request successful", logData)
+	return models.NewSuccessResponse(b, http.StatusOK, nil), nil
+}
+
+// getVersionForDiff returns a version in any state, along with the options of each of its dimensions.
+// Static versions do not have dimension options.
+func (api *DatasetAPI) getVersionForDiff(ctx context.Context, datasetID, edition string, versionNumber int, isStatic bool) (*models.Version, map[string][]string, error) {
+	if isStatic {
+		version, err := api.dataStore.Backend.GetVersionStatic(ctx, datasetID, edition, versionNumber, "")
+		if err != nil {
+			return nil, nil, err
+		}
+		version.DatasetID = datasetID
+		return version, nil, nil
+	}
+
+	version, err := api.dataStore.Backend.GetVersion(ctx, datasetID, edition, versionNumber, "")
+	if err != nil {
+		return nil, nil, err
+	}
+	version.DatasetID = datasetID
+
+	options := make(map[string][]string, len(version.Dimensions))
+	for _, dimension := range version.Dimensions {
+		var cursor *pagination.Cursor
+		for {
+			page, _, nextCursor, err := api.dataStore.Backend.GetDimensionOptions(ctx, version, dimension.Name, 0, diffOptionsPageSize, cursor)
+			if err != nil {
+				return nil, nil, err
+			}
+			for _, option := range page {
+				options[dimension.Name] = append(options[dimension.Name], option.Option)
+			}
+			if nextCursor == nil {
+				break
+			}
+			cursor = nextCursor
+		}
+	}
+
+	return version, options, nil
+}
@@ -0,0 +1,142 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ONSdigital/dp-authorisation/v2/authorisation"
+	authMock "github.com/ONSdigital/dp-authorisation/v2/authorisation/mock"
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	applicationMocks "github.com/ONSdigital/dp-dataset-api/application/mock"
+	cloudflareMocks "github.com/ONSdigital/dp-dataset-api/cloudflare/mocks"
+	"github.com/ONSdigital/dp-dataset-api/mocks"
+	"github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/dp-dataset-api/pagination"
+	storetest "github.com/ONSdigital/dp-dataset-api/store/datastoretest"
+	permissionsAPISDK "github.com/ONSdigital/dp-permissions-api/sdk"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGetVersionDiff(t *testing.T) {
+	t.Parallel()
+
+	authorisationMock := &authMock.MiddlewareMock{
+		RequireFunc: func(permission string, handlerFunc http.HandlerFunc) http.HandlerFunc {
+			return handlerFunc
+		},
+		RequireWithAttributesFunc: func(permission string, handlerFunc http.HandlerFunc, getAttributes authorisation.GetAttributesFromRequest) http.HandlerFunc {
+			return handlerFunc
+		},
+		ParseFunc: func(token string) (*permissionsAPISDK.EntityData, error) {
+			return testEntityData, nil
+		},
+	}
+
+	newVersion := func(number int, label string) *models.Version {
+		return &models.Version{
+			ID:         "instance-" + label,
+			Edition:    "2021",
+			Version:    number,
+			Dimensions: []models.Dimension{{Name: "geography", Label: label}},
+			Links:      &models.VersionLinks{Self: &models.LinkObject{}},
+		}
+	}
+
+	Convey("Given a dataset with two versions whose dimensions differ", t, func() {
+		mockedDataStore := &storetest.StorerMock{
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
+				return &models.DatasetUpdate{ID: "cpih01", Next: &models.Dataset{Type: models.Filterable.String()}}, nil
+			},
+			GetVersionFunc: func(_ context.Context, _, _ string, version int, _ string) (*models.Version, error) {
+				if version == 2 {
+					return newVersion(2, "Area"), nil
+				}
+				return newVersion(1, "Geography"), nil
+			},
+			GetDimensionOptionsFunc: func(_ context.Context, version *models.Version, _ string, _, _ int, cursor *pagination.Cursor) ([]*models.PublicDimensionOption, int, *pagination.Cursor, error) {
+				if version.Version == 1 {
+					return []*models.PublicDimensionOption{{Option: "E92000001"}, {Option: "W92000004"}}, 2, nil, nil
+				}
+				// the options of the second version are read in two pages
+				if cursor == nil {
+					return []*models.PublicDimensionOption{{Option: "E92000001"}}, 2, &pagination.Cursor{ID: "E92000001"}, nil
+				}
+				return []*models.PublicDimensionOption{{Option: "S92000003"}}, 2, nil, nil
+			},
+		}
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+
+		Convey("When the diff of the second version against the first is requested", func() {
+			r := createRequestWithAuth("GET", "http://localhost:22000/datasets/cpih01/editions/2021/versions/2/diff?against=1", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then the changes to the dimension and its options are returned", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+
+				var diff models.VersionDiff
+				So(json.Unmarshal(w.Body.Bytes(), &diff), ShouldBeNil)
+				So(diff.DatasetID, ShouldEqual, "cpih01")
+				So(diff.Version, ShouldEqual, 2)
+				So(diff.Against, ShouldEqual, 1)
+				So(diff.Dimensions, ShouldResemble, []models.DimensionDiff{{
+					Name:           "geography",
+					Change:         models.DimensionChanged,
+					Fields:         []models.FieldChange{{Field: "label", From: "Geography", To: "Area"}},
+					AddedOptions:   []string{"S92000003"},
+					RemovedOptions: []string{"W92000004"},
+				}})
+				So(mockedDataStore.GetDimensionOptionsCalls(), ShouldHaveLength, 3)
+			})
+		})
+
+		Convey("When the against query parameter is missing", func() {
+			r := createRequestWithAuth("GET", "http://localhost:22000/datasets/cpih01/editions/2021/versions/2/diff", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then a bad request is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+				So(w.Body.String(), ShouldContainSubstring, errs.ErrInvalidQueryParameter.Error())
+				So(mockedDataStore.GetVersionCalls(), ShouldHaveLength, 0)
+			})
+		})
+	})
+
+	Convey("Given a static dataset", t, func() {
+		mockedDataStore := &storetest.StorerMock{
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
+				return &models.DatasetUpdate{ID: "static", Next: &models.Dataset{Type: models.Static.String()}}, nil
+			},
+			GetVersionStaticFunc: func(_ context.Context, _, _ string, version int, _ string) (*models.Version, error) {
+				if version == 3 {
+					return nil, errs.ErrVersionNotFound
+				}
+				return &models.Version{Version: version, QualityDesignation: models.QualityDesignationOfficial}, nil
+			},
+		}
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+
+		Convey("When the diff of two of its versions is requested, then the static versions are compared without options", func() {
+			r := createRequestWithAuth("GET", "http://localhost:22000/datasets/static/editions/2021/versions/2/diff?against=1", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			So(w.Code, ShouldEqual, http.StatusOK)
+			So(mockedDataStore.GetVersionStaticCalls(), ShouldHaveLength, 2)
+			So(mockedDataStore.GetVersionStaticCalls()[1].Version, ShouldEqual, 1)
+			So(mockedDataStore.GetDimensionOptionsCalls(), ShouldHaveLength, 0)
+		})
+
+		Convey("When the version to compare against does not exist, then not found is returned", func() {
+			r := createRequestWithAuth("GET", "http://localhost:22000/datasets/static/editions/2021/versions/2/diff?against=3", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			So(w.Code, ShouldEqual, http.StatusNotFound)
+		})
+	})
+}
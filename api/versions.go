@@ -16,7 +16,6 @@ import (
 	"github.com/ONSdigital/dp-dataset-api/models"
 	"github.com/ONSdigital/dp-dataset-api/utils"
 	filesAPISDK "github.com/ONSdigital/dp-files-api/sdk"
-	kafka "github.com/ONSdigital/dp-kafka/v4"
 	dpresponse "github.com/ONSdigital/dp-net/v3/handlers/response"
 	dphttp "github.com/ONSdigital/dp-net/v3/http"
 	"github.com/ONSdigital/dp-net/v3/links"
@@ -42,6 +41,8 @@ var (
 		models.ErrPublishedVersionCollectionIDInvalid:  true,
 		models.ErrAssociatedVersionCollectionIDInvalid: true,
 		models.ErrVersionStateInvalid:                  true,
+		models.ErrWithdrawnVersionReasonMissing:        true,
+		models.ErrRejectedVersionCommentMissing:        true,
 		errs.ErrInvalidBody:                            true,
 		errs.ErrInvalidQueryParameter:                  true,
 		errs.ErrSpacesNotAllowedInID:                   true,
@@ -143,6 +144,10 @@ func (api *DatasetAPI) getVersions(w http.ResponseWriter, r *http.Request, limit
 					}
 				}
 			}
+
+			if !authorised {
+				item.ReviewComments = nil
+			}
 		}
 
 		if hasInvalidState {
@@ -185,6 +190,7 @@ func (api *DatasetAPI) getVersion(w http.ResponseWriter, r *http.Request) (*mode
 	logData := log.Data{"dataset_id": datasetID, "edition": edition, "version": versionNumber}
 
 	var authorised bool // Declare at function scope so audit can access it
+	var tombstone *models.VersionTombstone
 
 	v, getVersionErr := func() (*models.Version, error) {
 		versionID, err := models.ParseAndValidateVersionNumber(ctx, versionNumber)
@@ -236,6 +242,9 @@ func (api *DatasetAPI) getVersion(w http.ResponseWriter, r *http.Request) (*mode
 
 		if err != nil {
 			log.Error(ctx, "failed to verify edition existence for dataset", err, logData)
+			if tombstone = api.getVersionTombstone(ctx, datasetType, datasetID, edition, versionID, authorised, err); tombstone != nil {
+				return nil, errs.ErrVersionWithdrawn
+			}
 			return nil, err
 		}
 
@@ -249,6 +258,9 @@ func (api *DatasetAPI) getVersion(w http.ResponseWriter, r *http.Request) (*mode
 
 		if err != nil {
 			log.Error(ctx, "failed to find version for dataset edition", err, logData)
+			if tombstone = api.getVersionTombstone(ctx, datasetType, datasetID, edition, versionID, authorised, err); tombstone != nil {
+				return nil, errs.ErrVersionWithdrawn
+			}
 			return nil, err
 		}
 
@@ -277,8 +289,22 @@ func (api *DatasetAPI) getVersion(w http.ResponseWriter, r *http.Request) (*mode
 				}
 			}
 		}
+
+		// review comments, and the reviewers who left them, are only shown to publishers
+		if !authorised {
+			version.ReviewComments = nil
+		}
 		return version, nil
 	}()
+	if tombstone != nil {
+		b, err := json.Marshal(tombstone)
+		if err != nil {
+			log.Error(ctx, "getVersion endpoint: failed to marshal version tombstone into bytes", err, logData)
+			return nil, models.NewErrorResponse(http.StatusInternalServerError, nil, models.NewError(err, models.JSONMarshalError, models.ErrorMarshalFailedDescription))
+		}
+		log.Info(ctx, "getVersion endpoint: version has been withdrawn", logData)
+		return models.NewSuccessResponse(b, http.StatusGone, nil), nil
+	}
 	if getVersionErr != nil {
 		responseError := models.NewError(getVersionErr, getVersionErr.Error(), "internal error")
 		return nil, models.NewErrorResponse(getVersionAPIErrStatusCode(getVersionErr), nil, responseError)
@@ -341,7 +367,7 @@ func (api *DatasetAPI) getVersion(w http.ResponseWriter, r *http.Request) (*mode
 		logAuthOption := log.Auth(identityType, authEntityData.EntityData.UserID)
 
 		// ID and Email are the same as auth middleware can only provide userID
-		if err := api.auditService.RecordVersionAuditEvent(ctx, models.RequestedBy{ID: authEntityData.EntityData.UserID, Email: authEntityData.EntityData.UserID}, models.ActionRead, "/datasets/"+datasetID+"/editions/"+edition+"/versions/"+versionNumber, v); err != nil {
+		if err := api.auditService.RecordVersionAuditEvent(ctx, models.RequestedBy{ID: authEntityData.EntityData.UserID, Email: authEntityData.EntityData.UserID}, models.ActionRead, "/datasets/"+datasetID+"/editions/"+edition+"/versions/"+versionNumber, nil, v); err != nil {
 			log.Info(ctx, "failed to create version audit event", log.Classification(log.ProtectiveMonitoring), logAuthOption, log.Data{
 				"action":   models.ActionRead,
 				"endpoint": "/datasets/" + datasetID + "/editions/" + edition + "/versions/" + versionNumber,
@@ -479,16 +505,17 @@ func (api *DatasetAPI) putVersion(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	var amendedVersion *models.Version
+	var previousVersion, amendedVersion *models.Version
 
-	amendedVersion, err = api.smDatasetAPI.AmendVersion(r.Context(), vars, version)
+	vars[stateChangedBy] = authEntityData.EntityData.UserID
+	previousVersion, amendedVersion, err = api.smDatasetAPI.AmendVersion(r.Context(), vars, version)
 	if err != nil {
 		handleVersionAPIErr(ctx, err, w, data)
 		return
 	}
 
 	// ID and Email are the same as auth middleware can only provide userID
-	if err := api.auditService.RecordVersionAuditEvent(ctx, models.RequestedBy{ID: authEntityData.EntityData.UserID, Email: authEntityData.EntityData.UserID}, models.ActionUpdate, "/datasets/"+vars["dataset_id"]+"/editions/"+vars["edition"]+"/versions/"+vars["version"], amendedVersion); err != nil {
+	if err := api.auditService.RecordVersionAuditEvent(ctx, models.RequestedBy{ID: authEntityData.EntityData.UserID, Email: authEntityData.EntityData.UserID}, models.ActionUpdate, "/datasets/"+vars["dataset_id"]+"/editions/"+vars["edition"]+"/versions/"+vars["version"], previousVersion, amendedVersion); err != nil {
 		log.Info(ctx, "failed to create version audit event", log.Classification(log.ProtectiveMonitoring), logAuthOption, log.Data{
 			"action":   models.ActionUpdate,
 			"endpoint": "/datasets/" + vars["dataset_id"] + "/editions/" + vars["edition"] + "/versions/" + vars["version"],
@@ -572,14 +599,14 @@ func (api *DatasetAPI) deleteVersion(w http.ResponseWriter, r *http.Request) {
 			handleVersionAPIErr(ctx, errs.ErrUnauthorised, w, logData)
 			return
 		}
-		deletedVersion, err := api.smDatasetAPI.DeleteStaticVersion(ctx, datasetID, edition, versionNum, api.filesAPIClient, fetchAccessTokenFromHeader(r))
+		deletedVersion, err := api.smDatasetAPI.DeleteStaticVersion(ctx, datasetID, edition, versionNum, authEntityData.EntityData.UserID)
 		if err != nil {
 			handleVersionAPIErr(ctx, err, w, logData)
 			return
 		}
 
 		// ID and Email are the same as auth middleware can only provide userID
-		if err := api.auditService.RecordVersionAuditEvent(ctx, models.RequestedBy{ID: authEntityData.EntityData.UserID, Email: authEntityData.EntityData.UserID}, models.ActionDelete, "/datasets/"+datasetID+"/editions/"+edition+"/versions/"+versionStr, deletedVersion); err != nil {
+		if err := api.auditService.RecordVersionAuditEvent(ctx, models.RequestedBy{ID: authEntityData.EntityData.UserID, Email: authEntityData.EntityData.UserID}, models.ActionDelete, "/datasets/"+datasetID+"/editions/"+edition+"/versions/"+versionStr, nil, deletedVersion); err != nil {
 			log.Info(ctx, "failed to create version audit event", log.Classification(log.ProtectiveMonitoring), logAuthOption, log.Data{
 				"action":   models.ActionDelete,
 				"endpoint": "/datasets/" + datasetID + "/editions/" + edition + "/versions/" + versionStr,
@@ -609,6 +636,81 @@ func (api *DatasetAPI) deleteVersion(w http.ResponseWriter, r *http.Request) {
 	api.detachVersion(w, r)
 }
 
+// restoreVersion restores a deleted static version that has not been purged yet
+func (api *DatasetAPI) restoreVersion(w http.ResponseWriter, r *http.Request) {
+	defer dphttp.DrainBody(r)
+
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	datasetID := vars["dataset_id"]
+	edition := vars["edition"]
+	versionStr := vars["version"]
+
+	logData := log.Data{
+		"dataset_id": datasetID,
+		"edition":    edition,
+		"version":    versionStr,
+	}
+	authEntityData, err := api.getAuthEntityData(r)
+	if err != nil {
+		log.Error(ctx, "restoreVersion endpoint: failed to get auth entity data from request", err, logData)
+		handleVersionAPIErr(ctx, err, w, logData)
+		return
+	}
+
+	identityType := log.USER
+	if authEntityData.IsServiceAuth {
+		identityType = log.SERVICE
+	}
+	logAuthOption := log.Auth(identityType, authEntityData.EntityData.UserID)
+
+	versionNum, err := models.ParseAndValidateVersionNumber(ctx, versionStr)
+	if err != nil {
+		handleVersionAPIErr(ctx, err, w, logData)
+		return
+	}
+
+	isStatic, err := api.dataStore.Backend.IsStaticDataset(ctx, datasetID)
+	if err != nil {
+		handleVersionAPIErr(ctx, err, w, logData)
+		return
+	}
+
+	// only static versions are deleted, the versions of other types of dataset are detached instead
+	if !isStatic || !api.enableDeleteStaticVersion {
+		handleVersionAPIErr(ctx, errs.ErrMethodNotAllowed, w, logData)
+		return
+	}
+
+	restoredVersion, err := api.smDatasetAPI.RestoreStaticVersion(ctx, datasetID, edition, versionNum)
+	if err != nil {
+		handleVersionAPIErr(ctx, err, w, logData)
+		return
+	}
+
+	// ID and Email are the same as auth middleware can only provide userID
+	if err := api.auditService.RecordVersionAuditEvent(ctx, models.RequestedBy{ID: authEntityData.EntityData.UserID, Email: authEntityData.EntityData.UserID}, models.ActionRestore, "/datasets/"+datasetID+"/editions/"+edition+"/versions/"+versionStr, nil, restoredVersion); err != nil {
+		log.Info(ctx, "failed to create version audit event", log.Classification(log.ProtectiveMonitoring), logAuthOption, log.Data{
+			"action":   models.ActionRestore,
+			"endpoint": "/datasets/" + datasetID + "/editions/" + edition + "/versions/" + versionStr + "/restore",
+			"outcome":  "failure",
+			"reason":   err.Error(),
+		})
+		log.Error(ctx, "restoreVersion endpoint: failed to record version audit event", err, logData)
+		handleVersionAPIErr(ctx, err, w, logData)
+		return
+	}
+	log.Info(ctx, "successfully created version audit event", log.Classification(log.ProtectiveMonitoring), logAuthOption, log.Data{
+		"action":   models.ActionRestore,
+		"endpoint": "/datasets/" + datasetID + "/editions/" + edition + "/versions/" + versionStr + "/restore",
+		"outcome":  "success",
+	})
+
+	w.WriteHeader(http.StatusNoContent)
+	log.Info(ctx, "restoreVersion: successfully restored static version", logData)
+}
+
 // TODO: Refactor this to reduce the complexity
 //
 //nolint:gocyclo,gocognit // high cyclomactic & cognitive complexity not in scope for maintenance
@@ -698,7 +800,12 @@ func (api *DatasetAPI) detachVersion(w http.ResponseWriter, r *http.Request) {
 		} else {
 			// For first (unpublished) versions:
 			// delete edition doc
-			if err := api.dataStore.Backend.DeleteEdition(ctx, editionDoc.ID); err != nil {
+			authEntityData, err := api.getAuthEntityData(r)
+			if err != nil {
+				log.Error(ctx, "detachVersion endpoint: failed to get auth entity data from request", err, logData)
+				return err
+			}
+			if err := api.dataStore.Backend.DeleteEdition(ctx, editionDoc.ID, models.NewDeletion(authEntityData.EntityData.UserID)); err != nil {
 				log.Error(ctx, "detachVersion endpoint: failed to delete edition document", err, logData)
 				return err
 			}
@@ -851,6 +958,14 @@ func handleVersionAPIErr(ctx context.Context, err error, w http.ResponseWriter,
 		data = log.Data{}
 	}
 
+	// versions rejected by the guards of a state machine transition are given every validation error in the response
+	var validationErrors models.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		log.Error(ctx, "request unsuccessful", err, data)
+		writeErrorResponse(w, models.NewErrorResponse(http.StatusBadRequest, nil, validationErrors...))
+		return
+	}
+
 	status := getVersionAPIErrStatusCode(err)
 	if status == http.StatusInternalServerError && !internalServerErrWithMessage[err] {
 		err = fmt.Errorf("%s: %w", errs.ErrInternalServer.Error(), err)
@@ -935,6 +1050,64 @@ func (api *DatasetAPI) generateVersionLinks(datasetID, edition string, version i
 	}
 }
 
+// getVersionTombstone returns the tombstone of a withdrawn version, when it could not be found among the published
+// versions. Users who are authorised to see unpublished versions are given the withdrawn version instead.
+func (api *DatasetAPI) getVersionTombstone(ctx context.Context, datasetType, datasetID, edition string, versionID int, authorised bool, err error) *models.VersionTombstone {
+	if authorised || (err != errs.ErrEditionNotFound && err != errs.ErrVersionNotFound) {
+		return nil
+	}
+
+	var version *models.Version
+	if datasetType == models.Static.String() {
+		version, err = api.dataStore.Backend.GetVersionStatic(ctx, datasetID, edition, versionID, models.WithdrawnState)
+	} else {
+		version, err = api.dataStore.Backend.GetVersion(ctx, datasetID, edition, versionID, models.WithdrawnState)
+	}
+	if err != nil || version.State != models.WithdrawnState {
+		return nil
+	}
+
+	return models.NewVersionTombstone(datasetID, version)
+}
+
+// getDatasetVersionTombstone returns the tombstone of a withdrawn version for the endpoints that read versions without
+// knowing the type of their dataset, when the version could not be found among the published versions
+func (api *DatasetAPI) getDatasetVersionTombstone(ctx context.Context, datasetID, edition string, versionID int, authorised bool, err error) *models.VersionTombstone {
+	if authorised || (err != errs.ErrEditionNotFound && err != errs.ErrVersionNotFound) {
+		return nil
+	}
+
+	isStatic, err := api.dataStore.Backend.IsStaticDataset(ctx, datasetID)
+	if err != nil {
+		return nil
+	}
+
+	datasetType := models.Filterable.String()
+	if isStatic {
+		datasetType = models.Static.String()
+	}
+
+	return api.getVersionTombstone(ctx, datasetType, datasetID, edition, versionID, authorised, errs.ErrVersionNotFound)
+}
+
+// writeVersionTombstone responds with the tombstone of a withdrawn version in place of the resource requested from it
+func writeVersionTombstone(ctx context.Context, w http.ResponseWriter, tombstone *models.VersionTombstone, logData log.Data) {
+	b, err := json.Marshal(tombstone)
+	if err != nil {
+		log.Error(ctx, "failed to marshal version tombstone into bytes", err, logData)
+		http.Error(w, errs.ErrInternalServer.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	setJSONContentType(w)
+	w.WriteHeader(http.StatusGone)
+	if _, err = w.Write(b); err != nil {
+		log.Error(ctx, "failed to write version tombstone to response", err, logData)
+		return
+	}
+	log.Info(ctx, "version has been withdrawn", logData)
+}
+
 func (api *DatasetAPI) putState(w http.ResponseWriter, r *http.Request) {
 	defer dphttp.DrainBody(r)
 
@@ -956,12 +1129,6 @@ func (api *DatasetAPI) putState(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	identityType := log.USER
-	if authEntityData.IsServiceAuth {
-		identityType = log.SERVICE
-	}
-	logAuthOption := log.Auth(identityType, authEntityData.EntityData.UserID)
-
 	versionID, err := models.ParseAndValidateVersionNumber(ctx, version)
 	if err != nil {
 		log.Error(ctx, "putState endpoint: invalid version request", err, logData)
@@ -982,93 +1149,138 @@ func (api *DatasetAPI) putState(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	currentVersion, err := api.dataStore.Backend.GetVersionStatic(ctx, datasetID, edition, versionID, "")
+	if stateUpdate.State == models.WithdrawnState && strings.TrimSpace(stateUpdate.Reason) == "" {
+		log.Error(ctx, "putState endpoint: no reason given for withdrawing the version", models.ErrWithdrawnVersionReasonMissing, logData)
+		handleVersionAPIErr(ctx, models.ErrWithdrawnVersionReasonMissing, w, logData)
+		return
+	}
+
+	isStatic, err := api.dataStore.Backend.IsStaticDataset(ctx, datasetID)
 	if err != nil {
-		log.Error(ctx, "putState endpoint: failed to get version", err, logData)
+		log.Error(ctx, "putState endpoint: failed to check the type of the dataset", err, logData)
+		handleVersionAPIErr(ctx, err, w, logData)
+		return
+	}
+
+	// ID and Email are the same as auth middleware can only provide userID
+	requestedBy := models.RequestedBy{ID: authEntityData.EntityData.UserID, Email: authEntityData.EntityData.UserID}
+	if err = api.setVersionState(ctx, datasetID, edition, versionID, isStatic, stateUpdate, requestedBy, authEntityData.IsServiceAuth, false, fetchAccessTokenFromHeader(r), logData); err != nil {
 		handleVersionAPIErr(ctx, err, w, logData)
 		return
 	}
 
+	setJSONContentType(w)
+	w.WriteHeader(http.StatusOK)
+	log.Info(ctx, "putState endpoint: request successful", logData)
+}
+
+// setVersionState moves a version to the provided state through the state machine, and then completes the change of
+// state with completeVersionStateChange. When onlyIfApproved is true the version is only moved if it is approved,
+// otherwise apierrors.ErrExpectedResourceStateOfApproved is returned and nothing else is done.
+func (api *DatasetAPI) setVersionState(ctx context.Context, datasetID, edition string, versionID int, isStatic bool, stateUpdate models.StateUpdate, requestedBy models.RequestedBy, isServiceAuth, onlyIfApproved bool, accessToken string, logData log.Data) error {
+	state := stateUpdate.State
+	version := strconv.Itoa(versionID)
+	endpoint := "/datasets/" + datasetID + "/editions/" + edition + "/versions/" + version + "/state"
+
+	var currentVersion *models.Version
+	var err error
+	if isStatic {
+		currentVersion, err = api.dataStore.Backend.GetVersionStatic(ctx, datasetID, edition, versionID, "")
+	} else {
+		currentVersion, err = api.dataStore.Backend.GetVersion(ctx, datasetID, edition, versionID, "")
+	}
+	if err != nil {
+		log.Error(ctx, "setVersionState: failed to get version", err, logData)
+		return err
+	}
+
 	// Create a version update with the target state
 	versionUpdate := &models.Version{
 		ID:    currentVersion.ID,
-		State: stateUpdate.State,
-		Type:  models.Static.String(),
+		State: state,
+		Type:  currentVersion.Type,
+	}
+	if isStatic {
+		versionUpdate.Type = models.Static.String()
+	}
+	if state == models.WithdrawnState {
+		versionUpdate.Withdrawal = models.NewWithdrawal(stateUpdate.Reason, requestedBy.ID)
 	}
 
-	updatedVersion, err := api.smDatasetAPI.AmendVersion(r.Context(), vars, versionUpdate)
-	if err != nil {
-		handleVersionAPIErr(ctx, err, w, logData)
-		return
+	// the reason for withdrawing a version is also given as the comment on its change of state
+	comment := stateUpdate.Comment
+	if state == models.WithdrawnState {
+		comment = stateUpdate.Reason
 	}
 
-	if stateUpdate.State == models.PublishedState && updatedVersion.Distributions != nil && len(*updatedVersion.Distributions) > 0 {
-		err = api.publishDistributionFiles(ctx, updatedVersion, logData, fetchAccessTokenFromHeader(r))
-		if err != nil {
-			log.Error(ctx, "putState endpoint: failed to publish distribution files", err, logData)
-			handleVersionAPIErr(ctx, err, w, logData)
-			return
-		}
+	vars := map[string]string{
+		"dataset_id":       datasetID,
+		"edition":          edition,
+		"version":          version,
+		stateChangedBy:     requestedBy.ID,
+		stateChangeComment: comment,
+	}
+	if onlyIfApproved {
+		vars[approvedOnly] = trueStringified
+	}
+	previousVersion, updatedVersion, err := api.smDatasetAPI.AmendVersion(ctx, vars, versionUpdate)
+	if err != nil {
+		return err
 	}
 
-	if updatedVersion.State == models.PublishedState {
-		searchContentUpdatedEvent := map[string]interface{}{
-			"dataset_id":   datasetID,
-			"uri":          fmt.Sprintf("/datasets/%s", datasetID),
-			"title":        currentVersion.EditionTitle,
-			"edition":      currentVersion.Edition,
-			"content_type": "dataset_landing_page",
-			"release_date": currentVersion.ReleaseDate,
-		}
+	return api.completeVersionStateChange(ctx, endpoint, datasetID, edition, version, previousVersion, updatedVersion, requestedBy, isServiceAuth, accessToken, logData)
+}
+
+// completeVersionStateChange makes the changes that follow the change of state of a version. When a version is published
+// the files of its distributions are published, and when a static version is published or any version is withdrawn its
+// cached pages are purged. The change is recorded as an audit event of the endpoint on behalf of the requester.
+func (api *DatasetAPI) completeVersionStateChange(ctx context.Context, endpoint, datasetID, edition, version string, previousVersion, updatedVersion *models.Version, requestedBy models.RequestedBy, isServiceAuth bool, accessToken string, logData log.Data) error {
+	state := updatedVersion.State
 
-		jsonBytes, err := json.Marshal(searchContentUpdatedEvent)
-		logData["search_content_updated_event"] = searchContentUpdatedEvent
+	identityType := log.USER
+	if isServiceAuth {
+		identityType = log.SERVICE
+	}
+	logAuthOption := log.Auth(identityType, requestedBy.ID)
+
+	if state == models.PublishedState && updatedVersion.Distributions != nil && len(*updatedVersion.Distributions) > 0 {
+		err := api.publishDistributionFiles(ctx, updatedVersion, logData, accessToken)
 		if err != nil {
-			log.Error(ctx, "failed to marshal searchContentUpdatedEvent for kafka", err, logData)
-			handleVersionAPIErr(ctx, err, w, logData)
-			return
-		} else {
-			go func() {
-				api.searchContentUpdatedProducer.Producer.Output() <- kafka.BytesMessage{Value: jsonBytes, Context: ctx}
-			}()
-			log.Info(ctx, "putState endpoint: queued search content update for kafka", logData)
+			log.Error(ctx, "completeVersionStateChange: failed to publish distribution files", err, logData)
+			return err
 		}
 	}
 
-	// Purge Cloudflare cache if enabled and version is being published
-	if api.cloudflareEnabled && stateUpdate.State == models.PublishedState {
+	// Purge Cloudflare cache if enabled and a static version is being published or any version is withdrawn
+	if api.cloudflareEnabled && ((updatedVersion.Type == models.Static.String() && state == models.PublishedState) || state == models.WithdrawnState) {
 		prefixes := utils.GeneratePurgePrefixes(api.urlBuilder.GetWebsiteURL().String(), api.urlBuilder.GetAPIRouterPublicURL().String(), datasetID, edition, version)
 		logData["purge_prefixes"] = prefixes
 
 		err := api.cloudflareClient.PurgeByPrefixes(ctx, prefixes)
 		if err != nil {
-			log.Error(ctx, "putState endpoint: failed to purge cache by prefixes", err, logData)
+			log.Error(ctx, "completeVersionStateChange: failed to purge cache by prefixes", err, logData)
 		} else {
-			log.Info(ctx, "putState endpoint: successfully purged cache by prefixes", logData)
+			log.Info(ctx, "completeVersionStateChange: successfully purged cache by prefixes", logData)
 		}
 	}
 
-	// ID and Email are the same as auth middleware can only provide userID
-	if err := api.auditService.RecordVersionAuditEvent(ctx, models.RequestedBy{ID: authEntityData.EntityData.UserID, Email: authEntityData.EntityData.UserID}, models.ActionUpdate, "/datasets/"+datasetID+"/editions/"+edition+"/versions/"+version+"/state", updatedVersion); err != nil {
+	if err := api.auditService.RecordVersionAuditEvent(ctx, requestedBy, models.ActionUpdate, endpoint, previousVersion, updatedVersion); err != nil {
 		log.Info(ctx, "failed to create version audit event", log.Classification(log.ProtectiveMonitoring), logAuthOption, log.Data{
 			"action":   models.ActionUpdate,
-			"endpoint": "/datasets/" + datasetID + "/editions/" + edition + "/versions/" + version + "/state",
+			"endpoint": endpoint,
 			"outcome":  "failure",
 			"reason":   err.Error(),
 		})
-		log.Error(ctx, "putState endpoint: failed to record version audit event", err, logData)
-		handleVersionAPIErr(ctx, err, w, logData)
-		return
+		log.Error(ctx, "completeVersionStateChange: failed to record version audit event", err, logData)
+		return err
 	}
 	log.Info(ctx, "successfully created version audit event", log.Classification(log.ProtectiveMonitoring), logAuthOption, log.Data{
 		"action":   models.ActionUpdate,
-		"endpoint": "/datasets/" + datasetID + "/editions/" + edition + "/versions/" + version + "/state",
+		"endpoint": endpoint,
 		"outcome":  "success",
 	})
 
-	setJSONContentType(w)
-	w.WriteHeader(http.StatusOK)
-	log.Info(ctx, "putState endpoint: request successful", logData)
+	return nil
 }
 
 func (api *DatasetAPI) publishDistributionFiles(ctx context.Context, version *models.Version, logData log.Data, accessToken string) error {
@@ -17,11 +17,13 @@ import (
 	"github.com/ONSdigital/dp-authorisation/v2/authorisation"
 	authMock "github.com/ONSdigital/dp-authorisation/v2/authorisation/mock"
 	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	"github.com/ONSdigital/dp-dataset-api/application"
 	applicationMocks "github.com/ONSdigital/dp-dataset-api/application/mock"
 	cloudflareMocks "github.com/ONSdigital/dp-dataset-api/cloudflare/mocks"
 	"github.com/ONSdigital/dp-dataset-api/config"
 	"github.com/ONSdigital/dp-dataset-api/mocks"
 	"github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/dp-dataset-api/store"
 	storetest "github.com/ONSdigital/dp-dataset-api/store/datastoretest"
 	filesAPIModels "github.com/ONSdigital/dp-files-api/files"
 	filesAPIErrors "github.com/ONSdigital/dp-files-api/store"
@@ -54,6 +56,11 @@ func (m *mockFilesClient) MarkFilePublished(ctx context.Context, path string) er
 	return m.MarkFilePublishedFunc(ctx, path)
 }
 
+// runTransaction runs the provided function without a transaction, for StorerMock.RunTransactionFunc
+func runTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
 func TestGetVersionsReturnsForbidden(t *testing.T) {
 	t.Parallel()
 	Convey("Given a request is made to get versions which is forbidden", t, func() {
@@ -74,7 +81,7 @@ func TestGetVersionsReturnsForbidden(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 		api.Router.ServeHTTP(w, r)
 		Convey("Then a 403 response is received and no database calls are made", func() {
 			So(w.Code, ShouldEqual, http.StatusForbidden)
@@ -107,7 +114,7 @@ func TestGetVersionsReturnsUnauthorised(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 		api.Router.ServeHTTP(w, r)
 		Convey("Then a 401 response is received and no database calls are made", func() {
 			So(w.Code, ShouldEqual, http.StatusUnauthorized)
@@ -158,7 +165,7 @@ func TestGetVersionsReturnsOK(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 		list, totalCount, err := api.getVersions(w, r, 20, 0)
 
 		So(w.Code, ShouldEqual, http.StatusOK)
@@ -205,7 +212,7 @@ func TestGetVersionsReturnsOK(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 		list, totalCount, err := api.getVersions(w, r, 20, 0)
 
 		So(w.Code, ShouldEqual, http.StatusOK)
@@ -245,7 +252,7 @@ func TestGetVersionsReturnsError(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 		_, _, err := api.getVersions(w, r, 20, 0)
 		So(err, ShouldNotBeNil)
 
@@ -276,7 +283,7 @@ func TestGetVersionsReturnsError(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 		_, _, err := api.getVersions(w, r, 20, 0)
 		So(err, ShouldNotBeNil)
 
@@ -312,7 +319,7 @@ func TestGetVersionsReturnsError(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 		_, _, err := api.getVersions(w, r, 20, 0)
 		So(err, ShouldNotBeNil)
 
@@ -352,7 +359,7 @@ func TestGetVersionsReturnsError(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 		_, _, err := api.getVersions(w, r, 20, 0)
 		So(err, ShouldNotBeNil)
 
@@ -391,7 +398,7 @@ func TestGetVersionsReturnsError(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 		_, _, err := api.getVersions(w, r, 20, 0)
 		So(err, ShouldNotBeNil)
 
@@ -433,7 +440,7 @@ func TestGetVersionsReturnsError(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 		api.Router.ServeHTTP(w, r)
 
 		So(w.Code, ShouldEqual, http.StatusInternalServerError)
@@ -464,7 +471,7 @@ func TestGetVersionForbidden(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 
 		api.Router.ServeHTTP(w, r)
 
@@ -501,7 +508,7 @@ func TestGetVersionUnauathorised(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 
 		api.Router.ServeHTTP(w, r)
 
@@ -561,12 +568,12 @@ func TestGetVersionReturnsOK(t *testing.T) {
 		}
 
 		auditServiceMock := &applicationMocks.AuditServiceMock{
-			RecordVersionAuditEventFunc: func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, versionDoc *models.Version) error {
+			RecordVersionAuditEventFunc: func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, previous, versionDoc *models.Version) error {
 				return nil
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, auditServiceMock)
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, auditServiceMock)
 
 		Convey("With an etag", func() {
 			version.ETag = "version-etag"
@@ -627,7 +634,7 @@ func TestGetVersionReturnsError(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 		api.Router.ServeHTTP(w, r)
 
 		assertInternalServerErr(w)
@@ -653,7 +660,7 @@ func TestGetVersionReturnsError(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 		api.Router.ServeHTTP(w, r)
 
 		So(w.Code, ShouldEqual, http.StatusNotFound)
@@ -691,7 +698,7 @@ func TestGetVersionReturnsError(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 		api.Router.ServeHTTP(w, r)
 
 		So(w.Code, ShouldEqual, http.StatusNotFound)
@@ -732,7 +739,7 @@ func TestGetVersionReturnsError(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 		api.Router.ServeHTTP(w, r)
 
 		So(w.Code, ShouldEqual, http.StatusNotFound)
@@ -772,7 +779,7 @@ func TestGetVersionReturnsError(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 		api.Router.ServeHTTP(w, r)
 
 		So(w.Code, ShouldEqual, http.StatusNotFound)
@@ -797,7 +804,7 @@ func TestGetVersionReturnsError(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 		api.Router.ServeHTTP(w, r)
 
 		So(w.Code, ShouldEqual, http.StatusBadRequest)
@@ -823,7 +830,7 @@ func TestGetVersionReturnsError(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 		api.Router.ServeHTTP(w, r)
 
 		So(w.Code, ShouldEqual, http.StatusBadRequest)
@@ -847,7 +854,7 @@ func TestGetVersionReturnsError(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 		api.Router.ServeHTTP(w, r)
 
 		So(w.Code, ShouldEqual, http.StatusBadRequest)
@@ -896,7 +903,7 @@ func TestGetVersionReturnsError(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 		api.Router.ServeHTTP(w, r)
 
 		So(w.Code, ShouldEqual, http.StatusInternalServerError)
@@ -930,7 +937,7 @@ func TestPutVersionForbidden(t *testing.T) {
 		}
 
 		Convey("Given a valid request is executed", func() {
-			api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+			api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 			api.Router.ServeHTTP(w, r)
 
 			Convey("Then the request returns forbidden, with none of the expected calls made to the database", func() {
@@ -982,7 +989,7 @@ func TestPutVersionForbidden(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 		api.Router.ServeHTTP(w, r)
 
 		Convey("Then it returns 400 and update is not attempted", func() {
@@ -1015,7 +1022,7 @@ func TestPutVersionUnauthorised(t *testing.T) {
 		}
 
 		Convey("Given a valid request is executed", func() {
-			api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+			api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 			api.Router.ServeHTTP(w, r)
 
 			Convey("Then the request returns unauthorized, with none of the expected calls made to the database", func() {
@@ -1047,6 +1054,7 @@ func TestPutVersionReturnsSuccessfully(t *testing.T) {
 
 		isLocked := false
 		mockedDataStore := &storetest.StorerMock{
+			RunTransactionFunc: runTransaction,
 			CheckEditionExistsStaticFunc: func(ctx context.Context, datasetID, editionID, state string) error {
 				return errs.ErrEditionNotFound
 			},
@@ -1107,13 +1115,13 @@ func TestPutVersionReturnsSuccessfully(t *testing.T) {
 		}
 
 		auditServiceMock := &applicationMocks.AuditServiceMock{
-			RecordVersionAuditEventFunc: func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, version *models.Version) error {
+			RecordVersionAuditEventFunc: func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, previous, version *models.Version) error {
 				return nil
 			},
 		}
 
 		Convey("Given a valid request is executed", func() {
-			api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, auditServiceMock)
+			api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, auditServiceMock)
 			api.Router.ServeHTTP(w, r)
 
 			Convey("Then the request is successful, with the expected calls", func() {
@@ -1149,7 +1157,7 @@ func TestPutVersionReturnsSuccessfully(t *testing.T) {
 				return "", nil
 			}
 
-			api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, auditServiceMock)
+			api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, auditServiceMock)
 			api.Router.ServeHTTP(w, r)
 
 			Convey("Then the request is successful, with the expected calls including the update retry", func() {
@@ -1199,6 +1207,7 @@ func TestPutVersionReturnsSuccessfully(t *testing.T) {
 		InstanceID := "789"
 
 		mockedDataStore := &storetest.StorerMock{
+			RunTransactionFunc: runTransaction,
 			CheckEditionExistsStaticFunc: func(ctx context.Context, datasetID, editionID, state string) error {
 				return nil
 			},
@@ -1256,12 +1265,12 @@ func TestPutVersionReturnsSuccessfully(t *testing.T) {
 		}
 
 		auditServiceMock := &applicationMocks.AuditServiceMock{
-			RecordVersionAuditEventFunc: func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, version *models.Version) error {
+			RecordVersionAuditEventFunc: func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, previous, version *models.Version) error {
 				return nil
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, auditServiceMock)
+		api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, auditServiceMock)
 
 		api.Router.ServeHTTP(w, r)
 
@@ -1325,12 +1334,12 @@ func TestPutVersionReturnsSuccessfully(t *testing.T) {
 		}
 
 		auditServiceMock := &applicationMocks.AuditServiceMock{
-			RecordVersionAuditEventFunc: func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, version *models.Version) error {
+			RecordVersionAuditEventFunc: func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, previous, version *models.Version) error {
 				return nil
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, auditServiceMock)
+		api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, auditServiceMock)
 		api.Router.ServeHTTP(w, r)
 
 		Convey("Then it returns a 409 Conflict status", func() {
@@ -1407,12 +1416,12 @@ func TestPutVersionReturnsSuccessfully(t *testing.T) {
 		}
 
 		auditServiceMock := &applicationMocks.AuditServiceMock{
-			RecordVersionAuditEventFunc: func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, version *models.Version) error {
+			RecordVersionAuditEventFunc: func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, previous, version *models.Version) error {
 				return nil
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, auditServiceMock)
+		api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, auditServiceMock)
 		api.Router.ServeHTTP(w, r)
 
 		Convey("Then it returns a 409 Conflict status", func() {
@@ -1454,7 +1463,7 @@ func TestPutVersionReturnsSuccessfully(t *testing.T) {
 			},
 		}
 		auditServiceMock := &applicationMocks.AuditServiceMock{
-			RecordVersionAuditEventFunc: func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, version *models.Version) error {
+			RecordVersionAuditEventFunc: func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, previous, version *models.Version) error {
 				return nil
 			},
 		}
@@ -1462,6 +1471,7 @@ func TestPutVersionReturnsSuccessfully(t *testing.T) {
 		Convey("put version with CMD type", func() {
 			isLocked := false
 			mockedDataStore := &storetest.StorerMock{
+				RunTransactionFunc: runTransaction,
 				CheckEditionExistsStaticFunc: func(ctx context.Context, datasetID, editionID, state string) error {
 					return errs.ErrEditionNotFound
 				},
@@ -1495,7 +1505,7 @@ func TestPutVersionReturnsSuccessfully(t *testing.T) {
 				},
 			}
 
-			api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, auditServiceMock)
+			api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, auditServiceMock)
 			api.Router.ServeHTTP(w, r)
 
 			So(w.Code, ShouldEqual, http.StatusOK)
@@ -1519,6 +1529,7 @@ func TestPutVersionReturnsSuccessfully(t *testing.T) {
 
 		Convey("put version with Cantabular type and CMD mock", func() {
 			mockedDataStore := &storetest.StorerMock{
+				RunTransactionFunc: runTransaction,
 				GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
 					return &models.DatasetUpdate{}, nil
 				},
@@ -1544,7 +1555,7 @@ func TestPutVersionReturnsSuccessfully(t *testing.T) {
 				UnlockInstanceFunc: func(context.Context, string) {},
 			}
 
-			api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, auditServiceMock)
+			api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, auditServiceMock)
 			api.Router.ServeHTTP(w, r)
 
 			So(w.Code, ShouldEqual, http.StatusBadRequest)
@@ -1564,6 +1575,7 @@ func TestPutVersionReturnsSuccessfully(t *testing.T) {
 		Convey("put version with Cantabular type", func() {
 			isLocked := false
 			mockedDataStore := &storetest.StorerMock{
+				RunTransactionFunc: runTransaction,
 				GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
 					return &models.DatasetUpdate{}, nil
 				},
@@ -1636,6 +1648,7 @@ func TestPutVersionReturnsSuccessfully(t *testing.T) {
 
 		isLocked := false
 		mockedDataStore := &storetest.StorerMock{
+			RunTransactionFunc: runTransaction,
 			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
 				return &models.DatasetUpdate{}, nil
 			},
@@ -1678,12 +1691,12 @@ func TestPutVersionReturnsSuccessfully(t *testing.T) {
 		}
 
 		auditServiceMock := &applicationMocks.AuditServiceMock{
-			RecordVersionAuditEventFunc: func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, version *models.Version) error {
+			RecordVersionAuditEventFunc: func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, previous, version *models.Version) error {
 				return nil
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, auditServiceMock)
+		api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, auditServiceMock)
 		api.Router.ServeHTTP(w, r)
 
 		ctx := context.Background()
@@ -1742,13 +1755,14 @@ func TestPutVersionReturnsSuccessfully(t *testing.T) {
 		}
 
 		auditServiceMock := &applicationMocks.AuditServiceMock{
-			RecordVersionAuditEventFunc: func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, version *models.Version) error {
+			RecordVersionAuditEventFunc: func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, previous, version *models.Version) error {
 				return nil
 			},
 		}
 		Convey("And the datatype is CMD", func() {
 			isLocked := false
 			mockedDataStore := &storetest.StorerMock{
+				RunTransactionFunc: runTransaction,
 				CheckEditionExistsFunc: func(context.Context, string, string, string) error {
 					return nil
 				},
@@ -1838,7 +1852,7 @@ func TestPutVersionReturnsSuccessfully(t *testing.T) {
 				},
 			}
 
-			api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, auditServiceMock)
+			api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, auditServiceMock)
 			api.Router.ServeHTTP(w, r)
 
 			So(w.Code, ShouldEqual, http.StatusOK)
@@ -1870,6 +1884,7 @@ func TestPutVersionReturnsSuccessfully(t *testing.T) {
 		Convey("And the datatype is Cantabular", func() {
 			isLocked := false
 			mockedDataStore := &storetest.StorerMock{
+				RunTransactionFunc: runTransaction,
 				CheckEditionExistsFunc: func(context.Context, string, string, string) error {
 					return nil
 				},
@@ -2027,6 +2042,7 @@ func updateVersionDownloadTest(r *http.Request) {
 
 	isLocked := false
 	mockedDataStore := &storetest.StorerMock{
+		RunTransactionFunc: runTransaction,
 		GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
 			return &models.DatasetUpdate{
 				ID:      "123",
@@ -2102,12 +2118,12 @@ func updateVersionDownloadTest(r *http.Request) {
 	}
 
 	auditServiceMock := &applicationMocks.AuditServiceMock{
-		RecordVersionAuditEventFunc: func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, version *models.Version) error {
+		RecordVersionAuditEventFunc: func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, previous, version *models.Version) error {
 			return nil
 		},
 	}
 
-	api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, auditServiceMock)
+	api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, auditServiceMock)
 	api.Router.ServeHTTP(w, r)
 
 	So(w.Code, ShouldEqual, http.StatusOK)
@@ -2138,6 +2154,7 @@ func TestPutVersionGenerateDownloadsError(t *testing.T) {
 
 		isLocked := false
 		mockedDataStore := &storetest.StorerMock{
+			RunTransactionFunc: runTransaction,
 			CheckEditionExistsStaticFunc: func(ctx context.Context, datasetID, editionID, state string) error {
 				return errs.ErrEditionNotFound
 			},
@@ -2193,12 +2210,12 @@ func TestPutVersionGenerateDownloadsError(t *testing.T) {
 			}
 
 			auditServiceMock := &applicationMocks.AuditServiceMock{
-				RecordVersionAuditEventFunc: func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, version *models.Version) error {
+				RecordVersionAuditEventFunc: func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, previous, version *models.Version) error {
 					return nil
 				},
 			}
 
-			api := GetAPIWithCMDMocks(mockedDataStore, mockDownloadGenerator, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, auditServiceMock)
+			api := GetAPIWithCMDMocks(mockedDataStore, mockDownloadGenerator, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, auditServiceMock)
 			api.Router.ServeHTTP(w, r)
 
 			Convey("then an internal server error response is returned", func() {
@@ -2254,6 +2271,7 @@ func TestPutEmptyVersion(t *testing.T) {
 		v := getVersionAssociatedModel(models.Filterable)
 		isLocked := false
 		mockedDataStore := &storetest.StorerMock{
+			RunTransactionFunc: runTransaction,
 			CheckEditionExistsStaticFunc: func(ctx context.Context, datasetID, editionID, state string) error {
 				return errs.ErrEditionNotFound
 			},
@@ -2296,12 +2314,12 @@ func TestPutEmptyVersion(t *testing.T) {
 			}
 
 			auditServiceMock := &applicationMocks.AuditServiceMock{
-				RecordVersionAuditEventFunc: func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, version *models.Version) error {
+				RecordVersionAuditEventFunc: func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, previous, version *models.Version) error {
 					return nil
 				},
 			}
 
-			api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, auditServiceMock)
+			api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, auditServiceMock)
 			api.Router.ServeHTTP(w, r)
 
 			Convey("then a http status ok is returned", func() {
@@ -2325,6 +2343,7 @@ func TestPutEmptyVersion(t *testing.T) {
 		v := getVersionAssociatedModel(models.Static)
 		isLocked := false
 		mockedDataStore := &storetest.StorerMock{
+			RunTransactionFunc: runTransaction,
 			CheckEditionExistsStaticFunc: func(ctx context.Context, datasetID, editionID, state string) error {
 				return errs.ErrEditionNotFound
 			},
@@ -2371,11 +2390,11 @@ func TestPutEmptyVersion(t *testing.T) {
 			}
 
 			auditServiceMock := &applicationMocks.AuditServiceMock{
-				RecordVersionAuditEventFunc: func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, version *models.Version) error {
+				RecordVersionAuditEventFunc: func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, previous, version *models.Version) error {
 					return nil
 				},
 			}
-			api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, auditServiceMock)
+			api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, auditServiceMock)
 			api.Router.ServeHTTP(w, r)
 
 			Convey("then a http status ok is returned", func() {
@@ -2439,12 +2458,12 @@ func TestPutVersionReturnsError(t *testing.T) {
 		}
 
 		auditServiceMock := &applicationMocks.AuditServiceMock{
-			RecordVersionAuditEventFunc: func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, version *models.Version) error {
+			RecordVersionAuditEventFunc: func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, previous, version *models.Version) error {
 				return nil
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, auditServiceMock)
+		api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, auditServiceMock)
 
 		api.Router.ServeHTTP(w, r)
 		So(w.Code, ShouldEqual, http.StatusBadRequest)
@@ -2489,7 +2508,7 @@ func TestPutVersionReturnsError(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 		api.Router.ServeHTTP(w, r)
 
 		So(w.Code, ShouldEqual, http.StatusInternalServerError)
@@ -2537,7 +2556,7 @@ func TestPutVersionReturnsError(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 		api.Router.ServeHTTP(w, r)
 
 		So(w.Code, ShouldEqual, http.StatusBadRequest)
@@ -2576,7 +2595,7 @@ func TestPutVersionReturnsError(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 		api.Router.ServeHTTP(w, r)
 
 		So(w.Code, ShouldEqual, http.StatusBadRequest)
@@ -2615,7 +2634,7 @@ func TestPutVersionReturnsError(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 		api.Router.ServeHTTP(w, r)
 
 		So(w.Code, ShouldEqual, http.StatusBadRequest)
@@ -2676,12 +2695,12 @@ func TestPutVersionReturnsError(t *testing.T) {
 		}
 
 		auditServiceMock := &applicationMocks.AuditServiceMock{
-			RecordVersionAuditEventFunc: func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, version *models.Version) error {
+			RecordVersionAuditEventFunc: func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, previous, version *models.Version) error {
 				return nil
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, auditServiceMock)
+		api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, auditServiceMock)
 		api.Router.ServeHTTP(w, r)
 
 		So(w.Code, ShouldEqual, http.StatusNotFound)
@@ -2740,12 +2759,12 @@ func TestPutVersionReturnsError(t *testing.T) {
 		}
 
 		auditServiceMock := &applicationMocks.AuditServiceMock{
-			RecordVersionAuditEventFunc: func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, version *models.Version) error {
+			RecordVersionAuditEventFunc: func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, previous, version *models.Version) error {
 				return nil
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, auditServiceMock)
+		api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, auditServiceMock)
 
 		api.Router.ServeHTTP(w, r)
 		So(w.Code, ShouldEqual, http.StatusNotFound)
@@ -2773,6 +2792,7 @@ func TestPutVersionReturnsError(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
+			RunTransactionFunc: runTransaction,
 			CheckEditionExistsStaticFunc: func(ctx context.Context, datasetID, editionID, state string) error {
 				return errs.ErrEditionNotFound
 			},
@@ -2808,12 +2828,12 @@ func TestPutVersionReturnsError(t *testing.T) {
 		}
 
 		auditServiceMock := &applicationMocks.AuditServiceMock{
-			RecordVersionAuditEventFunc: func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, version *models.Version) error {
+			RecordVersionAuditEventFunc: func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, previous, version *models.Version) error {
 				return nil
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, auditServiceMock)
+		api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, auditServiceMock)
 
 		api.Router.ServeHTTP(w, r)
 		So(w.Code, ShouldEqual, http.StatusNotFound)
@@ -2857,7 +2877,7 @@ func TestPutVersionReturnsError(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 		api.Router.ServeHTTP(w, r)
 
 		So(w.Code, ShouldEqual, http.StatusUnauthorized)
@@ -2896,7 +2916,7 @@ func TestPutVersionReturnsError(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 
 		api.Router.ServeHTTP(w, r)
 		So(w.Code, ShouldEqual, http.StatusForbidden)
@@ -2924,6 +2944,7 @@ func TestPutVersionReturnsError(t *testing.T) {
 		w := httptest.NewRecorder()
 		isLocked := false
 		mockedDataStore := &storetest.StorerMock{
+			RunTransactionFunc: runTransaction,
 			CheckEditionExistsStaticFunc: func(ctx context.Context, datasetID, editionID, state string) error {
 				return errs.ErrEditionNotFound
 			},
@@ -2966,12 +2987,12 @@ func TestPutVersionReturnsError(t *testing.T) {
 		}
 
 		auditServiceMock := &applicationMocks.AuditServiceMock{
-			RecordVersionAuditEventFunc: func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, version *models.Version) error {
+			RecordVersionAuditEventFunc: func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, previous, version *models.Version) error {
 				return nil
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, auditServiceMock)
+		api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, auditServiceMock)
 		api.Router.ServeHTTP(w, r)
 
 		So(w.Code, ShouldEqual, http.StatusBadRequest)
@@ -3007,6 +3028,7 @@ func TestPutVersionReturnsError(t *testing.T) {
 
 		isLocked := false
 		mockedDataStore := &storetest.StorerMock{
+			RunTransactionFunc: runTransaction,
 			CheckEditionExistsStaticFunc: func(ctx context.Context, datasetID, editionID, state string) error {
 				return errs.ErrEditionNotFound
 			},
@@ -3112,12 +3134,12 @@ func TestPutVersionReturnsError(t *testing.T) {
 		}
 
 		auditServiceMock := &applicationMocks.AuditServiceMock{
-			RecordVersionAuditEventFunc: func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, version *models.Version) error {
+			RecordVersionAuditEventFunc: func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, previous, version *models.Version) error {
 				return nil
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, auditServiceMock)
+		api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, auditServiceMock)
 
 		api.Router.ServeHTTP(w, r)
 
@@ -3127,7 +3149,7 @@ func TestPutVersionReturnsError(t *testing.T) {
 		So(len(mockedDataStore.UpdateVersionCalls()), ShouldEqual, 1)
 		So(len(mockedDataStore.UpsertEditionCalls()), ShouldEqual, 1)
 		So(len(mockedDataStore.SetInstanceIsPublishedCalls()), ShouldEqual, 1)
-		So(len(mockedDataStore.UpsertDatasetCalls()), ShouldEqual, 0)
+		So(len(mockedDataStore.UpsertDatasetCalls()), ShouldEqual, 1)
 		So(len(mockedDataStore.UpdateDatasetWithAssociationCalls()), ShouldEqual, 0)
 		So(len(generatorMock.GenerateCalls()), ShouldEqual, 0)
 
@@ -3183,12 +3205,12 @@ func TestPutVersionReturnsError(t *testing.T) {
 		}
 
 		auditServiceMock := &applicationMocks.AuditServiceMock{
-			RecordVersionAuditEventFunc: func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, version *models.Version) error {
+			RecordVersionAuditEventFunc: func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, previous, version *models.Version) error {
 				return nil
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mocked, &mocks.DownloadsGeneratorMock{}, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, auditServiceMock)
+		api := GetAPIWithCMDMocks(mocked, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, auditServiceMock)
 		api.Router.ServeHTTP(w, r)
 
 		Convey("Then the API returns 400 with missing-format error", func() {
@@ -3240,12 +3262,12 @@ func TestPutVersionReturnsError(t *testing.T) {
 		}
 
 		auditServiceMock := &applicationMocks.AuditServiceMock{
-			RecordVersionAuditEventFunc: func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, version *models.Version) error {
+			RecordVersionAuditEventFunc: func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, previous, version *models.Version) error {
 				return nil
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mocked, &mocks.DownloadsGeneratorMock{}, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, auditServiceMock)
+		api := GetAPIWithCMDMocks(mocked, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, auditServiceMock)
 		api.Router.ServeHTTP(w, r)
 
 		Convey("Then API returns 400 with invalid-format error", func() {
@@ -3521,7 +3543,7 @@ func TestDetachVersionForbidden(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 
 		api.Router.ServeHTTP(w, r)
 
@@ -3567,7 +3589,7 @@ func TestDetachVersionUnauthorised(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 
 		api.Router.ServeHTTP(w, r)
 		Convey("Then the response code is 401 and no expected database calls are made.", t, func() {
@@ -3604,6 +3626,7 @@ func TestDetachVersionReturnOK(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
+			RunTransactionFunc: runTransaction,
 			GetEditionFunc: func(context.Context, string, string, string) (*models.EditionUpdate, error) {
 				return &models.EditionUpdate{
 					ID:      "test",
@@ -3644,7 +3667,7 @@ func TestDetachVersionReturnOK(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 
 		api.Router.ServeHTTP(w, r)
 
@@ -3670,6 +3693,7 @@ func TestDetachVersionReturnOK(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
+			RunTransactionFunc: runTransaction,
 			GetEditionFunc: func(context.Context, string, string, string) (*models.EditionUpdate, error) {
 				return &models.EditionUpdate{
 					ID:      "test",
@@ -3699,7 +3723,7 @@ func TestDetachVersionReturnOK(t *testing.T) {
 			IsStaticDatasetFunc: func(ctx context.Context, datasetID string) (bool, error) {
 				return false, nil
 			},
-			DeleteEditionFunc: func(ctx context.Context, editionID string) error {
+			DeleteEditionFunc: func(ctx context.Context, editionID string, deletion *models.Deletion) error {
 				return nil
 			},
 			RemoveDatasetVersionAndEditionLinksFunc: func(ctx context.Context, datasetID string) error {
@@ -3716,7 +3740,7 @@ func TestDetachVersionReturnOK(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 
 		api.Router.ServeHTTP(w, r)
 
@@ -3770,7 +3794,7 @@ func TestDetachVersionReturnsError(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 
 		api.Router.ServeHTTP(w, r)
 
@@ -3810,7 +3834,7 @@ func TestDetachVersionReturnsError(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 
 		api.Router.ServeHTTP(w, r)
 
@@ -3853,7 +3877,7 @@ func TestDetachVersionReturnsError(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 
 		api.Router.ServeHTTP(w, r)
 
@@ -3899,7 +3923,7 @@ func TestDetachVersionReturnsError(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 
 		api.Router.ServeHTTP(w, r)
 
@@ -3945,7 +3969,7 @@ func TestDetachVersionReturnsError(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 
 		api.Router.ServeHTTP(w, r)
 
@@ -3968,6 +3992,7 @@ func TestDetachVersionReturnsError(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
+			RunTransactionFunc: runTransaction,
 			IsStaticDatasetFunc: func(ctx context.Context, datasetID string) (bool, error) {
 				return false, nil
 			},
@@ -3999,7 +4024,7 @@ func TestDetachVersionReturnsError(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 
 		api.Router.ServeHTTP(w, r)
 
@@ -4023,6 +4048,7 @@ func TestDetachVersionReturnsError(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
+			RunTransactionFunc: runTransaction,
 			IsStaticDatasetFunc: func(ctx context.Context, datasetID string) (bool, error) {
 				return false, nil
 			},
@@ -4056,7 +4082,7 @@ func TestDetachVersionReturnsError(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 
 		api.Router.ServeHTTP(w, r)
 
@@ -4101,7 +4127,7 @@ func TestDetachVersionReturnsError(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 
 		api.Router.ServeHTTP(w, r)
 
@@ -4133,7 +4159,7 @@ func TestDetachVersionReturnsError(t *testing.T) {
 				return testEntityData, nil
 			},
 		}
-		api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 
 		api.Router.ServeHTTP(w, r)
 
@@ -4166,7 +4192,7 @@ func TestDetachVersionReturnsError(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 
 		api.Router.ServeHTTP(w, r)
 
@@ -4207,7 +4233,8 @@ func TestDeleteVersionStaticDatasetReturnOK(t *testing.T) {
 					State:   models.CreatedState,
 				}, nil
 			},
-			DeleteStaticDatasetVersionFunc: func(context.Context, string, string, int) error {
+			RunTransactionFunc: runTransaction,
+			DeleteStaticDatasetVersionFunc: func(context.Context, string, string, int, *models.Deletion) error {
 				return nil
 			},
 			UpsertDatasetFunc: func(ctx context.Context, ID string, datasetDoc *models.DatasetUpdate) error {
@@ -4224,12 +4251,12 @@ func TestDeleteVersionStaticDatasetReturnOK(t *testing.T) {
 			},
 		}
 		auditServiceMock := &applicationMocks.AuditServiceMock{
-			RecordVersionAuditEventFunc: func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, version *models.Version) error {
+			RecordVersionAuditEventFunc: func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, previous, version *models.Version) error {
 				return nil
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, auditServiceMock)
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, auditServiceMock)
 
 		api.Router.ServeHTTP(w, r)
 
@@ -4271,7 +4298,8 @@ func TestDeleteVersionStaticDatasetReturnError(t *testing.T) {
 					State:   models.CreatedState,
 				}, nil
 			},
-			DeleteStaticDatasetVersionFunc: func(context.Context, string, string, int) error {
+			RunTransactionFunc: runTransaction,
+			DeleteStaticDatasetVersionFunc: func(context.Context, string, string, int, *models.Deletion) error {
 				return nil
 			},
 			UpsertDatasetFunc: func(ctx context.Context, ID string, datasetDoc *models.DatasetUpdate) error {
@@ -4288,12 +4316,12 @@ func TestDeleteVersionStaticDatasetReturnError(t *testing.T) {
 			},
 		}
 		auditServiceMock := &applicationMocks.AuditServiceMock{
-			RecordVersionAuditEventFunc: func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, version *models.Version) error {
+			RecordVersionAuditEventFunc: func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, previous, version *models.Version) error {
 				return errors.New("failed to record audit event")
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, auditServiceMock)
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, auditServiceMock)
 
 		api.Router.ServeHTTP(w, r)
 
@@ -4322,7 +4350,7 @@ func TestDeleteVersionStaticDatasetReturnError(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 
 		api.Router.ServeHTTP(w, r)
 
@@ -4355,7 +4383,8 @@ func TestDeleteVersionStaticDatasetReturnError(t *testing.T) {
 			CheckEditionExistsStaticFunc: func(context.Context, string, string, string) error {
 				return errs.ErrEditionNotFound
 			},
-			DeleteStaticDatasetVersionFunc: func(context.Context, string, string, int) error {
+			RunTransactionFunc: runTransaction,
+			DeleteStaticDatasetVersionFunc: func(context.Context, string, string, int, *models.Deletion) error {
 				return errs.ErrVersionNotFound
 			},
 		}
@@ -4369,7 +4398,7 @@ func TestDeleteVersionStaticDatasetReturnError(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 		api.Router.ServeHTTP(w, r)
 
 		So(w.Code, ShouldEqual, http.StatusNotFound)
@@ -4416,7 +4445,7 @@ func TestDeleteVersionStaticDatasetReturnError(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 
 		api.Router.ServeHTTP(w, r)
 
@@ -4458,7 +4487,8 @@ func TestDeleteVersionStaticDatasetReturnError(t *testing.T) {
 					State:   models.PublishedState,
 				}, nil
 			},
-			DeleteStaticDatasetVersionFunc: func(context.Context, string, string, int) error {
+			RunTransactionFunc: runTransaction,
+			DeleteStaticDatasetVersionFunc: func(context.Context, string, string, int, *models.Deletion) error {
 				return errs.ErrDeletePublishedVersionForbidden
 			},
 		}
@@ -4472,7 +4502,7 @@ func TestDeleteVersionStaticDatasetReturnError(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 
 		api.Router.ServeHTTP(w, r)
 
@@ -4514,7 +4544,7 @@ func TestDeleteVersionStaticDatasetReturnError(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 
 		api.Router.ServeHTTP(w, r)
 
@@ -4558,7 +4588,7 @@ func TestDeleteVersionStaticDatasetReturnError(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 
 		api.Router.ServeHTTP(w, r)
 
@@ -4571,6 +4601,112 @@ func TestDeleteVersionStaticDatasetReturnError(t *testing.T) {
 	})
 }
 
+func TestRestoreVersion(t *testing.T) {
+	t.Parallel()
+
+	authorisationMock := &authMock.MiddlewareMock{
+		RequireFunc: func(permission string, handlerFunc http.HandlerFunc) http.HandlerFunc {
+			return handlerFunc
+		},
+		ParseFunc: func(token string) (*permissionsAPISDK.EntityData, error) {
+			return testEntityData, nil
+		},
+	}
+
+	newStore := func(isStatic bool, latestVersion int) *storetest.StorerMock {
+		return &storetest.StorerMock{
+			IsStaticDatasetFunc: func(context.Context, string) (bool, error) {
+				return isStatic, nil
+			},
+			RunTransactionFunc: runTransaction,
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
+				return &models.DatasetUpdate{ID: "123", Next: &models.Dataset{Type: models.Static.String(), Links: &models.DatasetLinks{}}}, nil
+			},
+			GetLatestVersionStaticFunc: func(context.Context, string, string, string) (*models.Version, error) {
+				return &models.Version{Version: latestVersion, State: models.PublishedState}, nil
+			},
+			RestoreStaticVersionFunc: func(context.Context, string, string, int) error {
+				return nil
+			},
+			GetVersionStaticFunc: func(context.Context, string, string, int, string) (*models.Version, error) {
+				return &models.Version{Version: 2, State: models.AssociatedState}, nil
+			},
+			UpsertDatasetFunc: func(context.Context, string, *models.DatasetUpdate) error {
+				return nil
+			},
+		}
+	}
+
+	Convey("A successful request to restore a deleted static version returns 204 No Content", t, func() {
+		r := createRequestWithAuth("POST", "http://localhost:22000/datasets/123/editions/2017/versions/2/restore", nil)
+		w := httptest.NewRecorder()
+
+		mockedDataStore := newStore(true, 1)
+		auditServiceMock := &applicationMocks.AuditServiceMock{
+			RecordVersionAuditEventFunc: func(context.Context, models.RequestedBy, models.Action, string, *models.Version, *models.Version) error {
+				return nil
+			},
+		}
+
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, auditServiceMock)
+		api.Router.ServeHTTP(w, r)
+
+		So(w.Code, ShouldEqual, http.StatusNoContent)
+		So(mockedDataStore.RestoreStaticVersionCalls(), ShouldHaveLength, 1)
+		So(mockedDataStore.RestoreStaticVersionCalls()[0].Edition, ShouldEqual, "2017")
+		So(mockedDataStore.RestoreStaticVersionCalls()[0].Version, ShouldEqual, 2)
+		So(mockedDataStore.UpsertDatasetCalls(), ShouldHaveLength, 1)
+		So(auditServiceMock.RecordVersionAuditEventCalls(), ShouldHaveLength, 1)
+		So(auditServiceMock.RecordVersionAuditEventCalls()[0].Action, ShouldEqual, models.ActionRestore)
+		So(auditServiceMock.RecordVersionAuditEventCalls()[0].Resource, ShouldEqual, "/datasets/123/editions/2017/versions/2")
+	})
+
+	Convey("When a later version of the edition has been created, return status conflict", t, func() {
+		r := createRequestWithAuth("POST", "http://localhost:22000/datasets/123/editions/2017/versions/2/restore", nil)
+		w := httptest.NewRecorder()
+
+		mockedDataStore := newStore(true, 3)
+		auditServiceMock := &applicationMocks.AuditServiceMock{}
+
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, auditServiceMock)
+		api.Router.ServeHTTP(w, r)
+
+		So(w.Code, ShouldEqual, http.StatusConflict)
+		So(w.Body.String(), ShouldContainSubstring, errs.ErrVersionSuperseded.Error())
+		So(mockedDataStore.RestoreStaticVersionCalls(), ShouldBeEmpty)
+		So(auditServiceMock.RecordVersionAuditEventCalls(), ShouldBeEmpty)
+	})
+
+	Convey("When the version is not deleted, return status not found", t, func() {
+		r := createRequestWithAuth("POST", "http://localhost:22000/datasets/123/editions/2017/versions/2/restore", nil)
+		w := httptest.NewRecorder()
+
+		mockedDataStore := newStore(true, 1)
+		mockedDataStore.RestoreStaticVersionFunc = func(context.Context, string, string, int) error {
+			return errs.ErrVersionNotFound
+		}
+
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api.Router.ServeHTTP(w, r)
+
+		So(w.Code, ShouldEqual, http.StatusNotFound)
+		So(mockedDataStore.UpsertDatasetCalls(), ShouldBeEmpty)
+	})
+
+	Convey("When the dataset is not static, return status method not allowed", t, func() {
+		r := createRequestWithAuth("POST", "http://localhost:22000/datasets/123/editions/2017/versions/2/restore", nil)
+		w := httptest.NewRecorder()
+
+		mockedDataStore := newStore(false, 1)
+
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api.Router.ServeHTTP(w, r)
+
+		So(w.Code, ShouldEqual, http.StatusMethodNotAllowed)
+		So(mockedDataStore.RestoreStaticVersionCalls(), ShouldBeEmpty)
+	})
+}
+
 func assertInternalServerErr(w *httptest.ResponseRecorder) {
 	So(w.Code, ShouldEqual, http.StatusInternalServerError)
 
@@ -4602,7 +4738,7 @@ func TestPutStateForbidden(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 		api.Router.ServeHTTP(w, r)
 
 		Convey("Then the response code is 403 and no expected database calls are made", func() {
@@ -4639,7 +4775,7 @@ func TestPutStateUnauthorised(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 		api.Router.ServeHTTP(w, r)
 
 		Convey("Then the response code is 401 and no expected database calls are made", func() {
@@ -4663,6 +4799,10 @@ func TestPutStateReturnsOk(t *testing.T) {
 		w := httptest.NewRecorder()
 
 		mockedDataStore := &storetest.StorerMock{
+			RunTransactionFunc: runTransaction,
+			IsStaticDatasetFunc: func(context.Context, string) (bool, error) {
+				return true, nil
+			},
 			GetVersionStaticFunc: func(ctx context.Context, datasetID string, editionID string, version int, state string) (*models.Version, error) {
 				jsonData := `{
 						"alerts": [
@@ -4806,13 +4946,16 @@ func TestPutStateReturnsOk(t *testing.T) {
 		}
 
 		auditServiceMock := &applicationMocks.AuditServiceMock{
-			RecordVersionAuditEventFunc: func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, version *models.Version) error {
+			RecordVersionAuditEventFunc: func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, previous, version *models.Version) error {
 				return nil
 			},
 		}
 
-		scuProducerMock := getSearchContentUpdatedMock()
-		searchContentUpdated := SearchContentUpdatedProducer{Producer: scuProducerMock}
+		searchContentUpdatedMock := &mocks.OutboxMock{
+			WriteFunc: func(context.Context, []byte) error {
+				return nil
+			},
+		}
 
 		cloudflareMock := &cloudflareMocks.ClienterMock{
 			PurgeByPrefixesFunc: func(ctx context.Context, prefixes []string) error {
@@ -4820,7 +4963,7 @@ func TestPutStateReturnsOk(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, searchContentUpdated, cloudflareMock, auditServiceMock)
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, searchContentUpdatedMock, cloudflareMock, auditServiceMock)
 		api.Router.ServeHTTP(w, r)
 
 		So(w.Code, ShouldEqual, http.StatusOK)
@@ -4833,10 +4976,12 @@ func TestPutStateReturnsOk(t *testing.T) {
 		So(mockedDataStore.UpsertVersionStaticCalls(), ShouldHaveLength, 1)
 		So(mockedDataStore.UpsertDatasetCalls(), ShouldHaveLength, 1)
 		So(mockedDataStore.CheckEditionExistsStaticCalls(), ShouldHaveLength, 1)
-		So(len(scuProducerMock.OutputCalls()), ShouldEqual, 1)
+		So(searchContentUpdatedMock.WriteCalls(), ShouldHaveLength, 1)
 		So(cloudflareMock.PurgeByPrefixesCalls(), ShouldHaveLength, 1)
 		So(auditServiceMock.RecordVersionAuditEventCalls(), ShouldHaveLength, 1)
 		So(auditServiceMock.RecordVersionAuditEventCalls()[0].Resource, ShouldEqual, "/datasets/test-static-dataset/editions/test-edition-1/versions/1/state")
+		So(auditServiceMock.RecordVersionAuditEventCalls()[0].Previous, ShouldNotBeNil)
+		So(auditServiceMock.RecordVersionAuditEventCalls()[0].Previous.State, ShouldNotEqual, auditServiceMock.RecordVersionAuditEventCalls()[0].Version.State)
 
 		Convey("And the correct URL's should have been purged", func() {
 			expectedPrefixes := []string{
@@ -4871,7 +5016,7 @@ func TestPutStateReturnsError(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 
 		api.putState(w, r)
 
@@ -4894,7 +5039,7 @@ func TestPutStateReturnsError(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 
 		api.Router.ServeHTTP(w, r)
 
@@ -4917,7 +5062,7 @@ func TestPutStateReturnsError(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 
 		api.Router.ServeHTTP(w, r)
 
@@ -4930,6 +5075,9 @@ func TestPutStateReturnsError(t *testing.T) {
 		w := httptest.NewRecorder()
 
 		mockedDataStore := &storetest.StorerMock{
+			IsStaticDatasetFunc: func(context.Context, string) (bool, error) {
+				return true, nil
+			},
 			GetVersionStaticFunc: func(ctx context.Context, datasetID string, editionID string, version int, state string) (*models.Version, error) {
 				return nil, errs.ErrVersionNotFound
 			},
@@ -4944,7 +5092,7 @@ func TestPutStateReturnsError(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 
 		api.Router.ServeHTTP(w, r)
 
@@ -4957,6 +5105,9 @@ func TestPutStateReturnsError(t *testing.T) {
 		w := httptest.NewRecorder()
 
 		mockedDataStore := &storetest.StorerMock{
+			IsStaticDatasetFunc: func(context.Context, string) (bool, error) {
+				return true, nil
+			},
 			GetVersionStaticFunc: func(ctx context.Context, datasetID string, editionID string, version int, state string) (*models.Version, error) {
 				return nil, errors.New("some error")
 			},
@@ -4971,7 +5122,7 @@ func TestPutStateReturnsError(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 
 		api.Router.ServeHTTP(w, r)
 
@@ -5313,6 +5464,7 @@ func TestPutVersionEditionValidationNonStatic(t *testing.T) {
 
 		isLocked := false
 		mockedDataStore := &storetest.StorerMock{
+			RunTransactionFunc: runTransaction,
 			CheckEditionExistsFunc: func(context.Context, string, string, string) error {
 				return nil
 			},
@@ -5353,7 +5505,7 @@ func TestPutVersionEditionValidationNonStatic(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		api := GetAPIWithCMDMocks(mockedDataStore, generatorMock, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 		api.Router.ServeHTTP(w, r)
 
 		So(w.Code, ShouldEqual, http.StatusBadRequest)
@@ -5516,7 +5668,7 @@ func TestGetVersionRecordsAuditEvent(t *testing.T) {
 		}
 
 		auditServiceMock := &applicationMocks.AuditServiceMock{
-			RecordVersionAuditEventFunc: func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, versionDoc *models.Version) error {
+			RecordVersionAuditEventFunc: func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, previous, versionDoc *models.Version) error {
 				return nil
 			},
 		}
@@ -5533,7 +5685,7 @@ func TestGetVersionRecordsAuditEvent(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, auditServiceMock)
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, auditServiceMock)
 
 		Convey("When we call the GET version endpoint", func() {
 			api.Router.ServeHTTP(w, r)
@@ -5597,7 +5749,7 @@ func TestGetVersionDoesNotRecordAuditEventForUnauthorisedUser(t *testing.T) {
 		}
 
 		auditServiceMock := &applicationMocks.AuditServiceMock{
-			RecordVersionAuditEventFunc: func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, versionDoc *models.Version) error {
+			RecordVersionAuditEventFunc: func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, previous, versionDoc *models.Version) error {
 				return nil
 			},
 		}
@@ -5614,7 +5766,7 @@ func TestGetVersionDoesNotRecordAuditEventForUnauthorisedUser(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, auditServiceMock)
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, auditServiceMock)
 
 		Convey("When we call the GET version endpoint", func() {
 			api.Router.ServeHTTP(w, r)
@@ -5673,7 +5825,7 @@ func TestGetVersionAuditEventLogsErrorButContinues(t *testing.T) {
 		}
 
 		auditServiceMock := &applicationMocks.AuditServiceMock{
-			RecordVersionAuditEventFunc: func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, versionDoc *models.Version) error {
+			RecordVersionAuditEventFunc: func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, previous, versionDoc *models.Version) error {
 				return errors.New("audit service error")
 			},
 		}
@@ -5690,7 +5842,7 @@ func TestGetVersionAuditEventLogsErrorButContinues(t *testing.T) {
 			},
 		}
 
-		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, SearchContentUpdatedProducer{}, &cloudflareMocks.ClienterMock{}, auditServiceMock)
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, auditServiceMock)
 
 		Convey("When we call the GET version endpoint", func() {
 			api.Router.ServeHTTP(w, r)
@@ -5705,3 +5857,452 @@ func TestGetVersionAuditEventLogsErrorButContinues(t *testing.T) {
 		})
 	})
 }
+
+func TestPutStateWithdrawsVersion(t *testing.T) {
+	t.Parallel()
+
+	publishedVersion := func() *models.Version {
+		return &models.Version{
+			ID:          "a1b2c3",
+			Edition:     "2025",
+			ReleaseDate: "2025-01-15",
+			State:       models.PublishedState,
+			Type:        models.Static.String(),
+			Version:     1,
+			Links: &models.VersionLinks{
+				Dataset: &models.LinkObject{ID: "cpih01"},
+				Edition: &models.LinkObject{ID: "2025"},
+				Version: &models.LinkObject{ID: "1", HRef: "http://localhost:22000/datasets/cpih01/editions/2025/versions/1"},
+			},
+		}
+	}
+
+	newStore := func() *storetest.StorerMock {
+		return &storetest.StorerMock{
+			RunTransactionFunc: runTransaction,
+			IsStaticDatasetFunc: func(context.Context, string) (bool, error) {
+				return true, nil
+			},
+			GetVersionStaticFunc: func(context.Context, string, string, int, string) (*models.Version, error) {
+				return publishedVersion(), nil
+			},
+			AcquireVersionsLockFunc: func(context.Context, string) (string, error) {
+				return testLockID, nil
+			},
+			UnlockVersionsFunc: func(context.Context, string) {},
+			CheckEditionExistsStaticFunc: func(context.Context, string, string, string) error {
+				return nil
+			},
+			UpdateVersionStaticFunc: func(context.Context, *models.Version, *models.Version, string) (string, error) {
+				return "", nil
+			},
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
+				latestVersion := func() *models.LinkObject {
+					return &models.LinkObject{ID: "1", HRef: "/datasets/cpih01/editions/2025/versions/1"}
+				}
+				return &models.DatasetUpdate{
+					ID:      "cpih01",
+					Current: &models.Dataset{Links: &models.DatasetLinks{LatestVersion: latestVersion()}},
+					Next:    &models.Dataset{Links: &models.DatasetLinks{LatestVersion: latestVersion()}},
+				}, nil
+			},
+			GetAllStaticVersionsFunc: func(context.Context, string, string, int, int) ([]*models.Version, int, error) {
+				return nil, 0, errs.ErrVersionsNotFound
+			},
+			UpsertDatasetFunc: func(context.Context, string, *models.DatasetUpdate) error {
+				return nil
+			},
+		}
+	}
+
+	authorisationMock := &authMock.MiddlewareMock{
+		RequireFunc: func(permission string, handlerFunc http.HandlerFunc) http.HandlerFunc {
+			return handlerFunc
+		},
+		ParseFunc: func(token string) (*permissionsAPISDK.EntityData, error) {
+			return testEntityData, nil
+		},
+	}
+
+	Convey("Given a published static version", t, func() {
+		mockedDataStore := newStore()
+		auditServiceMock := &applicationMocks.AuditServiceMock{
+			RecordVersionAuditEventFunc: func(context.Context, models.RequestedBy, models.Action, string, *models.Version, *models.Version) error {
+				return nil
+			},
+		}
+		cloudflareMock := &cloudflareMocks.ClienterMock{
+			PurgeByPrefixesFunc: func(context.Context, []string) error {
+				return nil
+			},
+		}
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, cloudflareMock, auditServiceMock)
+
+		Convey("When it is withdrawn with a reason", func() {
+			r := createRequestWithAuth("PUT", "http://localhost:22000/datasets/cpih01/editions/2025/versions/1/state", bytes.NewBufferString(`{"state":"withdrawn","reason":"incorrect figures"}`))
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then the version is withdrawn with the reason", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(mockedDataStore.UpdateVersionStaticCalls(), ShouldHaveLength, 1)
+				versionUpdate := mockedDataStore.UpdateVersionStaticCalls()[0].VersionUpdate
+				So(versionUpdate.State, ShouldEqual, models.WithdrawnState)
+				So(versionUpdate.Withdrawal.Reason, ShouldEqual, "incorrect figures")
+				So(versionUpdate.Withdrawal.WithdrawnBy, ShouldEqual, testEntityData.UserID)
+			})
+
+			Convey("Then the withdrawal is added to the state history of the version, with the reason as its comment", func() {
+				history := mockedDataStore.UpdateVersionStaticCalls()[0].VersionUpdate.StateHistory
+				So(history, ShouldHaveLength, 1)
+				So(history[0].From, ShouldEqual, models.PublishedState)
+				So(history[0].To, ShouldEqual, models.WithdrawnState)
+				So(history[0].ChangedBy, ShouldEqual, testEntityData.UserID)
+				So(history[0].Comment, ShouldEqual, "incorrect figures")
+			})
+
+			Convey("Then the latest version link of the dataset is removed, as the edition has no other published versions", func() {
+				So(mockedDataStore.UpsertDatasetCalls(), ShouldHaveLength, 1)
+				So(mockedDataStore.UpsertDatasetCalls()[0].DatasetDoc.Current.Links.LatestVersion, ShouldBeNil)
+				So(mockedDataStore.UpsertDatasetCalls()[0].DatasetDoc.Next.Links.LatestVersion, ShouldBeNil)
+			})
+
+			Convey("Then the search content deleted event is written, the cache is purged and the change is audited", func() {
+				searchContentDeleted := api.smDatasetAPI.SearchContentDeleted.(*mocks.OutboxMock)
+				So(searchContentDeleted.WriteCalls(), ShouldHaveLength, 1)
+				So(string(searchContentDeleted.WriteCalls()[0].Message), ShouldEqual, `{"uri":"/datasets/cpih01/editions/2025/versions/1"}`)
+				So(cloudflareMock.PurgeByPrefixesCalls(), ShouldHaveLength, 1)
+				So(auditServiceMock.RecordVersionAuditEventCalls(), ShouldHaveLength, 1)
+				So(auditServiceMock.RecordVersionAuditEventCalls()[0].Version.State, ShouldEqual, models.WithdrawnState)
+			})
+		})
+
+		Convey("When it is withdrawn without a reason", func() {
+			r := createRequestWithAuth("PUT", "http://localhost:22000/datasets/cpih01/editions/2025/versions/1/state", bytes.NewBufferString(`{"state":"withdrawn"}`))
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then a bad request status is returned and the version is not changed", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+				So(w.Body.String(), ShouldContainSubstring, models.ErrWithdrawnVersionReasonMissing.Error())
+				So(mockedDataStore.AcquireVersionsLockCalls(), ShouldBeEmpty)
+				So(mockedDataStore.UpdateVersionStaticCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("Given a published filterable version", t, func() {
+		latestVersion := func() *models.LinkObject {
+			return &models.LinkObject{ID: "1", HRef: "/datasets/cpih01/editions/2025/versions/1"}
+		}
+		mockedDataStore := &storetest.StorerMock{
+			RunTransactionFunc: runTransaction,
+			IsStaticDatasetFunc: func(context.Context, string) (bool, error) {
+				return false, nil
+			},
+			GetVersionFunc: func(context.Context, string, string, int, string) (*models.Version, error) {
+				version := publishedVersion()
+				version.Type = models.Filterable.String()
+				return version, nil
+			},
+			AcquireInstanceLockFunc: func(context.Context, string) (string, error) {
+				return testLockID, nil
+			},
+			UnlockInstanceFunc: func(context.Context, string) {},
+			CheckEditionExistsFunc: func(context.Context, string, string, string) error {
+				return nil
+			},
+			UpdateVersionFunc: func(context.Context, *models.Version, *models.Version, string) (string, error) {
+				return "", nil
+			},
+			GetEditionFunc: func(context.Context, string, string, string) (*models.EditionUpdate, error) {
+				return &models.EditionUpdate{
+					ID:      "cpih01-2025",
+					Current: &models.Edition{Links: &models.EditionUpdateLinks{LatestVersion: latestVersion()}},
+					Next:    &models.Edition{Links: &models.EditionUpdateLinks{LatestVersion: latestVersion()}},
+				}, nil
+			},
+			UpsertEditionFunc: func(context.Context, string, string, *models.EditionUpdate) error {
+				return nil
+			},
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
+				return &models.DatasetUpdate{
+					ID:      "cpih01",
+					Current: &models.Dataset{Links: &models.DatasetLinks{LatestVersion: latestVersion()}},
+					Next:    &models.Dataset{Links: &models.DatasetLinks{LatestVersion: latestVersion()}},
+				}, nil
+			},
+			GetLatestVersionFunc: func(context.Context, string, string, string) (*models.Version, error) {
+				return nil, errs.ErrVersionNotFound
+			},
+			UpsertDatasetFunc: func(context.Context, string, *models.DatasetUpdate) error {
+				return nil
+			},
+		}
+		auditServiceMock := &applicationMocks.AuditServiceMock{
+			RecordVersionAuditEventFunc: func(context.Context, models.RequestedBy, models.Action, string, *models.Version, *models.Version) error {
+				return nil
+			},
+		}
+		cloudflareMock := &cloudflareMocks.ClienterMock{
+			PurgeByPrefixesFunc: func(context.Context, []string) error {
+				return nil
+			},
+		}
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, cloudflareMock, auditServiceMock)
+
+		Convey("When it is withdrawn with a reason", func() {
+			r := createRequestWithAuth("PUT", "http://localhost:22000/datasets/cpih01/editions/2025/versions/1/state", bytes.NewBufferString(`{"state":"withdrawn","reason":"incorrect figures"}`))
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then the instance of the version is withdrawn with the reason", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(mockedDataStore.AcquireInstanceLockCalls(), ShouldHaveLength, 1)
+				So(mockedDataStore.UpdateVersionCalls(), ShouldHaveLength, 1)
+				versionUpdate := mockedDataStore.UpdateVersionCalls()[0].Version
+				So(versionUpdate.State, ShouldEqual, models.WithdrawnState)
+				So(versionUpdate.Withdrawal.Reason, ShouldEqual, "incorrect figures")
+			})
+
+			Convey("Then the latest version links of the edition and dataset are removed, as there are no other published versions", func() {
+				So(mockedDataStore.UpsertEditionCalls(), ShouldHaveLength, 1)
+				So(mockedDataStore.UpsertEditionCalls()[0].EditionDoc.Current.Links.LatestVersion, ShouldBeNil)
+				So(mockedDataStore.UpsertDatasetCalls(), ShouldHaveLength, 1)
+				So(mockedDataStore.UpsertDatasetCalls()[0].DatasetDoc.Current.Links.LatestVersion, ShouldBeNil)
+			})
+
+			Convey("Then the search content deleted event is written and the cache is purged", func() {
+				searchContentDeleted := api.smDatasetAPI.SearchContentDeleted.(*mocks.OutboxMock)
+				So(searchContentDeleted.WriteCalls(), ShouldHaveLength, 1)
+				So(cloudflareMock.PurgeByPrefixesCalls(), ShouldHaveLength, 1)
+			})
+		})
+	})
+}
+
+func TestGetVersionReturnsTombstoneForWithdrawnVersion(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given a static version that has been withdrawn", t, func() {
+		withdrawnAt := time.Date(2025, 3, 1, 9, 30, 0, 0, time.UTC)
+		mockedDataStore := &storetest.StorerMock{
+			IsStaticDatasetFunc: func(context.Context, string) (bool, error) {
+				return true, nil
+			},
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
+				return &models.DatasetUpdate{ID: "cpih01", Next: &models.Dataset{ID: "cpih01", Type: models.Static.String()}}, nil
+			},
+			CheckEditionExistsStaticFunc: func(context.Context, string, string, string) error {
+				return nil
+			},
+			GetVersionStaticFunc: func(_ context.Context, _, _ string, _ int, state string) (*models.Version, error) {
+				if state == models.PublishedState {
+					return nil, errs.ErrVersionNotFound
+				}
+				return &models.Version{
+					Edition:    "2025",
+					Version:    1,
+					State:      models.WithdrawnState,
+					Withdrawal: &models.Withdrawal{Reason: "incorrect figures", WithdrawnAt: withdrawnAt, WithdrawnBy: "publisher@ons.gov.uk"},
+				}, nil
+			},
+			GetVersionFunc: func(context.Context, string, string, int, string) (*models.Version, error) {
+				return nil, errs.ErrVersionNotFound
+			},
+		}
+
+		authorisationMock := &authMock.MiddlewareMock{
+			RequireFunc: func(permission string, handlerFunc http.HandlerFunc) http.HandlerFunc {
+				return handlerFunc
+			},
+			RequireWithAttributesFunc: func(permission string, handlerFunc http.HandlerFunc, getAttributes authorisation.GetAttributesFromRequest) http.HandlerFunc {
+				return handlerFunc
+			},
+			ParseFunc: func(token string) (*permissionsAPISDK.EntityData, error) {
+				return nil, errors.New("no token provided")
+			},
+		}
+
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+
+		expectedTombstone := models.VersionTombstone{
+			DatasetID:   "cpih01",
+			Edition:     "2025",
+			Version:     1,
+			State:       models.WithdrawnState,
+			Reason:      "incorrect figures",
+			WithdrawnAt: withdrawnAt,
+		}
+
+		for _, path := range []string{
+			"/datasets/cpih01/editions/2025/versions/1",
+			"/datasets/cpih01/editions/2025/versions/1/metadata",
+			"/datasets/cpih01/editions/2025/versions/1/dimensions",
+			"/datasets/cpih01/editions/2025/versions/1/dimensions/aggregate/options",
+		} {
+			Convey("When the public request "+path, func() {
+				r := httptest.NewRequest("GET", "http://localhost:22000"+path, http.NoBody)
+				w := httptest.NewRecorder()
+				api.Router.ServeHTTP(w, r)
+
+				Convey("Then a gone status is returned with a tombstone giving the reason", func() {
+					So(w.Code, ShouldEqual, http.StatusGone)
+
+					var tombstone models.VersionTombstone
+					So(json.Unmarshal(w.Body.Bytes(), &tombstone), ShouldBeNil)
+					So(tombstone, ShouldResemble, expectedTombstone)
+					So(w.Body.String(), ShouldNotContainSubstring, "publisher@ons.gov.uk")
+				})
+			})
+		}
+	})
+}
+
+func TestPutStateRejectsApprovedVersion(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given an approved static version", t, func() {
+		mockedDataStore := &storetest.StorerMock{
+			RunTransactionFunc: runTransaction,
+			IsStaticDatasetFunc: func(context.Context, string) (bool, error) {
+				return true, nil
+			},
+			GetVersionStaticFunc: func(context.Context, string, string, int, string) (*models.Version, error) {
+				return &models.Version{
+					ID:          "a1b2c3",
+					Edition:     "2025",
+					ReleaseDate: "2025-01-15",
+					State:       models.ApprovedState,
+					Type:        models.Static.String(),
+					Version:     1,
+				}, nil
+			},
+			AcquireVersionsLockFunc: func(context.Context, string) (string, error) {
+				return testLockID, nil
+			},
+			UnlockVersionsFunc: func(context.Context, string) {},
+			CheckEditionExistsStaticFunc: func(context.Context, string, string, string) error {
+				return nil
+			},
+			UpdateVersionStaticFunc: func(context.Context, *models.Version, *models.Version, string) (string, error) {
+				return "", nil
+			},
+		}
+
+		authorisationMock := &authMock.MiddlewareMock{
+			RequireFunc: func(permission string, handlerFunc http.HandlerFunc) http.HandlerFunc {
+				return handlerFunc
+			},
+			ParseFunc: func(token string) (*permissionsAPISDK.EntityData, error) {
+				return testEntityData, nil
+			},
+		}
+
+		auditServiceMock := &applicationMocks.AuditServiceMock{
+			RecordVersionAuditEventFunc: func(context.Context, models.RequestedBy, models.Action, string, *models.Version, *models.Version) error {
+				return nil
+			},
+		}
+
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, auditServiceMock)
+
+		Convey("When it is rejected with a comment", func() {
+			r := createRequestWithAuth("PUT", "http://localhost:22000/datasets/cpih01/editions/2025/versions/1/state", bytes.NewBufferString(`{"state":"associated","comment":"the figures do not match the source"}`))
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then the version is moved back to associated with the review comment, and the change is audited", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(mockedDataStore.UpdateVersionStaticCalls(), ShouldHaveLength, 1)
+				versionUpdate := mockedDataStore.UpdateVersionStaticCalls()[0].VersionUpdate
+				So(versionUpdate.State, ShouldEqual, models.AssociatedState)
+				So(versionUpdate.ReviewComments, ShouldHaveLength, 1)
+				So(versionUpdate.ReviewComments[0].Comment, ShouldEqual, "the figures do not match the source")
+				So(versionUpdate.ReviewComments[0].CommentedBy, ShouldEqual, testEntityData.UserID)
+				So(auditServiceMock.RecordVersionAuditEventCalls(), ShouldHaveLength, 1)
+				So(auditServiceMock.RecordVersionAuditEventCalls()[0].Version.ReviewComments, ShouldResemble, versionUpdate.ReviewComments)
+			})
+		})
+
+		Convey("When it is rejected without a comment", func() {
+			r := createRequestWithAuth("PUT", "http://localhost:22000/datasets/cpih01/editions/2025/versions/1/state", bytes.NewBufferString(`{"state":"associated"}`))
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then a bad request status is returned and the version is not changed", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+				So(w.Body.String(), ShouldContainSubstring, models.ErrRejectedVersionCommentMissing.Error())
+				So(mockedDataStore.UpdateVersionStaticCalls(), ShouldBeEmpty)
+				So(auditServiceMock.RecordVersionAuditEventCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestPutStateReturnsGuardValidationErrors(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given an associated static version without distributions or a quality designation", t, func() {
+		mockedDataStore := &storetest.StorerMock{
+			IsStaticDatasetFunc: func(context.Context, string) (bool, error) {
+				return true, nil
+			},
+			GetVersionStaticFunc: func(context.Context, string, string, int, string) (*models.Version, error) {
+				return &models.Version{
+					ID:          "a1b2c3",
+					Edition:     "2025",
+					ReleaseDate: "2025-01-15",
+					State:       models.AssociatedState,
+					Type:        models.Static.String(),
+					Version:     1,
+				}, nil
+			},
+			AcquireVersionsLockFunc: func(context.Context, string) (string, error) {
+				return testLockID, nil
+			},
+			UnlockVersionsFunc: func(context.Context, string) {},
+			CheckEditionExistsStaticFunc: func(context.Context, string, string, string) error {
+				return nil
+			},
+		}
+
+		authorisationMock := &authMock.MiddlewareMock{
+			RequireFunc: func(permission string, handlerFunc http.HandlerFunc) http.HandlerFunc {
+				return handlerFunc
+			},
+			ParseFunc: func(token string) (*permissionsAPISDK.EntityData, error) {
+				return testEntityData, nil
+			},
+		}
+
+		auditServiceMock := &applicationMocks.AuditServiceMock{}
+		api := GetAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, nil, &cloudflareMocks.ClienterMock{}, auditServiceMock)
+		api.smDatasetAPI.StateMachine = application.NewStateMachine(testContext, []application.State{application.Approved}, []application.Transition{{
+			Label:               "approved",
+			TargetState:         application.Approved,
+			AllowedSourceStates: []string{"associated"},
+			Type:                "static",
+			Guards:              []application.Guard{application.HasDistributions, application.HasQualityDesignation},
+		}}, store.DataStore{Backend: mockedDataStore})
+
+		Convey("When it is approved", func() {
+			r := createRequestWithAuth("PUT", "http://localhost:22000/datasets/cpih01/editions/2025/versions/1/state", bytes.NewBufferString(`{"state":"approved"}`))
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then a bad request status is returned with a validation error for each failed guard", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+				So(w.Header().Get("Content-Type"), ShouldEqual, "application/json")
+
+				var errorResponse models.ErrorResponse
+				So(json.Unmarshal(w.Body.Bytes(), &errorResponse), ShouldBeNil)
+				So(errorResponse.Errors, ShouldResemble, []models.Error{
+					{Code: models.ErrTransitionGuardFailed, Description: models.ErrMissingDistributionsDescription},
+					{Code: models.ErrTransitionGuardFailed, Description: models.ErrMissingQualityDesignationDescription},
+				})
+				So(auditServiceMock.RecordVersionAuditEventCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+}
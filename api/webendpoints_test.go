@@ -16,6 +16,7 @@ import (
 	"github.com/ONSdigital/dp-dataset-api/config"
 	"github.com/ONSdigital/dp-dataset-api/mocks"
 	"github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/dp-dataset-api/pagination"
 	"github.com/ONSdigital/dp-dataset-api/store"
 	storetest "github.com/ONSdigital/dp-dataset-api/store/datastoretest"
 	"github.com/gorilla/mux"
@@ -28,6 +29,9 @@ import (
 
 var testContext = context.Background()
 
+// reviewComments are left on a version by a reviewer, and must not be shown on the web
+var reviewComments = []models.ReviewComment{models.NewReviewComment("the figures do not match the source", "reviewer@ons.gov.uk")}
+
 func TestWebSubnetDatasetsEndpoint(t *testing.T) {
 	Convey("When the API is started with private endpoints disabled", t, func() {
 		r := createRequestWithAuth("GET", "http://localhost:22000/datasets", nil)
@@ -37,13 +41,13 @@ func TestWebSubnetDatasetsEndpoint(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			GetDatasetsFunc: func(context.Context, int, int, bool) ([]*models.DatasetUpdate, int, error) {
+			GetDatasetsFunc: func(context.Context, int, int, *pagination.Cursor, bool) ([]*models.DatasetUpdate, int, *pagination.Cursor, error) {
 				return []*models.DatasetUpdate{
 					{
 						Current: current,
 						Next:    next,
 					},
-				}, 0, nil
+				}, 0, nil, nil
 			},
 		}
 
@@ -204,17 +208,19 @@ func TestWebSubnetVersionsEndpoint(t *testing.T) {
 			},
 			GetVersionsFunc: func(_ context.Context, _ string, _ string, state string, _, _ int) ([]models.Version, int, error) {
 				versionSearchState = state
-				return []models.Version{{ID: "124", State: models.PublishedState}}, 1, nil
+				return []models.Version{{ID: "124", State: models.PublishedState, ReviewComments: reviewComments}}, 1, nil
 			},
 		}
 
-		Convey("Calling the versions endpoint should allow only published items", func() {
+		Convey("Calling the versions endpoint should allow only published items, without their review comments", func() {
 			api := GetWebAPIWithMocks(testContext, mockedDataStore, &mocks.DownloadsGeneratorMock{}, nil, nil, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 
 			api.Router.ServeHTTP(w, r)
 			So(w.Code, ShouldEqual, http.StatusOK)
 			So(editionSearchState, ShouldEqual, models.PublishedState)
 			So(versionSearchState, ShouldEqual, models.PublishedState)
+			So(w.Body.String(), ShouldNotContainSubstring, "review_comments")
+			So(w.Body.String(), ShouldNotContainSubstring, "reviewer@ons.gov.uk")
 		})
 	})
 }
@@ -235,7 +241,7 @@ func TestWebSubnetVersionEndpoint(t *testing.T) {
 			},
 			GetVersionFunc: func(_ context.Context, _ string, _ string, _ int, state string) (*models.Version, error) {
 				versionSearchState = state
-				return &models.Version{ID: "124", State: models.PublishedState,
+				return &models.Version{ID: "124", State: models.PublishedState, ReviewComments: reviewComments,
 					Links: &models.VersionLinks{
 						Version: &models.LinkObject{},
 						Self:    &models.LinkObject{}}}, nil
@@ -245,7 +251,7 @@ func TestWebSubnetVersionEndpoint(t *testing.T) {
 			},
 		}
 
-		Convey("Calling the version endpoint should allow only published items", func() {
+		Convey("Calling the version endpoint should allow only published items, without their review comments", func() {
 			api := GetWebAPIWithMocks(testContext, mockedDataStore, &mocks.DownloadsGeneratorMock{}, nil, nil, nil, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
 
 			api.Router.ServeHTTP(w, r)
@@ -253,6 +259,8 @@ func TestWebSubnetVersionEndpoint(t *testing.T) {
 			So(w.Code, ShouldEqual, http.StatusOK)
 			So(editionSearchState, ShouldEqual, models.PublishedState)
 			So(versionSearchState, ShouldEqual, models.PublishedState)
+			So(w.Body.String(), ShouldNotContainSubstring, "review_comments")
+			So(w.Body.String(), ShouldNotContainSubstring, "reviewer@ons.gov.uk")
 		})
 	})
 }
@@ -298,8 +306,8 @@ func TestWebSubnetDimensionOptionsEndpoint(t *testing.T) {
 						Version: &models.LinkObject{},
 						Self:    &models.LinkObject{}}}, nil
 			},
-			GetDimensionOptionsFunc: func(context.Context, *models.Version, string, int, int) ([]*models.PublicDimensionOption, int, error) {
-				return []*models.PublicDimensionOption{}, 0, nil
+			GetDimensionOptionsFunc: func(context.Context, *models.Version, string, int, int, *pagination.Cursor) ([]*models.PublicDimensionOption, int, *pagination.Cursor, error) {
+				return []*models.PublicDimensionOption{}, 0, nil, nil
 			},
 		}
 
@@ -380,5 +388,5 @@ func GetWebAPIWithMocks(ctx context.Context, mockedDataStore store.Storer, mocke
 	cfg.DatasetAPIURL = host
 	cfg.EnablePrivateEndpoints = false
 
-	return Setup(ctx, cfg, mux.NewRouter(), store.DataStore{Backend: mockedDataStore}, urlBuilder, mockedMapDownloadGenerators, authorisationMock, enableURLRewriting, &mockStatemachineDatasetAPI, auditServiceMock, permissionsMock, testIDClient, nil, mockCloudflareClient)
+	return Setup(ctx, cfg, mux.NewRouter(), store.DataStore{Backend: mockedDataStore}, urlBuilder, mockedMapDownloadGenerators, authorisationMock, enableURLRewriting, &mockStatemachineDatasetAPI, auditServiceMock, permissionsMock, testIDClient, mockCloudflareClient)
 }
@@ -21,6 +21,7 @@ var (
 	ErrTypeMismatch                       = errors.New("type mismatch")
 	ErrAddUpdateDatasetBadRequest         = errors.New("failed to parse json body")
 	ErrConflictUpdatingInstance           = errors.New("conflict updating instance resource")
+	ErrCollectionNotFound                 = errors.New("collection not found")
 	ErrDatasetNotFound                    = errors.New("dataset not found")
 	ErrDeletePublishedDatasetForbidden    = errors.New("a published dataset cannot be deleted")
 	ErrDeletePublishedVersionForbidden    = errors.New("a published version cannot be deleted")
@@ -50,6 +51,7 @@ var (
 	ErrVersionNotFound                    = errors.New("version not found")
 	ErrVersionsNotFound                   = errors.New("no versions were found")
 	ErrInvalidVersion                     = errors.New("invalid version requested")
+	ErrInvalidRevision                    = errors.New("invalid revision requested")
 	ErrVersionAlreadyExists               = errors.New("an unpublished version of this dataset already exists")
 	ErrNotFound                           = errors.New("not found")
 	ErrMissingDatasetID                   = errors.New("invalid fields: missing dataset id in request body")
@@ -62,14 +64,22 @@ var (
 	ErrInvalidParamCombination            = errors.New("cannot request state and published parameters at the same time")
 	ErrMethodNotAllowed                   = errors.New("method not allowed")
 	ErrPublishedDatasetTopicChange        = errors.New("canonical topic can't be changed once a series is published")
+	ErrRevisionNotFound                   = errors.New("revision not found")
+	ErrWorkflowNotFound                   = errors.New("workflow not found")
+	ErrVersionWithdrawn                   = errors.New("version has been withdrawn")
+	ErrOutboxMessageNotFound              = errors.New("outbox message not found")
+	ErrAuditEventChainConflict            = errors.New("another audit event was chained to the same event")
+	ErrVersionSuperseded                  = errors.New("a later version of the edition exists")
 
 	ErrExpectedResourceStateOfCreated          = errors.New("unable to update resource, expected resource to have a state of created")
 	ErrExpectedResourceStateOfSubmitted        = errors.New("unable to update resource, expected resource to have a state of submitted")
 	ErrExpectedResourceStateOfCompleted        = errors.New("unable to update resource, expected resource to have a state of completed")
 	ErrExpectedResourceStateOfEditionConfirmed = errors.New("unable to update resource, expected resource to have a state of edition-confirmed")
 	ErrExpectedResourceStateOfAssociated       = errors.New("unable to update resource, expected resource to have a state of associated")
+	ErrExpectedResourceStateOfApproved         = errors.New("unable to update resource, expected resource to have a state of approved")
 
 	NotFoundMap = map[error]bool{
+		ErrCollectionNotFound:      true,
 		ErrDatasetNotFound:         true,
 		ErrDimensionNotFound:       true,
 		ErrDimensionsNotFound:      true,
@@ -79,6 +89,8 @@ var (
 		ErrInstanceNotFound:        true,
 		ErrVersionNotFound:         true,
 		ErrFileMetadataNotFound:    true,
+		ErrRevisionNotFound:        true,
+		ErrWorkflowNotFound:        true,
 	}
 
 	BadRequestMap = map[error]bool{
@@ -93,6 +105,7 @@ var (
 		ErrTypeMismatch:                       true,
 		ErrDatasetTypeInvalid:                 true,
 		ErrInvalidVersion:                     true,
+		ErrInvalidRevision:                    true,
 		ErrInvalidDatasetTypeForEditionUpdate: true,
 		ErrInvalidParamCombination:            true,
 		ErrSpacesNotAllowedInID:               true,
@@ -104,6 +117,7 @@ var (
 		ErrEditionAlreadyExists:      true,
 		ErrEditionTitleAlreadyExists: true,
 		ErrFileNotInCorrectState:     true,
+		ErrVersionSuperseded:         true,
 	}
 
 	ForbiddenMap = map[error]bool{
@@ -112,6 +126,7 @@ var (
 		ErrExpectedResourceStateOfCompleted:        true,
 		ErrExpectedResourceStateOfEditionConfirmed: true,
 		ErrExpectedResourceStateOfAssociated:       true,
+		ErrExpectedResourceStateOfApproved:         true,
 		ErrResourcePublished:                       true,
 		ErrDeletePublishedVersionForbidden:         true,
 	}
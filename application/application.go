@@ -2,7 +2,9 @@ package application
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -11,7 +13,6 @@ import (
 	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
 	"github.com/ONSdigital/dp-dataset-api/models"
 	"github.com/ONSdigital/dp-dataset-api/store"
-	filesAPISDK "github.com/ONSdigital/dp-files-api/sdk"
 	dprequest "github.com/ONSdigital/dp-net/v3/request"
 	"github.com/ONSdigital/log.go/v2/log"
 	"github.com/jinzhu/copier"
@@ -28,6 +29,9 @@ const (
 	downloadServiceToken = "X-Download-Service-Token"
 	updateVersionAction  = "updateVersion"
 	hasDownloads         = "has_downloads"
+	stateChangedBy       = "state_changed_by"
+	stateChangeComment   = "state_change_comment"
+	approvedOnly         = "approved_only"
 )
 
 var (
@@ -45,17 +49,26 @@ type DownloadsGenerator interface {
 	Generate(ctx context.Context, datasetID, instanceID, edition, version string) error
 }
 
+// Outbox stores an outbound kafka message, to be delivered once the changes that caused it are saved
+type Outbox interface {
+	Write(ctx context.Context, message []byte) error
+}
+
 type StateMachineDatasetAPI struct {
-	DataStore          store.DataStore
-	DownloadGenerators map[models.DatasetType]DownloadsGenerator
-	StateMachine       *StateMachine
+	DataStore            store.DataStore
+	DownloadGenerators   map[models.DatasetType]DownloadsGenerator
+	SearchContentUpdated Outbox
+	SearchContentDeleted Outbox
+	StateMachine         *StateMachine
 }
 
-func Setup(dataStoreVal store.DataStore, downloadGenerators map[models.DatasetType]DownloadsGenerator, stateMachine *StateMachine) *StateMachineDatasetAPI {
+func Setup(dataStoreVal store.DataStore, downloadGenerators map[models.DatasetType]DownloadsGenerator, searchContentUpdated, searchContentDeleted Outbox, stateMachine *StateMachine) *StateMachineDatasetAPI {
 	newDS := &StateMachineDatasetAPI{
-		DataStore:          dataStoreVal,
-		DownloadGenerators: downloadGenerators,
-		StateMachine:       stateMachine,
+		DataStore:            dataStoreVal,
+		DownloadGenerators:   downloadGenerators,
+		SearchContentUpdated: searchContentUpdated,
+		SearchContentDeleted: searchContentDeleted,
+		StateMachine:         stateMachine,
 	}
 
 	return newDS
@@ -65,43 +78,195 @@ func (v VersionDetails) baseLogData() log.Data {
 	return log.Data{"dataset_id": v.datasetID, "edition": v.edition, "version": v.version}
 }
 
-func (smDS *StateMachineDatasetAPI) AmendVersion(ctx context.Context, vars map[string]string, version *models.Version) (*models.Version, error) {
-	versionDetails := VersionDetails{
-		datasetID: vars["dataset_id"],
-		edition:   vars["edition"],
-		version:   vars["version"],
+// path returns the path of the version resource
+func (v VersionDetails) path() string {
+	return fmt.Sprintf("/datasets/%s/editions/%s/versions/%s", v.datasetID, v.edition, v.version)
+}
+
+// AmendVersion updates a version through the state machine, returning the version as it was before the update
+// along with the amended version. A change of state is added to the state history of the version, by the user given
+// by the state_changed_by var and with the comment given by the state_change_comment var. When the approved_only var
+// is true, the version is only amended if it is approved.
+func (smDS *StateMachineDatasetAPI) AmendVersion(ctx context.Context, vars map[string]string, version *models.Version) (currentVersion, amendedVersion *models.Version, err error) {
+	unlock, err := smDS.lockVersion(ctx, version)
+	if err != nil {
+		return nil, nil, err
 	}
+	defer unlock()
 
-	if version.Type == models.Static.String() {
-		lockID, lockErr := smDS.DataStore.Backend.AcquireVersionsLock(ctx, version.ID)
-		if lockErr != nil {
-			return nil, lockErr
+	return smDS.amendVersion(ctx, vars, version)
+}
+
+// VersionAmendment is the amendment of one of the versions amended together by AmendVersions. Vars identify the
+// version, as for AmendVersion, and the current and amended versions, or the error that prevented the amendment,
+// are set once the versions have been amended.
+type VersionAmendment struct {
+	Vars           map[string]string
+	Version        *models.Version
+	CurrentVersion *models.Version
+	AmendedVersion *models.Version
+	Err            error
+}
+
+// DatasetPublication is the publication of one of the datasets published together with versions by AmendVersions.
+// The published dataset, or the error that prevented its publication, is set once the datasets have been published.
+type DatasetPublication struct {
+	ID               string
+	PublishedDataset *models.Dataset
+	Err              error
+}
+
+// AmendVersions amends several versions as one unit: every version is locked and the amendments are made in a single
+// transaction, so that either all of the versions are amended or none are. The provided datasets are published in the
+// same transaction, once the versions have been amended. The error of the first amendment or publication that failed
+// is set on it and returned, in which case none of the versions are amended and none of the datasets are published.
+func (smDS *StateMachineDatasetAPI) AmendVersions(ctx context.Context, amendments []*VersionAmendment, datasets []*DatasetPublication) error {
+	// the versions are locked in the order of their IDs, so that concurrent calls cannot deadlock
+	locking := make([]*VersionAmendment, len(amendments))
+	copy(locking, amendments)
+	sort.Slice(locking, func(i, j int) bool { return locking[i].Version.ID < locking[j].Version.ID })
+
+	unlocks := make([]func(), 0, len(locking))
+	defer func() {
+		for _, unlock := range unlocks {
+			unlock()
 		}
-		defer func() {
-			smDS.DataStore.Backend.UnlockVersions(ctx, lockID)
-		}()
-	} else {
-		lockID, lockErr := smDS.DataStore.Backend.AcquireInstanceLock(ctx, version.ID)
-		if lockErr != nil {
-			return nil, lockErr
+	}()
+
+	for _, amendment := range locking {
+		unlock, err := smDS.lockVersion(ctx, amendment.Version)
+		if err != nil {
+			amendment.Err = err
+			return err
+		}
+		unlocks = append(unlocks, unlock)
+	}
+
+	return smDS.DataStore.Backend.RunTransaction(ctx, func(ctx context.Context) error {
+		for _, amendment := range amendments {
+			amendment.CurrentVersion, amendment.AmendedVersion, amendment.Err = smDS.amendVersion(ctx, amendment.Vars, amendment.Version)
+			if amendment.Err != nil {
+				return amendment.Err
+			}
+		}
+		for _, publication := range datasets {
+			publication.PublishedDataset, publication.Err = smDS.publishAmendedDataset(ctx, publication.ID)
+			if publication.Err != nil {
+				return publication.Err
+			}
+		}
+		return nil
+	})
+}
+
+// publishAmendedDataset publishes the next document of a dataset, unless it has already been published along with one
+// of its versions, and returns the published dataset
+func (smDS *StateMachineDatasetAPI) publishAmendedDataset(ctx context.Context, id string) (*models.Dataset, error) {
+	currentDataset, err := smDS.DataStore.Backend.GetDataset(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if currentDataset.Next == nil {
+		return nil, errs.ErrDatasetNotFound
+	}
+
+	if currentDataset.Next.State == models.PublishedState {
+		return currentDataset.Next, nil
+	}
+
+	if err := models.ValidateDataset(currentDataset.Next); err != nil {
+		log.Error(ctx, "publishAmendedDataset: failed to validate dataset", err, log.Data{"dataset_id": id})
+		return nil, err
+	}
+
+	if err := smDS.publishDataset(ctx, currentDataset, nil); err != nil {
+		return nil, err
+	}
+
+	return currentDataset.Next, nil
+}
+
+// lockVersion locks the version, or the instance if the version is not static, and returns the function that unlocks it
+func (smDS *StateMachineDatasetAPI) lockVersion(ctx context.Context, version *models.Version) (unlock func(), err error) {
+	if version.Type == models.Static.String() {
+		lockID, err := smDS.DataStore.Backend.AcquireVersionsLock(ctx, version.ID)
+		if err != nil {
+			return nil, err
 		}
-		defer func() {
-			smDS.DataStore.Backend.UnlockInstance(ctx, lockID)
-		}()
+		return func() { smDS.DataStore.Backend.UnlockVersions(ctx, lockID) }, nil
+	}
+
+	lockID, err := smDS.DataStore.Backend.AcquireInstanceLock(ctx, version.ID)
+	if err != nil {
+		return nil, err
+	}
+	return func() { smDS.DataStore.Backend.UnlockInstance(ctx, lockID) }, nil
+}
+
+// amendVersion combines the version with the current version and transitions it to its new state. The version must be locked.
+func (smDS *StateMachineDatasetAPI) amendVersion(ctx context.Context, vars map[string]string, version *models.Version) (currentVersion, amendedVersion *models.Version, err error) {
+	versionDetails := VersionDetails{
+		datasetID: vars["dataset_id"],
+		edition:   vars["edition"],
+		version:   vars["version"],
 	}
 
 	currentVersion, versionUpdate, err := smDS.PopulateVersionInfo(ctx, version, versionDetails)
 	if err != nil {
 		log.Error(ctx, "amendVersion: creating models failed", err)
-		return nil, err
+		return nil, nil, err
+	}
+
+	// the version is written with the ETag it was read with, so a version that is approved here is still approved when
+	// it is amended, and the amendment fails if another request moves it on first
+	if vars[approvedOnly] == trueStringified && currentVersion.State != models.ApprovedState {
+		return nil, nil, errs.ErrExpectedResourceStateOfApproved
+	}
+
+	if versionUpdate.State != currentVersion.State {
+		stateChange := models.NewStateChange(currentVersion.State, versionUpdate.State, vars[stateChangedBy], vars[stateChangeComment])
+		versionUpdate.StateHistory = append(versionUpdate.StateHistory, stateChange)
+	}
+
+	// the comment of a reviewer rejecting an approved version is kept with it, so that the publisher knows what to change
+	if currentVersion.State == models.ApprovedState && versionUpdate.State == models.AssociatedState && strings.TrimSpace(vars[stateChangeComment]) != "" {
+		reviewComment := models.NewReviewComment(vars[stateChangeComment], vars[stateChangedBy])
+		versionUpdate.ReviewComments = append(versionUpdate.ReviewComments, reviewComment)
 	}
 
 	if err := smDS.StateMachine.Transition(ctx, smDS, currentVersion, versionUpdate, versionDetails, vars[hasDownloads]); err != nil {
 		log.Error(ctx, "amendVersion: state machine transition failed", err)
-		return nil, err
+		return nil, nil, err
 	}
 
-	return versionUpdate, nil
+	return currentVersion, versionUpdate, nil
+}
+
+// PreflightVersion checks, without changing anything, whether a version could be amended with the provided version.
+// The version is combined with the current version as it would be by AmendVersion, and the transition to its new
+// state, the guards of the transition and the validation of the amended version are each checked. The current and amended versions are
+// returned with the results of the checks.
+func (smDS *StateMachineDatasetAPI) PreflightVersion(ctx context.Context, vars map[string]string, version *models.Version) (currentVersion, amendedVersion *models.Version, checks []models.PreflightCheck, err error) {
+	versionDetails := VersionDetails{
+		datasetID: vars["dataset_id"],
+		edition:   vars["edition"],
+		version:   vars["version"],
+	}
+
+	currentVersion, amendedVersion, err = smDS.combineVersion(ctx, version, versionDetails, versionDetails.baseLogData())
+	if err != nil {
+		log.Error(ctx, "preflightVersion: creating models failed", err)
+		return nil, nil, nil, err
+	}
+
+	checks, err = smDS.StateMachine.Preflight(ctx, smDS, currentVersion, amendedVersion, versionDetails)
+	if err != nil {
+		log.Error(ctx, "preflightVersion: state machine preflight failed", err)
+		return nil, nil, nil, err
+	}
+	checks = append(checks, models.NewPreflightCheck("version", models.ValidateVersion(amendedVersion)))
+
+	return currentVersion, amendedVersion, checks, nil
 }
 
 func (smDS *StateMachineDatasetAPI) PopulateVersionInfo(ctx context.Context, versionUpdate *models.Version, versionDetails VersionDetails) (currentVersion, combinedVersionUpdate *models.Version, err error) {
@@ -109,6 +274,27 @@ func (smDS *StateMachineDatasetAPI) PopulateVersionInfo(ctx context.Context, ver
 
 	reqID := ctx.Value(dprequest.RequestIdKey) // used to differentiate logs of concurrent calls to this function from different services
 
+	currentVersion, combinedVersionUpdate, err = smDS.combineVersion(ctx, versionUpdate, versionDetails, data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	data["updated_version"] = combinedVersionUpdate
+
+	if err = models.ValidateVersion(combinedVersionUpdate); err != nil {
+		log.Error(ctx, "UpdateVersion: failed validation check for version update", err)
+		return nil, nil, err
+	}
+
+	data["type"] = currentVersion.Type
+	data["reqID"] = reqID
+	log.Info(ctx, "update version completed successfully", data)
+
+	return currentVersion, combinedVersionUpdate, nil
+}
+
+// combineVersion gets the current version and combines it with the version update, without validating the result
+func (smDS *StateMachineDatasetAPI) combineVersion(ctx context.Context, versionUpdate *models.Version, versionDetails VersionDetails, data log.Data) (currentVersion, combinedVersionUpdate *models.Version, err error) {
 	versionNumber, err := models.ParseAndValidateVersionNumber(ctx, versionDetails.version)
 	if err != nil {
 		log.Error(ctx, "UpdateVersion: invalid version request", err, data)
@@ -173,17 +359,6 @@ func (smDS *StateMachineDatasetAPI) PopulateVersionInfo(ctx context.Context, ver
 		return nil, nil, err
 	}
 
-	data["updated_version"] = combinedVersionUpdate
-
-	if err = models.ValidateVersion(combinedVersionUpdate); err != nil {
-		log.Error(ctx, "UpdateVersion: failed validation check for version update", err)
-		return nil, nil, err
-	}
-
-	data["type"] = currentVersion.Type
-	data["reqID"] = reqID
-	log.Info(ctx, "update version completed successfully", data)
-
 	return currentVersion, combinedVersionUpdate, nil
 }
 
@@ -222,6 +397,10 @@ func populateNewVersionDoc(currentVersion, originalVersion *models.Version) (*mo
 		version.LatestChanges = &latestChanges
 	}
 
+	// the review comments and the state history are only added to by AmendVersion, so any in the update are replaced
+	version.ReviewComments = append([]models.ReviewComment(nil), currentVersion.ReviewComments...)
+	version.StateHistory = append([]models.StateChange(nil), currentVersion.StateHistory...)
+
 	if version.ReleaseDate == "" {
 		version.ReleaseDate = currentVersion.ReleaseDate
 	}
@@ -408,6 +587,28 @@ func AssociateVersion(ctx context.Context, smDS *StateMachineDatasetAPI,
 		return errModel
 	}
 
+	// an approved version moved back to associated has been rejected by a reviewer, who must say why
+	if currentVersion.State == models.ApprovedState {
+		if err := models.ValidateVersionRejection(currentVersion, versionUpdate); err != nil {
+			log.Error(ctx, "State machine - Associating: ValidateVersionRejection : failed to validate rejected version", err, data)
+			return err
+		}
+	}
+
+	// the version and dataset updates are performed in the same transaction as writing the generate downloads event to the outbox,
+	// so that the event is only sent if the version is associated
+	return smDS.DataStore.Backend.RunTransaction(ctx, func(ctx context.Context) error {
+		return associateVersionTransaction(ctx, smDS, currentVersion, versionUpdate, versionDetails, hasDownloads, data)
+	})
+}
+
+// associateVersionTransaction performs the updates required to associate a version. It must be run within a transaction.
+func associateVersionTransaction(ctx context.Context, smDS *StateMachineDatasetAPI,
+	currentVersion *models.Version, // Called Instances in Mongo
+	versionUpdate *models.Version, // Next version, that is the new version
+	versionDetails VersionDetails,
+	hasDownloads string,
+	data log.Data) error {
 	_, err := UpdateVersionInfo(ctx, smDS, currentVersion, versionUpdate, versionDetails)
 	if err != nil {
 		log.Error(ctx, "State machine - Associating: UpdateVersionInfo : failed to update the version", err, data)
@@ -508,41 +709,283 @@ func PublishVersion(ctx context.Context, smDS *StateMachineDatasetAPI,
 		return err
 	}
 
-	versionUpdate, err = UpdateVersionInfo(ctx, smDS, currentVersion, versionUpdate, versionDetails)
+	var dsType models.DatasetType
+	if hasDownloads != trueStringified {
+		// the type is checked before anything is written, as it decides what is done after the transaction
+		dsType, err = models.GetDatasetType(currentVersion.Type)
+		if err != nil {
+			log.Error(ctx, "State machine - Publish: GetDatasetType : failed to get dataset type", err, data)
+			return err
+		}
+	}
+
+	// all the writes made to publish the version, edition and dataset are performed in a single transaction,
+	// so that a failure at any point leaves no partially published resources behind
+	var publishedVersion *models.Version
+	err = smDS.DataStore.Backend.RunTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		publishedVersion, err = publishVersionTransaction(ctx, smDS, currentVersion, versionUpdate, versionDetails, hasDownloads, data)
+		return err
+	})
 	if err != nil {
-		log.Error(ctx, "State machine - Publish: UpdateVersionInfo : failed to update the version", err, data)
 		return err
 	}
 
+	if hasDownloads == trueStringified {
+		return nil
+	}
+
+	// the graph and the download generators cannot be rolled back, so they are only called once the transaction has
+	// committed. If either fails the version stays published, and publishing it again retries them.
+	return publishVersionDependencies(ctx, smDS, dsType, currentVersion, publishedVersion, versionDetails, data)
+}
+
+// publishVersionTransaction performs the updates required to publish a version, returning the published version. It must be run within a transaction.
+func publishVersionTransaction(ctx context.Context, smDS *StateMachineDatasetAPI,
+	currentVersion *models.Version, // Called Instances in Mongo
+	versionUpdate *models.Version, // Next version, that is the new version
+	versionDetails VersionDetails,
+	hasDownloads string,
+	data log.Data) (*models.Version, error) {
+	versionUpdate, err := UpdateVersionInfo(ctx, smDS, currentVersion, versionUpdate, versionDetails)
+	if err != nil {
+		log.Error(ctx, "State machine - Publish: UpdateVersionInfo : failed to update the version", err, data)
+		return nil, err
+	}
+
 	if hasDownloads != trueStringified {
 		log.Info(ctx, "attempting to publish edition", data)
 
 		err = PublishEdition(ctx, smDS, versionUpdate, versionDetails, data)
 		if err != nil {
 			log.Error(ctx, "State machine - Publish: PublishEdition : failed to publish edition", err, data)
-			return err
+			return nil, err
 		}
 
-		dsType, err := models.GetDatasetType(currentVersion.Type)
+		err = PublishDataset(ctx, smDS, currentVersion, versionUpdate, versionDetails, data)
 		if err != nil {
-			log.Error(ctx, "State machine - Publish: GetDatasetType : failed to get dataset type", err, data)
-			return err
+			log.Error(ctx, "State machine - Publish: PublishDataset : failed to publish dataset", err, data)
+			return nil, err
 		}
+	}
 
-		if dsType == models.Filterable || dsType == models.CantabularFlexibleTable || dsType == models.CantabularMultivariateTable || dsType == models.CantabularTable {
-			err = PublishInstance(ctx, smDS, versionUpdate, data)
-			if err != nil {
-				log.Error(ctx, "State machine - Publish: PublishInstance : failed to publish instance", err, data)
-				return err
-			}
+	if currentVersion.Type == models.Static.String() {
+		err = writeSearchContentUpdated(ctx, smDS, versionUpdate, versionDetails, data)
+		if err != nil {
+			log.Error(ctx, "State machine - Publish: writeSearchContentUpdated : failed to write search content updated event", err, data)
+			return nil, err
 		}
+	}
+	return versionUpdate, nil
+}
 
-		err = PublishDataset(ctx, smDS, currentVersion, versionUpdate, versionDetails, data)
+// publishVersionDependencies publishes the instance of a published version in the graph and generates its downloads
+func publishVersionDependencies(ctx context.Context, smDS *StateMachineDatasetAPI,
+	dsType models.DatasetType,
+	currentVersion *models.Version, // Called Instances in Mongo
+	publishedVersion *models.Version,
+	versionDetails VersionDetails,
+	data log.Data) error {
+	if dsType == models.Filterable || dsType == models.CantabularFlexibleTable || dsType == models.CantabularMultivariateTable || dsType == models.CantabularTable {
+		err := PublishInstance(ctx, smDS, publishedVersion, data)
 		if err != nil {
-			log.Error(ctx, "State machine - Publish: PublishDataset : failed to publish dataset", err, data)
+			log.Error(ctx, "State machine - Publish: PublishInstance : failed to publish instance", err, data)
 			return err
 		}
 	}
+
+	return generatePublishedDownloads(ctx, smDS, currentVersion, publishedVersion, versionDetails, data)
+}
+
+// writeSearchContentUpdated adds the event that notifies the search service of a published static version to the outbox
+func writeSearchContentUpdated(ctx context.Context, smDS *StateMachineDatasetAPI, version *models.Version, versionDetails VersionDetails, data log.Data) error {
+	searchContentUpdatedEvent := map[string]interface{}{
+		"dataset_id":   versionDetails.datasetID,
+		"uri":          fmt.Sprintf("/datasets/%s", versionDetails.datasetID),
+		"title":        version.EditionTitle,
+		"edition":      version.Edition,
+		"content_type": "dataset_landing_page",
+		"release_date": version.ReleaseDate,
+	}
+
+	jsonBytes, err := json.Marshal(searchContentUpdatedEvent)
+	if err != nil {
+		return err
+	}
+
+	if err := smDS.SearchContentUpdated.Write(ctx, jsonBytes); err != nil {
+		return err
+	}
+
+	data["search_content_updated_event"] = searchContentUpdatedEvent
+	log.Info(ctx, "State machine - Publish: search content updated event written to the outbox", data)
+
+	return nil
+}
+
+// WithdrawVersion withdraws a published version, so that the public are given a tombstone in its place. The latest
+// version links of the dataset and edition are moved back to the latest version that is still published, and the search
+// service is told to remove the version's landing page.
+//
+//nolint:revive // hasDownloads is intentionally unused to be compatible with the State struct
+func WithdrawVersion(ctx context.Context, smDS *StateMachineDatasetAPI,
+	currentVersion *models.Version, // Called Instances in Mongo
+	versionUpdate *models.Version, // Next version, that is the new version
+	versionDetails VersionDetails,
+	hasDownloads string) error {
+	data := versionDetails.baseLogData()
+	log.Info(ctx, "putState endpoint (withdrawVersion): beginning transition to withdrawn", data)
+
+	if err := models.ValidateVersion(versionUpdate); err != nil {
+		log.Error(ctx, "State machine - Withdrawing: ValidateVersion : failed to validate version", err, data)
+		return err
+	}
+
+	// the version, the dataset and the search content deleted event are written in a single transaction, so that the
+	// search service is only told to remove versions that have been withdrawn
+	return smDS.DataStore.Backend.RunTransaction(ctx, func(ctx context.Context) error {
+		if _, err := UpdateVersionInfo(ctx, smDS, currentVersion, versionUpdate, versionDetails); err != nil {
+			log.Error(ctx, "State machine - Withdrawing: UpdateVersionInfo : failed to update the version", err, data)
+			return err
+		}
+
+		if err := updateLatestVersionLinks(ctx, smDS, currentVersion.Type, versionDetails); err != nil {
+			log.Error(ctx, "State machine - Withdrawing: updateLatestVersionLinks : failed to update the latest version of the dataset", err, data)
+			return err
+		}
+
+		if err := writeSearchContentDeleted(ctx, smDS, versionDetails, data); err != nil {
+			log.Error(ctx, "State machine - Withdrawing: writeSearchContentDeleted : failed to write search content deleted event", err, data)
+			return err
+		}
+
+		return nil
+	})
+}
+
+// updateLatestVersionLinks moves the latest version links that point at a withdrawn version back to the most recently
+// released version that is still published. The link of the dataset may move to a version of another edition, and the
+// editions of the types of dataset that store them have their own link, which stays within the edition. A link is
+// removed if there are no published versions left for it.
+func updateLatestVersionLinks(ctx context.Context, smDS *StateMachineDatasetAPI, versionType string, versionDetails VersionDetails) error {
+	// the links of the dataset and edition may be absolute or relative, so they are matched by the path of the version
+	withdrawnPath := versionDetails.path()
+
+	if versionType != models.Static.String() {
+		if err := updateEditionLatestVersionLink(ctx, smDS, versionDetails, withdrawnPath); err != nil {
+			return err
+		}
+	}
+
+	dataset, err := smDS.DataStore.Backend.GetDataset(ctx, versionDetails.datasetID)
+	if err != nil {
+		return err
+	}
+
+	if !linksTo(dataset.Current, withdrawnPath) && !linksTo(dataset.Next, withdrawnPath) {
+		return nil
+	}
+
+	latestVersion, err := latestPublishedVersionLink(ctx, smDS, versionType, versionDetails.datasetID, "")
+	if err != nil {
+		return err
+	}
+
+	for _, d := range []*models.Dataset{dataset.Current, dataset.Next} {
+		if linksTo(d, withdrawnPath) {
+			d.Links.LatestVersion = latestVersion
+			d.LastUpdated = time.Now()
+		}
+	}
+
+	return smDS.DataStore.Backend.UpsertDataset(ctx, dataset.ID, dataset)
+}
+
+// updateEditionLatestVersionLink moves the latest version link of an edition that points at a withdrawn version back to
+// the most recently released version of the edition that is still published
+func updateEditionLatestVersionLink(ctx context.Context, smDS *StateMachineDatasetAPI, versionDetails VersionDetails, withdrawnPath string) error {
+	edition, err := smDS.DataStore.Backend.GetEdition(ctx, versionDetails.datasetID, versionDetails.edition, "")
+	if err != nil {
+		return err
+	}
+
+	if !editionLinksTo(edition.Current, withdrawnPath) && !editionLinksTo(edition.Next, withdrawnPath) {
+		return nil
+	}
+
+	latestVersion, err := latestPublishedVersionLink(ctx, smDS, "", versionDetails.datasetID, versionDetails.edition)
+	if err != nil {
+		return err
+	}
+
+	for _, e := range []*models.Edition{edition.Current, edition.Next} {
+		if editionLinksTo(e, withdrawnPath) {
+			e.Links.LatestVersion = latestVersion
+			e.LastUpdated = time.Now()
+		}
+	}
+
+	return smDS.DataStore.Backend.UpsertEdition(ctx, versionDetails.datasetID, versionDetails.edition, edition)
+}
+
+// latestPublishedVersionLink returns the link to the most recently released published version of a dataset, in the
+// provided edition unless it is empty, or nil if the dataset has no published versions. The editions of static
+// datasets are not stored, so the versions of static datasets are always looked up across all editions.
+func latestPublishedVersionLink(ctx context.Context, smDS *StateMachineDatasetAPI, versionType, datasetID, edition string) (*models.LinkObject, error) {
+	var latest *models.Version
+	if versionType == models.Static.String() {
+		published, _, err := smDS.DataStore.Backend.GetAllStaticVersions(ctx, datasetID, models.PublishedState, 0, 1)
+		switch {
+		case err == nil && len(published) > 0:
+			latest = published[0]
+		case err != nil && err != errs.ErrVersionsNotFound:
+			return nil, err
+		}
+	} else {
+		version, err := smDS.DataStore.Backend.GetLatestVersion(ctx, datasetID, edition, models.PublishedState)
+		switch {
+		case err == nil:
+			latest = version
+		case err != errs.ErrVersionNotFound:
+			return nil, err
+		}
+	}
+
+	if latest == nil || latest.Links == nil || latest.Links.Version == nil {
+		return nil, nil
+	}
+
+	return &models.LinkObject{ID: latest.Links.Version.ID, HRef: latest.Links.Version.HRef}, nil
+}
+
+// linksTo reports whether the latest version link of a dataset is to the version at the provided path
+func linksTo(dataset *models.Dataset, versionPath string) bool {
+	return dataset != nil && dataset.Links != nil && dataset.Links.LatestVersion != nil && strings.HasSuffix(dataset.Links.LatestVersion.HRef, versionPath)
+}
+
+// editionLinksTo reports whether the latest version link of an edition is to the version at the provided path
+func editionLinksTo(edition *models.Edition, versionPath string) bool {
+	return edition != nil && edition.Links != nil && edition.Links.LatestVersion != nil && strings.HasSuffix(edition.Links.LatestVersion.HRef, versionPath)
+}
+
+// writeSearchContentDeleted adds the event that notifies the search service of a withdrawn version to the outbox
+func writeSearchContentDeleted(ctx context.Context, smDS *StateMachineDatasetAPI, versionDetails VersionDetails, data log.Data) error {
+	searchContentDeletedEvent := map[string]interface{}{
+		"uri": versionDetails.path(),
+	}
+
+	jsonBytes, err := json.Marshal(searchContentDeletedEvent)
+	if err != nil {
+		return err
+	}
+
+	if err := smDS.SearchContentDeleted.Write(ctx, jsonBytes); err != nil {
+		return err
+	}
+
+	data["search_content_deleted_event"] = searchContentDeletedEvent
+	log.Info(ctx, "State machine - Withdraw: search content deleted event written to the outbox", data)
+
 	return nil
 }
 
@@ -708,6 +1151,15 @@ func PublishDataset(ctx context.Context, smDS *StateMachineDatasetAPI,
 	data["version_update"] = versionUpdate
 	log.Info(ctx, "State Machine: Publish: PublishDataset: published version", data)
 
+	return nil
+}
+
+// generatePublishedDownloads generates the full downloads of a published version that is not static, if it has no public download yet
+func generatePublishedDownloads(ctx context.Context, smDS *StateMachineDatasetAPI,
+	currentVersion *models.Version, // Called Instances in Mongo
+	versionUpdate *models.Version, // Next version, that is the new version
+	versionDetails VersionDetails,
+	data log.Data) error {
 	if currentVersion.Type != models.Static.String() {
 		// Only want to generate downloads again if there is no public link available
 		if currentVersion.Downloads != nil && currentVersion.Downloads.CSV != nil && currentVersion.Downloads.CSV.Public == "" {
@@ -725,18 +1177,20 @@ func PublishDataset(ctx context.Context, smDS *StateMachineDatasetAPI,
 				data["instance_id"] = versionUpdate.ID
 				data["state"] = versionUpdate.State
 				data["type"] = t.String()
-				log.Error(ctx, "State Machine: Publish: PublishDataset: error while attempting to generate full dataset version downloads on version publish", err, data)
+				log.Error(ctx, "State Machine: Publish: generatePublishedDownloads: error while attempting to generate full dataset version downloads on version publish", err, data)
 				return err
 				// TODO - TECH DEBT - need to add an error event for this.  Kafka message perhaps.
 			}
-			log.Info(ctx, "State Machine: Publish: PublishDataset: generated full dataset version downloads:", data)
+			log.Info(ctx, "State Machine: Publish: generatePublishedDownloads: generated full dataset version downloads:", data)
 		}
 	}
 
 	return nil
 }
 
-func (smDS *StateMachineDatasetAPI) DeleteStaticVersion(ctx context.Context, datasetID, edition string, version int, filesAPIClient filesAPISDK.Clienter, accessToken string) (*models.Version, error) {
+// DeleteStaticVersion marks an unpublished static version as deleted. The files of its distributions are kept
+// until the version is purged, so that it can be restored in the meantime.
+func (smDS *StateMachineDatasetAPI) DeleteStaticVersion(ctx context.Context, datasetID, edition string, version int, deletedBy string) (*models.Version, error) {
 	logData := log.Data{"dataset_id": datasetID, "edition": edition, "version": version}
 
 	// Validate edition exists for the dataset (static context)
@@ -758,21 +1212,6 @@ func (smDS *StateMachineDatasetAPI) DeleteStaticVersion(ctx context.Context, dat
 		return nil, errs.ErrDeletePublishedVersionForbidden
 	}
 
-	// Delete any files associated with the version
-	if versionDoc.Distributions != nil {
-		for _, distribution := range *versionDoc.Distributions {
-			logData["distribution_title"] = distribution.Title
-			logData["distribution_download_url"] = distribution.DownloadURL
-
-			err := filesAPIClient.DeleteFile(ctx, distribution.DownloadURL, filesAPISDK.Headers{Authorization: accessToken})
-			if err != nil {
-				log.Error(ctx, "DeleteStaticVersion: failed to delete distribution file from files API", err, logData)
-				return nil, err
-			}
-			log.Info(ctx, "DeleteStaticVersion: successfully deleted distribution file from files API", logData)
-		}
-	}
-
 	// Get the dataset to allow Next<-Current sync after deletion
 	datasetDoc, err := smDS.DataStore.Backend.GetDataset(ctx, datasetID)
 	if err != nil {
@@ -780,22 +1219,83 @@ func (smDS *StateMachineDatasetAPI) DeleteStaticVersion(ctx context.Context, dat
 		return nil, err
 	}
 
-	// Perform the deletion
-	if err := smDS.DataStore.Backend.DeleteStaticDatasetVersion(ctx, datasetID, edition, version); err != nil {
-		log.Error(ctx, "DeleteStaticVersion: failed to delete static dataset version", err, logData)
+	err = smDS.DataStore.Backend.RunTransaction(ctx, func(ctx context.Context) error {
+		// Perform the deletion
+		if err := smDS.DataStore.Backend.DeleteStaticDatasetVersion(ctx, datasetID, edition, version, models.NewDeletion(deletedBy)); err != nil {
+			log.Error(ctx, "DeleteStaticVersion: failed to delete static dataset version", err, logData)
+			return err
+		}
+
+		// If there is a current document, make next equal to current to retain consistency
+		if datasetDoc.Current != nil {
+			datasetDoc.Next = datasetDoc.Current
+			if err := smDS.DataStore.Backend.UpsertDataset(ctx, datasetID, datasetDoc); err != nil {
+				log.Error(ctx, "DeleteStaticVersion: failed to update dataset after version deletion", err, logData)
+				return err
+			}
+			log.Info(ctx, "DeleteStaticVersion: updated dataset next document to current", logData)
+		}
+		return nil
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	// If there is a current document, make next equal to current to retain consistency
-	if datasetDoc.Current != nil {
-		datasetDoc.Next = datasetDoc.Current
+	log.Info(ctx, "DeleteStaticVersion: successfully deleted static version", logData)
+	return versionDoc, nil
+}
+
+// RestoreStaticVersion restores a deleted static version of a dataset that has not been deleted, and makes it the latest
+// version of the dataset again. A version cannot be restored once a later version of its edition has been created.
+func (smDS *StateMachineDatasetAPI) RestoreStaticVersion(ctx context.Context, datasetID, edition string, version int) (*models.Version, error) {
+	logData := log.Data{"dataset_id": datasetID, "edition": edition, "version": version}
+
+	var restoredVersion *models.Version
+	err := smDS.DataStore.Backend.RunTransaction(ctx, func(ctx context.Context) error {
+		datasetDoc, err := smDS.DataStore.Backend.GetDataset(ctx, datasetID)
+		if err != nil {
+			log.Error(ctx, "RestoreStaticVersion: failed to get dataset", err, logData)
+			return err
+		}
+
+		latestVersion, err := smDS.DataStore.Backend.GetLatestVersionStatic(ctx, datasetID, edition, "")
+		if err != nil && !errors.Is(err, errs.ErrVersionNotFound) {
+			log.Error(ctx, "RestoreStaticVersion: failed to get the latest version of the edition", err, logData)
+			return err
+		}
+		if latestVersion != nil && latestVersion.Version > version {
+			log.Error(ctx, "RestoreStaticVersion: unable to restore a version that has been superseded", errs.ErrVersionSuperseded, logData)
+			return errs.ErrVersionSuperseded
+		}
+
+		if err := smDS.DataStore.Backend.RestoreStaticVersion(ctx, datasetID, edition, version); err != nil {
+			log.Error(ctx, "RestoreStaticVersion: failed to restore static dataset version", err, logData)
+			return err
+		}
+
+		restoredVersion, err = smDS.DataStore.Backend.GetVersionStatic(ctx, datasetID, edition, version, "")
+		if err != nil {
+			log.Error(ctx, "RestoreStaticVersion: failed to get restored version", err, logData)
+			return err
+		}
+
+		// the dataset is moved on to the restored version, as it was when the version was created
+		datasetDoc.Next.LastUpdated = restoredVersion.LastUpdated
+		datasetDoc.Next.State = models.AssociatedState
+		datasetDoc.Next.Links.LatestVersion = &models.LinkObject{
+			HRef: fmt.Sprintf("/datasets/%s/editions/%s/versions/%d", datasetID, edition, version),
+			ID:   strconv.Itoa(version),
+		}
 		if err := smDS.DataStore.Backend.UpsertDataset(ctx, datasetID, datasetDoc); err != nil {
-			log.Error(ctx, "DeleteStaticVersion: failed to update dataset after version deletion", err, logData)
-			return nil, err
+			log.Error(ctx, "RestoreStaticVersion: failed to update dataset after version restore", err, logData)
+			return err
 		}
-		log.Info(ctx, "DeleteStaticVersion: updated dataset next document to current", logData)
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	log.Info(ctx, "DeleteStaticVersion: successfully deleted static version", logData)
-	return versionDoc, nil
+	log.Info(ctx, "RestoreStaticVersion: successfully restored static version", logData)
+	return restoredVersion, nil
 }
@@ -3,6 +3,7 @@ package application
 import (
 	"context"
 	"errors"
+	"strconv"
 	"testing"
 
 	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
@@ -10,8 +11,6 @@ import (
 	"github.com/ONSdigital/dp-dataset-api/models"
 	"github.com/ONSdigital/dp-dataset-api/store"
 	storetest "github.com/ONSdigital/dp-dataset-api/store/datastoretest"
-	filesAPISDK "github.com/ONSdigital/dp-files-api/sdk"
-	filesAPISDKMocks "github.com/ONSdigital/dp-files-api/sdk/mocks"
 	. "github.com/smartystreets/goconvey/convey"
 )
 
@@ -69,6 +68,11 @@ var versionUpdateEditionConfirmed = &models.Version{
 	CollectionID: "3434",
 }
 
+// runTransaction runs the provided function without a transaction, for StorerMock.RunTransactionFunc
+func runTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
 func setUpStatesTransitions() ([]State, []Transition) {
 	states := []State{Published, EditionConfirmed, Associated}
 	transitions := []Transition{{
@@ -159,7 +163,7 @@ func TestAmendVersionInvalidState(t *testing.T) {
 		stateMachine := NewStateMachine(testContext, states, transitions, store.DataStore{Backend: mockedDataStore})
 		smDS := GetStateMachineAPIWithCMDMocks(mockedDataStore, generatorMock, stateMachine)
 
-		amendedVersion, err := smDS.AmendVersion(testContext, vars, publishVersionUpdate)
+		_, amendedVersion, err := smDS.AmendVersion(testContext, vars, publishVersionUpdate)
 		So(err, ShouldNotBeNil)
 		So(amendedVersion, ShouldBeNil)
 		So(err.Error(), ShouldContainSubstring, "state not allowed to transition")
@@ -200,7 +204,7 @@ func TestAmendVersionPopulateModelsFails(t *testing.T) {
 		stateMachine := NewStateMachine(testContext, states, transitions, store.DataStore{Backend: mockedDataStore})
 		smDS := GetStateMachineAPIWithCMDMocks(mockedDataStore, generatorMock, stateMachine)
 
-		amendedVersion, err := smDS.AmendVersion(testContext, vars, publishVersionUpdate)
+		_, amendedVersion, err := smDS.AmendVersion(testContext, vars, publishVersionUpdate)
 		So(err, ShouldNotBeNil)
 		So(amendedVersion, ShouldBeNil)
 		So(err.Error(), ShouldContainSubstring, "edition not found")
@@ -269,7 +273,7 @@ func TestAmendVersionPopulateVersionFails(t *testing.T) {
 		stateMachine := NewStateMachine(testContext, states, transitions, store.DataStore{Backend: mockedDataStore})
 		smDS := GetStateMachineAPIWithCMDMocks(mockedDataStore, generatorMock, stateMachine)
 
-		amendedVersion, err := smDS.AmendVersion(testContext, vars, versionUpdateInvalid)
+		_, amendedVersion, err := smDS.AmendVersion(testContext, vars, versionUpdateInvalid)
 		So(err, ShouldNotBeNil)
 		So(amendedVersion, ShouldBeNil)
 		So(err.Error(), ShouldContainSubstring, "missing mandatory fields: [release_date]")
@@ -297,6 +301,7 @@ func TestAmendVersionStaticSuccess(t *testing.T) {
 
 	Convey("When a request is made to change a version from associated to published but the dataset is not found", t, func() {
 		mockedDataStore := &storetest.StorerMock{
+			RunTransactionFunc: runTransaction,
 			CheckEditionExistsStaticFunc: func(context.Context, string, string, string) error {
 				return nil
 			},
@@ -342,7 +347,7 @@ func TestAmendVersionStaticSuccess(t *testing.T) {
 		stateMachine := NewStateMachine(testContext, states, transitions, store.DataStore{Backend: mockedDataStore})
 		smDS := GetStateMachineAPIWithCMDMocks(mockedDataStore, generatorMock, stateMachine)
 
-		amendedVersion, err := smDS.AmendVersion(testContext, vars, versionUpdateAssociatedStatic)
+		_, amendedVersion, err := smDS.AmendVersion(testContext, vars, versionUpdateAssociatedStatic)
 		So(err, ShouldBeNil)
 		So(amendedVersion, ShouldNotBeNil)
 		So(amendedVersion.State, ShouldEqual, models.AssociatedState)
@@ -371,6 +376,7 @@ func TestAmendVersionSuccess(t *testing.T) {
 
 	Convey("When a request is made to change a version from associated to published but the dataset is not found", t, func() {
 		mockedDataStore := &storetest.StorerMock{
+			RunTransactionFunc: runTransaction,
 			CheckEditionExistsFunc: func(context.Context, string, string, string) error {
 				return nil
 			},
@@ -416,8 +422,9 @@ func TestAmendVersionSuccess(t *testing.T) {
 		stateMachine := NewStateMachine(testContext, states, transitions, store.DataStore{Backend: mockedDataStore})
 		smDS := GetStateMachineAPIWithCMDMocks(mockedDataStore, generatorMock, stateMachine)
 
-		amendedVersion, err := smDS.AmendVersion(testContext, vars, versionUpdateAssociated)
+		currentVersion, amendedVersion, err := smDS.AmendVersion(testContext, vars, versionUpdateAssociated)
 		So(err, ShouldBeNil)
+		So(currentVersion.State, ShouldEqual, models.EditionConfirmedState)
 		So(amendedVersion, ShouldNotBeNil)
 		So(amendedVersion.State, ShouldEqual, models.AssociatedState)
 		So(len(mockedDataStore.AcquireInstanceLockCalls()), ShouldEqual, 1)
@@ -456,7 +463,7 @@ func TestAmendVersionErrorLockFails(t *testing.T) {
 		stateMachine := NewStateMachine(testContext, states, transitions, store.DataStore{Backend: mockedDataStore})
 		smDS := GetStateMachineAPIWithCMDMocks(mockedDataStore, generatorMock, stateMachine)
 
-		amendedVersion, err := smDS.AmendVersion(testContext, vars, publishVersionUpdate)
+		_, amendedVersion, err := smDS.AmendVersion(testContext, vars, publishVersionUpdate)
 
 		So(err, ShouldNotBeNil)
 		So(amendedVersion, ShouldBeNil)
@@ -476,7 +483,7 @@ func TestAmendVersionErrorLockFails(t *testing.T) {
 		stateMachine := NewStateMachine(testContext, states, transitions, store.DataStore{Backend: mockedDataStore})
 		smDS := GetStateMachineAPIWithCMDMocks(mockedDataStore, generatorMock, stateMachine)
 
-		amendedVersion, err := smDS.AmendVersion(testContext, vars, publishVersionUpdateStatic)
+		_, amendedVersion, err := smDS.AmendVersion(testContext, vars, publishVersionUpdateStatic)
 
 		So(err, ShouldNotBeNil)
 		So(amendedVersion, ShouldBeNil)
@@ -484,6 +491,294 @@ func TestAmendVersionErrorLockFails(t *testing.T) {
 	})
 }
 
+func TestAmendVersionRecordsStateHistory(t *testing.T) {
+	t.Parallel()
+
+	previousChange := models.StateChange{From: models.CreatedState, To: models.EditionConfirmedState, ChangedBy: "publisher@ons.gov.uk"}
+
+	newStore := func(state string) *storetest.StorerMock {
+		return &storetest.StorerMock{
+			RunTransactionFunc: runTransaction,
+			CheckEditionExistsStaticFunc: func(context.Context, string, string, string) error {
+				return nil
+			},
+			GetVersionStaticFunc: func(context.Context, string, string, int, string) (*models.Version, error) {
+				return &models.Version{
+					ID:           "789",
+					ReleaseDate:  "2017-12-12",
+					State:        state,
+					Type:         models.Static.String(),
+					StateHistory: []models.StateChange{previousChange},
+				}, nil
+			},
+			UpdateVersionStaticFunc: func(context.Context, *models.Version, *models.Version, string) (string, error) {
+				return "", nil
+			},
+			AcquireVersionsLockFunc: func(context.Context, string) (string, error) {
+				return "", nil
+			},
+			UnlockVersionsFunc: func(context.Context, string) {},
+		}
+	}
+
+	amend := func(mockedDataStore *storetest.StorerMock, vars map[string]string) error {
+		states, transitions := setUpStatesTransitions()
+		stateMachine := NewStateMachine(testContext, states, transitions, store.DataStore{Backend: mockedDataStore})
+		smDS := GetStateMachineAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, stateMachine)
+
+		versionUpdate := *versionUpdateAssociatedStatic
+		versionUpdate.StateHistory = []models.StateChange{{From: models.ApprovedState, To: models.PublishedState}}
+		_, _, err := smDS.AmendVersion(testContext, vars, &versionUpdate)
+		return err
+	}
+
+	Convey("When the state of a version is changed", t, func() {
+		mockedDataStore := newStore(models.EditionConfirmedState)
+		err := amend(mockedDataStore, map[string]string{
+			"dataset_id": "123", "edition": "2021", "version": "1",
+			stateChangedBy: "reviewer@ons.gov.uk", stateChangeComment: "ready for review",
+		})
+
+		Convey("Then the change is added to the state history of the version, replacing any history in the update", func() {
+			So(err, ShouldBeNil)
+			So(mockedDataStore.UpdateVersionStaticCalls(), ShouldHaveLength, 1)
+
+			history := mockedDataStore.UpdateVersionStaticCalls()[0].VersionUpdate.StateHistory
+			So(history, ShouldHaveLength, 2)
+			So(history[0], ShouldResemble, previousChange)
+			So(history[1].From, ShouldEqual, models.EditionConfirmedState)
+			So(history[1].To, ShouldEqual, models.AssociatedState)
+			So(history[1].ChangedBy, ShouldEqual, "reviewer@ons.gov.uk")
+			So(history[1].Comment, ShouldEqual, "ready for review")
+			So(history[1].ChangedAt, ShouldNotBeZeroValue)
+		})
+	})
+
+	Convey("When a version is updated without its state changing", t, func() {
+		mockedDataStore := newStore(models.AssociatedState)
+		err := amend(mockedDataStore, map[string]string{"dataset_id": "123", "edition": "2021", "version": "1"})
+
+		Convey("Then its state history is unchanged", func() {
+			So(err, ShouldBeNil)
+			So(mockedDataStore.UpdateVersionStaticCalls(), ShouldHaveLength, 1)
+			So(mockedDataStore.UpdateVersionStaticCalls()[0].VersionUpdate.StateHistory, ShouldResemble, []models.StateChange{previousChange})
+		})
+	})
+}
+
+func TestAmendVersionRecordsReviewComments(t *testing.T) {
+	t.Parallel()
+
+	previousComment := models.NewReviewComment("add the usage notes", "reviewer@ons.gov.uk")
+	forgedComment := models.NewReviewComment("approved by the head of profession", "someone@ons.gov.uk")
+
+	newStore := func(state string) *storetest.StorerMock {
+		return &storetest.StorerMock{
+			RunTransactionFunc: runTransaction,
+			CheckEditionExistsStaticFunc: func(context.Context, string, string, string) error {
+				return nil
+			},
+			GetVersionStaticFunc: func(context.Context, string, string, int, string) (*models.Version, error) {
+				return &models.Version{
+					ID:             "789",
+					ReleaseDate:    "2017-12-12",
+					State:          state,
+					Type:           models.Static.String(),
+					ReviewComments: []models.ReviewComment{previousComment},
+				}, nil
+			},
+			UpdateVersionStaticFunc: func(context.Context, *models.Version, *models.Version, string) (string, error) {
+				return "", nil
+			},
+			AcquireVersionsLockFunc: func(context.Context, string) (string, error) {
+				return "", nil
+			},
+			UnlockVersionsFunc: func(context.Context, string) {},
+		}
+	}
+
+	amend := func(mockedDataStore *storetest.StorerMock, vars map[string]string) error {
+		transitions := []Transition{{
+			Label:               "associated",
+			TargetState:         Associated,
+			AllowedSourceStates: []string{"approved", "associated"},
+			Type:                models.Static.String(),
+		}}
+		stateMachine := NewStateMachine(testContext, []State{Associated, Approved}, transitions, store.DataStore{Backend: mockedDataStore})
+		smDS := GetStateMachineAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, stateMachine)
+
+		versionUpdate := *versionUpdateAssociatedStatic
+		versionUpdate.ReviewComments = []models.ReviewComment{forgedComment}
+		_, _, err := smDS.AmendVersion(testContext, vars, &versionUpdate)
+		return err
+	}
+
+	Convey("When an approved version is rejected with a comment", t, func() {
+		mockedDataStore := newStore(models.ApprovedState)
+		err := amend(mockedDataStore, map[string]string{
+			"dataset_id": "123", "edition": "2021", "version": "1",
+			stateChangedBy: "reviewer@ons.gov.uk", stateChangeComment: "the figures do not match the source",
+		})
+
+		Convey("Then the comment is added to the review comments of the version, replacing any comments in the update", func() {
+			So(err, ShouldBeNil)
+			So(mockedDataStore.UpdateVersionStaticCalls(), ShouldHaveLength, 1)
+
+			comments := mockedDataStore.UpdateVersionStaticCalls()[0].VersionUpdate.ReviewComments
+			So(comments, ShouldHaveLength, 2)
+			So(comments[0], ShouldResemble, previousComment)
+			So(comments[1].Comment, ShouldEqual, "the figures do not match the source")
+			So(comments[1].CommentedBy, ShouldEqual, "reviewer@ons.gov.uk")
+		})
+	})
+
+	Convey("When a version is updated with review comments without being rejected", t, func() {
+		mockedDataStore := newStore(models.AssociatedState)
+		err := amend(mockedDataStore, map[string]string{"dataset_id": "123", "edition": "2021", "version": "1"})
+
+		Convey("Then its review comments are unchanged", func() {
+			So(err, ShouldBeNil)
+			So(mockedDataStore.UpdateVersionStaticCalls(), ShouldHaveLength, 1)
+			So(mockedDataStore.UpdateVersionStaticCalls()[0].VersionUpdate.ReviewComments, ShouldResemble, []models.ReviewComment{previousComment})
+		})
+	})
+}
+
+func TestAmendVersions(t *testing.T) {
+	t.Parallel()
+
+	versionIDs := map[int]string{1: "789", 2: "456"}
+
+	// the transactions started within another transaction join it, so only the outer transactions are counted
+	type transactionKey struct{}
+	var outerTransactions int
+
+	newStore := func(updateErr error) *storetest.StorerMock {
+		outerTransactions = 0
+		return &storetest.StorerMock{
+			RunTransactionFunc: func(ctx context.Context, fn func(ctx context.Context) error) error {
+				if ctx.Value(transactionKey{}) != nil {
+					return fn(ctx)
+				}
+				outerTransactions++
+				return fn(context.WithValue(ctx, transactionKey{}, true))
+			},
+			CheckEditionExistsStaticFunc: func(context.Context, string, string, string) error {
+				return nil
+			},
+			GetVersionStaticFunc: func(_ context.Context, _, _ string, version int, _ string) (*models.Version, error) {
+				return &models.Version{
+					ID:          versionIDs[version],
+					ReleaseDate: "2017-12-12",
+					State:       models.EditionConfirmedState,
+					Type:        models.Static.String(),
+				}, nil
+			},
+			UpdateVersionStaticFunc: func(_ context.Context, currentVersion, _ *models.Version, _ string) (string, error) {
+				if currentVersion.ID == "456" {
+					return "", updateErr
+				}
+				return "", nil
+			},
+			AcquireVersionsLockFunc: func(_ context.Context, versionID string) (string, error) {
+				return "lock-" + versionID, nil
+			},
+			UnlockVersionsFunc: func(context.Context, string) {},
+			GetDatasetFunc: func(_ context.Context, id string) (*models.DatasetUpdate, error) {
+				state := models.ApprovedState
+				if id == "cpih01" {
+					state = models.PublishedState
+				}
+				return &models.DatasetUpdate{ID: id, Next: &models.Dataset{ID: id, State: state, Links: &models.DatasetLinks{}}}, nil
+			},
+			UpsertDatasetFunc: func(context.Context, string, *models.DatasetUpdate) error {
+				return nil
+			},
+		}
+	}
+
+	amend := func(mockedDataStore *storetest.StorerMock, datasetIDs ...string) ([]*VersionAmendment, []*DatasetPublication, error) {
+		states, transitions := setUpStatesTransitions()
+		stateMachine := NewStateMachine(testContext, states, transitions, store.DataStore{Backend: mockedDataStore})
+		smDS := GetStateMachineAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, stateMachine)
+
+		var amendments []*VersionAmendment
+		for _, version := range []int{1, 2} {
+			versionUpdate := *versionUpdateAssociatedStatic
+			versionUpdate.ID = versionIDs[version]
+			versionUpdate.Version = version
+			amendments = append(amendments, &VersionAmendment{
+				Vars:    map[string]string{"dataset_id": "123", "edition": "2021", "version": strconv.Itoa(version)},
+				Version: &versionUpdate,
+			})
+		}
+
+		var datasets []*DatasetPublication
+		for _, id := range datasetIDs {
+			datasets = append(datasets, &DatasetPublication{ID: id})
+		}
+
+		return amendments, datasets, smDS.AmendVersions(testContext, amendments, datasets)
+	}
+
+	Convey("When several versions are amended together successfully", t, func() {
+		mockedDataStore := newStore(nil)
+		amendments, _, err := amend(mockedDataStore)
+
+		Convey("Then every version is locked in the order of its ID and amended within a single transaction", func() {
+			So(err, ShouldBeNil)
+			So(outerTransactions, ShouldEqual, 1)
+			So(mockedDataStore.AcquireVersionsLockCalls(), ShouldHaveLength, 2)
+			So(mockedDataStore.AcquireVersionsLockCalls()[0].VersionID, ShouldEqual, "456")
+			So(mockedDataStore.AcquireVersionsLockCalls()[1].VersionID, ShouldEqual, "789")
+			So(mockedDataStore.UnlockVersionsCalls(), ShouldHaveLength, 2)
+			So(mockedDataStore.UpdateVersionStaticCalls(), ShouldHaveLength, 2)
+			for _, call := range mockedDataStore.UpdateVersionStaticCalls() {
+				So(call.Ctx.Value(transactionKey{}), ShouldNotBeNil)
+			}
+
+			for _, amendment := range amendments {
+				So(amendment.Err, ShouldBeNil)
+				So(amendment.CurrentVersion.State, ShouldEqual, models.EditionConfirmedState)
+				So(amendment.AmendedVersion.State, ShouldEqual, models.AssociatedState)
+			}
+		})
+	})
+
+	Convey("When one of the versions amended together fails", t, func() {
+		mockedDataStore := newStore(errs.ErrInternalServer)
+		amendments, datasets, err := amend(mockedDataStore, "123")
+
+		Convey("Then its error is set on its amendment and returned, every version is unlocked, and no dataset is published", func() {
+			So(err, ShouldEqual, errs.ErrInternalServer)
+			So(amendments[0].Err, ShouldBeNil)
+			So(amendments[1].Err, ShouldEqual, errs.ErrInternalServer)
+			So(mockedDataStore.UnlockVersionsCalls(), ShouldHaveLength, 2)
+			So(datasets[0].PublishedDataset, ShouldBeNil)
+			So(mockedDataStore.UpsertDatasetCalls(), ShouldBeEmpty)
+		})
+	})
+
+	Convey("When datasets are published together with the versions", t, func() {
+		mockedDataStore := newStore(nil)
+		_, datasets, err := amend(mockedDataStore, "123", "cpih01")
+
+		Convey("Then the datasets are published within the same transaction, unless already published with their versions", func() {
+			So(err, ShouldBeNil)
+			So(outerTransactions, ShouldEqual, 1)
+			So(mockedDataStore.UpsertDatasetCalls(), ShouldHaveLength, 1)
+			So(mockedDataStore.UpsertDatasetCalls()[0].ID, ShouldEqual, "123")
+			So(mockedDataStore.UpsertDatasetCalls()[0].Ctx.Value(transactionKey{}), ShouldNotBeNil)
+			So(mockedDataStore.UpsertDatasetCalls()[0].DatasetDoc.Current.State, ShouldEqual, models.PublishedState)
+
+			for _, publication := range datasets {
+				So(publication.Err, ShouldBeNil)
+				So(publication.PublishedDataset.State, ShouldEqual, models.PublishedState)
+			}
+		})
+	})
+}
+
 func TestAssociateVersionInvalidVersion(t *testing.T) {
 	t.Parallel()
 
@@ -500,7 +795,9 @@ func TestAssociateVersionInvalidVersion(t *testing.T) {
 			edition:   "2017",
 		}
 
-		mockedDataStore := &storetest.StorerMock{}
+		mockedDataStore := &storetest.StorerMock{
+			RunTransactionFunc: runTransaction,
+		}
 
 		states, transitions := setUpStatesTransitions()
 
@@ -540,6 +837,7 @@ func TestAssociateVersionInvalidType(t *testing.T) {
 		}
 
 		mockedDataStore := &storetest.StorerMock{
+			RunTransactionFunc: runTransaction,
 			UpdateVersionFunc: func(context.Context, *models.Version, *models.Version, string) (string, error) {
 				return "", nil
 			},
@@ -619,6 +917,7 @@ func TestAssociateStaticVersionNoErrors(t *testing.T) {
 		}
 
 		mockedDataStore := &storetest.StorerMock{
+			RunTransactionFunc: runTransaction,
 			UpdateVersionFunc: func(context.Context, *models.Version, *models.Version, string) (string, error) {
 				return "", nil
 			},
@@ -659,6 +958,7 @@ func TestAssociateVersionErrors(t *testing.T) {
 		}
 
 		mockedDataStore := &storetest.StorerMock{
+			RunTransactionFunc: runTransaction,
 			UpdateVersionFunc: func(context.Context, *models.Version, *models.Version, string) (string, error) {
 				return "", nil
 			},
@@ -753,6 +1053,7 @@ func TestAssociateVersionFailedToGenerateDownloads(t *testing.T) {
 		}
 
 		mockedDataStore := &storetest.StorerMock{
+			RunTransactionFunc: runTransaction,
 			UpdateVersionFunc: func(context.Context, *models.Version, *models.Version, string) (string, error) {
 				return "", nil
 			},
@@ -789,6 +1090,7 @@ func TestApproveVersionReturnsOK(t *testing.T) {
 		}
 
 		mockedDataStore := &storetest.StorerMock{
+			RunTransactionFunc: runTransaction,
 			UpdateVersionFunc: func(context.Context, *models.Version, *models.Version, string) (string, error) {
 				return "", nil
 			},
@@ -821,6 +1123,7 @@ func TestApproveVersionFails(t *testing.T) {
 		}
 
 		mockedDataStore := &storetest.StorerMock{
+			RunTransactionFunc: runTransaction,
 			UpdateVersionFunc: func(context.Context, *models.Version, *models.Version, string) (string, error) {
 				return "", errs.ErrDatasetNotFound
 			},
@@ -913,6 +1216,7 @@ func TestEditionConfirmVersionReturnsOK(t *testing.T) {
 		}
 
 		mockedDataStore := &storetest.StorerMock{
+			RunTransactionFunc: runTransaction,
 			UpdateVersionFunc: func(context.Context, *models.Version, *models.Version, string) (string, error) {
 				return "", nil
 			},
@@ -945,6 +1249,7 @@ func TestEditionConfirmVersionUpdateVersionFails(t *testing.T) {
 		}
 
 		mockedDataStore := &storetest.StorerMock{
+			RunTransactionFunc: runTransaction,
 			UpdateVersionFunc: func(context.Context, *models.Version, *models.Version, string) (string, error) {
 				return "", errs.ErrDatasetNotFound
 			},
@@ -1439,6 +1744,7 @@ func TestPublishCMDVersionFailsToPublish(t *testing.T) {
 		}
 
 		mockedDataStore := &storetest.StorerMock{
+			RunTransactionFunc: runTransaction,
 			UpdateVersionFunc: func(context.Context, *models.Version, *models.Version, string) (string, error) {
 				return "", nil
 			},
@@ -1473,6 +1779,15 @@ func TestPublishCMDVersionFailsToPublish(t *testing.T) {
 			UpsertEditionFunc: func(context.Context, string, string, *models.EditionUpdate) error {
 				return nil
 			},
+			GetDatasetFunc: func(_ context.Context, _ string) (*models.DatasetUpdate, error) {
+				return &models.DatasetUpdate{
+					ID:   "123",
+					Next: &models.Dataset{Links: &models.DatasetLinks{}},
+				}, nil
+			},
+			UpsertDatasetFunc: func(context.Context, string, *models.DatasetUpdate) error {
+				return nil
+			},
 			SetInstanceIsPublishedFunc: func(context.Context, string) error {
 				return errors.New("failed to set is_published on the instance node")
 			},
@@ -1494,6 +1809,7 @@ func TestPublishCMDVersionFailsToPublish(t *testing.T) {
 		So(len(mockedDataStore.GetEditionCalls()), ShouldEqual, 1)
 		So(len(mockedDataStore.UpsertEditionCalls()), ShouldEqual, 1)
 		So(len(mockedDataStore.SetInstanceIsPublishedCalls()), ShouldEqual, 1)
+		So(len(mockedDataStore.UpsertDatasetCalls()), ShouldEqual, 1)
 	})
 }
 
@@ -1555,6 +1871,7 @@ func TestPublishVersionDatabaseFails(t *testing.T) {
 		}
 
 		mockedDataStore := &storetest.StorerMock{
+			RunTransactionFunc: runTransaction,
 			UpdateVersionFunc: func(context.Context, *models.Version, *models.Version, string) (string, error) {
 				return "", nil
 			},
@@ -1619,7 +1936,7 @@ func TestPublishVersionDatabaseFails(t *testing.T) {
 		So(len(mockedDataStore.UpdateVersionCalls()), ShouldEqual, 1)
 		So(len(mockedDataStore.GetEditionCalls()), ShouldEqual, 1)
 		So(len(mockedDataStore.UpsertEditionCalls()), ShouldEqual, 1)
-		So(len(mockedDataStore.SetInstanceIsPublishedCalls()), ShouldEqual, 1)
+		So(len(mockedDataStore.SetInstanceIsPublishedCalls()), ShouldEqual, 0)
 		So(len(mockedDataStore.UpsertDatasetCalls()), ShouldEqual, 1)
 	})
 }
@@ -1682,6 +1999,7 @@ func TestPublishVersionDatasetNotFound(t *testing.T) {
 		}
 
 		mockedDataStore := &storetest.StorerMock{
+			RunTransactionFunc: runTransaction,
 			UpdateVersionFunc: func(context.Context, *models.Version, *models.Version, string) (string, error) {
 				return "", nil
 			},
@@ -1740,7 +2058,7 @@ func TestPublishVersionDatasetNotFound(t *testing.T) {
 		So(len(mockedDataStore.UpdateVersionCalls()), ShouldEqual, 1)
 		So(len(mockedDataStore.GetEditionCalls()), ShouldEqual, 1)
 		So(len(mockedDataStore.UpsertEditionCalls()), ShouldEqual, 1)
-		So(len(mockedDataStore.SetInstanceIsPublishedCalls()), ShouldEqual, 1)
+		So(len(mockedDataStore.SetInstanceIsPublishedCalls()), ShouldEqual, 0)
 	})
 }
 
@@ -1821,6 +2139,7 @@ func TestPublishVersionInvalidType(t *testing.T) {
 		}
 
 		mockedDataStore := &storetest.StorerMock{
+			RunTransactionFunc: runTransaction,
 			UpdateVersionFunc: func(context.Context, *models.Version, *models.Version, string) (string, error) {
 				return "", nil
 			},
@@ -1868,9 +2187,8 @@ func TestPublishVersionInvalidType(t *testing.T) {
 
 		So(err, ShouldNotBeNil)
 		So(err.Error(), ShouldContainSubstring, "invalid dataset type")
-		So(len(mockedDataStore.UpdateVersionCalls()), ShouldEqual, 1)
-		So(len(mockedDataStore.GetEditionCalls()), ShouldEqual, 1)
-		So(len(mockedDataStore.UpsertEditionCalls()), ShouldEqual, 1)
+		So(len(mockedDataStore.RunTransactionCalls()), ShouldEqual, 0)
+		So(len(mockedDataStore.UpdateVersionCalls()), ShouldEqual, 0)
 	})
 }
 
@@ -1950,6 +2268,7 @@ func TestPublishVersionDatasetDownloadsOK(t *testing.T) {
 		}
 
 		mockedDataStore := &storetest.StorerMock{
+			RunTransactionFunc: runTransaction,
 			UpdateVersionFunc: func(context.Context, *models.Version, *models.Version, string) (string, error) {
 				return "", nil
 			},
@@ -2092,6 +2411,7 @@ func TestPublishVersionDatasetDownloadsOK(t *testing.T) {
 		}
 
 		mockedDataStore := &storetest.StorerMock{
+			RunTransactionFunc: runTransaction,
 			UpdateVersionStaticFunc: func(context.Context, *models.Version, *models.Version, string) (string, error) {
 				return "", nil
 			},
@@ -2215,6 +2535,7 @@ func TestPublishVersionFailedToUpdate(t *testing.T) {
 		}
 
 		mockedDataStore := &storetest.StorerMock{
+			RunTransactionFunc: runTransaction,
 			UpdateVersionFunc: func(context.Context, *models.Version, *models.Version, string) (string, error) {
 				return "", errs.ErrDatasetNotFound
 			},
@@ -2299,6 +2620,7 @@ func TestPublishVersionPublishLinksFails(t *testing.T) {
 		}
 
 		mockedDataStore := &storetest.StorerMock{
+			RunTransactionFunc: runTransaction,
 			UpdateVersionFunc: func(context.Context, *models.Version, *models.Version, string) (string, error) {
 				return "", nil
 			},
@@ -2419,6 +2741,7 @@ func TestPublishVersionUpsertEditionFails(t *testing.T) {
 		}
 
 		mockedDataStore := &storetest.StorerMock{
+			RunTransactionFunc: runTransaction,
 			UpdateVersionFunc: func(context.Context, *models.Version, *models.Version, string) (string, error) {
 				return "", nil
 			},
@@ -2467,12 +2790,455 @@ func TestPublishVersionUpsertEditionFails(t *testing.T) {
 
 		So(err, ShouldNotBeNil)
 		So(err.Error(), ShouldContainSubstring, "edition not found")
+		So(len(mockedDataStore.RunTransactionCalls()), ShouldEqual, 1)
 		So(len(mockedDataStore.UpdateVersionCalls()), ShouldEqual, 1)
 		So(len(mockedDataStore.GetEditionCalls()), ShouldEqual, 1)
 		So(len(mockedDataStore.UpsertEditionCalls()), ShouldEqual, 1)
 	})
 }
 
+func TestPublishVersionTransactionFails(t *testing.T) {
+	t.Parallel()
+	Convey("When a version is set to published from associated but the transaction cannot be run", t, func() {
+		currentVersion := &models.Version{
+			State: models.AssociatedState,
+			Type:  models.CantabularFlexibleTable.String(),
+		}
+
+		versionUpdate := &models.Version{
+			State:       models.PublishedState,
+			ReleaseDate: "2024-12-31",
+			Version:     1,
+			ID:          "789",
+			Type:        models.CantabularFlexibleTable.String(),
+		}
+
+		mockedDataStore := &storetest.StorerMock{
+			RunTransactionFunc: func(context.Context, func(context.Context) error) error {
+				return errs.ErrInternalServer
+			},
+		}
+
+		states, transitions := setUpStatesTransitions()
+
+		stateMachine := NewStateMachine(testContext, states, transitions, store.DataStore{Backend: mockedDataStore})
+
+		smDS := GetStateMachineAPIWithCMDMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, stateMachine)
+		err := PublishVersion(testContext, smDS, currentVersion, versionUpdate, versionDetails, "")
+
+		So(err, ShouldEqual, errs.ErrInternalServer)
+		So(len(mockedDataStore.RunTransactionCalls()), ShouldEqual, 1)
+		So(len(mockedDataStore.UpdateVersionCalls()), ShouldEqual, 0)
+	})
+}
+
+func TestPublishStaticVersionSearchContentUpdated(t *testing.T) {
+	t.Parallel()
+
+	staticVersion := func() *models.Version {
+		return &models.Version{
+			State:        models.ApprovedState,
+			Edition:      "2017",
+			EditionTitle: "2017 edition",
+			ReleaseDate:  "2024-12-31",
+			Type:         models.Static.String(),
+			Links: &models.VersionLinks{
+				Version: &models.LinkObject{
+					HRef: "http://localhost:22000/datasets/123/editions/2017/versions/1",
+					ID:   "1",
+				},
+			},
+		}
+	}
+
+	versionUpdate := func() *models.Version {
+		return &models.Version{
+			State:       models.PublishedState,
+			ReleaseDate: "2024-12-31",
+			ID:          "a1b2c3",
+			Type:        models.Static.String(),
+		}
+	}
+
+	newStaticStore := func() *storetest.StorerMock {
+		return &storetest.StorerMock{
+			RunTransactionFunc: runTransaction,
+			UpdateVersionStaticFunc: func(context.Context, *models.Version, *models.Version, string) (string, error) {
+				return "", nil
+			},
+			GetDatasetTypeFunc: func(context.Context, string, bool) (string, error) {
+				return models.Static.String(), nil
+			},
+			GetVersionStaticFunc: func(context.Context, string, string, int, string) (*models.Version, error) {
+				return staticVersion(), nil
+			},
+			UpsertVersionStaticFunc: func(context.Context, *models.Version) error {
+				return nil
+			},
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
+				return &models.DatasetUpdate{ID: "123", Next: &models.Dataset{Links: &models.DatasetLinks{}}}, nil
+			},
+			UpsertDatasetFunc: func(context.Context, string, *models.DatasetUpdate) error {
+				return nil
+			},
+		}
+	}
+
+	Convey("When a static version is published", t, func() {
+		mockedDataStore := newStaticStore()
+		outboxMock := &mocks.OutboxMock{
+			WriteFunc: func(context.Context, []byte) error {
+				return nil
+			},
+		}
+
+		smDS := Setup(store.DataStore{Backend: mockedDataStore}, nil, outboxMock, nil, &StateMachine{})
+		err := PublishVersion(testContext, smDS, staticVersion(), versionUpdate(), versionDetails, "")
+
+		Convey("Then the search content updated event is written to the outbox within the publish transaction", func() {
+			So(err, ShouldBeNil)
+			So(mockedDataStore.RunTransactionCalls(), ShouldHaveLength, 1)
+			So(outboxMock.WriteCalls(), ShouldHaveLength, 1)
+			So(string(outboxMock.WriteCalls()[0].Message), ShouldEqual,
+				`{"content_type":"dataset_landing_page","dataset_id":"123","edition":"2017","release_date":"2024-12-31","title":"2017 edition","uri":"/datasets/123"}`)
+		})
+	})
+
+	Convey("When a static version is published but the event cannot be written to the outbox", t, func() {
+		mockedDataStore := newStaticStore()
+		outboxMock := &mocks.OutboxMock{
+			WriteFunc: func(context.Context, []byte) error {
+				return errs.ErrInternalServer
+			},
+		}
+
+		smDS := Setup(store.DataStore{Backend: mockedDataStore}, nil, outboxMock, nil, &StateMachine{})
+		err := PublishVersion(testContext, smDS, staticVersion(), versionUpdate(), versionDetails, "")
+
+		Convey("Then the publish transaction fails", func() {
+			So(err, ShouldEqual, errs.ErrInternalServer)
+			So(mockedDataStore.RunTransactionCalls(), ShouldHaveLength, 1)
+			So(outboxMock.WriteCalls(), ShouldHaveLength, 1)
+		})
+	})
+}
+
+func TestWithdrawStaticVersion(t *testing.T) {
+	t.Parallel()
+
+	publishedVersion := func() *models.Version {
+		return &models.Version{
+			State:       models.PublishedState,
+			Edition:     "2017",
+			ReleaseDate: "2024-12-31",
+			Type:        models.Static.String(),
+			Links: &models.VersionLinks{
+				Version: &models.LinkObject{HRef: "http://localhost:22000/datasets/123/editions/2017/versions/2", ID: "2"},
+			},
+		}
+	}
+
+	withdrawal := func(reason string) *models.Version {
+		return &models.Version{
+			State:       models.WithdrawnState,
+			ReleaseDate: "2024-12-31",
+			ID:          "a1b2c3",
+			Type:        models.Static.String(),
+			Withdrawal:  models.NewWithdrawal(reason, "publisher@ons.gov.uk"),
+		}
+	}
+
+	withdrawnVersionDetails := VersionDetails{datasetID: "123", edition: "2017", version: "2"}
+
+	newStaticStore := func(latestVersion *models.LinkObject) *storetest.StorerMock {
+		return &storetest.StorerMock{
+			RunTransactionFunc: runTransaction,
+			UpdateVersionStaticFunc: func(context.Context, *models.Version, *models.Version, string) (string, error) {
+				return "", nil
+			},
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
+				return &models.DatasetUpdate{
+					ID:      "123",
+					Current: &models.Dataset{Links: &models.DatasetLinks{LatestVersion: latestVersion}},
+					Next:    &models.Dataset{Links: &models.DatasetLinks{LatestVersion: latestVersion}},
+				}, nil
+			},
+			GetAllStaticVersionsFunc: func(context.Context, string, string, int, int) ([]*models.Version, int, error) {
+				return []*models.Version{{Links: &models.VersionLinks{
+					Version: &models.LinkObject{HRef: "http://localhost:22000/datasets/123/editions/2017/versions/1", ID: "1"},
+				}}}, 1, nil
+			},
+			UpsertDatasetFunc: func(context.Context, string, *models.DatasetUpdate) error {
+				return nil
+			},
+		}
+	}
+
+	newOutboxMock := func() *mocks.OutboxMock {
+		return &mocks.OutboxMock{
+			WriteFunc: func(context.Context, []byte) error {
+				return nil
+			},
+		}
+	}
+
+	Convey("When the latest version of a static dataset is withdrawn", t, func() {
+		mockedDataStore := newStaticStore(&models.LinkObject{HRef: "/datasets/123/editions/2017/versions/2", ID: "2"})
+		outboxMock := newOutboxMock()
+
+		smDS := Setup(store.DataStore{Backend: mockedDataStore}, nil, nil, outboxMock, &StateMachine{})
+		err := WithdrawVersion(testContext, smDS, publishedVersion(), withdrawal("incorrect figures"), withdrawnVersionDetails, "")
+
+		Convey("Then the version is updated and the latest version of the dataset is moved back to the latest published version", func() {
+			So(err, ShouldBeNil)
+			So(mockedDataStore.RunTransactionCalls(), ShouldHaveLength, 1)
+			So(mockedDataStore.UpdateVersionStaticCalls(), ShouldHaveLength, 1)
+			So(mockedDataStore.GetAllStaticVersionsCalls(), ShouldHaveLength, 1)
+			So(mockedDataStore.GetAllStaticVersionsCalls()[0].ID, ShouldEqual, "123")
+			So(mockedDataStore.GetAllStaticVersionsCalls()[0].State, ShouldEqual, models.PublishedState)
+			So(mockedDataStore.GetAllStaticVersionsCalls()[0].Limit, ShouldEqual, 1)
+			So(mockedDataStore.UpsertDatasetCalls(), ShouldHaveLength, 1)
+			dataset := mockedDataStore.UpsertDatasetCalls()[0].DatasetDoc
+			So(dataset.Current.Links.LatestVersion.ID, ShouldEqual, "1")
+			So(dataset.Next.Links.LatestVersion.ID, ShouldEqual, "1")
+		})
+
+		Convey("Then the search content deleted event is written to the outbox within the transaction", func() {
+			So(outboxMock.WriteCalls(), ShouldHaveLength, 1)
+			So(string(outboxMock.WriteCalls()[0].Message), ShouldEqual, `{"uri":"/datasets/123/editions/2017/versions/2"}`)
+		})
+	})
+
+	Convey("When the only published version of the latest edition of a static dataset is withdrawn", t, func() {
+		mockedDataStore := newStaticStore(&models.LinkObject{HRef: "/datasets/123/editions/2018/versions/1", ID: "1"})
+		mockedDataStore.GetAllStaticVersionsFunc = func(context.Context, string, string, int, int) ([]*models.Version, int, error) {
+			return []*models.Version{{Links: &models.VersionLinks{
+				Version: &models.LinkObject{HRef: "http://localhost:22000/datasets/123/editions/2017/versions/3", ID: "3"},
+			}}}, 1, nil
+		}
+		outboxMock := newOutboxMock()
+
+		smDS := Setup(store.DataStore{Backend: mockedDataStore}, nil, nil, outboxMock, &StateMachine{})
+		err := WithdrawVersion(testContext, smDS, publishedVersion(), withdrawal("incorrect figures"), VersionDetails{datasetID: "123", edition: "2018", version: "1"}, "")
+
+		Convey("Then the latest version of the dataset is moved to the latest published version of an earlier edition", func() {
+			So(err, ShouldBeNil)
+			So(mockedDataStore.UpsertDatasetCalls(), ShouldHaveLength, 1)
+			dataset := mockedDataStore.UpsertDatasetCalls()[0].DatasetDoc
+			So(dataset.Current.Links.LatestVersion, ShouldResemble, &models.LinkObject{HRef: "http://localhost:22000/datasets/123/editions/2017/versions/3", ID: "3"})
+			So(dataset.Next.Links.LatestVersion, ShouldResemble, &models.LinkObject{HRef: "http://localhost:22000/datasets/123/editions/2017/versions/3", ID: "3"})
+		})
+	})
+
+	Convey("When the only published version of a static dataset is withdrawn", t, func() {
+		mockedDataStore := newStaticStore(&models.LinkObject{HRef: "/datasets/123/editions/2017/versions/2", ID: "2"})
+		mockedDataStore.GetAllStaticVersionsFunc = func(context.Context, string, string, int, int) ([]*models.Version, int, error) {
+			return nil, 0, errs.ErrVersionsNotFound
+		}
+		outboxMock := newOutboxMock()
+
+		smDS := Setup(store.DataStore{Backend: mockedDataStore}, nil, nil, outboxMock, &StateMachine{})
+		err := WithdrawVersion(testContext, smDS, publishedVersion(), withdrawal("incorrect figures"), withdrawnVersionDetails, "")
+
+		Convey("Then the latest version link of the dataset is removed", func() {
+			So(err, ShouldBeNil)
+			So(mockedDataStore.UpsertDatasetCalls(), ShouldHaveLength, 1)
+			dataset := mockedDataStore.UpsertDatasetCalls()[0].DatasetDoc
+			So(dataset.Current.Links.LatestVersion, ShouldBeNil)
+			So(dataset.Next.Links.LatestVersion, ShouldBeNil)
+		})
+	})
+
+	Convey("When an earlier version of a static dataset is withdrawn", t, func() {
+		mockedDataStore := newStaticStore(&models.LinkObject{HRef: "/datasets/123/editions/2017/versions/3", ID: "3"})
+		outboxMock := newOutboxMock()
+
+		smDS := Setup(store.DataStore{Backend: mockedDataStore}, nil, nil, outboxMock, &StateMachine{})
+		err := WithdrawVersion(testContext, smDS, publishedVersion(), withdrawal("incorrect figures"), withdrawnVersionDetails, "")
+
+		Convey("Then the latest version of the dataset is left unchanged", func() {
+			So(err, ShouldBeNil)
+			So(mockedDataStore.UpsertDatasetCalls(), ShouldBeEmpty)
+			So(outboxMock.WriteCalls(), ShouldHaveLength, 1)
+		})
+	})
+
+	Convey("When a static version is withdrawn without a reason", t, func() {
+		mockedDataStore := newStaticStore(nil)
+		outboxMock := newOutboxMock()
+
+		smDS := Setup(store.DataStore{Backend: mockedDataStore}, nil, nil, outboxMock, &StateMachine{})
+		err := WithdrawVersion(testContext, smDS, publishedVersion(), withdrawal(""), withdrawnVersionDetails, "")
+
+		Convey("Then the version is not withdrawn", func() {
+			So(err, ShouldEqual, models.ErrWithdrawnVersionReasonMissing)
+			So(mockedDataStore.UpdateVersionStaticCalls(), ShouldBeEmpty)
+			So(outboxMock.WriteCalls(), ShouldBeEmpty)
+		})
+	})
+}
+
+func TestWithdrawNonStaticVersion(t *testing.T) {
+	t.Parallel()
+
+	publishedVersion := &models.Version{
+		State:       models.PublishedState,
+		Edition:     "2017",
+		ReleaseDate: "2024-12-31",
+		Type:        models.Filterable.String(),
+	}
+
+	withdrawal := &models.Version{
+		State:       models.WithdrawnState,
+		ReleaseDate: "2024-12-31",
+		ID:          "a1b2c3",
+		Type:        models.Filterable.String(),
+		Withdrawal:  models.NewWithdrawal("incorrect figures", "publisher@ons.gov.uk"),
+	}
+
+	withdrawnLink := &models.LinkObject{HRef: "/datasets/123/editions/2017/versions/2", ID: "2"}
+
+	Convey("When the latest version of a filterable dataset is withdrawn", t, func() {
+		mockedDataStore := &storetest.StorerMock{
+			RunTransactionFunc: runTransaction,
+			UpdateVersionFunc: func(context.Context, *models.Version, *models.Version, string) (string, error) {
+				return "", nil
+			},
+			GetEditionFunc: func(context.Context, string, string, string) (*models.EditionUpdate, error) {
+				return &models.EditionUpdate{
+					ID:      "edition-2017",
+					Current: &models.Edition{Links: &models.EditionUpdateLinks{LatestVersion: withdrawnLink}},
+					Next:    &models.Edition{Links: &models.EditionUpdateLinks{LatestVersion: withdrawnLink}},
+				}, nil
+			},
+			UpsertEditionFunc: func(context.Context, string, string, *models.EditionUpdate) error {
+				return nil
+			},
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
+				return &models.DatasetUpdate{
+					ID:      "123",
+					Current: &models.Dataset{Links: &models.DatasetLinks{LatestVersion: withdrawnLink}},
+					Next:    &models.Dataset{Links: &models.DatasetLinks{LatestVersion: withdrawnLink}},
+				}, nil
+			},
+			GetLatestVersionFunc: func(_ context.Context, _, edition, _ string) (*models.Version, error) {
+				if edition == "" {
+					return &models.Version{Links: &models.VersionLinks{
+						Version: &models.LinkObject{HRef: "http://localhost:22000/datasets/123/editions/2018/versions/1", ID: "1"},
+					}}, nil
+				}
+				return nil, errs.ErrVersionNotFound
+			},
+			UpsertDatasetFunc: func(context.Context, string, *models.DatasetUpdate) error {
+				return nil
+			},
+		}
+		outboxMock := &mocks.OutboxMock{
+			WriteFunc: func(context.Context, []byte) error {
+				return nil
+			},
+		}
+
+		smDS := Setup(store.DataStore{Backend: mockedDataStore}, nil, nil, outboxMock, &StateMachine{})
+		err := WithdrawVersion(testContext, smDS, publishedVersion, withdrawal, VersionDetails{datasetID: "123", edition: "2017", version: "2"}, "")
+
+		Convey("Then the version is updated and the latest version links of the edition and dataset are moved back to the latest published versions", func() {
+			So(err, ShouldBeNil)
+			So(mockedDataStore.UpdateVersionCalls(), ShouldHaveLength, 1)
+			So(mockedDataStore.GetLatestVersionCalls(), ShouldHaveLength, 2)
+			So(mockedDataStore.GetLatestVersionCalls()[0].EditionID, ShouldEqual, "2017")
+			So(mockedDataStore.GetLatestVersionCalls()[0].State, ShouldEqual, models.PublishedState)
+
+			So(mockedDataStore.UpsertEditionCalls(), ShouldHaveLength, 1)
+			edition := mockedDataStore.UpsertEditionCalls()[0].EditionDoc
+			So(edition.Current.Links.LatestVersion, ShouldBeNil)
+			So(edition.Next.Links.LatestVersion, ShouldBeNil)
+
+			So(mockedDataStore.UpsertDatasetCalls(), ShouldHaveLength, 1)
+			dataset := mockedDataStore.UpsertDatasetCalls()[0].DatasetDoc
+			So(dataset.Current.Links.LatestVersion.ID, ShouldEqual, "1")
+			So(dataset.Next.Links.LatestVersion.ID, ShouldEqual, "1")
+		})
+
+		Convey("Then the search content deleted event is written to the outbox", func() {
+			So(outboxMock.WriteCalls(), ShouldHaveLength, 1)
+			So(string(outboxMock.WriteCalls()[0].Message), ShouldEqual, `{"uri":"/datasets/123/editions/2017/versions/2"}`)
+		})
+	})
+}
+
+func TestRejectApprovedStaticVersion(t *testing.T) {
+	t.Parallel()
+
+	approvedVersion := func() *models.Version {
+		return &models.Version{
+			State:          models.ApprovedState,
+			Edition:        "2017",
+			ReleaseDate:    "2024-12-31",
+			Type:           models.Static.String(),
+			ReviewComments: []models.ReviewComment{models.NewReviewComment("add the usage notes", "reviewer@ons.gov.uk")},
+		}
+	}
+
+	rejection := func(comments ...models.ReviewComment) *models.Version {
+		return &models.Version{
+			State:          models.AssociatedState,
+			ReleaseDate:    "2024-12-31",
+			ID:             "a1b2c3",
+			Type:           models.Static.String(),
+			ReviewComments: append(approvedVersion().ReviewComments, comments...),
+		}
+	}
+
+	newStaticStore := func() *storetest.StorerMock {
+		return &storetest.StorerMock{
+			RunTransactionFunc: runTransaction,
+			UpdateVersionStaticFunc: func(context.Context, *models.Version, *models.Version, string) (string, error) {
+				return "", nil
+			},
+		}
+	}
+
+	Convey("When an approved static version is rejected with a comment", t, func() {
+		mockedDataStore := newStaticStore()
+
+		smDS := Setup(store.DataStore{Backend: mockedDataStore}, nil, nil, nil, &StateMachine{})
+		err := AssociateVersion(testContext, smDS, approvedVersion(), rejection(models.NewReviewComment("the figures do not match the source", "reviewer@ons.gov.uk")), versionDetails, "")
+
+		Convey("Then the version is moved back to associated with the comment added to its review comments", func() {
+			So(err, ShouldBeNil)
+			So(mockedDataStore.UpdateVersionStaticCalls(), ShouldHaveLength, 1)
+			comments := mockedDataStore.UpdateVersionStaticCalls()[0].VersionUpdate.ReviewComments
+			So(comments, ShouldHaveLength, 2)
+			So(comments[1].Comment, ShouldEqual, "the figures do not match the source")
+		})
+	})
+
+	Convey("When an approved static version is rejected without a comment", t, func() {
+		mockedDataStore := newStaticStore()
+
+		smDS := Setup(store.DataStore{Backend: mockedDataStore}, nil, nil, nil, &StateMachine{})
+		err := AssociateVersion(testContext, smDS, approvedVersion(), rejection(), versionDetails, "")
+
+		Convey("Then the version is not rejected", func() {
+			So(err, ShouldEqual, models.ErrRejectedVersionCommentMissing)
+			So(mockedDataStore.UpdateVersionStaticCalls(), ShouldBeEmpty)
+		})
+	})
+
+	Convey("When an approved static version is rejected with a blank comment", t, func() {
+		mockedDataStore := newStaticStore()
+
+		smDS := Setup(store.DataStore{Backend: mockedDataStore}, nil, nil, nil, &StateMachine{})
+		err := AssociateVersion(testContext, smDS, approvedVersion(), rejection(models.NewReviewComment("  ", "reviewer@ons.gov.uk")), versionDetails, "")
+
+		Convey("Then the version is not rejected", func() {
+			So(err, ShouldEqual, models.ErrRejectedVersionCommentMissing)
+			So(mockedDataStore.UpdateVersionStaticCalls(), ShouldBeEmpty)
+		})
+	})
+}
+
 func TestPublishVersionFailedToGenerateDownloads(t *testing.T) {
 	t.Parallel()
 	Convey("When a version is set to published from associated but the downloads fail to generate", t, func() {
@@ -2544,6 +3310,7 @@ func TestPublishVersionFailedToGenerateDownloads(t *testing.T) {
 		}
 
 		mockedDataStore := &storetest.StorerMock{
+			RunTransactionFunc: runTransaction,
 			UpdateVersionFunc: func(context.Context, *models.Version, *models.Version, string) (string, error) {
 				return "", nil
 			},
@@ -2678,6 +3445,7 @@ func TestPublishVersionFailedToFindEdition(t *testing.T) {
 		}
 
 		mockedDataStore := &storetest.StorerMock{
+			RunTransactionFunc: runTransaction,
 			UpdateVersionFunc: func(context.Context, *models.Version, *models.Version, string) (string, error) {
 				return "", nil
 			},
@@ -2746,7 +3514,19 @@ func GetStateMachineAPIWithCMDMocks(mockedDataStore store.Storer, mockedGenerate
 		models.CantabularFlexibleTable: mockedGeneratedDownloads,
 	}
 
-	return Setup(store.DataStore{Backend: mockedDataStore}, mockedMapSMGeneratedDownloads, statemachine)
+	searchContentUpdatedMock := &mocks.OutboxMock{
+		WriteFunc: func(context.Context, []byte) error {
+			return nil
+		},
+	}
+
+	searchContentDeletedMock := &mocks.OutboxMock{
+		WriteFunc: func(context.Context, []byte) error {
+			return nil
+		},
+	}
+
+	return Setup(store.DataStore{Backend: mockedDataStore}, mockedMapSMGeneratedDownloads, searchContentUpdatedMock, searchContentDeletedMock, statemachine)
 }
 
 func TestPopulateNewVersionDocWithEditionChange(t *testing.T) {
@@ -3030,35 +3810,28 @@ func TestDeleteStaticVersion_ReturnSuccess(t *testing.T) {
 							Title:       "Distribution 1",
 							DownloadURL: "/path/to/distribution",
 						},
-						{
-							Title:       "Distribution 2",
-							DownloadURL: "/path/to/distribution2",
-						},
 					}}, nil
 			},
 			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
 				return &models.DatasetUpdate{Current: &models.Dataset{State: models.PublishedState}}, nil
 			},
-			DeleteStaticDatasetVersionFunc: func(context.Context, string, string, int) error { return nil },
+			RunTransactionFunc:             runTransaction,
+			DeleteStaticDatasetVersionFunc: func(context.Context, string, string, int, *models.Deletion) error { return nil },
 			UpsertDatasetFunc:              func(context.Context, string, *models.DatasetUpdate) error { return nil },
 		}
 
-		mockFilesAPIClient := &filesAPISDKMocks.ClienterMock{
-			DeleteFileFunc: func(ctx context.Context, filePath string, headers filesAPISDK.Headers) error {
-				return nil
-			},
-		}
-
 		sm := &StateMachine{}
-		smDS := Setup(store.DataStore{Backend: mocked}, map[models.DatasetType]DownloadsGenerator{}, sm)
+		smDS := Setup(store.DataStore{Backend: mocked}, map[models.DatasetType]DownloadsGenerator{}, nil, nil, sm)
 
-		_, err := smDS.DeleteStaticVersion(context.Background(), "ds1", "ed1", 1, mockFilesAPIClient, "test-token")
+		_, err := smDS.DeleteStaticVersion(context.Background(), "ds1", "ed1", 1, "user@ons.gov.uk")
 		So(err, ShouldBeNil)
 		So(len(mocked.CheckEditionExistsStaticCalls()), ShouldEqual, 1)
 		So(len(mocked.GetVersionStaticCalls()), ShouldEqual, 1)
-		So(len(mockFilesAPIClient.DeleteFileCalls()), ShouldEqual, 2)
 		So(len(mocked.GetDatasetCalls()), ShouldEqual, 1)
+		So(len(mocked.RunTransactionCalls()), ShouldEqual, 1)
 		So(len(mocked.DeleteStaticDatasetVersionCalls()), ShouldEqual, 1)
+		So(mocked.DeleteStaticDatasetVersionCalls()[0].Deletion.DeletedBy, ShouldEqual, "user@ons.gov.uk")
+		So(mocked.DeleteStaticDatasetVersionCalls()[0].Deletion.DeletedAt, ShouldNotBeZeroValue)
 		So(len(mocked.UpsertDatasetCalls()), ShouldEqual, 1)
 	})
 }
@@ -3066,49 +3839,12 @@ func TestDeleteStaticVersion_ReturnSuccess(t *testing.T) {
 func TestDeleteStaticVersion_Errors(t *testing.T) {
 	t.Parallel()
 
-	Convey("When an invalid access token is provided, the filesAPIClient receives the token and returns an error", t, func() {
-		invalidToken := "invalid-token"
-		expectedError := errors.New("unauthorized: invalid access token")
-
-		mocked := &storetest.StorerMock{
-			CheckEditionExistsStaticFunc: func(context.Context, string, string, string) error { return nil },
-			GetVersionStaticFunc: func(context.Context, string, string, int, string) (*models.Version, error) {
-				return &models.Version{
-					State: models.AssociatedState,
-					Distributions: &[]models.Distribution{
-						{
-							Title:       "Test File",
-							DownloadURL: "/test/path.csv",
-						},
-					},
-				}, nil
-			},
-		}
-
-		mockFilesAPIClient := &filesAPISDKMocks.ClienterMock{
-			DeleteFileFunc: func(ctx context.Context, filePath string, headers filesAPISDK.Headers) error {
-				if headers.Authorization == invalidToken {
-					return expectedError
-				}
-				return nil
-			},
-		}
-
-		smDS := Setup(store.DataStore{Backend: mocked}, nil, &StateMachine{})
-
-		_, err := smDS.DeleteStaticVersion(context.Background(), "ds1", "ed1", 1, mockFilesAPIClient, invalidToken)
-
-		So(err, ShouldEqual, expectedError)
-		So(len(mockFilesAPIClient.DeleteFileCalls()), ShouldEqual, 1)
-		So(mockFilesAPIClient.DeleteFileCalls()[0].Headers.Authorization, ShouldEqual, invalidToken)
-	})
-
 	Convey("When edition doesn't exist, return edition not found", t, func() {
 		mocked := &storetest.StorerMock{
 			CheckEditionExistsStaticFunc: func(context.Context, string, string, string) error { return errs.ErrEditionNotFound },
 		}
-		smDS := Setup(store.DataStore{Backend: mocked}, nil, &StateMachine{})
-		_, err := smDS.DeleteStaticVersion(context.Background(), "ds1", "missing", 1, nil, "test-token")
+		smDS := Setup(store.DataStore{Backend: mocked}, nil, nil, nil, &StateMachine{})
+		_, err := smDS.DeleteStaticVersion(context.Background(), "ds1", "missing", 1, "user@ons.gov.uk")
 		So(err, ShouldEqual, errs.ErrEditionNotFound)
 		So(len(mocked.CheckEditionExistsStaticCalls()), ShouldEqual, 1)
 	})
@@ -3120,8 +3856,8 @@ func TestDeleteStaticVersion_Errors(t *testing.T) {
 				return nil, errs.ErrVersionNotFound
 			},
 		}
-		smDS := Setup(store.DataStore{Backend: mocked}, nil, &StateMachine{})
-		_, err := smDS.DeleteStaticVersion(context.Background(), "ds1", "ed1", 10, nil, "test-token")
+		smDS := Setup(store.DataStore{Backend: mocked}, nil, nil, nil, &StateMachine{})
+		_, err := smDS.DeleteStaticVersion(context.Background(), "ds1", "ed1", 10, "user@ons.gov.uk")
 		So(err, ShouldEqual, errs.ErrVersionNotFound)
 		So(len(mocked.CheckEditionExistsStaticCalls()), ShouldEqual, 1)
 		So(len(mocked.GetVersionStaticCalls()), ShouldEqual, 1)
@@ -3134,98 +3870,41 @@ func TestDeleteStaticVersion_Errors(t *testing.T) {
 				return &models.Version{State: models.PublishedState}, nil
 			},
 		}
-		smDS := Setup(store.DataStore{Backend: mocked}, nil, &StateMachine{})
-		_, err := smDS.DeleteStaticVersion(context.Background(), "ds1", "ed1", 3, nil, "test-token")
+		smDS := Setup(store.DataStore{Backend: mocked}, nil, nil, nil, &StateMachine{})
+		_, err := smDS.DeleteStaticVersion(context.Background(), "ds1", "ed1", 3, "user@ons.gov.uk")
 		So(err, ShouldEqual, errs.ErrDeletePublishedVersionForbidden)
 	})
 
-	Convey("When the filesAPIClient fails to delete a distribution", t, func() {
-		expectedError := errors.New("files API client delete error")
-
-		mocked := &storetest.StorerMock{
-			CheckEditionExistsStaticFunc: func(context.Context, string, string, string) error { return nil },
-			GetVersionStaticFunc: func(context.Context, string, string, int, string) (*models.Version, error) {
-				return &models.Version{
-					State: models.CreatedState,
-					Distributions: &[]models.Distribution{
-						{
-							Title:       "Distribution 1",
-							DownloadURL: "/path/to/distribution",
-						},
-					}}, nil
-			},
-			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) { return nil, errs.ErrInternalServer },
-		}
-
-		mockFilesAPIClient := &filesAPISDKMocks.ClienterMock{
-			DeleteFileFunc: func(ctx context.Context, filePath string, headers filesAPISDK.Headers) error {
-				return expectedError
-			},
-		}
-
-		smDS := Setup(store.DataStore{Backend: mocked}, nil, &StateMachine{})
-		_, err := smDS.DeleteStaticVersion(context.Background(), "ds1", "ed1", 2, mockFilesAPIClient, "test-token")
-		So(err, ShouldEqual, expectedError)
-		So(len(mockFilesAPIClient.DeleteFileCalls()), ShouldEqual, 1)
-	})
-
 	Convey("When getting dataset fails, internal error", t, func() {
 		mocked := &storetest.StorerMock{
 			CheckEditionExistsStaticFunc: func(context.Context, string, string, string) error { return nil },
 			GetVersionStaticFunc: func(context.Context, string, string, int, string) (*models.Version, error) {
-				return &models.Version{
-					State: models.CreatedState,
-					Distributions: &[]models.Distribution{
-						{
-							Title:       "Distribution 1",
-							DownloadURL: "/path/to/distribution",
-						},
-					}}, nil
+				return &models.Version{State: models.CreatedState}, nil
 			},
 			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) { return nil, errs.ErrInternalServer },
 		}
 
-		mockFilesAPIClient := &filesAPISDKMocks.ClienterMock{
-			DeleteFileFunc: func(ctx context.Context, filePath string, headers filesAPISDK.Headers) error {
-				return nil
-			},
-		}
-
-		smDS := Setup(store.DataStore{Backend: mocked}, nil, &StateMachine{})
-		_, err := smDS.DeleteStaticVersion(context.Background(), "ds1", "ed1", 4, mockFilesAPIClient, "test-token")
+		smDS := Setup(store.DataStore{Backend: mocked}, nil, nil, nil, &StateMachine{})
+		_, err := smDS.DeleteStaticVersion(context.Background(), "ds1", "ed1", 4, "user@ons.gov.uk")
 		So(err, ShouldEqual, errs.ErrInternalServer)
-		So(len(mockFilesAPIClient.DeleteFileCalls()), ShouldEqual, 1)
 	})
 
 	Convey("When delete fails, internal error", t, func() {
 		mocked := &storetest.StorerMock{
 			CheckEditionExistsStaticFunc: func(context.Context, string, string, string) error { return nil },
 			GetVersionStaticFunc: func(context.Context, string, string, int, string) (*models.Version, error) {
-				return &models.Version{
-					State: models.CreatedState,
-					Distributions: &[]models.Distribution{
-						{
-							Title:       "Distribution 1",
-							DownloadURL: "/path/to/distribution",
-						},
-					}}, nil
+				return &models.Version{State: models.CreatedState}, nil
 			},
 			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
 				return &models.DatasetUpdate{Current: &models.Dataset{}}, nil
 			},
-			DeleteStaticDatasetVersionFunc: func(context.Context, string, string, int) error { return errs.ErrInternalServer },
-		}
-
-		mockFilesAPIClient := &filesAPISDKMocks.ClienterMock{
-			DeleteFileFunc: func(ctx context.Context, filePath string, headers filesAPISDK.Headers) error {
-				return nil
-			},
+			RunTransactionFunc:             runTransaction,
+			DeleteStaticDatasetVersionFunc: func(context.Context, string, string, int, *models.Deletion) error { return errs.ErrInternalServer },
 		}
 
-		smDS := Setup(store.DataStore{Backend: mocked}, nil, &StateMachine{})
-		_, err := smDS.DeleteStaticVersion(context.Background(), "ds1", "ed1", 5, mockFilesAPIClient, "test-token")
+		smDS := Setup(store.DataStore{Backend: mocked}, nil, nil, nil, &StateMachine{})
+		_, err := smDS.DeleteStaticVersion(context.Background(), "ds1", "ed1", 5, "user@ons.gov.uk")
 		So(err, ShouldEqual, errs.ErrInternalServer)
-		So(len(mockFilesAPIClient.DeleteFileCalls()), ShouldEqual, 1)
 		So(len(mocked.DeleteStaticDatasetVersionCalls()), ShouldEqual, 1)
 	})
 
@@ -3233,33 +3912,160 @@ func TestDeleteStaticVersion_Errors(t *testing.T) {
 		mocked := &storetest.StorerMock{
 			CheckEditionExistsStaticFunc: func(context.Context, string, string, string) error { return nil },
 			GetVersionStaticFunc: func(context.Context, string, string, int, string) (*models.Version, error) {
-				return &models.Version{
-					State: models.CreatedState,
-					Distributions: &[]models.Distribution{
-						{
-							Title:       "Distribution 1",
-							DownloadURL: "/path/to/distribution",
-						},
-					}}, nil
+				return &models.Version{State: models.CreatedState}, nil
 			},
 			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
 				return &models.DatasetUpdate{Current: &models.Dataset{}}, nil
 			},
-			DeleteStaticDatasetVersionFunc: func(context.Context, string, string, int) error { return nil },
+			RunTransactionFunc:             runTransaction,
+			DeleteStaticDatasetVersionFunc: func(context.Context, string, string, int, *models.Deletion) error { return nil },
 			UpsertDatasetFunc:              func(context.Context, string, *models.DatasetUpdate) error { return errs.ErrInternalServer },
 		}
 
-		mockFilesAPIClient := &filesAPISDKMocks.ClienterMock{
-			DeleteFileFunc: func(ctx context.Context, filePath string, headers filesAPISDK.Headers) error {
+		smDS := Setup(store.DataStore{Backend: mocked}, nil, nil, nil, &StateMachine{})
+		_, err := smDS.DeleteStaticVersion(context.Background(), "ds1", "ed1", 6, "user@ons.gov.uk")
+		So(err, ShouldEqual, errs.ErrInternalServer)
+		So(len(mocked.DeleteStaticDatasetVersionCalls()), ShouldEqual, 1)
+		So(len(mocked.UpsertDatasetCalls()), ShouldEqual, 1)
+	})
+}
+
+func TestRestoreStaticVersion(t *testing.T) {
+	t.Parallel()
+
+	newStore := func(latestVersion *models.Version) *storetest.StorerMock {
+		return &storetest.StorerMock{
+			RunTransactionFunc: runTransaction,
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
+				return &models.DatasetUpdate{
+					Current: &models.Dataset{State: models.PublishedState, Links: &models.DatasetLinks{}},
+					Next:    &models.Dataset{State: models.PublishedState, Links: &models.DatasetLinks{}},
+				}, nil
+			},
+			GetLatestVersionStaticFunc: func(context.Context, string, string, string) (*models.Version, error) {
+				if latestVersion == nil {
+					return nil, errs.ErrVersionNotFound
+				}
+				return latestVersion, nil
+			},
+			RestoreStaticVersionFunc: func(context.Context, string, string, int) error { return nil },
+			GetVersionStaticFunc: func(context.Context, string, string, int, string) (*models.Version, error) {
+				return &models.Version{ID: "a1b2c3", Version: 2, State: models.AssociatedState}, nil
+			},
+			UpsertDatasetFunc: func(context.Context, string, *models.DatasetUpdate) error { return nil },
+		}
+	}
+
+	Convey("Given a deleted static version that is later than the versions of its edition", t, func() {
+		mocked := newStore(&models.Version{Version: 1, State: models.PublishedState})
+		smDS := Setup(store.DataStore{Backend: mocked}, nil, nil, nil, &StateMachine{})
+
+		Convey("When it is restored", func() {
+			version, err := smDS.RestoreStaticVersion(context.Background(), "ds1", "ed1", 2)
+
+			Convey("Then the version is restored and becomes the latest version of the dataset", func() {
+				So(err, ShouldBeNil)
+				So(version.ID, ShouldEqual, "a1b2c3")
+				So(mocked.RestoreStaticVersionCalls(), ShouldHaveLength, 1)
+				So(mocked.RestoreStaticVersionCalls()[0].Version, ShouldEqual, 2)
+				So(mocked.UpsertDatasetCalls(), ShouldHaveLength, 1)
+				next := mocked.UpsertDatasetCalls()[0].DatasetDoc.Next
+				So(next.State, ShouldEqual, models.AssociatedState)
+				So(next.Links.LatestVersion.HRef, ShouldEqual, "/datasets/ds1/editions/ed1/versions/2")
+			})
+		})
+	})
+
+	Convey("Given a deleted static version whose edition has a later version", t, func() {
+		mocked := newStore(&models.Version{Version: 3, State: models.AssociatedState})
+		smDS := Setup(store.DataStore{Backend: mocked}, nil, nil, nil, &StateMachine{})
+
+		Convey("When it is restored", func() {
+			_, err := smDS.RestoreStaticVersion(context.Background(), "ds1", "ed1", 2)
+
+			Convey("Then it is not restored, as it has been superseded", func() {
+				So(err, ShouldEqual, errs.ErrVersionSuperseded)
+				So(mocked.RestoreStaticVersionCalls(), ShouldBeEmpty)
+				So(mocked.UpsertDatasetCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("Given a static version that is not deleted", t, func() {
+		mocked := newStore(nil)
+		mocked.RestoreStaticVersionFunc = func(context.Context, string, string, int) error { return errs.ErrVersionNotFound }
+		smDS := Setup(store.DataStore{Backend: mocked}, nil, nil, nil, &StateMachine{})
+
+		Convey("When it is restored, then version not found is returned and the dataset is not changed", func() {
+			_, err := smDS.RestoreStaticVersion(context.Background(), "ds1", "ed1", 2)
+			So(err, ShouldEqual, errs.ErrVersionNotFound)
+			So(mocked.UpsertDatasetCalls(), ShouldBeEmpty)
+		})
+	})
+}
+
+func TestPreflightVersion(t *testing.T) {
+	t.Parallel()
+
+	vars := map[string]string{"dataset_id": "123", "edition": "2017", "version": "1"}
+
+	newStateMachineDatasetAPI := func(currentVersion *models.Version) (*StateMachineDatasetAPI, *storetest.StorerMock) {
+		mockedDataStore := &storetest.StorerMock{
+			CheckEditionExistsStaticFunc: func(context.Context, string, string, string) error {
 				return nil
 			},
+			GetVersionStaticFunc: func(context.Context, string, string, int, string) (*models.Version, error) {
+				return currentVersion, nil
+			},
 		}
+		stateMachine := NewStateMachine(testContext, []State{Published}, []Transition{{
+			Label:               "published",
+			TargetState:         Published,
+			AllowedSourceStates: []string{"approved"},
+			Type:                "static",
+		}}, store.DataStore{Backend: mockedDataStore})
+		return Setup(store.DataStore{Backend: mockedDataStore}, nil, nil, nil, stateMachine), mockedDataStore
+	}
 
-		smDS := Setup(store.DataStore{Backend: mocked}, nil, &StateMachine{})
-		_, err := smDS.DeleteStaticVersion(context.Background(), "ds1", "ed1", 6, mockFilesAPIClient, "test-token")
-		So(err, ShouldEqual, errs.ErrInternalServer)
-		So(len(mockFilesAPIClient.DeleteFileCalls()), ShouldEqual, 1)
-		So(len(mocked.DeleteStaticDatasetVersionCalls()), ShouldEqual, 1)
-		So(len(mocked.UpsertDatasetCalls()), ShouldEqual, 1)
+	publish := &models.Version{ID: "a1b2c3", State: models.PublishedState, Type: models.Static.String()}
+
+	Convey("When an approved static version is checked for publishing", t, func() {
+		smDS, mockedDataStore := newStateMachineDatasetAPI(&models.Version{ID: "a1b2c3", State: models.ApprovedState, ReleaseDate: "2024-12-31", Type: models.Static.String()})
+		currentVersion, amendedVersion, checks, err := smDS.PreflightVersion(testContext, vars, publish)
+
+		Convey("Then every check passes and the version is not updated", func() {
+			So(err, ShouldBeNil)
+			So(currentVersion.State, ShouldEqual, models.ApprovedState)
+			So(amendedVersion.State, ShouldEqual, models.PublishedState)
+			So(checks, ShouldResemble, []models.PreflightCheck{
+				{Name: "transition", Passed: true},
+				{Name: "version", Passed: true},
+			})
+			So(mockedDataStore.UpdateVersionStaticCalls(), ShouldBeEmpty)
+		})
+	})
+
+	Convey("When a static version that would fail validation is checked for publishing", t, func() {
+		smDS, _ := newStateMachineDatasetAPI(&models.Version{ID: "a1b2c3", State: models.ApprovedState, Type: models.Static.String()})
+		_, _, checks, err := smDS.PreflightVersion(testContext, vars, publish)
+
+		Convey("Then the version check fails rather than an error being returned", func() {
+			So(err, ShouldBeNil)
+			So(checks[1].Name, ShouldEqual, "version")
+			So(checks[1].Passed, ShouldBeFalse)
+			So(checks[1].Reason, ShouldContainSubstring, "release_date")
+		})
+	})
+
+	Convey("When a version that does not exist is checked for publishing", t, func() {
+		smDS, mockedDataStore := newStateMachineDatasetAPI(nil)
+		mockedDataStore.GetVersionStaticFunc = func(context.Context, string, string, int, string) (*models.Version, error) {
+			return nil, errs.ErrVersionNotFound
+		}
+		_, _, _, err := smDS.PreflightVersion(testContext, vars, publish)
+
+		Convey("Then the error is returned", func() {
+			So(err, ShouldEqual, errs.ErrVersionNotFound)
+		})
 	})
 }
@@ -2,8 +2,12 @@ package application
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"time"
 
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
 	"github.com/ONSdigital/dp-dataset-api/models"
 	"github.com/ONSdigital/dp-dataset-api/store"
 )
@@ -12,15 +16,46 @@ import (
 //
 //go:generate moq -out mock/audit_service.go -pkg mock . AuditService
 type AuditService interface {
-	RecordDatasetAuditEvent(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, dataset *models.Dataset) error
-	RecordVersionAuditEvent(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, version *models.Version) error
-	RecordEditionAuditEvent(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, edition *models.Edition) error
-	RecordMetadataAuditEvent(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, metadata *models.Metadata) error
+	RecordDatasetAuditEvent(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, previous, dataset *models.Dataset) error
+	RecordVersionAuditEvent(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, previous, version *models.Version) error
+	RecordEditionAuditEvent(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, previous, edition *models.Edition) error
+	RecordMetadataAuditEvent(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, previous, metadata *models.Metadata) error
+	RecordInstanceAuditEvent(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource, datasetID string, previous, instance *models.Instance) error
+	RecordDimensionOptionsAuditEvent(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource, datasetID string, options []*models.CachedDimensionOption) error
+	VerifyAuditChain(ctx context.Context) (*models.AuditChainVerification, error)
+}
+
+// auditChainBatchSize is the number of audit events read at a time when verifying the chain
+const auditChainBatchSize = 500
+
+// maxAuditEventChainAttempts is the number of times that recording an event is attempted, when other events are
+// concurrently chained to the same last event
+const maxAuditEventChainAttempts = 5
+
+// AuditEventMarshaller marshals audit events into avro format
+type AuditEventMarshaller interface {
+	Marshal(s interface{}) ([]byte, error)
+}
+
+// auditEventMessage is the kafka message sent for an audit event, see schema.AuditEvent
+type auditEventMessage struct {
+	CreatedAt        string `avro:"created_at"`
+	RequestedByID    string `avro:"requested_by_id"`
+	RequestedByEmail string `avro:"requested_by_email"`
+	Action           string `avro:"action"`
+	Resource         string `avro:"resource"`
+	Sequence         int64  `avro:"sequence"`
+	PreviousHash     string `avro:"previous_hash"`
+	Hash             string `avro:"hash"`
+	Event            string `avro:"event"`
 }
 
 // auditService provides methods for audit logging
 type auditService struct {
 	DataStore store.DataStore
+	// Outbox and Marshaller are only set when the audit events are also sent to kafka
+	Outbox     Outbox
+	Marshaller AuditEventMarshaller
 }
 
 // NewAuditService creates a new instance of AuditService
@@ -30,36 +65,185 @@ func NewAuditService(dataStore store.DataStore) AuditService {
 	}
 }
 
-// recordAuditEvent validates and records an audit event for dataset, version, edition, or metadata
-func (a *auditService) recordAuditEvent(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, dataset *models.Dataset, version *models.Version, edition *models.Edition, metadata *models.Metadata) error {
-	event, err := models.NewAuditEvent(requestedBy, action, resource, dataset, version, edition, metadata)
+// NewKafkaAuditService creates a new instance of AuditService that also sends each audit event to kafka, by writing
+// it to the provided outbox once it has been recorded in the store
+func NewKafkaAuditService(dataStore store.DataStore, outbox Outbox, marshaller AuditEventMarshaller) AuditService {
+	return &auditService{
+		DataStore:  dataStore,
+		Outbox:     outbox,
+		Marshaller: marshaller,
+	}
+}
+
+// recordAuditEvent validates and records an audit event for dataset, version, edition, metadata, instance or dimension
+// options, along with the fields changed by the action. The dataset ID is only provided for the resources whose path
+// does not identify their dataset.
+func (a *auditService) recordAuditEvent(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource, datasetID string, changes []models.FieldChange, dataset *models.Dataset, version *models.Version, edition *models.Edition, metadata *models.Metadata, instance *models.Instance, dimensionOptions []*models.CachedDimensionOption) error {
+	event, err := models.NewAuditEvent(requestedBy, action, resource, dataset, version, edition, metadata, instance, dimensionOptions)
 	if err != nil {
 		return fmt.Errorf("recordAuditEvent: failed to create audit event model: %w", err)
 	}
+	event.Changes = changes
+	if datasetID != "" {
+		event.DatasetID = datasetID
+	}
+
+	// the event is chained again in a new transaction if another event was chained to the same event
+	for attempt := 0; attempt < maxAuditEventChainAttempts; attempt++ {
+		err = a.DataStore.Backend.RunTransaction(ctx, func(ctx context.Context) error {
+			return a.storeAuditEvent(ctx, event)
+		})
+		if !errors.Is(err, errs.ErrAuditEventChainConflict) {
+			break
+		}
+	}
+
+	return err
+}
 
+// storeAuditEvent records the audit event in the store and, if the events are sent to kafka, writes its message to
+// the outbox, so that the message is only sent if the event is recorded
+func (a *auditService) storeAuditEvent(ctx context.Context, event *models.AuditEvent) error {
 	if err := a.DataStore.Backend.CreateAuditEvent(ctx, event); err != nil {
 		return fmt.Errorf("recordAuditEvent: failed to create audit event in store: %w", err)
 	}
 
+	if a.Outbox != nil {
+		if err := a.sendAuditEvent(ctx, event); err != nil {
+			return fmt.Errorf("recordAuditEvent: failed to send audit event to kafka: %w", err)
+		}
+	}
+
 	return nil
 }
 
-// RecordDatasetAuditEvent records an audit event for a dataset action
-func (a *auditService) RecordDatasetAuditEvent(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, dataset *models.Dataset) error {
-	return a.recordAuditEvent(ctx, requestedBy, action, resource, dataset, nil, nil, nil)
+// sendAuditEvent writes the kafka message for a recorded audit event to the outbox
+func (a *auditService) sendAuditEvent(ctx context.Context, event *models.AuditEvent) error {
+	eventJSON, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	message := auditEventMessage{
+		CreatedAt:        event.CreatedAt.Format(time.RFC3339Nano),
+		RequestedByID:    event.RequestedBy.ID,
+		RequestedByEmail: event.RequestedBy.Email,
+		Action:           string(event.Action),
+		Resource:         event.Resource,
+		Sequence:         event.Sequence,
+		PreviousHash:     event.PreviousHash,
+		Hash:             event.Hash,
+		Event:            string(eventJSON),
+	}
+
+	avroBytes, err := a.Marshaller.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	return a.Outbox.Write(ctx, avroBytes)
 }
 
-// RecordVersionAuditEvent records an audit event for a version action
-func (a *auditService) RecordVersionAuditEvent(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, version *models.Version) error {
-	return a.recordAuditEvent(ctx, requestedBy, action, resource, nil, version, nil, nil)
+// RecordDatasetAuditEvent records an audit event for a dataset action. The previous state is nil
+// unless the action changed an existing dataset.
+func (a *auditService) RecordDatasetAuditEvent(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, previous, dataset *models.Dataset) error {
+	changes, err := diffAuditedState(previous, dataset)
+	if err != nil {
+		return err
+	}
+	return a.recordAuditEvent(ctx, requestedBy, action, resource, "", changes, dataset, nil, nil, nil, nil, nil)
 }
 
-// RecordEditionAuditEvent records an audit event for an edition action
-func (a *auditService) RecordEditionAuditEvent(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, edition *models.Edition) error {
-	return a.recordAuditEvent(ctx, requestedBy, action, resource, nil, nil, edition, nil)
+// RecordVersionAuditEvent records an audit event for a version action. The previous state is nil
+// unless the action changed an existing version.
+func (a *auditService) RecordVersionAuditEvent(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, previous, version *models.Version) error {
+	changes, err := diffAuditedState(previous, version)
+	if err != nil {
+		return err
+	}
+	return a.recordAuditEvent(ctx, requestedBy, action, resource, "", changes, nil, version, nil, nil, nil, nil)
+}
+
+// RecordEditionAuditEvent records an audit event for an edition action. The previous state is nil
+// unless the action changed an existing edition.
+func (a *auditService) RecordEditionAuditEvent(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, previous, edition *models.Edition) error {
+	changes, err := diffAuditedState(previous, edition)
+	if err != nil {
+		return err
+	}
+	return a.recordAuditEvent(ctx, requestedBy, action, resource, "", changes, nil, nil, edition, nil, nil, nil)
+}
+
+// RecordMetadataAuditEvent records an audit event for a metadata action. The previous state is nil
+// unless the action changed existing metadata.
+func (a *auditService) RecordMetadataAuditEvent(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, previous, metadata *models.Metadata) error {
+	changes, err := diffAuditedState(previous, metadata)
+	if err != nil {
+		return err
+	}
+	return a.recordAuditEvent(ctx, requestedBy, action, resource, "", changes, nil, nil, nil, metadata, nil, nil)
 }
 
-// RecordMetadataAuditEvent records an audit event for a metadata action
-func (a *auditService) RecordMetadataAuditEvent(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, metadata *models.Metadata) error {
-	return a.recordAuditEvent(ctx, requestedBy, action, resource, nil, nil, nil, metadata)
+// RecordInstanceAuditEvent records an audit event for an instance action, on behalf of the dataset of the instance if
+// it is known. The previous state is nil unless the action changed an existing instance.
+func (a *auditService) RecordInstanceAuditEvent(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource, datasetID string, previous, instance *models.Instance) error {
+	changes, err := diffAuditedState(previous, instance)
+	if err != nil {
+		return err
+	}
+	return a.recordAuditEvent(ctx, requestedBy, action, resource, datasetID, changes, nil, nil, nil, nil, instance, nil)
+}
+
+// RecordDimensionOptionsAuditEvent records an audit event for the dimension options added or updated by an action,
+// on behalf of the dataset of their instance if it is known
+func (a *auditService) RecordDimensionOptionsAuditEvent(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource, datasetID string, options []*models.CachedDimensionOption) error {
+	return a.recordAuditEvent(ctx, requestedBy, action, resource, datasetID, nil, nil, nil, nil, nil, nil, options)
+}
+
+// VerifyAuditChain walks the chain of audit events from the first one, and reports the first event whose link to the
+// previous event is broken. The events recorded before the chain was introduced are not part of it.
+func (a *auditService) VerifyAuditChain(ctx context.Context) (*models.AuditChainVerification, error) {
+	verification := &models.AuditChainVerification{Valid: true}
+
+	var previous *models.AuditEvent
+	for fromSequence := int64(1); ; {
+		events, err := a.DataStore.Backend.GetAuditEventChain(ctx, fromSequence, auditChainBatchSize)
+		if err != nil {
+			return nil, fmt.Errorf("VerifyAuditChain: failed to get audit events from store: %w", err)
+		}
+
+		for _, event := range events {
+			reason, err := event.CheckChainLink(previous)
+			if err != nil {
+				return nil, fmt.Errorf("VerifyAuditChain: failed to check audit event %d: %w", event.Sequence, err)
+			}
+			if reason != "" {
+				verification.Valid = false
+				verification.FirstBrokenLink = &models.AuditChainLink{Sequence: event.Sequence, Reason: reason}
+				return verification, nil
+			}
+
+			verification.VerifiedEvents++
+			previous = event
+		}
+
+		if len(events) < auditChainBatchSize {
+			return verification, nil
+		}
+		fromSequence = previous.Sequence + 1
+	}
+}
+
+// diffAuditedState returns the fields changed from the previous state of a resource, or nil if there was no previous state
+func diffAuditedState[T any](previous, current *T) ([]models.FieldChange, error) {
+	if previous == nil || current == nil {
+		return nil, nil
+	}
+
+	changes, err := models.DiffFields(previous, current)
+	if err != nil {
+		return nil, fmt.Errorf("diffAuditedState: failed to compare with the previous state: %w", err)
+	}
+
+	return changes, nil
 }
@@ -5,7 +5,10 @@ import (
 	"errors"
 	"testing"
 
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	"github.com/ONSdigital/dp-dataset-api/mocks"
 	"github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/dp-dataset-api/schema"
 	"github.com/ONSdigital/dp-dataset-api/store"
 	storetest "github.com/ONSdigital/dp-dataset-api/store/datastoretest"
 	. "github.com/smartystreets/goconvey/convey"
@@ -18,6 +21,9 @@ func TestAuditService_RecordAuditEvent(t *testing.T) {
 			CreateAuditEventFunc: func(ctx context.Context, event *models.AuditEvent) error {
 				return nil
 			},
+			RunTransactionFunc: func(ctx context.Context, fn func(ctx context.Context) error) error {
+				return fn(ctx)
+			},
 		}
 
 		auditService := NewAuditService(store.DataStore{Backend: mockDataStore})
@@ -27,6 +33,7 @@ func TestAuditService_RecordAuditEvent(t *testing.T) {
 				models.RequestedBy{ID: "user-1", Email: "user1@example.com"},
 				models.ActionCreate,
 				"/datasets/dataset-1",
+				nil,
 				&models.Dataset{ID: "dataset-1"},
 			)
 
@@ -35,11 +42,30 @@ func TestAuditService_RecordAuditEvent(t *testing.T) {
 			})
 		})
 
+		Convey("When RecordDatasetAuditEvent is called with the previous state of the dataset", func() {
+			err := auditService.RecordDatasetAuditEvent(context.Background(),
+				models.RequestedBy{ID: "user-1", Email: "user1@example.com"},
+				models.ActionUpdate,
+				"/datasets/dataset-1",
+				&models.Dataset{ID: "dataset-1", Title: "Old title"},
+				&models.Dataset{ID: "dataset-1", Title: "New title"},
+			)
+
+			Convey("Then the changed fields are stored with the event", func() {
+				So(err, ShouldBeNil)
+				So(mockDataStore.CreateAuditEventCalls(), ShouldHaveLength, 1)
+				So(mockDataStore.CreateAuditEventCalls()[0].Event.Changes, ShouldResemble, []models.FieldChange{
+					{Field: "title", From: "Old title", To: "New title"},
+				})
+			})
+		})
+
 		Convey("When RecordVersionAuditEvent is called successfully", func() {
 			err := auditService.RecordVersionAuditEvent(context.Background(),
 				models.RequestedBy{ID: "user-1", Email: "user1@example.com"},
 				models.ActionCreate,
 				"/datasets/dataset-1/editions/2026/versions/1",
+				nil,
 				&models.Version{ID: "version-1"},
 			)
 
@@ -48,17 +74,58 @@ func TestAuditService_RecordAuditEvent(t *testing.T) {
 			})
 		})
 
+		Convey("When RecordInstanceAuditEvent is called with the previous state of the instance", func() {
+			err := auditService.RecordInstanceAuditEvent(context.Background(),
+				models.RequestedBy{ID: "import-service"},
+				models.ActionUpdate,
+				"/instances/instance-1",
+				"cpih01",
+				&models.Instance{InstanceID: "instance-1", State: models.CreatedState},
+				&models.Instance{InstanceID: "instance-1", State: models.SubmittedState},
+			)
+
+			Convey("Then the instance and its changed fields are stored with the event", func() {
+				So(err, ShouldBeNil)
+				So(mockDataStore.CreateAuditEventCalls(), ShouldHaveLength, 1)
+				event := mockDataStore.CreateAuditEventCalls()[0].Event
+				So(event.Instance.InstanceID, ShouldEqual, "instance-1")
+				So(event.DatasetID, ShouldEqual, "cpih01")
+				So(event.Changes, ShouldResemble, []models.FieldChange{
+					{Field: "state", From: models.CreatedState, To: models.SubmittedState},
+				})
+			})
+		})
+
+		Convey("When RecordDimensionOptionsAuditEvent is called successfully", func() {
+			options := []*models.CachedDimensionOption{{InstanceID: "instance-1", Name: "geography", Option: "K02000001"}}
+			err := auditService.RecordDimensionOptionsAuditEvent(context.Background(),
+				models.RequestedBy{ID: "import-service"},
+				models.ActionUpdate,
+				"/instances/instance-1/dimensions",
+				"cpih01",
+				options,
+			)
+
+			Convey("Then the dimension options are stored with the event", func() {
+				So(err, ShouldBeNil)
+				So(mockDataStore.CreateAuditEventCalls(), ShouldHaveLength, 1)
+				So(mockDataStore.CreateAuditEventCalls()[0].Event.DimensionOptions, ShouldResemble, options)
+				So(mockDataStore.CreateAuditEventCalls()[0].Event.DatasetID, ShouldEqual, "cpih01")
+			})
+		})
+
 		Convey("When RecordVersionAuditEvent is called with a nil version", func() {
 			err := auditService.RecordVersionAuditEvent(context.Background(),
 				models.RequestedBy{ID: "user-1", Email: "user1@example.com"},
 				models.ActionCreate,
 				"/datasets/dataset-1/editions/2026/versions/1",
 				nil,
+				nil,
 			)
 
 			Convey("Then an error is returned", func() {
 				So(err, ShouldNotBeNil)
-				So(err.Error(), ShouldContainSubstring, "recordAuditEvent: failed to create audit event model: exactly one of dataset, version, edition, or metadata must be provided")
+				So(err.Error(), ShouldContainSubstring, "recordAuditEvent: failed to create audit event model: exactly one of dataset, version, edition, metadata, instance or dimension options must be provided")
 			})
 		})
 
@@ -71,6 +138,7 @@ func TestAuditService_RecordAuditEvent(t *testing.T) {
 				models.RequestedBy{ID: "user-1", Email: "user1@example.com"},
 				models.ActionCreate,
 				"/datasets/dataset-1/editions/2026/versions/1",
+				nil,
 				&models.Version{ID: "version-1"},
 			)
 
@@ -81,3 +149,178 @@ func TestAuditService_RecordAuditEvent(t *testing.T) {
 		})
 	})
 }
+
+func TestKafkaAuditService_RecordAuditEvent(t *testing.T) {
+	Convey("Given an audit service that sends the audit events to kafka", t, func() {
+		mockDataStore := &storetest.StorerMock{
+			CreateAuditEventFunc: func(ctx context.Context, event *models.AuditEvent) error {
+				event.Sequence = 7
+				event.Hash = "hash-7"
+				return nil
+			},
+			RunTransactionFunc: func(ctx context.Context, fn func(ctx context.Context) error) error {
+				return fn(ctx)
+			},
+		}
+		outboxMock := &mocks.OutboxMock{
+			WriteFunc: func(ctx context.Context, message []byte) error {
+				return nil
+			},
+		}
+
+		auditService := NewKafkaAuditService(store.DataStore{Backend: mockDataStore}, outboxMock, schema.AuditEvent)
+
+		Convey("When RecordDatasetAuditEvent is called successfully", func() {
+			err := auditService.RecordDatasetAuditEvent(context.Background(),
+				models.RequestedBy{ID: "user-1", Email: "user1@example.com"},
+				models.ActionCreate,
+				"/datasets/dataset-1",
+				nil,
+				&models.Dataset{ID: "dataset-1"},
+			)
+
+			Convey("Then the recorded event is written to the outbox in the same transaction", func() {
+				So(err, ShouldBeNil)
+				So(mockDataStore.RunTransactionCalls(), ShouldHaveLength, 1)
+				So(mockDataStore.CreateAuditEventCalls(), ShouldHaveLength, 1)
+				So(outboxMock.WriteCalls(), ShouldHaveLength, 1)
+
+				var message auditEventMessage
+				So(schema.AuditEvent.Unmarshal(outboxMock.WriteCalls()[0].Message, &message), ShouldBeNil)
+				So(message.RequestedByID, ShouldEqual, "user-1")
+				So(message.RequestedByEmail, ShouldEqual, "user1@example.com")
+				So(message.Action, ShouldEqual, "CREATE")
+				So(message.Resource, ShouldEqual, "/datasets/dataset-1")
+				So(message.Sequence, ShouldEqual, 7)
+				So(message.Hash, ShouldEqual, "hash-7")
+				So(message.Event, ShouldContainSubstring, `"dataset":{"id":"dataset-1"`)
+			})
+		})
+
+		Convey("When the DataStore returns an error", func() {
+			mockDataStore.CreateAuditEventFunc = func(ctx context.Context, event *models.AuditEvent) error {
+				return errors.New("datastore error")
+			}
+
+			err := auditService.RecordDatasetAuditEvent(context.Background(),
+				models.RequestedBy{ID: "user-1"}, models.ActionCreate, "/datasets/dataset-1", nil, &models.Dataset{ID: "dataset-1"})
+
+			Convey("Then an error is returned and nothing is written to the outbox", func() {
+				So(err, ShouldNotBeNil)
+				So(outboxMock.WriteCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When another event is concurrently chained to the same event", func() {
+			mockDataStore.CreateAuditEventFunc = func(ctx context.Context, event *models.AuditEvent) error {
+				if len(mockDataStore.CreateAuditEventCalls()) == 1 {
+					return errs.ErrAuditEventChainConflict
+				}
+				return nil
+			}
+
+			err := auditService.RecordDatasetAuditEvent(context.Background(),
+				models.RequestedBy{ID: "user-1"}, models.ActionCreate, "/datasets/dataset-1", nil, &models.Dataset{ID: "dataset-1"})
+
+			Convey("Then the event is chained again in a new transaction and written to the outbox once", func() {
+				So(err, ShouldBeNil)
+				So(mockDataStore.RunTransactionCalls(), ShouldHaveLength, 2)
+				So(mockDataStore.CreateAuditEventCalls(), ShouldHaveLength, 2)
+				So(outboxMock.WriteCalls(), ShouldHaveLength, 1)
+			})
+		})
+
+		Convey("When events are always chained concurrently to the same event", func() {
+			mockDataStore.CreateAuditEventFunc = func(ctx context.Context, event *models.AuditEvent) error {
+				return errs.ErrAuditEventChainConflict
+			}
+
+			err := auditService.RecordDatasetAuditEvent(context.Background(),
+				models.RequestedBy{ID: "user-1"}, models.ActionCreate, "/datasets/dataset-1", nil, &models.Dataset{ID: "dataset-1"})
+
+			Convey("Then the attempts are limited and the conflict is returned", func() {
+				So(errors.Is(err, errs.ErrAuditEventChainConflict), ShouldBeTrue)
+				So(mockDataStore.RunTransactionCalls(), ShouldHaveLength, maxAuditEventChainAttempts)
+				So(outboxMock.WriteCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When the outbox returns an error", func() {
+			outboxMock.WriteFunc = func(ctx context.Context, message []byte) error {
+				return errors.New("outbox error")
+			}
+
+			err := auditService.RecordDatasetAuditEvent(context.Background(),
+				models.RequestedBy{ID: "user-1"}, models.ActionCreate, "/datasets/dataset-1", nil, &models.Dataset{ID: "dataset-1"})
+
+			Convey("Then an error is returned", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "recordAuditEvent: failed to send audit event to kafka: outbox error")
+			})
+		})
+	})
+}
+
+func TestAuditService_VerifyAuditChain(t *testing.T) {
+	Convey("Given a chain of audit events in the store", t, func() {
+		var events []*models.AuditEvent
+		var previous *models.AuditEvent
+		for i := 0; i < auditChainBatchSize+2; i++ {
+			event := &models.AuditEvent{RequestedBy: models.RequestedBy{ID: "user-1"}, Action: models.ActionRead, Resource: "/datasets/dataset-1"}
+			So(event.ChainTo(previous), ShouldBeNil)
+			events = append(events, event)
+			previous = event
+		}
+
+		mockDataStore := &storetest.StorerMock{
+			GetAuditEventChainFunc: func(ctx context.Context, fromSequence int64, limit int) ([]*models.AuditEvent, error) {
+				from := int(fromSequence) - 1
+				to := from + limit
+				if to > len(events) {
+					to = len(events)
+				}
+				return events[from:to], nil
+			},
+		}
+
+		auditService := NewAuditService(store.DataStore{Backend: mockDataStore})
+
+		Convey("When the chain is intact", func() {
+			verification, err := auditService.VerifyAuditChain(context.Background())
+
+			Convey("Then every event is verified, reading them in batches", func() {
+				So(err, ShouldBeNil)
+				So(verification, ShouldResemble, &models.AuditChainVerification{Valid: true, VerifiedEvents: auditChainBatchSize + 2})
+				So(mockDataStore.GetAuditEventChainCalls(), ShouldHaveLength, 2)
+				So(mockDataStore.GetAuditEventChainCalls()[1].FromSequence, ShouldEqual, auditChainBatchSize+1)
+			})
+		})
+
+		Convey("When an event has been altered", func() {
+			events[auditChainBatchSize].Resource = "/datasets/dataset-2"
+			verification, err := auditService.VerifyAuditChain(context.Background())
+
+			Convey("Then the altered event is reported as the first broken link", func() {
+				So(err, ShouldBeNil)
+				So(verification.Valid, ShouldBeFalse)
+				So(verification.VerifiedEvents, ShouldEqual, auditChainBatchSize)
+				So(verification.FirstBrokenLink, ShouldResemble, &models.AuditChainLink{
+					Sequence: auditChainBatchSize + 1,
+					Reason:   "hash does not match the content of the event",
+				})
+			})
+		})
+
+		Convey("When the store fails", func() {
+			mockDataStore.GetAuditEventChainFunc = func(ctx context.Context, fromSequence int64, limit int) ([]*models.AuditEvent, error) {
+				return nil, errors.New("store error")
+			}
+			_, err := auditService.VerifyAuditChain(context.Background())
+
+			Convey("Then the error is returned", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "store error")
+			})
+		})
+	})
+}
@@ -0,0 +1,99 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ONSdigital/dp-dataset-api/models"
+)
+
+// Guard is a check that a version must pass before the state machine moves it to a state. A guard returns a validation
+// error for each of its checks that the version fails, or an error if the check itself could not be made.
+type Guard struct {
+	Name      string
+	CheckFunc func(ctx context.Context, smDS *StateMachineDatasetAPI,
+		currentVersion *models.Version, // Called Instances in Mongo
+		versionUpdate *models.Version, // Next version, that is the new version
+		versionDetails VersionDetails) ([]models.Error, error)
+}
+
+func (g Guard) String() string {
+	return g.Name
+}
+
+var HasDistributions = Guard{
+	Name:      "has-distributions",
+	CheckFunc: checkHasDistributions,
+}
+
+var HasQualityDesignation = Guard{
+	Name:      "has-quality-designation",
+	CheckFunc: checkHasQualityDesignation,
+}
+
+var ImportTasksCompleted = Guard{
+	Name:      "import-tasks-completed",
+	CheckFunc: checkImportTasksCompleted,
+}
+
+// guards are the guards that the workflows can attach to their transitions, by name
+var guards = map[string]Guard{
+	HasDistributions.Name:      HasDistributions,
+	HasQualityDesignation.Name: HasQualityDesignation,
+	ImportTasksCompleted.Name:  ImportTasksCompleted,
+}
+
+// checkHasDistributions checks that the version has at least one distribution
+func checkHasDistributions(_ context.Context, _ *StateMachineDatasetAPI, currentVersion, versionUpdate *models.Version, _ VersionDetails) ([]models.Error, error) {
+	distributions := versionUpdate.Distributions
+	if distributions == nil {
+		distributions = currentVersion.Distributions
+	}
+
+	if distributions == nil || len(*distributions) == 0 {
+		return []models.Error{models.NewValidationError(models.ErrTransitionGuardFailed, models.ErrMissingDistributionsDescription)}, nil
+	}
+	return nil, nil
+}
+
+// checkHasQualityDesignation checks that the version has a quality designation
+func checkHasQualityDesignation(_ context.Context, _ *StateMachineDatasetAPI, currentVersion, versionUpdate *models.Version, _ VersionDetails) ([]models.Error, error) {
+	if versionUpdate.QualityDesignation == "" && currentVersion.QualityDesignation == "" {
+		return []models.Error{models.NewValidationError(models.ErrTransitionGuardFailed, models.ErrMissingQualityDesignationDescription)}, nil
+	}
+	return nil, nil
+}
+
+// checkImportTasksCompleted checks that every import task of the instance behind the version has completed
+func checkImportTasksCompleted(ctx context.Context, smDS *StateMachineDatasetAPI, currentVersion, _ *models.Version, _ VersionDetails) ([]models.Error, error) {
+	instance, err := smDS.DataStore.Backend.GetInstance(ctx, currentVersion.ID, "")
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := instance.ImportTasks
+	if tasks == nil {
+		return nil, nil
+	}
+
+	var validationErrors []models.Error
+	incomplete := func(task string) {
+		validationErrors = append(validationErrors, models.NewValidationError(models.ErrTransitionGuardFailed, fmt.Sprintf("%s: %s", models.ErrImportTasksIncompleteDescription, task)))
+	}
+
+	if tasks.ImportObservations != nil && tasks.ImportObservations.State != models.CompletedState {
+		incomplete("import_observations")
+	}
+	for _, task := range tasks.BuildHierarchyTasks {
+		if task != nil && task.State != models.CompletedState {
+			incomplete("build_hierarchies " + task.DimensionName)
+		}
+	}
+	for _, task := range tasks.BuildSearchIndexTasks {
+		if task != nil && task.State != models.CompletedState {
+			incomplete("build_search_indexes " + task.DimensionName)
+		}
+	}
+
+	return validationErrors, nil
+}
@@ -0,0 +1,115 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/dp-dataset-api/store"
+	storetest "github.com/ONSdigital/dp-dataset-api/store/datastoretest"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCheckHasDistributions(t *testing.T) {
+	t.Parallel()
+	Convey("When a version has distributions, from the update or the current version", t, func() {
+		distributions := &[]models.Distribution{{Title: "Full dataset"}}
+
+		Convey("Then it passes the guard", func() {
+			validationErrors, err := checkHasDistributions(testContext, nil, &models.Version{}, &models.Version{Distributions: distributions}, versionDetails)
+			So(err, ShouldBeNil)
+			So(validationErrors, ShouldBeEmpty)
+
+			validationErrors, err = checkHasDistributions(testContext, nil, &models.Version{Distributions: distributions}, &models.Version{}, versionDetails)
+			So(err, ShouldBeNil)
+			So(validationErrors, ShouldBeEmpty)
+		})
+	})
+
+	Convey("When a version has no distributions", t, func() {
+		validationErrors, err := checkHasDistributions(testContext, nil, &models.Version{}, &models.Version{Distributions: &[]models.Distribution{}}, versionDetails)
+
+		Convey("Then it fails the guard", func() {
+			So(err, ShouldBeNil)
+			So(validationErrors, ShouldResemble, []models.Error{
+				models.NewValidationError(models.ErrTransitionGuardFailed, models.ErrMissingDistributionsDescription),
+			})
+		})
+	})
+}
+
+func TestCheckHasQualityDesignation(t *testing.T) {
+	t.Parallel()
+	Convey("When a version has a quality designation", t, func() {
+		validationErrors, err := checkHasQualityDesignation(testContext, nil, &models.Version{QualityDesignation: models.QualityDesignationOfficial}, &models.Version{}, versionDetails)
+
+		Convey("Then it passes the guard", func() {
+			So(err, ShouldBeNil)
+			So(validationErrors, ShouldBeEmpty)
+		})
+	})
+
+	Convey("When a version has no quality designation", t, func() {
+		validationErrors, err := checkHasQualityDesignation(testContext, nil, &models.Version{}, &models.Version{}, versionDetails)
+
+		Convey("Then it fails the guard", func() {
+			So(err, ShouldBeNil)
+			So(validationErrors, ShouldResemble, []models.Error{
+				models.NewValidationError(models.ErrTransitionGuardFailed, models.ErrMissingQualityDesignationDescription),
+			})
+		})
+	})
+}
+
+func TestCheckImportTasksCompleted(t *testing.T) {
+	t.Parallel()
+
+	newSMDS := func(instance *models.Instance, err error) *StateMachineDatasetAPI {
+		return &StateMachineDatasetAPI{DataStore: store.DataStore{Backend: &storetest.StorerMock{
+			GetInstanceFunc: func(context.Context, string, string) (*models.Instance, error) {
+				return instance, err
+			},
+		}}}
+	}
+
+	Convey("When every import task of the instance has completed", t, func() {
+		smDS := newSMDS(&models.Instance{ImportTasks: &models.InstanceImportTasks{
+			ImportObservations:    &models.ImportObservationsTask{State: models.CompletedState},
+			BuildHierarchyTasks:   []*models.BuildHierarchyTask{{GenericTaskDetails: models.GenericTaskDetails{DimensionName: "geography", State: models.CompletedState}}},
+			BuildSearchIndexTasks: []*models.BuildSearchIndexTask{{GenericTaskDetails: models.GenericTaskDetails{DimensionName: "geography", State: models.CompletedState}}},
+		}}, nil)
+		validationErrors, err := checkImportTasksCompleted(testContext, smDS, &models.Version{ID: "789"}, &models.Version{}, versionDetails)
+
+		Convey("Then it passes the guard", func() {
+			So(err, ShouldBeNil)
+			So(validationErrors, ShouldBeEmpty)
+		})
+	})
+
+	Convey("When import tasks of the instance have not completed", t, func() {
+		smDS := newSMDS(&models.Instance{ImportTasks: &models.InstanceImportTasks{
+			ImportObservations:    &models.ImportObservationsTask{State: models.SubmittedState},
+			BuildHierarchyTasks:   []*models.BuildHierarchyTask{{GenericTaskDetails: models.GenericTaskDetails{DimensionName: "geography", State: models.CompletedState}}},
+			BuildSearchIndexTasks: []*models.BuildSearchIndexTask{{GenericTaskDetails: models.GenericTaskDetails{DimensionName: "aggregate", State: models.CreatedState}}},
+		}}, nil)
+		validationErrors, err := checkImportTasksCompleted(testContext, smDS, &models.Version{ID: "789"}, &models.Version{}, versionDetails)
+
+		Convey("Then it fails the guard with an error for each incomplete task", func() {
+			So(err, ShouldBeNil)
+			So(validationErrors, ShouldResemble, []models.Error{
+				models.NewValidationError(models.ErrTransitionGuardFailed, models.ErrImportTasksIncompleteDescription+": import_observations"),
+				models.NewValidationError(models.ErrTransitionGuardFailed, models.ErrImportTasksIncompleteDescription+": build_search_indexes aggregate"),
+			})
+		})
+	})
+
+	Convey("When the instance cannot be found", t, func() {
+		smDS := newSMDS(nil, errors.New("instance not found"))
+		_, err := checkImportTasksCompleted(testContext, smDS, &models.Version{ID: "789"}, &models.Version{}, versionDetails)
+
+		Convey("Then an error is returned", func() {
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
@@ -20,18 +20,27 @@ var _ application.AuditService = &AuditServiceMock{}
 //
 //		// make and configure a mocked application.AuditService
 //		mockedAuditService := &AuditServiceMock{
-//			RecordDatasetAuditEventFunc: func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, dataset *models.Dataset) error {
+//			RecordDatasetAuditEventFunc: func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, previous *models.Dataset, dataset *models.Dataset) error {
 //				panic("mock out the RecordDatasetAuditEvent method")
 //			},
-//			RecordEditionAuditEventFunc: func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, edition *models.Edition) error {
+//			RecordDimensionOptionsAuditEventFunc: func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, datasetID string, options []*models.CachedDimensionOption) error {
+//				panic("mock out the RecordDimensionOptionsAuditEvent method")
+//			},
+//			RecordEditionAuditEventFunc: func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, previous *models.Edition, edition *models.Edition) error {
 //				panic("mock out the RecordEditionAuditEvent method")
 //			},
-//			RecordMetadataAuditEventFunc: func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, metadata *models.Metadata) error {
+//			RecordInstanceAuditEventFunc: func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, datasetID string, previous *models.Instance, instance *models.Instance) error {
+//				panic("mock out the RecordInstanceAuditEvent method")
+//			},
+//			RecordMetadataAuditEventFunc: func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, previous *models.Metadata, metadata *models.Metadata) error {
 //				panic("mock out the RecordMetadataAuditEvent method")
 //			},
-//			RecordVersionAuditEventFunc: func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, version *models.Version) error {
+//			RecordVersionAuditEventFunc: func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, previous *models.Version, version *models.Version) error {
 //				panic("mock out the RecordVersionAuditEvent method")
 //			},
+//			VerifyAuditChainFunc: func(ctx context.Context) (*models.AuditChainVerification, error) {
+//				panic("mock out the VerifyAuditChain method")
+//			},
 //		}
 //
 //		// use mockedAuditService in code that requires application.AuditService
@@ -40,16 +49,25 @@ var _ application.AuditService = &AuditServiceMock{}
 //	}
 type AuditServiceMock struct {
 	// RecordDatasetAuditEventFunc mocks the RecordDatasetAuditEvent method.
-	RecordDatasetAuditEventFunc func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, dataset *models.Dataset) error
+	RecordDatasetAuditEventFunc func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, previous *models.Dataset, dataset *models.Dataset) error
+
+	// RecordDimensionOptionsAuditEventFunc mocks the RecordDimensionOptionsAuditEvent method.
+	RecordDimensionOptionsAuditEventFunc func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, datasetID string, options []*models.CachedDimensionOption) error
 
 	// RecordEditionAuditEventFunc mocks the RecordEditionAuditEvent method.
-	RecordEditionAuditEventFunc func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, edition *models.Edition) error
+	RecordEditionAuditEventFunc func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, previous *models.Edition, edition *models.Edition) error
+
+	// RecordInstanceAuditEventFunc mocks the RecordInstanceAuditEvent method.
+	RecordInstanceAuditEventFunc func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, datasetID string, previous *models.Instance, instance *models.Instance) error
 
 	// RecordMetadataAuditEventFunc mocks the RecordMetadataAuditEvent method.
-	RecordMetadataAuditEventFunc func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, metadata *models.Metadata) error
+	RecordMetadataAuditEventFunc func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, previous *models.Metadata, metadata *models.Metadata) error
 
 	// RecordVersionAuditEventFunc mocks the RecordVersionAuditEvent method.
-	RecordVersionAuditEventFunc func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, version *models.Version) error
+	RecordVersionAuditEventFunc func(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, previous *models.Version, version *models.Version) error
+
+	// VerifyAuditChainFunc mocks the VerifyAuditChain method.
+	VerifyAuditChainFunc func(ctx context.Context) (*models.AuditChainVerification, error)
 
 	// calls tracks calls to the methods.
 	calls struct {
@@ -63,9 +81,26 @@ type AuditServiceMock struct {
 			Action models.Action
 			// Resource is the resource argument value.
 			Resource string
+			// Previous is the previous argument value.
+			Previous *models.Dataset
 			// Dataset is the dataset argument value.
 			Dataset *models.Dataset
 		}
+		// RecordDimensionOptionsAuditEvent holds details about calls to the RecordDimensionOptionsAuditEvent method.
+		RecordDimensionOptionsAuditEvent []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// RequestedBy is the requestedBy argument value.
+			RequestedBy models.RequestedBy
+			// Action is the action argument value.
+			Action models.Action
+			// Resource is the resource argument value.
+			Resource string
+			// DatasetID is the datasetID argument value.
+			DatasetID string
+			// Options is the options argument value.
+			Options []*models.CachedDimensionOption
+		}
 		// RecordEditionAuditEvent holds details about calls to the RecordEditionAuditEvent method.
 		RecordEditionAuditEvent []struct {
 			// Ctx is the ctx argument value.
@@ -76,9 +111,28 @@ type AuditServiceMock struct {
 			Action models.Action
 			// Resource is the resource argument value.
 			Resource string
+			// Previous is the previous argument value.
+			Previous *models.Edition
 			// Edition is the edition argument value.
 			Edition *models.Edition
 		}
+		// RecordInstanceAuditEvent holds details about calls to the RecordInstanceAuditEvent method.
+		RecordInstanceAuditEvent []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// RequestedBy is the requestedBy argument value.
+			RequestedBy models.RequestedBy
+			// Action is the action argument value.
+			Action models.Action
+			// Resource is the resource argument value.
+			Resource string
+			// DatasetID is the datasetID argument value.
+			DatasetID string
+			// Previous is the previous argument value.
+			Previous *models.Instance
+			// Instance is the instance argument value.
+			Instance *models.Instance
+		}
 		// RecordMetadataAuditEvent holds details about calls to the RecordMetadataAuditEvent method.
 		RecordMetadataAuditEvent []struct {
 			// Ctx is the ctx argument value.
@@ -89,6 +143,8 @@ type AuditServiceMock struct {
 			Action models.Action
 			// Resource is the resource argument value.
 			Resource string
+			// Previous is the previous argument value.
+			Previous *models.Metadata
 			// Metadata is the metadata argument value.
 			Metadata *models.Metadata
 		}
@@ -102,18 +158,28 @@ type AuditServiceMock struct {
 			Action models.Action
 			// Resource is the resource argument value.
 			Resource string
+			// Previous is the previous argument value.
+			Previous *models.Version
 			// Version is the version argument value.
 			Version *models.Version
 		}
+		// VerifyAuditChain holds details about calls to the VerifyAuditChain method.
+		VerifyAuditChain []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
 	}
-	lockRecordDatasetAuditEvent  sync.RWMutex
-	lockRecordEditionAuditEvent  sync.RWMutex
-	lockRecordMetadataAuditEvent sync.RWMutex
-	lockRecordVersionAuditEvent  sync.RWMutex
+	lockRecordDatasetAuditEvent          sync.RWMutex
+	lockRecordDimensionOptionsAuditEvent sync.RWMutex
+	lockRecordEditionAuditEvent          sync.RWMutex
+	lockRecordInstanceAuditEvent         sync.RWMutex
+	lockRecordMetadataAuditEvent         sync.RWMutex
+	lockRecordVersionAuditEvent          sync.RWMutex
+	lockVerifyAuditChain                 sync.RWMutex
 }
 
 // RecordDatasetAuditEvent calls RecordDatasetAuditEventFunc.
-func (mock *AuditServiceMock) RecordDatasetAuditEvent(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, dataset *models.Dataset) error {
+func (mock *AuditServiceMock) RecordDatasetAuditEvent(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, previous *models.Dataset, dataset *models.Dataset) error {
 	if mock.RecordDatasetAuditEventFunc == nil {
 		panic("AuditServiceMock.RecordDatasetAuditEventFunc: method is nil but AuditService.RecordDatasetAuditEvent was just called")
 	}
@@ -122,18 +188,20 @@ func (mock *AuditServiceMock) RecordDatasetAuditEvent(ctx context.Context, reque
 		RequestedBy models.RequestedBy
 		Action      models.Action
 		Resource    string
+		Previous    *models.Dataset
 		Dataset     *models.Dataset
 	}{
 		Ctx:         ctx,
 		RequestedBy: requestedBy,
 		Action:      action,
 		Resource:    resource,
+		Previous:    previous,
 		Dataset:     dataset,
 	}
 	mock.lockRecordDatasetAuditEvent.Lock()
 	mock.calls.RecordDatasetAuditEvent = append(mock.calls.RecordDatasetAuditEvent, callInfo)
 	mock.lockRecordDatasetAuditEvent.Unlock()
-	return mock.RecordDatasetAuditEventFunc(ctx, requestedBy, action, resource, dataset)
+	return mock.RecordDatasetAuditEventFunc(ctx, requestedBy, action, resource, previous, dataset)
 }
 
 // RecordDatasetAuditEventCalls gets all the calls that were made to RecordDatasetAuditEvent.
@@ -145,6 +213,7 @@ func (mock *AuditServiceMock) RecordDatasetAuditEventCalls() []struct {
 	RequestedBy models.RequestedBy
 	Action      models.Action
 	Resource    string
+	Previous    *models.Dataset
 	Dataset     *models.Dataset
 } {
 	var calls []struct {
@@ -152,6 +221,7 @@ func (mock *AuditServiceMock) RecordDatasetAuditEventCalls() []struct {
 		RequestedBy models.RequestedBy
 		Action      models.Action
 		Resource    string
+		Previous    *models.Dataset
 		Dataset     *models.Dataset
 	}
 	mock.lockRecordDatasetAuditEvent.RLock()
@@ -160,8 +230,60 @@ func (mock *AuditServiceMock) RecordDatasetAuditEventCalls() []struct {
 	return calls
 }
 
+// RecordDimensionOptionsAuditEvent calls RecordDimensionOptionsAuditEventFunc.
+func (mock *AuditServiceMock) RecordDimensionOptionsAuditEvent(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, datasetID string, options []*models.CachedDimensionOption) error {
+	if mock.RecordDimensionOptionsAuditEventFunc == nil {
+		panic("AuditServiceMock.RecordDimensionOptionsAuditEventFunc: method is nil but AuditService.RecordDimensionOptionsAuditEvent was just called")
+	}
+	callInfo := struct {
+		Ctx         context.Context
+		RequestedBy models.RequestedBy
+		Action      models.Action
+		Resource    string
+		DatasetID   string
+		Options     []*models.CachedDimensionOption
+	}{
+		Ctx:         ctx,
+		RequestedBy: requestedBy,
+		Action:      action,
+		Resource:    resource,
+		DatasetID:   datasetID,
+		Options:     options,
+	}
+	mock.lockRecordDimensionOptionsAuditEvent.Lock()
+	mock.calls.RecordDimensionOptionsAuditEvent = append(mock.calls.RecordDimensionOptionsAuditEvent, callInfo)
+	mock.lockRecordDimensionOptionsAuditEvent.Unlock()
+	return mock.RecordDimensionOptionsAuditEventFunc(ctx, requestedBy, action, resource, datasetID, options)
+}
+
+// RecordDimensionOptionsAuditEventCalls gets all the calls that were made to RecordDimensionOptionsAuditEvent.
+// Check the length with:
+//
+//	len(mockedAuditService.RecordDimensionOptionsAuditEventCalls())
+func (mock *AuditServiceMock) RecordDimensionOptionsAuditEventCalls() []struct {
+	Ctx         context.Context
+	RequestedBy models.RequestedBy
+	Action      models.Action
+	Resource    string
+	DatasetID   string
+	Options     []*models.CachedDimensionOption
+} {
+	var calls []struct {
+		Ctx         context.Context
+		RequestedBy models.RequestedBy
+		Action      models.Action
+		Resource    string
+		DatasetID   string
+		Options     []*models.CachedDimensionOption
+	}
+	mock.lockRecordDimensionOptionsAuditEvent.RLock()
+	calls = mock.calls.RecordDimensionOptionsAuditEvent
+	mock.lockRecordDimensionOptionsAuditEvent.RUnlock()
+	return calls
+}
+
 // RecordEditionAuditEvent calls RecordEditionAuditEventFunc.
-func (mock *AuditServiceMock) RecordEditionAuditEvent(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, edition *models.Edition) error {
+func (mock *AuditServiceMock) RecordEditionAuditEvent(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, previous *models.Edition, edition *models.Edition) error {
 	if mock.RecordEditionAuditEventFunc == nil {
 		panic("AuditServiceMock.RecordEditionAuditEventFunc: method is nil but AuditService.RecordEditionAuditEvent was just called")
 	}
@@ -170,18 +292,20 @@ func (mock *AuditServiceMock) RecordEditionAuditEvent(ctx context.Context, reque
 		RequestedBy models.RequestedBy
 		Action      models.Action
 		Resource    string
+		Previous    *models.Edition
 		Edition     *models.Edition
 	}{
 		Ctx:         ctx,
 		RequestedBy: requestedBy,
 		Action:      action,
 		Resource:    resource,
+		Previous:    previous,
 		Edition:     edition,
 	}
 	mock.lockRecordEditionAuditEvent.Lock()
 	mock.calls.RecordEditionAuditEvent = append(mock.calls.RecordEditionAuditEvent, callInfo)
 	mock.lockRecordEditionAuditEvent.Unlock()
-	return mock.RecordEditionAuditEventFunc(ctx, requestedBy, action, resource, edition)
+	return mock.RecordEditionAuditEventFunc(ctx, requestedBy, action, resource, previous, edition)
 }
 
 // RecordEditionAuditEventCalls gets all the calls that were made to RecordEditionAuditEvent.
@@ -193,6 +317,7 @@ func (mock *AuditServiceMock) RecordEditionAuditEventCalls() []struct {
 	RequestedBy models.RequestedBy
 	Action      models.Action
 	Resource    string
+	Previous    *models.Edition
 	Edition     *models.Edition
 } {
 	var calls []struct {
@@ -200,6 +325,7 @@ func (mock *AuditServiceMock) RecordEditionAuditEventCalls() []struct {
 		RequestedBy models.RequestedBy
 		Action      models.Action
 		Resource    string
+		Previous    *models.Edition
 		Edition     *models.Edition
 	}
 	mock.lockRecordEditionAuditEvent.RLock()
@@ -208,8 +334,64 @@ func (mock *AuditServiceMock) RecordEditionAuditEventCalls() []struct {
 	return calls
 }
 
+// RecordInstanceAuditEvent calls RecordInstanceAuditEventFunc.
+func (mock *AuditServiceMock) RecordInstanceAuditEvent(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, datasetID string, previous *models.Instance, instance *models.Instance) error {
+	if mock.RecordInstanceAuditEventFunc == nil {
+		panic("AuditServiceMock.RecordInstanceAuditEventFunc: method is nil but AuditService.RecordInstanceAuditEvent was just called")
+	}
+	callInfo := struct {
+		Ctx         context.Context
+		RequestedBy models.RequestedBy
+		Action      models.Action
+		Resource    string
+		DatasetID   string
+		Previous    *models.Instance
+		Instance    *models.Instance
+	}{
+		Ctx:         ctx,
+		RequestedBy: requestedBy,
+		Action:      action,
+		Resource:    resource,
+		DatasetID:   datasetID,
+		Previous:    previous,
+		Instance:    instance,
+	}
+	mock.lockRecordInstanceAuditEvent.Lock()
+	mock.calls.RecordInstanceAuditEvent = append(mock.calls.RecordInstanceAuditEvent, callInfo)
+	mock.lockRecordInstanceAuditEvent.Unlock()
+	return mock.RecordInstanceAuditEventFunc(ctx, requestedBy, action, resource, datasetID, previous, instance)
+}
+
+// RecordInstanceAuditEventCalls gets all the calls that were made to RecordInstanceAuditEvent.
+// Check the length with:
+//
+//	len(mockedAuditService.RecordInstanceAuditEventCalls())
+func (mock *AuditServiceMock) RecordInstanceAuditEventCalls() []struct {
+	Ctx         context.Context
+	RequestedBy models.RequestedBy
+	Action      models.Action
+	Resource    string
+	DatasetID   string
+	Previous    *models.Instance
+	Instance    *models.Instance
+} {
+	var calls []struct {
+		Ctx         context.Context
+		RequestedBy models.RequestedBy
+		Action      models.Action
+		Resource    string
+		DatasetID   string
+		Previous    *models.Instance
+		Instance    *models.Instance
+	}
+	mock.lockRecordInstanceAuditEvent.RLock()
+	calls = mock.calls.RecordInstanceAuditEvent
+	mock.lockRecordInstanceAuditEvent.RUnlock()
+	return calls
+}
+
 // RecordMetadataAuditEvent calls RecordMetadataAuditEventFunc.
-func (mock *AuditServiceMock) RecordMetadataAuditEvent(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, metadata *models.Metadata) error {
+func (mock *AuditServiceMock) RecordMetadataAuditEvent(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, previous *models.Metadata, metadata *models.Metadata) error {
 	if mock.RecordMetadataAuditEventFunc == nil {
 		panic("AuditServiceMock.RecordMetadataAuditEventFunc: method is nil but AuditService.RecordMetadataAuditEvent was just called")
 	}
@@ -218,18 +400,20 @@ func (mock *AuditServiceMock) RecordMetadataAuditEvent(ctx context.Context, requ
 		RequestedBy models.RequestedBy
 		Action      models.Action
 		Resource    string
+		Previous    *models.Metadata
 		Metadata    *models.Metadata
 	}{
 		Ctx:         ctx,
 		RequestedBy: requestedBy,
 		Action:      action,
 		Resource:    resource,
+		Previous:    previous,
 		Metadata:    metadata,
 	}
 	mock.lockRecordMetadataAuditEvent.Lock()
 	mock.calls.RecordMetadataAuditEvent = append(mock.calls.RecordMetadataAuditEvent, callInfo)
 	mock.lockRecordMetadataAuditEvent.Unlock()
-	return mock.RecordMetadataAuditEventFunc(ctx, requestedBy, action, resource, metadata)
+	return mock.RecordMetadataAuditEventFunc(ctx, requestedBy, action, resource, previous, metadata)
 }
 
 // RecordMetadataAuditEventCalls gets all the calls that were made to RecordMetadataAuditEvent.
@@ -241,6 +425,7 @@ func (mock *AuditServiceMock) RecordMetadataAuditEventCalls() []struct {
 	RequestedBy models.RequestedBy
 	Action      models.Action
 	Resource    string
+	Previous    *models.Metadata
 	Metadata    *models.Metadata
 } {
 	var calls []struct {
@@ -248,6 +433,7 @@ func (mock *AuditServiceMock) RecordMetadataAuditEventCalls() []struct {
 		RequestedBy models.RequestedBy
 		Action      models.Action
 		Resource    string
+		Previous    *models.Metadata
 		Metadata    *models.Metadata
 	}
 	mock.lockRecordMetadataAuditEvent.RLock()
@@ -257,7 +443,7 @@ func (mock *AuditServiceMock) RecordMetadataAuditEventCalls() []struct {
 }
 
 // RecordVersionAuditEvent calls RecordVersionAuditEventFunc.
-func (mock *AuditServiceMock) RecordVersionAuditEvent(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, version *models.Version) error {
+func (mock *AuditServiceMock) RecordVersionAuditEvent(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, previous *models.Version, version *models.Version) error {
 	if mock.RecordVersionAuditEventFunc == nil {
 		panic("AuditServiceMock.RecordVersionAuditEventFunc: method is nil but AuditService.RecordVersionAuditEvent was just called")
 	}
@@ -266,18 +452,20 @@ func (mock *AuditServiceMock) RecordVersionAuditEvent(ctx context.Context, reque
 		RequestedBy models.RequestedBy
 		Action      models.Action
 		Resource    string
+		Previous    *models.Version
 		Version     *models.Version
 	}{
 		Ctx:         ctx,
 		RequestedBy: requestedBy,
 		Action:      action,
 		Resource:    resource,
+		Previous:    previous,
 		Version:     version,
 	}
 	mock.lockRecordVersionAuditEvent.Lock()
 	mock.calls.RecordVersionAuditEvent = append(mock.calls.RecordVersionAuditEvent, callInfo)
 	mock.lockRecordVersionAuditEvent.Unlock()
-	return mock.RecordVersionAuditEventFunc(ctx, requestedBy, action, resource, version)
+	return mock.RecordVersionAuditEventFunc(ctx, requestedBy, action, resource, previous, version)
 }
 
 // RecordVersionAuditEventCalls gets all the calls that were made to RecordVersionAuditEvent.
@@ -289,6 +477,7 @@ func (mock *AuditServiceMock) RecordVersionAuditEventCalls() []struct {
 	RequestedBy models.RequestedBy
 	Action      models.Action
 	Resource    string
+	Previous    *models.Version
 	Version     *models.Version
 } {
 	var calls []struct {
@@ -296,6 +485,7 @@ func (mock *AuditServiceMock) RecordVersionAuditEventCalls() []struct {
 		RequestedBy models.RequestedBy
 		Action      models.Action
 		Resource    string
+		Previous    *models.Version
 		Version     *models.Version
 	}
 	mock.lockRecordVersionAuditEvent.RLock()
@@ -303,3 +493,35 @@ func (mock *AuditServiceMock) RecordVersionAuditEventCalls() []struct {
 	mock.lockRecordVersionAuditEvent.RUnlock()
 	return calls
 }
+
+// VerifyAuditChain calls VerifyAuditChainFunc.
+func (mock *AuditServiceMock) VerifyAuditChain(ctx context.Context) (*models.AuditChainVerification, error) {
+	if mock.VerifyAuditChainFunc == nil {
+		panic("AuditServiceMock.VerifyAuditChainFunc: method is nil but AuditService.VerifyAuditChain was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockVerifyAuditChain.Lock()
+	mock.calls.VerifyAuditChain = append(mock.calls.VerifyAuditChain, callInfo)
+	mock.lockVerifyAuditChain.Unlock()
+	return mock.VerifyAuditChainFunc(ctx)
+}
+
+// VerifyAuditChainCalls gets all the calls that were made to VerifyAuditChain.
+// Check the length with:
+//
+//	len(mockedAuditService.VerifyAuditChainCalls())
+func (mock *AuditServiceMock) VerifyAuditChainCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockVerifyAuditChain.RLock()
+	calls = mock.calls.VerifyAuditChain
+	mock.lockVerifyAuditChain.RUnlock()
+	return calls
+}
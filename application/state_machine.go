@@ -3,6 +3,9 @@ package application
 import (
 	"context"
 	"errors"
+	"fmt"
+	"slices"
+	"sort"
 
 	"github.com/ONSdigital/dp-dataset-api/models"
 	"github.com/ONSdigital/dp-dataset-api/store"
@@ -25,6 +28,7 @@ func (s State) String() string {
 type StateMachine struct {
 	states      map[string]State
 	transitions map[KeyVal][]string
+	guards      map[KeyVal][]Guard
 	DataStore   store.DataStore
 	ctx         context.Context
 }
@@ -34,6 +38,8 @@ type Transition struct {
 	TargetState         State
 	AllowedSourceStates []string
 	Type                string
+	// Guards are checked, in order, before versions are moved to the target state
+	Guards []Guard
 }
 
 type KeyVal struct {
@@ -42,18 +48,11 @@ type KeyVal struct {
 }
 
 func castStateToState(state string) (*State, bool) {
-	switch s := state; s {
-	case "published":
-		return &Published, true
-	case "associated":
-		return &Associated, true
-	case "approved":
-		return &Approved, true
-	case "edition-confirmed":
-		return &EditionConfirmed, true
-	default:
+	s, ok := enterStates[state]
+	if !ok {
 		return nil, false
 	}
+	return &s, true
 }
 
 func (sm *StateMachine) Transition(ctx context.Context, smDS *StateMachineDatasetAPI,
@@ -92,6 +91,10 @@ func (sm *StateMachine) Transition(ctx context.Context, smDS *StateMachineDatase
 		return errors.New("state not allowed to transition")
 	}
 
+	if err := sm.checkGuards(ctx, smDS, currentVersion, versionUpdate, versionDetails); err != nil {
+		return err
+	}
+
 	err := nextState.EnterFunc(ctx, smDS,
 		currentVersion, // Called Instances in Mongo
 		versionUpdate,  // Next version, that is the new version
@@ -103,6 +106,65 @@ func (sm *StateMachine) Transition(ctx context.Context, smDS *StateMachineDatase
 	return nil
 }
 
+// checkGuards runs every guard of the transition to the state of the version update. The validation errors of all the
+// guards are returned together as models.ValidationErrors, so that every problem with the version is reported at once.
+func (sm *StateMachine) checkGuards(ctx context.Context, smDS *StateMachineDatasetAPI,
+	currentVersion *models.Version, // Called Instances in Mongo
+	versionUpdate *models.Version, // Next version, that is the new version
+	versionDetails VersionDetails) error {
+	var validationErrors models.ValidationErrors
+	for _, guard := range sm.guards[KeyVal{StateVal: versionUpdate.State, Type: currentVersion.Type}] {
+		guardErrors, err := guard.CheckFunc(ctx, smDS, currentVersion, versionUpdate, versionDetails)
+		if err != nil {
+			log.Error(ctx, "state machine: failed to check guard", err, log.Data{"guard": guard.Name, "state": versionUpdate.State})
+			return err
+		}
+		validationErrors = append(validationErrors, guardErrors...)
+	}
+
+	if len(validationErrors) > 0 {
+		log.Info(ctx, "state machine: version rejected by the guards of the transition", log.Data{"state": versionUpdate.State, "errors": validationErrors.Error()})
+		return validationErrors
+	}
+
+	return nil
+}
+
+// Preflight checks, without moving the version, whether it could be moved to the state of the version update: that the
+// transition is allowed from its current state, and that it passes each of the guards of the transition
+func (sm *StateMachine) Preflight(ctx context.Context, smDS *StateMachineDatasetAPI,
+	currentVersion *models.Version, // Called Instances in Mongo
+	versionUpdate *models.Version, // Next version, that is the new version
+	versionDetails VersionDetails) ([]models.PreflightCheck, error) {
+	datasetType := currentVersion.Type
+	if datasetType == "" {
+		datasetType = "v4"
+	}
+	key := KeyVal{StateVal: versionUpdate.State, Type: datasetType}
+
+	var transitionErr error
+	if !slices.Contains(sm.transitions[key], currentVersion.State) {
+		transitionErr = fmt.Errorf("state not allowed to transition from %s to %s", currentVersion.State, versionUpdate.State)
+	}
+	checks := []models.PreflightCheck{models.NewPreflightCheck("transition", transitionErr)}
+
+	for _, guard := range sm.guards[key] {
+		guardErrors, err := guard.CheckFunc(ctx, smDS, currentVersion, versionUpdate, versionDetails)
+		if err != nil {
+			log.Error(ctx, "state machine: failed to check guard", err, log.Data{"guard": guard.Name, "state": versionUpdate.State})
+			return nil, err
+		}
+
+		var guardErr error
+		if len(guardErrors) > 0 {
+			guardErr = models.ValidationErrors(guardErrors)
+		}
+		checks = append(checks, models.NewPreflightCheck("guard:"+guard.Name, guardErr))
+	}
+
+	return checks, nil
+}
+
 func NewStateMachine(ctx context.Context, states []State, transitions []Transition, dataStore store.DataStore) *StateMachine {
 	statesMap := make(map[string]State)
 	for _, state := range states {
@@ -110,16 +172,83 @@ func NewStateMachine(ctx context.Context, states []State, transitions []Transiti
 	}
 
 	transitionsMap := make(map[KeyVal][]string)
+	guardsMap := make(map[KeyVal][]Guard)
 	for _, transition := range transitions {
-		transitionsMap[KeyVal{StateVal: transition.TargetState.String(), Type: transition.Type}] = transition.AllowedSourceStates
+		key := KeyVal{StateVal: transition.TargetState.String(), Type: transition.Type}
+		transitionsMap[key] = transition.AllowedSourceStates
+		if len(transition.Guards) > 0 {
+			guardsMap[key] = transition.Guards
+		}
 	}
 
 	sm := &StateMachine{
 		states:      statesMap,
 		transitions: transitionsMap,
+		guards:      guardsMap,
 		DataStore:   dataStore,
 		ctx:         ctx,
 	}
 
 	return sm
 }
+
+// Workflows returns the transitions of the state machine for each type of dataset, ordered by type and target state
+func (sm *StateMachine) Workflows() []models.StateMachineWorkflow {
+	byType := map[string]*models.StateMachineWorkflow{}
+	for key, sourceStates := range sm.transitions {
+		workflow, ok := byType[key.Type]
+		if !ok {
+			workflow = &models.StateMachineWorkflow{Type: key.Type}
+			byType[key.Type] = workflow
+		}
+		transition := models.StateMachineTransition{
+			State:               key.StateVal,
+			AllowedSourceStates: sourceStates,
+		}
+		for _, guard := range sm.guards[key] {
+			transition.Guards = append(transition.Guards, guard.Name)
+		}
+		workflow.Transitions = append(workflow.Transitions, transition)
+	}
+
+	workflows := make([]models.StateMachineWorkflow, 0, len(byType))
+	for _, workflow := range byType {
+		sort.Slice(workflow.Transitions, func(i, j int) bool {
+			return workflow.Transitions[i].State < workflow.Transitions[j].State
+		})
+		workflows = append(workflows, *workflow)
+	}
+	sort.Slice(workflows, func(i, j int) bool {
+		return workflows[i].Type < workflows[j].Type
+	})
+
+	return workflows
+}
+
+// Workflow returns the transitions of the state machine for a type of dataset, or false if it has none
+func (sm *StateMachine) Workflow(datasetType string) (*models.StateMachineWorkflow, bool) {
+	for _, workflow := range sm.Workflows() {
+		if workflow.Type == datasetType {
+			return &workflow, true
+		}
+	}
+	return nil, false
+}
+
+// AllowedTransitions returns the states that a version of a type of dataset can be moved to from its current state,
+// in order. Versions without a type are treated as v4 versions, as they are by Transition.
+func (sm *StateMachine) AllowedTransitions(datasetType, state string) []string {
+	if datasetType == "" {
+		datasetType = "v4"
+	}
+
+	nextStates := []string{}
+	for key, sourceStates := range sm.transitions {
+		if key.Type == datasetType && slices.Contains(sourceStates, state) {
+			nextStates = append(nextStates, key.StateVal)
+		}
+	}
+	sort.Strings(nextStates)
+
+	return nextStates
+}
@@ -2,6 +2,7 @@ package application
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/ONSdigital/dp-dataset-api/mocks"
@@ -42,6 +43,7 @@ func TestTransition(t *testing.T) {
 	states, transitions := setUpStatesTransitions()
 
 	mockedDataStore := &storetest.StorerMock{
+		RunTransactionFunc: runTransaction,
 		UpdateVersionFunc: func(context.Context, *models.Version, *models.Version, string) (string, error) {
 			return "", nil
 		},
@@ -101,3 +103,208 @@ func TestTransition(t *testing.T) {
 		So(len(mockedDataStore.UpdateVersionCalls()), ShouldEqual, 2)
 	})
 }
+
+func TestStateMachineIntrospection(t *testing.T) {
+	t.Parallel()
+	Convey("Given a state machine with workflows for several types of dataset", t, func() {
+		states, transitions := setUpStatesTransitions()
+		stateMachine := NewStateMachine(testContext, states, transitions, store.DataStore{Backend: &storetest.StorerMock{}})
+
+		Convey("Then its workflows are ordered by type, and their transitions by state", func() {
+			workflows := stateMachine.Workflows()
+			So(workflows, ShouldNotBeEmpty)
+			So(workflows[len(workflows)-1].Type, ShouldEqual, "v4")
+
+			for i, workflow := range workflows {
+				if i > 0 {
+					So(workflow.Type, ShouldBeGreaterThan, workflows[i-1].Type)
+				}
+				for j := 1; j < len(workflow.Transitions); j++ {
+					So(workflow.Transitions[j].State, ShouldBeGreaterThan, workflow.Transitions[j-1].State)
+				}
+			}
+		})
+
+		Convey("Then the workflow of a type of dataset is returned", func() {
+			workflow, ok := stateMachine.Workflow("v4")
+			So(ok, ShouldBeTrue)
+			So(workflow.Transitions, ShouldResemble, []models.StateMachineTransition{
+				{State: "associated", AllowedSourceStates: []string{"edition-confirmed", "associated"}},
+				{State: "edition-confirmed", AllowedSourceStates: []string{"edition-confirmed", "completed", "published"}},
+				{State: "published", AllowedSourceStates: []string{"associated", "published", "edition-confirmed"}},
+			})
+
+			_, ok = stateMachine.Workflow("cantabular_blob")
+			So(ok, ShouldBeFalse)
+		})
+
+		Convey("Then the states that a version can be moved to from its state are returned", func() {
+			So(stateMachine.AllowedTransitions("v4", "edition-confirmed"), ShouldResemble, []string{"associated", "edition-confirmed", "published"})
+			So(stateMachine.AllowedTransitions("", "completed"), ShouldResemble, []string{"edition-confirmed"})
+			So(stateMachine.AllowedTransitions("v4", "created"), ShouldBeEmpty)
+		})
+	})
+}
+
+func TestTransitionGuards(t *testing.T) {
+	t.Parallel()
+
+	failingGuard := func(description string) Guard {
+		return Guard{
+			Name: description,
+			CheckFunc: func(context.Context, *StateMachineDatasetAPI, *models.Version, *models.Version, VersionDetails) ([]models.Error, error) {
+				return []models.Error{models.NewValidationError(models.ErrTransitionGuardFailed, description)}, nil
+			},
+		}
+	}
+	passingGuard := Guard{
+		Name: "passing",
+		CheckFunc: func(context.Context, *StateMachineDatasetAPI, *models.Version, *models.Version, VersionDetails) ([]models.Error, error) {
+			return nil, nil
+		},
+	}
+
+	newStateMachine := func(transitionGuards ...Guard) (*StateMachineDatasetAPI, *storetest.StorerMock) {
+		mockedDataStore := &storetest.StorerMock{
+			UpdateVersionStaticFunc: func(context.Context, *models.Version, *models.Version, string) (string, error) {
+				return "", nil
+			},
+		}
+		stateMachine := NewStateMachine(testContext, []State{Approved}, []Transition{{
+			Label:               "approved",
+			TargetState:         Approved,
+			AllowedSourceStates: []string{"associated"},
+			Type:                "static",
+			Guards:              transitionGuards,
+		}}, store.DataStore{Backend: mockedDataStore})
+		return &StateMachineDatasetAPI{DataStore: store.DataStore{Backend: mockedDataStore}, StateMachine: stateMachine}, mockedDataStore
+	}
+
+	currentVersion := func() *models.Version {
+		return &models.Version{State: models.AssociatedState, Type: models.Static.String(), ReleaseDate: "2024-12-31"}
+	}
+	versionUpdate := func() *models.Version {
+		return &models.Version{State: models.ApprovedState, Type: models.Static.String(), ReleaseDate: "2024-12-31"}
+	}
+
+	Convey("When a version passes the guards of a transition", t, func() {
+		smDS, mockedDataStore := newStateMachine(passingGuard)
+		err := smDS.StateMachine.Transition(testContext, smDS, currentVersion(), versionUpdate(), versionDetails, "")
+
+		Convey("Then it is moved to the state", func() {
+			So(err, ShouldBeNil)
+			So(mockedDataStore.UpdateVersionStaticCalls(), ShouldHaveLength, 1)
+		})
+	})
+
+	Convey("When a version fails the guards of a transition", t, func() {
+		smDS, mockedDataStore := newStateMachine(failingGuard("first"), passingGuard, failingGuard("second"))
+		err := smDS.StateMachine.Transition(testContext, smDS, currentVersion(), versionUpdate(), versionDetails, "")
+
+		Convey("Then the validation errors of every guard are returned and the version is not moved to the state", func() {
+			var validationErrors models.ValidationErrors
+			So(errors.As(err, &validationErrors), ShouldBeTrue)
+			So(validationErrors, ShouldHaveLength, 2)
+			So(validationErrors[0].Description, ShouldEqual, "first")
+			So(validationErrors[1].Description, ShouldEqual, "second")
+			So(mockedDataStore.UpdateVersionStaticCalls(), ShouldBeEmpty)
+		})
+	})
+
+	Convey("When the guards of a transition cannot be checked", t, func() {
+		checkErr := errors.New("datastore unavailable")
+		smDS, mockedDataStore := newStateMachine(Guard{
+			Name: "erroring",
+			CheckFunc: func(context.Context, *StateMachineDatasetAPI, *models.Version, *models.Version, VersionDetails) ([]models.Error, error) {
+				return nil, checkErr
+			},
+		})
+		err := smDS.StateMachine.Transition(testContext, smDS, currentVersion(), versionUpdate(), versionDetails, "")
+
+		Convey("Then the error is returned and the version is not moved to the state", func() {
+			So(err, ShouldEqual, checkErr)
+			So(mockedDataStore.UpdateVersionStaticCalls(), ShouldBeEmpty)
+		})
+	})
+
+	Convey("When the workflows of a state machine with guards are requested", t, func() {
+		smDS, _ := newStateMachine(failingGuard("first"), passingGuard)
+		workflow, ok := smDS.StateMachine.Workflow("static")
+
+		Convey("Then the guards of each transition are listed", func() {
+			So(ok, ShouldBeTrue)
+			So(workflow.Transitions[0].Guards, ShouldResemble, []string{"first", "passing"})
+		})
+	})
+}
+
+func TestStateMachinePreflight(t *testing.T) {
+	t.Parallel()
+
+	newStateMachine := func(transitionGuards ...Guard) (*StateMachineDatasetAPI, *storetest.StorerMock) {
+		mockedDataStore := &storetest.StorerMock{}
+		stateMachine := NewStateMachine(testContext, []State{Approved}, []Transition{{
+			Label:               "approved",
+			TargetState:         Approved,
+			AllowedSourceStates: []string{"associated"},
+			Type:                "static",
+			Guards:              transitionGuards,
+		}}, store.DataStore{Backend: mockedDataStore})
+		return &StateMachineDatasetAPI{DataStore: store.DataStore{Backend: mockedDataStore}, StateMachine: stateMachine}, mockedDataStore
+	}
+
+	passingGuard := Guard{
+		Name: "passing",
+		CheckFunc: func(context.Context, *StateMachineDatasetAPI, *models.Version, *models.Version, VersionDetails) ([]models.Error, error) {
+			return nil, nil
+		},
+	}
+	failingGuard := Guard{
+		Name: "failing",
+		CheckFunc: func(context.Context, *StateMachineDatasetAPI, *models.Version, *models.Version, VersionDetails) ([]models.Error, error) {
+			return []models.Error{models.NewValidationError(models.ErrTransitionGuardFailed, "not ready")}, nil
+		},
+	}
+
+	versionUpdate := &models.Version{State: models.ApprovedState, Type: models.Static.String()}
+
+	Convey("When a version that can be moved to a state is checked", t, func() {
+		smDS, mockedDataStore := newStateMachine(passingGuard, failingGuard)
+		checks, err := smDS.StateMachine.Preflight(testContext, smDS, &models.Version{State: models.AssociatedState, Type: models.Static.String()}, versionUpdate, versionDetails)
+
+		Convey("Then the transition and each of its guards are checked without the version being moved", func() {
+			So(err, ShouldBeNil)
+			So(checks, ShouldResemble, []models.PreflightCheck{
+				{Name: "transition", Passed: true},
+				{Name: "guard:passing", Passed: true},
+				{Name: "guard:failing", Passed: false, Reason: "validation failed: not ready"},
+			})
+			So(mockedDataStore.UpdateVersionStaticCalls(), ShouldBeEmpty)
+		})
+	})
+
+	Convey("When a version that cannot be moved to a state from its current state is checked", t, func() {
+		smDS, _ := newStateMachine(passingGuard)
+		checks, err := smDS.StateMachine.Preflight(testContext, smDS, &models.Version{State: models.CreatedState, Type: models.Static.String()}, versionUpdate, versionDetails)
+
+		Convey("Then the transition check fails", func() {
+			So(err, ShouldBeNil)
+			So(checks[0], ShouldResemble, models.PreflightCheck{Name: "transition", Passed: false, Reason: "state not allowed to transition from created to approved"})
+		})
+	})
+
+	Convey("When the guards of a transition cannot be checked", t, func() {
+		checkErr := errors.New("datastore unavailable")
+		smDS, _ := newStateMachine(Guard{
+			Name: "erroring",
+			CheckFunc: func(context.Context, *StateMachineDatasetAPI, *models.Version, *models.Version, VersionDetails) ([]models.Error, error) {
+				return nil, checkErr
+			},
+		})
+		_, err := smDS.StateMachine.Preflight(testContext, smDS, &models.Version{State: models.AssociatedState, Type: models.Static.String()}, versionUpdate, versionDetails)
+
+		Convey("Then the error is returned", func() {
+			So(err, ShouldEqual, checkErr)
+		})
+	})
+}
@@ -19,3 +19,18 @@ var Approved = State{
 	Name:      "approved",
 	EnterFunc: ApproveVersion,
 }
+
+var Withdrawn = State{
+	Name:      "withdrawn",
+	EnterFunc: WithdrawVersion,
+}
+
+// enterStates are the states that versions can be moved to by the state machine, by name. The workflows can only
+// transition versions to these states, as they provide the function run on entering the state.
+var enterStates = map[string]State{
+	Published.Name:        Published,
+	EditionConfirmed.Name: EditionConfirmed,
+	Associated.Name:       Associated,
+	Approved.Name:         Approved,
+	Withdrawn.Name:        Withdrawn,
+}
@@ -0,0 +1,227 @@
+package application
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/ONSdigital/dp-dataset-api/models"
+)
+
+// defaultWorkflows are the workflows used when no workflows file is configured
+//
+//go:embed workflows.json
+var defaultWorkflows []byte
+
+// Workflows is the declarative definition of the state machine, with a workflow for each type of dataset
+type Workflows struct {
+	Workflows []Workflow `json:"workflows"`
+}
+
+// Workflow defines the transitions that the state machine allows for the versions of a type of dataset
+type Workflow struct {
+	Type string `json:"type"`
+	// InitialStates are the states that versions are given outside of the state machine, such as when they are created
+	InitialStates []string             `json:"initial_states"`
+	Transitions   []WorkflowTransition `json:"transitions"`
+}
+
+// WorkflowTransition allows versions to be moved to a state from any of the allowed source states, once they have
+// passed the named guards
+type WorkflowTransition struct {
+	State               string   `json:"state"`
+	AllowedSourceStates []string `json:"allowed_source_states"`
+	Guards              []string `json:"guards,omitempty"`
+}
+
+// LoadWorkflows reads the workflows from the provided file, or the default workflows if path is empty, and returns
+// the transitions of the state machine once the workflows have been validated
+func LoadWorkflows(path string) ([]Transition, error) {
+	b := defaultWorkflows
+	if path != "" {
+		var err error
+		if b, err = os.ReadFile(path); err != nil {
+			return nil, fmt.Errorf("failed to read workflows file: %w", err)
+		}
+	}
+
+	workflows, err := ParseWorkflows(b)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := workflows.Validate(); err != nil {
+		return nil, err
+	}
+
+	return workflows.Transitions(), nil
+}
+
+// ParseWorkflows decodes the JSON definition of the workflows, rejecting unknown fields
+func ParseWorkflows(b []byte) (*Workflows, error) {
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.DisallowUnknownFields()
+
+	var workflows Workflows
+	if err := decoder.Decode(&workflows); err != nil {
+		return nil, fmt.Errorf("failed to parse workflows: %w", err)
+	}
+
+	return &workflows, nil
+}
+
+// Validate checks that there is exactly one workflow for every type of dataset, that every workflow only uses known
+// states and guards, that the states it moves versions to have an enter function, and that they can be reached from its
+// initial states. All the problems found are returned together.
+func (w *Workflows) Validate() error {
+	if len(w.Workflows) == 0 {
+		return errors.New("invalid workflows: no workflows defined")
+	}
+
+	var errs []error
+	types := map[string]bool{}
+	datasetTypes := map[models.DatasetType]string{}
+	for _, workflow := range w.Workflows {
+		if types[workflow.Type] {
+			errs = append(errs, fmt.Errorf("workflow %q is defined more than once", workflow.Type))
+		}
+		types[workflow.Type] = true
+
+		if workflow.Type != "" {
+			if datasetType, err := models.GetDatasetType(workflow.Type); err != nil {
+				errs = append(errs, fmt.Errorf("workflow %q: unknown dataset type", workflow.Type))
+			} else if other, ok := datasetTypes[datasetType]; ok && other != workflow.Type {
+				// "v4" and "filterable" are names for the same type, so only one of their workflows could be used
+				errs = append(errs, fmt.Errorf("workflow %q is for the same dataset type as workflow %q", workflow.Type, other))
+			} else {
+				datasetTypes[datasetType] = workflow.Type
+			}
+		}
+
+		errs = append(errs, workflow.validate()...)
+	}
+
+	// the versions of a type of dataset without a workflow could never change state
+	for datasetType := models.Filterable; datasetType < models.Invalid; datasetType++ {
+		if _, ok := datasetTypes[datasetType]; !ok {
+			errs = append(errs, fmt.Errorf("no workflow for dataset type %q", datasetType))
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid workflows: %w", errors.Join(errs...))
+	}
+
+	return nil
+}
+
+// validate returns the problems found in the workflow
+func (w Workflow) validate() []error {
+	var errs []error
+	invalid := func(format string, a ...interface{}) {
+		errs = append(errs, fmt.Errorf("workflow %q: "+format, append([]interface{}{w.Type}, a...)...))
+	}
+
+	if w.Type == "" {
+		invalid("missing type")
+	}
+	if len(w.InitialStates) == 0 {
+		invalid("no initial states")
+	}
+	if len(w.Transitions) == 0 {
+		invalid("no transitions")
+	}
+
+	for _, state := range w.InitialStates {
+		if models.ValidateInstanceState(state) != nil {
+			invalid("unknown initial state %q", state)
+		}
+	}
+
+	targets := map[string]bool{}
+	for _, transition := range w.Transitions {
+		if targets[transition.State] {
+			invalid("state %q has more than one transition", transition.State)
+		}
+		targets[transition.State] = true
+
+		if models.ValidateInstanceState(transition.State) != nil {
+			invalid("unknown state %q", transition.State)
+		} else if state, ok := enterStates[transition.State]; !ok || state.EnterFunc == nil {
+			invalid("state %q has no enter function", transition.State)
+		}
+
+		if len(transition.AllowedSourceStates) == 0 {
+			invalid("state %q has no allowed source states", transition.State)
+		}
+		for _, source := range transition.AllowedSourceStates {
+			if models.ValidateInstanceState(source) != nil {
+				invalid("unknown source state %q for state %q", source, transition.State)
+			}
+		}
+		for _, name := range transition.Guards {
+			if guard, ok := guards[name]; !ok || guard.CheckFunc == nil {
+				invalid("unknown guard %q for state %q", name, transition.State)
+			}
+		}
+	}
+
+	reachable := w.reachableStates()
+	for _, transition := range w.Transitions {
+		if !reachable[transition.State] {
+			invalid("state %q cannot be reached from the initial states", transition.State)
+		}
+	}
+
+	return errs
+}
+
+// reachableStates returns the states that versions can be moved to from the initial states of the workflow
+func (w Workflow) reachableStates() map[string]bool {
+	reachable := map[string]bool{}
+	for _, state := range w.InitialStates {
+		reachable[state] = true
+	}
+
+	for changed := true; changed; {
+		changed = false
+		for _, transition := range w.Transitions {
+			if reachable[transition.State] {
+				continue
+			}
+			for _, source := range transition.AllowedSourceStates {
+				if reachable[source] {
+					reachable[transition.State] = true
+					changed = true
+					break
+				}
+			}
+		}
+	}
+
+	return reachable
+}
+
+// Transitions returns the transitions of the state machine for every workflow. The workflows must have been validated.
+func (w *Workflows) Transitions() []Transition {
+	var transitions []Transition
+	for _, workflow := range w.Workflows {
+		for _, transition := range workflow.Transitions {
+			var transitionGuards []Guard
+			for _, name := range transition.Guards {
+				transitionGuards = append(transitionGuards, guards[name])
+			}
+			transitions = append(transitions, Transition{
+				Label:               transition.State,
+				TargetState:         enterStates[transition.State],
+				AllowedSourceStates: transition.AllowedSourceStates,
+				Type:                workflow.Type,
+				Guards:              transitionGuards,
+			})
+		}
+	}
+	return transitions
+}
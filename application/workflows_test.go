@@ -0,0 +1,252 @@
+package application
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestLoadWorkflows(t *testing.T) {
+	Convey("When the default workflows are loaded", t, func() {
+		transitions, err := LoadWorkflows("")
+
+		Convey("Then they are valid, and there are transitions for every type of dataset", func() {
+			So(err, ShouldBeNil)
+			So(transitions, ShouldHaveLength, 24)
+
+			types := map[string]int{}
+			for _, transition := range transitions {
+				types[transition.Type]++
+				So(transition.TargetState.EnterFunc, ShouldNotBeNil)
+			}
+			So(types, ShouldResemble, map[string]int{
+				"v4": 4, "cantabular_table": 4, "cantabular_blob": 4, "cantabular_flexible_table": 4, "cantabular_multivariate_table": 4, "static": 4,
+			})
+		})
+
+		Convey("Then static versions can only be published once approved", func() {
+			for _, transition := range transitions {
+				if transition.Type == "static" && transition.Label == "published" {
+					So(transition.TargetState.Name, ShouldEqual, Published.Name)
+					So(transition.AllowedSourceStates, ShouldResemble, []string{"approved"})
+				}
+			}
+		})
+
+		Convey("Then static versions need distributions and a quality designation to be approved", func() {
+			for _, transition := range transitions {
+				if transition.Type == "static" && transition.Label == "approved" {
+					So(transition.Guards, ShouldHaveLength, 2)
+					So(transition.Guards[0].Name, ShouldEqual, HasDistributions.Name)
+					So(transition.Guards[1].Name, ShouldEqual, HasQualityDesignation.Name)
+				}
+			}
+		})
+
+		Convey("Then v4 versions need their import tasks completed to be published", func() {
+			for _, transition := range transitions {
+				if transition.Type == "v4" && transition.Label == "published" {
+					So(transition.Guards, ShouldHaveLength, 1)
+					So(transition.Guards[0].Name, ShouldEqual, ImportTasksCompleted.Name)
+				}
+			}
+		})
+
+		Convey("Then approved static versions can be rejected back to associated", func() {
+			for _, transition := range transitions {
+				if transition.Type == "static" && transition.Label == "associated" {
+					So(transition.AllowedSourceStates, ShouldContain, "approved")
+				}
+			}
+		})
+
+		Convey("Then the versions of every type of dataset can only be withdrawn once published", func() {
+			withdrawn := map[string]bool{}
+			for _, transition := range transitions {
+				if transition.Label == "withdrawn" {
+					withdrawn[transition.Type] = true
+					So(transition.TargetState.Name, ShouldEqual, Withdrawn.Name)
+					So(transition.AllowedSourceStates, ShouldResemble, []string{"published"})
+				}
+			}
+			So(withdrawn, ShouldHaveLength, 6)
+		})
+	})
+
+	Convey("Given a workflows file", t, func() {
+		path := filepath.Join(t.TempDir(), "workflows.json")
+		So(os.WriteFile(path, []byte(publishOnlyWorkflows(allWorkflowTypes...)), 0o600), ShouldBeNil)
+
+		Convey("When it is loaded", func() {
+			transitions, err := LoadWorkflows(path)
+
+			Convey("Then its transitions are returned", func() {
+				So(err, ShouldBeNil)
+				So(transitions, ShouldHaveLength, len(allWorkflowTypes))
+				So(transitions[len(transitions)-1].Type, ShouldEqual, "static")
+				So(transitions[len(transitions)-1].TargetState.Name, ShouldEqual, Published.Name)
+				So(transitions[len(transitions)-1].AllowedSourceStates, ShouldResemble, []string{"created"})
+			})
+		})
+	})
+
+	Convey("When a workflows file that does not exist is loaded", t, func() {
+		_, err := LoadWorkflows(filepath.Join(t.TempDir(), "missing.json"))
+
+		Convey("Then an error is returned", func() {
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, "failed to read workflows file")
+		})
+	})
+}
+
+func TestParseWorkflows(t *testing.T) {
+	Convey("When workflows with an unknown field are parsed", t, func() {
+		_, err := ParseWorkflows([]byte(`{"workflows": [{"type": "v4", "states": []}]}`))
+
+		Convey("Then an error is returned", func() {
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, `unknown field "states"`)
+		})
+	})
+}
+
+func TestValidateWorkflows(t *testing.T) {
+	validate := func(definition string) error {
+		workflows, err := ParseWorkflows([]byte(definition))
+		So(err, ShouldBeNil)
+		return workflows.Validate()
+	}
+
+	Convey("When there are no workflows", t, func() {
+		err := validate(`{"workflows": []}`)
+
+		Convey("Then they are invalid", func() {
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, "no workflows defined")
+		})
+	})
+
+	Convey("When a workflow uses unknown states", t, func() {
+		err := validate(`{"workflows": [{"type": "v4", "initial_states": ["drafted"], "transitions": [
+			{"state": "published", "allowed_source_states": ["drafted", "reviewed"]},
+			{"state": "archived", "allowed_source_states": ["published"]}
+		]}]}`)
+
+		Convey("Then every unknown state is reported", func() {
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, `workflow "v4": unknown initial state "drafted"`)
+			So(err.Error(), ShouldContainSubstring, `workflow "v4": unknown source state "reviewed" for state "published"`)
+			So(err.Error(), ShouldContainSubstring, `workflow "v4": unknown state "archived"`)
+		})
+	})
+
+	Convey("When a workflow moves versions to a state without an enter function", t, func() {
+		err := validate(`{"workflows": [{"type": "v4", "initial_states": ["created"], "transitions": [
+			{"state": "completed", "allowed_source_states": ["created"]}
+		]}]}`)
+
+		Convey("Then the state is reported", func() {
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, `workflow "v4": state "completed" has no enter function`)
+		})
+	})
+
+	Convey("When a workflow has a state that cannot be reached from its initial states", t, func() {
+		err := validate(`{"workflows": [{"type": "static", "initial_states": ["created"], "transitions": [
+			{"state": "associated", "allowed_source_states": ["created"]},
+			{"state": "approved", "allowed_source_states": ["published"]},
+			{"state": "published", "allowed_source_states": ["approved"]}
+		]}]}`)
+
+		Convey("Then every unreachable state is reported", func() {
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, `workflow "static": state "approved" cannot be reached from the initial states`)
+			So(err.Error(), ShouldContainSubstring, `workflow "static": state "published" cannot be reached from the initial states`)
+			So(err.Error(), ShouldNotContainSubstring, `state "associated" cannot be reached`)
+		})
+	})
+
+	Convey("When a workflow uses an unknown guard", t, func() {
+		err := validate(`{"workflows": [{"type": "v4", "initial_states": ["created"], "transitions": [
+			{"state": "published", "allowed_source_states": ["created"], "guards": ["import-tasks-completed", "has-approval"]}
+		]}]}`)
+
+		Convey("Then the guard is reported", func() {
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, `workflow "v4": unknown guard "has-approval" for state "published"`)
+			So(err.Error(), ShouldNotContainSubstring, `unknown guard "import-tasks-completed"`)
+		})
+	})
+
+	Convey("When a type of dataset has no workflow", t, func() {
+		err := validate(publishOnlyWorkflows("v4", "cantabular_flexible_table", "cantabular_multivariate_table", "static"))
+
+		Convey("Then every type without a workflow is reported", func() {
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, `no workflow for dataset type "cantabular_table"`)
+			So(err.Error(), ShouldContainSubstring, `no workflow for dataset type "cantabular_blob"`)
+			So(err.Error(), ShouldNotContainSubstring, `no workflow for dataset type "static"`)
+		})
+	})
+
+	Convey("When a workflow is for an unknown type of dataset", t, func() {
+		err := validate(publishOnlyWorkflows(append(allWorkflowTypes, "nomis")...))
+
+		Convey("Then the type is reported", func() {
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, `workflow "nomis": unknown dataset type`)
+		})
+	})
+
+	Convey("When two workflows are for the same type of dataset", t, func() {
+		err := validate(publishOnlyWorkflows(append(allWorkflowTypes, "filterable")...))
+
+		Convey("Then the second workflow is reported", func() {
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, `workflow "filterable" is for the same dataset type as workflow "v4"`)
+		})
+	})
+
+	Convey("When there is a valid workflow for every type of dataset", t, func() {
+		err := validate(publishOnlyWorkflows(allWorkflowTypes...))
+
+		Convey("Then they are valid", func() {
+			So(err, ShouldBeNil)
+		})
+	})
+
+	Convey("When a workflow is defined twice, or defines a state twice", t, func() {
+		err := validate(`{"workflows": [
+			{"type": "v4", "initial_states": ["created"], "transitions": [{"state": "published", "allowed_source_states": ["created"]}]},
+			{"type": "v4", "initial_states": ["created"], "transitions": [
+				{"state": "published", "allowed_source_states": ["created"]},
+				{"state": "published", "allowed_source_states": ["published"]}
+			]}
+		]}`)
+
+		Convey("Then the duplicates are reported", func() {
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, `workflow "v4" is defined more than once`)
+			So(err.Error(), ShouldContainSubstring, `workflow "v4": state "published" has more than one transition`)
+		})
+	})
+}
+
+// allWorkflowTypes are the workflow types that cover every type of dataset, where v4 is the filterable type
+var allWorkflowTypes = []string{"v4", "cantabular_table", "cantabular_blob", "cantabular_flexible_table", "cantabular_multivariate_table", "static"}
+
+// publishOnlyWorkflows returns the definition of a workflow for each of the provided types, that only publishes versions
+func publishOnlyWorkflows(types ...string) string {
+	workflows := make([]string, 0, len(types))
+	for _, workflowType := range types {
+		workflows = append(workflows, fmt.Sprintf(`{"type": %q, "initial_states": ["created"], "transitions": [
+			{"state": "published", "allowed_source_states": ["created"]}
+		]}`, workflowType))
+	}
+	return `{"workflows": [` + strings.Join(workflows, ",") + `]}`
+}
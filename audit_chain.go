@@ -0,0 +1,43 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"os"
+
+	"github.com/ONSdigital/dp-dataset-api/application"
+	"github.com/ONSdigital/dp-dataset-api/service"
+	"github.com/ONSdigital/dp-dataset-api/store"
+)
+
+const verifyAuditChainCommand = "verify-audit-chain"
+
+// errAuditChainBroken is returned by the verify-audit-chain command when an audit event is not correctly chained
+var errAuditChainBroken = errors.New("the audit chain is broken")
+
+// verifyAuditChain walks the chain of audit events and writes the outcome to stdout as JSON, including the first
+// broken link of the chain, in which case the command fails
+func verifyAuditChain(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet(verifyAuditChainCommand, flag.ContinueOnError)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	return withMongoDB(ctx, func(mongoDB store.MongoDB) error {
+		auditService := application.NewAuditService(store.DataStore{Backend: service.DatsetAPIStore{MongoDB: mongoDB}})
+		verification, err := auditService.VerifyAuditChain(ctx)
+		if err != nil {
+			return err
+		}
+
+		if err = json.NewEncoder(os.Stdout).Encode(verification); err != nil {
+			return err
+		}
+		if !verification.Valid {
+			return errAuditChainBroken
+		}
+		return nil
+	})
+}
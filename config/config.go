@@ -16,6 +16,9 @@ type MongoConfig struct {
 
 	CodeListAPIURL string `envconfig:"CODE_LIST_API_URL"`
 	DatasetAPIURL  string `envconfig:"DATASET_API_URL"`
+	Datastore      string `envconfig:"DATASTORE"`
+	// MigrateOnStartup runs the pending schema migrations before the service starts serving requests
+	MigrateOnStartup bool `envconfig:"MIGRATE_ON_STARTUP"`
 }
 
 // Configuration structure which hold information for configuring the import API
@@ -32,6 +35,23 @@ type Configuration struct {
 	GenerateDownloadsTopic         string        `envconfig:"GENERATE_DOWNLOADS_TOPIC"`
 	CantabularExportStartTopic     string        `envconfig:"CANTABULAR_EXPORT_START"`
 	SearchContentUpdatedTopic      string        `envconfig:"SEARCH_CONTENT_UPDATED_TOPIC"`
+	SearchContentDeletedTopic      string        `envconfig:"SEARCH_CONTENT_DELETED_TOPIC"`
+	AuditEventsTopic               string        `envconfig:"AUDIT_EVENTS_TOPIC"`
+	EnableAuditEventsKafka         bool          `envconfig:"ENABLE_AUDIT_EVENTS_KAFKA"`
+	WorkflowsFile                  string        `envconfig:"WORKFLOWS_FILE"`
+	OutboxRelayInterval            time.Duration `envconfig:"OUTBOX_RELAY_INTERVAL"`
+	OutboxRelayBatchSize           int           `envconfig:"OUTBOX_RELAY_BATCH_SIZE"`
+	OutboxSendTimeout              time.Duration `envconfig:"OUTBOX_SEND_TIMEOUT"`
+	OutboxMaxAttempts              int           `envconfig:"OUTBOX_MAX_ATTEMPTS"`
+	OutboxLeaseDuration            time.Duration `envconfig:"OUTBOX_LEASE_DURATION"`
+	OutboxFailedRetryDelay         time.Duration `envconfig:"OUTBOX_FAILED_RETRY_DELAY"`
+	OutboxSentRetention            time.Duration `envconfig:"OUTBOX_SENT_RETENTION"`
+	DeletedRetentionPeriod         time.Duration `envconfig:"DELETED_RETENTION_PERIOD"`
+	PurgeInterval                  time.Duration `envconfig:"PURGE_INTERVAL"`
+	PurgeBatchSize                 int           `envconfig:"PURGE_BATCH_SIZE"`
+	EnableScheduledPublishing      bool          `envconfig:"ENABLE_SCHEDULED_PUBLISHING"`
+	ScheduledPublishingInterval    time.Duration `envconfig:"SCHEDULED_PUBLISHING_INTERVAL"`
+	ScheduledPublishingBatchSize   int           `envconfig:"SCHEDULED_PUBLISHING_BATCH_SIZE"`
 	APIRouterPublicURL             string        `envconfig:"API_ROUTER_PUBLIC_URL"`
 	CodeListAPIURL                 string        `envconfig:"CODE_LIST_API_URL"`
 	DatasetAPIURL                  string        `envconfig:"DATASET_API_URL"`
@@ -80,6 +100,15 @@ const (
 	InstanceLockCollection     = "InstanceLockCollection"
 	VersionsCollection         = "VersionsCollection"
 	DatasetEventsCollection    = "DatasetEventsCollection"
+	OutboxCollection           = "OutboxCollection"
+	MigrationsCollection       = "MigrationsCollection"
+	DatasetRevisionsCollection = "DatasetRevisionsCollection"
+)
+
+// Supported datastore implementations
+const (
+	MongoDatastore  = "mongo"
+	MemoryDatastore = "memory"
 )
 
 // Get the application and returns the configuration structure, and initialises with default values.
@@ -96,6 +125,23 @@ func Get() (*Configuration, error) {
 		GenerateDownloadsTopic:         "filter-job-submitted",
 		CantabularExportStartTopic:     "cantabular-export-start",
 		SearchContentUpdatedTopic:      "search-content-updated",
+		SearchContentDeletedTopic:      "search-content-deleted",
+		AuditEventsTopic:               "dataset-audit-events",
+		EnableAuditEventsKafka:         false,
+		WorkflowsFile:                  "",
+		OutboxRelayInterval:            time.Second,
+		OutboxRelayBatchSize:           100,
+		OutboxSendTimeout:              5 * time.Second,
+		OutboxMaxAttempts:              10,
+		OutboxLeaseDuration:            time.Minute,
+		OutboxFailedRetryDelay:         time.Hour,
+		OutboxSentRetention:            24 * time.Hour,
+		DeletedRetentionPeriod:         30 * 24 * time.Hour,
+		PurgeInterval:                  time.Hour,
+		PurgeBatchSize:                 100,
+		EnableScheduledPublishing:      false,
+		ScheduledPublishingInterval:    time.Minute,
+		ScheduledPublishingBatchSize:   100,
 		APIRouterPublicURL:             "http://localhost:23200/v1",
 		CodeListAPIURL:                 "http://localhost:22400",
 		DatasetAPIURL:                  "http://localhost:22000",
@@ -139,6 +185,9 @@ func Get() (*Configuration, error) {
 					InstanceLockCollection:     "instances_locks",
 					VersionsCollection:         "versions",
 					DatasetEventsCollection:    "dataset_events",
+					OutboxCollection:           "outbox",
+					MigrationsCollection:       "migrations",
+					DatasetRevisionsCollection: "dataset_revisions",
 				},
 				ReplicaSet:                    "",
 				IsStrongReadConcernEnabled:    false,
@@ -149,8 +198,10 @@ func Get() (*Configuration, error) {
 					IsSSL: false,
 				},
 			},
-			CodeListAPIURL: "http://localhost:22400",
-			DatasetAPIURL:  "http://localhost:22000",
+			CodeListAPIURL:   "http://localhost:22400",
+			DatasetAPIURL:    "http://localhost:22000",
+			Datastore:        MongoDatastore,
+			MigrateOnStartup: true,
 		},
 		ComponentTestUseLogFile: false,
 		AuthConfig:              authorisation.NewDefaultConfig(),
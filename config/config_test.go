@@ -34,6 +34,23 @@ func TestSpec(t *testing.T) {
 				So(cfg.GenerateDownloadsTopic, ShouldEqual, "filter-job-submitted")
 				So(cfg.CantabularExportStartTopic, ShouldEqual, "cantabular-export-start")
 				So(cfg.SearchContentUpdatedTopic, ShouldEqual, "search-content-updated")
+				So(cfg.SearchContentDeletedTopic, ShouldEqual, "search-content-deleted")
+				So(cfg.AuditEventsTopic, ShouldEqual, "dataset-audit-events")
+				So(cfg.EnableAuditEventsKafka, ShouldBeFalse)
+				So(cfg.WorkflowsFile, ShouldEqual, "")
+				So(cfg.OutboxRelayInterval, ShouldEqual, time.Second)
+				So(cfg.OutboxRelayBatchSize, ShouldEqual, 100)
+				So(cfg.OutboxSendTimeout, ShouldEqual, 5*time.Second)
+				So(cfg.OutboxMaxAttempts, ShouldEqual, 10)
+				So(cfg.OutboxLeaseDuration, ShouldEqual, time.Minute)
+				So(cfg.OutboxFailedRetryDelay, ShouldEqual, time.Hour)
+				So(cfg.OutboxSentRetention, ShouldEqual, 24*time.Hour)
+				So(cfg.DeletedRetentionPeriod, ShouldEqual, 30*24*time.Hour)
+				So(cfg.PurgeInterval, ShouldEqual, time.Hour)
+				So(cfg.PurgeBatchSize, ShouldEqual, 100)
+				So(cfg.EnableScheduledPublishing, ShouldBeFalse)
+				So(cfg.ScheduledPublishingInterval, ShouldEqual, time.Minute)
+				So(cfg.ScheduledPublishingBatchSize, ShouldEqual, 100)
 				So(cfg.APIRouterPublicURL, ShouldEqual, "http://localhost:23200/v1")
 				So(cfg.DatasetAPIURL, ShouldEqual, "http://localhost:22000")
 				So(cfg.CodeListAPIURL, ShouldEqual, "http://localhost:22400")
@@ -62,7 +79,10 @@ func TestSpec(t *testing.T) {
 					"DimensionOptionsCollection": "dimension.options",
 					"InstanceLockCollection":     "instances_locks",
 					"VersionsCollection":         "versions",
-					"DatasetEventsCollection":    "dataset_events"},
+					"DatasetEventsCollection":    "dataset_events",
+					"OutboxCollection":           "outbox",
+					"MigrationsCollection":       "migrations",
+					"DatasetRevisionsCollection": "dataset_revisions"},
 				)
 				So(cfg.Username, ShouldEqual, "")
 				So(cfg.Password, ShouldEqual, "")
@@ -73,6 +93,8 @@ func TestSpec(t *testing.T) {
 				So(cfg.IsWriteConcernMajorityEnabled, ShouldEqual, true)
 				So(cfg.DatasetAPIURL, ShouldEqual, "http://localhost:22000")
 				So(cfg.CodeListAPIURL, ShouldEqual, "http://localhost:22400")
+				So(cfg.Datastore, ShouldEqual, "mongo")
+				So(cfg.MigrateOnStartup, ShouldBeTrue)
 				So(cfg.AuthConfig, ShouldEqual, authorisation.NewDefaultConfig())
 				So(cfg.CloudflareEnabled, ShouldBeFalse)
 				So(cfg.CloudflareConfig, ShouldEqual, cloudflare.NewDefaultConfig())
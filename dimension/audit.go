@@ -0,0 +1,48 @@
+package dimension
+
+import (
+	"context"
+
+	"github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// recordAuditEvent records a dimension options audit event for the requester and logs its outcome for protective monitoring
+func (s *Store) recordAuditEvent(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource, datasetID string, options []*models.CachedDimensionOption) error {
+	logData := log.Data{
+		"action":       action,
+		"endpoint":     resource,
+		"requested_by": requestedBy.ID,
+	}
+
+	if err := s.AuditService.RecordDimensionOptionsAuditEvent(ctx, requestedBy, action, resource, datasetID, options); err != nil {
+		logData["outcome"] = "failure"
+		logData["reason"] = err.Error()
+		log.Info(ctx, "failed to create dimension options audit event", log.Classification(log.ProtectiveMonitoring), logData)
+		return err
+	}
+
+	logData["outcome"] = "success"
+	log.Info(ctx, "successfully created dimension options audit event", log.Classification(log.ProtectiveMonitoring), logData)
+	return nil
+}
+
+// optionsAuditEvent is the audit event of an action on dimension options. It is recorded once the instance has been
+// checked and before the options are written, so that they are not changed if the event cannot be recorded.
+type optionsAuditEvent struct {
+	requestedBy models.RequestedBy
+	action      models.Action
+	resource    string
+	options     []*models.CachedDimensionOption
+}
+
+// cachedOptionFromUpdate returns the node id and order update of a dimension option as it is recorded in audit events
+func cachedOptionFromUpdate(option *models.DimensionOption) *models.CachedDimensionOption {
+	return &models.CachedDimensionOption{
+		InstanceID: option.InstanceID,
+		Name:       option.Name,
+		Option:     option.Option,
+		NodeID:     option.NodeID,
+		Order:      option.Order,
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/gorilla/mux"
 
 	"github.com/ONSdigital/dp-dataset-api/apierrors"
+	"github.com/ONSdigital/dp-dataset-api/application"
 	"github.com/ONSdigital/dp-dataset-api/models"
 	"github.com/ONSdigital/dp-dataset-api/store"
 	"github.com/ONSdigital/dp-dataset-api/url"
@@ -28,6 +29,9 @@ type Store struct {
 	MaxRequestOptions  int
 	URLBuilder         *url.Builder
 	EnableURLRewriting bool
+	AuditService       application.AuditService
+	// RequestedBy identifies the user or service that made a request, for the audit events
+	RequestedBy func(r *http.Request) (models.RequestedBy, error)
 }
 
 // List of actions for dimensions
@@ -161,6 +165,13 @@ func (s *Store) AddHandler(w http.ResponseWriter, r *http.Request) {
 	logData := log.Data{"instance_id": instanceID}
 	logData["action"] = AddDimensionAction
 
+	requestedBy, err := s.RequestedBy(r)
+	if err != nil {
+		log.Error(ctx, "failed to get the requester of the request", err, logData)
+		handleDimensionErr(ctx, w, err, logData)
+		return
+	}
+
 	option, err := unmarshalDimensionCache(r.Body)
 	if err != nil {
 		log.Error(ctx, "failed to unmarshal dimension cache", err, logData)
@@ -176,8 +187,15 @@ func (s *Store) AddHandler(w http.ResponseWriter, r *http.Request) {
 	}
 	defer s.UnlockInstance(ctx, lockID)
 
+	audit := &optionsAuditEvent{
+		requestedBy: requestedBy,
+		action:      models.ActionCreate,
+		resource:    "/instances/" + instanceID + "/dimensions",
+		options:     []*models.CachedDimensionOption{option},
+	}
+
 	// upsert dimension option
-	newETag, err := s.upsertDimensionOption(ctx, instanceID, option, logData, eTag)
+	newETag, err := s.upsertDimensionOption(ctx, instanceID, option, audit, logData, eTag)
 	if err != nil {
 		handleDimensionErr(ctx, w, err, logData)
 		return
@@ -198,6 +216,13 @@ func (s *Store) PatchDimensionsHandler(w http.ResponseWriter, r *http.Request) {
 	eTag := getIfMatch(r)
 	logData := log.Data{"instance_id": instanceID}
 
+	requestedBy, err := s.RequestedBy(r)
+	if err != nil {
+		log.Error(ctx, "failed to get the requester of the request", err, logData)
+		handleDimensionErr(ctx, w, err, logData)
+		return
+	}
+
 	// unmarshal and validate the patch array
 	patches, err := createPatches(r.Body, dprequest.OpAdd)
 	if err != nil {
@@ -208,7 +233,7 @@ func (s *Store) PatchDimensionsHandler(w http.ResponseWriter, r *http.Request) {
 	logData["num_patches"] = len(patches)
 
 	// apply the patches to the instance dimensions
-	successfulPatches, newETag, err := s.applyPatchesForDimensions(ctx, instanceID, patches, logData, eTag)
+	successfulPatches, newETag, err := s.applyPatchesForDimensions(ctx, instanceID, patches, requestedBy, logData, eTag)
 	if err != nil {
 		logData["successful_patches"] = successfulPatches
 		handleDimensionErr(ctx, w, err, logData)
@@ -229,7 +254,7 @@ func (s *Store) PatchDimensionsHandler(w http.ResponseWriter, r *http.Request) {
 	log.Info(ctx, "successfully patched dimensions of an instance resource", logData)
 }
 
-func (s *Store) applyPatchesForDimensions(ctx context.Context, instanceID string, patches []dprequest.Patch, logData log.Data, eTagSelector string) (successful []dprequest.Patch, newETag string, err error) {
+func (s *Store) applyPatchesForDimensions(ctx context.Context, instanceID string, patches []dprequest.Patch, requestedBy models.RequestedBy, logData log.Data, eTagSelector string) (successful []dprequest.Patch, newETag string, err error) {
 	upserts := []dprequest.Patch{} // list of patches that correspond to a MongoDB upsert
 	updates := []dprequest.Patch{} // list of patches that correspond to a MongoDB update
 
@@ -254,11 +279,11 @@ func (s *Store) applyPatchesForDimensions(ctx context.Context, instanceID string
 
 		if patch.Path == "/-" {
 			// get list of options provided as value
-			options, err := getOptionsArrayFromInterface(patch.Value)
+			patchOptions, err := getOptionsArrayFromInterface(patch.Value)
 			if err != nil {
 				return nil, "", apierrors.ErrInvalidPatch{Msg: fmt.Sprintf("provided values '%#v' is not a list of dimension options", patch.Value)}
 			}
-			optionsToUpsert = append(optionsToUpsert, options...)
+			optionsToUpsert = append(optionsToUpsert, patchOptions...)
 			upserts = append(upserts, patch)
 			continue
 		}
@@ -301,6 +326,16 @@ func (s *Store) applyPatchesForDimensions(ctx context.Context, instanceID string
 		return nil, "", err
 	}
 
+	audit := &optionsAuditEvent{
+		requestedBy: requestedBy,
+		action:      models.ActionUpdate,
+		resource:    "/instances/" + instanceID + "/dimensions",
+		options:     append([]*models.CachedDimensionOption{}, optionsToUpsert...),
+	}
+	for _, option := range optionsToUpdate {
+		audit.options = append(audit.options, cachedOptionFromUpdate(option))
+	}
+
 	// acquire instance lock so that the instance update and the dimension.options update are atomic
 	lockID, err := s.AcquireInstanceLock(ctx, instanceID)
 	if err != nil {
@@ -310,7 +345,7 @@ func (s *Store) applyPatchesForDimensions(ctx context.Context, instanceID string
 
 	// Upsert and update dimension options
 	upsertOK := false
-	newETag, upsertOK, err = s.upsertAndUpdateDimensionOptions(ctx, instanceID, optionsToUpsert, optionsToUpdate, logData, eTagSelector)
+	newETag, upsertOK, err = s.upsertAndUpdateDimensionOptions(ctx, instanceID, optionsToUpsert, optionsToUpdate, audit, logData, eTagSelector)
 	if upsertOK {
 		successful = append(successful, upserts...)
 	}
@@ -323,22 +358,22 @@ func (s *Store) applyPatchesForDimensions(ctx context.Context, instanceID string
 }
 
 // upsertDimensionOption wraps upsertAndUpdateDimensionOptions for a single option upsert case, for simplicity
-func (s *Store) upsertDimensionOption(ctx context.Context, instanceID string, op *models.CachedDimensionOption, logData log.Data, eTagSelector string) (newETag string, err error) {
-	newETag, _, err = s.upsertAndUpdateDimensionOptions(ctx, instanceID, []*models.CachedDimensionOption{op}, nil, logData, eTagSelector)
+func (s *Store) upsertDimensionOption(ctx context.Context, instanceID string, op *models.CachedDimensionOption, audit *optionsAuditEvent, logData log.Data, eTagSelector string) (newETag string, err error) {
+	newETag, _, err = s.upsertAndUpdateDimensionOptions(ctx, instanceID, []*models.CachedDimensionOption{op}, nil, audit, logData, eTagSelector)
 	return
 }
 
 // updateDimensionOption wraps upsertAndUpdateDimensionOptions for a single option update case, for simplicity
-func (s *Store) updateDimensionOption(ctx context.Context, instanceID string, op *models.DimensionOption, logData log.Data, eTagSelector string) (newETag string, err error) {
-	newETag, _, err = s.upsertAndUpdateDimensionOptions(ctx, instanceID, nil, []*models.DimensionOption{op}, logData, eTagSelector)
+func (s *Store) updateDimensionOption(ctx context.Context, instanceID string, op *models.DimensionOption, audit *optionsAuditEvent, logData log.Data, eTagSelector string) (newETag string, err error) {
+	newETag, _, err = s.upsertAndUpdateDimensionOptions(ctx, instanceID, nil, []*models.DimensionOption{op}, audit, logData, eTagSelector)
 	return
 }
 
-// upsertAndUpdateDimensionOptions checks that the instance is in a valid state,
+// upsertAndUpdateDimensionOptions checks that the instance is in a valid state, records the audit event, if one is provided,
 // then it updates its ETag value according to the upserts and updates
 // and then performs the upserts (inert or update) and updates (node id and order) in bulk
 // the caller may need to acquire an exclusive lock because this method does a read and potentially 2 writes to mongoDB
-func (s *Store) upsertAndUpdateDimensionOptions(ctx context.Context, instanceID string, optionsToUpsert []*models.CachedDimensionOption, optionsToUpdate []*models.DimensionOption, logData log.Data, eTagSelector string) (newETag string, upsertOK bool, err error) {
+func (s *Store) upsertAndUpdateDimensionOptions(ctx context.Context, instanceID string, optionsToUpsert []*models.CachedDimensionOption, optionsToUpdate []*models.DimensionOption, audit *optionsAuditEvent, logData log.Data, eTagSelector string) (newETag string, upsertOK bool, err error) {
 	if len(optionsToUpsert) == 0 && len(optionsToUpdate) == 0 {
 		return "", true, nil // nothing to update or upsert
 	}
@@ -365,6 +400,17 @@ func (s *Store) upsertAndUpdateDimensionOptions(ctx context.Context, instanceID
 		option.InstanceID = instanceID
 	}
 
+	if audit != nil {
+		var datasetID string
+		if instance.Links != nil && instance.Links.Dataset != nil {
+			datasetID = instance.Links.Dataset.ID
+		}
+		if err = s.recordAuditEvent(ctx, audit.requestedBy, audit.action, audit.resource, datasetID, audit.options); err != nil {
+			log.Error(ctx, "failed to record dimension options audit event", err, logData)
+			return "", false, err
+		}
+	}
+
 	// generate a new unique ETag for the instance + options and update it in DB
 	newETag, err = s.UpdateETagForOptions(ctx, instance, optionsToUpsert, optionsToUpdate, eTagSelector)
 	if err != nil {
@@ -423,6 +469,13 @@ func (s *Store) PatchOptionHandler(w http.ResponseWriter, r *http.Request) {
 	eTag := getIfMatch(r)
 	logData := log.Data{"instance_id": instanceID, "dimension": dimensionName, "option": option}
 
+	requestedBy, err := s.RequestedBy(r)
+	if err != nil {
+		log.Error(ctx, "failed to get the requester of the request", err, logData)
+		handleDimensionErr(ctx, w, err, logData)
+		return
+	}
+
 	// unmarshal and validate the patch array
 	patches, err := createPatches(r.Body, dprequest.OpAdd) // OpAdd Upserts all the items provided in the value array
 	if err != nil {
@@ -433,7 +486,7 @@ func (s *Store) PatchOptionHandler(w http.ResponseWriter, r *http.Request) {
 	logData["patch_list"] = patches
 
 	// apply the patches to the dimension option
-	successfulPatches, newETag, err := s.patchOption(ctx, instanceID, dimensionName, option, patches, logData, eTag)
+	successfulPatches, newETag, err := s.patchOption(ctx, instanceID, dimensionName, option, patches, requestedBy, logData, eTag)
 	if err != nil {
 		logData["successful_patches"] = successfulPatches
 		handleDimensionErr(ctx, w, err, logData)
@@ -454,19 +507,14 @@ func (s *Store) PatchOptionHandler(w http.ResponseWriter, r *http.Request) {
 	log.Info(ctx, "successfully patched dimension option of an instance resource", logData)
 }
 
-func (s *Store) patchOption(ctx context.Context, instanceID, dimensionName, option string, patches []dprequest.Patch, logData log.Data, eTagSelector string) (successful []dprequest.Patch, newETag string, err error) {
-	// acquire instance lock so that the instance update and the dimension.options update are atomic
-	lockID, err := s.AcquireInstanceLock(ctx, instanceID)
-	if err != nil {
-		return successful, "", err
-	}
-	defer s.UnlockInstance(ctx, lockID)
+func (s *Store) patchOption(ctx context.Context, instanceID, dimensionName, option string, patches []dprequest.Patch, requestedBy models.RequestedBy, logData log.Data, eTagSelector string) (successful []dprequest.Patch, newETag string, err error) {
+	patched := &models.CachedDimensionOption{Name: dimensionName, Option: option, InstanceID: instanceID}
+	dimOptions := make([]models.DimensionOption, 0, len(patches))
 
-	// apply patch operations sequentially, stop processing if one patch fails, and return a list of successful patches operations
+	// populate the fields from the patch paths, failing if there is any wrong path or value (nothing is written to the DB yet)
 	for _, patch := range patches {
 		dimOption := models.DimensionOption{Name: dimensionName, Option: option, InstanceID: instanceID}
 
-		// populate the field from the patch path
 		switch patch.Path {
 		case "/node_id":
 			val, ok := patch.Value.(string)
@@ -474,6 +522,7 @@ func (s *Store) patchOption(ctx context.Context, instanceID, dimensionName, opti
 				return successful, "", apierrors.ErrInvalidPatch{Msg: "wrong value type for /node_id, expected string"}
 			}
 			dimOption.NodeID = val
+			patched.NodeID = val
 		case "/order":
 			// json numeric values are always float64
 			v, ok := patch.Value.(float64)
@@ -482,17 +531,38 @@ func (s *Store) patchOption(ctx context.Context, instanceID, dimensionName, opti
 			}
 			val := int(v)
 			dimOption.Order = &val
+			patched.Order = &val
 		default:
 			return successful, "", apierrors.ErrInvalidPatch{Msg: fmt.Sprintf("wrong path: %s", patch.Path)}
 		}
+		dimOptions = append(dimOptions, dimOption)
+	}
 
+	// acquire instance lock so that the instance update and the dimension.options update are atomic
+	lockID, err := s.AcquireInstanceLock(ctx, instanceID)
+	if err != nil {
+		return successful, "", err
+	}
+	defer s.UnlockInstance(ctx, lockID)
+
+	// the audit event of the whole patch is recorded before the first update
+	audit := &optionsAuditEvent{
+		requestedBy: requestedBy,
+		action:      models.ActionUpdate,
+		resource:    "/instances/" + instanceID + "/dimensions/" + dimensionName + "/options/" + option,
+		options:     []*models.CachedDimensionOption{patched},
+	}
+
+	// apply patch operations sequentially, stop processing if one patch fails, and return a list of successful patches operations
+	for i := range dimOptions {
 		// update values in database, updating the instance eTag
-		newETag, err = s.updateDimensionOption(ctx, dimOption.InstanceID, &dimOption, logData, eTagSelector)
+		newETag, err = s.updateDimensionOption(ctx, instanceID, &dimOptions[i], audit, logData, eTagSelector)
 		if err != nil {
 			return successful, "", err
 		}
-		successful = append(successful, patch)
+		successful = append(successful, patches[i])
 		eTagSelector = newETag
+		audit = nil
 	}
 	return successful, newETag, nil
 }
@@ -510,6 +580,13 @@ func (s *Store) AddNodeIDHandler(w http.ResponseWriter, r *http.Request) {
 	eTag := getIfMatch(r)
 	logData := log.Data{"instance_id": instanceID, "dimension": dimensionName, "option": option, "node_id": nodeID, "action": UpdateNodeIDAction}
 
+	requestedBy, err := s.RequestedBy(r)
+	if err != nil {
+		log.Error(ctx, "failed to get the requester of the request", err, logData)
+		handleDimensionErr(ctx, w, err, logData)
+		return
+	}
+
 	dimOption := models.DimensionOption{Name: dimensionName, Option: option, NodeID: nodeID, InstanceID: instanceID}
 
 	// acquire instance lock so that the instance update and the dimension.options update are atomic
@@ -520,7 +597,14 @@ func (s *Store) AddNodeIDHandler(w http.ResponseWriter, r *http.Request) {
 	}
 	defer s.UnlockInstance(ctx, lockID)
 
-	newETag, err := s.updateDimensionOption(ctx, dimOption.InstanceID, &dimOption, logData, eTag)
+	audit := &optionsAuditEvent{
+		requestedBy: requestedBy,
+		action:      models.ActionUpdate,
+		resource:    "/instances/" + instanceID + "/dimensions/" + dimensionName + "/options/" + option,
+		options:     []*models.CachedDimensionOption{cachedOptionFromUpdate(&dimOption)},
+	}
+
+	newETag, err := s.updateDimensionOption(ctx, dimOption.InstanceID, &dimOption, audit, logData, eTag)
 	if err != nil {
 		handleDimensionErr(ctx, w, err, logData)
 		return
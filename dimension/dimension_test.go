@@ -258,7 +258,9 @@ func TestPatchOptionReturnsOK(t *testing.T) {
 			},
 		}
 
-		datasetAPI := getAPIWithCMDMocks(testContext, mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		auditServiceMock := &applicationMocks.AuditServiceMock{}
+
+		datasetAPI := getAPIWithCMDMocks(testContext, mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, &cloudflareMocks.ClienterMock{}, auditServiceMock)
 
 		Convey("Then patch dimension option with a valid node_id returns ok", func() {
 			body := strings.NewReader(`[
@@ -284,6 +286,20 @@ func TestPatchOptionReturnsOK(t *testing.T) {
 				}, testIfMatch)
 			})
 
+			Convey("And a dimension options audit event is recorded for the patched option", func() {
+				So(auditServiceMock.RecordDimensionOptionsAuditEventCalls(), ShouldHaveLength, 1)
+				So(auditServiceMock.RecordDimensionOptionsAuditEventCalls()[0].Action, ShouldEqual, models.ActionUpdate)
+				So(auditServiceMock.RecordDimensionOptionsAuditEventCalls()[0].Resource, ShouldEqual, "/instances/123/dimensions/age/options/55")
+				So(auditServiceMock.RecordDimensionOptionsAuditEventCalls()[0].Options, ShouldResemble, []*models.CachedDimensionOption{
+					{
+						InstanceID: "123",
+						Name:       "age",
+						NodeID:     "11",
+						Option:     "55",
+					},
+				})
+			})
+
 			Convey("Then the db lock is acquired and released as expected", func() {
 				validateLock(mockedDataStore, "123")
 				So(*isLocked, ShouldBeFalse)
@@ -1136,6 +1152,36 @@ func TestAddDimensionToInstanceReturnsInternalError(t *testing.T) {
 		// checks the instance is not published before entering handler
 		So(mockedDataStore.GetInstanceCalls(), ShouldHaveLength, 1)
 	})
+
+	Convey("Given the audit event cannot be recorded, then response returns an internal error and the option is not added", t, func() {
+		json := strings.NewReader(`{"value":"24", "code_list":"123-456", "dimension": "test"}`)
+		r, err := createRequestWithToken("POST", "http://localhost:21800/instances/123/dimensions", json)
+		So(err, ShouldBeNil)
+
+		w := httptest.NewRecorder()
+
+		mockedDataStore, _ := storeMockWithLock(true)
+
+		authorisationMock := &authMock.MiddlewareMock{
+			RequireFunc: func(permission string, handlerFunc http.HandlerFunc) http.HandlerFunc {
+				return handlerFunc
+			},
+		}
+
+		auditServiceMock := &applicationMocks.AuditServiceMock{
+			RecordDimensionOptionsAuditEventFunc: func(context.Context, models.RequestedBy, models.Action, string, string, []*models.CachedDimensionOption) error {
+				return errs.ErrInternalServer
+			},
+		}
+
+		datasetAPI := getAPIWithCMDMocks(testContext, mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, &cloudflareMocks.ClienterMock{}, auditServiceMock)
+		datasetAPI.Router.ServeHTTP(w, r)
+
+		So(w.Code, ShouldEqual, http.StatusInternalServerError)
+		So(auditServiceMock.RecordDimensionOptionsAuditEventCalls(), ShouldHaveLength, 1)
+		So(mockedDataStore.UpdateETagForOptionsCalls(), ShouldBeEmpty)
+		So(mockedDataStore.UpsertDimensionsToInstanceCalls(), ShouldBeEmpty)
+	})
 }
 
 func TestGetDimensionsUnauthorised(t *testing.T) {
@@ -1788,7 +1834,9 @@ func TestPatchDimensions(t *testing.T) {
 			},
 		}
 
-		datasetAPI := getAPIWithCMDMocks(testContext, mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		auditServiceMock := &applicationMocks.AuditServiceMock{}
+
+		datasetAPI := getAPIWithCMDMocks(testContext, mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, &cloudflareMocks.ClienterMock{}, auditServiceMock)
 
 		Convey("When calling patch dimension with a valid single patch 'upsert' operation", func() {
 			body := strings.NewReader(`[
@@ -1823,6 +1871,13 @@ func TestPatchDimensions(t *testing.T) {
 				}, []string{testIfMatch})
 			})
 
+			Convey("Then a single dimension options audit event is recorded for the upserted options", func() {
+				So(auditServiceMock.RecordDimensionOptionsAuditEventCalls(), ShouldHaveLength, 1)
+				So(auditServiceMock.RecordDimensionOptionsAuditEventCalls()[0].Action, ShouldEqual, models.ActionUpdate)
+				So(auditServiceMock.RecordDimensionOptionsAuditEventCalls()[0].Resource, ShouldEqual, "/instances/123/dimensions")
+				So(auditServiceMock.RecordDimensionOptionsAuditEventCalls()[0].Options, ShouldHaveLength, 2)
+			})
+
 			Convey("Then the db lock is acquired and released as expected, only once", func() {
 				validateLock(mockedDataStore, "123")
 				So(*isLocked, ShouldBeFalse)
@@ -2058,6 +2113,24 @@ func getAPIWithCMDMocks(ctx context.Context, mockedDataStore store.Storer, mocke
 			return handlerFunc
 		}
 	}
+	if authorisationMock.ParseFunc == nil {
+		authorisationMock.ParseFunc = func(_ string) (*permissionsAPISDK.EntityData, error) {
+			return &permissionsAPISDK.EntityData{UserID: "admin"}, nil
+		}
+	}
+	if auditServiceMock == nil {
+		auditServiceMock = &applicationMocks.AuditServiceMock{}
+	}
+	if auditServiceMock.RecordInstanceAuditEventFunc == nil {
+		auditServiceMock.RecordInstanceAuditEventFunc = func(_ context.Context, _ models.RequestedBy, _ models.Action, _, _ string, _, _ *models.Instance) error {
+			return nil
+		}
+	}
+	if auditServiceMock.RecordDimensionOptionsAuditEventFunc == nil {
+		auditServiceMock.RecordDimensionOptionsAuditEventFunc = func(_ context.Context, _ models.RequestedBy, _ models.Action, _, _ string, _ []*models.CachedDimensionOption) error {
+			return nil
+		}
+	}
 	mu.Lock()
 	defer mu.Unlock()
 
@@ -2070,5 +2143,5 @@ func getAPIWithCMDMocks(ctx context.Context, mockedDataStore store.Storer, mocke
 
 	testIdentityClient := clientsidentity.New(cfg.ZebedeeURL)
 
-	return api.Setup(ctx, cfg, mux.NewRouter(), store.DataStore{Backend: mockedDataStore}, urlBuilder, downloadGenerators, authorisationMock, enableURLRewriting, &mockStatemachineDatasetAPI, auditServiceMock, permissionsChecker, testIdentityClient, nil, cloudflareMock)
+	return api.Setup(ctx, cfg, mux.NewRouter(), store.DataStore{Backend: mockedDataStore}, urlBuilder, downloadGenerators, authorisationMock, enableURLRewriting, &mockStatemachineDatasetAPI, auditServiceMock, permissionsChecker, testIdentityClient, cloudflareMock)
 }
@@ -3,7 +3,6 @@ package download
 import (
 	"context"
 
-	kafka "github.com/ONSdigital/dp-kafka/v4"
 	"github.com/ONSdigital/log.go/v2/log"
 )
 
@@ -18,7 +17,7 @@ type CantabularGeneratorDownloads struct {
 
 // Generator kicks off a full dataset version download task
 type CantabularGenerator struct {
-	Producer   KafkaProducer
+	Outbox     Outbox
 	Marshaller GenerateDownloadsEvent
 }
 
@@ -60,7 +59,9 @@ func (gen *CantabularGenerator) Generate(ctx context.Context, datasetID, instanc
 		return newGeneratorError(err, avroMarshalErr)
 	}
 
-	gen.Producer.Output() <- kafka.BytesMessage{Value: avroBytes, Context: ctx}
+	if err := gen.Outbox.Write(ctx, avroBytes); err != nil {
+		return newGeneratorError(err, outboxWriteErr)
+	}
 
 	return nil
 }
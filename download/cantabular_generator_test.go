@@ -1,17 +1,17 @@
 package download
 
 import (
+	"context"
 	"testing"
 
 	"github.com/ONSdigital/dp-dataset-api/mocks"
-	kafka "github.com/ONSdigital/dp-kafka/v4"
 	"github.com/pkg/errors"
 	. "github.com/smartystreets/goconvey/convey"
 )
 
 func TestGenerator_GenerateFullCantabularDatasetDownloadsValidationErrors(t *testing.T) {
-	producerMock := &mocks.KafkaProducerMock{
-		OutputFunc: func() chan kafka.BytesMessage {
+	outboxMock := &mocks.OutboxMock{
+		WriteFunc: func(context.Context, []byte) error {
 			return nil
 		},
 	}
@@ -23,7 +23,7 @@ func TestGenerator_GenerateFullCantabularDatasetDownloadsValidationErrors(t *tes
 	}
 
 	gen := CantabularGenerator{
-		Producer:   producerMock,
+		Outbox:     outboxMock,
 		Marshaller: marhsallerMock,
 	}
 
@@ -39,8 +39,8 @@ func TestGenerator_GenerateFullCantabularDatasetDownloadsValidationErrors(t *tes
 				So(len(marhsallerMock.MarshalCalls()), ShouldEqual, 0)
 			})
 
-			Convey("And producer is never called", func() {
-				So(len(producerMock.OutputCalls()), ShouldEqual, 0)
+			Convey("And the outbox is never written to", func() {
+				So(len(outboxMock.WriteCalls()), ShouldEqual, 0)
 			})
 		})
 	})
@@ -57,8 +57,8 @@ func TestGenerator_GenerateFullCantabularDatasetDownloadsValidationErrors(t *tes
 				So(len(marhsallerMock.MarshalCalls()), ShouldEqual, 0)
 			})
 
-			Convey("And producer is never called", func() {
-				So(len(producerMock.OutputCalls()), ShouldEqual, 0)
+			Convey("And the outbox is never written to", func() {
+				So(len(outboxMock.WriteCalls()), ShouldEqual, 0)
 			})
 		})
 	})
@@ -75,8 +75,8 @@ func TestGenerator_GenerateFullCantabularDatasetDownloadsValidationErrors(t *tes
 				So(len(marhsallerMock.MarshalCalls()), ShouldEqual, 0)
 			})
 
-			Convey("And producer is never called", func() {
-				So(len(producerMock.OutputCalls()), ShouldEqual, 0)
+			Convey("And the outbox is never written to", func() {
+				So(len(outboxMock.WriteCalls()), ShouldEqual, 0)
 			})
 		})
 	})
@@ -93,8 +93,8 @@ func TestGenerator_GenerateFullCantabularDatasetDownloadsValidationErrors(t *tes
 				So(len(marhsallerMock.MarshalCalls()), ShouldEqual, 0)
 			})
 
-			Convey("And producer is never called", func() {
-				So(len(producerMock.OutputCalls()), ShouldEqual, 0)
+			Convey("And the outbox is never written to", func() {
+				So(len(outboxMock.WriteCalls()), ShouldEqual, 0)
 			})
 		})
 	})
@@ -108,8 +108,8 @@ func TestGeneratorCantabular_GenerateMarshalError(t *testing.T) {
 		version := "4"
 		mockErr := errors.New("let's get schwifty")
 
-		producerMock := &mocks.KafkaProducerMock{
-			OutputFunc: func() chan kafka.BytesMessage {
+		outboxMock := &mocks.OutboxMock{
+			WriteFunc: func(context.Context, []byte) error {
 				return nil
 			},
 		}
@@ -121,7 +121,7 @@ func TestGeneratorCantabular_GenerateMarshalError(t *testing.T) {
 		}
 
 		gen := CantabularGenerator{
-			Producer:   producerMock,
+			Outbox:     outboxMock,
 			Marshaller: marhsallerMock,
 		}
 
@@ -135,8 +135,41 @@ func TestGeneratorCantabular_GenerateMarshalError(t *testing.T) {
 			So(len(marhsallerMock.MarshalCalls()), ShouldEqual, 1)
 		})
 
-		Convey("and kafka producer is never called", func() {
-			So(len(producerMock.OutputCalls()), ShouldEqual, 0)
+		Convey("and the outbox is never written to", func() {
+			So(len(outboxMock.WriteCalls()), ShouldEqual, 0)
+		})
+	})
+}
+
+func TestGeneratorCantabular_GenerateOutboxError(t *testing.T) {
+	Convey("when the outbox returns an error", t, func() {
+		mockErr := errors.New("outbox unavailable")
+
+		outboxMock := &mocks.OutboxMock{
+			WriteFunc: func(context.Context, []byte) error {
+				return mockErr
+			},
+		}
+
+		marhsallerMock := &mocks.GenerateDownloadsEventMock{
+			MarshalFunc: func(interface{}) ([]byte, error) {
+				return []byte("hello world"), nil
+			},
+		}
+
+		gen := CantabularGenerator{
+			Outbox:     outboxMock,
+			Marshaller: marhsallerMock,
+		}
+
+		err := gen.Generate(testContext, "111", "222", "333", "4")
+
+		Convey("then the expected error is returned", func() {
+			So(err, ShouldResemble, newGeneratorError(mockErr, outboxWriteErr))
+		})
+
+		Convey("and the outbox is written to one time", func() {
+			So(len(outboxMock.WriteCalls()), ShouldEqual, 1)
 		})
 	})
 }
@@ -155,12 +188,11 @@ func TestCantabularGenerator_Generate(t *testing.T) {
 			Version:    version,
 		}
 
-		output := make(chan kafka.BytesMessage, 1)
 		avroBytes := []byte("hello world")
 
-		producerMock := &mocks.KafkaProducerMock{
-			OutputFunc: func() chan kafka.BytesMessage {
-				return output
+		outboxMock := &mocks.OutboxMock{
+			WriteFunc: func(context.Context, []byte) error {
+				return nil
 			},
 		}
 
@@ -171,7 +203,7 @@ func TestCantabularGenerator_Generate(t *testing.T) {
 		}
 
 		gen := CantabularGenerator{
-			Producer:   producerMock,
+			Outbox:     outboxMock,
 			Marshaller: marhsallerMock,
 		}
 
@@ -184,11 +216,10 @@ func TestCantabularGenerator_Generate(t *testing.T) {
 				So(marhsallerMock.MarshalCalls()[0].S, ShouldResemble, downloads)
 			})
 
-			Convey("and producer output is called one time with the expected parameters", func() {
-				So(len(producerMock.OutputCalls()), ShouldEqual, 1)
+			Convey("and the event is written to the outbox one time", func() {
+				So(len(outboxMock.WriteCalls()), ShouldEqual, 1)
 
-				producerOut := <-output
-				So(producerOut.Value, ShouldResemble, avroBytes)
+				So(outboxMock.WriteCalls()[0].Message, ShouldResemble, avroBytes)
 			})
 		})
 	})
@@ -3,13 +3,12 @@ package download
 import (
 	"context"
 
-	kafka "github.com/ONSdigital/dp-kafka/v4"
 	"github.com/ONSdigital/log.go/v2/log"
 )
 
 // Generator kicks off a full dataset version download task
 type CMDGenerator struct {
-	Producer   KafkaProducer
+	Outbox     Outbox
 	Marshaller GenerateDownloadsEvent
 }
 
@@ -59,7 +58,9 @@ func (gen *CMDGenerator) Generate(ctx context.Context, datasetID, instanceID, ed
 		return newGeneratorError(err, avroMarshalErr)
 	}
 
-	gen.Producer.Output() <- kafka.BytesMessage{Value: avroBytes, Context: ctx}
+	if err := gen.Outbox.Write(ctx, avroBytes); err != nil {
+		return newGeneratorError(err, outboxWriteErr)
+	}
 
 	return nil
 }
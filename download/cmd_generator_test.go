@@ -5,7 +5,6 @@ import (
 	"testing"
 
 	"github.com/ONSdigital/dp-dataset-api/mocks"
-	kafka "github.com/ONSdigital/dp-kafka/v4"
 	"github.com/pkg/errors"
 	. "github.com/smartystreets/goconvey/convey"
 )
@@ -13,8 +12,8 @@ import (
 var testContext = context.Background()
 
 func TestGenerator_GenerateFullDatasetDownloadsValidationErrors(t *testing.T) {
-	producerMock := &mocks.KafkaProducerMock{
-		OutputFunc: func() chan kafka.BytesMessage {
+	outboxMock := &mocks.OutboxMock{
+		WriteFunc: func(context.Context, []byte) error {
 			return nil
 		},
 	}
@@ -26,7 +25,7 @@ func TestGenerator_GenerateFullDatasetDownloadsValidationErrors(t *testing.T) {
 	}
 
 	gen := CMDGenerator{
-		Producer:   producerMock,
+		Outbox:     outboxMock,
 		Marshaller: marhsallerMock,
 	}
 
@@ -42,8 +41,8 @@ func TestGenerator_GenerateFullDatasetDownloadsValidationErrors(t *testing.T) {
 				So(len(marhsallerMock.MarshalCalls()), ShouldEqual, 0)
 			})
 
-			Convey("And producer is never called", func() {
-				So(len(producerMock.OutputCalls()), ShouldEqual, 0)
+			Convey("And the outbox is never written to", func() {
+				So(len(outboxMock.WriteCalls()), ShouldEqual, 0)
 			})
 		})
 	})
@@ -60,8 +59,8 @@ func TestGenerator_GenerateFullDatasetDownloadsValidationErrors(t *testing.T) {
 				So(len(marhsallerMock.MarshalCalls()), ShouldEqual, 0)
 			})
 
-			Convey("And producer is never called", func() {
-				So(len(producerMock.OutputCalls()), ShouldEqual, 0)
+			Convey("And the outbox is never written to", func() {
+				So(len(outboxMock.WriteCalls()), ShouldEqual, 0)
 			})
 		})
 	})
@@ -78,8 +77,8 @@ func TestGenerator_GenerateFullDatasetDownloadsValidationErrors(t *testing.T) {
 				So(len(marhsallerMock.MarshalCalls()), ShouldEqual, 0)
 			})
 
-			Convey("And producer is never called", func() {
-				So(len(producerMock.OutputCalls()), ShouldEqual, 0)
+			Convey("And the outbox is never written to", func() {
+				So(len(outboxMock.WriteCalls()), ShouldEqual, 0)
 			})
 		})
 	})
@@ -96,8 +95,8 @@ func TestGenerator_GenerateFullDatasetDownloadsValidationErrors(t *testing.T) {
 				So(len(marhsallerMock.MarshalCalls()), ShouldEqual, 0)
 			})
 
-			Convey("And producer is never called", func() {
-				So(len(producerMock.OutputCalls()), ShouldEqual, 0)
+			Convey("And the outbox is never written to", func() {
+				So(len(outboxMock.WriteCalls()), ShouldEqual, 0)
 			})
 		})
 	})
@@ -111,8 +110,8 @@ func TestGenerator_GenerateMarshalError(t *testing.T) {
 		version := "4"
 		mockErr := errors.New("let's get schwifty")
 
-		producerMock := &mocks.KafkaProducerMock{
-			OutputFunc: func() chan kafka.BytesMessage {
+		outboxMock := &mocks.OutboxMock{
+			WriteFunc: func(context.Context, []byte) error {
 				return nil
 			},
 		}
@@ -124,7 +123,7 @@ func TestGenerator_GenerateMarshalError(t *testing.T) {
 		}
 
 		gen := CMDGenerator{
-			Producer:   producerMock,
+			Outbox:     outboxMock,
 			Marshaller: marhsallerMock,
 		}
 
@@ -138,8 +137,41 @@ func TestGenerator_GenerateMarshalError(t *testing.T) {
 			So(len(marhsallerMock.MarshalCalls()), ShouldEqual, 1)
 		})
 
-		Convey("and kafka producer is never called", func() {
-			So(len(producerMock.OutputCalls()), ShouldEqual, 0)
+		Convey("and the outbox is never written to", func() {
+			So(len(outboxMock.WriteCalls()), ShouldEqual, 0)
+		})
+	})
+}
+
+func TestGenerator_GenerateOutboxError(t *testing.T) {
+	Convey("when the outbox returns an error", t, func() {
+		mockErr := errors.New("outbox unavailable")
+
+		outboxMock := &mocks.OutboxMock{
+			WriteFunc: func(context.Context, []byte) error {
+				return mockErr
+			},
+		}
+
+		marhsallerMock := &mocks.GenerateDownloadsEventMock{
+			MarshalFunc: func(interface{}) ([]byte, error) {
+				return []byte("hello world"), nil
+			},
+		}
+
+		gen := CMDGenerator{
+			Outbox:     outboxMock,
+			Marshaller: marhsallerMock,
+		}
+
+		err := gen.Generate(testContext, "111", "222", "333", "4")
+
+		Convey("then the expected error is returned", func() {
+			So(err, ShouldResemble, newGeneratorError(mockErr, outboxWriteErr))
+		})
+
+		Convey("and the outbox is written to one time", func() {
+			So(len(outboxMock.WriteCalls()), ShouldEqual, 1)
 		})
 	})
 }
@@ -159,12 +191,11 @@ func TestGenerator_Generate(t *testing.T) {
 			Version:        version,
 		}
 
-		output := make(chan kafka.BytesMessage, 1)
 		avroBytes := []byte("hello world")
 
-		producerMock := &mocks.KafkaProducerMock{
-			OutputFunc: func() chan kafka.BytesMessage {
-				return output
+		outboxMock := &mocks.OutboxMock{
+			WriteFunc: func(context.Context, []byte) error {
+				return nil
 			},
 		}
 
@@ -175,7 +206,7 @@ func TestGenerator_Generate(t *testing.T) {
 		}
 
 		gen := CMDGenerator{
-			Producer:   producerMock,
+			Outbox:     outboxMock,
 			Marshaller: marhsallerMock,
 		}
 
@@ -188,11 +219,10 @@ func TestGenerator_Generate(t *testing.T) {
 				So(marhsallerMock.MarshalCalls()[0].S, ShouldResemble, downloads)
 			})
 
-			Convey("and producer output is called one time with the expected parameters", func() {
-				So(len(producerMock.OutputCalls()), ShouldEqual, 1)
+			Convey("and the event is written to the outbox one time", func() {
+				So(len(outboxMock.WriteCalls()), ShouldEqual, 1)
 
-				producerOut := <-output
-				So(producerOut.Value, ShouldResemble, avroBytes)
+				So(outboxMock.WriteCalls()[0].Message, ShouldResemble, avroBytes)
 			})
 		})
 	})
@@ -8,6 +8,7 @@ import (
 
 var (
 	avroMarshalErr = "error while attempting to marshal generateDownloadsEvent to avro bytes"
+	outboxWriteErr = "error while attempting to write generateDownloadsEvent to the outbox"
 
 	datasetIDEmptyErr  = newGeneratorError(nil, "failed to generate full dataset download as dataset ID was empty")
 	instanceIDEmptyErr = newGeneratorError(nil, "failed to generate full dataset download as instance ID was empty")
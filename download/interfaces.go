@@ -1,12 +1,12 @@
 package download
 
-import kafka "github.com/ONSdigital/dp-kafka/v4"
+import "context"
 
-//go:generate moq -out ../mocks/generate_downloads_mocks.go -pkg mocks . KafkaProducer GenerateDownloadsEvent
+//go:generate moq -out ../mocks/generate_downloads_mocks.go -pkg mocks -skip-ensure . Outbox GenerateDownloadsEvent
 
-// KafkaProducer sends an outbound kafka message
-type KafkaProducer interface {
-	Output() chan kafka.BytesMessage
+// Outbox stores an outbound kafka message, to be delivered once the changes that caused it are saved
+type Outbox interface {
+	Write(ctx context.Context, message []byte) error
 }
 
 // GenerateDownloadsEvent marshal the event into avro format
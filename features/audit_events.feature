@@ -0,0 +1,62 @@
+Feature: Audit events
+
+    Background:
+        Given private endpoints are enabled
+        And I have these datasets:
+            """
+            [
+                {
+                    "id": "population-estimates",
+                    "title": "Population estimates",
+                    "state": "created"
+                },
+                {
+                    "id": "population-estimates-2021"
+                }
+            ]
+            """
+
+    Scenario: Listing the audit events of a dataset, most recent first
+        Given I am an admin user
+        When I PUT "/datasets/population-estimates"
+            """
+            {
+                "title": "Mid-year population estimates"
+            }
+            """
+        And I PUT "/datasets/population-estimates-2021"
+            """
+            {
+                "title": "Census population estimates"
+            }
+            """
+        And I DELETE "/datasets/population-estimates"
+        And I GET "/audit-events?dataset_id=population-estimates"
+        Then the HTTP status code should be "200"
+        And the response should list audit events with these actions and resources:
+            | DELETE | /datasets/population-estimates |
+            | UPDATE | /datasets/population-estimates |
+
+    Scenario: Filtering the audit events by action
+        Given I am an admin user
+        When I PUT "/datasets/population-estimates"
+            """
+            {
+                "title": "Mid-year population estimates"
+            }
+            """
+        And I DELETE "/datasets/population-estimates"
+        And I GET "/audit-events?action=DELETE"
+        Then the HTTP status code should be "200"
+        And the response should list audit events with these actions and resources:
+            | DELETE | /datasets/population-estimates |
+
+    Scenario: Listing the audit events with an invalid action returns bad request
+        Given I am an admin user
+        When I GET "/audit-events?action=PUBLISH"
+        Then the HTTP status code should be "400"
+
+    Scenario: Listing the audit events requires permission
+        Given I am a publisher user
+        When I GET "/audit-events"
+        Then the HTTP status code should be "403"
@@ -51,7 +51,8 @@ Feature: Dataset Pagination
                 ],
                 "limit": 1,
                 "offset": 0,
-                "total_count": 3
+                "total_count": 3,
+                "next_cursor": "eyJpZCI6InBvcHVsYXRpb24tZXN0aW1hdGVzIn0"
             }
             """
     Scenario: Second dataset returned when offset and limit set to 1
@@ -68,10 +69,64 @@ Feature: Dataset Pagination
                 ],
                 "limit": 1,
                 "offset": 1,
+                "total_count": 3,
+                "next_cursor": "eyJpZCI6ImluY29tZSJ9"
+            }
+            """
+
+    Scenario: Second dataset returned when the cursor of the first page is provided
+        When I GET "/datasets?limit=1&cursor=eyJpZCI6InBvcHVsYXRpb24tZXN0aW1hdGVzIn0"
+        Then I should receive the following JSON response with status "200":
+            """
+            {
+                "count": 1,
+                "items": [
+                    {
+                        "id": "income",
+                        "last_updated":"0001-01-01T00:00:00Z"
+                    }
+                ],
+                "limit": 1,
+                "offset": 0,
+                "total_count": 3,
+                "next_cursor": "eyJpZCI6ImluY29tZSJ9"
+            }
+            """
+
+    Scenario: Last page returned without a next cursor
+        When I GET "/datasets?cursor=eyJpZCI6ImluY29tZSJ9"
+        Then I should receive the following JSON response with status "200":
+            """
+            {
+                "count": 1,
+                "items": [
+                    {
+                        "id": "age",
+                        "last_updated":"0001-01-01T00:00:00Z"
+                    }
+                ],
+                "limit": 20,
+                "offset": 0,
                 "total_count": 3
             }
             """
 
+    Scenario: 400 error returned when the cursor is invalid
+        When I GET "/datasets?cursor=invalid"
+        Then the HTTP status code should be "400"
+        And I should receive the following response:
+            """
+            invalid query parameter
+            """
+
+    Scenario: 400 error returned when the cursor is combined with an offset
+        When I GET "/datasets?offset=1&cursor=eyJpZCI6ImluY29tZSJ9"
+        Then the HTTP status code should be "400"
+        And I should receive the following response:
+            """
+            invalid query parameter
+            """
+
     Scenario: No datasets returned when  limit set to 0
         When I GET "/datasets?limit=0"
         Then I should receive the following JSON response with status "200":
@@ -114,6 +114,40 @@ Feature: Private Dataset API
         And the total number of audit events should be 1
         And the number of events with action "UPDATE" and resource "/datasets/population-estimates" should be 1
 
+    Scenario: Updating a dataset stores a revision of its metadata
+        Given I have these datasets:
+            """
+            [
+                {
+                    "id": "population-estimates"
+                }
+            ]
+            """
+        When I PUT "/datasets/population-estimates"
+            """
+            {
+                "title": "Population estimates"
+            }
+            """
+        And I PUT "/datasets/population-estimates"
+            """
+            {
+                "title": "Mid-year population estimates"
+            }
+            """
+        Then the dataset "population-estimates" should have revisions with these titles:
+            """
+            ["Mid-year population estimates", "Population estimates"]
+            """
+        When I GET "/datasets/population-estimates/revisions/1"
+        Then the HTTP status code should be "200"
+        When I GET "/datasets/population-estimates/revisions/3"
+        Then the HTTP status code should be "404"
+        And I should receive the following response:
+            """
+            revision not found
+            """
+
     Scenario: Successfully change title of a static dataset
         Given I have these datasets:
             """
@@ -44,6 +44,13 @@ Feature: Static Dataset Type DELETE API
                         "edition": {
                             "href": "/datasets/static-dataset-test/editions/2024",
                             "id": "2024"
+                        },
+                        "self": {
+                            "href": "/datasets/static-dataset-test/editions/2024/versions/1"
+                        },
+                        "version": {
+                            "href": "/datasets/static-dataset-test/editions/2024/versions/1",
+                            "id": "1"
                         }
                     },
                     "version": 1,
@@ -122,8 +129,8 @@ Feature: Static Dataset Type DELETE API
         And I am an admin user
         When I DELETE "/datasets/static-dataset-test"
         Then the HTTP status code should be "204"
-        And the dataset "static-dataset-test" should not exist
-        And the static version "static-version-approved" should not exist
+        And the dataset "static-dataset-test" should be marked as deleted
+        And the static version "static-version-approved" should be marked as deleted
         And the total number of audit events should be 1
         And the number of events with action "DELETE" and resource "/datasets/static-dataset-test" should be 1
 
@@ -132,8 +139,8 @@ Feature: Static Dataset Type DELETE API
         And I am a publisher user
         When I DELETE "/datasets/static-dataset-test"
         Then the HTTP status code should be "204"
-        And the dataset "static-dataset-test" should not exist
-        And the static version "static-version-approved" should not exist
+        And the dataset "static-dataset-test" should be marked as deleted
+        And the static version "static-version-approved" should be marked as deleted
         And the total number of audit events should be 1
         And the number of events with action "DELETE" and resource "/datasets/static-dataset-test" should be 1
 
@@ -145,19 +152,50 @@ Feature: Static Dataset Type DELETE API
         And the dataset "static-dataset-published" should exist
         And the static version "static-version-published" should exist
 
-    Scenario: DELETE /datasets/{id} fails due to bad files-api client response
+    Scenario: DELETE /datasets/{id} keeps the files of the static versions until they are purged
         Given private endpoints are enabled
         And I am an admin user
         When I DELETE "/datasets/static-dataset-bad-version-download-url"
-        Then the HTTP status code should be "500"
-        And the dataset "static-dataset-bad-version-download-url" should exist
-        And the static version "static-version-bad-download-url" should exist
+        Then the HTTP status code should be "204"
+        And the dataset "static-dataset-bad-version-download-url" should be marked as deleted
+        And the static version "static-version-bad-download-url" should be marked as deleted
 
     Scenario: DELETE unpublished static dataset with no versions successfully
         Given private endpoints are enabled
         And I am an admin user
         When I DELETE "/datasets/static-dataset-no-versions"
         Then the HTTP status code should be "204"
-        And the dataset "static-dataset-no-versions" should not exist
+        And the dataset "static-dataset-no-versions" should be marked as deleted
         And the total number of audit events should be 1
         And the number of events with action "DELETE" and resource "/datasets/static-dataset-no-versions" should be 1
+
+    Scenario: Deleted static dataset is hidden from reads
+        Given private endpoints are enabled
+        And I am an admin user
+        When I DELETE "/datasets/static-dataset-test"
+        And I GET "/datasets/static-dataset-test"
+        Then the HTTP status code should be "404"
+        When I GET "/datasets/static-dataset-test/editions/2024/versions"
+        Then the HTTP status code should be "404"
+
+    Scenario: Restore a deleted static dataset along with its versions
+        Given private endpoints are enabled
+        And I am an admin user
+        When I DELETE "/datasets/static-dataset-test"
+        And I POST "/datasets/static-dataset-test/restore"
+            """
+            """
+        Then the HTTP status code should be "204"
+        And the dataset "static-dataset-test" should exist
+        And the total number of audit events should be 2
+        And the number of events with action "RESTORE" and resource "/datasets/static-dataset-test" should be 1
+        When I GET "/datasets/static-dataset-test/editions/2024/versions/1"
+        Then the HTTP status code should be "200"
+
+    Scenario: Restore a dataset that is not deleted returns 404
+        Given private endpoints are enabled
+        And I am an admin user
+        When I POST "/datasets/static-dataset-test/restore"
+            """
+            """
+        Then the HTTP status code should be "404"
@@ -158,7 +158,7 @@ Feature: Static Dataset Version DELETE API
         And the "ENABLE_DELETE_STATIC_VERSION" feature flag is "true"
         When I DELETE "/datasets/static-dataset-test/editions/2024/versions/1"
         Then the HTTP status code should be "204"
-        And the static version "static-version-approved" should not exist
+        And the static version "static-version-approved" should be marked as deleted
         And the dataset "static-dataset-test" should exist
         And the dataset "static-dataset-test" should have next equal to current
         And the total number of audit events should be 1
@@ -171,7 +171,7 @@ Feature: Static Dataset Version DELETE API
         And the "ENABLE_DELETE_STATIC_VERSION" feature flag is "true"
         When I DELETE "/datasets/static-dataset-test/editions/2024/versions/1"
         Then the HTTP status code should be "204"
-        And the static version "static-version-approved" should not exist
+        And the static version "static-version-approved" should be marked as deleted
         And the dataset "static-dataset-test" should exist
         And the dataset "static-dataset-test" should have next equal to current
         And the total number of audit events should be 1
@@ -254,13 +254,36 @@ Feature: Static Dataset Version DELETE API
             method not allowed
             """
     
-    Scenario: DELETE /datasets/{id}/editions/{edition}/versions/{version} fails due to bad files-api client response
+    Scenario: DELETE /datasets/{id}/editions/{edition}/versions/{version} keeps the files of the version until it is purged
         Given private endpoints are enabled
         And I am an admin user
         And the "ENABLE_DELETE_STATIC_VERSION" feature flag is "true"
         When I DELETE "/datasets/static-dataset-bad-version-download-url/editions/January/versions/1"
-        Then the HTTP status code should be "500"
-        And I should receive the following response:
+        Then the HTTP status code should be "204"
+        And the static version "static-version-bad-download-url" should be marked as deleted
+        When I GET "/datasets/static-dataset-bad-version-download-url/editions/January/versions/1"
+        Then the HTTP status code should be "404"
+
+    Scenario: Restore a deleted static dataset version
+        Given private endpoints are enabled
+        And I am an admin user
+        And the "ENABLE_DELETE_STATIC_VERSION" feature flag is "true"
+        When I DELETE "/datasets/static-dataset-test/editions/2024/versions/1"
+        And I POST "/datasets/static-dataset-test/editions/2024/versions/1/restore"
             """
-            internal error: failed to delete file at path: /fail/to/delete.csv
             """
+        Then the HTTP status code should be "204"
+        And the static version "static-version-approved" should exist
+        And the total number of audit events should be 2
+        And the number of events with action "RESTORE" and resource "/datasets/static-dataset-test/editions/2024/versions/1" should be 1
+        When I GET "/datasets/static-dataset-test/editions/2024/versions/1"
+        Then the HTTP status code should be "200"
+
+    Scenario: Restore a static dataset version that is not deleted returns 404
+        Given private endpoints are enabled
+        And I am an admin user
+        And the "ENABLE_DELETE_STATIC_VERSION" feature flag is "true"
+        When I POST "/datasets/static-dataset-test/editions/2024/versions/1/restore"
+            """
+            """
+        Then the HTTP status code should be "404"
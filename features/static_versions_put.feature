@@ -28,6 +28,7 @@ Feature: Static Dataset Versions PUT API
                     },
                     "version": 1,
                     "release_date": "2025-01-01T09:00:00.000Z",
+                    "quality_designation": "official",
                     "state": "associated",
                     "type": "static",
                     "distributions": [
@@ -335,6 +336,89 @@ Feature: Static Dataset Versions PUT API
         And the number of events with action "UPDATE" and resource "/datasets/static-dataset-update/editions/2025/versions/1/state" should be 1
         And there are no cloudflare purge calls
 
+    Scenario: PUT state fails to approve a static version without a quality designation
+        Given private endpoints are enabled
+        And I am an admin user
+        When I PUT "/datasets/static-dataset-update/editions/2024/versions/1/state"
+            """
+            {
+                "state": "approved"
+            }
+            """
+        Then the HTTP status code should be "400"
+        And I should receive the following JSON response:
+            """
+            {
+                "errors": [
+                    {
+                        "code": "ErrTransitionGuardFailed",
+                        "description": "version has no quality_designation"
+                    }
+                ]
+            }
+            """
+        And the static version "static-version-2024" should have state "associated"
+
+    Scenario: PUT fails to approve a static version without distributions or a quality designation
+        Given I have a static dataset with version:
+            """
+            {
+                "dataset": {
+                    "id": "static-dataset-incomplete",
+                    "title": "Static Dataset without Distributions",
+                    "state": "associated",
+                    "type": "static"
+                },
+                "version": {
+                    "id": "static-version-incomplete",
+                    "edition": "2025",
+                    "edition_title": "2025 Edition",
+                    "links": {
+                        "dataset": {
+                            "id": "static-dataset-incomplete"
+                        },
+                        "edition": {
+                            "href": "/datasets/static-dataset-incomplete/editions/2025",
+                            "id": "2025"
+                        },
+                        "self": {
+                            "href": "/datasets/static-dataset-incomplete/editions/2025/versions/1"
+                        }
+                    },
+                    "version": 1,
+                    "release_date": "2025-01-01T09:00:00.000Z",
+                    "state": "associated",
+                    "type": "static"
+                }
+            }
+            """
+        And private endpoints are enabled
+        And I am an admin user
+        When I PUT "/datasets/static-dataset-incomplete/editions/2025/versions/1"
+            """
+            {
+                "state": "approved",
+                "type": "static"
+            }
+            """
+        Then the HTTP status code should be "400"
+        And I should receive the following JSON response:
+            """
+            {
+                "errors": [
+                    {
+                        "code": "ErrTransitionGuardFailed",
+                        "description": "version has no distributions"
+                    },
+                    {
+                        "code": "ErrTransitionGuardFailed",
+                        "description": "version has no quality_designation"
+                    }
+                ]
+            }
+            """
+        And the static version "static-version-incomplete" should have state "associated"
+
     Scenario: PUT state transitions from approved to published and purges URL's
         Given I have a static dataset with version:
             """
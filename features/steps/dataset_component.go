@@ -5,7 +5,6 @@ import (
 	"crypto/rsa"
 	"fmt"
 	"net/http"
-	"net/url"
 	"time"
 
 	permissionsSDK "github.com/ONSdigital/dp-permissions-api/sdk"
@@ -13,11 +12,9 @@ import (
 	"github.com/ONSdigital/dp-authorisation/v2/authorisation"
 	"github.com/ONSdigital/dp-authorisation/v2/authorisationtest"
 	componenttest "github.com/ONSdigital/dp-component-test"
-	"github.com/ONSdigital/dp-component-test/utils"
 	"github.com/ONSdigital/dp-dataset-api/cloudflare"
 	cloudflareMocks "github.com/ONSdigital/dp-dataset-api/cloudflare/mocks"
 	"github.com/ONSdigital/dp-dataset-api/config"
-	"github.com/ONSdigital/dp-dataset-api/mongo"
 	"github.com/ONSdigital/dp-dataset-api/service"
 	serviceMock "github.com/ONSdigital/dp-dataset-api/service/mock"
 	"github.com/ONSdigital/dp-dataset-api/store"
@@ -26,8 +23,6 @@ import (
 	filesAPISDKMocks "github.com/ONSdigital/dp-files-api/sdk/mocks"
 	"github.com/ONSdigital/dp-healthcheck/healthcheck"
 	kafka "github.com/ONSdigital/dp-kafka/v4"
-	"github.com/ONSdigital/dp-kafka/v4/kafkatest"
-	mongodriver "github.com/ONSdigital/dp-mongodb/v3/mongodb"
 	"github.com/ONSdigital/log.go/v2/log"
 )
 
@@ -41,7 +36,7 @@ type DatasetComponent struct {
 	apiFeature              *componenttest.APIFeature
 	svc                     *service.Service
 	errorChan               chan error
-	MongoClient             *mongo.Mongo
+	Datastore               ComponentDatastore
 	cloudflareClient        cloudflare.Clienter
 	Config                  *config.Configuration
 	HTTPServer              *http.Server
@@ -82,29 +77,11 @@ func NewDatasetComponent(mongoURI, zebedeeURL string) (*DatasetComponent, error)
 
 	c.Config.ZebedeeURL = zebedeeURL
 
-	parsedMongoURI, err := url.Parse(mongoURI)
+	c.Datastore, err = newComponentDatastore(context.Background(), c.Config, mongoURI)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse MongoDB URI: %w", err)
-	}
-
-	mongodb := &mongo.Mongo{
-		MongoConfig: config.MongoConfig{
-			MongoDriverConfig: mongodriver.MongoDriverConfig{
-				ClusterEndpoint: parsedMongoURI.Host,
-				Database:        utils.RandomDatabase(),
-				Collections:     c.Config.Collections,
-				ConnectTimeout:  c.Config.ConnectTimeout,
-				QueryTimeout:    c.Config.QueryTimeout,
-			},
-			DatasetAPIURL:  "datasets",
-			CodeListAPIURL: "",
-		}}
-
-	if err := mongodb.Init(context.Background()); err != nil {
 		return nil, err
 	}
 
-	c.MongoClient = mongodb
 	c.apiFeature = componenttest.NewAPIFeature(c.InitialiseService)
 
 	return c, nil
@@ -113,9 +90,8 @@ func NewDatasetComponent(mongoURI, zebedeeURL string) (*DatasetComponent, error)
 func (c *DatasetComponent) Reset() error {
 	ctx := context.Background()
 
-	c.MongoClient.Database = utils.RandomDatabase()
-	if err := c.MongoClient.Init(ctx); err != nil {
-		log.Warn(ctx, "error initialising MongoClient during Reset", log.Data{"err": err.Error()})
+	if err := c.Datastore.Reset(ctx); err != nil {
+		log.Warn(ctx, "error resetting datastore during Reset", log.Data{"err": err.Error()})
 	}
 
 	c.Config.EnablePrivateEndpoints = false
@@ -140,9 +116,9 @@ func (c *DatasetComponent) Close() error {
 			return fmt.Errorf("failed to close Kafka producer %w", err)
 		}
 	}
-	// Closing Mongo DB
+	// Dropping the datastore
 	if c.svc != nil && c.ServiceRunning {
-		if err := c.MongoClient.Connection.DropDatabase(ctx); err != nil {
+		if err := c.Datastore.DropDatabase(ctx); err != nil {
 			log.Warn(ctx, "error dropping database on Close", log.Data{"err": err.Error()})
 		}
 		if err := c.svc.Close(ctx); err != nil {
@@ -215,40 +191,25 @@ func (c *DatasetComponent) DoGetHTTPServer(bindAddr string, router http.Handler)
 	return c.HTTPServer
 }
 
-func (c *DatasetComponent) DoGetKafkaProducer(ctx context.Context, cfg *config.Configuration, topic string) (kafka.IProducer, error) {
-	pConfig := &kafka.ProducerConfig{
-		KafkaVersion:      &cfg.KafkaVersion,
-		Topic:             topic,
-		BrokerAddrs:       cfg.KafkaAddr,
-		MinBrokersHealthy: &cfg.KafkaProducerMinBrokersHealthy,
-	}
-
-	if cfg.KafkaSecProtocol == "TLS" {
-		pConfig.SecurityConfig = kafka.GetSecurityConfig(
-			cfg.KafkaSecCACerts,
-			cfg.KafkaSecClientCert,
-			cfg.KafkaSecClientKey,
-			cfg.KafkaSecSkipVerify,
-		)
-	}
-
-	return kafka.NewProducer(ctx, pConfig)
+func (c *DatasetComponent) DoGetOutboxProducer(ctx context.Context, cfg *config.Configuration, topic string) (service.OutboxProducer, error) {
+	return (&service.Init{}).DoGetOutboxProducer(ctx, cfg, topic)
 }
 
-func (c *DatasetComponent) DoGetMockedKafkaProducerOk(context.Context, *config.Configuration, string) (kafka.IProducer, error) {
-	return &kafkatest.IProducerMock{
-		ChannelsFunc: func() *kafka.ProducerChannels {
-			return &kafka.ProducerChannels{}
+func (c *DatasetComponent) DoGetMockedOutboxProducerOk(context.Context, *config.Configuration, string) (service.OutboxProducer, error) {
+	// messages delivered by the outbox relay are discarded
+	return &serviceMock.OutboxProducerMock{
+		SendMessageFunc: func(context.Context, []byte) error {
+			return nil
 		},
-		CloseFunc: funcClose,
-		LogErrorsFunc: func(context.Context) {
-			// Do nothing
+		CheckerFunc: func(context.Context, *healthcheck.CheckState) error {
+			return nil
 		},
+		CloseFunc: funcClose,
 	}, nil
 }
 
 func (c *DatasetComponent) DoGetMongoDB(context.Context, config.MongoConfig) (store.MongoDB, error) {
-	return c.MongoClient, nil
+	return c.Datastore, nil
 }
 
 func (c *DatasetComponent) DoGetGraphDBOk(context.Context) (store.GraphDB, service.Closer, error) {
@@ -301,7 +262,7 @@ func (c *DatasetComponent) setInitialiserMock() {
 		DoGetGraphDBFunc:                 c.DoGetGraphDBOk,
 		DoGetFilesAPIClientFunc:          c.DoGetFilesAPIClientOk,
 		DoGetCloudflareClientFunc:        c.DoGetCloudflareClientOk,
-		DoGetKafkaProducerFunc:           c.DoGetMockedKafkaProducerOk,
+		DoGetOutboxProducerFunc:          c.DoGetMockedOutboxProducerOk,
 		DoGetHealthCheckFunc:             c.DoGetHealthcheckOk,
 		DoGetHTTPServerFunc:              c.DoGetHTTPServer,
 		DoGetAuthorisationMiddlewareFunc: c.DoGetAuthorisationMiddleware,
@@ -313,7 +274,7 @@ func (c *DatasetComponent) setInitialiserRealKafka() {
 		DoGetGraphDBFunc:                 c.DoGetGraphDBOk,
 		DoGetFilesAPIClientFunc:          c.DoGetFilesAPIClientOk,
 		DoGetCloudflareClientFunc:        c.DoGetCloudflareClientOk,
-		DoGetKafkaProducerFunc:           c.DoGetKafkaProducer,
+		DoGetOutboxProducerFunc:          c.DoGetOutboxProducer,
 		DoGetHealthCheckFunc:             c.DoGetHealthcheckOk,
 		DoGetHTTPServerFunc:              c.DoGetHTTPServer,
 		DoGetAuthorisationMiddlewareFunc: c.DoGetAuthorisationMiddleware,
@@ -475,6 +436,13 @@ func getPermissionsDataset() *permissionsSDK.Bundle {
 				},
 			},
 		},
+		"dataset-audit-events:read": {
+			"groups/role-admin": {
+				{
+					ID: "1",
+				},
+			},
+		},
 	}
 }
 
@@ -0,0 +1,119 @@
+package steps
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/ONSdigital/dp-component-test/utils"
+	"github.com/ONSdigital/dp-dataset-api/config"
+	"github.com/ONSdigital/dp-dataset-api/mongo"
+	"github.com/ONSdigital/dp-dataset-api/store"
+	"github.com/ONSdigital/dp-dataset-api/store/memory"
+	mongodriver "github.com/ONSdigital/dp-mongodb/v3/mongodb"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// ComponentDatastore is the datastore used by the component tests, which allows the steps to seed and
+// inspect documents directly, regardless of the underlying implementation (mongoDB or in-memory)
+type ComponentDatastore interface {
+	store.MongoDB
+	ActualCollectionName(collection string) string
+	FindOne(ctx context.Context, collection string, filter bson.M, result interface{}) error
+	Count(ctx context.Context, collection string, filter bson.M) (int, error)
+	UpsertByID(ctx context.Context, collection string, id interface{}, update bson.M) error
+	UpdateByID(ctx context.Context, collection string, id interface{}, update bson.M) error
+	DropDatabase(ctx context.Context) error
+	Reset(ctx context.Context) error
+}
+
+// newComponentDatastore returns the datastore configured by DATASTORE; mongoURI is ignored for the in-memory store
+func newComponentDatastore(ctx context.Context, cfg *config.Configuration, mongoURI string) (ComponentDatastore, error) {
+	mongoConfig := config.MongoConfig{
+		MongoDriverConfig: mongodriver.MongoDriverConfig{
+			Database:       utils.RandomDatabase(),
+			Collections:    cfg.Collections,
+			ConnectTimeout: cfg.ConnectTimeout,
+			QueryTimeout:   cfg.QueryTimeout,
+		},
+		DatasetAPIURL:  "datasets",
+		CodeListAPIURL: "",
+		Datastore:      cfg.Datastore,
+	}
+
+	if cfg.Datastore == config.MemoryDatastore {
+		return &memoryDatastore{Store: memory.New(mongoConfig)}, nil
+	}
+
+	parsedMongoURI, err := url.Parse(mongoURI)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse MongoDB URI: %w", err)
+	}
+	mongoConfig.ClusterEndpoint = parsedMongoURI.Host
+
+	mongodb := &mongo.Mongo{MongoConfig: mongoConfig}
+	if err := mongodb.Init(ctx); err != nil {
+		return nil, err
+	}
+
+	return &mongoDatastore{Mongo: mongodb}, nil
+}
+
+// mongoDatastore is a ComponentDatastore backed by a real mongoDB
+type mongoDatastore struct {
+	*mongo.Mongo
+}
+
+func (m *mongoDatastore) FindOne(ctx context.Context, collection string, filter bson.M, result interface{}) error {
+	return m.Connection.Collection(collection).FindOne(ctx, filter, result)
+}
+
+func (m *mongoDatastore) Count(ctx context.Context, collection string, filter bson.M) (int, error) {
+	return m.Connection.Collection(collection).Count(ctx, filter)
+}
+
+func (m *mongoDatastore) UpsertByID(ctx context.Context, collection string, id interface{}, update bson.M) error {
+	_, err := m.Connection.Collection(collection).UpsertById(ctx, id, update)
+	return err
+}
+
+func (m *mongoDatastore) UpdateByID(ctx context.Context, collection string, id interface{}, update bson.M) error {
+	_, err := m.Connection.Collection(collection).UpdateById(ctx, id, update)
+	return err
+}
+
+func (m *mongoDatastore) DropDatabase(ctx context.Context) error {
+	return m.Connection.DropDatabase(ctx)
+}
+
+// Reset connects to a new random database, so that scenarios do not share any data
+func (m *mongoDatastore) Reset(ctx context.Context) error {
+	m.Database = utils.RandomDatabase()
+	return m.Init(ctx)
+}
+
+// memoryDatastore is a ComponentDatastore backed by the in-memory store
+type memoryDatastore struct {
+	*memory.Store
+}
+
+func (m *memoryDatastore) FindOne(ctx context.Context, collection string, filter bson.M, result interface{}) error {
+	return m.Collection(collection).FindOne(ctx, filter, result)
+}
+
+func (m *memoryDatastore) Count(ctx context.Context, collection string, filter bson.M) (int, error) {
+	return m.Collection(collection).Count(ctx, filter)
+}
+
+func (m *memoryDatastore) UpsertByID(ctx context.Context, collection string, id interface{}, update bson.M) error {
+	return m.Collection(collection).UpsertById(ctx, id, update)
+}
+
+func (m *memoryDatastore) UpdateByID(ctx context.Context, collection string, id interface{}, update bson.M) error {
+	return m.Collection(collection).UpdateById(ctx, id, update)
+}
+
+// Reset discards all the documents, so that scenarios do not share any data
+func (m *memoryDatastore) Reset(ctx context.Context) error {
+	return m.DropDatabase(ctx)
+}
@@ -62,6 +62,10 @@ func (c *DatasetComponent) RegisterSteps(ctx *godog.ScenarioContext) {
 	ctx.Step(`^the dataset "([^"]*)" should not exist$`, c.datasetShouldNotExist)
 	ctx.Step(`^the static version "([^"]*)" should exist$`, c.staticVersionShouldExist)
 	ctx.Step(`^the static version "([^"]*)" should not exist$`, c.staticVersionShouldNotExist)
+	ctx.Step(`^the dataset "([^"]*)" should be marked as deleted$`, c.datasetShouldBeMarkedAsDeleted)
+	ctx.Step(`^the static version "([^"]*)" should be marked as deleted$`, c.staticVersionShouldBeMarkedAsDeleted)
+	ctx.Step(`^the static version "([^"]*)" should have state "([^"]*)"$`, c.staticVersionShouldHaveState)
+	ctx.Step(`^the dataset "([^"]*)" should have revisions with these titles:$`, c.datasetShouldHaveRevisionsWithTitles)
 	ctx.Step(`^the response header "([^"]*)" should not be empty$`, c.theResponseHeaderShouldNotBeEmpty)
 	ctx.Step(`^the dataset "([^"]*)" should have next equal to current$`, c.theDatasetShouldHaveNextEqualToCurrent)
 	ctx.Step(`^the "([^"]*)" feature flag is "([^"]*)"$`, c.theFeatureFlagIs)
@@ -78,6 +82,7 @@ func (c *DatasetComponent) RegisterSteps(ctx *godog.ScenarioContext) {
 	ctx.Step(`^I don't have viewer access to the dataset edition "([^"]*)"$`, c.viewerDoesNotHavePreviewAccessToDatasetEdition)
 	ctx.Step(`the total number of audit events should be (\d+)`, c.theTotalNumberOfAuditEventsShouldBe)
 	ctx.Step(`the number of events with action "([^"]*)" and resource "([^"]*)" should be (\d+)`, c.theNumberOfEventsWithActionAndResourceShouldBe)
+	ctx.Step(`^the response should list audit events with these actions and resources:$`, c.theResponseShouldListAuditEvents)
 }
 
 func (c *DatasetComponent) viewerHasPreviewAccessToDataset(datasetID string) error {
@@ -202,7 +207,7 @@ func (c *DatasetComponent) theFeatureFlagIs(flagName, status string) error {
 }
 
 func (c *DatasetComponent) thereAreNoDatasets() error {
-	return c.MongoClient.Connection.DropDatabase(context.Background())
+	return c.Datastore.DropDatabase(context.Background())
 }
 
 func (c *DatasetComponent) privateEndpointsAreEnabled() error {
@@ -222,9 +227,9 @@ func (c *DatasetComponent) theDocumentInTheDatabaseForIDShouldBe(documentID stri
 		return err
 	}
 
-	collectionName := c.MongoClient.ActualCollectionName(config.DatasetsCollection)
+	collectionName := c.Datastore.ActualCollectionName(config.DatasetsCollection)
 	var link models.DatasetUpdate
-	if err := c.MongoClient.Connection.Collection(collectionName).FindOne(context.Background(), bson.M{"_id": documentID}, &link); err != nil {
+	if err := c.Datastore.FindOne(context.Background(), collectionName, bson.M{"_id": documentID}, &link); err != nil {
 		return err
 	}
 
@@ -248,10 +253,10 @@ func (c *DatasetComponent) theInstanceInTheDatabaseForIDShouldBe(id string, body
 		return fmt.Errorf("failed to unmarshal body: %w", err)
 	}
 
-	collectionName := c.MongoClient.ActualCollectionName(config.InstanceCollection)
+	collectionName := c.Datastore.ActualCollectionName(config.InstanceCollection)
 	var got models.Instance
 
-	if err := c.MongoClient.Connection.Collection(collectionName).FindOne(context.Background(), bson.M{"_id": id}, &got); err != nil {
+	if err := c.Datastore.FindOne(context.Background(), collectionName, bson.M{"_id": id}, &got); err != nil {
 		return fmt.Errorf("failed to get instance from collection: %w", err)
 	}
 
@@ -267,10 +272,10 @@ func (c *DatasetComponent) theVersionInTheDatabaseForIDShouldBe(id string, body
 		return fmt.Errorf("failed to unmarshal body: %w", err)
 	}
 
-	collectionName := c.MongoClient.ActualCollectionName(config.InstanceCollection)
+	collectionName := c.Datastore.ActualCollectionName(config.InstanceCollection)
 	var got models.Version
 
-	if err := c.MongoClient.Connection.Collection(collectionName).FindOne(context.Background(), bson.M{"_id": id}, &got); err != nil {
+	if err := c.Datastore.FindOne(context.Background(), collectionName, bson.M{"_id": id}, &got); err != nil {
 		return fmt.Errorf("failed to get version from collection: %w", err)
 	}
 
@@ -461,7 +466,7 @@ func (c *DatasetComponent) iHaveTheseEditions(editionsJSON *godog.DocString) err
 			Current: editionDoc,
 		}
 
-		editionsCollection := c.MongoClient.ActualCollectionName(config.EditionsCollection)
+		editionsCollection := c.Datastore.ActualCollectionName(config.EditionsCollection)
 		err = c.putDocumentInDatabase(editionUp, editionID, editionsCollection, timeOffset)
 
 		if err != nil {
@@ -489,7 +494,7 @@ func (c *DatasetComponent) iHaveTheseDatasets(datasetsJSON *godog.DocString) err
 			Current: datasetDoc,
 		}
 
-		datasetsCollection := c.MongoClient.ActualCollectionName(config.DatasetsCollection)
+		datasetsCollection := c.Datastore.ActualCollectionName(config.DatasetsCollection)
 		if err := c.putDocumentInDatabase(datasetUp, datasetID, datasetsCollection, timeOffset); err != nil {
 			return err
 		}
@@ -520,7 +525,7 @@ func (c *DatasetComponent) iHaveTheseConditionalDatasets(status string, datasets
 			datasetUp.Next = datasetDoc
 		}
 
-		datasetsCollection := c.MongoClient.ActualCollectionName(config.DatasetsCollection)
+		datasetsCollection := c.Datastore.ActualCollectionName(config.DatasetsCollection)
 		if err := c.putDocumentInDatabase(datasetUp, datasetID, datasetsCollection, timeOffset); err != nil {
 			return fmt.Errorf("failed to insert to mongo: %w", err)
 		}
@@ -549,7 +554,7 @@ func (c *DatasetComponent) iHaveTheseVersions(versionsJSON *godog.DocString) err
 		// Set the etag (json omitted)
 		version.ETag = "etag-" + version.ID
 
-		instanceCollection := c.MongoClient.ActualCollectionName(config.InstanceCollection)
+		instanceCollection := c.Datastore.ActualCollectionName(config.InstanceCollection)
 		if err := c.putDocumentInDatabase(version, versionID, instanceCollection, timeOffset); err != nil {
 			return err
 		}
@@ -566,7 +571,7 @@ func (c *DatasetComponent) iHaveTheseStaticVersions(versionsJSON *godog.DocStrin
 		return fmt.Errorf("failed to unmarshal versionsJSON: %w", err)
 	}
 
-	versionsCollection := c.MongoClient.ActualCollectionName(config.VersionsCollection)
+	versionsCollection := c.Datastore.ActualCollectionName(config.VersionsCollection)
 
 	for timeOffset := range versions {
 		version := &versions[timeOffset]
@@ -599,7 +604,7 @@ func (c *DatasetComponent) theseVersionsNeedToBePublished(idsJSON *godog.DocStri
 		verDoc := make(bson.M)
 		verDoc["links.version.id"] = v.VersionNumber
 
-		instanceCollection := c.MongoClient.ActualCollectionName(config.InstanceCollection)
+		instanceCollection := c.Datastore.ActualCollectionName(config.InstanceCollection)
 		if err := c.updateDocumentInDatabase(verDoc, v.VersionID, instanceCollection, i); err != nil {
 			return fmt.Errorf("failed to update database: %w", err)
 		}
@@ -620,7 +625,7 @@ func (c *DatasetComponent) iHaveTheseDimensions(dimensionsJSON *godog.DocString)
 		dimension := &dimensions[timeOffset]
 		dimensionID := dimension.Option
 
-		dimensionOptionsCollection := c.MongoClient.ActualCollectionName(config.DimensionOptionsCollection)
+		dimensionOptionsCollection := c.Datastore.ActualCollectionName(config.DimensionOptionsCollection)
 		if err := c.putDocumentInDatabase(dimension, dimensionID, dimensionOptionsCollection, timeOffset); err != nil {
 			return err
 		}
@@ -641,7 +646,7 @@ func (c *DatasetComponent) iHaveTheseInstances(instancesJSON *godog.DocString) e
 		instance := &instances[timeOffset]
 		instanceID := instance.InstanceID
 
-		instanceCollection := c.MongoClient.ActualCollectionName(config.InstanceCollection)
+		instanceCollection := c.Datastore.ActualCollectionName(config.InstanceCollection)
 		if err := c.putDocumentInDatabase(instance, instanceID, instanceCollection, timeOffset); err != nil {
 			return err
 		}
@@ -655,7 +660,7 @@ func (c *DatasetComponent) updateDocumentInDatabase(document bson.M, id, collect
 		"$set": document,
 	}
 
-	_, err := c.MongoClient.Connection.Collection(collectionName).UpdateById(context.Background(), id, update)
+	err := c.Datastore.UpdateByID(context.Background(), collectionName, id, update)
 	if err != nil {
 		return fmt.Errorf("failed to update document in DB: %w", err)
 	}
@@ -670,7 +675,7 @@ func (c *DatasetComponent) putDocumentInDatabase(document interface{}, id, colle
 		},
 	}
 
-	_, err := c.MongoClient.Connection.Collection(collectionName).UpsertById(context.Background(), id, update)
+	err := c.Datastore.UpsertByID(context.Background(), collectionName, id, update)
 
 	if err != nil {
 		return err
@@ -695,7 +700,7 @@ func (c *DatasetComponent) iHaveStaticDatasetWithVersion(jsonData *godog.DocStri
 		Next:    &data.Dataset,
 		Current: &data.Dataset,
 	}
-	datasetsCollection := c.MongoClient.ActualCollectionName(config.DatasetsCollection)
+	datasetsCollection := c.Datastore.ActualCollectionName(config.DatasetsCollection)
 	if err := c.putDocumentInDatabase(datasetUp, datasetID, datasetsCollection, 0); err != nil {
 		return fmt.Errorf("failed to insert static dataset: %w", err)
 	}
@@ -711,7 +716,7 @@ func (c *DatasetComponent) iHaveStaticDatasetWithVersion(jsonData *godog.DocStri
 
 	data.Version.ETag = "etag-" + versionID
 
-	versionsCollection := c.MongoClient.ActualCollectionName(config.VersionsCollection)
+	versionsCollection := c.Datastore.ActualCollectionName(config.VersionsCollection)
 	if err := c.putDocumentInDatabase(data.Version, versionID, versionsCollection, 0); err != nil {
 		return fmt.Errorf("failed to insert static version: %w", err)
 	}
@@ -721,10 +726,10 @@ func (c *DatasetComponent) iHaveStaticDatasetWithVersion(jsonData *godog.DocStri
 
 // checkDocumentExistence checks for the existence of a document by ID in a given collection.
 func (c *DatasetComponent) checkDocumentExistence(collectionName, id string, shouldExist bool) error {
-	collection := c.MongoClient.ActualCollectionName(collectionName)
+	collection := c.Datastore.ActualCollectionName(collectionName)
 	var result map[string]interface{}
 
-	err := c.MongoClient.Connection.Collection(collection).FindOne(context.Background(), bson.M{"_id": id}, &result)
+	err := c.Datastore.FindOne(context.Background(), collection, bson.M{"_id": id}, &result)
 
 	if shouldExist {
 		if err != nil {
@@ -767,11 +772,74 @@ func (c *DatasetComponent) staticVersionShouldNotExist(versionID string) error {
 	return c.checkDocumentExistence(config.VersionsCollection, versionID, false)
 }
 
+// checkDocumentMarkedAsDeleted checks that the document is kept in the collection, marked as deleted so that it can be restored
+func (c *DatasetComponent) checkDocumentMarkedAsDeleted(collectionName, id string) error {
+	collection := c.Datastore.ActualCollectionName(collectionName)
+	var deletion models.Deletion
+
+	if err := c.Datastore.FindOne(context.Background(), collection, bson.M{"_id": id}, &deletion); err != nil {
+		return fmt.Errorf("expected deleted document with ID '%s' in collection '%s' but it was not found: %w", id, collection, err)
+	}
+
+	if deletion.DeletedAt.IsZero() || deletion.DeletedBy == "" {
+		return fmt.Errorf("expected document with ID '%s' in collection '%s' to be marked as deleted", id, collection)
+	}
+	return nil
+}
+
+// datasetShouldBeMarkedAsDeleted checks the dataset is marked as deleted in the datasets collection
+func (c *DatasetComponent) datasetShouldBeMarkedAsDeleted(datasetID string) error {
+	return c.checkDocumentMarkedAsDeleted(config.DatasetsCollection, datasetID)
+}
+
+// staticVersionShouldBeMarkedAsDeleted checks the version document is marked as deleted in the versions collection
+func (c *DatasetComponent) staticVersionShouldBeMarkedAsDeleted(versionID string) error {
+	return c.checkDocumentMarkedAsDeleted(config.VersionsCollection, versionID)
+}
+
+// staticVersionShouldHaveState checks the state of the version document in the versions collection
+func (c *DatasetComponent) staticVersionShouldHaveState(versionID, state string) error {
+	collection := c.Datastore.ActualCollectionName(config.VersionsCollection)
+	var version models.Version
+
+	if err := c.Datastore.FindOne(context.Background(), collection, bson.M{"_id": versionID}, &version); err != nil {
+		return fmt.Errorf("expected version with ID '%s' in collection '%s' but it was not found: %w", versionID, collection, err)
+	}
+
+	if version.State != state {
+		return fmt.Errorf("expected version with ID '%s' to have state '%s' but it has state '%s'", versionID, state, version.State)
+	}
+	return nil
+}
+
+// datasetShouldHaveRevisionsWithTitles checks the titles of the revisions of the dataset, most recent first
+func (c *DatasetComponent) datasetShouldHaveRevisionsWithTitles(datasetID string, titlesJSON *godog.DocString) error {
+	var expected []string
+	if err := json.Unmarshal([]byte(titlesJSON.Content), &expected); err != nil {
+		return err
+	}
+
+	revisions, _, err := c.Datastore.GetDatasetRevisions(context.Background(), datasetID, 0, len(expected)+1)
+	if err != nil {
+		return err
+	}
+
+	titles := make([]string, 0, len(revisions))
+	for _, revision := range revisions {
+		titles = append(titles, revision.Dataset.Title)
+	}
+
+	if diff := cmp.Diff(expected, titles); diff != "" {
+		return fmt.Errorf("unexpected revisions of dataset %s:\n%s", datasetID, diff)
+	}
+	return nil
+}
+
 func (c *DatasetComponent) theDatasetShouldHaveNextEqualToCurrent(datasetID string) error {
-	collectionName := c.MongoClient.ActualCollectionName(config.DatasetsCollection)
+	collectionName := c.Datastore.ActualCollectionName(config.DatasetsCollection)
 	var dataset models.DatasetUpdate
 
-	if err := c.MongoClient.Connection.Collection(collectionName).FindOne(context.Background(), bson.M{"_id": datasetID}, &dataset); err != nil {
+	if err := c.Datastore.FindOne(context.Background(), collectionName, bson.M{"_id": datasetID}, &dataset); err != nil {
 		return err
 	}
 
@@ -836,9 +904,9 @@ func (c *DatasetComponent) cloudflareIsEnabled() error {
 
 func (c *DatasetComponent) theTotalNumberOfAuditEventsShouldBe(expectedCount int) error {
 	ctx := context.Background()
-	collectionName := c.MongoClient.ActualCollectionName(config.DatasetEventsCollection)
+	collectionName := c.Datastore.ActualCollectionName(config.DatasetEventsCollection)
 
-	count, err := c.MongoClient.Connection.Collection(collectionName).Count(ctx, bson.M{})
+	count, err := c.Datastore.Count(ctx, collectionName, bson.M{})
 	if err != nil {
 		return fmt.Errorf("failed to count events: %w", err)
 	}
@@ -851,14 +919,14 @@ func (c *DatasetComponent) theTotalNumberOfAuditEventsShouldBe(expectedCount int
 
 func (c *DatasetComponent) theNumberOfEventsWithActionAndResourceShouldBe(action, resource string, expectedCount int) error {
 	ctx := context.Background()
-	collectionName := c.MongoClient.ActualCollectionName(config.DatasetEventsCollection)
+	collectionName := c.Datastore.ActualCollectionName(config.DatasetEventsCollection)
 
 	filter := bson.M{
 		"action":   action,
 		"resource": resource,
 	}
 
-	count, err := c.MongoClient.Connection.Collection(collectionName).Count(ctx, filter)
+	count, err := c.Datastore.Count(ctx, collectionName, filter)
 	if err != nil {
 		return fmt.Errorf("failed to count events: %w", err)
 	}
@@ -868,3 +936,31 @@ func (c *DatasetComponent) theNumberOfEventsWithActionAndResourceShouldBe(action
 	}
 	return nil
 }
+
+func (c *DatasetComponent) theResponseShouldListAuditEvents(table *godog.Table) error {
+	var page struct {
+		Items      []*models.AuditEvent `json:"items"`
+		TotalCount int                  `json:"total_count"`
+	}
+	if err := json.NewDecoder(c.apiFeature.HTTPResponse.Body).Decode(&page); err != nil {
+		return fmt.Errorf("failed to decode audit events response: %w", err)
+	}
+
+	expected := make([]string, 0, len(table.Rows))
+	for _, row := range table.Rows {
+		expected = append(expected, row.Cells[0].Value+" "+row.Cells[1].Value)
+	}
+
+	actual := make([]string, 0, len(page.Items))
+	for _, event := range page.Items {
+		actual = append(actual, string(event.Action)+" "+event.Resource)
+	}
+
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		return fmt.Errorf("unexpected audit events:\n%s", diff)
+	}
+	if page.TotalCount != len(expected) {
+		return fmt.Errorf("expected a total count of %d audit events, but got %d", len(expected), page.TotalCount)
+	}
+	return nil
+}
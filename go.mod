@@ -18,6 +18,7 @@ require (
 	github.com/ONSdigital/dp-otel-go v0.0.8
 	github.com/ONSdigital/dp-permissions-api v1.10.1
 	github.com/ONSdigital/log.go/v2 v2.5.2
+	github.com/Shopify/sarama v1.38.1
 	github.com/cloudflare/cloudflare-go/v6 v6.4.0
 	github.com/cucumber/godog v0.15.1
 	github.com/golang-jwt/jwt/v4 v4.5.2
@@ -47,7 +48,6 @@ require (
 	github.com/ONSdigital/golang-neo4j-bolt-driver v0.0.0-20241121114036-9f4b82bb9d37 // indirect
 	github.com/ONSdigital/graphson v0.3.0 // indirect
 	github.com/ONSdigital/gremgo-neptune v1.1.0 // indirect
-	github.com/Shopify/sarama v1.38.1 // indirect
 	github.com/aws/aws-sdk-go-v2 v1.39.4 // indirect
 	github.com/aws/aws-sdk-go-v2/aws/protocol/eventstream v1.7.2 // indirect
 	github.com/aws/aws-sdk-go-v2/internal/configsources v1.4.11 // indirect
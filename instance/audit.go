@@ -0,0 +1,39 @@
+package instance
+
+import (
+	"context"
+
+	"github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// recordAuditEvent records an instance audit event for the requester and logs its outcome for protective monitoring
+func (s *Store) recordAuditEvent(ctx context.Context, requestedBy models.RequestedBy, action models.Action, resource string, previous, instance *models.Instance) error {
+	logData := log.Data{
+		"action":       action,
+		"endpoint":     resource,
+		"requested_by": requestedBy.ID,
+	}
+
+	if err := s.AuditService.RecordInstanceAuditEvent(ctx, requestedBy, action, resource, auditedDatasetID(previous, instance), previous, instance); err != nil {
+		logData["outcome"] = "failure"
+		logData["reason"] = err.Error()
+		log.Info(ctx, "failed to create instance audit event", log.Classification(log.ProtectiveMonitoring), logData)
+		return err
+	}
+
+	logData["outcome"] = "success"
+	log.Info(ctx, "successfully created instance audit event", log.Classification(log.ProtectiveMonitoring), logData)
+	return nil
+}
+
+// auditedDatasetID returns the ID of the dataset of an audited instance, from its previous state if it is known, as an
+// update does not have to provide the links of the instance
+func auditedDatasetID(previous, instance *models.Instance) string {
+	for _, i := range []*models.Instance{previous, instance} {
+		if i != nil && i.Links != nil && i.Links.Dataset != nil && i.Links.Dataset.ID != "" {
+			return i.Links.Dataset.ID
+		}
+	}
+	return ""
+}
@@ -27,6 +27,13 @@ func (s *Store) UpdateDimension(w http.ResponseWriter, r *http.Request) {
 
 	log.Info(ctx, "update instance dimension: update instance dimension", logData)
 
+	requestedBy, err := s.RequestedBy(r)
+	if err != nil {
+		log.Error(ctx, "update instance dimension: failed to get the requester of the request", err, logData)
+		handleInstanceErr(ctx, err, w, logData)
+		return
+	}
+
 	// Acquire instance lock to make sure that this call does not interfere with any other 'write' call against the same instance
 	lockID, err := s.AcquireInstanceLock(ctx, instanceID)
 	if err != nil {
@@ -66,6 +73,10 @@ func (s *Store) UpdateDimension(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// the dimensions are updated in place, so they are copied to keep the previous state of the instance
+	previousInstance := *instance
+	previousInstance.Dimensions = append([]models.Dimension(nil), instance.Dimensions...)
+
 	// Update instance-dimension
 	notFound := true
 	for i := range instance.Dimensions {
@@ -96,6 +107,12 @@ func (s *Store) UpdateDimension(w http.ResponseWriter, r *http.Request) {
 		UniqueTimestamp: instance.UniqueTimestamp,
 	}
 
+	if err = s.recordAuditEvent(ctx, requestedBy, models.ActionUpdate, "/instances/"+instanceID+"/dimensions/"+dimension, &previousInstance, instance); err != nil {
+		log.Error(ctx, "update instance dimension: failed to record instance audit event", err, logData)
+		handleInstanceErr(ctx, err, w, logData)
+		return
+	}
+
 	// Update instance
 	newETag, err := s.UpdateInstance(ctx, instance, instanceUpdate, eTag)
 	if err != nil {
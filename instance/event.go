@@ -38,6 +38,13 @@ func (s *Store) AddEvent(w http.ResponseWriter, r *http.Request) {
 	eTag := getIfMatch(r)
 	data := log.Data{"instance_id": instanceID, "action": AddInstanceEventAction}
 
+	requestedBy, err := s.RequestedBy(r)
+	if err != nil {
+		log.Error(ctx, "add instance event: failed to get the requester of the request", err, data)
+		handleInstanceErr(ctx, err, w, data)
+		return
+	}
+
 	event, err := unmarshalEvent(r.Body)
 	if err != nil {
 		log.Error(ctx, "add instance event: failed to unmarshal request body", err, data)
@@ -65,6 +72,12 @@ func (s *Store) AddEvent(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err = s.recordAuditEvent(ctx, requestedBy, models.ActionCreate, "/instances/"+instanceID+"/events", nil, instance); err != nil {
+		log.Error(ctx, "add instance event: failed to record instance audit event", err, data)
+		handleInstanceErr(ctx, err, w, data)
+		return
+	}
+
 	newETag, err := s.AddEventToInstance(ctx, instance, event, eTag)
 	if err != nil {
 		log.Error(ctx, "add instance event: failed to add event to instance in datastore", err, data)
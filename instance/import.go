@@ -27,6 +27,13 @@ func (s *Store) UpdateObservations(w http.ResponseWriter, r *http.Request) {
 	eTag := getIfMatch(r)
 	logData := log.Data{"instance_id": instanceID, "inserted_observations": insert}
 
+	requestedBy, err := s.RequestedBy(r)
+	if err != nil {
+		log.Error(ctx, "update imported observations: failed to get the requester of the request", err, logData)
+		handleInstanceErr(ctx, err, w, logData)
+		return
+	}
+
 	observations, err := strconv.ParseInt(insert, 10, 64)
 	if err != nil {
 		log.Error(ctx, "update imported observations: failed to parse inserted_observations string to int", err, logData)
@@ -48,6 +55,12 @@ func (s *Store) UpdateObservations(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err = s.recordAuditEvent(ctx, requestedBy, models.ActionUpdate, "/instances/"+instanceID+"/inserted_observations/"+insert, nil, instance); err != nil {
+		log.Error(ctx, "update imported observations: failed to record instance audit event", err, logData)
+		handleInstanceErr(ctx, err, w, logData)
+		return
+	}
+
 	newETag, err := s.UpdateObservationInserted(ctx, instance, observations, eTag)
 	if err != nil {
 		log.Error(ctx, "update imported observations: store.UpdateObservationInserted returned an error", err, logData)
@@ -78,6 +91,13 @@ func (s *Store) UpdateImportTask(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, updateErr.Error(), updateErr.status)
 	}
 
+	requestedBy, err := s.RequestedBy(r)
+	if err != nil {
+		log.Error(ctx, "failed to get the requester of the request", err, logData)
+		handleError(&taskError{err, http.StatusInternalServerError})
+		return
+	}
+
 	tasks, err := unmarshalImportTasks(r.Body)
 	if err != nil {
 		log.Error(ctx, "failed to unmarshal request body to UpdateImportTasks model", err, logData)
@@ -103,6 +123,7 @@ func (s *Store) UpdateImportTask(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// the tasks are all validated before any of them is updated, so that the audit event is recorded before the updates
 	validationErrs := make([]error, 0)
 	var hasImportTasks bool
 
@@ -111,13 +132,6 @@ func (s *Store) UpdateImportTask(w http.ResponseWriter, r *http.Request) {
 		if tasks.ImportObservations.State != "" {
 			if tasks.ImportObservations.State != models.CompletedState {
 				validationErrs = append(validationErrs, fmt.Errorf("bad request - invalid task state value for import observations: %v", tasks.ImportObservations.State))
-			} else {
-				eTag, err = s.UpdateImportObservationsTaskState(ctx, instance, tasks.ImportObservations.State, eTag)
-				if err != nil {
-					log.Error(ctx, "failed to update import observations task state", err, logData)
-					handleError(&taskError{err, http.StatusInternalServerError})
-					return
-				}
 			}
 		} else {
 			validationErrs = append(validationErrs, errors.New("bad request - invalid import observation task, must include state"))
@@ -126,56 +140,26 @@ func (s *Store) UpdateImportTask(w http.ResponseWriter, r *http.Request) {
 
 	if tasks.BuildHierarchyTasks != nil {
 		hasImportTasks = true
-		var hasHierarchyImportTask bool
+		if len(tasks.BuildHierarchyTasks) == 0 {
+			validationErrs = append(validationErrs, errors.New("bad request - missing hierarchy task"))
+		}
 		for _, task := range tasks.BuildHierarchyTasks {
-			hasHierarchyImportTask = true
 			if err := models.ValidateImportTask(task.GenericTaskDetails); err != nil {
 				validationErrs = append(validationErrs, err)
-			} else {
-				eTag, err = s.UpdateBuildHierarchyTaskState(ctx, instance, task.DimensionName, task.State, eTag)
-				if err != nil {
-					if err.Error() == errs.ErrNotFound.Error() {
-						notFoundErr := task.DimensionName + " hierarchy import task does not exist"
-						log.Error(ctx, notFoundErr, err, logData)
-						handleError(&taskError{errors.New(notFoundErr), http.StatusNotFound})
-						return
-					}
-					log.Error(ctx, "failed to update build hierarchy task state", err, logData)
-					handleError(&taskError{err, http.StatusInternalServerError})
-					return
-				}
 			}
 		}
-		if !hasHierarchyImportTask {
-			validationErrs = append(validationErrs, errors.New("bad request - missing hierarchy task"))
-		}
 	}
 
 	if tasks.BuildSearchIndexTasks != nil {
 		hasImportTasks = true
-		var hasSearchIndexImportTask bool
+		if len(tasks.BuildSearchIndexTasks) == 0 {
+			validationErrs = append(validationErrs, errors.New("bad request - missing search index task"))
+		}
 		for _, task := range tasks.BuildSearchIndexTasks {
-			hasSearchIndexImportTask = true
 			if err := models.ValidateImportTask(task.GenericTaskDetails); err != nil {
 				validationErrs = append(validationErrs, err)
-			} else {
-				eTag, err = s.UpdateBuildSearchTaskState(ctx, instance, task.DimensionName, task.State, eTag)
-				if err != nil {
-					if err.Error() == "not found" {
-						notFoundErr := task.DimensionName + " search index import task does not exist"
-						log.Error(ctx, notFoundErr, err, logData)
-						handleError(&taskError{errors.New(notFoundErr), http.StatusNotFound})
-						return
-					}
-					log.Error(ctx, "failed to update build hierarchy task state", err, logData)
-					handleError(&taskError{err, http.StatusInternalServerError})
-					return
-				}
 			}
 		}
-		if !hasSearchIndexImportTask {
-			validationErrs = append(validationErrs, errors.New("bad request - missing search index task"))
-		}
 	}
 
 	if !hasImportTasks {
@@ -191,6 +175,51 @@ func (s *Store) UpdateImportTask(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err = s.recordAuditEvent(ctx, requestedBy, models.ActionUpdate, "/instances/"+instanceID+"/import_tasks", nil, instance); err != nil {
+		log.Error(ctx, "failed to record instance audit event", err, logData)
+		handleError(&taskError{err, http.StatusInternalServerError})
+		return
+	}
+
+	if tasks.ImportObservations != nil {
+		eTag, err = s.UpdateImportObservationsTaskState(ctx, instance, tasks.ImportObservations.State, eTag)
+		if err != nil {
+			log.Error(ctx, "failed to update import observations task state", err, logData)
+			handleError(&taskError{err, http.StatusInternalServerError})
+			return
+		}
+	}
+
+	for _, task := range tasks.BuildHierarchyTasks {
+		eTag, err = s.UpdateBuildHierarchyTaskState(ctx, instance, task.DimensionName, task.State, eTag)
+		if err != nil {
+			if err.Error() == errs.ErrNotFound.Error() {
+				notFoundErr := task.DimensionName + " hierarchy import task does not exist"
+				log.Error(ctx, notFoundErr, err, logData)
+				handleError(&taskError{errors.New(notFoundErr), http.StatusNotFound})
+				return
+			}
+			log.Error(ctx, "failed to update build hierarchy task state", err, logData)
+			handleError(&taskError{err, http.StatusInternalServerError})
+			return
+		}
+	}
+
+	for _, task := range tasks.BuildSearchIndexTasks {
+		eTag, err = s.UpdateBuildSearchTaskState(ctx, instance, task.DimensionName, task.State, eTag)
+		if err != nil {
+			if err.Error() == "not found" {
+				notFoundErr := task.DimensionName + " search index import task does not exist"
+				log.Error(ctx, notFoundErr, err, logData)
+				handleError(&taskError{errors.New(notFoundErr), http.StatusNotFound})
+				return
+			}
+			log.Error(ctx, "failed to update build hierarchy task state", err, logData)
+			handleError(&taskError{err, http.StatusInternalServerError})
+			return
+		}
+	}
+
 	log.Info(ctx, "updateImportTask endpoint: request successful", logData)
 	dpresponse.SetETag(w, eTag)
 }
@@ -10,8 +10,10 @@ import (
 	"strings"
 
 	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	"github.com/ONSdigital/dp-dataset-api/application"
 	"github.com/ONSdigital/dp-dataset-api/models"
 	"github.com/ONSdigital/dp-dataset-api/mongo"
+	"github.com/ONSdigital/dp-dataset-api/pagination"
 	"github.com/ONSdigital/dp-dataset-api/store"
 	"github.com/ONSdigital/dp-dataset-api/url"
 	"github.com/ONSdigital/dp-dataset-api/utils"
@@ -31,6 +33,9 @@ type Store struct {
 	EnableDetachDataset bool
 	URLBuilder          *url.Builder
 	EnableURLRewriting  bool
+	AuditService        application.AuditService
+	// RequestedBy identifies the user or service that made a request, for the audit events
+	RequestedBy func(r *http.Request) (models.RequestedBy, error)
 }
 
 type taskError struct {
@@ -45,8 +50,8 @@ func (e taskError) Error() string {
 	return ""
 }
 
-// GetList returns a list of instances, the total count of instances that match the query parameters and an error
-func (s *Store) GetList(w http.ResponseWriter, r *http.Request, limit, offset int) (results interface{}, totalCount int, err error) {
+// GetList returns a list of instances, the total count of instances that match the query parameters, the cursor of the next page and an error
+func (s *Store) GetList(w http.ResponseWriter, r *http.Request, limit, offset int, cursor *pagination.Cursor) (results interface{}, totalCount int, nextCursor *pagination.Cursor, err error) {
 	ctx := r.Context()
 	stateFilterQuery := r.URL.Query().Get("state")
 	datasetFilterQuery := r.URL.Query().Get("dataset")
@@ -66,18 +71,18 @@ func (s *Store) GetList(w http.ResponseWriter, r *http.Request, limit, offset in
 
 	log.Info(ctx, "get list of instances", logData)
 
-	results, totalCount, err = func() ([]*models.Instance, int, error) {
+	results, totalCount, nextCursor, err = func() ([]*models.Instance, int, *pagination.Cursor, error) {
 		if len(stateFilterList) > 0 {
 			if err := models.ValidateStateFilter(stateFilterList); err != nil {
 				log.Error(ctx, "get instances: filter state invalid", err, logData)
-				return nil, 0, taskError{error: err, status: http.StatusBadRequest}
+				return nil, 0, nil, taskError{error: err, status: http.StatusBadRequest}
 			}
 		}
 
-		instancesResults, instancesTotalCount, err := s.GetInstances(ctx, stateFilterList, datasetFilterList, offset, limit)
+		instancesResults, instancesTotalCount, instancesNextCursor, err := s.GetInstances(ctx, stateFilterList, datasetFilterList, offset, limit, cursor)
 		if err != nil {
 			log.Error(ctx, "get instances: store.GetInstances returned an error", err, logData)
-			return nil, 0, err
+			return nil, 0, nil, err
 		}
 
 		if s.EnableURLRewriting {
@@ -89,20 +94,20 @@ func (s *Store) GetList(w http.ResponseWriter, r *http.Request, limit, offset in
 			if err != nil {
 				log.Error(ctx, "get instances endpoint: failed to rewrite instances", err, logData)
 				handleInstanceErr(ctx, err, w, logData)
-				return nil, 0, err
+				return nil, 0, nil, err
 			}
 		}
 
-		return instancesResults, instancesTotalCount, nil
+		return instancesResults, instancesTotalCount, instancesNextCursor, nil
 	}()
 
 	if err != nil {
 		handleInstanceErr(ctx, err, w, logData)
-		return nil, 0, err
+		return nil, 0, nil, err
 	}
 
 	log.Info(ctx, "get instances: request successful", logData)
-	return results, totalCount, nil
+	return results, totalCount, nextCursor, nil
 }
 
 // Get a single instance by id
@@ -171,6 +176,13 @@ func (s *Store) Add(w http.ResponseWriter, r *http.Request) {
 
 	log.Info(ctx, "add instance", logData)
 
+	requestedBy, err := s.RequestedBy(r)
+	if err != nil {
+		log.Error(ctx, "add instance: failed to get the requester of the request", err, logData)
+		handleInstanceErr(ctx, err, w, logData)
+		return
+	}
+
 	instance, err := UnmarshalInstance(ctx, r.Body, true)
 	if err != nil {
 		handleInstanceErr(ctx, err, w, logData)
@@ -183,6 +195,12 @@ func (s *Store) Add(w http.ResponseWriter, r *http.Request) {
 		HRef: fmt.Sprintf("%s/instances/%s", s.Host, instance.InstanceID),
 	}
 
+	if err = s.recordAuditEvent(ctx, requestedBy, models.ActionCreate, "/instances/"+instance.InstanceID, nil, instance); err != nil {
+		log.Error(ctx, "add instance: failed to record instance audit event", err, logData)
+		handleInstanceErr(ctx, err, w, logData)
+		return
+	}
+
 	instance, err = s.AddInstance(ctx, instance)
 	if err != nil {
 		log.Error(ctx, "add instance: store.AddInstance returned an error", err, logData)
@@ -226,6 +244,13 @@ func (s *Store) Update(w http.ResponseWriter, r *http.Request) {
 
 	logData := log.Data{"instance_id": instanceID}
 
+	requestedBy, err := s.RequestedBy(r)
+	if err != nil {
+		log.Error(ctx, "update instance: failed to get the requester of the request", err, logData)
+		handleInstanceErr(ctx, err, w, logData)
+		return
+	}
+
 	instance, err := UnmarshalInstance(ctx, r.Body, false)
 	if err != nil {
 		log.Error(ctx, "update instance: failed unmarshalling json to model", err, logData)
@@ -255,6 +280,13 @@ func (s *Store) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// confirming an edition updates the links of the current instance, so they are copied to keep its previous state
+	previousInstance := *currentInstance
+	if currentInstance.Links != nil {
+		previousLinks := *currentInstance.Links
+		previousInstance.Links = &previousLinks
+	}
+
 	logData["current_state"] = currentInstance.State
 	logData["requested_state"] = instance.State
 	if instance.State != "" && instance.State != currentInstance.State {
@@ -263,6 +295,9 @@ func (s *Store) Update(w http.ResponseWriter, r *http.Request) {
 			handleInstanceErr(ctx, err, w, logData)
 			return
 		}
+
+		stateChange := models.NewStateChange(currentInstance.State, instance.State, requestedBy.ID, "")
+		instance.StateHistory = append(append([]models.StateChange(nil), currentInstance.StateHistory...), stateChange)
 	}
 
 	datasetID := currentInstance.Links.Dataset.ID
@@ -313,6 +348,12 @@ func (s *Store) Update(w http.ResponseWriter, r *http.Request) {
 		log.Info(ctx, "update instance: added version details to instance", editionLogData)
 	}
 
+	if err = s.recordAuditEvent(ctx, requestedBy, models.ActionUpdate, "/instances/"+instanceID, &previousInstance, instance); err != nil {
+		log.Error(ctx, "update instance: failed to record instance audit event", err, logData)
+		handleInstanceErr(ctx, err, w, logData)
+		return
+	}
+
 	// Set the current mongo timestamp on instance document
 	instance.UniqueTimestamp = currentInstance.UniqueTimestamp
 	newETag, err := s.UpdateInstance(ctx, currentInstance, instance, eTag)
@@ -25,6 +25,7 @@ import (
 	"github.com/ONSdigital/dp-dataset-api/instance"
 	"github.com/ONSdigital/dp-dataset-api/mocks"
 	"github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/dp-dataset-api/pagination"
 	"github.com/ONSdigital/dp-dataset-api/store"
 	storetest "github.com/ONSdigital/dp-dataset-api/store/datastoretest"
 	"github.com/ONSdigital/dp-dataset-api/url"
@@ -75,13 +76,13 @@ func Test_GetInstancesReturnsOK(t *testing.T) {
 			w := httptest.NewRecorder()
 
 			mockedDataStore := &storetest.StorerMock{
-				GetInstancesFunc: func(context.Context, []string, []string, int, int) ([]*models.Instance, int, error) {
-					return []*models.Instance{}, 0, nil
+				GetInstancesFunc: func(context.Context, []string, []string, int, int, *pagination.Cursor) ([]*models.Instance, int, *pagination.Cursor, error) {
+					return []*models.Instance{}, 0, nil, nil
 				},
 			}
 
 			instanceAPI := initAPIWithMockedStore(mockedDataStore)
-			list, totalCount, err := instanceAPI.GetList(w, r, 20, 0)
+			list, totalCount, _, err := instanceAPI.GetList(w, r, 20, 0, nil)
 
 			So(len(mockedDataStore.GetInstancesCalls()), ShouldEqual, 1)
 			So(totalCount, ShouldEqual, 0)
@@ -94,13 +95,13 @@ func Test_GetInstancesReturnsOK(t *testing.T) {
 			w := httptest.NewRecorder()
 
 			mockedDataStore := &storetest.StorerMock{
-				GetInstancesFunc: func(context.Context, []string, []string, int, int) ([]*models.Instance, int, error) {
-					return []*models.Instance{{InstanceID: "test"}}, 1, nil
+				GetInstancesFunc: func(context.Context, []string, []string, int, int, *pagination.Cursor) ([]*models.Instance, int, *pagination.Cursor, error) {
+					return []*models.Instance{{InstanceID: "test"}}, 1, nil, nil
 				},
 			}
 
 			instanceAPI := initAPIWithMockedStore(mockedDataStore)
-			list, totalCount, err := instanceAPI.GetList(w, r, 20, 0)
+			list, totalCount, _, err := instanceAPI.GetList(w, r, 20, 0, nil)
 
 			So(len(mockedDataStore.GetInstancesCalls()), ShouldEqual, 1)
 			So(mockedDataStore.GetInstancesCalls()[0].States, ShouldResemble, []string{"completed"})
@@ -114,13 +115,13 @@ func Test_GetInstancesReturnsOK(t *testing.T) {
 			w := httptest.NewRecorder()
 
 			mockedDataStore := &storetest.StorerMock{
-				GetInstancesFunc: func(context.Context, []string, []string, int, int) ([]*models.Instance, int, error) {
-					return []*models.Instance{}, 0, nil
+				GetInstancesFunc: func(context.Context, []string, []string, int, int, *pagination.Cursor) ([]*models.Instance, int, *pagination.Cursor, error) {
+					return []*models.Instance{}, 0, nil, nil
 				},
 			}
 
 			instanceAPI := initAPIWithMockedStore(mockedDataStore)
-			_, _, _ = instanceAPI.GetList(w, r, 20, 0)
+			_, _, _, _ = instanceAPI.GetList(w, r, 20, 0, nil)
 
 			So(mockedDataStore.GetInstancesCalls()[0].Datasets, ShouldResemble, []string{"test"})
 			So(len(mockedDataStore.GetInstancesCalls()), ShouldEqual, 1)
@@ -131,13 +132,13 @@ func Test_GetInstancesReturnsOK(t *testing.T) {
 			w := httptest.NewRecorder()
 
 			mockedDataStore := &storetest.StorerMock{
-				GetInstancesFunc: func(context.Context, []string, []string, int, int) ([]*models.Instance, int, error) {
-					return []*models.Instance{}, 0, nil
+				GetInstancesFunc: func(context.Context, []string, []string, int, int, *pagination.Cursor) ([]*models.Instance, int, *pagination.Cursor, error) {
+					return []*models.Instance{}, 0, nil, nil
 				},
 			}
 
 			instanceAPI := initAPIWithMockedStore(mockedDataStore)
-			_, _, _ = instanceAPI.GetList(w, r, 20, 0)
+			_, _, _, _ = instanceAPI.GetList(w, r, 20, 0, nil)
 
 			So(mockedDataStore.GetInstancesCalls()[0].States, ShouldResemble, []string{"completed", "edition-confirmed"})
 			So(len(mockedDataStore.GetInstancesCalls()), ShouldEqual, 1)
@@ -148,13 +149,13 @@ func Test_GetInstancesReturnsOK(t *testing.T) {
 			w := httptest.NewRecorder()
 
 			mockedDataStore := &storetest.StorerMock{
-				GetInstancesFunc: func(context.Context, []string, []string, int, int) ([]*models.Instance, int, error) {
-					return []*models.Instance{}, 0, nil
+				GetInstancesFunc: func(context.Context, []string, []string, int, int, *pagination.Cursor) ([]*models.Instance, int, *pagination.Cursor, error) {
+					return []*models.Instance{}, 0, nil, nil
 				},
 			}
 
 			instanceAPI := initAPIWithMockedStore(mockedDataStore)
-			_, _, _ = instanceAPI.GetList(w, r, 20, 0)
+			_, _, _, _ = instanceAPI.GetList(w, r, 20, 0, nil)
 
 			So(mockedDataStore.GetInstancesCalls()[0].States, ShouldResemble, []string{"completed"})
 			So(mockedDataStore.GetInstancesCalls()[0].Datasets, ShouldResemble, []string{"test"})
@@ -172,13 +173,13 @@ func Test_GetInstancesReturnsError(t *testing.T) {
 				w := httptest.NewRecorder()
 
 				mockedDataStore := &storetest.StorerMock{
-					GetInstancesFunc: func(context.Context, []string, []string, int, int) ([]*models.Instance, int, error) {
-						return nil, 0, errs.ErrInternalServer
+					GetInstancesFunc: func(context.Context, []string, []string, int, int, *pagination.Cursor) ([]*models.Instance, int, *pagination.Cursor, error) {
+						return nil, 0, nil, errs.ErrInternalServer
 					},
 				}
 
 				instanceAPI := initAPIWithMockedStore(mockedDataStore)
-				_, _, _ = instanceAPI.GetList(w, r, 20, 0)
+				_, _, _, _ = instanceAPI.GetList(w, r, 20, 0, nil)
 
 				So(w.Code, ShouldEqual, http.StatusInternalServerError)
 				So(w.Body.String(), ShouldContainSubstring, errs.ErrInternalServer.Error())
@@ -192,7 +193,7 @@ func Test_GetInstancesReturnsError(t *testing.T) {
 				w := httptest.NewRecorder()
 
 				instanceAPI := initAPIWithMockedStore(&storetest.StorerMock{})
-				_, _, _ = instanceAPI.GetList(w, r, 20, 0)
+				_, _, _, _ = instanceAPI.GetList(w, r, 20, 0, nil)
 
 				So(w.Code, ShouldEqual, http.StatusBadRequest)
 				So(w.Body.String(), ShouldContainSubstring, "bad request - invalid filter state values: [foo]")
@@ -532,12 +533,49 @@ func Test_AddInstanceReturnsCreated(t *testing.T) {
 					},
 				}
 
-				datasetAPI := getAPIWithCantabularMocks(testContext, mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+				auditServiceMock := &applicationMocks.AuditServiceMock{}
+
+				datasetAPI := getAPIWithCantabularMocks(testContext, mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, &cloudflareMocks.ClienterMock{}, auditServiceMock)
 				datasetAPI.Router.ServeHTTP(w, r)
 
 				So(w.Code, ShouldEqual, http.StatusCreated)
 				So(w.Header().Get("ETag"), ShouldEqual, testETag)
 				So(len(mockedDataStore.AddInstanceCalls()), ShouldEqual, 1)
+				So(auditServiceMock.RecordInstanceAuditEventCalls(), ShouldHaveLength, 1)
+				So(auditServiceMock.RecordInstanceAuditEventCalls()[0].Action, ShouldEqual, models.ActionCreate)
+				So(auditServiceMock.RecordInstanceAuditEventCalls()[0].Previous, ShouldBeNil)
+				So(auditServiceMock.RecordInstanceAuditEventCalls()[0].Instance.Links.Job.ID, ShouldEqual, "123-456")
+			})
+		})
+
+		Convey("When the audit event cannot be recorded", func() {
+			Convey("Then return status internal server error (500) and the instance is not added", func() {
+				body := strings.NewReader(`{"links": { "job": { "id":"123-456", "href":"http://localhost:2200/jobs/123-456" } } }`)
+				r, err := createRequestWithToken("POST", "http://localhost:21800/instances", body)
+				So(err, ShouldBeNil)
+				w := httptest.NewRecorder()
+
+				mockedDataStore := &storetest.StorerMock{
+					AddInstanceFunc: func(context.Context, *models.Instance) (*models.Instance, error) {
+						return &models.Instance{
+							ETag: testETag,
+						}, nil
+					},
+				}
+
+				auditServiceMock := &applicationMocks.AuditServiceMock{
+					RecordInstanceAuditEventFunc: func(context.Context, models.RequestedBy, models.Action, string, string, *models.Instance, *models.Instance) error {
+						return errs.ErrInternalServer
+					},
+				}
+
+				datasetAPI := getAPIWithCantabularMocks(testContext, mockedDataStore, &mocks.DownloadsGeneratorMock{}, nil, &cloudflareMocks.ClienterMock{}, auditServiceMock)
+				datasetAPI.Router.ServeHTTP(w, r)
+
+				So(w.Code, ShouldEqual, http.StatusInternalServerError)
+				So(w.Body.String(), ShouldContainSubstring, errs.ErrInternalServer.Error())
+				So(auditServiceMock.RecordInstanceAuditEventCalls(), ShouldHaveLength, 1)
+				So(mockedDataStore.AddInstanceCalls(), ShouldBeEmpty)
 			})
 		})
 	})
@@ -733,7 +771,9 @@ func Test_UpdateInstanceReturnsOk(t *testing.T) {
 			},
 		}
 
-		datasetAPI := getAPIWithCantabularMocks(testContext, mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, &cloudflareMocks.ClienterMock{}, &applicationMocks.AuditServiceMock{})
+		auditServiceMock := &applicationMocks.AuditServiceMock{}
+
+		datasetAPI := getAPIWithCantabularMocks(testContext, mockedDataStore, &mocks.DownloadsGeneratorMock{}, authorisationMock, &cloudflareMocks.ClienterMock{}, auditServiceMock)
 
 		Convey("When a PUT request to update state of an instance resource to 'submitted' is made with a valid If-Match header", func() {
 			body := strings.NewReader(`{"state":"submitted"}`)
@@ -762,6 +802,25 @@ func Test_UpdateInstanceReturnsOk(t *testing.T) {
 				So(mockedDataStore.UpdateInstanceCalls()[0].UpdatedInstance.State, ShouldEqual, models.SubmittedState)
 			})
 
+			Convey("Then the change of state is appended to the state history of the instance", func() {
+				stateHistory := mockedDataStore.UpdateInstanceCalls()[0].UpdatedInstance.StateHistory
+				So(stateHistory, ShouldHaveLength, 1)
+				So(stateHistory[0].From, ShouldEqual, models.CreatedState)
+				So(stateHistory[0].To, ShouldEqual, models.SubmittedState)
+				So(stateHistory[0].ChangedBy, ShouldEqual, "admin")
+				So(stateHistory[0].ChangedAt, ShouldNotBeZeroValue)
+			})
+
+			Convey("Then an instance audit event is recorded with the previous state of the instance", func() {
+				So(auditServiceMock.RecordInstanceAuditEventCalls(), ShouldHaveLength, 1)
+				So(auditServiceMock.RecordInstanceAuditEventCalls()[0].RequestedBy.ID, ShouldEqual, "admin")
+				So(auditServiceMock.RecordInstanceAuditEventCalls()[0].Action, ShouldEqual, models.ActionUpdate)
+				So(auditServiceMock.RecordInstanceAuditEventCalls()[0].Resource, ShouldEqual, "/instances/123")
+				So(auditServiceMock.RecordInstanceAuditEventCalls()[0].DatasetID, ShouldEqual, "234")
+				So(auditServiceMock.RecordInstanceAuditEventCalls()[0].Previous.State, ShouldEqual, models.CreatedState)
+				So(auditServiceMock.RecordInstanceAuditEventCalls()[0].Instance.State, ShouldEqual, models.SubmittedState)
+			})
+
 			Convey("Then the mongoDB instance lock is acquired and released as expected", func() {
 				validateLock(mockedDataStore, "123")
 				So(*isLocked, ShouldBeFalse)
@@ -1011,6 +1070,24 @@ func getAPIWithCantabularMocks(ctx context.Context, mockedDataStore store.Storer
 			return handlerFunc
 		}
 	}
+	if am.ParseFunc == nil {
+		am.ParseFunc = func(_ string) (*permissionsAPISDK.EntityData, error) {
+			return &permissionsAPISDK.EntityData{UserID: "admin"}, nil
+		}
+	}
+	if auditServiceMock == nil {
+		auditServiceMock = &applicationMocks.AuditServiceMock{}
+	}
+	if auditServiceMock.RecordInstanceAuditEventFunc == nil {
+		auditServiceMock.RecordInstanceAuditEventFunc = func(_ context.Context, _ models.RequestedBy, _ models.Action, _, _ string, _, _ *models.Instance) error {
+			return nil
+		}
+	}
+	if auditServiceMock.RecordDimensionOptionsAuditEventFunc == nil {
+		auditServiceMock.RecordDimensionOptionsAuditEventFunc = func(_ context.Context, _ models.RequestedBy, _ models.Action, _, _ string, _ []*models.CachedDimensionOption) error {
+			return nil
+		}
+	}
 
 	mu.Lock()
 	defer mu.Unlock()
@@ -1028,5 +1105,5 @@ func getAPIWithCantabularMocks(ctx context.Context, mockedDataStore store.Storer
 	}
 	testIdentityClient := clientsidentity.New(cfg.ZebedeeURL)
 
-	return api.Setup(ctx, cfg, mux.NewRouter(), store.DataStore{Backend: mockedDataStore}, urlBuilder, mockedMapDownloadGenerators, am, enableURLRewriting, &mockStatemachineDatasetAPI, auditServiceMock, permissionsChecker, testIdentityClient, nil, cloudflareMock)
+	return api.Setup(ctx, cfg, mux.NewRouter(), store.DataStore{Backend: mockedDataStore}, urlBuilder, mockedMapDownloadGenerators, am, enableURLRewriting, &mockStatemachineDatasetAPI, auditServiceMock, permissionsChecker, testIdentityClient, cloudflareMock)
 }
@@ -29,6 +29,22 @@ func main() {
 	log.Namespace = serviceName
 	ctx := context.Background()
 
+	commands := map[string]func(context.Context, []string) error{
+		migrateCommand:          migrate,
+		exportCommand:           exportDatasets,
+		importCommand:           importDatasets,
+		verifyAuditChainCommand: verifyAuditChain,
+	}
+	if len(os.Args) > 1 {
+		if command, ok := commands[os.Args[1]]; ok {
+			if err := command(ctx, os.Args[2:]); err != nil {
+				log.Error(ctx, os.Args[1]+" command failed", err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
 	if err := run(ctx); err != nil {
 		log.Error(ctx, "application unexpectedly failed", err)
 		os.Exit(1)
@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	componenttest "github.com/ONSdigital/dp-component-test"
+	"github.com/ONSdigital/dp-dataset-api/config"
 	"github.com/ONSdigital/dp-dataset-api/features/steps"
 	"github.com/ONSdigital/log.go/v2/log"
 	"github.com/cucumber/godog"
@@ -27,9 +28,12 @@ type ComponentTest struct {
 func (f *ComponentTest) InitializeScenario(godogCtx *godog.ScenarioContext) {
 	authorizationFeature := componenttest.NewAuthorizationFeature()
 
-	mongoURI, err := f.MongoFeature.GetConnectionString()
-	if err != nil {
-		panic(err)
+	var mongoURI string
+	if f.MongoFeature != nil {
+		var err error
+		if mongoURI, err = f.MongoFeature.GetConnectionString(); err != nil {
+			panic(err)
+		}
 	}
 
 	datasetFeature, err := steps.NewDatasetComponent(mongoURI, authorizationFeature.FakeAuthService.Server.URL)
@@ -42,8 +46,10 @@ func (f *ComponentTest) InitializeScenario(godogCtx *godog.ScenarioContext) {
 			log.Error(context.Background(), "failed to reset dataset feature", err)
 		}
 
-		if err := f.MongoFeature.Reset(); err != nil {
-			log.Error(context.Background(), "failed to reset mongo feature", err)
+		if f.MongoFeature != nil {
+			if err := f.MongoFeature.Reset(); err != nil {
+				log.Error(context.Background(), "failed to reset mongo feature", err)
+			}
 		}
 		authorizationFeature.Reset()
 		return ctx, nil
@@ -61,10 +67,16 @@ func (f *ComponentTest) InitializeScenario(godogCtx *godog.ScenarioContext) {
 
 func (f *ComponentTest) InitializeTestSuite(ctx *godog.TestSuiteContext) {
 	ctx.BeforeSuite(func() {
+		// the in-memory datastore does not need a mongoDB (nor Docker) to run the component tests
+		if os.Getenv("DATASTORE") == config.MemoryDatastore {
+			return
+		}
 		f.MongoFeature = componenttest.NewMongoFeature(componenttest.MongoOptions{MongoVersion: mongoVersion, DatabaseName: databaseName, ReplicaSetName: replicaSetName})
 	})
 	ctx.AfterSuite(func() {
-		f.MongoFeature.Close()
+		if f.MongoFeature != nil {
+			f.MongoFeature.Close()
+		}
 	})
 }
 
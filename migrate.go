@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"os"
+
+	"github.com/ONSdigital/dp-dataset-api/config"
+	"github.com/ONSdigital/dp-dataset-api/migrations"
+	"github.com/ONSdigital/dp-dataset-api/service"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+const migrateCommand = "migrate"
+
+var errMigrationsNotSupported = errors.New("the configured datastore does not support migrations")
+
+// migrate applies the pending schema migrations without starting the service, or with -dry-run
+// lists them along with the number of documents they would change
+func migrate(ctx context.Context, args []string) (err error) {
+	flags := flag.NewFlagSet(migrateCommand, flag.ContinueOnError)
+	dryRun := flags.Bool("dry-run", false, "report the pending migrations without applying them")
+	if err = flags.Parse(args); err != nil {
+		return err
+	}
+
+	cfg, err := config.Get()
+	if err != nil {
+		log.Error(ctx, "failed to retrieve configuration", err)
+		return err
+	}
+
+	mongoDB, err := (&service.Init{}).DoGetMongoDB(ctx, cfg.MongoConfig)
+	if err != nil {
+		log.Error(ctx, "failed to initialise mongo", err)
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, mongoDB.Close(ctx))
+	}()
+
+	db, ok := mongoDB.(migrations.Database)
+	if !ok {
+		return errMigrationsNotSupported
+	}
+
+	migrator, err := migrations.New(db, migrations.All())
+	if err != nil {
+		return err
+	}
+
+	results, err := migrator.Run(ctx, *dryRun)
+	if reportErr := migrations.WriteReport(os.Stdout, results, *dryRun); reportErr != nil {
+		return errors.Join(err, reportErr)
+	}
+	return err
+}
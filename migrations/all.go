@@ -0,0 +1,52 @@
+package migrations
+
+import (
+	"github.com/ONSdigital/dp-dataset-api/config"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// downloadTypes are the keys of the download objects stored in a models.DownloadList
+var downloadTypes = []string{"xls", "xlsx", "csv", "txt", "csvw"}
+
+// All returns the migrations of the dataset API, in the order they must be applied.
+// Once released, a migration must not be changed; add a new one with a later ID instead.
+func All() []*Migration {
+	return []*Migration{
+		{
+			ID:          "0001_version_type_v4",
+			Description: "set the type of instances and versions without one to v4",
+			Steps: []Step{
+				versionTypeV4(config.InstanceCollection),
+				versionTypeV4(config.VersionsCollection),
+			},
+		},
+		{
+			ID:          "0002_download_size_string",
+			Description: "store the size of instance and version downloads as a string",
+			Steps:       append(downloadSizeString(config.InstanceCollection), downloadSizeString(config.VersionsCollection)...),
+		},
+	}
+}
+
+// versionTypeV4 sets the type that an empty or missing type has always meant
+func versionTypeV4(collection string) Step {
+	return Step{
+		Collection: collection,
+		Filter:     bson.M{"type": bson.M{"$in": bson.A{nil, ""}}},
+		Update:     bson.M{"$set": bson.M{"type": "v4"}},
+	}
+}
+
+// downloadSizeString converts the download sizes that were stored as numbers into strings
+func downloadSizeString(collection string) []Step {
+	steps := make([]Step, 0, len(downloadTypes))
+	for _, downloadType := range downloadTypes {
+		field := "downloads." + downloadType + ".size"
+		steps = append(steps, Step{
+			Collection: collection,
+			Filter:     bson.M{field: bson.M{"$type": "number"}},
+			Update:     bson.A{bson.M{"$set": bson.M{field: bson.M{"$toString": "$" + field}}}},
+		})
+	}
+	return steps
+}
@@ -0,0 +1,234 @@
+package migrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/ONSdigital/dp-dataset-api/config"
+	mongodriver "github.com/ONSdigital/dp-mongodb/v3/mongodb"
+	"github.com/ONSdigital/log.go/v2/log"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+//go:generate moq -out mock/database.go -pkg mock . Database Collection
+
+var errNoMigrationID = errors.New("migration has no id")
+
+// ErrLocked is returned by Database.LockMigrations when another instance of the service is running the migrations
+var ErrLocked = errors.New("migrations are locked by another instance")
+
+// LockRetryPeriod is the time to wait before trying again to lock the migrations, while another instance is running them
+var LockRetryPeriod = time.Second
+
+// Collection represents the mongo collection methods required to run migrations
+type Collection interface {
+	Count(ctx context.Context, filter interface{}, opts ...mongodriver.FindOption) (int, error)
+	Find(ctx context.Context, filter, results interface{}, opts ...mongodriver.FindOption) (int, error)
+	UpdateMany(ctx context.Context, selector, update interface{}) (*mongodriver.CollectionUpdateResult, error)
+	UpsertById(ctx context.Context, id, update interface{}) (*mongodriver.CollectionUpdateResult, error)
+}
+
+// Database provides the collections that migrations are applied to, by their config key (e.g. config.DatasetsCollection),
+// and the lock that stops several instances of the service from applying migrations at the same time
+type Database interface {
+	MigrationCollection(name string) Collection
+	LockMigrations(ctx context.Context) (lockID string, err error)
+	UnlockMigrations(ctx context.Context, lockID string)
+}
+
+// Step is a single update of a migration, applied to every document of a collection that matches its filter
+type Step struct {
+	// Collection is the config key of the collection to update
+	Collection string
+	// Filter must only match the documents that have not been migrated yet, so that the step changes nothing when run again
+	Filter bson.M
+	// Update is either an update document or an aggregation pipeline
+	Update interface{}
+}
+
+// Migration is an ordered set of steps that changes the shape of the stored documents
+type Migration struct {
+	// ID orders the migrations and records that a migration has been applied, e.g. "0001_instance_type"
+	ID          string
+	Description string
+	Steps       []Step
+}
+
+// Record is the document stored in the migrations collection once a migration has been applied
+type Record struct {
+	ID               string    `bson:"_id"`
+	Description      string    `bson:"description"`
+	AppliedAt        time.Time `bson:"applied_at"`
+	DocumentsChanged int       `bson:"documents_changed"`
+}
+
+// Result is the outcome of running a pending migration
+type Result struct {
+	ID          string
+	Description string
+	// Documents is the number of documents changed by the migration, or that would be changed on a dry run
+	Documents int
+	Applied   bool
+}
+
+// Migrator applies the migrations that have not been recorded as applied yet
+type Migrator struct {
+	db         Database
+	migrations []*Migration
+}
+
+// New creates a Migrator for the provided migrations, which must have unique IDs in ascending order
+func New(db Database, migrations []*Migration) (*Migrator, error) {
+	for i, migration := range migrations {
+		if migration.ID == "" {
+			return nil, errNoMigrationID
+		}
+		if i > 0 && migration.ID <= migrations[i-1].ID {
+			return nil, fmt.Errorf("migration %s must come after migration %s", migration.ID, migrations[i-1].ID)
+		}
+	}
+
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// Pending returns the migrations that have not been applied yet, in order
+func (m *Migrator) Pending(ctx context.Context) ([]*Migration, error) {
+	// the collection also holds the claim of the instance applying the migrations, which has not been applied
+	var records []*Record
+	if _, err := m.db.MigrationCollection(config.MigrationsCollection).Find(ctx, bson.M{"applied_at": bson.M{"$exists": true}}, &records); err != nil {
+		return nil, fmt.Errorf("failed to get applied migrations: %w", err)
+	}
+
+	applied := make(map[string]bool, len(records))
+	for _, record := range records {
+		applied[record.ID] = true
+	}
+
+	var pending []*Migration
+	for _, migration := range m.migrations {
+		if !applied[migration.ID] {
+			pending = append(pending, migration)
+		}
+	}
+
+	return pending, nil
+}
+
+// Run applies the pending migrations in order, stopping at the first one that fails. On a dry run nothing is changed,
+// and the results contain the number of documents that each pending migration would change instead.
+// Migrations are only applied by one instance of the service at a time: if another instance is applying them,
+// Run waits until it is done and then applies any migrations that are still pending.
+func (m *Migrator) Run(ctx context.Context, dryRun bool) ([]Result, error) {
+	if !dryRun {
+		lockID, err := m.lock(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to lock migrations: %w", err)
+		}
+		defer m.db.UnlockMigrations(ctx, lockID)
+	}
+
+	pending, err := m.Pending(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]Result, 0, len(pending))
+	for _, migration := range pending {
+		logData := log.Data{"migration_id": migration.ID, "dry_run": dryRun}
+
+		result := Result{ID: migration.ID, Description: migration.Description}
+		if dryRun {
+			result.Documents, err = m.count(ctx, migration)
+		} else {
+			result.Documents, err = m.apply(ctx, migration)
+			result.Applied = err == nil
+		}
+		if err != nil {
+			log.Error(ctx, "migration failed", err, logData)
+			return results, fmt.Errorf("migration %s failed: %w", migration.ID, err)
+		}
+
+		logData["documents"] = result.Documents
+		log.Info(ctx, "migration completed", logData)
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// lock locks the migrations, waiting for any other instance of the service that is running them
+func (m *Migrator) lock(ctx context.Context) (string, error) {
+	for {
+		lockID, err := m.db.LockMigrations(ctx)
+		if !errors.Is(err, ErrLocked) {
+			return lockID, err
+		}
+
+		log.Info(ctx, "waiting for another instance to finish running migrations")
+
+		select {
+		case <-time.After(LockRetryPeriod):
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+	}
+}
+
+// count returns the number of documents that the steps of the migration would change
+func (m *Migrator) count(ctx context.Context, migration *Migration) (int, error) {
+	total := 0
+	for _, step := range migration.Steps {
+		n, err := m.db.MigrationCollection(step.Collection).Count(ctx, step.Filter)
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	return total, nil
+}
+
+// apply runs the steps of the migration and records it as applied
+func (m *Migrator) apply(ctx context.Context, migration *Migration) (int, error) {
+	total := 0
+	for _, step := range migration.Steps {
+		result, err := m.db.MigrationCollection(step.Collection).UpdateMany(ctx, step.Filter, step.Update)
+		if err != nil {
+			return total, err
+		}
+		total += result.ModifiedCount
+	}
+
+	record := bson.M{
+		"description":       migration.Description,
+		"applied_at":        time.Now().UTC(),
+		"documents_changed": total,
+	}
+	if _, err := m.db.MigrationCollection(config.MigrationsCollection).UpsertById(ctx, migration.ID, bson.M{"$set": record}); err != nil {
+		return total, fmt.Errorf("failed to record migration: %w", err)
+	}
+
+	return total, nil
+}
+
+// WriteReport writes a line for each of the results, describing what was, or on a dry run what would be, migrated
+func WriteReport(w io.Writer, results []Result, dryRun bool) error {
+	if len(results) == 0 {
+		_, err := fmt.Fprintln(w, "no pending migrations")
+		return err
+	}
+
+	verb := "migrated"
+	if dryRun {
+		verb = "would migrate"
+	}
+
+	for _, result := range results {
+		if _, err := fmt.Fprintf(w, "%s: %s %d document(s) - %s\n", result.ID, verb, result.Documents, result.Description); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,258 @@
+package migrations_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-dataset-api/config"
+	"github.com/ONSdigital/dp-dataset-api/migrations"
+	"github.com/ONSdigital/dp-dataset-api/migrations/mock"
+	mongodriver "github.com/ONSdigital/dp-mongodb/v3/mongodb"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+var testContext = context.Background()
+
+var testMigrations = []*migrations.Migration{
+	{
+		ID:          "0001_first",
+		Description: "first migration",
+		Steps: []migrations.Step{
+			{Collection: config.InstanceCollection, Filter: bson.M{"type": ""}, Update: bson.M{"$set": bson.M{"type": "v4"}}},
+		},
+	},
+	{
+		ID:          "0002_second",
+		Description: "second migration",
+		Steps: []migrations.Step{
+			{Collection: config.InstanceCollection, Filter: bson.M{"state": "old"}, Update: bson.M{"$set": bson.M{"state": "new"}}},
+			{Collection: config.VersionsCollection, Filter: bson.M{"state": "old"}, Update: bson.M{"$set": bson.M{"state": "new"}}},
+		},
+	},
+}
+
+// newCollectionMock returns a collection mock with the provided applied migration records, which changes or counts 2 documents per step
+func newCollectionMock(applied ...string) *mock.CollectionMock {
+	return &mock.CollectionMock{
+		FindFunc: func(ctx context.Context, filter, results interface{}, opts ...mongodriver.FindOption) (int, error) {
+			records := results.(*[]*migrations.Record)
+			for _, id := range applied {
+				*records = append(*records, &migrations.Record{ID: id})
+			}
+			return len(applied), nil
+		},
+		CountFunc: func(ctx context.Context, filter interface{}, opts ...mongodriver.FindOption) (int, error) {
+			return 2, nil
+		},
+		UpdateManyFunc: func(ctx context.Context, selector, update interface{}) (*mongodriver.CollectionUpdateResult, error) {
+			return &mongodriver.CollectionUpdateResult{MatchedCount: 2, ModifiedCount: 2}, nil
+		},
+		UpsertByIdFunc: func(ctx context.Context, id, update interface{}) (*mongodriver.CollectionUpdateResult, error) {
+			return &mongodriver.CollectionUpdateResult{UpsertedCount: 1}, nil
+		},
+	}
+}
+
+func newDatabaseMock(collection migrations.Collection) *mock.DatabaseMock {
+	return &mock.DatabaseMock{
+		MigrationCollectionFunc: func(name string) migrations.Collection {
+			return collection
+		},
+		LockMigrationsFunc: func(context.Context) (string, error) {
+			return "lock-id", nil
+		},
+		UnlockMigrationsFunc: func(context.Context, string) {},
+	}
+}
+
+func TestNew(t *testing.T) {
+	Convey("The migrations of the dataset API are valid", t, func() {
+		_, err := migrations.New(newDatabaseMock(newCollectionMock()), migrations.All())
+		So(err, ShouldBeNil)
+	})
+
+	Convey("Migrations that are not in ascending order are rejected", t, func() {
+		_, err := migrations.New(newDatabaseMock(newCollectionMock()), []*migrations.Migration{testMigrations[1], testMigrations[0]})
+		So(err, ShouldResemble, errors.New("migration 0001_first must come after migration 0002_second"))
+	})
+
+	Convey("Migrations with the same ID are rejected", t, func() {
+		_, err := migrations.New(newDatabaseMock(newCollectionMock()), []*migrations.Migration{testMigrations[0], testMigrations[0]})
+		So(err, ShouldNotBeNil)
+	})
+
+	Convey("Migrations without an ID are rejected", t, func() {
+		_, err := migrations.New(newDatabaseMock(newCollectionMock()), []*migrations.Migration{{Description: "no id"}})
+		So(err, ShouldNotBeNil)
+	})
+}
+
+func TestRun(t *testing.T) {
+	Convey("Given a migrator where the first migration has already been applied", t, func() {
+		collection := newCollectionMock("0001_first")
+		db := newDatabaseMock(collection)
+		migrator, err := migrations.New(db, testMigrations)
+		So(err, ShouldBeNil)
+
+		Convey("When the migrations are run", func() {
+			results, err := migrator.Run(testContext, false)
+
+			Convey("Then only the steps of the pending migration are applied", func() {
+				So(err, ShouldBeNil)
+				So(results, ShouldResemble, []migrations.Result{
+					{ID: "0002_second", Description: "second migration", Documents: 4, Applied: true},
+				})
+				So(collection.UpdateManyCalls(), ShouldHaveLength, 2)
+				So(collection.UpdateManyCalls()[0].Selector, ShouldResemble, bson.M{"state": "old"})
+				So(collection.CountCalls(), ShouldHaveLength, 0)
+			})
+
+			Convey("And the migrations are locked while they are applied", func() {
+				So(db.LockMigrationsCalls(), ShouldHaveLength, 1)
+				So(db.UnlockMigrationsCalls(), ShouldHaveLength, 1)
+				So(db.UnlockMigrationsCalls()[0].LockID, ShouldEqual, "lock-id")
+			})
+
+			Convey("And the pending migration is recorded as applied", func() {
+				So(collection.UpsertByIdCalls(), ShouldHaveLength, 1)
+				So(collection.UpsertByIdCalls()[0].ID, ShouldEqual, "0002_second")
+				record := collection.UpsertByIdCalls()[0].Update.(bson.M)["$set"].(bson.M)
+				So(record["documents_changed"], ShouldEqual, 4)
+			})
+
+			Convey("And the steps are applied to their collections", func() {
+				names := []string{}
+				for _, call := range db.MigrationCollectionCalls() {
+					names = append(names, call.Name)
+				}
+				So(names, ShouldResemble, []string{
+					config.MigrationsCollection, config.InstanceCollection, config.VersionsCollection, config.MigrationsCollection,
+				})
+			})
+		})
+
+		Convey("When the migrations are run as a dry run", func() {
+			results, err := migrator.Run(testContext, true)
+
+			Convey("Then the documents that the pending migration would change are counted, without changing anything", func() {
+				So(err, ShouldBeNil)
+				So(results, ShouldResemble, []migrations.Result{
+					{ID: "0002_second", Description: "second migration", Documents: 4},
+				})
+				So(collection.CountCalls(), ShouldHaveLength, 2)
+				So(collection.UpdateManyCalls(), ShouldHaveLength, 0)
+				So(collection.UpsertByIdCalls(), ShouldHaveLength, 0)
+			})
+		})
+	})
+
+	Convey("Given a migrator whose migrations are being run by another instance", t, func() {
+		migrations.LockRetryPeriod = time.Millisecond
+		collection := newCollectionMock()
+		db := newDatabaseMock(collection)
+		db.LockMigrationsFunc = func(context.Context) (string, error) {
+			if len(db.LockMigrationsCalls()) < 3 {
+				return "", migrations.ErrLocked
+			}
+			collection.FindFunc = newCollectionMock("0001_first", "0002_second").FindFunc
+			return "lock-id", nil
+		}
+		migrator, err := migrations.New(db, testMigrations)
+		So(err, ShouldBeNil)
+
+		Convey("When the migrations are run", func() {
+			results, err := migrator.Run(testContext, false)
+
+			Convey("Then they wait for the other instance, and nothing is applied again", func() {
+				So(err, ShouldBeNil)
+				So(results, ShouldBeEmpty)
+				So(db.LockMigrationsCalls(), ShouldHaveLength, 3)
+				So(db.UnlockMigrationsCalls(), ShouldHaveLength, 1)
+				So(collection.UpdateManyCalls(), ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("When the migrations are run and the context is done while waiting", func() {
+			ctx, cancel := context.WithCancel(testContext)
+			cancel()
+			db.LockMigrationsFunc = func(context.Context) (string, error) {
+				return "", migrations.ErrLocked
+			}
+			_, err := migrator.Run(ctx, false)
+
+			Convey("Then an error is returned and nothing is applied", func() {
+				So(errors.Is(err, context.Canceled), ShouldBeTrue)
+				So(collection.UpdateManyCalls(), ShouldHaveLength, 0)
+				So(db.UnlockMigrationsCalls(), ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("When the migrations are run as a dry run", func() {
+			_, err := migrator.Run(testContext, true)
+
+			Convey("Then the migrations are not locked", func() {
+				So(err, ShouldBeNil)
+				So(db.LockMigrationsCalls(), ShouldHaveLength, 0)
+			})
+		})
+	})
+
+	Convey("Given a migrator where a migration fails", t, func() {
+		collection := newCollectionMock()
+		collection.UpdateManyFunc = func(ctx context.Context, selector, update interface{}) (*mongodriver.CollectionUpdateResult, error) {
+			return nil, errors.New("mongo error")
+		}
+		migrator, err := migrations.New(newDatabaseMock(collection), testMigrations)
+		So(err, ShouldBeNil)
+
+		Convey("When the migrations are run", func() {
+			results, err := migrator.Run(testContext, false)
+
+			Convey("Then the error is returned and neither the failed nor the following migrations are recorded", func() {
+				So(err.Error(), ShouldEqual, "migration 0001_first failed: mongo error")
+				So(results, ShouldBeEmpty)
+				So(collection.UpdateManyCalls(), ShouldHaveLength, 1)
+				So(collection.UpsertByIdCalls(), ShouldHaveLength, 0)
+			})
+		})
+	})
+
+	Convey("Given a migrator that cannot read the applied migrations", t, func() {
+		collection := newCollectionMock()
+		collection.FindFunc = func(ctx context.Context, filter, results interface{}, opts ...mongodriver.FindOption) (int, error) {
+			return 0, errors.New("mongo error")
+		}
+		migrator, err := migrations.New(newDatabaseMock(collection), testMigrations)
+		So(err, ShouldBeNil)
+
+		Convey("When the migrations are run, then nothing is applied", func() {
+			_, err := migrator.Run(testContext, false)
+			So(err, ShouldNotBeNil)
+			So(collection.UpdateManyCalls(), ShouldHaveLength, 0)
+		})
+	})
+}
+
+func TestWriteReport(t *testing.T) {
+	Convey("A report lists each migration with the number of documents", t, func() {
+		results := []migrations.Result{{ID: "0001_first", Description: "first migration", Documents: 3, Applied: true}}
+
+		var b bytes.Buffer
+		So(migrations.WriteReport(&b, results, false), ShouldBeNil)
+		So(b.String(), ShouldEqual, "0001_first: migrated 3 document(s) - first migration\n")
+
+		b.Reset()
+		So(migrations.WriteReport(&b, results, true), ShouldBeNil)
+		So(b.String(), ShouldEqual, "0001_first: would migrate 3 document(s) - first migration\n")
+	})
+
+	Convey("A report without migrations says there are none pending", t, func() {
+		var b bytes.Buffer
+		So(migrations.WriteReport(&b, nil, true), ShouldBeNil)
+		So(b.String(), ShouldEqual, "no pending migrations\n")
+	})
+}
@@ -4,63 +4,75 @@
 package mocks
 
 import (
+	"context"
 	"sync"
-
-	kafka "github.com/ONSdigital/dp-kafka/v4"
 )
 
-// KafkaProducerMock is a mock implementation of download.KafkaProducer.
+// OutboxMock is a mock implementation of download.Outbox.
 //
-//	func TestSomethingThatUsesKafkaProducer(t *testing.T) {
+//	func TestSomethingThatUsesOutbox(t *testing.T) {
 //
-//		// make and configure a mocked download.KafkaProducer
-//		mockedKafkaProducer := &KafkaProducerMock{
-//			OutputFunc: func() chan kafka.BytesMessage {
-//				panic("mock out the Output method")
+//		// make and configure a mocked download.Outbox
+//		mockedOutbox := &OutboxMock{
+//			WriteFunc: func(ctx context.Context, message []byte) error {
+//				panic("mock out the Write method")
 //			},
 //		}
 //
-//		// use mockedKafkaProducer in code that requires download.KafkaProducer
+//		// use mockedOutbox in code that requires download.Outbox
 //		// and then make assertions.
 //
 //	}
-type KafkaProducerMock struct {
-	// OutputFunc mocks the Output method.
-	OutputFunc func() chan kafka.BytesMessage
+type OutboxMock struct {
+	// WriteFunc mocks the Write method.
+	WriteFunc func(ctx context.Context, message []byte) error
 
 	// calls tracks calls to the methods.
 	calls struct {
-		// Output holds details about calls to the Output method.
-		Output []struct {
+		// Write holds details about calls to the Write method.
+		Write []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Message is the message argument value.
+			Message []byte
 		}
 	}
-	lockOutput sync.RWMutex
+	lockWrite sync.RWMutex
 }
 
-// Output calls OutputFunc.
-func (mock *KafkaProducerMock) Output() chan kafka.BytesMessage {
-	if mock.OutputFunc == nil {
-		panic("KafkaProducerMock.OutputFunc: method is nil but KafkaProducer.Output was just called")
+// Write calls WriteFunc.
+func (mock *OutboxMock) Write(ctx context.Context, message []byte) error {
+	if mock.WriteFunc == nil {
+		panic("OutboxMock.WriteFunc: method is nil but Outbox.Write was just called")
 	}
 	callInfo := struct {
-	}{}
-	mock.lockOutput.Lock()
-	mock.calls.Output = append(mock.calls.Output, callInfo)
-	mock.lockOutput.Unlock()
-	return mock.OutputFunc()
+		Ctx     context.Context
+		Message []byte
+	}{
+		Ctx:     ctx,
+		Message: message,
+	}
+	mock.lockWrite.Lock()
+	mock.calls.Write = append(mock.calls.Write, callInfo)
+	mock.lockWrite.Unlock()
+	return mock.WriteFunc(ctx, message)
 }
 
-// OutputCalls gets all the calls that were made to Output.
+// WriteCalls gets all the calls that were made to Write.
 // Check the length with:
 //
-//	len(mockedKafkaProducer.OutputCalls())
-func (mock *KafkaProducerMock) OutputCalls() []struct {
+//	len(mockedOutbox.WriteCalls())
+func (mock *OutboxMock) WriteCalls() []struct {
+	Ctx     context.Context
+	Message []byte
 } {
 	var calls []struct {
+		Ctx     context.Context
+		Message []byte
 	}
-	mock.lockOutput.RLock()
-	calls = mock.calls.Output
-	mock.lockOutput.RUnlock()
+	mock.lockWrite.RLock()
+	calls = mock.calls.Write
+	mock.lockWrite.RUnlock()
 	return calls
 }
 
@@ -0,0 +1,94 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// AuditChainLink identifies the first event of the audit log whose link to the previous event is broken
+type AuditChainLink struct {
+	Sequence int64  `json:"sequence"`
+	Reason   string `json:"reason"`
+}
+
+// AuditChainVerification is the outcome of walking the chain of audit events, from the first one
+type AuditChainVerification struct {
+	Valid           bool            `json:"valid"`
+	VerifiedEvents  int64           `json:"verified_events"`
+	FirstBrokenLink *AuditChainLink `json:"first_broken_link,omitempty"`
+}
+
+// ChainTo links the event to the previous event of the audit log, or makes it the first event if previous is nil.
+// The event gets the next sequence number and the hash of the previous event, and then its own hash, so that
+// altering or removing an event breaks the link to the event that follows it.
+func (e *AuditEvent) ChainTo(previous *AuditEvent) error {
+	e.Sequence = 1
+	e.PreviousHash = ""
+	if previous != nil {
+		e.Sequence = previous.Sequence + 1
+		e.PreviousHash = previous.Hash
+	}
+
+	hash, err := e.ComputeHash()
+	if err != nil {
+		return err
+	}
+	e.Hash = hash
+	return nil
+}
+
+// ComputeHash returns the hex encoded SHA-256 hash of the event, leaving out its own hash. The event is normalised
+// through its BSON representation first, so that an event read back from the store has the hash that was computed
+// before it was written, even though the store only keeps times to the millisecond and drops empty fields.
+func (e *AuditEvent) ComputeHash() (string, error) {
+	event := *e
+	event.Hash = ""
+
+	b, err := bson.Marshal(event)
+	if err != nil {
+		return "", err
+	}
+
+	var normalised AuditEvent
+	if err = bson.Unmarshal(b, &normalised); err != nil {
+		return "", err
+	}
+
+	b, err = json.Marshal(normalised)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// CheckChainLink returns the reason why the event is not correctly chained to the previous event of the audit log,
+// or an empty string if it is. A nil previous event means that the event should be the first one.
+func (e *AuditEvent) CheckChainLink(previous *AuditEvent) (string, error) {
+	expectedSequence, expectedPreviousHash := int64(1), ""
+	if previous != nil {
+		expectedSequence, expectedPreviousHash = previous.Sequence+1, previous.Hash
+	}
+
+	if e.Sequence != expectedSequence {
+		return fmt.Sprintf("expected sequence %d, found %d", expectedSequence, e.Sequence), nil
+	}
+	if e.PreviousHash != expectedPreviousHash {
+		return "previous hash does not match the hash of the previous event", nil
+	}
+
+	hash, err := e.ComputeHash()
+	if err != nil {
+		return "", err
+	}
+	if e.Hash != hash {
+		return "hash does not match the content of the event", nil
+	}
+
+	return "", nil
+}
@@ -0,0 +1,110 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func newChainedAuditEvents(resources ...string) []*AuditEvent {
+	var events []*AuditEvent
+	var previous *AuditEvent
+	for _, resource := range resources {
+		event := &AuditEvent{
+			CreatedAt:   time.Date(2025, 1, 1, 10, 30, 0, 123456789, time.UTC),
+			RequestedBy: RequestedBy{ID: "user-1"},
+			Action:      ActionUpdate,
+			Resource:    resource,
+			Dataset:     &Dataset{ID: "cpih01", Keywords: []string{}},
+		}
+		So(event.ChainTo(previous), ShouldBeNil)
+		events = append(events, event)
+		previous = event
+	}
+	return events
+}
+
+func TestChainTo(t *testing.T) {
+	Convey("Given audit events chained to each other", t, func() {
+		events := newChainedAuditEvents("/datasets/cpih01", "/datasets/cpih01/editions/2025")
+
+		Convey("Then the first event starts the chain", func() {
+			So(events[0].Sequence, ShouldEqual, 1)
+			So(events[0].PreviousHash, ShouldBeEmpty)
+			So(events[0].Hash, ShouldHaveLength, 64)
+		})
+
+		Convey("Then the next event is linked to the hash of the first one", func() {
+			So(events[1].Sequence, ShouldEqual, 2)
+			So(events[1].PreviousHash, ShouldEqual, events[0].Hash)
+			So(events[1].Hash, ShouldNotEqual, events[0].Hash)
+		})
+	})
+}
+
+func TestComputeHash(t *testing.T) {
+	Convey("Given a chained audit event", t, func() {
+		event := newChainedAuditEvents("/datasets/cpih01")[0]
+
+		Convey("When it is read back from its BSON representation", func() {
+			b, err := bson.Marshal(event)
+			So(err, ShouldBeNil)
+			var stored AuditEvent
+			So(bson.Unmarshal(b, &stored), ShouldBeNil)
+
+			Convey("Then it has the same hash, even though its time was truncated and its empty fields dropped", func() {
+				So(stored.CreatedAt, ShouldNotEqual, event.CreatedAt)
+				hash, err := stored.ComputeHash()
+				So(err, ShouldBeNil)
+				So(hash, ShouldEqual, event.Hash)
+			})
+		})
+	})
+}
+
+func TestCheckChainLink(t *testing.T) {
+	Convey("Given audit events chained to each other", t, func() {
+		events := newChainedAuditEvents("/datasets/cpih01", "/datasets/cpih01/editions/2025", "/datasets/cpih01/editions/2025/versions/1")
+
+		Convey("Then every link is valid", func() {
+			var previous *AuditEvent
+			for _, event := range events {
+				reason, err := event.CheckChainLink(previous)
+				So(err, ShouldBeNil)
+				So(reason, ShouldBeEmpty)
+				previous = event
+			}
+		})
+
+		Convey("When an event is altered", func() {
+			events[1].RequestedBy.ID = "user-2"
+
+			Convey("Then its hash no longer matches its content", func() {
+				reason, err := events[1].CheckChainLink(events[0])
+				So(err, ShouldBeNil)
+				So(reason, ShouldEqual, "hash does not match the content of the event")
+			})
+		})
+
+		Convey("When an event is altered and hashed again", func() {
+			events[1].RequestedBy.ID = "user-2"
+			So(events[1].ChainTo(events[0]), ShouldBeNil)
+
+			Convey("Then the link of the next event is broken", func() {
+				reason, err := events[2].CheckChainLink(events[1])
+				So(err, ShouldBeNil)
+				So(reason, ShouldEqual, "previous hash does not match the hash of the previous event")
+			})
+		})
+
+		Convey("When an event is removed", func() {
+			Convey("Then the sequence of the next event is not the expected one", func() {
+				reason, err := events[2].CheckChainLink(events[0])
+				So(err, ShouldBeNil)
+				So(reason, ShouldEqual, "expected sequence 2, found 3")
+			})
+		})
+	})
+}
@@ -1,20 +1,34 @@
 package models
 
 import (
+	"encoding/json"
 	"errors"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 )
 
-// AuditEvent represents an audit log entry for actions performed on a dataset, version, edition, or metadata
+// AuditEvent represents an audit log entry for actions performed on a dataset, version, edition, metadata, instance
+// or dimension options
 type AuditEvent struct {
-	CreatedAt   time.Time   `bson:"created_at" json:"created_at"`
-	RequestedBy RequestedBy `bson:"requested_by" json:"requested_by"`
-	Action      Action      `bson:"action" json:"action"`
-	Resource    string      `bson:"resource" json:"resource"`
-	Dataset     *Dataset    `bson:"dataset,omitempty" json:"dataset,omitempty"`
-	Version     *Version    `bson:"version,omitempty" json:"version,omitempty"`
-	Edition     *Edition    `bson:"edition,omitempty" json:"edition,omitempty"`
-	Metadata    *Metadata   `bson:"metadata,omitempty" json:"metadata,omitempty"`
+	CreatedAt        time.Time                `bson:"created_at" json:"created_at"`
+	RequestedBy      RequestedBy              `bson:"requested_by" json:"requested_by"`
+	Action           Action                   `bson:"action" json:"action"`
+	Resource         string                   `bson:"resource" json:"resource"`
+	DatasetID        string                   `bson:"dataset_id,omitempty" json:"dataset_id,omitempty"`
+	Dataset          *Dataset                 `bson:"dataset,omitempty" json:"dataset,omitempty"`
+	Version          *Version                 `bson:"version,omitempty" json:"version,omitempty"`
+	Edition          *Edition                 `bson:"edition,omitempty" json:"edition,omitempty"`
+	Metadata         *Metadata                `bson:"metadata,omitempty" json:"metadata,omitempty"`
+	Instance         *Instance                `bson:"instance,omitempty" json:"instance,omitempty"`
+	DimensionOptions []*CachedDimensionOption `bson:"dimension_options,omitempty" json:"dimension_options,omitempty"`
+	// Changes lists the fields changed by the action, when the previous state of the resource is known
+	Changes []FieldChange `bson:"changes,omitempty" json:"changes,omitempty"`
+	// Sequence, PreviousHash and Hash chain the event to the one recorded before it, see ChainTo
+	Sequence     int64  `bson:"sequence,omitempty" json:"sequence,omitempty"`
+	PreviousHash string `bson:"previous_hash,omitempty" json:"previous_hash,omitempty"`
+	Hash         string `bson:"hash,omitempty" json:"hash,omitempty"`
 }
 
 // RequestedBy contains information about the user who initiated the action
@@ -27,15 +41,38 @@ type RequestedBy struct {
 type Action string
 
 const (
-	ActionCreate Action = "CREATE"
-	ActionRead   Action = "READ"
-	ActionUpdate Action = "UPDATE"
-	ActionDelete Action = "DELETE"
+	ActionCreate  Action = "CREATE"
+	ActionRead    Action = "READ"
+	ActionUpdate  Action = "UPDATE"
+	ActionDelete  Action = "DELETE"
+	ActionRestore Action = "RESTORE"
 )
 
+// IsValid returns true if the action is one of the audited actions
+func (a Action) IsValid() bool {
+	switch a {
+	case ActionCreate, ActionRead, ActionUpdate, ActionDelete, ActionRestore:
+		return true
+	}
+	return false
+}
+
+// AuditEventFilter selects the audit events to return. Empty fields match every event.
+type AuditEventFilter struct {
+	// Resource matches the events of a resource path and of the resources below it
+	Resource string
+	// DatasetID matches the events of a dataset and of its editions, versions, instances and dimension options
+	DatasetID   string
+	Action      Action
+	RequestedBy string
+	// From and To select the events created at or after From and before To
+	From *time.Time
+	To   *time.Time
+}
+
 // NewAuditEvent creates a new AuditEvent instance
-// It requires exactly one of dataset, version, edition, or metadata to be provided
-func NewAuditEvent(requestedBy RequestedBy, action Action, resource string, dataset *Dataset, version *Version, edition *Edition, metadata *Metadata) (*AuditEvent, error) {
+// It requires exactly one of dataset, version, edition, metadata, instance or dimension options to be provided
+func NewAuditEvent(requestedBy RequestedBy, action Action, resource string, dataset *Dataset, version *Version, edition *Edition, metadata *Metadata, instance *Instance, dimensionOptions []*CachedDimensionOption) (*AuditEvent, error) {
 	provided := 0
 	if dataset != nil {
 		provided++
@@ -49,19 +86,128 @@ func NewAuditEvent(requestedBy RequestedBy, action Action, resource string, data
 	if metadata != nil {
 		provided++
 	}
+	if instance != nil {
+		provided++
+	}
+	if len(dimensionOptions) > 0 {
+		provided++
+	}
 
 	if provided != 1 {
-		return nil, errors.New("exactly one of dataset, version, edition, or metadata must be provided")
+		return nil, errors.New("exactly one of dataset, version, edition, metadata, instance or dimension options must be provided")
 	}
 
 	return &AuditEvent{
-		CreatedAt:   time.Now().UTC(),
-		RequestedBy: requestedBy,
-		Action:      action,
-		Resource:    resource,
-		Dataset:     dataset,
-		Version:     version,
-		Edition:     edition,
-		Metadata:    metadata,
+		CreatedAt:        time.Now().UTC(),
+		RequestedBy:      requestedBy,
+		Action:           action,
+		Resource:         resource,
+		DatasetID:        resourceDatasetID(resource),
+		Dataset:          dataset,
+		Version:          version,
+		Edition:          edition,
+		Metadata:         metadata,
+		Instance:         instance,
+		DimensionOptions: dimensionOptions,
 	}, nil
 }
+
+// resourceDatasetID returns the ID of the dataset of a resource whose path starts with the path of its dataset, or an
+// empty string for the other resources
+func resourceDatasetID(resource string) string {
+	path, ok := strings.CutPrefix(resource, "/datasets/")
+	if !ok {
+		return ""
+	}
+	id, _, _ := strings.Cut(path, "/")
+	return id
+}
+
+// DiffFields returns the fields of current that differ from previous, identified by their path in the JSON
+// representation of the resource, such as "links.self.href" or "keywords.0". Fields that are absent from current are
+// left out, as updates only change the fields they provide, but the items removed from a list are included.
+func DiffFields(previous, current interface{}) ([]FieldChange, error) {
+	from, _, err := flattenFields(previous)
+	if err != nil {
+		return nil, err
+	}
+
+	to, lists, err := flattenFields(current)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make([]string, 0, len(to))
+	for path := range to {
+		paths = append(paths, path)
+	}
+	for path := range from {
+		if _, ok := to[path]; !ok && inList(path, lists) {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+
+	var changes []FieldChange
+	for _, path := range paths {
+		if from[path] != to[path] {
+			changes = append(changes, FieldChange{Field: path, From: from[path], To: to[path]})
+		}
+	}
+
+	return changes, nil
+}
+
+// flattenFields returns the values of a resource by their path in its JSON representation, along with the paths of
+// the lists it contains. Objects and lists are flattened so that every value is a string, number, bool or nil.
+func flattenFields(resource interface{}) (fields map[string]interface{}, lists map[string]bool, err error) {
+	b, err := json.Marshal(resource)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(b, &value); err != nil {
+		return nil, nil, err
+	}
+
+	fields = map[string]interface{}{}
+	lists = map[string]bool{}
+
+	var flatten func(path string, value interface{})
+	flatten = func(path string, value interface{}) {
+		join := func(key string) string {
+			if path == "" {
+				return key
+			}
+			return path + "." + key
+		}
+
+		switch v := value.(type) {
+		case map[string]interface{}:
+			for key, item := range v {
+				flatten(join(key), item)
+			}
+		case []interface{}:
+			lists[path] = true
+			for i, item := range v {
+				flatten(join(strconv.Itoa(i)), item)
+			}
+		default:
+			fields[path] = v
+		}
+	}
+	flatten("", value)
+
+	return fields, lists, nil
+}
+
+// inList returns true if the path is within one of the lists
+func inList(path string, lists map[string]bool) bool {
+	for i := strings.LastIndex(path, "."); i > 0; i = strings.LastIndex(path[:i], ".") {
+		if lists[path[:i]] {
+			return true
+		}
+	}
+	return false
+}
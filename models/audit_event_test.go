@@ -15,6 +15,7 @@ func TestNewAuditEvent(t *testing.T) {
 		resource    string
 		dataset     *Dataset
 		version     *Version
+		instance    *Instance
 		expectedErr error
 	}{
 		{
@@ -24,7 +25,7 @@ func TestNewAuditEvent(t *testing.T) {
 			resource:    "/datasets/dataset-1",
 			dataset:     nil,
 			version:     nil,
-			expectedErr: errors.New("exactly one of dataset, version, edition, or metadata must be provided"),
+			expectedErr: errors.New("exactly one of dataset, version, edition, metadata, instance or dimension options must be provided"),
 		},
 		{
 			name:        "both dataset and version are provided",
@@ -33,7 +34,7 @@ func TestNewAuditEvent(t *testing.T) {
 			resource:    "/datasets/dataset-1",
 			dataset:     &Dataset{ID: "dataset-1"},
 			version:     &Version{ID: "version-1"},
-			expectedErr: errors.New("exactly one of dataset, version, edition, or metadata must be provided"),
+			expectedErr: errors.New("exactly one of dataset, version, edition, metadata, instance or dimension options must be provided"),
 		},
 		{
 			name:        "only dataset is provided",
@@ -44,6 +45,23 @@ func TestNewAuditEvent(t *testing.T) {
 			version:     nil,
 			expectedErr: nil,
 		},
+		{
+			name:        "both dataset and instance are provided",
+			requestedBy: RequestedBy{ID: "user-1"},
+			action:      ActionUpdate,
+			resource:    "/instances/instance-1",
+			dataset:     &Dataset{ID: "dataset-1"},
+			instance:    &Instance{InstanceID: "instance-1"},
+			expectedErr: errors.New("exactly one of dataset, version, edition, metadata, instance or dimension options must be provided"),
+		},
+		{
+			name:        "only instance is provided",
+			requestedBy: RequestedBy{ID: "user-1"},
+			action:      ActionUpdate,
+			resource:    "/instances/instance-1",
+			instance:    &Instance{InstanceID: "instance-1"},
+			expectedErr: nil,
+		},
 		{
 			name:        "only version is provided",
 			requestedBy: RequestedBy{ID: "user-1"},
@@ -58,7 +76,7 @@ func TestNewAuditEvent(t *testing.T) {
 	Convey("NewAuditEvent input validation", t, func() {
 		for _, tc := range testCases {
 			Convey(tc.name, func() {
-				_, err := NewAuditEvent(tc.requestedBy, tc.action, tc.resource, tc.dataset, tc.version, nil, nil)
+				_, err := NewAuditEvent(tc.requestedBy, tc.action, tc.resource, tc.dataset, tc.version, nil, nil, tc.instance, nil)
 				So(err, ShouldEqual, tc.expectedErr)
 			})
 		}
@@ -70,13 +88,54 @@ func TestNewAuditEvent(t *testing.T) {
 		resource := "/datasets/dataset-1"
 		dataset := &Dataset{ID: "dataset-1"}
 
-		auditEvent, err := NewAuditEvent(requestedBy, action, resource, dataset, nil, nil, nil)
+		auditEvent, err := NewAuditEvent(requestedBy, action, resource, dataset, nil, nil, nil, nil, nil)
 		So(err, ShouldBeNil)
 		So(auditEvent.CreatedAt.IsZero(), ShouldBeFalse)
 		So(auditEvent.RequestedBy, ShouldResemble, requestedBy)
 		So(auditEvent.Action, ShouldEqual, action)
 		So(auditEvent.Resource, ShouldEqual, resource)
+		So(auditEvent.DatasetID, ShouldEqual, "dataset-1")
 		So(auditEvent.Dataset, ShouldResemble, dataset)
 		So(auditEvent.Version, ShouldBeNil)
 	})
 }
+
+func TestDiffFields(t *testing.T) {
+	Convey("Given the previous and the updated state of a dataset", t, func() {
+		previous := &Dataset{
+			ID:       "dataset-1",
+			Title:    "Old title",
+			Keywords: []string{"inflation", "prices", "cpi"},
+			Links:    &DatasetLinks{Self: &LinkObject{HRef: "/datasets/dataset-1"}},
+			License:  "ONS License",
+		}
+		current := &Dataset{
+			ID:       "dataset-1",
+			Title:    "New title",
+			Keywords: []string{"inflation", "costs"},
+			Links:    &DatasetLinks{Self: &LinkObject{HRef: "/datasets/dataset-1"}},
+		}
+
+		Convey("Then the changed fields are returned by path, including the removed list items", func() {
+			changes, err := DiffFields(previous, current)
+			So(err, ShouldBeNil)
+			So(changes, ShouldResemble, []FieldChange{
+				{Field: "keywords.1", From: "prices", To: "costs"},
+				{Field: "keywords.2", From: "cpi", To: nil},
+				{Field: "title", From: "Old title", To: "New title"},
+			})
+		})
+
+		Convey("Then the fields absent from the updated state are not reported as changed", func() {
+			changes, err := DiffFields(previous, &Dataset{ID: "dataset-1", License: "OGL"})
+			So(err, ShouldBeNil)
+			So(changes, ShouldResemble, []FieldChange{{Field: "license", From: "ONS License", To: "OGL"}})
+		})
+
+		Convey("Then no changes are returned for identical states", func() {
+			changes, err := DiffFields(previous, previous)
+			So(err, ShouldBeNil)
+			So(changes, ShouldBeEmpty)
+		})
+	})
+}
@@ -0,0 +1,57 @@
+package models
+
+// The resources of a collection that are published with it
+const (
+	CollectionItemDataset = "dataset"
+	CollectionItemVersion = "version"
+)
+
+// The outcomes of publishing one of the datasets or versions of a collection
+const (
+	// CollectionItemPublished means that the item was published
+	CollectionItemPublished = "published"
+	// CollectionItemInvalid means that the item failed the checks made before publishing the collection
+	CollectionItemInvalid = "invalid"
+	// CollectionItemFailed means that publishing the item failed
+	CollectionItemFailed = "failed"
+	// CollectionItemNotPublished means that the item was not published because another item of the collection
+	// could not be
+	CollectionItemNotPublished = "not_published"
+)
+
+// CollectionPublish is the outcome of publishing the datasets and versions of a collection as one unit: either every
+// item of the collection is published or none are
+type CollectionPublish struct {
+	CollectionID string                  `json:"collection_id"`
+	Published    bool                    `json:"published"`
+	Items        []CollectionPublishItem `json:"items"`
+}
+
+// CollectionPublishItem is the outcome of publishing one of the datasets or versions of a collection, as identified by
+// its resource. Error is the reason the item was not published or, for a published item, the reason that completing
+// its publication failed.
+type CollectionPublishItem struct {
+	Resource  string           `json:"resource"`
+	DatasetID string           `json:"dataset_id"`
+	Edition   string           `json:"edition,omitempty"`
+	Version   int              `json:"version,omitempty"`
+	State     string           `json:"state"`
+	Outcome   string           `json:"outcome"`
+	Checks    []PreflightCheck `json:"checks,omitempty"`
+	Error     string           `json:"error,omitempty"`
+}
+
+// NewCollectionPublish returns the outcome of publishing the provided items of a collection, which was published if
+// every item was
+func NewCollectionPublish(collectionID string, items []CollectionPublishItem) *CollectionPublish {
+	published := len(items) > 0
+	for i := range items {
+		published = published && items[i].Outcome == CollectionItemPublished
+	}
+
+	return &CollectionPublish{
+		CollectionID: collectionID,
+		Published:    published,
+		Items:        items,
+	}
+}
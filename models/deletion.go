@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// Deletion records when and by whom a dataset, edition or static version was deleted. Deleted documents are kept,
+// hidden from every read, until they are restored or purged once the retention period is over.
+type Deletion struct {
+	DeletedAt time.Time `bson:"deleted_at" json:"deleted_at"`
+	DeletedBy string    `bson:"deleted_by" json:"deleted_by"`
+}
+
+// NewDeletion creates a Deletion by the provided user at the current time. The time is truncated to the precision
+// stored by mongo, so that the documents deleted together can be matched by it.
+func NewDeletion(deletedBy string) *Deletion {
+	return &Deletion{
+		DeletedAt: time.Now().UTC().Truncate(time.Millisecond),
+		DeletedBy: deletedBy,
+	}
+}
@@ -56,6 +56,7 @@ type PublicDimensionOption struct {
 	Links  DimensionOptionLinks `bson:"links,omitempty"          json:"links"`
 	Name   string               `bson:"name,omitempty"           json:"dimension"`
 	Option string               `bson:"option,omitempty"         json:"option"`
+	Order  *int                 `bson:"order,omitempty"          json:"-"`
 }
 
 // DimensionOptionLinks represents a list of link objects related to dimension options
@@ -19,6 +19,7 @@ const (
 	ErrEditionAlreadyExists      = "ErrEditionAlreadyExists"
 	ErrEditionTitleAlreadyExists = "ErrEditionTitleAlreadyExists"
 	ErrNoSpacesAllowedError      = "ErrSpacesNotAllowed"
+	ErrTransitionGuardFailed     = "ErrTransitionGuardFailed"
 )
 
 // API error descriptions
@@ -40,4 +41,7 @@ const (
 	ErrTypeNotStaticDescription                   = "version type should be static"
 	ErrEditionAlreadyExistsDescription            = "edition already exists"
 	ErrEditionTitleAlreadyExistsDescription       = "edition title already exists"
+	ErrMissingDistributionsDescription            = "version has no distributions"
+	ErrMissingQualityDesignationDescription       = "version has no quality_designation"
+	ErrImportTasksIncompleteDescription           = "import task has not completed"
 )
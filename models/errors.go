@@ -2,6 +2,7 @@ package models
 
 import (
 	"errors"
+	"strings"
 )
 
 // Error represents a custom error type containing a cause, code, and description.
@@ -38,3 +39,16 @@ func NewValidationError(code, description string) Error {
 	}
 	return err
 }
+
+// ValidationErrors is returned when a request fails one or more validation checks, with an Error for each check that
+// failed, so that they can all be returned to the caller together
+type ValidationErrors []Error
+
+// Error returns the descriptions of the validation errors
+func (v ValidationErrors) Error() string {
+	descriptions := make([]string, 0, len(v))
+	for _, err := range v {
+		descriptions = append(descriptions, err.Description)
+	}
+	return "validation failed: " + strings.Join(descriptions, ", ")
+}
@@ -38,6 +38,7 @@ type Instance struct {
 	Description       string               `bson:"description,omitempty"                 json:"description,omitempty"`
 	Title             string               `bson:"title,omitempty"                       json:"title,omitempty"`
 	NextRelease       string               `bson:"next_release,omitempty"                json:"next_release,omitempty"`
+	StateHistory      []StateChange        `bson:"state_history,omitempty"               json:"-"` // only returned by the state history endpoint of the version
 }
 
 // Hash generates a SHA-1 hash of the instance struct. SHA-1 is not cryptographically safe,
@@ -33,6 +33,7 @@ type Metadata struct {
 	Type            string               `json:"type,omitempty"`
 	URI             string               `json:"uri,omitempty"`
 	Version         int                  `json:"version,omitempty"`
+	ReviewComments  []ReviewComment      `json:"review_comments,omitempty"`
 	EditableMetadata
 }
 
@@ -226,12 +226,18 @@ func TestCreateMetadata(t *testing.T) {
 			websiteURL := &neturl.URL{Scheme: "http", Host: "localhost:20000"}
 			apiRouterPublicURL := &neturl.URL{Scheme: "http", Host: "localhost:23200", Path: "v1"}
 			urlBuilder := url.NewBuilder(websiteURL, downloadServiceURL, datasetAPIURL, codeListAPIURL, importAPIURL, apiRouterPublicURL)
-			metaDataDoc := CreateMetaDataDoc(&staticDataset, &version, urlBuilder)
+			rejectedVersion := version
+			rejectedVersion.ReviewComments = []ReviewComment{NewReviewComment("the figures do not match the source", "reviewer@ons.gov.uk")}
+			metaDataDoc := CreateMetaDataDoc(&staticDataset, &rejectedVersion, urlBuilder)
 
 			Convey("Then it returns a metadata object with topics populated", func() {
 				So(metaDataDoc.Topics, ShouldResemble, staticDataset.Topics)
 			})
 
+			Convey("And the review comments are not set, as they are only shown to publishers", func() {
+				So(metaDataDoc.ReviewComments, ShouldBeNil)
+			})
+
 			Convey("And the state is set from the version", func() {
 				So(metaDataDoc.State, ShouldEqual, version.State)
 			})
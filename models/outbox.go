@@ -0,0 +1,50 @@
+package models
+
+import (
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// Outbox message states
+const (
+	OutboxMessagePending    = "pending"
+	OutboxMessageDelivering = "delivering"
+	OutboxMessageSent       = "sent"
+	OutboxMessageFailed     = "failed"
+)
+
+// OutboxMessage represents a kafka message that has been stored alongside the changes that caused it,
+// waiting to be delivered to its topic by the outbox relay. A message being delivered is claimed by a single relay
+// until its lease expires, after which it can be claimed again.
+type OutboxMessage struct {
+	ID             string     `bson:"_id"                        json:"id"`
+	Topic          string     `bson:"topic"                      json:"topic"`
+	Payload        []byte     `bson:"payload"                    json:"payload"`
+	State          string     `bson:"state"                      json:"state"`
+	Attempts       int        `bson:"attempts"                   json:"attempts"`
+	LastError      string     `bson:"last_error,omitempty"       json:"last_error,omitempty"`
+	CreatedAt      time.Time  `bson:"created_at"                 json:"created_at"`
+	NextAttemptAt  time.Time  `bson:"next_attempt_at"            json:"next_attempt_at"`
+	SentAt         *time.Time `bson:"sent_at,omitempty"          json:"sent_at,omitempty"`
+	ClaimedBy      string     `bson:"claimed_by,omitempty"       json:"claimed_by,omitempty"`
+	LeaseExpiresAt *time.Time `bson:"lease_expires_at,omitempty" json:"lease_expires_at,omitempty"`
+}
+
+// NewOutboxMessage creates a pending outbox message for the provided topic and payload
+func NewOutboxMessage(topic string, payload []byte) (*OutboxMessage, error) {
+	id, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	return &OutboxMessage{
+		ID:            id.String(),
+		Topic:         topic,
+		Payload:       payload,
+		State:         OutboxMessagePending,
+		CreatedAt:     now,
+		NextAttemptAt: now,
+	}, nil
+}
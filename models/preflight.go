@@ -0,0 +1,45 @@
+package models
+
+// PreflightCheck is the result of one of the checks made on a version before it is published
+type PreflightCheck struct {
+	Name   string `json:"name"`
+	Passed bool   `json:"passed"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// NewPreflightCheck returns the result of a check, which has passed if err is nil, or failed for the reason given by err
+func NewPreflightCheck(name string, err error) PreflightCheck {
+	check := PreflightCheck{Name: name, Passed: err == nil}
+	if err != nil {
+		check.Reason = err.Error()
+	}
+	return check
+}
+
+// PublishPreflight is the checklist of the checks made, without changing anything, on whether a version will publish
+// cleanly. The version will only publish if every check has passed.
+type PublishPreflight struct {
+	DatasetID string           `json:"dataset_id"`
+	Edition   string           `json:"edition"`
+	Version   int              `json:"version"`
+	State     string           `json:"state"`
+	Passed    bool             `json:"passed"`
+	Checks    []PreflightCheck `json:"checks"`
+}
+
+// NewPublishPreflight returns the checklist of the checks made on a version in the provided state
+func NewPublishPreflight(datasetID, edition string, version int, state string, checks []PreflightCheck) *PublishPreflight {
+	passed := true
+	for _, check := range checks {
+		passed = passed && check.Passed
+	}
+
+	return &PublishPreflight{
+		DatasetID: datasetID,
+		Edition:   edition,
+		Version:   version,
+		State:     state,
+		Passed:    passed,
+		Checks:    checks,
+	}
+}
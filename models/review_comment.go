@@ -0,0 +1,38 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// ReviewComment is left by a reviewer when an approved static version is rejected and sent back to associated, so
+// that the publisher knows what needs to change before the version is approved again
+type ReviewComment struct {
+	Comment     string    `bson:"comment"      json:"comment"`
+	CommentedAt time.Time `bson:"commented_at" json:"commented_at"`
+	CommentedBy string    `bson:"commented_by" json:"commented_by,omitempty"`
+}
+
+// NewReviewComment creates a ReviewComment with the provided comment, by the provided user at the current time
+func NewReviewComment(comment, commentedBy string) ReviewComment {
+	return ReviewComment{
+		Comment:     comment,
+		CommentedAt: time.Now().UTC().Truncate(time.Millisecond),
+		CommentedBy: commentedBy,
+	}
+}
+
+// ValidateVersionRejection checks that a version moving from approved back to associated has been given a new review
+// comment explaining why it was rejected
+func ValidateVersionRejection(currentVersion, versionUpdate *Version) error {
+	if len(versionUpdate.ReviewComments) <= len(currentVersion.ReviewComments) {
+		return ErrRejectedVersionCommentMissing
+	}
+
+	latest := versionUpdate.ReviewComments[len(versionUpdate.ReviewComments)-1]
+	if strings.TrimSpace(latest.Comment) == "" {
+		return ErrRejectedVersionCommentMissing
+	}
+
+	return nil
+}
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// DatasetRevision is an immutable copy of the metadata of a dataset, stored each time the metadata is updated,
+// so that earlier titles, contacts and topics remain available once they have been overwritten
+type DatasetRevision struct {
+	ID        string    `bson:"_id"        json:"-"`
+	DatasetID string    `bson:"dataset_id" json:"dataset_id"`
+	Revision  int       `bson:"revision"   json:"revision"`
+	Dataset   *Dataset  `bson:"dataset"    json:"dataset"`
+	CreatedAt time.Time `bson:"created_at" json:"created_at"`
+}
+
+// NewDatasetRevision creates the revision numbered revision of a dataset, holding the provided metadata
+func NewDatasetRevision(datasetID string, revision int, dataset *Dataset) (*DatasetRevision, error) {
+	id, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+
+	return &DatasetRevision{
+		ID:        id.String(),
+		DatasetID: datasetID,
+		Revision:  revision,
+		Dataset:   dataset,
+		CreatedAt: time.Now().UTC(),
+	}, nil
+}
@@ -0,0 +1,56 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrReleaseDateInvalid is returned when the release date of a version is neither an RFC3339 time nor a date
+var ErrReleaseDateInvalid = errors.New("release date is neither an RFC3339 time nor a date")
+
+// ScheduledPublish is an approved static version that is published automatically at its release date. A version whose
+// release date is not valid has no release date and an error instead, as it can only be published manually.
+type ScheduledPublish struct {
+	DatasetID   string     `json:"dataset_id"`
+	Edition     string     `json:"edition"`
+	Version     int        `json:"version"`
+	VersionID   string     `json:"version_id"`
+	ReleaseDate *time.Time `json:"release_date,omitempty"`
+	Error       string     `json:"error,omitempty"`
+}
+
+// NewScheduledPublish returns the scheduled publish of an approved static version, from its release date
+func NewScheduledPublish(version *Version) *ScheduledPublish {
+	datasetID := version.DatasetID
+	if version.Links != nil && version.Links.Dataset != nil {
+		datasetID = version.Links.Dataset.ID
+	}
+
+	scheduled := &ScheduledPublish{
+		DatasetID: datasetID,
+		Edition:   version.Edition,
+		Version:   version.Version,
+		VersionID: version.ID,
+	}
+
+	releaseDate, err := ParseReleaseDate(version.ReleaseDate)
+	if err != nil {
+		scheduled.Error = err.Error()
+		return scheduled
+	}
+	scheduled.ReleaseDate = &releaseDate
+
+	return scheduled
+}
+
+// ParseReleaseDate parses the release date of a version, which is an RFC3339 time such as 2025-01-01T07:00:00.000Z,
+// or a date such as 2025-01-01 for the start of the day in UTC
+func ParseReleaseDate(releaseDate string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, releaseDate); err == nil {
+		return t.UTC(), nil
+	}
+	if t, err := time.Parse(time.DateOnly, releaseDate); err == nil {
+		return t, nil
+	}
+	return time.Time{}, ErrReleaseDateInvalid
+}
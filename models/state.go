@@ -15,6 +15,7 @@ const (
 	AssociatedState       = "associated"
 	ApprovedState         = "approved"
 	PublishedState        = "published"
+	WithdrawnState        = "withdrawn"
 	DetachedState         = "detached"
 	FailedState           = "failed"
 )
@@ -24,6 +25,7 @@ var validVersionStates = map[string]int{
 	AssociatedState:       1,
 	ApprovedState:         1,
 	PublishedState:        1,
+	WithdrawnState:        1,
 }
 
 var validStates = map[string]int{
@@ -34,6 +36,7 @@ var validStates = map[string]int{
 	ApprovedState:         1,
 	AssociatedState:       1,
 	PublishedState:        1,
+	WithdrawnState:        1,
 	FailedState:           1,
 }
 
@@ -83,4 +86,9 @@ func CheckState(docType, state string) error {
 
 type StateUpdate struct {
 	State string `json:"state"`
+	// Reason is required when a version is withdrawn
+	Reason string `json:"reason,omitempty"`
+	// Comment is added to the state history of the version, and is required when an approved version is rejected
+	// back to associated
+	Comment string `json:"comment,omitempty"`
 }
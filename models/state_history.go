@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+// StateChange records a version being moved from one state to another by the state machine
+type StateChange struct {
+	From      string    `bson:"from"              json:"from"`
+	To        string    `bson:"to"                json:"to"`
+	ChangedBy string    `bson:"changed_by"        json:"changed_by,omitempty"`
+	ChangedAt time.Time `bson:"changed_at"        json:"changed_at"`
+	Comment   string    `bson:"comment,omitempty" json:"comment,omitempty"`
+}
+
+// NewStateChange creates a StateChange from one state to another, by the provided user at the current time
+func NewStateChange(from, to, changedBy, comment string) StateChange {
+	return StateChange{
+		From:      from,
+		To:        to,
+		ChangedBy: changedBy,
+		ChangedAt: time.Now().UTC().Truncate(time.Millisecond),
+		Comment:   comment,
+	}
+}
+
+// VersionStateHistory is the current state of a version, with every change of state made to it, oldest first
+type VersionStateHistory struct {
+	DatasetID    string        `json:"dataset_id"`
+	Edition      string        `json:"edition"`
+	Version      int           `json:"version"`
+	State        string        `json:"state"`
+	StateHistory []StateChange `json:"state_history"`
+}
@@ -0,0 +1,98 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// StateMachineWorkflow describes the transitions that the state machine allows for the versions of a type of dataset
+type StateMachineWorkflow struct {
+	Type        string                   `json:"type"`
+	Transitions []StateMachineTransition `json:"transitions"`
+}
+
+// StateMachineTransition allows versions to be moved to a state from any of the allowed source states
+type StateMachineTransition struct {
+	State               string   `json:"state"`
+	AllowedSourceStates []string `json:"allowed_source_states"`
+	Guards              []string `json:"guards,omitempty"`
+}
+
+// StateMachineWorkflows is the list of the workflows of the state machine
+type StateMachineWorkflows struct {
+	Workflows []StateMachineWorkflow `json:"workflows"`
+}
+
+// AllowedTransitions lists the states that a version can be moved to from its current state
+type AllowedTransitions struct {
+	Type              string   `json:"type"`
+	State             string   `json:"state"`
+	AllowedNextStates []string `json:"allowed_next_states"`
+}
+
+// RenderMermaid returns a Mermaid state diagram of the workflows, with a composite state for each type of dataset
+func RenderMermaid(workflows []StateMachineWorkflow) string {
+	var b strings.Builder
+	b.WriteString("stateDiagram-v2\n")
+	for _, workflow := range workflows {
+		id := mermaidID(workflow.Type)
+		fmt.Fprintf(&b, "    state %q as %s\n", workflow.Type, id)
+		fmt.Fprintf(&b, "    state %s {\n", id)
+		for _, state := range workflowStates(workflow) {
+			fmt.Fprintf(&b, "        state %q as %s_%s\n", state, id, mermaidID(state))
+		}
+		for _, transition := range workflow.Transitions {
+			for _, source := range transition.AllowedSourceStates {
+				fmt.Fprintf(&b, "        %s_%s --> %s_%s\n", id, mermaidID(source), id, mermaidID(transition.State))
+			}
+		}
+		b.WriteString("    }\n")
+	}
+	return b.String()
+}
+
+// RenderDOT returns a Graphviz DOT graph of the workflows, with a cluster for each type of dataset
+func RenderDOT(workflows []StateMachineWorkflow) string {
+	var b strings.Builder
+	b.WriteString("digraph state_machine {\n")
+	for _, workflow := range workflows {
+		fmt.Fprintf(&b, "    subgraph %q {\n", "cluster_"+workflow.Type)
+		fmt.Fprintf(&b, "        label=%q;\n", workflow.Type)
+		for _, state := range workflowStates(workflow) {
+			fmt.Fprintf(&b, "        %q [label=%q];\n", workflow.Type+"/"+state, state)
+		}
+		for _, transition := range workflow.Transitions {
+			for _, source := range transition.AllowedSourceStates {
+				fmt.Fprintf(&b, "        %q -> %q;\n", workflow.Type+"/"+source, workflow.Type+"/"+transition.State)
+			}
+		}
+		b.WriteString("    }\n")
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// workflowStates returns the states of a workflow, in the order that they first appear in its transitions
+func workflowStates(workflow StateMachineWorkflow) []string {
+	var states []string
+	seen := map[string]bool{}
+	add := func(state string) {
+		if !seen[state] {
+			seen[state] = true
+			states = append(states, state)
+		}
+	}
+
+	for _, transition := range workflow.Transitions {
+		for _, source := range transition.AllowedSourceStates {
+			add(source)
+		}
+		add(transition.State)
+	}
+	return states
+}
+
+// mermaidID returns a Mermaid identifier for a state or dataset type, which cannot contain dashes
+func mermaidID(name string) string {
+	return strings.ReplaceAll(name, "-", "_")
+}
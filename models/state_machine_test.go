@@ -0,0 +1,56 @@
+package models
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+var testWorkflows = []StateMachineWorkflow{{
+	Type: "static",
+	Transitions: []StateMachineTransition{
+		{State: "approved", AllowedSourceStates: []string{"associated"}},
+		{State: "associated", AllowedSourceStates: []string{"created", "associated"}},
+	},
+}}
+
+func TestRenderMermaid(t *testing.T) {
+	Convey("When workflows are rendered as a Mermaid state diagram", t, func() {
+		diagram := RenderMermaid(testWorkflows)
+
+		Convey("Then each workflow is a composite state, with a transition from each allowed source state", func() {
+			So(diagram, ShouldEqual, `stateDiagram-v2
+    state "static" as static
+    state static {
+        state "associated" as static_associated
+        state "approved" as static_approved
+        state "created" as static_created
+        static_associated --> static_approved
+        static_created --> static_associated
+        static_associated --> static_associated
+    }
+`)
+		})
+	})
+}
+
+func TestRenderDOT(t *testing.T) {
+	Convey("When workflows are rendered as a Graphviz graph", t, func() {
+		graph := RenderDOT(testWorkflows)
+
+		Convey("Then each workflow is a cluster, with an edge from each allowed source state", func() {
+			So(graph, ShouldEqual, `digraph state_machine {
+    subgraph "cluster_static" {
+        label="static";
+        "static/associated" [label="associated"];
+        "static/approved" [label="approved"];
+        "static/created" [label="created"];
+        "static/associated" -> "static/approved";
+        "static/created" -> "static/associated";
+        "static/associated" -> "static/associated";
+    }
+}
+`)
+		})
+	})
+}
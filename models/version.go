@@ -21,8 +21,10 @@ import (
 var (
 	ErrAssociatedVersionCollectionIDInvalid = errors.New("missing collection_id for association between version and a collection")
 	ErrPublishedVersionCollectionIDInvalid  = errors.New("unexpected collection_id in published version")
+	ErrRejectedVersionCommentMissing        = errors.New("missing comment for rejecting approved version")
 	ErrVersionStateDatasetTypeInvalid       = errors.New("incorrect state for dataset type")
-	ErrVersionStateInvalid                  = errors.New("incorrect state, can be one of the following: edition-confirmed, associated, approved or published")
+	ErrVersionStateInvalid                  = errors.New("incorrect state, can be one of the following: edition-confirmed, associated, approved, published or withdrawn")
+	ErrWithdrawnVersionReasonMissing        = errors.New("missing reason for withdrawing version")
 )
 
 // Version represents information related to a single version for an edition of a dataset
@@ -50,6 +52,9 @@ type Version struct {
 	LowestGeography    string               `bson:"lowest_geography,omitempty"      json:"lowest_geography,omitempty"`
 	QualityDesignation QualityDesignation   `bson:"quality_designation,omitempty"   json:"quality_designation,omitempty"`
 	Distributions      *[]Distribution      `bson:"distributions,omitempty"         json:"distributions,omitempty"`
+	Withdrawal         *Withdrawal          `bson:"withdrawal,omitempty"            json:"withdrawal,omitempty"`
+	ReviewComments     []ReviewComment      `bson:"review_comments,omitempty"       json:"review_comments,omitempty"`
+	StateHistory       []StateChange        `bson:"state_history,omitempty"         json:"-"` // only returned by the state history endpoint of the version
 }
 
 // Alert represents an object containing information on an alert
@@ -454,6 +459,10 @@ func ValidateVersion(version *Version) error {
 		if version.Type != Static.String() {
 			return ErrVersionStateDatasetTypeInvalid
 		}
+	case WithdrawnState:
+		if version.Withdrawal == nil || version.Withdrawal.Reason == "" {
+			return ErrWithdrawnVersionReasonMissing
+		}
 	default:
 		return ErrVersionStateInvalid
 	}
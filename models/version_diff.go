@@ -0,0 +1,214 @@
+package models
+
+// Dimension changes
+const (
+	DimensionAdded   = "added"
+	DimensionRemoved = "removed"
+	DimensionChanged = "changed"
+)
+
+// VersionDiff describes the changes made to a version of an edition since another version of the same edition
+type VersionDiff struct {
+	DatasetID          string                      `json:"dataset_id"`
+	Edition            string                      `json:"edition"`
+	Version            int                         `json:"version"`
+	Against            int                         `json:"against"`
+	Dimensions         []DimensionDiff             `json:"dimensions"`
+	Distributions      ListDiff[Distribution]      `json:"distributions"`
+	Alerts             ListDiff[Alert]             `json:"alerts"`
+	UsageNotes         ListDiff[UsageNote]         `json:"usage_notes"`
+	QualityDesignation *Change[QualityDesignation] `json:"quality_designation,omitempty"`
+}
+
+// Change holds the previous and the new value of something that has changed
+type Change[T any] struct {
+	From T `json:"from"`
+	To   T `json:"to"`
+}
+
+// ListDiff lists the items that were added to, removed from or changed in a list
+type ListDiff[T any] struct {
+	Added   []T         `json:"added,omitempty"`
+	Removed []T         `json:"removed,omitempty"`
+	Changed []Change[T] `json:"changed,omitempty"`
+}
+
+// FieldChange describes a change to a field of a dimension
+type FieldChange struct {
+	Field string      `json:"field"`
+	From  interface{} `json:"from"`
+	To    interface{} `json:"to"`
+}
+
+// DimensionDiff describes a dimension that was added, removed or changed, including the options added and removed
+type DimensionDiff struct {
+	Name           string        `json:"name"`
+	Change         string        `json:"change"`
+	Fields         []FieldChange `json:"fields,omitempty"`
+	AddedOptions   []string      `json:"added_options,omitempty"`
+	RemovedOptions []string      `json:"removed_options,omitempty"`
+}
+
+// DiffVersions returns the changes made to version since the against version. The dimension options of each version
+// are provided by dimension name, as they are not stored in the version.
+func DiffVersions(version, against *Version, options, againstOptions map[string][]string) *VersionDiff {
+	diff := &VersionDiff{
+		DatasetID:     version.DatasetID,
+		Edition:       version.Edition,
+		Version:       version.Version,
+		Against:       against.Version,
+		Dimensions:    diffDimensions(against.Dimensions, version.Dimensions, againstOptions, options),
+		Distributions: diffListFunc(derefList(against.Distributions), derefList(version.Distributions), distributionKey, sameDistribution),
+		Alerts:        diffList(derefList(against.Alerts), derefList(version.Alerts), func(a Alert) Alert { return a }),
+		UsageNotes:    diffList(derefList(against.UsageNotes), derefList(version.UsageNotes), func(n UsageNote) string { return n.Title }),
+	}
+
+	if version.QualityDesignation != against.QualityDesignation {
+		diff.QualityDesignation = &Change[QualityDesignation]{From: against.QualityDesignation, To: version.QualityDesignation}
+	}
+
+	return diff
+}
+
+func derefList[T any](list *[]T) []T {
+	if list == nil {
+		return nil
+	}
+	return *list
+}
+
+// distributionKey identifies a distribution within a version, as a version can have several distributions with the same title
+func distributionKey(d Distribution) [2]string {
+	return [2]string{d.Title, string(d.Format)}
+}
+
+// sameDistribution compares two distributions without their download URLs, which are specific to each version
+func sameDistribution(a, b Distribution) bool {
+	a.DownloadURL, b.DownloadURL = "", ""
+	return a == b
+}
+
+// diffList compares two lists whose items are identified by key. Items that have no identity, such as alerts,
+// are their own key, so that they can only be added or removed.
+func diffList[T comparable, K comparable](from, to []T, key func(T) K) ListDiff[T] {
+	return diffListFunc(from, to, key, func(a, b T) bool { return a == b })
+}
+
+// diffListFunc is like diffList, but uses the provided function to decide whether an item has changed
+func diffListFunc[T any, K comparable](from, to []T, key func(T) K, same func(a, b T) bool) ListDiff[T] {
+	diff := ListDiff[T]{}
+
+	previous := make(map[K]T, len(from))
+	for _, item := range from {
+		previous[key(item)] = item
+	}
+
+	current := make(map[K]bool, len(to))
+	for _, item := range to {
+		k := key(item)
+		current[k] = true
+
+		old, ok := previous[k]
+		switch {
+		case !ok:
+			diff.Added = append(diff.Added, item)
+		case !same(old, item):
+			diff.Changed = append(diff.Changed, Change[T]{From: old, To: item})
+		}
+	}
+
+	for _, item := range from {
+		if !current[key(item)] {
+			diff.Removed = append(diff.Removed, item)
+		}
+	}
+
+	return diff
+}
+
+func diffDimensions(from, to []Dimension, fromOptions, toOptions map[string][]string) []DimensionDiff {
+	diffs := []DimensionDiff{}
+
+	previous := make(map[string]Dimension, len(from))
+	for _, dimension := range from {
+		previous[dimension.Name] = dimension
+	}
+
+	current := make(map[string]bool, len(to))
+	for _, dimension := range to {
+		current[dimension.Name] = true
+
+		old, ok := previous[dimension.Name]
+		if !ok {
+			diffs = append(diffs, DimensionDiff{Name: dimension.Name, Change: DimensionAdded, AddedOptions: toOptions[dimension.Name]})
+			continue
+		}
+
+		added, removed := diffOptions(fromOptions[dimension.Name], toOptions[dimension.Name])
+		fields := diffDimensionFields(&old, &dimension)
+		if len(fields) > 0 || len(added) > 0 || len(removed) > 0 {
+			diffs = append(diffs, DimensionDiff{Name: dimension.Name, Change: DimensionChanged, Fields: fields, AddedOptions: added, RemovedOptions: removed})
+		}
+	}
+
+	for _, dimension := range from {
+		if !current[dimension.Name] {
+			diffs = append(diffs, DimensionDiff{Name: dimension.Name, Change: DimensionRemoved, RemovedOptions: fromOptions[dimension.Name]})
+		}
+	}
+
+	return diffs
+}
+
+// diffDimensionFields compares the descriptive fields of a dimension; links and timestamps are expected to change
+// between versions and are ignored
+func diffDimensionFields(from, to *Dimension) []FieldChange {
+	var changes []FieldChange
+	compare := func(field string, old, updated interface{}) {
+		if old != updated {
+			changes = append(changes, FieldChange{Field: field, From: old, To: updated})
+		}
+	}
+
+	compare("label", from.Label, to.Label)
+	compare("description", from.Description, to.Description)
+	compare("variable", from.Variable, to.Variable)
+	compare("number_of_options", derefValue(from.NumberOfOptions), derefValue(to.NumberOfOptions))
+	compare("is_area_type", derefValue(from.IsAreaType), derefValue(to.IsAreaType))
+	compare("quality_statement_text", from.QualityStatementText, to.QualityStatementText)
+	compare("quality_statement_url", from.QualityStatementURL, to.QualityStatementURL)
+
+	return changes
+}
+
+// derefValue returns the value of a pointer as an interface, so that nil pointers compare equal to each other
+func derefValue[T any](value *T) interface{} {
+	if value == nil {
+		return nil
+	}
+	return *value
+}
+
+// diffOptions returns the options that were added and removed, in the order in which they are listed
+func diffOptions(from, to []string) (added, removed []string) {
+	previous := make(map[string]bool, len(from))
+	for _, option := range from {
+		previous[option] = true
+	}
+
+	current := make(map[string]bool, len(to))
+	for _, option := range to {
+		current[option] = true
+		if !previous[option] {
+			added = append(added, option)
+		}
+	}
+
+	for _, option := range from {
+		if !current[option] {
+			removed = append(removed, option)
+		}
+	}
+
+	return added, removed
+}
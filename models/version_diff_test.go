@@ -0,0 +1,104 @@
+package models
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestDiffVersions(t *testing.T) {
+	t.Parallel()
+
+	areaType := true
+	csv := Distribution{Title: "Full dataset", Format: DistributionFormatCSV, DownloadURL: "/v1.csv", ByteSize: 100}
+	xls := Distribution{Title: "Spreadsheet", Format: DistributionFormatXLS, DownloadURL: "/v1.xls", ByteSize: 200}
+	sdmx := Distribution{Title: "SDMX", Format: DistributionFormatSDMX, DownloadURL: "/v1.sdmx", ByteSize: 10}
+	correction := Alert{Date: "2024-01-01", Description: "Corrected values", Type: AlertTypeCorrection}
+	note := UsageNote{Title: "Coverage", Note: "England and Wales"}
+
+	against := &Version{
+		Version: 1,
+		Dimensions: []Dimension{
+			{Name: "geography", Label: "Geography", IsAreaType: &areaType},
+			{Name: "sex", Label: "Sex"},
+			{Name: "time", Label: "Time"},
+		},
+		Distributions:      &[]Distribution{csv, xls, sdmx},
+		UsageNotes:         &[]UsageNote{note},
+		QualityDesignation: QualityDesignationOfficialInDevelopment,
+	}
+	againstOptions := map[string][]string{
+		"geography": {"E92000001", "W92000004"},
+		"sex":       {"1", "2"},
+		"time":      {"2022"},
+	}
+
+	Convey("Given two versions of an edition", t, func() {
+		updatedCSV := csv
+		updatedCSV.DownloadURL = "/v2.csv"
+		updatedCSV.ByteSize = 150
+		csvAsXLSX := Distribution{Title: "Full dataset", Format: DistributionFormatXLSX, DownloadURL: "/v2.xlsx", ByteSize: 300}
+		movedSDMX := sdmx
+		movedSDMX.DownloadURL = "/v2.sdmx"
+
+		version := &Version{
+			DatasetID: "cpih01",
+			Edition:   "time-series",
+			Version:   2,
+			Dimensions: []Dimension{
+				{Name: "geography", Label: "Geography", IsAreaType: &areaType},
+				{Name: "sex", Label: "Sex of person"},
+				{Name: "age", Label: "Age"},
+			},
+			Distributions:      &[]Distribution{updatedCSV, csvAsXLSX, movedSDMX},
+			Alerts:             &[]Alert{correction},
+			UsageNotes:         &[]UsageNote{note},
+			QualityDesignation: QualityDesignationOfficial,
+		}
+		options := map[string][]string{
+			"geography": {"E92000001", "S92000003"},
+			"sex":       {"1", "2"},
+			"age":       {"0-15", "16+"},
+		}
+
+		Convey("When they are compared", func() {
+			diff := DiffVersions(version, against, options, againstOptions)
+
+			Convey("Then the versions being compared are identified", func() {
+				So(diff.DatasetID, ShouldEqual, "cpih01")
+				So(diff.Edition, ShouldEqual, "time-series")
+				So(diff.Version, ShouldEqual, 2)
+				So(diff.Against, ShouldEqual, 1)
+			})
+
+			Convey("Then the dimensions that were changed, added and removed are listed with their options", func() {
+				So(diff.Dimensions, ShouldResemble, []DimensionDiff{
+					{Name: "geography", Change: DimensionChanged, AddedOptions: []string{"S92000003"}, RemovedOptions: []string{"W92000004"}},
+					{Name: "sex", Change: DimensionChanged, Fields: []FieldChange{{Field: "label", From: "Sex", To: "Sex of person"}}},
+					{Name: "age", Change: DimensionAdded, AddedOptions: []string{"0-15", "16+"}},
+					{Name: "time", Change: DimensionRemoved, RemovedOptions: []string{"2022"}},
+				})
+			})
+
+			Convey("Then the distributions are compared by title and format, ignoring their download URLs", func() {
+				So(diff.Distributions.Added, ShouldResemble, []Distribution{csvAsXLSX})
+				So(diff.Distributions.Removed, ShouldResemble, []Distribution{xls})
+				So(diff.Distributions.Changed, ShouldResemble, []Change[Distribution]{{From: csv, To: updatedCSV}})
+			})
+
+			Convey("Then the alerts, usage notes and quality designation are compared", func() {
+				So(diff.Alerts.Added, ShouldResemble, []Alert{correction})
+				So(diff.UsageNotes, ShouldResemble, ListDiff[UsageNote]{})
+				So(diff.QualityDesignation, ShouldResemble, &Change[QualityDesignation]{From: QualityDesignationOfficialInDevelopment, To: QualityDesignationOfficial})
+			})
+		})
+	})
+
+	Convey("A version compared with itself has no changes", t, func() {
+		diff := DiffVersions(against, against, againstOptions, againstOptions)
+		So(diff.Dimensions, ShouldBeEmpty)
+		So(diff.Distributions, ShouldResemble, ListDiff[Distribution]{})
+		So(diff.Alerts, ShouldResemble, ListDiff[Alert]{})
+		So(diff.QualityDesignation, ShouldBeNil)
+	})
+}
@@ -207,6 +207,18 @@ func TestValidateVersion(t *testing.T) {
 			So(err, ShouldNotBeNil)
 			So(err, ShouldEqual, ErrVersionStateDatasetTypeInvalid)
 		})
+
+		Convey("when the version state is withdrawn for a non-static dataset without a reason", func() {
+			nonStaticVersion := Version{
+				State:      WithdrawnState,
+				Type:       "filterable",
+				Withdrawal: &Withdrawal{},
+			}
+
+			err := ValidateVersion(&nonStaticVersion)
+			So(err, ShouldNotBeNil)
+			So(err, ShouldEqual, ErrWithdrawnVersionReasonMissing)
+		})
 	})
 }
 
@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+// Withdrawal records why, when and by whom a published version was withdrawn. A withdrawn version is no longer
+// returned to the public, who are given a tombstone with the reason instead.
+type Withdrawal struct {
+	Reason      string    `bson:"reason"       json:"reason"`
+	WithdrawnAt time.Time `bson:"withdrawn_at" json:"withdrawn_at"`
+	WithdrawnBy string    `bson:"withdrawn_by" json:"withdrawn_by,omitempty"`
+}
+
+// NewWithdrawal creates a Withdrawal for the provided reason, by the provided user at the current time
+func NewWithdrawal(reason, withdrawnBy string) *Withdrawal {
+	return &Withdrawal{
+		Reason:      reason,
+		WithdrawnAt: time.Now().UTC().Truncate(time.Millisecond),
+		WithdrawnBy: withdrawnBy,
+	}
+}
+
+// VersionTombstone is returned to the public in place of a withdrawn version
+type VersionTombstone struct {
+	DatasetID   string    `json:"dataset_id"`
+	Edition     string    `json:"edition"`
+	Version     int       `json:"version"`
+	State       string    `json:"state"`
+	Reason      string    `json:"reason"`
+	WithdrawnAt time.Time `json:"withdrawn_at"`
+}
+
+// NewVersionTombstone returns the tombstone of a withdrawn version, which does not reveal who withdrew it
+func NewVersionTombstone(datasetID string, version *Version) *VersionTombstone {
+	tombstone := &VersionTombstone{
+		DatasetID: datasetID,
+		Edition:   version.Edition,
+		Version:   version.Version,
+		State:     version.State,
+	}
+	if version.Withdrawal != nil {
+		tombstone.Reason = version.Withdrawal.Reason
+		tombstone.WithdrawnAt = version.Withdrawal.WithdrawnAt
+	}
+	return tombstone
+}
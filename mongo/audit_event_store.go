@@ -2,13 +2,131 @@ package mongo
 
 import (
 	"context"
+	"errors"
+	"regexp"
 
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
 	"github.com/ONSdigital/dp-dataset-api/config"
 	"github.com/ONSdigital/dp-dataset-api/models"
+	mongodriver "github.com/ONSdigital/dp-mongodb/v3/mongodb"
+	"go.mongodb.org/mongo-driver/bson"
+	mongodb "go.mongodb.org/mongo-driver/mongo"
 )
 
-// CreateAuditEvent inserts a new audit event into the dataset_events collection
+// auditEventIndexes are the indexes used to list the audit events, most recent first, by resource, dataset or requester
+var auditEventIndexes = []CollectionIndexes{
+	indexesOn(config.DatasetEventsCollection,
+		Index{Keys: bson.D{{Key: "created_at", Value: -1}}},
+		ascending("resource"),
+		Index{Keys: bson.D{{Key: "dataset_id", Value: 1}, {Key: "created_at", Value: -1}}},
+		Index{Keys: bson.D{{Key: "requested_by.id", Value: 1}, {Key: "created_at", Value: -1}}},
+		// the events recorded before the chain was introduced have no sequence
+		Index{Keys: bson.D{{Key: "sequence", Value: 1}}, Unique: true, Sparse: true},
+	),
+}
+
+// CreateAuditEvent chains a new audit event to the last one and inserts it into the dataset_events collection.
+// The unique index on the sequence prevents two events from being chained to the same event, in which case
+// ErrAuditEventChainConflict is returned. A duplicate key aborts a mongoDB transaction, so the caller has to chain
+// the event again in a new transaction. Without mongoDB transactions the event is kept if the transaction fails, as
+// deleting it would break the chain of any event that has since been chained to it.
 func (m *Mongo) CreateAuditEvent(ctx context.Context, event *models.AuditEvent) error {
-	_, err := m.Connection.Collection(m.ActualCollectionName(config.DatasetEventsCollection)).InsertOne(ctx, event)
+	collection := m.ActualCollectionName(config.DatasetEventsCollection)
+
+	last, err := m.getLastAuditEvent(ctx)
+	if err != nil {
+		return err
+	}
+	if err = event.ChainTo(last); err != nil {
+		return err
+	}
+
+	_, err = m.Connection.Collection(collection).InsertOne(ctx, event)
+	if mongodb.IsDuplicateKeyError(err) {
+		return errs.ErrAuditEventChainConflict
+	}
+
 	return err
 }
+
+// getLastAuditEvent returns the last event of the audit chain, or nil if no event has been chained yet
+func (m *Mongo) getLastAuditEvent(ctx context.Context) (*models.AuditEvent, error) {
+	var last models.AuditEvent
+	err := m.Connection.Collection(m.ActualCollectionName(config.DatasetEventsCollection)).FindOne(ctx, bson.M{"sequence": bson.M{"$exists": true}}, &last,
+		mongodriver.Sort(bson.M{"sequence": -1}))
+	if errors.Is(err, mongodriver.ErrNoDocumentFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &last, nil
+}
+
+// GetAuditEventChain returns up to limit events of the audit chain, in sequence order, starting from fromSequence
+func (m *Mongo) GetAuditEventChain(ctx context.Context, fromSequence int64, limit int) ([]*models.AuditEvent, error) {
+	events := []*models.AuditEvent{}
+	_, err := m.Connection.Collection(m.ActualCollectionName(config.DatasetEventsCollection)).Find(ctx, bson.M{"sequence": bson.M{"$gte": fromSequence}}, &events,
+		mongodriver.Sort(bson.M{"sequence": 1}), mongodriver.Limit(limit))
+	if err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// GetAuditEvents returns the audit events that match the filter, most recent first, along with the total number of matching events
+func (m *Mongo) GetAuditEvents(ctx context.Context, filter *models.AuditEventFilter, offset, limit int) ([]*models.AuditEvent, int, error) {
+	events := []*models.AuditEvent{}
+	totalCount, err := m.Connection.Collection(m.ActualCollectionName(config.DatasetEventsCollection)).Find(ctx, BuildAuditEventsQuery(filter), &events,
+		mongodriver.Sort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}), mongodriver.Offset(offset), mongodriver.Limit(limit))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return events, totalCount, nil
+}
+
+// BuildAuditEventsQuery constructs the MongoDB query for the audit events that match the filter
+func BuildAuditEventsQuery(filter *models.AuditEventFilter) bson.M {
+	var conditions []bson.M
+
+	if filter.Resource != "" {
+		conditions = append(conditions, bson.M{"resource": bson.M{"$regex": resourcePathPattern(filter.Resource)}})
+	}
+	// the events recorded before their dataset was stored with them are matched by the path of their resource
+	if filter.DatasetID != "" {
+		conditions = append(conditions, bson.M{"$or": bson.A{
+			bson.M{"dataset_id": filter.DatasetID},
+			bson.M{"dataset_id": bson.M{"$exists": false}, "resource": bson.M{"$regex": resourcePathPattern("/datasets/" + filter.DatasetID)}},
+		}})
+	}
+	if filter.Action != "" {
+		conditions = append(conditions, bson.M{"action": filter.Action})
+	}
+	if filter.RequestedBy != "" {
+		conditions = append(conditions, bson.M{"requested_by.id": filter.RequestedBy})
+	}
+
+	createdAt := bson.M{}
+	if filter.From != nil {
+		createdAt["$gte"] = *filter.From
+	}
+	if filter.To != nil {
+		createdAt["$lt"] = *filter.To
+	}
+	if len(createdAt) > 0 {
+		conditions = append(conditions, bson.M{"created_at": createdAt})
+	}
+
+	if len(conditions) == 0 {
+		return bson.M{}
+	}
+	return bson.M{"$and": conditions}
+}
+
+// resourcePathPattern returns a regular expression that matches a resource path and the paths below it
+func resourcePathPattern(path string) string {
+	return "^" + regexp.QuoteMeta(path) + "(/|$)"
+}
@@ -2,8 +2,11 @@ package mongo
 
 import (
 	"context"
+	"errors"
+	"sync"
 	"testing"
 
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
 	"github.com/ONSdigital/dp-dataset-api/models"
 	. "github.com/smartystreets/goconvey/convey"
 )
@@ -20,7 +23,7 @@ func TestCreateAuditEvent(t *testing.T) {
 				models.ActionCreate,
 				"/datasets/dataset-1",
 				&models.Dataset{ID: "dataset-1"},
-				nil, nil, nil,
+				nil, nil, nil, nil, nil,
 			)
 			So(err, ShouldBeNil)
 
@@ -29,6 +32,82 @@ func TestCreateAuditEvent(t *testing.T) {
 			Convey("Then the audit event is created successfully", func() {
 				So(err, ShouldBeNil)
 			})
+
+			Convey("Then the next audit event is chained to it", func() {
+				next, err := models.NewAuditEvent(
+					models.RequestedBy{ID: "user-1", Email: "user1@example.com"},
+					models.ActionUpdate,
+					"/datasets/dataset-1",
+					&models.Dataset{ID: "dataset-1", Title: "Dataset 1"},
+					nil, nil, nil, nil, nil,
+				)
+				So(err, ShouldBeNil)
+				So(mongo.CreateAuditEvent(ctx, next), ShouldBeNil)
+				So(next.Sequence, ShouldEqual, event.Sequence+1)
+				So(next.PreviousHash, ShouldEqual, event.Hash)
+
+				chain, err := mongo.GetAuditEventChain(ctx, event.Sequence, 10)
+				So(err, ShouldBeNil)
+				So(chain, ShouldHaveLength, 2)
+				reason, err := chain[1].CheckChainLink(chain[0])
+				So(err, ShouldBeNil)
+				So(reason, ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestCreateAuditEventConcurrently(t *testing.T) {
+	Convey("Given MongoDB is running without transactions", t, func() {
+		ctx := context.Background()
+		mongo, err := getTestMongoDB(ctx, t)
+		So(err, ShouldBeNil)
+
+		Convey("When several writers chain their events to the same sequence and the conflicting ones are rolled back", func() {
+			var wg sync.WaitGroup
+			results := make(chan error, 10)
+			for i := 0; i < 10; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					event, err := models.NewAuditEvent(
+						models.RequestedBy{ID: "user-1"},
+						models.ActionUpdate,
+						"/datasets/dataset-1",
+						&models.Dataset{ID: "dataset-1"},
+						nil, nil, nil, nil, nil,
+					)
+					if err != nil {
+						results <- err
+						return
+					}
+					results <- mongo.RunTransaction(ctx, func(transactionCtx context.Context) error {
+						return mongo.CreateAuditEvent(transactionCtx, event)
+					})
+				}()
+			}
+			wg.Wait()
+			close(results)
+
+			Convey("Then the events of the writers that won their sequence are kept and the chain is intact", func() {
+				created := 0
+				for err := range results {
+					if err == nil {
+						created++
+						continue
+					}
+					So(errors.Is(err, errs.ErrAuditEventChainConflict), ShouldBeTrue)
+				}
+
+				chain, err := mongo.GetAuditEventChain(ctx, 0, 20)
+				So(err, ShouldBeNil)
+				So(chain, ShouldHaveLength, created)
+				for i := 1; i < len(chain); i++ {
+					reason, err := chain[i].CheckChainLink(chain[i-1])
+					So(err, ShouldBeNil)
+					So(reason, ShouldBeEmpty)
+				}
+			})
 		})
 	})
 }
@@ -9,6 +9,7 @@ import (
 	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
 	"github.com/ONSdigital/dp-dataset-api/config"
 	"github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/dp-dataset-api/pagination"
 	mongodriver "github.com/ONSdigital/dp-mongodb/v3/mongodb"
 	"github.com/ONSdigital/log.go/v2/log"
 	"go.mongodb.org/mongo-driver/bson"
@@ -18,10 +19,25 @@ import (
 const ASCOrder = "ASC"
 const DESCOrder = "DESC"
 
-func (m *Mongo) GetDatasetsByQueryParams(ctx context.Context, id, datasetType, sortOrder, datasetID string, offset, limit int, authorised bool) (values []*models.DatasetUpdate, totalCount int, err error) {
-	filter, err := buildDatasetsQueryUsingParameters(id, datasetType, datasetID, authorised)
+// datasetIndexes are the indexes used to filter datasets by type and by the dataset they are based on,
+// and to find the editions of a dataset
+var datasetIndexes = []CollectionIndexes{
+	indexesOn(config.DatasetsCollection,
+		ascending("current.type"),
+		ascending("next.type"),
+		ascending("current.is_based_on.id"),
+		ascending("next.is_based_on.id"),
+	),
+	indexesOn(config.EditionsCollection,
+		ascending("current.links.dataset.id", "current.edition"),
+		ascending("next.links.dataset.id", "next.edition"),
+	),
+}
+
+func (m *Mongo) GetDatasetsByQueryParams(ctx context.Context, id, datasetType, sortOrder, datasetID string, offset, limit int, cursor *pagination.Cursor, authorised bool) (values []*models.DatasetUpdate, totalCount int, nextCursor *pagination.Cursor, err error) {
+	filter, err := BuildDatasetsQueryUsingParameters(id, datasetType, datasetID, authorised)
 	if err != nil {
-		return nil, 0, err
+		return nil, 0, nil, err
 	}
 
 	// Determine sort direction: 1 for ASC, -1 for DESC or default
@@ -31,28 +47,19 @@ func (m *Mongo) GetDatasetsByQueryParams(ctx context.Context, id, datasetType, s
 	}
 
 	// Query MongoDB
-	values = []*models.DatasetUpdate{}
-	totalCount, err = m.Connection.
-		Collection(m.ActualCollectionName(config.DatasetsCollection)).
-		Find(
-			ctx,
-			filter,
-			&values,
-			mongodriver.Sort(bson.M{"_id": sortDir}),
-			mongodriver.Offset(offset), mongodriver.Limit(limit),
-		)
+	values, totalCount, nextCursor, err = m.findDatasets(ctx, filter, sortDir, offset, limit, cursor)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to retrieve datasets: %w", err)
+		return nil, 0, nil, fmt.Errorf("failed to retrieve datasets: %w", err)
 	}
 	if len(values) == 0 {
-		return nil, 0, errs.ErrDatasetNotFound
+		return nil, 0, nil, errs.ErrDatasetNotFound
 	}
 
-	return values, totalCount, nil
+	return values, totalCount, nextCursor, nil
 }
 
-// buildDatasetsQuery constructs the MongoDB query for datasets
-func buildDatasetsQueryUsingParameters(basedOnID, datasetType, datasetID string, authorised bool) (bson.M, error) {
+// BuildDatasetsQueryUsingParameters constructs the MongoDB query for datasets
+func BuildDatasetsQueryUsingParameters(basedOnID, datasetType, datasetID string, authorised bool) (bson.M, error) {
 	filter := bson.M{}
 
 	// Apply datasetType filter if provided
@@ -112,28 +119,50 @@ func buildDatasetsQueryUsingParameters(basedOnID, datasetType, datasetID string,
 }
 
 // GetDatasets retrieves all dataset documents
-func (m *Mongo) GetDatasets(ctx context.Context, offset, limit int, authorised bool) (values []*models.DatasetUpdate, totalCount int, err error) {
-	var filter interface{}
-	if authorised {
-		filter = bson.M{}
-	} else {
-		filter = bson.M{"current": bson.M{"$exists": true}}
+func (m *Mongo) GetDatasets(ctx context.Context, offset, limit int, cursor *pagination.Cursor, authorised bool) (values []*models.DatasetUpdate, totalCount int, nextCursor *pagination.Cursor, err error) {
+	filter := bson.M{}
+	if !authorised {
+		filter["current"] = bson.M{"$exists": true}
 	}
 
+	return m.findDatasets(ctx, filter, -1, offset, limit, cursor)
+}
+
+// findDatasets retrieves the page of dataset documents that satisfy the filter, sorted by id in the provided direction,
+// starting at the offset or right after the cursor if provided. It also returns the total count of documents that satisfy
+// the filter, and the cursor of the last document if there are more documents after it.
+func (m *Mongo) findDatasets(ctx context.Context, filter bson.M, sortDir, offset, limit int, cursor *pagination.Cursor) (values []*models.DatasetUpdate, totalCount int, nextCursor *pagination.Cursor, err error) {
+	collection := m.Connection.Collection(m.ActualCollectionName(config.DatasetsCollection))
+	sort := mongodriver.Sort(bson.M{"_id": sortDir})
+	filter = NotDeleted(filter)
+
 	values = []*models.DatasetUpdate{}
-	totalCount, err = m.Connection.Collection(m.ActualCollectionName(config.DatasetsCollection)).Find(ctx, filter, &values,
-		mongodriver.Sort(bson.M{"_id": -1}), mongodriver.Offset(offset), mongodriver.Limit(limit))
+	if cursor == nil {
+		totalCount, err = collection.Find(ctx, filter, &values, sort, mongodriver.Offset(offset), mongodriver.Limit(limit))
+		if err != nil {
+			return nil, 0, nil, err
+		}
+
+		return values, totalCount, NextCursor(values, offset+len(values) < totalCount, DatasetCursor), nil
+	}
+
+	totalCount, err = collection.Count(ctx, filter)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
+	remaining, err := collection.Find(ctx, AfterCursor(filter, "", nil, "_id", cursor.ID, sortDir), &values, sort, mongodriver.Limit(limit))
 	if err != nil {
-		return nil, 0, err
+		return nil, 0, nil, err
 	}
 
-	return values, totalCount, nil
+	return values, totalCount, NextCursor(values, len(values) < remaining, DatasetCursor), nil
 }
 
 // GetDataset retrieves a dataset document
 func (m *Mongo) GetDataset(ctx context.Context, id string) (*models.DatasetUpdate, error) {
 	var dataset models.DatasetUpdate
-	err := m.Connection.Collection(m.ActualCollectionName(config.DatasetsCollection)).FindOne(ctx, bson.M{"_id": id}, &dataset)
+	err := m.Connection.Collection(m.ActualCollectionName(config.DatasetsCollection)).FindOne(ctx, NotDeleted(bson.M{"_id": id}), &dataset)
 	if err != nil {
 		if errors.Is(err, mongodriver.ErrNoDocumentFound) {
 			return nil, errs.ErrDatasetNotFound
@@ -144,6 +173,24 @@ func (m *Mongo) GetDataset(ctx context.Context, id string) (*models.DatasetUpdat
 	return &dataset, nil
 }
 
+// CollectionDatasetsSelector selects the datasets whose next document belongs to a collection and is in one of the
+// provided states
+func CollectionDatasetsSelector(collectionID string, states []string) bson.M {
+	return NotDeleted(bson.M{"next.collection_id": collectionID, "next.state": bson.M{"$in": states}})
+}
+
+// GetDatasetsByCollectionID retrieves the datasets whose next document belongs to a collection and is in one of the
+// provided states, sorted by id
+func (m *Mongo) GetDatasetsByCollectionID(ctx context.Context, collectionID string, states []string) ([]*models.DatasetUpdate, error) {
+	datasets := []*models.DatasetUpdate{}
+	if _, err := m.Connection.Collection(m.ActualCollectionName(config.DatasetsCollection)).Find(ctx, CollectionDatasetsSelector(collectionID, states), &datasets,
+		mongodriver.Sort(bson.M{"_id": 1})); err != nil {
+		return nil, err
+	}
+
+	return datasets, nil
+}
+
 func (m *Mongo) CheckDatasetTitleExist(ctx context.Context, title string) (bool, error) {
 	titleFilter := bson.M{
 		"$or": bson.A{
@@ -151,7 +198,7 @@ func (m *Mongo) CheckDatasetTitleExist(ctx context.Context, title string) (bool,
 			bson.M{"next.title": title},
 		},
 	}
-	count, err := m.Connection.Collection(m.ActualCollectionName(config.DatasetsCollection)).Count(ctx, titleFilter)
+	count, err := m.Connection.Collection(m.ActualCollectionName(config.DatasetsCollection)).Count(ctx, NotDeleted(titleFilter))
 	if err != nil {
 		return false, err
 	}
@@ -163,11 +210,11 @@ func (m *Mongo) CheckDatasetTitleExist(ctx context.Context, title string) (bool,
 
 // GetEditions retrieves all edition documents for a dataset
 func (m *Mongo) GetEditions(ctx context.Context, id, state string, offset, limit int, authorised bool) ([]*models.EditionUpdate, int, error) {
-	selector := buildEditionsQuery(id, state, authorised)
+	selector := BuildEditionsQuery(id, state, authorised)
 
 	// get total count and paginated values according to provided offset and limit
 	results := []*models.EditionUpdate{}
-	totalCount, err := m.Connection.Collection(m.ActualCollectionName(config.EditionsCollection)).Find(ctx, selector, &results,
+	totalCount, err := m.Connection.Collection(m.ActualCollectionName(config.EditionsCollection)).Find(ctx, NotDeleted(selector), &results,
 		mongodriver.Sort(bson.M{"_id": 1}), mongodriver.Offset(offset), mongodriver.Limit(limit))
 	if err != nil {
 		return results, 0, err
@@ -180,7 +227,8 @@ func (m *Mongo) GetEditions(ctx context.Context, id, state string, offset, limit
 	return results, totalCount, nil
 }
 
-func buildEditionsQuery(id, state string, authorised bool) bson.M {
+// BuildEditionsQuery constructs the MongoDB query for the editions of a dataset
+func BuildEditionsQuery(id, state string, authorised bool) bson.M {
 	// all queries must get the dataset by id
 	selector := bson.M{
 		"next.links.dataset.id": id,
@@ -201,10 +249,10 @@ func buildEditionsQuery(id, state string, authorised bool) bson.M {
 
 // GetEdition retrieves an edition document for a dataset
 func (m *Mongo) GetEdition(ctx context.Context, id, editionID, state string) (*models.EditionUpdate, error) {
-	selector := buildEditionQuery(id, editionID, state)
+	selector := BuildEditionQuery(id, editionID, state)
 
 	var edition models.EditionUpdate
-	err := m.Connection.Collection(m.ActualCollectionName(config.EditionsCollection)).FindOne(ctx, selector, &edition)
+	err := m.Connection.Collection(m.ActualCollectionName(config.EditionsCollection)).FindOne(ctx, NotDeleted(selector), &edition)
 
 	if err != nil {
 		if errors.Is(err, mongodriver.ErrNoDocumentFound) {
@@ -215,7 +263,8 @@ func (m *Mongo) GetEdition(ctx context.Context, id, editionID, state string) (*m
 	return &edition, nil
 }
 
-func buildEditionQuery(id, editionID, state string) bson.M {
+// BuildEditionQuery constructs the MongoDB query for an edition of a dataset
+func BuildEditionQuery(id, editionID, state string) bson.M {
 	var selector bson.M
 	if state != "" {
 		selector = bson.M{
@@ -259,7 +308,7 @@ func (m *Mongo) GetNextVersion(ctx context.Context, datasetID, edition string) (
 
 // GetVersions retrieves all version documents for a dataset edition
 func (m *Mongo) GetVersions(ctx context.Context, datasetID, editionID, state string, offset, limit int) ([]models.Version, int, error) {
-	selector := buildVersionsQuery(datasetID, editionID, state)
+	selector := BuildVersionsQuery(datasetID, editionID, state)
 	// get total count and paginated values according to provided offset and limit
 	results := []models.Version{}
 	totalCount, err := m.Connection.Collection(m.ActualCollectionName(config.InstanceCollection)).Find(ctx, selector, &results,
@@ -282,7 +331,8 @@ func (m *Mongo) GetVersions(ctx context.Context, datasetID, editionID, state str
 	return results, totalCount, nil
 }
 
-func buildVersionsQuery(datasetID, editionID, state string) bson.M {
+// BuildVersionsQuery constructs the MongoDB query for the versions of a dataset edition
+func BuildVersionsQuery(datasetID, editionID, state string) bson.M {
 	var selector bson.M
 	if state == "" {
 		selector = bson.M{
@@ -293,6 +343,7 @@ func buildVersionsQuery(datasetID, editionID, state string) bson.M {
 				bson.M{"state": models.AssociatedState},
 				bson.M{"state": models.ApprovedState},
 				bson.M{"state": models.PublishedState},
+				bson.M{"state": models.WithdrawnState},
 			},
 		}
 	} else {
@@ -308,7 +359,7 @@ func buildVersionsQuery(datasetID, editionID, state string) bson.M {
 
 // GetVersion retrieves a version document for a dataset edition
 func (m *Mongo) GetVersion(ctx context.Context, id, editionID string, versionID int, state string) (*models.Version, error) {
-	selector := buildVersionQuery(id, editionID, state, versionID)
+	selector := BuildVersionQuery(id, editionID, state, versionID)
 
 	var version models.Version
 	err := m.Connection.Collection(m.ActualCollectionName(config.InstanceCollection)).FindOne(ctx, selector, &version)
@@ -322,7 +373,37 @@ func (m *Mongo) GetVersion(ctx context.Context, id, editionID string, versionID
 	return &version, nil
 }
 
-func buildVersionQuery(id, editionID, state string, versionID int) bson.M {
+// GetLatestVersion retrieves the most recently released version of a dataset, in the provided edition unless it is empty
+func (m *Mongo) GetLatestVersion(ctx context.Context, datasetID, editionID, state string) (*models.Version, error) {
+	selector := LatestVersionSelector(datasetID, editionID, state)
+
+	var version models.Version
+	err := m.Connection.Collection(m.ActualCollectionName(config.InstanceCollection)).FindOne(ctx, selector, &version, mongodriver.Sort(bson.M{"release_date": -1}))
+	if err != nil {
+		if errors.Is(err, mongodriver.ErrNoDocumentFound) {
+			return nil, errs.ErrVersionNotFound
+		}
+		return nil, err
+	}
+
+	return &version, nil
+}
+
+// LatestVersionSelector constructs the MongoDB query for the versions of a dataset, in the provided edition unless it is empty
+func LatestVersionSelector(datasetID, editionID, state string) bson.M {
+	selector := bson.M{"links.dataset.id": datasetID}
+	if editionID != "" {
+		selector["edition"] = editionID
+	}
+	if state != "" {
+		selector["state"] = state
+	}
+
+	return selector
+}
+
+// BuildVersionQuery constructs the MongoDB query for a version of a dataset edition
+func BuildVersionQuery(id, editionID, state string, versionID int) bson.M {
 	var selector bson.M
 	if state != models.PublishedState {
 		selector = bson.M{
@@ -342,29 +423,45 @@ func buildVersionQuery(id, editionID, state string, versionID int) bson.M {
 	return selector
 }
 
-// UpdateDataset updates an existing dataset document
+// UpdateDataset updates an existing dataset document and stores the resulting metadata as a new revision.
+// Both are written in the transaction of the caller, if there is one.
 func (m *Mongo) UpdateDataset(ctx context.Context, id string, dataset *models.Dataset, currentState string) (err error) {
-	updates := createDatasetUpdateQuery(ctx, id, dataset, currentState)
+	updates := CreateDatasetUpdateQuery(ctx, id, dataset, currentState)
 	update := bson.M{"$set": updates}
 
 	if dataset.Type != models.Static.String() {
 		update["$setOnInsert"] = bson.M{"next.last_updated": time.Now()}
 	}
 
-	if _, err = m.Connection.Collection(m.ActualCollectionName(config.DatasetsCollection)).Must().UpdateOne(ctx, bson.M{"_id": id}, update); err != nil {
-		if errors.Is(err, mongodriver.ErrNoDocumentFound) {
-			return errs.ErrDatasetNotFound
+	collection := m.ActualCollectionName(config.DatasetsCollection)
+
+	return m.RunTransaction(ctx, func(transactionCtx context.Context) error {
+		entry, err := m.recordRollback(transactionCtx, collection, bson.M{"_id": id})
+		if err != nil {
+			return err
 		}
-		return err
-	}
 
-	return nil
+		if _, err := m.Connection.Collection(collection).Must().UpdateOne(transactionCtx, bson.M{"_id": id}, update); err != nil {
+			if errors.Is(err, mongodriver.ErrNoDocumentFound) {
+				return errs.ErrDatasetNotFound
+			}
+			return err
+		}
+
+		if err := m.recordWrite(transactionCtx, entry, nil); err != nil {
+			return err
+		}
+
+		return m.addDatasetRevision(transactionCtx, id)
+	})
 }
 
+// CreateDatasetUpdateQuery builds the set of field updates to apply to the next sub-document of a dataset
+//
 // TODO: Refactor this to reduce the complexity
 //
 //nolint:gocyclo,gocognit // high cyclomactic & cognitive complexity not in scope for maintenance
-func createDatasetUpdateQuery(ctx context.Context, id string, dataset *models.Dataset, currentState string) bson.M {
+func CreateDatasetUpdateQuery(ctx context.Context, id string, dataset *models.Dataset, currentState string) bson.M {
 	updates := make(bson.M)
 
 	log.Info(ctx, "building update query for dataset resource", log.Data{"datasetID": id, "dataset": dataset, "updates": updates})
@@ -497,7 +594,8 @@ func createDatasetUpdateQuery(ctx context.Context, id string, dataset *models.Da
 	return updates
 }
 
-// UpdateDatasetWithAssociation updates an existing dataset document with collection data
+// UpdateDatasetWithAssociation updates an existing dataset document with collection data, and stores the resulting
+// metadata as a new revision in the transaction of the caller, if there is one
 func (m *Mongo) UpdateDatasetWithAssociation(ctx context.Context, id, state string, version *models.Version) (err error) {
 	update := bson.M{
 		"$set": bson.M{
@@ -509,26 +607,44 @@ func (m *Mongo) UpdateDatasetWithAssociation(ctx context.Context, id, state stri
 		},
 	}
 
-	if _, err = m.Connection.Collection(m.ActualCollectionName(config.DatasetsCollection)).Must().UpdateOne(ctx, bson.M{"_id": id}, update); err != nil {
-		if errors.Is(err, mongodriver.ErrNoDocumentFound) {
-			return errs.ErrDatasetNotFound
+	collection := m.ActualCollectionName(config.DatasetsCollection)
+
+	return m.RunTransaction(ctx, func(transactionCtx context.Context) error {
+		entry, err := m.recordRollback(transactionCtx, collection, bson.M{"_id": id})
+		if err != nil {
+			return err
 		}
-		return err
-	}
 
-	return nil
+		if _, err := m.Connection.Collection(collection).Must().UpdateOne(transactionCtx, bson.M{"_id": id}, update); err != nil {
+			if errors.Is(err, mongodriver.ErrNoDocumentFound) {
+				return errs.ErrDatasetNotFound
+			}
+			return err
+		}
+
+		if err := m.recordWrite(transactionCtx, entry, nil); err != nil {
+			return err
+		}
+
+		return m.addDatasetRevision(transactionCtx, id)
+	})
 }
 
 // UpdateVersion updates an existing version document
 func (m *Mongo) UpdateVersion(ctx context.Context, currentVersion, versionUpdate *models.Version, eTagSelector string) (newETag string, err error) {
 	// calculate the new eTag hash for the instance that would result from adding the event
-	newETag, err = newETagForVersionUpdate(currentVersion, versionUpdate)
+	newETag, err = NewETagForVersionUpdate(currentVersion, versionUpdate)
 	if err != nil {
 		return "", err
 	}
 
 	sel := selector(currentVersion.ID, bsonprim.Timestamp{}, eTagSelector)
-	updates := createVersionUpdateQuery(versionUpdate, newETag)
+	updates := CreateVersionUpdateQuery(versionUpdate, newETag)
+
+	entry, err := m.recordRollback(ctx, m.ActualCollectionName(config.InstanceCollection), sel)
+	if err != nil {
+		return "", err
+	}
 
 	if _, err := m.Connection.Collection(m.ActualCollectionName(config.InstanceCollection)).Must().Update(ctx, sel, bson.M{"$set": updates}); err != nil {
 		if errors.Is(err, mongodriver.ErrNoDocumentFound) {
@@ -537,10 +653,15 @@ func (m *Mongo) UpdateVersion(ctx context.Context, currentVersion, versionUpdate
 		return "", err
 	}
 
+	if err := m.recordWrite(ctx, entry, nil); err != nil {
+		return "", err
+	}
+
 	return newETag, nil
 }
 
-func createVersionUpdateQuery(version *models.Version, newETag string) bson.M {
+// CreateVersionUpdateQuery builds the set of field updates to apply to a version document
+func CreateVersionUpdateQuery(version *models.Version, newETag string) bson.M {
 	setUpdates := make(bson.M)
 
 	/*
@@ -603,6 +724,18 @@ func createVersionUpdateQuery(version *models.Version, newETag string) bson.M {
 		setUpdates["distributions"] = version.Distributions
 	}
 
+	if version.Withdrawal != nil {
+		setUpdates["withdrawal"] = version.Withdrawal
+	}
+
+	if version.ReviewComments != nil {
+		setUpdates["review_comments"] = version.ReviewComments
+	}
+
+	if version.StateHistory != nil {
+		setUpdates["state_history"] = version.StateHistory
+	}
+
 	if newETag != "" {
 		setUpdates["e_tag"] = newETag
 	}
@@ -681,25 +814,54 @@ func (m *Mongo) UpdateMetadata(ctx context.Context, datasetID, versionID, versio
 			return nil, err
 		}
 
-		return nil, nil
+		return nil, m.addDatasetRevision(transactionCtx, datasetID)
 	})
 
 	return err
 }
 
-// UpsertDataset adds or overrides an existing dataset document
+// UpsertDataset adds or overrides an existing dataset document and stores the resulting metadata as a new revision.
+// Both are written in the transaction of the caller, if there is one.
 func (m *Mongo) UpsertDataset(ctx context.Context, id string, datasetDoc *models.DatasetUpdate) (err error) {
-	update := bson.M{"$set": datasetDoc}
+	// a deleted dataset that is replaced by a new one is no longer deleted
+	update := bson.M{"$set": datasetDoc, "$unset": DeletionFields()}
 
 	if datasetDoc.Next.Type != "static" {
 		update["$setOnInsert"] = bson.M{"last_updated": time.Now()}
 	}
 
-	_, err = m.Connection.Collection(m.ActualCollectionName(config.DatasetsCollection)).UpsertById(ctx, id, update)
+	collectionName := m.ActualCollectionName(config.DatasetsCollection)
 
-	return err
+	return m.RunTransaction(ctx, func(transactionCtx context.Context) error {
+		entry, err := m.recordRollback(transactionCtx, collectionName, bson.M{"_id": id})
+		if err != nil {
+			return err
+		}
+
+		collection := m.Connection.Collection(collectionName)
+
+		// nor is the published dataset that it replaces brought back with it
+		if datasetDoc.Current == nil {
+			if _, err := collection.UpdateOne(transactionCtx, Deleted(bson.M{"_id": id}), bson.M{"$unset": bson.M{"current": ""}}); err != nil {
+				return err
+			}
+		}
+
+		result, err := collection.UpsertById(transactionCtx, id, update)
+		if err != nil {
+			return err
+		}
+
+		if err := m.recordWrite(transactionCtx, entry, result.UpsertedID); err != nil {
+			return err
+		}
+
+		return m.addDatasetRevision(transactionCtx, id)
+	})
 }
 
+// RemoveDatasetVersionAndEditionLinks removes the editions and latest version links from the next sub-document of a
+// dataset, and stores the resulting metadata as a new revision in the transaction of the caller, if there is one
 func (m *Mongo) RemoveDatasetVersionAndEditionLinks(ctx context.Context, id string) error {
 	update := bson.M{
 		"$unset": bson.M{
@@ -711,11 +873,24 @@ func (m *Mongo) RemoveDatasetVersionAndEditionLinks(ctx context.Context, id stri
 		},
 	}
 
-	if _, err := m.Connection.Collection(m.ActualCollectionName(config.DatasetsCollection)).Must().UpdateOne(ctx, bson.M{"_id": id}, update); err != nil {
-		return fmt.Errorf("failed in query to MongoDB: %w", err)
-	}
+	collection := m.ActualCollectionName(config.DatasetsCollection)
 
-	return nil
+	return m.RunTransaction(ctx, func(transactionCtx context.Context) error {
+		entry, err := m.recordRollback(transactionCtx, collection, bson.M{"_id": id})
+		if err != nil {
+			return err
+		}
+
+		if _, err := m.Connection.Collection(collection).Must().UpdateOne(transactionCtx, bson.M{"_id": id}, update); err != nil {
+			return fmt.Errorf("failed in query to MongoDB: %w", err)
+		}
+
+		if err := m.recordWrite(transactionCtx, entry, nil); err != nil {
+			return err
+		}
+
+		return m.addDatasetRevision(transactionCtx, id)
+	})
 }
 
 // UpsertEdition adds or overrides an existing edition document
@@ -728,12 +903,21 @@ func (m *Mongo) UpsertEdition(ctx context.Context, datasetID, edition string, ed
 	editionDoc.Next.LastUpdated = time.Now()
 
 	update := bson.M{
-		"$set": editionDoc,
+		"$set":   editionDoc,
+		"$unset": DeletionFields(),
+	}
+
+	entry, err := m.recordRollback(ctx, m.ActualCollectionName(config.EditionsCollection), selector)
+	if err != nil {
+		return err
 	}
 
-	_, err = m.Connection.Collection(m.ActualCollectionName(config.EditionsCollection)).Upsert(ctx, selector, update)
+	result, err := m.Connection.Collection(m.ActualCollectionName(config.EditionsCollection)).Upsert(ctx, selector, update)
+	if err != nil {
+		return err
+	}
 
-	return err
+	return m.recordWrite(ctx, entry, result.UpsertedID)
 }
 
 // UpsertVersion adds or overrides an existing version document
@@ -765,7 +949,7 @@ func (m *Mongo) CheckDatasetExists(ctx context.Context, id, state string) error
 	}
 
 	var d models.Dataset
-	if err := m.Connection.Collection(m.ActualCollectionName(config.DatasetsCollection)).FindOne(ctx, query, &d, mongodriver.Projection(bson.M{"_id": 1})); err != nil {
+	if err := m.Connection.Collection(m.ActualCollectionName(config.DatasetsCollection)).FindOne(ctx, NotDeleted(query), &d, mongodriver.Projection(bson.M{"_id": 1})); err != nil {
 		if errors.Is(err, mongodriver.ErrNoDocumentFound) {
 			return errs.ErrDatasetNotFound
 		}
@@ -792,7 +976,7 @@ func (m *Mongo) CheckEditionExists(ctx context.Context, id, editionID, state str
 	}
 
 	var d models.Edition
-	if err := m.Connection.Collection(m.ActualCollectionName(config.EditionsCollection)).FindOne(ctx, query, &d, mongodriver.Projection(bson.M{"_id": 1})); err != nil {
+	if err := m.Connection.Collection(m.ActualCollectionName(config.EditionsCollection)).FindOne(ctx, NotDeleted(query), &d, mongodriver.Projection(bson.M{"_id": 1})); err != nil {
 		if errors.Is(err, mongodriver.ErrNoDocumentFound) {
 			return errs.ErrEditionNotFound
 		}
@@ -802,28 +986,18 @@ func (m *Mongo) CheckEditionExists(ctx context.Context, id, editionID, state str
 	return nil
 }
 
-// DeleteDataset deletes an existing dataset document
-func (m *Mongo) DeleteDataset(ctx context.Context, id string) (err error) {
-	if _, err = m.Connection.Collection(m.ActualCollectionName(config.DatasetsCollection)).Must().DeleteById(ctx, id); err != nil {
-		if errors.Is(err, mongodriver.ErrNoDocumentFound) {
-			return errs.ErrDatasetNotFound
-		}
-		return err
-	}
-
-	return nil
+// DeleteDataset marks an existing dataset document as deleted
+func (m *Mongo) DeleteDataset(ctx context.Context, id string, deletion *models.Deletion) error {
+	return m.softDelete(ctx, config.DatasetsCollection, bson.M{"_id": id}, deletion, errs.ErrDatasetNotFound)
 }
 
-// DeleteEdition deletes an existing edition document
-func (m *Mongo) DeleteEdition(ctx context.Context, id string) (err error) {
-	if _, err = m.Connection.Collection(m.ActualCollectionName(config.EditionsCollection)).Must().Delete(ctx, bson.D{{Key: "id", Value: id}}); err != nil {
-		if errors.Is(err, mongodriver.ErrNoDocumentFound) {
-			return errs.ErrEditionNotFound
-		}
+// DeleteEdition marks an existing edition document as deleted
+func (m *Mongo) DeleteEdition(ctx context.Context, id string, deletion *models.Deletion) error {
+	if err := m.softDelete(ctx, config.EditionsCollection, bson.M{"id": id}, deletion, errs.ErrEditionNotFound); err != nil {
 		return err
 	}
 
-	log.Info(context.TODO(), "edition deleted", log.Data{"id": id})
+	log.Info(ctx, "edition deleted", log.Data{"id": id})
 	return nil
 }
 
@@ -841,7 +1015,7 @@ func (m *Mongo) IsStaticDataset(ctx context.Context, datasetID string) (bool, er
 		} `bson:"next"`
 	}
 
-	err := coll.FindOne(ctx, filter, &result, mongodriver.Projection(bson.M{
+	err := coll.FindOne(ctx, NotDeleted(filter), &result, mongodriver.Projection(bson.M{
 		"_id":          1,
 		"current.type": 1,
 		"next.type":    1,
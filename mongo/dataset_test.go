@@ -2,6 +2,8 @@ package mongo
 
 import (
 	"context"
+	"fmt"
+	"sync"
 	"testing"
 
 	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
@@ -21,14 +23,14 @@ var (
 func TestBuildEditionsQuery(t *testing.T) {
 	t.Parallel()
 	Convey("When no state was set and the request is authorised then the selector only queries by id", t, func() {
-		selector := buildEditionsQuery(id, "", true)
+		selector := BuildEditionsQuery(id, "", true)
 		So(selector, ShouldNotBeNil)
 		So(selector, ShouldHaveLength, 1)
 		So(selector["next.links.dataset.id"], ShouldEqual, id)
 	})
 
 	Convey("When no state was set and the request is not authorised then the selector queries by id and current must exist", t, func() {
-		selector := buildEditionsQuery(id, "", false)
+		selector := BuildEditionsQuery(id, "", false)
 		So(selector, ShouldNotBeNil)
 		So(selector, ShouldHaveLength, 2)
 		So(selector["next.links.dataset.id"], ShouldEqual, id)
@@ -36,7 +38,7 @@ func TestBuildEditionsQuery(t *testing.T) {
 	})
 
 	Convey("When state was set to published and request is authorised then the selector queries by id and state", t, func() {
-		selector := buildEditionsQuery(id, state, true)
+		selector := BuildEditionsQuery(id, state, true)
 		So(selector, ShouldNotBeNil)
 		So(selector, ShouldHaveLength, 2)
 		So(selector["next.links.dataset.id"], ShouldEqual, id)
@@ -44,7 +46,7 @@ func TestBuildEditionsQuery(t *testing.T) {
 	})
 
 	Convey("When state was set to published and request is not authorised then the selector queries by id, state and current must exist", t, func() {
-		selector := buildEditionsQuery(id, state, false)
+		selector := BuildEditionsQuery(id, state, false)
 		So(selector, ShouldNotBeNil)
 		So(selector, ShouldHaveLength, 3)
 		So(selector["next.links.dataset.id"], ShouldEqual, id)
@@ -61,7 +63,7 @@ func TestBuildEditionQuery(t *testing.T) {
 			"next.edition":          editionID,
 		}
 
-		selector := buildEditionQuery(id, editionID, "")
+		selector := BuildEditionQuery(id, editionID, "")
 		So(selector, ShouldNotBeNil)
 		So(selector, ShouldResemble, expectedSelector)
 	})
@@ -73,7 +75,7 @@ func TestBuildEditionQuery(t *testing.T) {
 			"current.state":            state,
 		}
 
-		selector := buildEditionQuery(id, editionID, state)
+		selector := BuildEditionQuery(id, editionID, state)
 		So(selector, ShouldNotBeNil)
 		So(selector, ShouldResemble, expectedSelector)
 	})
@@ -90,10 +92,11 @@ func TestBuildVersionsQuery(t *testing.T) {
 				bson.M{"state": "associated"},
 				bson.M{"state": "approved"},
 				bson.M{"state": "published"},
+				bson.M{"state": "withdrawn"},
 			},
 		}
 
-		selector := buildVersionsQuery(id, editionID, "")
+		selector := BuildVersionsQuery(id, editionID, "")
 		So(selector, ShouldNotBeNil)
 		So(selector, ShouldResemble, expectedSelector)
 	})
@@ -105,7 +108,7 @@ func TestBuildVersionsQuery(t *testing.T) {
 			"state":            state,
 		}
 
-		selector := buildVersionsQuery(id, editionID, state)
+		selector := BuildVersionsQuery(id, editionID, state)
 		So(selector, ShouldNotBeNil)
 		So(selector, ShouldResemble, expectedSelector)
 	})
@@ -120,7 +123,7 @@ func TestBuildVersionQuery(t *testing.T) {
 			"version":          versionID,
 		}
 
-		selector := buildVersionQuery(id, editionID, "", versionID)
+		selector := BuildVersionQuery(id, editionID, "", versionID)
 		So(selector, ShouldNotBeNil)
 		So(selector, ShouldResemble, expectedSelector)
 	})
@@ -133,7 +136,7 @@ func TestBuildVersionQuery(t *testing.T) {
 			"state":            state,
 		}
 
-		selector := buildVersionQuery(id, editionID, state, versionID)
+		selector := BuildVersionQuery(id, editionID, state, versionID)
 		So(selector, ShouldNotBeNil)
 		So(selector, ShouldResemble, expectedSelector)
 	})
@@ -257,7 +260,7 @@ func TestDatasetUpdateQuery(t *testing.T) {
 			RelatedContent:   relatedContent,
 		}
 
-		selector := createDatasetUpdateQuery(testContext, "123", dataset, models.CreatedState)
+		selector := CreateDatasetUpdateQuery(testContext, "123", dataset, models.CreatedState)
 		So(selector, ShouldNotBeNil)
 		So(selector, ShouldResemble, expectedUpdate)
 	})
@@ -272,7 +275,7 @@ func TestDatasetUpdateQuery(t *testing.T) {
 			"next.national_statistic": &nationalStatistic,
 		}
 
-		selector := createDatasetUpdateQuery(testContext, "123", dataset, models.CreatedState)
+		selector := CreateDatasetUpdateQuery(testContext, "123", dataset, models.CreatedState)
 		So(selector, ShouldNotBeNil)
 		So(selector, ShouldResemble, expectedUpdate)
 	})
@@ -304,6 +307,8 @@ func TestVersionUpdateQuery(t *testing.T) {
 			},
 		}
 
+		stateHistory := []models.StateChange{{From: models.ApprovedState, To: models.PublishedState, ChangedBy: "publisher@ons.gov.uk"}}
+
 		version := &models.Version{
 			CollectionID: "12345678",
 			ReleaseDate:  "2017-09-09",
@@ -325,9 +330,10 @@ func TestVersionUpdateQuery(t *testing.T) {
 			State:         models.PublishedState,
 			Temporal:      &[]models.TemporalFrequency{temporal},
 			Distributions: distributions,
+			StateHistory:  stateHistory,
 		}
 
-		selector := createVersionUpdateQuery(version, "newETag")
+		selector := CreateVersionUpdateQuery(version, "newETag")
 		So(selector, ShouldNotBeNil)
 		So(selector, ShouldNotBeNil)
 		So(selector["collection_id"], ShouldEqual, "12345678")
@@ -341,6 +347,7 @@ func TestVersionUpdateQuery(t *testing.T) {
 		So(selector["state"], ShouldEqual, models.PublishedState)
 		So(selector["temporal"], ShouldResemble, &[]models.TemporalFrequency{temporal})
 		So(selector["distributions"], ShouldResemble, distributions)
+		So(selector["state_history"], ShouldResemble, stateHistory)
 		So(selector["e_tag"], ShouldEqual, "newETag")
 		So(selector["last_updated"], ShouldNotBeEmpty)
 	})
@@ -351,7 +358,7 @@ func TestBuildDatasetsQueryUsingParameters(t *testing.T) {
 
 	Convey("When no datasetType and is_based_on are provided", t, func() {
 		expectedFilter := bson.M{}
-		filter, err := buildDatasetsQueryUsingParameters("", "", "", true)
+		filter, err := BuildDatasetsQueryUsingParameters("", "", "", true)
 
 		So(err, ShouldBeNil)
 		So(filter, ShouldResemble, expectedFilter)
@@ -365,7 +372,7 @@ func TestBuildDatasetsQueryUsingParameters(t *testing.T) {
 				bson.M{"next.type": mockDatasetType.String()},
 			},
 		}
-		filter, err := buildDatasetsQueryUsingParameters("", mockDatasetType.String(), "", true)
+		filter, err := BuildDatasetsQueryUsingParameters("", mockDatasetType.String(), "", true)
 
 		So(err, ShouldBeNil)
 		So(filter, ShouldResemble, expectedFilter)
@@ -380,7 +387,7 @@ func TestBuildDatasetsQueryUsingParameters(t *testing.T) {
 			},
 		}
 
-		filter, err := buildDatasetsQueryUsingParameters(mockID, "", "", true)
+		filter, err := BuildDatasetsQueryUsingParameters(mockID, "", "", true)
 
 		So(err, ShouldBeNil)
 		So(filter, ShouldResemble, expectedFilter)
@@ -406,7 +413,7 @@ func TestBuildDatasetsQueryUsingParameters(t *testing.T) {
 			},
 		}
 
-		filter, err := buildDatasetsQueryUsingParameters(mockID, mockDatasetType.String(), "", true)
+		filter, err := BuildDatasetsQueryUsingParameters(mockID, mockDatasetType.String(), "", true)
 
 		So(err, ShouldBeNil)
 		So(filter, ShouldResemble, expectedFilter)
@@ -416,7 +423,7 @@ func TestBuildDatasetsQueryUsingParameters(t *testing.T) {
 		mockID := "12345"
 		expectedFilter := bson.M{"_id": bson.M{"$regex": mockID}}
 
-		filter, err := buildDatasetsQueryUsingParameters("", "", mockID, true)
+		filter, err := BuildDatasetsQueryUsingParameters("", "", mockID, true)
 
 		So(err, ShouldBeNil)
 		So(filter, ShouldResemble, expectedFilter)
@@ -438,7 +445,7 @@ func TestBuildDatasetsQueryUsingParameters(t *testing.T) {
 			},
 		}
 
-		filter, err := buildDatasetsQueryUsingParameters("", mockType.String(), mockID, true)
+		filter, err := BuildDatasetsQueryUsingParameters("", mockType.String(), mockID, true)
 
 		So(err, ShouldBeNil)
 		So(filter, ShouldResemble, expectedFilter)
@@ -449,7 +456,7 @@ func TestBuildDatasetsQueryUsingParameters(t *testing.T) {
 			"current": bson.M{"$exists": true},
 		}
 
-		filter, err := buildDatasetsQueryUsingParameters("", "", "", false)
+		filter, err := BuildDatasetsQueryUsingParameters("", "", "", false)
 
 		So(err, ShouldBeNil)
 		So(filter, ShouldResemble, expectedFilter)
@@ -476,7 +483,7 @@ func TestBuildDatasetsQueryUsingParameters(t *testing.T) {
 			"current": bson.M{"$exists": true},
 		}
 
-		filter, err := buildDatasetsQueryUsingParameters(mockID, mockDatasetType.String(), "", false)
+		filter, err := BuildDatasetsQueryUsingParameters(mockID, mockDatasetType.String(), "", false)
 
 		So(err, ShouldBeNil)
 		So(filter, ShouldResemble, expectedFilter)
@@ -485,7 +492,7 @@ func TestBuildDatasetsQueryUsingParameters(t *testing.T) {
 	Convey("When an invalid datasetType is provided", t, func() {
 		invalidType := "invalid_type"
 
-		filter, err := buildDatasetsQueryUsingParameters("", invalidType, "", true)
+		filter, err := BuildDatasetsQueryUsingParameters("", invalidType, "", true)
 
 		So(err, ShouldNotBeNil)
 		So(filter, ShouldBeNil)
@@ -536,3 +543,71 @@ func TestIsStaticDataset(t *testing.T) {
 		})
 	})
 }
+
+func TestUpsertDatasetOverDeletedDataset(t *testing.T) {
+	Convey("Given a published dataset that has been deleted", t, func() {
+		ctx := context.Background()
+		mongo, err := getTestMongoDB(ctx, t)
+		So(err, ShouldBeNil)
+
+		published := &models.Dataset{ID: "deleted-dataset", Title: "Deleted dataset", State: models.PublishedState}
+		So(mongo.UpsertDataset(ctx, "deleted-dataset", &models.DatasetUpdate{ID: "deleted-dataset", Current: published, Next: published}), ShouldBeNil)
+		So(mongo.DeleteDataset(ctx, "deleted-dataset", models.NewDeletion("user@ons.gov.uk")), ShouldBeNil)
+
+		Convey("When a new dataset is created with the same ID", func() {
+			err := mongo.UpsertDataset(ctx, "deleted-dataset", &models.DatasetUpdate{
+				ID:   "deleted-dataset",
+				Next: &models.Dataset{ID: "deleted-dataset", Title: "New dataset", State: models.CreatedState, Type: models.Filterable.String()},
+			})
+
+			Convey("Then the new dataset is not deleted and the published state of the deleted one is not brought back", func() {
+				So(err, ShouldBeNil)
+
+				dataset, err := mongo.GetDataset(ctx, "deleted-dataset")
+				So(err, ShouldBeNil)
+				So(dataset.Next.Title, ShouldEqual, "New dataset")
+				So(dataset.Current, ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestUpdateDatasetConcurrently(t *testing.T) {
+	Convey("Given a dataset", t, func() {
+		ctx := context.Background()
+		mongo, err := getTestMongoDB(ctx, t)
+		So(err, ShouldBeNil)
+
+		So(mongo.UpsertDataset(ctx, "revised-dataset", &models.DatasetUpdate{
+			ID:   "revised-dataset",
+			Next: &models.Dataset{ID: "revised-dataset", Title: "Revised dataset", State: models.CreatedState, Type: models.Filterable.String()},
+		}), ShouldBeNil)
+
+		Convey("When it is updated concurrently", func() {
+			var wg sync.WaitGroup
+			results := make(chan error, 10)
+			for i := 0; i < 10; i++ {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					results <- mongo.UpdateDataset(ctx, "revised-dataset", &models.Dataset{Title: fmt.Sprintf("Revised dataset %d", i)}, models.CreatedState)
+				}(i)
+			}
+			wg.Wait()
+			close(results)
+
+			Convey("Then every update succeeds and stores a revision with its own number", func() {
+				for err := range results {
+					So(err, ShouldBeNil)
+				}
+
+				revisions, count, err := mongo.GetDatasetRevisions(ctx, "revised-dataset", 0, 20)
+				So(err, ShouldBeNil)
+				So(count, ShouldEqual, 11)
+				for i, revision := range revisions {
+					So(revision.Revision, ShouldEqual, 11-i)
+				}
+			})
+		})
+	})
+}
@@ -0,0 +1,191 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	"github.com/ONSdigital/dp-dataset-api/config"
+	"github.com/ONSdigital/dp-dataset-api/models"
+	mongodriver "github.com/ONSdigital/dp-mongodb/v3/mongodb"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// NotDeleted restricts the filter to the documents that have not been deleted. Deleted datasets, editions and static
+// versions are kept until they are purged, so that they can be restored, but they are hidden from every read.
+func NotDeleted(filter bson.M) bson.M {
+	filter["deleted_at"] = bson.M{"$exists": false}
+	return filter
+}
+
+// Deleted restricts the filter to the documents that have been deleted
+func Deleted(filter bson.M) bson.M {
+	filter["deleted_at"] = bson.M{"$exists": true}
+	return filter
+}
+
+// DeletedBefore selects the documents that were deleted before the provided time
+func DeletedBefore(before time.Time) bson.M {
+	return bson.M{"deleted_at": bson.M{"$lt": before}}
+}
+
+// DeleteUpdate marks a document as deleted
+func DeleteUpdate(deletion *models.Deletion) bson.M {
+	return bson.M{"$set": bson.M{"deleted_at": deletion.DeletedAt, "deleted_by": deletion.DeletedBy}}
+}
+
+// DeletionFields are the fields to unset in order to restore a deleted document
+func DeletionFields() bson.M {
+	return bson.M{"deleted_at": "", "deleted_by": ""}
+}
+
+// RestoreUpdate removes the deletion of a document
+func RestoreUpdate() bson.M {
+	return bson.M{"$unset": DeletionFields()}
+}
+
+// softDelete marks the document matched by the selector as deleted, returning notFound if there is no such document
+func (m *Mongo) softDelete(ctx context.Context, collectionKey string, selector bson.M, deletion *models.Deletion, notFound error) error {
+	collection := m.ActualCollectionName(collectionKey)
+	selector = NotDeleted(selector)
+
+	entry, err := m.recordRollback(ctx, collection, selector)
+	if err != nil {
+		return err
+	}
+
+	if _, err := m.Connection.Collection(collection).Must().UpdateOne(ctx, selector, DeleteUpdate(deletion)); err != nil {
+		if errors.Is(err, mongodriver.ErrNoDocumentFound) {
+			return notFound
+		}
+		return err
+	}
+
+	return m.recordWrite(ctx, entry, nil)
+}
+
+// RestoreDataset restores a deleted dataset, along with the editions and static versions that were deleted with it.
+// The documents are restored in a single transaction, so that either all of them are restored or none are.
+func (m *Mongo) RestoreDataset(ctx context.Context, id string) error {
+	return m.RunTransaction(ctx, func(transactionCtx context.Context) error {
+		var deleted models.Deletion
+		if err := m.Connection.Collection(m.ActualCollectionName(config.DatasetsCollection)).FindOne(transactionCtx, Deleted(bson.M{"_id": id}), &deleted); err != nil {
+			if errors.Is(err, mongodriver.ErrNoDocumentFound) {
+				return errs.ErrDatasetNotFound
+			}
+			return err
+		}
+
+		if err := m.restoreDeleted(transactionCtx, config.EditionsCollection, bson.M{"next.links.dataset.id": id, "deleted_at": deleted.DeletedAt}); err != nil {
+			return err
+		}
+
+		if err := m.restoreDeleted(transactionCtx, config.VersionsCollection, bson.M{"links.dataset.id": id, "deleted_at": deleted.DeletedAt}); err != nil {
+			return err
+		}
+
+		return m.restoreDeleted(transactionCtx, config.DatasetsCollection, Deleted(bson.M{"_id": id}))
+	})
+}
+
+// RestoreEdition restores a deleted edition of a dataset
+func (m *Mongo) RestoreEdition(ctx context.Context, datasetID, edition string) error {
+	selector := bson.M{"next.links.dataset.id": datasetID, "next.edition": edition}
+	return m.restore(ctx, config.EditionsCollection, selector, errs.ErrEditionNotFound)
+}
+
+// RestoreStaticVersion restores a deleted static version of an edition of a dataset
+func (m *Mongo) RestoreStaticVersion(ctx context.Context, datasetID, edition string, version int) error {
+	selector := bson.M{"links.dataset.id": datasetID, "edition": edition, "version": version}
+	return m.restore(ctx, config.VersionsCollection, selector, errs.ErrVersionNotFound)
+}
+
+// restore removes the deletion of the deleted document matched by the selector, returning notFound if there is no such document
+func (m *Mongo) restore(ctx context.Context, collectionKey string, selector bson.M, notFound error) error {
+	collection := m.ActualCollectionName(collectionKey)
+	selector = Deleted(selector)
+
+	entry, err := m.recordRollback(ctx, collection, selector)
+	if err != nil {
+		return err
+	}
+
+	if _, err := m.Connection.Collection(collection).Must().UpdateOne(ctx, selector, RestoreUpdate()); err != nil {
+		if errors.Is(err, mongodriver.ErrNoDocumentFound) {
+			return notFound
+		}
+		return err
+	}
+
+	return m.recordWrite(ctx, entry, nil)
+}
+
+// restoreDeleted restores the documents matched by the selector, which must only match deleted documents. Each document is restored on its own, so
+// that it can be reverted if the transaction of the caller fails without mongoDB transactions.
+func (m *Mongo) restoreDeleted(ctx context.Context, collectionKey string, selector bson.M) error {
+	collection := m.ActualCollectionName(collectionKey)
+
+	var documents []bson.M
+	if _, err := m.Connection.Collection(collection).Find(ctx, selector, &documents, mongodriver.Projection(bson.M{"_id": 1})); err != nil {
+		return err
+	}
+
+	for _, document := range documents {
+		entry, err := m.recordRollback(ctx, collection, bson.M{"_id": document["_id"]})
+		if err != nil {
+			return err
+		}
+
+		if _, err := m.Connection.Collection(collection).UpdateById(ctx, document["_id"], RestoreUpdate()); err != nil {
+			return err
+		}
+
+		if err := m.recordWrite(ctx, entry, nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// PurgeDeletedDatasets permanently removes the datasets and editions that were deleted before the provided time, along
+// with the revisions of the datasets, returning the number of documents removed
+func (m *Mongo) PurgeDeletedDatasets(ctx context.Context, before time.Time) (int, error) {
+	// the revisions are purged first, as the deleted datasets are needed to find them
+	purged, err := m.purgeDatasetRevisions(ctx, before)
+	if err != nil {
+		return purged, err
+	}
+
+	for _, collection := range []string{config.EditionsCollection, config.DatasetsCollection} {
+		result, err := m.Connection.Collection(m.ActualCollectionName(collection)).DeleteMany(ctx, DeletedBefore(before))
+		if err != nil {
+			return purged, err
+		}
+		purged += result.DeletedCount
+	}
+	return purged, nil
+}
+
+// GetDeletedStaticVersions returns up to limit static versions that were deleted before the provided time
+func (m *Mongo) GetDeletedStaticVersions(ctx context.Context, before time.Time, limit int) ([]*models.Version, error) {
+	results := []*models.Version{}
+	if _, err := m.Connection.Collection(m.ActualCollectionName(config.VersionsCollection)).Find(ctx, DeletedBefore(before), &results,
+		mongodriver.Sort(bson.M{"deleted_at": 1}), mongodriver.Limit(limit)); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// PurgeStaticVersion permanently removes a deleted static version
+func (m *Mongo) PurgeStaticVersion(ctx context.Context, id string) error {
+	selector := bson.M{"id": id, "deleted_at": bson.M{"$exists": true}}
+	if _, err := m.Connection.Collection(m.ActualCollectionName(config.VersionsCollection)).Must().DeleteOne(ctx, selector); err != nil {
+		if errors.Is(err, mongodriver.ErrNoDocumentFound) {
+			return errs.ErrVersionNotFound
+		}
+		return err
+	}
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
 	"github.com/ONSdigital/dp-dataset-api/config"
 	"github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/dp-dataset-api/pagination"
 	"github.com/ONSdigital/log.go/v2/log"
 
 	mongodriver "github.com/ONSdigital/dp-mongodb/v3/mongodb"
@@ -17,6 +18,14 @@ import (
 
 const maxIDs = 1000
 
+// dimensionOptionIndexes are the indexes used to list the options of an instance dimension, sorted by option or by order
+var dimensionOptionIndexes = []CollectionIndexes{
+	indexesOn(config.DimensionOptionsCollection,
+		ascending("instance_id", "name", "option"),
+		ascending("instance_id", "name", "order", "option"),
+	),
+}
+
 // GetDimensionsFromInstance returns a list of dimensions and their options for an instance resource.
 // Note that all dimension options for all dimensions are returned as high level items, hence there can be duplicate dimension names,
 // which correspond to different options.
@@ -94,23 +103,49 @@ func (m *Mongo) GetDimensions(ctx context.Context, versionID string) ([]bson.M,
 	return results, nil
 }
 
-// GetDimensionOptions returns dimension options for a dimensions within a dataset, according to the provided limit and offset.
-// Offset and limit need to be positive or zero
-func (m *Mongo) GetDimensionOptions(ctx context.Context, version *models.Version, dimension string, offset, limit int) ([]*models.PublicDimensionOption, int, error) {
+// GetDimensionOptions returns dimension options for a dimensions within a dataset, according to the provided limit and offset,
+// or starting right after the cursor if provided. Offset and limit need to be positive or zero
+func (m *Mongo) GetDimensionOptions(ctx context.Context, version *models.Version, dimension string, offset, limit int, cursor *pagination.Cursor) ([]*models.PublicDimensionOption, int, *pagination.Cursor, error) {
 	// define selector to obtain all the dimension options for an instance
 	selector := bson.M{"instance_id": version.ID, "name": dimension}
 
-	s, err := m.sortOrder(ctx, selector)
+	sortBy, err := m.sortOrder(ctx, selector)
 	if err != nil {
-		return nil, 0, err
+		return nil, 0, nil, err
+	}
+
+	collection := m.Connection.Collection(m.ActualCollectionName(config.DimensionOptionsCollection))
+	s := mongodriver.Sort(bson.D{{Key: "option", Value: 1}})
+	if sortBy == "order" {
+		s = mongodriver.Sort(bson.D{{Key: "order", Value: 1}, {Key: "option", Value: 1}})
 	}
 
 	// get total count and paginated values according to provided offset and limit
 	values := []*models.PublicDimensionOption{}
-	totalCount, err := m.Connection.Collection(m.ActualCollectionName(config.DimensionOptionsCollection)).Find(ctx, selector, &values,
-		s, mongodriver.Offset(offset), mongodriver.Limit(limit))
-	if err != nil {
-		return values, 0, err
+	var totalCount int
+	var nextCursor *pagination.Cursor
+	if cursor == nil {
+		totalCount, err = collection.Find(ctx, selector, &values, s, mongodriver.Offset(offset), mongodriver.Limit(limit))
+		if err != nil {
+			return values, 0, nil, err
+		}
+		nextCursor = NextCursor(values, offset+len(values) < totalCount, DimensionOptionCursor)
+	} else {
+		after, err := DimensionOptionsAfterCursor(selector, sortBy, cursor)
+		if err != nil {
+			return nil, 0, nil, err
+		}
+
+		totalCount, err = collection.Count(ctx, selector)
+		if err != nil {
+			return values, 0, nil, err
+		}
+
+		remaining, err := collection.Find(ctx, after, &values, s, mongodriver.Limit(limit))
+		if err != nil {
+			return values, 0, nil, err
+		}
+		nextCursor = NextCursor(values, len(values) < remaining, DimensionOptionCursor)
 	}
 
 	// update links for returned values
@@ -118,7 +153,21 @@ func (m *Mongo) GetDimensionOptions(ctx context.Context, version *models.Version
 		values[i].Links.Version = *version.Links.Self
 	}
 
-	return values, totalCount, nil
+	return values, totalCount, nextCursor, nil
+}
+
+// DimensionOptionsAfterCursor restricts the selector to the dimension options that come after the cursor, when sorted by sortBy.
+// A cursor without order cannot be used to continue a list of options sorted by order.
+func DimensionOptionsAfterCursor(selector bson.M, sortBy string, cursor *pagination.Cursor) (bson.M, error) {
+	if sortBy != "order" {
+		return AfterCursor(selector, "", nil, "option", cursor.ID, 1), nil
+	}
+
+	if cursor.Order == nil {
+		return nil, errs.ErrInvalidQueryParameter
+	}
+
+	return AfterCursor(selector, "order", *cursor.Order, "option", cursor.ID, 1), nil
 }
 
 // GetDimensionOptionsFromIDs returns dimension options for a dimension within a dataset, whose IDs match the provided list of IDs
@@ -138,14 +187,14 @@ func (m *Mongo) GetDimensionOptionsFromIDs(ctx context.Context, version *models.
 	var values []*models.PublicDimensionOption
 	if totalCount > 0 {
 		// obtain query defining the order for the provided IDs only
-		s, err := m.sortOrder(ctx, selectorInList)
+		sortBy, err := m.sortOrder(ctx, selectorInList)
 		if err != nil {
 			return nil, 0, err
 		}
 
 		// obtain all required options in order
 		if _, err := m.Connection.Collection(m.ActualCollectionName(config.DimensionOptionsCollection)).Find(ctx, selectorInList, &values,
-			s, mongodriver.Limit(totalCount)); err != nil {
+			mongodriver.Sort(bson.M{sortBy: 1}), mongodriver.Limit(totalCount)); err != nil {
 			return nil, 0, err
 		}
 
@@ -196,20 +245,20 @@ func (m *Mongo) UpdateDimensionsNodeIDAndOrder(ctx context.Context, dimensions [
 	return nil
 }
 
-// sortOrder generates a sort order from the provided bson.M selector
+// sortOrder returns the field to sort the options matched by the provided bson.M selector:
 // if the order property exists, it will be used to determine the order
 // otherwise, the items will be sorted alphabetically by option
-func (m *Mongo) sortOrder(ctx context.Context, selector bson.M) (mongodriver.FindOption, error) {
+func (m *Mongo) sortOrder(ctx context.Context, selector bson.M) (string, error) {
 	selector["order"] = bson.M{"$exists": true}
 	orderCount, err := m.Connection.Collection(m.ActualCollectionName(config.DimensionOptionsCollection)).Count(ctx, selector)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 	delete(selector, "order")
 
 	if orderCount > 0 {
-		return mongodriver.Sort(bson.M{"order": 1}), nil
+		return "order", nil
 	}
 
-	return mongodriver.Sort(bson.M{"option": 1}), nil
+	return "option", nil
 }
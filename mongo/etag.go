@@ -11,7 +11,8 @@ import (
 // AnyETag represents the wildchar that corresponds to not check the ETag value for update requests
 const AnyETag = "*"
 
-func newETagForUpdate(currentInstance, update *models.Instance) (eTag string, err error) {
+// NewETagForUpdate returns the eTag that an instance would have after applying the provided update
+func NewETagForUpdate(currentInstance, update *models.Instance) (eTag string, err error) {
 	b, err := bson.Marshal(update)
 	if err != nil {
 		return "", err
@@ -19,7 +20,8 @@ func newETagForUpdate(currentInstance, update *models.Instance) (eTag string, er
 	return currentInstance.Hash(b)
 }
 
-func newETagForVersionUpdate(currentVersion, update *models.Version) (eTag string, err error) {
+// NewETagForVersionUpdate returns the eTag that a version would have after applying the provided update
+func NewETagForVersionUpdate(currentVersion, update *models.Version) (eTag string, err error) {
 	b, err := bson.Marshal(update)
 	if err != nil {
 		return "", err
@@ -27,7 +29,8 @@ func newETagForVersionUpdate(currentVersion, update *models.Version) (eTag strin
 	return currentVersion.Hash(b)
 }
 
-func newETagForAddEvent(currentInstance *models.Instance, event *models.Event) (eTag string, err error) {
+// NewETagForAddEvent returns the eTag that an instance would have after adding the provided event
+func NewETagForAddEvent(currentInstance *models.Instance, event *models.Event) (eTag string, err error) {
 	b, err := bson.Marshal(event)
 	if err != nil {
 		return "", err
@@ -35,27 +38,32 @@ func newETagForAddEvent(currentInstance *models.Instance, event *models.Event) (
 	return currentInstance.Hash(b)
 }
 
-func newETagForObservationsInserted(currentInstance *models.Instance, observationInserted int64) (eTag string, err error) {
+// NewETagForObservationsInserted returns the eTag that an instance would have after increasing its inserted observations
+func NewETagForObservationsInserted(currentInstance *models.Instance, observationInserted int64) (eTag string, err error) {
 	b := []byte(fmt.Sprintf("observationInserted%d", observationInserted))
 	return currentInstance.Hash(b)
 }
 
-func newETagForStateUpdate(currentInstance *models.Instance, state string) (eTag string, err error) {
+// NewETagForStateUpdate returns the eTag that an instance would have after updating its import observations task state
+func NewETagForStateUpdate(currentInstance *models.Instance, state string) (eTag string, err error) {
 	b := []byte(fmt.Sprintf("state%s", state))
 	return currentInstance.Hash(b)
 }
 
-func newETagForHierarchyTaskStateUpdate(currentInstance *models.Instance, dimension, state string) (eTag string, err error) {
+// NewETagForHierarchyTaskStateUpdate returns the eTag that an instance would have after updating a build hierarchy task state
+func NewETagForHierarchyTaskStateUpdate(currentInstance *models.Instance, dimension, state string) (eTag string, err error) {
 	b := []byte(fmt.Sprintf("hierarchyTask_dimension%sstate%s", dimension, state))
 	return currentInstance.Hash(b)
 }
 
-func newETagForBuildSearchTaskStateUpdate(currentInstance *models.Instance, dimension, state string) (eTag string, err error) {
+// NewETagForBuildSearchTaskStateUpdate returns the eTag that an instance would have after updating a build search task state
+func NewETagForBuildSearchTaskStateUpdate(currentInstance *models.Instance, dimension, state string) (eTag string, err error) {
 	b := []byte(fmt.Sprintf("buildSearchTask_dimension%sstate%s", dimension, state))
 	return currentInstance.Hash(b)
 }
 
-func newETagForOptions(currentInstance *models.Instance, upserts []*models.CachedDimensionOption, updates []*models.DimensionOption) (eTag string, err error) {
+// NewETagForOptions returns the eTag that an instance would have after applying the provided dimension option upserts and updates
+func NewETagForOptions(currentInstance *models.Instance, upserts []*models.CachedDimensionOption, updates []*models.DimensionOption) (eTag string, err error) {
 	extraBytes := []byte{}
 
 	// append upserts option bytes to the hash func
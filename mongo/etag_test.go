@@ -55,14 +55,14 @@ func TestNewETagForUpdate(t *testing.T) {
 		}
 
 		Convey("getNewETagForUpdate returns an eTag that is different from the original instance ETag", func() {
-			eTag1, err := newETagForUpdate(currentInstance, update)
+			eTag1, err := NewETagForUpdate(currentInstance, update)
 			So(err, ShouldBeNil)
 			So(eTag1, ShouldNotEqual, currentInstance.ETag)
 
 			Convey("Applying the same update to a different instance results in a different ETag", func() {
 				instance2 := testInstance()
 				instance2.InstanceID = instanceID(2)
-				eTag2, err := newETagForUpdate(instance2, update)
+				eTag2, err := NewETagForUpdate(instance2, update)
 				So(err, ShouldBeNil)
 				So(eTag2, ShouldNotEqual, eTag1)
 			})
@@ -71,7 +71,7 @@ func TestNewETagForUpdate(t *testing.T) {
 				update2 := &models.Instance{
 					InstanceID: instanceID(3),
 				}
-				eTag3, err := newETagForUpdate(currentInstance, update2)
+				eTag3, err := NewETagForUpdate(currentInstance, update2)
 				So(err, ShouldBeNil)
 				So(eTag3, ShouldNotEqual, eTag1)
 			})
@@ -87,15 +87,15 @@ func TestNewETagForVersionUpdate(t *testing.T) {
 			State: models.CompletedState,
 		}
 
-		Convey("newETagForVersionUpdate returns an eTag that is different from the original version ETag", func() {
-			eTag1, err := newETagForVersionUpdate(currentVersion, update)
+		Convey("NewETagForVersionUpdate returns an eTag that is different from the original version ETag", func() {
+			eTag1, err := NewETagForVersionUpdate(currentVersion, update)
 			So(err, ShouldBeNil)
 			So(eTag1, ShouldNotEqual, currentVersion.ETag)
 
 			Convey("Applying the same update to a different version results in a different ETag", func() {
 				v2 := testVersion()
 				v2.ID = "otherVersion"
-				eTag2, err := newETagForVersionUpdate(v2, update)
+				eTag2, err := NewETagForVersionUpdate(v2, update)
 				So(err, ShouldBeNil)
 				So(eTag2, ShouldNotEqual, eTag1)
 			})
@@ -104,7 +104,7 @@ func TestNewETagForVersionUpdate(t *testing.T) {
 				update2 := &models.Version{
 					ID: "anotherInstanceID",
 				}
-				eTag3, err := newETagForVersionUpdate(currentVersion, update2)
+				eTag3, err := NewETagForVersionUpdate(currentVersion, update2)
 				So(err, ShouldBeNil)
 				So(eTag3, ShouldNotEqual, eTag1)
 			})
@@ -120,15 +120,15 @@ func TestNewETagForAddEvent(t *testing.T) {
 			Message: "testEvent",
 		}
 
-		Convey("newETagForAddEvent returns an eTag that is different from the original instance ETag", func() {
-			eTag1, err := newETagForAddEvent(currentInstance, &event)
+		Convey("NewETagForAddEvent returns an eTag that is different from the original instance ETag", func() {
+			eTag1, err := NewETagForAddEvent(currentInstance, &event)
 			So(err, ShouldBeNil)
 			So(eTag1, ShouldNotEqual, currentInstance.ETag)
 
 			Convey("Applying the same update to a different instance results in a different ETag", func() {
 				instance2 := testInstance()
 				instance2.InstanceID = instanceID(2)
-				eTag2, err := newETagForAddEvent(instance2, &event)
+				eTag2, err := NewETagForAddEvent(instance2, &event)
 				So(err, ShouldBeNil)
 				So(eTag2, ShouldNotEqual, eTag1)
 			})
@@ -137,7 +137,7 @@ func TestNewETagForAddEvent(t *testing.T) {
 				event = models.Event{
 					Message: "anotherEvent",
 				}
-				eTag3, err := newETagForAddEvent(currentInstance, &event)
+				eTag3, err := NewETagForAddEvent(currentInstance, &event)
 				So(err, ShouldBeNil)
 				So(eTag3, ShouldNotEqual, eTag1)
 			})
@@ -151,22 +151,22 @@ func TestNewETagForObservationsInserted(t *testing.T) {
 
 		var obsInserted int64 = 12345
 
-		Convey("newETagForObservationsInserted returns an eTag that is different from the original instance ETag", func() {
-			eTag1, err := newETagForObservationsInserted(currentInstance, obsInserted)
+		Convey("NewETagForObservationsInserted returns an eTag that is different from the original instance ETag", func() {
+			eTag1, err := NewETagForObservationsInserted(currentInstance, obsInserted)
 			So(err, ShouldBeNil)
 			So(eTag1, ShouldNotEqual, currentInstance.ETag)
 
 			Convey("Applying the same update to a different instance results in a different ETag", func() {
 				instance2 := testInstance()
 				instance2.InstanceID = instanceID(2)
-				eTag2, err := newETagForObservationsInserted(instance2, obsInserted)
+				eTag2, err := NewETagForObservationsInserted(instance2, obsInserted)
 				So(err, ShouldBeNil)
 				So(eTag2, ShouldNotEqual, eTag1)
 			})
 
 			Convey("Applying a different update to the same instance results in a different ETag", func() {
 				obsInserted = 54321
-				eTag3, err := newETagForObservationsInserted(currentInstance, obsInserted)
+				eTag3, err := NewETagForObservationsInserted(currentInstance, obsInserted)
 				So(err, ShouldBeNil)
 				So(eTag3, ShouldNotEqual, eTag1)
 			})
@@ -178,21 +178,21 @@ func TestNewETagForStateUpdate(t *testing.T) {
 	Convey("Given an instance", t, func() {
 		currentInstance := testInstance()
 
-		Convey("newETagForStateUpdate returns an eTag that is different from the original instance ETag", func() {
-			eTag1, err := newETagForStateUpdate(currentInstance, models.CompletedState)
+		Convey("NewETagForStateUpdate returns an eTag that is different from the original instance ETag", func() {
+			eTag1, err := NewETagForStateUpdate(currentInstance, models.CompletedState)
 			So(err, ShouldBeNil)
 			So(eTag1, ShouldNotEqual, currentInstance.ETag)
 
 			Convey("Applying the same update to a different instance results in a different ETag", func() {
 				instance2 := testInstance()
 				instance2.InstanceID = instanceID(2)
-				eTag2, err := newETagForStateUpdate(instance2, models.CompletedState)
+				eTag2, err := NewETagForStateUpdate(instance2, models.CompletedState)
 				So(err, ShouldBeNil)
 				So(eTag2, ShouldNotEqual, eTag1)
 			})
 
 			Convey("Applying a different update to the same instance results in a different ETag", func() {
-				eTag3, err := newETagForStateUpdate(currentInstance, models.DetachedState)
+				eTag3, err := NewETagForStateUpdate(currentInstance, models.DetachedState)
 				So(err, ShouldBeNil)
 				So(eTag3, ShouldNotEqual, eTag1)
 			})
@@ -205,21 +205,21 @@ func TestNewETagForHierarchyTaskStateUpdate(t *testing.T) {
 		currentInstance := testInstance()
 		dimension := "dim1"
 
-		Convey("newETagForHierarchyTaskStateUpdate returns an eTag that is different from the original instance ETag", func() {
-			eTag1, err := newETagForHierarchyTaskStateUpdate(currentInstance, dimension, models.CompletedState)
+		Convey("NewETagForHierarchyTaskStateUpdate returns an eTag that is different from the original instance ETag", func() {
+			eTag1, err := NewETagForHierarchyTaskStateUpdate(currentInstance, dimension, models.CompletedState)
 			So(err, ShouldBeNil)
 			So(eTag1, ShouldNotEqual, currentInstance.ETag)
 
 			Convey("Applying the same update to a different instance results in a different ETag", func() {
 				instance2 := testInstance()
 				instance2.InstanceID = instanceID(2)
-				eTag2, err := newETagForHierarchyTaskStateUpdate(instance2, dimension, models.CompletedState)
+				eTag2, err := NewETagForHierarchyTaskStateUpdate(instance2, dimension, models.CompletedState)
 				So(err, ShouldBeNil)
 				So(eTag2, ShouldNotEqual, eTag1)
 			})
 
 			Convey("Applying a different update to the same instance results in a different ETag", func() {
-				eTag3, err := newETagForHierarchyTaskStateUpdate(currentInstance, dimension, models.DetachedState)
+				eTag3, err := NewETagForHierarchyTaskStateUpdate(currentInstance, dimension, models.DetachedState)
 				So(err, ShouldBeNil)
 				So(eTag3, ShouldNotEqual, eTag1)
 			})
@@ -232,21 +232,21 @@ func TestNewETagForBuildSearchTaskStateUpdate(t *testing.T) {
 		currentInstance := testInstance()
 		dimension := "dim1"
 
-		Convey("newETagForBuildSearchTaskStateUpdate returns an eTag that is different from the original instance ETag", func() {
-			eTag1, err := newETagForBuildSearchTaskStateUpdate(currentInstance, dimension, models.CompletedState)
+		Convey("NewETagForBuildSearchTaskStateUpdate returns an eTag that is different from the original instance ETag", func() {
+			eTag1, err := NewETagForBuildSearchTaskStateUpdate(currentInstance, dimension, models.CompletedState)
 			So(err, ShouldBeNil)
 			So(eTag1, ShouldNotEqual, currentInstance.ETag)
 
 			Convey("Applying the same update to a different instance results in a different ETag", func() {
 				instance2 := testInstance()
 				instance2.InstanceID = instanceID(2)
-				eTag2, err := newETagForBuildSearchTaskStateUpdate(instance2, dimension, models.CompletedState)
+				eTag2, err := NewETagForBuildSearchTaskStateUpdate(instance2, dimension, models.CompletedState)
 				So(err, ShouldBeNil)
 				So(eTag2, ShouldNotEqual, eTag1)
 			})
 
 			Convey("Applying a different update to the same instance results in a different ETag", func() {
-				eTag3, err := newETagForBuildSearchTaskStateUpdate(currentInstance, dimension, models.DetachedState)
+				eTag3, err := NewETagForBuildSearchTaskStateUpdate(currentInstance, dimension, models.DetachedState)
 				So(err, ShouldBeNil)
 				So(eTag3, ShouldNotEqual, eTag1)
 			})
@@ -278,27 +278,27 @@ func TestNewETagForOptions(t *testing.T) {
 			NodeID: "anotherNodeID",
 		}
 
-		Convey("newETagForOptions returns an eTag that is different from the original instance ETag when it is provided an upsert", func() {
-			eTag1, err := newETagForOptions(currentInstance, []*models.CachedDimensionOption{&optionUpsert}, nil)
+		Convey("NewETagForOptions returns an eTag that is different from the original instance ETag when it is provided an upsert", func() {
+			eTag1, err := NewETagForOptions(currentInstance, []*models.CachedDimensionOption{&optionUpsert}, nil)
 			So(err, ShouldBeNil)
 			So(eTag1, ShouldNotEqual, currentInstance.ETag)
 
 			Convey("Applying the same upsert to a different instance results in a different ETag", func() {
 				instance2 := testInstance()
 				instance2.InstanceID = instanceID(2)
-				eTag2, err := newETagForOptions(instance2, []*models.CachedDimensionOption{&optionUpsert}, nil)
+				eTag2, err := NewETagForOptions(instance2, []*models.CachedDimensionOption{&optionUpsert}, nil)
 				So(err, ShouldBeNil)
 				So(eTag2, ShouldNotEqual, eTag1)
 			})
 
 			Convey("Applying a different upsert to the same instance results in a different ETag", func() {
-				eTag3, err := newETagForOptions(currentInstance, []*models.CachedDimensionOption{&anotherOptionUpsert}, nil)
+				eTag3, err := NewETagForOptions(currentInstance, []*models.CachedDimensionOption{&anotherOptionUpsert}, nil)
 				So(err, ShouldBeNil)
 				So(eTag3, ShouldNotEqual, eTag1)
 			})
 
 			Convey("Applying an extra update to the same instance with the same update results in a different ETag", func() {
-				eTag3, err := newETagForOptions(currentInstance, []*models.CachedDimensionOption{&optionUpsert}, []*models.DimensionOption{&optionUpdate})
+				eTag3, err := NewETagForOptions(currentInstance, []*models.CachedDimensionOption{&optionUpsert}, []*models.DimensionOption{&optionUpdate})
 				So(err, ShouldBeNil)
 				So(eTag3, ShouldNotEqual, eTag1)
 			})
@@ -308,27 +308,27 @@ func TestNewETagForOptions(t *testing.T) {
 					Code: "anotherCode",
 					Name: "anotherName",
 				}
-				eTag3, err := newETagForOptions(currentInstance, []*models.CachedDimensionOption{&option, &anotherOptionUpsert}, nil)
+				eTag3, err := NewETagForOptions(currentInstance, []*models.CachedDimensionOption{&option, &anotherOptionUpsert}, nil)
 				So(err, ShouldBeNil)
 				So(eTag3, ShouldNotEqual, eTag1)
 			})
 		})
 
-		Convey("newETagForOptions returns an eTag that is different from the original instance ETag when it is provided an update", func() {
-			eTag1, err := newETagForOptions(currentInstance, nil, []*models.DimensionOption{&optionUpdate})
+		Convey("NewETagForOptions returns an eTag that is different from the original instance ETag when it is provided an update", func() {
+			eTag1, err := NewETagForOptions(currentInstance, nil, []*models.DimensionOption{&optionUpdate})
 			So(err, ShouldBeNil)
 			So(eTag1, ShouldNotEqual, currentInstance.ETag)
 
 			Convey("Applying the same update to a different instance results in a different ETag", func() {
 				instance2 := testInstance()
 				instance2.InstanceID = instanceID(2)
-				eTag2, err := newETagForOptions(instance2, nil, []*models.DimensionOption{&optionUpdate})
+				eTag2, err := NewETagForOptions(instance2, nil, []*models.DimensionOption{&optionUpdate})
 				So(err, ShouldBeNil)
 				So(eTag2, ShouldNotEqual, eTag1)
 			})
 
 			Convey("Applying a different update to the same instance results in a different ETag", func() {
-				eTag3, err := newETagForOptions(currentInstance, nil, []*models.DimensionOption{&anotherOptionUpdate})
+				eTag3, err := NewETagForOptions(currentInstance, nil, []*models.DimensionOption{&anotherOptionUpdate})
 				So(err, ShouldBeNil)
 				So(eTag3, ShouldNotEqual, eTag1)
 			})
@@ -0,0 +1,277 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ONSdigital/log.go/v2/log"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// defaultIndexName is the name of the index that mongo creates on _id for every collection
+const defaultIndexName = "_id_"
+
+// indexRefreshInterval is the time after which the indexes are verified again by the Checker, while some are missing
+const indexRefreshInterval = time.Minute
+
+// Index describes an index that a store relies on
+type Index struct {
+	Keys   bson.D
+	Unique bool
+	Sparse bool
+}
+
+// Name returns the name that mongo gives to an index with these keys by default, e.g. "state_1_last_updated_-1"
+func (i Index) Name() string {
+	parts := make([]string, 0, len(i.Keys)*2)
+	for _, key := range i.Keys {
+		parts = append(parts, key.Key, fmt.Sprint(key.Value))
+	}
+	return strings.Join(parts, "_")
+}
+
+// CollectionIndexes are the indexes that are required on a collection
+type CollectionIndexes struct {
+	// Collection is the config key of the collection
+	Collection string
+	// Locks selects the lock collection that dplock creates for the collection, instead of the collection itself
+	Locks   bool
+	Indexes []Index
+}
+
+// lockIndexes are the indexes that the lock client creates on a lock collection
+var lockIndexes = []Index{
+	{Keys: bson.D{{Key: "resource", Value: 1}}, Unique: true, Sparse: true},
+	{Keys: bson.D{{Key: "exclusive.lockId", Value: 1}}},
+	{Keys: bson.D{{Key: "exclusive.expiresAt", Value: 1}}},
+	{Keys: bson.D{{Key: "shared.locks.lockId", Value: 1}}},
+	{Keys: bson.D{{Key: "shared.locks.expiresAt", Value: 1}}},
+}
+
+// RequiredIndexes returns the indexes declared by all the stores
+func RequiredIndexes() []CollectionIndexes {
+	var required []CollectionIndexes
+	required = append(required, datasetIndexes...)
+	required = append(required, instanceIndexes...)
+	required = append(required, dimensionOptionIndexes...)
+	required = append(required, versionIndexes...)
+	required = append(required, outboxIndexes...)
+	required = append(required, revisionIndexes...)
+	required = append(required, auditEventIndexes...)
+	return required
+}
+
+// IndexReport lists the differences between the required indexes and the existing ones, as collection.index_name.
+// A required index is missing if no index with its name exists with the same keys and options.
+// Extra indexes are only reported for information, as they may have been created by hand to investigate a query.
+type IndexReport struct {
+	Missing []string
+	Extra   []string
+}
+
+// OK returns true if all the required indexes exist, whether or not there are extra indexes
+func (r *IndexReport) OK() bool {
+	return len(r.Missing) == 0
+}
+
+func (r *IndexReport) String() string {
+	var parts []string
+	if len(r.Missing) > 0 {
+		parts = append(parts, "missing mongo indexes: "+strings.Join(r.Missing, ", "))
+	}
+	if len(r.Extra) > 0 {
+		parts = append(parts, "unexpected mongo indexes: "+strings.Join(r.Extra, ", "))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// indexCheck is the last report of the indexes, and the time they were verified at
+type indexCheck struct {
+	report     *IndexReport
+	verifiedAt time.Time
+}
+
+// existingIndex is an index returned by the $indexStats aggregation stage, whose spec holds the options of the index
+type existingIndex struct {
+	Name string `bson:"name"`
+	Key  bson.D `bson:"key"`
+	Spec struct {
+		Unique bool `bson:"unique"`
+		Sparse bool `bson:"sparse"`
+	} `bson:"spec"`
+}
+
+// indexCollectionName returns the actual name of the collection that the indexes are required on
+func (m *Mongo) indexCollectionName(collectionIndexes CollectionIndexes) string {
+	name := m.ActualCollectionName(collectionIndexes.Collection)
+	if collectionIndexes.Locks {
+		// dplock names the lock collection of a resource after it
+		return name + "_locks"
+	}
+	return name
+}
+
+// listIndexes returns the indexes that exist on the collection, using the $indexStats aggregation stage, as the connection
+// does not return the result of the listIndexes command. A collection that does not exist yet has no indexes.
+func (m *Mongo) listIndexes(ctx context.Context, collection string) ([]existingIndex, error) {
+	var stats []existingIndex
+	if err := m.Connection.Collection(collection).Aggregate(ctx, bson.A{bson.M{"$indexStats": bson.M{}}}, &stats); err != nil {
+		return nil, err
+	}
+
+	// the stats of an index are returned by each of the servers that hold it
+	indexes := make([]existingIndex, 0, len(stats))
+	listed := make(map[string]bool, len(stats))
+	for _, index := range stats {
+		if !listed[index.Name] {
+			listed[index.Name] = true
+			indexes = append(indexes, index)
+		}
+	}
+	return indexes, nil
+}
+
+// EnsureIndexes creates the required indexes that do not exist yet, then verifies them and caches the report for the Checker,
+// which verifies them again while some are missing.
+// Failures are logged rather than returned, so that the service can still start, and the indexes that could not be created
+// are reported by the Checker.
+func (m *Mongo) EnsureIndexes(ctx context.Context) {
+	m.createIndexes(ctx)
+
+	report, err := m.VerifyIndexes(ctx)
+	if err != nil {
+		log.Error(ctx, "failed to verify mongo indexes", err)
+		return
+	}
+	if len(report.Extra) > 0 {
+		log.Info(ctx, "found mongo indexes that are not required", log.Data{"indexes": report.Extra})
+	}
+	m.indexCheck.Store(&indexCheck{report: report, verifiedAt: time.Now()})
+}
+
+// checkedIndexReport returns the last report of the indexes, verifying them if they have not been verified yet, or if some
+// were missing and they were verified before the refresh interval, e.g. because they were still being built
+func (m *Mongo) checkedIndexReport(ctx context.Context) (*IndexReport, error) {
+	check := m.indexCheck.Load()
+	if check != nil && (check.report.OK() || time.Since(check.verifiedAt) < indexRefreshInterval) {
+		return check.report, nil
+	}
+
+	report, err := m.VerifyIndexes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	m.indexCheck.Store(&indexCheck{report: report, verifiedAt: time.Now()})
+	return report, nil
+}
+
+// createIndexes creates the required indexes that do not exist yet, logging any failure
+func (m *Mongo) createIndexes(ctx context.Context) {
+	for _, collectionIndexes := range RequiredIndexes() {
+		collection := m.indexCollectionName(collectionIndexes)
+		logData := log.Data{"collection": collection}
+
+		existing, err := m.listIndexes(ctx, collection)
+		if err != nil {
+			log.Error(ctx, "failed to list mongo indexes", err, logData)
+			continue
+		}
+
+		names := make(map[string]bool, len(existing))
+		for _, index := range existing {
+			names[index.Name] = true
+		}
+
+		var specs bson.A
+		for _, index := range collectionIndexes.Indexes {
+			if names[index.Name()] {
+				continue
+			}
+			spec := bson.M{"key": index.Keys, "name": index.Name()}
+			if index.Unique {
+				spec["unique"] = true
+			}
+			if index.Sparse {
+				spec["sparse"] = true
+			}
+			specs = append(specs, spec)
+		}
+		if len(specs) == 0 {
+			continue
+		}
+
+		logData["indexes"] = specs
+		if err := m.Connection.RunCommand(ctx, bson.D{{Key: "createIndexes", Value: collection}, {Key: "indexes", Value: specs}}); err != nil {
+			log.Error(ctx, "failed to create mongo indexes", err, logData)
+			continue
+		}
+		log.Info(ctx, "created mongo indexes", logData)
+	}
+}
+
+// VerifyIndexes compares the existing indexes with the required ones
+func (m *Mongo) VerifyIndexes(ctx context.Context) (*IndexReport, error) {
+	report := &IndexReport{}
+	for _, collectionIndexes := range RequiredIndexes() {
+		collection := m.indexCollectionName(collectionIndexes)
+
+		existing, err := m.listIndexes(ctx, collection)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list indexes of collection %s: %w", collection, err)
+		}
+
+		missing, extra := compareIndexes(collectionIndexes.Indexes, existing)
+		for _, name := range missing {
+			report.Missing = append(report.Missing, collection+"."+name)
+		}
+		for _, name := range extra {
+			report.Extra = append(report.Extra, collection+"."+name)
+		}
+	}
+	return report, nil
+}
+
+// compareIndexes returns the names of the required indexes that do not exist with the same keys and options,
+// and of the existing indexes that are not required
+func compareIndexes(required []Index, existing []existingIndex) (missing, extra []string) {
+	existingIndexes := make(map[string]Index, len(existing))
+	for _, index := range existing {
+		existingIndexes[index.Name] = Index{Keys: index.Key, Unique: index.Spec.Unique, Sparse: index.Spec.Sparse}
+	}
+
+	requiredNames := make(map[string]bool, len(required))
+	for _, index := range required {
+		name := index.Name()
+		requiredNames[name] = true
+		existingIndex, ok := existingIndexes[name]
+		if !ok || existingIndex.Name() != name || existingIndex.Unique != index.Unique || existingIndex.Sparse != index.Sparse {
+			missing = append(missing, name)
+		}
+	}
+
+	for _, index := range existing {
+		if index.Name != defaultIndexName && !requiredNames[index.Name] {
+			extra = append(extra, index.Name)
+		}
+	}
+	sort.Strings(extra)
+
+	return missing, extra
+}
+
+// indexesOn is a shorthand for declaring the indexes of a collection in a store
+func indexesOn(collection string, indexes ...Index) CollectionIndexes {
+	return CollectionIndexes{Collection: collection, Indexes: indexes}
+}
+
+// ascending declares an index on the fields, in ascending order
+func ascending(fields ...string) Index {
+	keys := make(bson.D, 0, len(fields))
+	for _, field := range fields {
+		keys = append(keys, bson.E{Key: field, Value: 1})
+	}
+	return Index{Keys: keys}
+}
@@ -0,0 +1,167 @@
+package mongo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-dataset-api/config"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestIndexName(t *testing.T) {
+	Convey("An index is named after its keys and their order", t, func() {
+		So(ascending("instance_id", "name", "option").Name(), ShouldEqual, "instance_id_1_name_1_option_1")
+		So(Index{Keys: bson.D{{Key: "last_updated", Value: -1}, {Key: "id", Value: -1}}}.Name(), ShouldEqual, "last_updated_-1_id_-1")
+	})
+}
+
+func TestCompareIndexes(t *testing.T) {
+	required := []Index{ascending("state"), ascending("links.dataset.id", "edition")}
+
+	Convey("When the existing indexes are the required ones, nothing is reported", t, func() {
+		missing, extra := compareIndexes(required, []existingIndex{
+			{Name: "_id_", Key: bson.D{{Key: "_id", Value: int32(1)}}},
+			{Name: "state_1", Key: bson.D{{Key: "state", Value: int32(1)}}},
+			{Name: "links.dataset.id_1_edition_1", Key: bson.D{{Key: "links.dataset.id", Value: 1.0}, {Key: "edition", Value: 1.0}}},
+		})
+		So(missing, ShouldBeEmpty)
+		So(extra, ShouldBeEmpty)
+	})
+
+	Convey("When indexes are missing or not required, they are reported", t, func() {
+		missing, extra := compareIndexes(required, []existingIndex{
+			{Name: "_id_", Key: bson.D{{Key: "_id", Value: int32(1)}}},
+			{Name: "title_1", Key: bson.D{{Key: "title", Value: int32(1)}}},
+			{Name: "state_1", Key: bson.D{{Key: "state", Value: int32(1)}}},
+		})
+		So(missing, ShouldResemble, []string{"links.dataset.id_1_edition_1"})
+		So(extra, ShouldResemble, []string{"title_1"})
+	})
+
+	Convey("When an index has a required name but different keys, it is reported as missing", t, func() {
+		missing, extra := compareIndexes(required[:1], []existingIndex{
+			{Name: "state_1", Key: bson.D{{Key: "state", Value: int32(-1)}}},
+		})
+		So(missing, ShouldResemble, []string{"state_1"})
+		So(extra, ShouldBeEmpty)
+	})
+
+	Convey("When an index has the required keys but different options, it is reported as missing", t, func() {
+		sequence := Index{Keys: bson.D{{Key: "sequence", Value: 1}}, Unique: true, Sparse: true}
+
+		unique := existingIndex{Name: "sequence_1", Key: bson.D{{Key: "sequence", Value: int32(1)}}}
+		unique.Spec.Unique = true
+		missing, _ := compareIndexes([]Index{sequence}, []existingIndex{unique})
+		So(missing, ShouldResemble, []string{"sequence_1"})
+
+		unique.Spec.Sparse = true
+		missing, _ = compareIndexes([]Index{sequence}, []existingIndex{unique})
+		So(missing, ShouldBeEmpty)
+
+		missing, _ = compareIndexes(required[:1], []existingIndex{{Name: "state_1", Key: bson.D{{Key: "state", Value: int32(1)}}, Spec: unique.Spec}})
+		So(missing, ShouldResemble, []string{"state_1"})
+	})
+}
+
+func TestIndexReport(t *testing.T) {
+	Convey("A report describes the missing and unexpected indexes", t, func() {
+		report := &IndexReport{Missing: []string{"versions.state_1"}, Extra: []string{"datasets.title_1", "datasets.state_1"}}
+		So(report.OK(), ShouldBeFalse)
+		So(report.String(), ShouldEqual, "missing mongo indexes: versions.state_1; unexpected mongo indexes: datasets.title_1, datasets.state_1")
+		So((&IndexReport{}).OK(), ShouldBeTrue)
+	})
+
+	Convey("A report with only unexpected indexes is OK", t, func() {
+		So((&IndexReport{Extra: []string{"datasets.title_1"}}).OK(), ShouldBeTrue)
+	})
+}
+
+func TestRequiredIndexes(t *testing.T) {
+	Convey("Indexes are declared for the collections queried by the stores, including the lock collections", t, func() {
+		m := &Mongo{}
+		m.Collections = map[string]string{
+			config.DatasetsCollection:         "datasets",
+			config.EditionsCollection:         "editions",
+			config.InstanceCollection:         "instances",
+			config.DimensionOptionsCollection: "dimension.options",
+			config.VersionsCollection:         "versions",
+			config.OutboxCollection:           "outbox",
+			config.DatasetRevisionsCollection: "dataset_revisions",
+			config.DatasetEventsCollection:    "dataset_events",
+		}
+
+		collections := map[string]bool{}
+		for _, collectionIndexes := range RequiredIndexes() {
+			collections[m.indexCollectionName(collectionIndexes)] = true
+		}
+		So(collections, ShouldResemble, map[string]bool{
+			"datasets": true, "editions": true, "instances": true, "instances_locks": true,
+			"dimension.options": true, "versions": true, "versions_locks": true, "outbox": true, "dataset_revisions": true,
+			"dataset_events": true,
+		})
+	})
+}
+
+func TestCheckedIndexReport(t *testing.T) {
+	Convey("Given the indexes were verified recently and some were missing", t, func() {
+		m := &Mongo{}
+		missing := &IndexReport{Missing: []string{"versions.state_1"}}
+		m.indexCheck.Store(&indexCheck{report: missing, verifiedAt: time.Now()})
+
+		Convey("Then the cached report is returned until the refresh interval passed", func() {
+			report, err := m.checkedIndexReport(context.Background())
+			So(err, ShouldBeNil)
+			So(report, ShouldEqual, missing)
+		})
+	})
+}
+
+func TestEnsureIndexes(t *testing.T) {
+	Convey("Given MongoDB is running", t, func() {
+		ctx := context.Background()
+		mongo, err := getTestMongoDB(ctx, t)
+		So(err, ShouldBeNil)
+
+		Convey("When the indexes are ensured", func() {
+			mongo.EnsureIndexes(ctx)
+
+			Convey("Then all the required indexes exist", func() {
+				report, err := mongo.VerifyIndexes(ctx)
+				So(err, ShouldBeNil)
+				So(report.Missing, ShouldBeEmpty)
+				So(report.Extra, ShouldBeEmpty)
+			})
+
+			Convey("And the report is cached for the health check", func() {
+				check := mongo.indexCheck.Load()
+				So(check, ShouldNotBeNil)
+				So(check.report.OK(), ShouldBeTrue)
+			})
+
+			Convey("And a missing index is verified again by the health check once the refresh interval passed", func() {
+				missing := &IndexReport{Missing: []string{"datasets.state_1"}}
+				mongo.indexCheck.Store(&indexCheck{report: missing, verifiedAt: time.Now().Add(-indexRefreshInterval)})
+
+				report, err := mongo.checkedIndexReport(ctx)
+				So(err, ShouldBeNil)
+				So(report.OK(), ShouldBeTrue)
+				So(mongo.indexCheck.Load().report, ShouldEqual, report)
+			})
+
+			Convey("And an index that is not required is reported, without failing the verification", func() {
+				err := mongo.Connection.RunCommand(ctx, bson.D{
+					{Key: "createIndexes", Value: "datasets"},
+					{Key: "indexes", Value: bson.A{bson.M{"key": bson.M{"title": 1}, "name": "title_1"}}},
+				})
+				So(err, ShouldBeNil)
+
+				report, err := mongo.VerifyIndexes(ctx)
+				So(err, ShouldBeNil)
+				So(report.Extra, ShouldResemble, []string{"datasets.title_1"})
+				So(report.OK(), ShouldBeTrue)
+			})
+		})
+	})
+}
@@ -9,6 +9,7 @@ import (
 
 	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
 	"github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/dp-dataset-api/pagination"
 
 	"github.com/ONSdigital/log.go/v2/log"
 
@@ -17,6 +18,19 @@ import (
 	bsonprim "go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// instanceIndexes are the indexes used to find an instance by id, to list instances by state or dataset
+// from the most recently updated, to find the instances of a collection, and to lock instances
+var instanceIndexes = []CollectionIndexes{
+	indexesOn(config.InstanceCollection,
+		ascending("id"),
+		ascending("links.dataset.id", "edition", "version"),
+		Index{Keys: bson.D{{Key: "last_updated", Value: -1}, {Key: "id", Value: -1}}},
+		Index{Keys: bson.D{{Key: "state", Value: 1}, {Key: "last_updated", Value: -1}, {Key: "id", Value: -1}}},
+		ascending("collection_id"),
+	),
+	{Collection: config.InstanceCollection, Locks: true, Indexes: lockIndexes},
+}
+
 // AcquireInstanceLock tries to lock the provided instanceID.
 // If the instance is already locked, this function will block until it's released,
 // at which point we acquire the lock and return.
@@ -29,8 +43,8 @@ func (m *Mongo) UnlockInstance(ctx context.Context, lockID string) {
 	m.lockClientInstanceCollection.Unlock(ctx, lockID)
 }
 
-// GetInstances from a mongo collection
-func (m *Mongo) GetInstances(ctx context.Context, states, datasets []string, offset, limit int) ([]*models.Instance, int, error) {
+// GetInstances from a mongo collection, sorted by last_updated and id, starting at the offset or right after the cursor if provided
+func (m *Mongo) GetInstances(ctx context.Context, states, datasets []string, offset, limit int, cursor *pagination.Cursor) ([]*models.Instance, int, *pagination.Cursor, error) {
 	selector := bson.M{}
 	if len(states) > 0 {
 		selector["state"] = bson.M{"$in": states}
@@ -39,17 +53,36 @@ func (m *Mongo) GetInstances(ctx context.Context, states, datasets []string, off
 		selector["links.dataset.id"] = bson.M{"$in": datasets}
 	}
 
+	collection := m.Connection.Collection(m.ActualCollectionName(config.InstanceCollection))
+	sort := mongodriver.Sort(bson.D{{Key: "last_updated", Value: -1}, {Key: "id", Value: -1}})
+
 	// get total count and paginated values according to provided offset and limit
 	results := []*models.Instance{}
-	totalCount, err := m.Connection.Collection(m.ActualCollectionName(config.InstanceCollection)).Find(ctx, selector, &results,
-		mongodriver.Sort(bson.M{"last_updated": -1}),
-		mongodriver.Offset(offset),
-		mongodriver.Limit(limit))
+	if cursor == nil {
+		totalCount, err := collection.Find(ctx, selector, &results, sort, mongodriver.Offset(offset), mongodriver.Limit(limit))
+		if err != nil {
+			return results, 0, nil, err
+		}
+
+		return results, totalCount, NextCursor(results, offset+len(results) < totalCount, InstanceCursor), nil
+	}
+
+	if cursor.Time == nil {
+		return nil, 0, nil, errs.ErrInvalidQueryParameter
+	}
+
+	totalCount, err := collection.Count(ctx, selector)
+	if err != nil {
+		return results, 0, nil, err
+	}
+
+	// get the values after the cursor, along with the count of all of them
+	remaining, err := collection.Find(ctx, AfterCursor(selector, "last_updated", *cursor.Time, "id", cursor.ID, -1), &results, sort, mongodriver.Limit(limit))
 	if err != nil {
-		return results, 0, err
+		return results, 0, nil, err
 	}
 
-	return results, totalCount, nil
+	return results, totalCount, NextCursor(results, len(results) < remaining, InstanceCursor), nil
 }
 
 // GetInstance returns a single instance from an ID
@@ -101,14 +134,14 @@ func (m *Mongo) UpdateInstance(ctx context.Context, currentInstance, updatedInst
 	updatedInstance.LastUpdated = time.Now().UTC()
 
 	// calculate the new eTag hash for the instance that would result from applying the update
-	newETag, err = newETagForUpdate(currentInstance, updatedInstance)
+	newETag, err = NewETagForUpdate(currentInstance, updatedInstance)
 	if err != nil {
 		return "", err
 	}
 	updatedInstance.ETag = newETag
 
 	// create update query from updatedInstance and newly generated eTag
-	updates := createInstanceUpdateQuery(ctx, currentInstance.InstanceID, updatedInstance)
+	updates := CreateInstanceUpdateQuery(ctx, currentInstance.InstanceID, updatedInstance)
 	update := bson.M{"$set": updates}
 	updateWithTimestamps, err := mongodriver.WithUpdates(update)
 	if err != nil {
@@ -129,10 +162,12 @@ func (m *Mongo) UpdateInstance(ctx context.Context, currentInstance, updatedInst
 	return newETag, nil
 }
 
+// CreateInstanceUpdateQuery builds the set of field updates to apply to an instance document
+//
 // TODO: Refactor this to reduce the complexity
 //
 //nolint:gocyclo,gocognit // high cyclomactic & cognitive complexity not in scope for maintenance
-func createInstanceUpdateQuery(ctx context.Context, instanceID string, instance *models.Instance) bson.M {
+func CreateInstanceUpdateQuery(ctx context.Context, instanceID string, instance *models.Instance) bson.M {
 	updates := make(bson.M)
 
 	logData := log.Data{"instance_id": instanceID}
@@ -247,6 +282,10 @@ func createInstanceUpdateQuery(ctx context.Context, instanceID string, instance
 		updates["state"] = instance.State
 	}
 
+	if instance.StateHistory != nil {
+		updates["state_history"] = instance.StateHistory
+	}
+
 	if instance.Temporal != nil {
 		updates["temporal"] = instance.Temporal
 	}
@@ -277,7 +316,7 @@ func createInstanceUpdateQuery(ctx context.Context, instanceID string, instance
 // AddEventToInstance to the instance collection
 func (m *Mongo) AddEventToInstance(ctx context.Context, currentInstance *models.Instance, event *models.Event, eTagSelector string) (newETag string, err error) {
 	// calculate the new eTag hash for the instance that would result from adding the event
-	newETag, err = newETagForAddEvent(currentInstance, event)
+	newETag, err = NewETagForAddEvent(currentInstance, event)
 	if err != nil {
 		return "", err
 	}
@@ -306,7 +345,7 @@ func (m *Mongo) AddEventToInstance(ctx context.Context, currentInstance *models.
 // UpdateObservationInserted by incrementing the stored value
 func (m *Mongo) UpdateObservationInserted(ctx context.Context, currentInstance *models.Instance, observationInserted int64, eTagSelector string) (newETag string, err error) {
 	// calculate the new eTag hash for the instance that would result from inceasing the observations
-	newETag, err = newETagForObservationsInserted(currentInstance, observationInserted)
+	newETag, err = NewETagForObservationsInserted(currentInstance, observationInserted)
 	if err != nil {
 		return "", err
 	}
@@ -333,7 +372,7 @@ func (m *Mongo) UpdateObservationInserted(ctx context.Context, currentInstance *
 // UpdateImportObservationsTaskState to the given state.
 func (m *Mongo) UpdateImportObservationsTaskState(ctx context.Context, currentInstance *models.Instance, state, eTagSelector string) (newETag string, err error) {
 	// calculate the new eTag hash for the instance that would result from inceasing the observations
-	newETag, err = newETagForStateUpdate(currentInstance, state)
+	newETag, err = NewETagForStateUpdate(currentInstance, state)
 	if err != nil {
 		return "", err
 	}
@@ -361,7 +400,7 @@ func (m *Mongo) UpdateImportObservationsTaskState(ctx context.Context, currentIn
 // UpdateBuildHierarchyTaskState updates the state of a build hierarchy task.
 func (m *Mongo) UpdateBuildHierarchyTaskState(ctx context.Context, currentInstance *models.Instance, dimension, state, eTagSelector string) (newETag string, err error) {
 	// calculate the new eTag hash for the instance that would result from inceasing the observations
-	newETag, err = newETagForHierarchyTaskStateUpdate(currentInstance, dimension, state)
+	newETag, err = NewETagForHierarchyTaskStateUpdate(currentInstance, dimension, state)
 	if err != nil {
 		return "", err
 	}
@@ -387,7 +426,7 @@ func (m *Mongo) UpdateBuildHierarchyTaskState(ctx context.Context, currentInstan
 // UpdateBuildSearchTaskState updates the state of a build search task.
 func (m *Mongo) UpdateBuildSearchTaskState(ctx context.Context, currentInstance *models.Instance, dimension, state, eTagSelector string) (newETag string, err error) {
 	// calculate the new eTag hash for the instance that would result from inceasing the observations
-	newETag, err = newETagForBuildSearchTaskStateUpdate(currentInstance, dimension, state)
+	newETag, err = NewETagForBuildSearchTaskStateUpdate(currentInstance, dimension, state)
 	if err != nil {
 		return "", err
 	}
@@ -413,7 +452,7 @@ func (m *Mongo) UpdateBuildSearchTaskState(ctx context.Context, currentInstance
 // UpdateETagForOptions updates the eTag value for an instance according to the provided dimension options upserts and updates
 func (m *Mongo) UpdateETagForOptions(ctx context.Context, currentInstance *models.Instance, upserts []*models.CachedDimensionOption, updates []*models.DimensionOption, eTagSelector string) (newETag string, err error) {
 	// calculate the new eTag hash by calculating the hash of the current instance plus the provided option upserts and updates
-	newETag, err = newETagForOptions(currentInstance, upserts, updates)
+	newETag, err = NewETagForOptions(currentInstance, upserts, updates)
 	if err != nil {
 		return "", err
 	}
@@ -0,0 +1,93 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ONSdigital/dp-dataset-api/config"
+	"github.com/ONSdigital/dp-dataset-api/migrations"
+	mongodriver "github.com/ONSdigital/dp-mongodb/v3/mongodb"
+	"github.com/ONSdigital/log.go/v2/log"
+	uuid "github.com/satori/go.uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	mongodb "go.mongodb.org/mongo-driver/mongo"
+)
+
+// migrationsLockID is the ID of the document in the migrations collection that records which instance of the service
+// is applying the schema migrations. It is not a migration record, as it has no applied_at.
+const migrationsLockID = "lock"
+
+// MigrationsLease is how long an instance of the service holds the schema migrations for without renewing its claim.
+// The claim is renewed every third of it while the migrations run, so it only expires if the instance stops, however
+// long the migrations take.
+var MigrationsLease = time.Minute
+
+// LockMigrations claims the schema migrations for this instance of the service, without waiting, and keeps renewing
+// the claim until UnlockMigrations is called. A migrations.ErrLocked error is returned if another instance holds it.
+func (m *Mongo) LockMigrations(ctx context.Context) (string, error) {
+	owner, err := uuid.NewV4()
+	if err != nil {
+		return "", err
+	}
+	lockID := owner.String()
+
+	// the claim is only inserted, or taken over, if there is no claim that has not expired yet: the insert of a
+	// second claim fails on the unique _id
+	selector := bson.M{"_id": migrationsLockID, "expires_at": bson.M{"$lt": time.Now().UTC()}}
+	if _, err := m.migrationsCollection().Upsert(ctx, selector, migrationsLockUpdate(lockID)); err != nil {
+		if mongodb.IsDuplicateKeyError(err) {
+			return "", migrations.ErrLocked
+		}
+		return "", err
+	}
+
+	renewCtx, stopRenewing := context.WithCancel(context.WithoutCancel(ctx))
+	m.migrationsLocks.Store(lockID, stopRenewing)
+	go m.renewMigrationsLock(renewCtx, lockID)
+
+	return lockID, nil
+}
+
+// UnlockMigrations stops renewing the claim on the schema migrations and releases it
+func (m *Mongo) UnlockMigrations(ctx context.Context, lockID string) {
+	if stopRenewing, ok := m.migrationsLocks.LoadAndDelete(lockID); ok {
+		stopRenewing.(context.CancelFunc)()
+	}
+
+	if _, err := m.migrationsCollection().DeleteOne(ctx, bson.M{"_id": migrationsLockID, "owner": lockID}); err != nil {
+		log.Error(ctx, "failed to release the lock on the schema migrations", err, log.Data{"lock_id": lockID})
+	}
+}
+
+// renewMigrationsLock extends the claim on the schema migrations until the context is cancelled
+func (m *Mongo) renewMigrationsLock(ctx context.Context, lockID string) {
+	ticker := time.NewTicker(MigrationsLease / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		_, err := m.migrationsCollection().Must().UpdateOne(ctx, bson.M{"_id": migrationsLockID, "owner": lockID}, migrationsLockUpdate(lockID))
+		switch {
+		case errors.Is(err, mongodriver.ErrNoDocumentFound):
+			log.Error(ctx, "lost the lock on the schema migrations, which has been claimed by another instance", err, log.Data{"lock_id": lockID})
+			return
+		case err != nil && ctx.Err() == nil:
+			log.Error(ctx, "failed to renew the lock on the schema migrations", err, log.Data{"lock_id": lockID})
+		}
+	}
+}
+
+// migrationsLockUpdate sets the owner of the claim on the schema migrations, and when it expires unless renewed
+func migrationsLockUpdate(lockID string) bson.M {
+	return bson.M{"$set": bson.M{"owner": lockID, "expires_at": time.Now().UTC().Add(MigrationsLease)}}
+}
+
+func (m *Mongo) migrationsCollection() *mongodriver.Collection {
+	return m.Connection.Collection(m.ActualCollectionName(config.MigrationsCollection))
+}
@@ -0,0 +1,67 @@
+package mongo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-dataset-api/config"
+	"github.com/ONSdigital/dp-dataset-api/migrations"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestLockMigrations(t *testing.T) {
+	Convey("Given the schema migrations have been claimed by an instance of the service", t, func() {
+		ctx := context.Background()
+		mongo, err := getTestMongoDB(ctx, t)
+		So(err, ShouldBeNil)
+
+		lease := MigrationsLease
+		MigrationsLease = 300 * time.Millisecond
+		Reset(func() { MigrationsLease = lease })
+
+		lockID, err := mongo.LockMigrations(ctx)
+		So(err, ShouldBeNil)
+		Reset(func() { mongo.UnlockMigrations(ctx, lockID) })
+
+		Convey("Then another instance cannot claim them, even after the lease would have expired without being renewed", func() {
+			_, err := mongo.LockMigrations(ctx)
+			So(err, ShouldEqual, migrations.ErrLocked)
+
+			time.Sleep(3 * MigrationsLease)
+			_, err = mongo.LockMigrations(ctx)
+			So(err, ShouldEqual, migrations.ErrLocked)
+		})
+
+		Convey("When they are released", func() {
+			mongo.UnlockMigrations(ctx, lockID)
+
+			Convey("Then another instance can claim them", func() {
+				otherLockID, err := mongo.LockMigrations(ctx)
+				So(err, ShouldBeNil)
+				So(otherLockID, ShouldNotEqual, lockID)
+				mongo.UnlockMigrations(ctx, otherLockID)
+			})
+		})
+	})
+
+	Convey("Given a claim on the schema migrations that has expired", t, func() {
+		ctx := context.Background()
+		mongo, err := getTestMongoDB(ctx, t)
+		So(err, ShouldBeNil)
+
+		_, err = mongo.Connection.Collection(mongo.ActualCollectionName(config.MigrationsCollection)).InsertOne(ctx, bson.M{
+			"_id":        migrationsLockID,
+			"owner":      "stopped-instance",
+			"expires_at": time.Now().UTC().Add(-time.Minute),
+		})
+		So(err, ShouldBeNil)
+
+		Convey("Then another instance can take the claim over", func() {
+			lockID, err := mongo.LockMigrations(ctx)
+			So(err, ShouldBeNil)
+			mongo.UnlockMigrations(ctx, lockID)
+		})
+	})
+}
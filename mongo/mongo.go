@@ -2,8 +2,12 @@ package mongo
 
 import (
 	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
 
 	"github.com/ONSdigital/dp-dataset-api/config"
+	"github.com/ONSdigital/dp-dataset-api/migrations"
 	"github.com/ONSdigital/dp-healthcheck/healthcheck"
 
 	mongolock "github.com/ONSdigital/dp-mongodb/v3/dplock"
@@ -18,10 +22,14 @@ type Mongo struct {
 	healthClient                 *mongohealth.CheckMongoClient
 	lockClientInstanceCollection *mongolock.Lock
 	lockClientVersionsCollection *mongolock.Lock
+	transactionsUnavailable      atomic.Bool
+	indexCheck                   atomic.Pointer[indexCheck]
+	migrationsLocks              sync.Map
 }
 
 // Init returns an initialised Mongo object encapsulating a connection to the mongo server/cluster with the given configuration,
-// a health client to check the health of the mongo server/cluster, and a lock client
+// a health client to check the health of the mongo server/cluster, and a lock client. The indexes required by the stores
+// are created if they do not exist yet.
 func (m *Mongo) Init(ctx context.Context) (err error) {
 	m.Connection, err = mongodriver.Open(&m.MongoDriverConfig)
 	if err != nil {
@@ -43,6 +51,8 @@ func (m *Mongo) Init(ctx context.Context) (err error) {
 	m.lockClientInstanceCollection = mongolock.New(ctx, m.Connection, m.ActualCollectionName(config.InstanceCollection))
 	m.lockClientVersionsCollection = mongolock.New(ctx, m.Connection, m.ActualCollectionName(config.VersionsCollection))
 
+	m.EnsureIndexes(ctx)
+
 	return nil
 }
 
@@ -51,7 +61,29 @@ func (m *Mongo) Close(ctx context.Context) error {
 	return m.Connection.Close(ctx)
 }
 
-// Checker is called by the healthcheck library to check the health state of this mongoDB instance
+// Checker is called by the healthcheck library to check the health state of this mongoDB instance.
+// A healthy instance is reported as WARNING if the indexes required by the stores are missing or differ from the existing ones,
+// and the indexes that are not required are listed in the message of an OK state.
 func (m *Mongo) Checker(ctx context.Context, state *healthcheck.CheckState) error {
-	return m.healthClient.Checker(ctx, state)
+	if err := m.healthClient.Checker(ctx, state); err != nil || state.Status() != healthcheck.StatusOK {
+		return err
+	}
+
+	report, err := m.checkedIndexReport(ctx)
+	if err != nil {
+		return state.Update(healthcheck.StatusWarning, fmt.Sprintf("failed to verify mongo indexes: %s", err.Error()), 0)
+	}
+	if !report.OK() {
+		return state.Update(healthcheck.StatusWarning, report.String(), 0)
+	}
+	if len(report.Extra) > 0 {
+		return state.Update(healthcheck.StatusOK, report.String(), 0)
+	}
+
+	return nil
+}
+
+// MigrationCollection returns the collection with the provided config key, for the schema migrations to be applied to
+func (m *Mongo) MigrationCollection(name string) migrations.Collection {
+	return m.Connection.Collection(m.ActualCollectionName(name))
 }
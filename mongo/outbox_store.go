@@ -0,0 +1,156 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	"github.com/ONSdigital/dp-dataset-api/config"
+	"github.com/ONSdigital/dp-dataset-api/models"
+	mongodriver "github.com/ONSdigital/dp-mongodb/v3/mongodb"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// outboxIndexes are the indexes used to find the messages that are due to be delivered or re-queued, whose lease expired
+// or that were sent before the retention period, and to count messages by state
+var outboxIndexes = []CollectionIndexes{
+	indexesOn(config.OutboxCollection,
+		ascending("state", "next_attempt_at", "created_at"),
+		ascending("state", "lease_expires_at", "created_at"),
+		ascending("state", "sent_at"),
+	),
+}
+
+// AddOutboxMessage inserts a new message into the outbox collection.
+// When called within RunTransaction, the message is only stored if the transaction succeeds.
+func (m *Mongo) AddOutboxMessage(ctx context.Context, message *models.OutboxMessage) error {
+	collection := m.ActualCollectionName(config.OutboxCollection)
+
+	result, err := m.Connection.Collection(collection).InsertOne(ctx, message)
+	if err != nil {
+		return err
+	}
+
+	return m.recordInsert(ctx, collection, result.InsertedId)
+}
+
+// ClaimOutboxMessage atomically claims the oldest outbox message that is due to be sent, for the provided owner until the lease
+// expires. Messages that are pending, or whose previous claim expired, can be claimed, except the ones of the topics to skip.
+// If there are no messages to claim, an ErrOutboxMessageNotFound error is returned.
+func (m *Mongo) ClaimOutboxMessage(ctx context.Context, owner string, lease time.Duration, skipTopics []string) (*models.OutboxMessage, error) {
+	now := time.Now().UTC()
+	leaseExpiresAt := now.Add(lease)
+
+	selector := ClaimableOutboxMessageSelector(now, skipTopics)
+	update := bson.M{
+		"$set": bson.M{
+			"state":            models.OutboxMessageDelivering,
+			"claimed_by":       owner,
+			"lease_expires_at": leaseExpiresAt,
+		},
+	}
+
+	var message models.OutboxMessage
+	if err := m.Connection.Collection(m.ActualCollectionName(config.OutboxCollection)).FindOneAndUpdate(ctx, selector, update, &message,
+		mongodriver.Sort(bson.M{"created_at": 1}), mongodriver.ReturnDocument(options.After)); err != nil {
+		if errors.Is(err, mongodriver.ErrNoDocumentFound) {
+			return nil, errs.ErrOutboxMessageNotFound
+		}
+		return nil, err
+	}
+
+	return &message, nil
+}
+
+// ClaimableOutboxMessageSelector matches the outbox messages that are pending and due to be sent,
+// or whose delivery lease expired, excluding the messages of the provided topics
+func ClaimableOutboxMessageSelector(now time.Time, skipTopics []string) bson.M {
+	selector := bson.M{
+		"$or": bson.A{
+			bson.M{"state": models.OutboxMessagePending, "next_attempt_at": bson.M{"$lte": now}},
+			bson.M{"state": models.OutboxMessageDelivering, "lease_expires_at": bson.M{"$lte": now}},
+		},
+	}
+	if len(skipTopics) > 0 {
+		selector["topic"] = bson.M{"$nin": skipTopics}
+	}
+	return selector
+}
+
+// UpdateOutboxMessage stores the delivery state of an outbox message and releases its claim. If the message is no longer claimed
+// by the owner that delivered it (i.e. its lease expired and it was claimed again), an ErrOutboxMessageNotFound error is returned.
+func (m *Mongo) UpdateOutboxMessage(ctx context.Context, message *models.OutboxMessage) error {
+	selector := bson.M{"_id": message.ID, "claimed_by": message.ClaimedBy}
+	update := bson.M{
+		"$set": bson.M{
+			"state":           message.State,
+			"attempts":        message.Attempts,
+			"last_error":      message.LastError,
+			"next_attempt_at": message.NextAttemptAt,
+			"sent_at":         message.SentAt,
+		},
+		"$unset": bson.M{
+			"claimed_by":       "",
+			"lease_expires_at": "",
+		},
+	}
+
+	result, err := m.Connection.Collection(m.ActualCollectionName(config.OutboxCollection)).UpdateOne(ctx, selector, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return errs.ErrOutboxMessageNotFound
+	}
+
+	return nil
+}
+
+// CountOutboxMessages returns the number of outbox messages in the provided state
+func (m *Mongo) CountOutboxMessages(ctx context.Context, state string) (int, error) {
+	return m.Connection.Collection(m.ActualCollectionName(config.OutboxCollection)).Count(ctx, bson.M{"state": state})
+}
+
+// RequeueFailedOutboxMessages makes the failed outbox messages that are due to be retried at the provided time pending again,
+// with a new set of delivery attempts, returning the number of messages re-queued
+func (m *Mongo) RequeueFailedOutboxMessages(ctx context.Context, now time.Time) (int, error) {
+	result, err := m.Connection.Collection(m.ActualCollectionName(config.OutboxCollection)).UpdateMany(ctx,
+		RequeueableOutboxMessageSelector(now), RequeueOutboxMessageUpdate(now))
+	if err != nil {
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
+
+// RequeueableOutboxMessageSelector matches the failed outbox messages that are due to be retried at the provided time
+func RequeueableOutboxMessageSelector(now time.Time) bson.M {
+	return bson.M{"state": models.OutboxMessageFailed, "next_attempt_at": bson.M{"$lte": now}}
+}
+
+// RequeueOutboxMessageUpdate makes an outbox message pending and due to be sent at the provided time, resetting its attempts
+func RequeueOutboxMessageUpdate(now time.Time) bson.M {
+	return bson.M{
+		"$set": bson.M{
+			"state":           models.OutboxMessagePending,
+			"attempts":        0,
+			"next_attempt_at": now,
+		},
+	}
+}
+
+// PurgeSentOutboxMessages permanently removes the outbox messages that were sent before the provided time,
+// returning the number of messages removed
+func (m *Mongo) PurgeSentOutboxMessages(ctx context.Context, before time.Time) (int, error) {
+	result, err := m.Connection.Collection(m.ActualCollectionName(config.OutboxCollection)).DeleteMany(ctx, SentOutboxMessageSelector(before))
+	if err != nil {
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
+
+// SentOutboxMessageSelector matches the outbox messages that were sent before the provided time
+func SentOutboxMessageSelector(before time.Time) bson.M {
+	return bson.M{"state": models.OutboxMessageSent, "sent_at": bson.M{"$lt": before}}
+}
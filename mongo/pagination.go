@@ -0,0 +1,56 @@
+package mongo
+
+import (
+	"github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/dp-dataset-api/pagination"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// AfterCursor restricts the provided filter to the documents that come after the cursor position, for a list sorted by keyField
+// (unless empty) and then by the unique idField, both in the direction given by sortDir (1 for ascending, -1 for descending)
+func AfterCursor(filter bson.M, keyField string, key interface{}, idField, id string, sortDir int) bson.M {
+	op := "$gt"
+	if sortDir < 0 {
+		op = "$lt"
+	}
+
+	after := bson.M{idField: bson.M{op: id}}
+	if keyField != "" {
+		after = bson.M{
+			"$or": bson.A{
+				bson.M{keyField: bson.M{op: key}},
+				bson.M{keyField: key, idField: bson.M{op: id}},
+			},
+		}
+	}
+
+	if len(filter) == 0 {
+		return after
+	}
+
+	return bson.M{"$and": bson.A{filter, after}}
+}
+
+// NextCursor returns the cursor of the last of the provided values, or nil if there are no more values after them
+func NextCursor[T any](values []T, hasMore bool, cursorOf func(T) *pagination.Cursor) *pagination.Cursor {
+	if !hasMore || len(values) == 0 {
+		return nil
+	}
+	return cursorOf(values[len(values)-1])
+}
+
+// DatasetCursor returns the cursor of a dataset in a list sorted by id
+func DatasetCursor(dataset *models.DatasetUpdate) *pagination.Cursor {
+	return &pagination.Cursor{ID: dataset.ID}
+}
+
+// InstanceCursor returns the cursor of an instance in a list sorted by last_updated and id
+func InstanceCursor(instance *models.Instance) *pagination.Cursor {
+	lastUpdated := instance.LastUpdated
+	return &pagination.Cursor{ID: instance.InstanceID, Time: &lastUpdated}
+}
+
+// DimensionOptionCursor returns the cursor of a dimension option in a list sorted by order (if any) and option
+func DimensionOptionCursor(option *models.PublicDimensionOption) *pagination.Cursor {
+	return &pagination.Cursor{ID: option.Option, Order: option.Order}
+}
@@ -0,0 +1,118 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	"github.com/ONSdigital/dp-dataset-api/config"
+	"github.com/ONSdigital/dp-dataset-api/models"
+	mongodriver "github.com/ONSdigital/dp-mongodb/v3/mongodb"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// revisionIndexes are the indexes used to list the revisions of a dataset, which also ensure that no two revisions of
+// a dataset are stored with the same number
+var revisionIndexes = []CollectionIndexes{
+	indexesOn(config.DatasetRevisionsCollection,
+		Index{Keys: bson.D{{Key: "dataset_id", Value: 1}, {Key: "revision", Value: 1}}, Unique: true},
+	),
+}
+
+// RevisionCounter is the metadata of a dataset along with the number of revisions stored for it, which is kept in the
+// dataset document and incremented atomically by IncrementRevisionCount, so that concurrent updates get their own number
+type RevisionCounter struct {
+	Next          *models.Dataset `bson:"next"`
+	RevisionCount int             `bson:"revision_count"`
+}
+
+// IncrementRevisionCount is the update that numbers the next revision of a dataset
+func IncrementRevisionCount() bson.M {
+	return bson.M{"$inc": bson.M{"revision_count": 1}}
+}
+
+// addDatasetRevision stores the current metadata of a dataset as its next revision.
+// It must be called within the transaction that updates the dataset.
+func (m *Mongo) addDatasetRevision(ctx context.Context, datasetID string) error {
+	datasets := m.ActualCollectionName(config.DatasetsCollection)
+	entry, err := m.recordRollback(ctx, datasets, bson.M{"_id": datasetID})
+	if err != nil {
+		return err
+	}
+
+	var dataset RevisionCounter
+	if err := m.Connection.Collection(datasets).FindOneAndUpdate(ctx, bson.M{"_id": datasetID}, IncrementRevisionCount(), &dataset,
+		mongodriver.ReturnDocument(options.After)); err != nil {
+		if errors.Is(err, mongodriver.ErrNoDocumentFound) {
+			return errs.ErrDatasetNotFound
+		}
+		return err
+	}
+
+	if err := m.recordWrite(ctx, entry, nil); err != nil {
+		return err
+	}
+
+	revision, err := models.NewDatasetRevision(datasetID, dataset.RevisionCount, dataset.Next)
+	if err != nil {
+		return err
+	}
+
+	collection := m.ActualCollectionName(config.DatasetRevisionsCollection)
+	result, err := m.Connection.Collection(collection).InsertOne(ctx, revision)
+	if err != nil {
+		return err
+	}
+
+	return m.recordInsert(ctx, collection, result.InsertedId)
+}
+
+// GetDatasetRevisions returns the revisions of a dataset, most recent first, along with the total number of revisions
+func (m *Mongo) GetDatasetRevisions(ctx context.Context, datasetID string, offset, limit int) ([]*models.DatasetRevision, int, error) {
+	revisions := []*models.DatasetRevision{}
+	totalCount, err := m.Connection.Collection(m.ActualCollectionName(config.DatasetRevisionsCollection)).Find(ctx, bson.M{"dataset_id": datasetID}, &revisions,
+		mongodriver.Sort(bson.M{"revision": -1}), mongodriver.Offset(offset), mongodriver.Limit(limit))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return revisions, totalCount, nil
+}
+
+// GetDatasetRevision returns a revision of a dataset by its number
+func (m *Mongo) GetDatasetRevision(ctx context.Context, datasetID string, revision int) (*models.DatasetRevision, error) {
+	var result models.DatasetRevision
+	if err := m.Connection.Collection(m.ActualCollectionName(config.DatasetRevisionsCollection)).FindOne(ctx, bson.M{"dataset_id": datasetID, "revision": revision}, &result); err != nil {
+		if errors.Is(err, mongodriver.ErrNoDocumentFound) {
+			return nil, errs.ErrRevisionNotFound
+		}
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// purgeDatasetRevisions permanently removes the revisions of the datasets that were deleted before the provided time
+func (m *Mongo) purgeDatasetRevisions(ctx context.Context, before time.Time) (int, error) {
+	var deleted []models.DatasetUpdate
+	if _, err := m.Connection.Collection(m.ActualCollectionName(config.DatasetsCollection)).Find(ctx, DeletedBefore(before), &deleted,
+		mongodriver.Projection(bson.M{"_id": 1})); err != nil {
+		return 0, err
+	}
+	if len(deleted) == 0 {
+		return 0, nil
+	}
+
+	ids := make([]string, 0, len(deleted))
+	for _, dataset := range deleted {
+		ids = append(ids, dataset.ID)
+	}
+
+	result, err := m.Connection.Collection(m.ActualCollectionName(config.DatasetRevisionsCollection)).DeleteMany(ctx, bson.M{"dataset_id": bson.M{"$in": ids}})
+	if err != nil {
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
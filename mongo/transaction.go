@@ -0,0 +1,220 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	mongodriver "github.com/ONSdigital/dp-mongodb/v3/mongodb"
+	"github.com/ONSdigital/log.go/v2/log"
+	"go.mongodb.org/mongo-driver/bson"
+	mongodb "go.mongodb.org/mongo-driver/mongo"
+)
+
+// illegalOperationCode is the mongoDB error code returned when transactions are used against a standalone server
+const illegalOperationCode = 20
+
+type rollbackKey struct{}
+
+// rollbackEntry holds the state of a document before and after it was written within RunTransaction, so that the
+// write can be compensated if the transaction fails. A nil document means that it did not exist before the write.
+type rollbackEntry struct {
+	collection string
+	id         interface{}
+	document   bson.M
+	written    bson.M
+}
+
+// rollbackLog is the list of compensating actions for the writes performed within RunTransaction,
+// used when mongoDB transactions are not available
+type rollbackLog struct {
+	mu      sync.Mutex
+	entries []rollbackEntry
+}
+
+// RunTransaction executes fn within a mongoDB multi-document transaction, so that either all of its writes are committed or none are.
+// All the store calls made by fn must be passed the context it receives. If the server does not support transactions (i.e. it is not
+// part of a replica set), fn is executed without a transaction and the documents it modified are restored to their previous state if it fails.
+// A call made with the context of another RunTransaction call joins that transaction, so its writes are only kept if the outer call succeeds.
+// A transaction that conflicts with a concurrent one writing the same documents is retried, so fn must only write through the store.
+func (m *Mongo) RunTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if inTransaction(ctx) {
+		return fn(ctx)
+	}
+
+	if !m.transactionsUnavailable.Load() {
+		_, err := m.Connection.RunTransaction(ctx, true, func(transactionCtx context.Context) (interface{}, error) {
+			return nil, fn(transactionCtx)
+		})
+		if !isTransactionsUnavailable(err) {
+			return err
+		}
+
+		log.Warn(ctx, "mongoDB transactions are not available, falling back to compensating rollbacks", log.Data{"err": err.Error()})
+		m.transactionsUnavailable.Store(true)
+	}
+
+	return m.runWithRollback(ctx, fn)
+}
+
+// runWithRollback executes fn, restoring any documents it modified if it returns an error
+func (m *Mongo) runWithRollback(ctx context.Context, fn func(ctx context.Context) error) error {
+	rollback := &rollbackLog{}
+	err := fn(context.WithValue(ctx, rollbackKey{}, rollback))
+	if err == nil {
+		return nil
+	}
+
+	if rollbackErr := m.rollback(ctx, rollback); rollbackErr != nil {
+		log.Error(ctx, "failed to roll back changes after an error", rollbackErr)
+		return fmt.Errorf("%w (rollback failed: %s)", err, rollbackErr.Error())
+	}
+
+	return err
+}
+
+// inTransaction returns true if the context belongs to a RunTransaction call, with or without mongoDB transactions
+func inTransaction(ctx context.Context) bool {
+	return mongodb.SessionFromContext(ctx) != nil || ctx.Value(rollbackKey{}) != nil
+}
+
+// recordRollback returns the current state of the document matched by the selector, if the context belongs to a
+// RunTransaction call without mongoDB transactions, and nil otherwise. It must be called before the document is
+// modified, and the entry passed to recordWrite once it is.
+func (m *Mongo) recordRollback(ctx context.Context, collection string, selector bson.M) (*rollbackEntry, error) {
+	if _, ok := ctx.Value(rollbackKey{}).(*rollbackLog); !ok {
+		return nil, nil
+	}
+
+	entry := &rollbackEntry{collection: collection}
+
+	var document bson.M
+	err := m.Connection.Collection(collection).FindOne(ctx, selector, &document)
+	switch {
+	case err == nil:
+		entry.id = document["_id"]
+		entry.document = document
+	case !errors.Is(err, mongodriver.ErrNoDocumentFound):
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// recordWrite records the document written by this call, so that it is compensated if the call fails. The document is
+// the one recorded by recordRollback or, if there was none, the one inserted with the provided ID. Nothing is recorded
+// if the entry is nil, as the context does not belong to a RunTransaction call without mongoDB transactions, or if no
+// document was written. It must be called once the document is written.
+func (m *Mongo) recordWrite(ctx context.Context, entry *rollbackEntry, insertedID interface{}) error {
+	if entry == nil {
+		return nil
+	}
+	if entry.document == nil {
+		entry.id = insertedID
+	}
+	if entry.id == nil {
+		return nil
+	}
+
+	var written bson.M
+	if err := m.Connection.Collection(entry.collection).FindOne(ctx, bson.M{"_id": entry.id}, &written); err != nil {
+		if errors.Is(err, mongodriver.ErrNoDocumentFound) {
+			return nil
+		}
+		return err
+	}
+	entry.written = written
+
+	rollback := ctx.Value(rollbackKey{}).(*rollbackLog)
+	rollback.mu.Lock()
+	defer rollback.mu.Unlock()
+	rollback.entries = append(rollback.entries, *entry)
+
+	return nil
+}
+
+// recordInsert records the document inserted with the provided ID, if the context belongs to a RunTransaction call without
+// mongoDB transactions, so that only this document is deleted if the call fails. It must be called once the document is inserted.
+func (m *Mongo) recordInsert(ctx context.Context, collection string, id interface{}) error {
+	if _, ok := ctx.Value(rollbackKey{}).(*rollbackLog); !ok {
+		return nil
+	}
+
+	return m.recordWrite(ctx, &rollbackEntry{collection: collection}, id)
+}
+
+// rollback restores the recorded documents in reverse order: documents that existed are reverted to their previous
+// state and documents that did not exist are deleted. A document that has been changed by another writer since it was
+// written by this call is left as it is, so that the concurrent change is not lost.
+func (m *Mongo) rollback(ctx context.Context, rollback *rollbackLog) error {
+	rollback.mu.Lock()
+	defer rollback.mu.Unlock()
+
+	for i := len(rollback.entries) - 1; i >= 0; i-- {
+		entry := rollback.entries[i]
+		collection := m.Connection.Collection(entry.collection)
+		logData := log.Data{"collection": entry.collection, "id": entry.id}
+
+		var current bson.M
+		if err := collection.FindOne(ctx, bson.M{"_id": entry.id}, &current); err != nil {
+			if errors.Is(err, mongodriver.ErrNoDocumentFound) {
+				log.Warn(ctx, "document written within the transaction has since been deleted, so it is not rolled back", logData)
+				continue
+			}
+			return err
+		}
+
+		if !reflect.DeepEqual(current, entry.written) {
+			log.Warn(ctx, "document written within the transaction has since been changed, so it is not rolled back", logData)
+			continue
+		}
+
+		if entry.document == nil {
+			if _, err := collection.DeleteOne(ctx, bson.M{"_id": entry.id}); err != nil {
+				return err
+			}
+			continue
+		}
+
+		set := bson.M{}
+		for k, v := range entry.document {
+			if k != "_id" {
+				set[k] = v
+			}
+		}
+		update := bson.M{"$set": set}
+
+		unset := bson.M{}
+		for k := range current {
+			if _, ok := entry.document[k]; !ok {
+				unset[k] = ""
+			}
+		}
+		if len(unset) > 0 {
+			update["$unset"] = unset
+		}
+
+		if _, err := collection.UpdateById(ctx, entry.id, update); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// isTransactionsUnavailable returns true if the error was caused by running a transaction against a server that does not support them
+func isTransactionsUnavailable(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var cmdErr mongodb.CommandError
+	if errors.As(err, &cmdErr) && cmdErr.Code == illegalOperationCode {
+		return true
+	}
+
+	return strings.Contains(err.Error(), "Transaction numbers are only allowed on a replica set member or mongos")
+}
@@ -0,0 +1,132 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ONSdigital/dp-dataset-api/config"
+	"github.com/ONSdigital/dp-dataset-api/models"
+	mongodriver "github.com/ONSdigital/dp-mongodb/v3/mongodb"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.mongodb.org/mongo-driver/bson"
+	mongodb "go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestIsTransactionsUnavailable(t *testing.T) {
+	t.Parallel()
+	Convey("When the error is an IllegalOperation command error then transactions are unavailable", t, func() {
+		err := mongodb.CommandError{Code: illegalOperationCode, Message: "Transaction numbers are only allowed on a replica set member or mongos"}
+		So(isTransactionsUnavailable(err), ShouldBeTrue)
+	})
+
+	Convey("When the error message reports that transactions are only allowed on replica sets then transactions are unavailable", t, func() {
+		err := errors.New("(IllegalOperation) Transaction numbers are only allowed on a replica set member or mongos")
+		So(isTransactionsUnavailable(err), ShouldBeTrue)
+	})
+
+	Convey("When the error is not related to transactions then it is not reported as such", t, func() {
+		So(isTransactionsUnavailable(nil), ShouldBeFalse)
+		So(isTransactionsUnavailable(mongodriver.ErrNoDocumentFound), ShouldBeFalse)
+	})
+}
+
+func TestRunTransactionNested(t *testing.T) {
+	t.Parallel()
+	Convey("Given a context that belongs to a transaction", t, func() {
+		m := &Mongo{}
+		rollback := &rollbackLog{}
+		ctx := context.WithValue(context.Background(), rollbackKey{}, rollback)
+
+		Convey("When a nested transaction is run", func() {
+			var nestedCtx context.Context
+			err := m.RunTransaction(ctx, func(transactionCtx context.Context) error {
+				nestedCtx = transactionCtx
+				return nil
+			})
+
+			Convey("Then it joins the transaction of the caller instead of starting a new one", func() {
+				So(err, ShouldBeNil)
+				So(nestedCtx, ShouldEqual, ctx)
+			})
+		})
+	})
+}
+
+func TestRunWithRollback(t *testing.T) {
+	Convey("Given a dataset and a transaction that updates it and adds an outbox message without mongoDB transactions", t, func() {
+		ctx := context.Background()
+		mongo, err := getTestMongoDB(ctx, t)
+		So(err, ShouldBeNil)
+
+		So(mongo.UpsertDataset(ctx, "rollback-dataset", &models.DatasetUpdate{
+			ID:   "rollback-dataset",
+			Next: &models.Dataset{ID: "rollback-dataset", Title: "Original title", State: models.CreatedState, Type: models.Filterable.String()},
+		}), ShouldBeNil)
+
+		failure := errors.New("failure")
+		transaction := func(concurrently func(ctx context.Context)) error {
+			return mongo.runWithRollback(ctx, func(transactionCtx context.Context) error {
+				if err := mongo.UpdateDataset(transactionCtx, "rollback-dataset", &models.Dataset{Title: "Updated title"}, models.CreatedState); err != nil {
+					return err
+				}
+				if err := mongo.AddOutboxMessage(transactionCtx, &models.OutboxMessage{ID: "rollback-message"}); err != nil {
+					return err
+				}
+				concurrently(ctx)
+				return failure
+			})
+		}
+
+		Convey("When the transaction fails", func() {
+			err := transaction(func(context.Context) {})
+
+			Convey("Then the dataset is restored and the outbox message is deleted", func() {
+				So(err, ShouldEqual, failure)
+
+				dataset, err := mongo.GetDataset(ctx, "rollback-dataset")
+				So(err, ShouldBeNil)
+				So(dataset.Next.Title, ShouldEqual, "Original title")
+
+				count, err := mongo.Connection.Collection(mongo.ActualCollectionName(config.OutboxCollection)).Count(ctx, bson.M{"_id": "rollback-message"})
+				So(err, ShouldBeNil)
+				So(count, ShouldEqual, 0)
+			})
+		})
+
+		Convey("When the transaction fails after recording an audit event", func() {
+			event, err := models.NewAuditEvent(models.RequestedBy{ID: "user"}, models.ActionUpdate, "/datasets/rollback-dataset", &models.Dataset{ID: "rollback-dataset"}, nil, nil, nil, nil, nil)
+			So(err, ShouldBeNil)
+
+			err = mongo.runWithRollback(ctx, func(transactionCtx context.Context) error {
+				if err := mongo.CreateAuditEvent(transactionCtx, event); err != nil {
+					return err
+				}
+				return failure
+			})
+
+			Convey("Then the audit event is kept, so that the chain is not broken", func() {
+				So(err, ShouldEqual, failure)
+
+				chain, err := mongo.GetAuditEventChain(ctx, event.Sequence, 1)
+				So(err, ShouldBeNil)
+				So(chain, ShouldHaveLength, 1)
+				So(chain[0].Hash, ShouldEqual, event.Hash)
+			})
+		})
+
+		Convey("When the transaction fails after another writer changed the dataset", func() {
+			err := transaction(func(ctx context.Context) {
+				So(mongo.UpdateDataset(ctx, "rollback-dataset", &models.Dataset{Title: "Concurrent title"}, models.CreatedState), ShouldBeNil)
+			})
+
+			Convey("Then the concurrent change is kept", func() {
+				So(err, ShouldEqual, failure)
+
+				dataset, err := mongo.GetDataset(ctx, "rollback-dataset")
+				So(err, ShouldBeNil)
+				So(dataset.Next.Title, ShouldEqual, "Concurrent title")
+			})
+		})
+	})
+}
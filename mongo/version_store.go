@@ -15,6 +15,20 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 )
 
+// versionIndexes are the indexes used to find the versions of an edition, to list static versions by state
+// from the most recently updated or by release date, to find the versions of a collection, and to lock versions
+var versionIndexes = []CollectionIndexes{
+	indexesOn(config.VersionsCollection,
+		ascending("links.dataset.id", "edition", "version"),
+		ascending("collection_id"),
+		Index{Keys: bson.D{{Key: "type", Value: 1}, {Key: "state", Value: 1}, {Key: "last_updated", Value: -1}}},
+		Index{Keys: bson.D{{Key: "type", Value: 1}, {Key: "state", Value: 1}, {Key: "release_date", Value: 1}}},
+		// deleted versions are looked up by the purge job
+		Index{Keys: bson.D{{Key: "deleted_at", Value: 1}}, Sparse: true},
+	),
+	{Collection: config.VersionsCollection, Locks: true, Indexes: lockIndexes},
+}
+
 // AcquireVersionsLock tries to lock the provided versionID.
 func (m *Mongo) AcquireVersionsLock(ctx context.Context, versionID string) (lockID string, err error) {
 	return m.lockClientVersionsCollection.Acquire(ctx, versionID)
@@ -24,6 +38,21 @@ func (m *Mongo) UnlockVersions(ctx context.Context, lockID string) {
 	m.lockClientVersionsCollection.Unlock(ctx, lockID)
 }
 
+// scheduledPublishingLockID is the resource locked by the instance of the service that publishes the scheduled versions.
+// It is kept in the lock collection of the versions, and cannot clash with a version ID.
+const scheduledPublishingLockID = "scheduled-publishing"
+
+// LockScheduledPublishing locks the publishing of scheduled versions for this instance of the service, without waiting.
+// An error is returned if another instance holds the lock. The lock expires if it is not released.
+func (m *Mongo) LockScheduledPublishing(ctx context.Context) (lockID string, err error) {
+	return m.lockClientVersionsCollection.Lock(ctx, scheduledPublishingLockID)
+}
+
+// UnlockScheduledPublishing releases the lock on the publishing of scheduled versions
+func (m *Mongo) UnlockScheduledPublishing(ctx context.Context, lockID string) {
+	m.lockClientVersionsCollection.Unlock(ctx, lockID)
+}
+
 // UpsertVersion adds or overrides an existing version document
 func (m *Mongo) UpsertVersionStatic(ctx context.Context, version *models.Version) (err error) {
 	version.LastUpdated = time.Now()
@@ -37,8 +66,17 @@ func (m *Mongo) UpsertVersionStatic(ctx context.Context, version *models.Version
 		"e_tag":   version.ETag,
 	}
 
-	_, err = m.Connection.Collection(m.ActualCollectionName(config.VersionsCollection)).UpsertOne(ctx, sel, update)
-	return err
+	entry, err := m.recordRollback(ctx, m.ActualCollectionName(config.VersionsCollection), sel)
+	if err != nil {
+		return err
+	}
+
+	result, err := m.Connection.Collection(m.ActualCollectionName(config.VersionsCollection)).UpsertOne(ctx, sel, update)
+	if err != nil {
+		return err
+	}
+
+	return m.recordWrite(ctx, entry, result.UpsertedID)
 }
 
 // AddVersion to the versions collection
@@ -74,7 +112,7 @@ func (m *Mongo) CheckEditionExistsStatic(ctx context.Context, datasetID, edition
 	}
 
 	var d models.Version
-	if err := m.Connection.Collection(m.ActualCollectionName(config.VersionsCollection)).FindOne(ctx, query, &d, mongodriver.Projection(bson.M{"_id": 1})); err != nil {
+	if err := m.Connection.Collection(m.ActualCollectionName(config.VersionsCollection)).FindOne(ctx, NotDeleted(query), &d, mongodriver.Projection(bson.M{"_id": 1})); err != nil {
 		if errors.Is(err, mongodriver.ErrNoDocumentFound) {
 			return errs.ErrEditionNotFound
 		}
@@ -92,7 +130,7 @@ func (m *Mongo) CheckVersionExistsStatic(ctx context.Context, datasetID, edition
 		"version":          version,
 	}
 
-	count, err := m.Connection.Collection(m.ActualCollectionName(config.VersionsCollection)).Count(ctx, query)
+	count, err := m.Connection.Collection(m.ActualCollectionName(config.VersionsCollection)).Count(ctx, NotDeleted(query))
 	if err != nil {
 		return false, err
 	}
@@ -119,7 +157,7 @@ func (m *Mongo) GetStaticVersionsByState(ctx context.Context, state, publishedOn
 	}
 
 	results := []*models.Version{}
-	totalCount, err := m.Connection.Collection(m.ActualCollectionName(config.VersionsCollection)).Find(ctx, filter, &results,
+	totalCount, err := m.Connection.Collection(m.ActualCollectionName(config.VersionsCollection)).Find(ctx, NotDeleted(filter), &results,
 		mongodriver.Sort(bson.M{"last_updated": -1}),
 		mongodriver.Offset(offset),
 		mongodriver.Limit(limit))
@@ -134,12 +172,59 @@ func (m *Mongo) GetStaticVersionsByState(ctx context.Context, state, publishedOn
 	return results, totalCount, nil
 }
 
+// ScheduledVersionsSelector selects the approved static versions that have a release date, which are published when
+// it has passed
+func ScheduledVersionsSelector() bson.M {
+	return NotDeleted(bson.M{"type": models.Static.String(), "state": models.ApprovedState, "release_date": bson.M{"$gt": ""}})
+}
+
+// GetScheduledVersions retrieves the approved static versions that have a release date, ordered by their release date as
+// stored. Release dates in different formats or time zones do not sort in the order they are released in, so the
+// versions that are due must be found from their parsed release dates.
+func (m *Mongo) GetScheduledVersions(ctx context.Context, offset, limit int) ([]*models.Version, int, error) {
+	results := []*models.Version{}
+	totalCount, err := m.Connection.Collection(m.ActualCollectionName(config.VersionsCollection)).Find(ctx, ScheduledVersionsSelector(), &results,
+		mongodriver.Sort(bson.D{{Key: "release_date", Value: 1}, {Key: "_id", Value: 1}}),
+		mongodriver.Offset(offset),
+		mongodriver.Limit(limit))
+	if err != nil {
+		return results, 0, err
+	}
+
+	return results, totalCount, nil
+}
+
+// CollectionVersionsSelector selects the versions of a collection that are in one of the provided states
+func CollectionVersionsSelector(collectionID string, states []string) bson.M {
+	return NotDeleted(bson.M{"collection_id": collectionID, "state": bson.M{"$in": states}})
+}
+
+// GetVersionsByCollectionID retrieves the versions and instances of a collection that are in one of the provided states,
+// sorted by dataset, edition and version
+func (m *Mongo) GetVersionsByCollectionID(ctx context.Context, collectionID string, states []string) ([]*models.Version, error) {
+	results := []*models.Version{}
+	for _, collection := range []string{config.InstanceCollection, config.VersionsCollection} {
+		versions := []*models.Version{}
+		if _, err := m.Connection.Collection(m.ActualCollectionName(collection)).Find(ctx, CollectionVersionsSelector(collectionID, states), &versions,
+			mongodriver.Sort(bson.D{{Key: "links.dataset.id", Value: 1}, {Key: "edition", Value: 1}, {Key: "version", Value: 1}})); err != nil {
+			return nil, err
+		}
+		results = append(results, versions...)
+	}
+
+	if len(results) == 0 {
+		return nil, errs.ErrVersionsNotFound
+	}
+
+	return results, nil
+}
+
 // GetVersions retrieves all version documents for a dataset
 func (m *Mongo) GetVersionsStatic(ctx context.Context, datasetID, edition, state string, offset, limit int) ([]models.Version, int, error) {
-	selector := buildVersionsQuery(datasetID, edition, state)
+	selector := BuildVersionsQuery(datasetID, edition, state)
 	// get total count and paginated values according to provided offset and limit
 	results := []models.Version{}
-	totalCount, err := m.Connection.Collection(m.ActualCollectionName(config.VersionsCollection)).Find(ctx, selector, &results,
+	totalCount, err := m.Connection.Collection(m.ActualCollectionName(config.VersionsCollection)).Find(ctx, NotDeleted(selector), &results,
 		mongodriver.Sort(bson.M{"last_updated": -1}),
 		mongodriver.Offset(offset),
 		mongodriver.Limit(limit))
@@ -161,10 +246,10 @@ func (m *Mongo) GetVersionsStatic(ctx context.Context, datasetID, edition, state
 
 // GetVersion retrieves a version document for a dataset edition
 func (m *Mongo) GetVersionStatic(ctx context.Context, id, editionID string, versionID int, state string) (*models.Version, error) {
-	selector := buildVersionQuery(id, editionID, state, versionID)
+	selector := BuildVersionQuery(id, editionID, state, versionID)
 
 	var version models.Version
-	err := m.Connection.Collection(m.ActualCollectionName(config.VersionsCollection)).FindOne(ctx, selector, &version)
+	err := m.Connection.Collection(m.ActualCollectionName(config.VersionsCollection)).FindOne(ctx, NotDeleted(selector), &version)
 	if err != nil {
 		if errors.Is(err, mongodriver.ErrNoDocumentFound) {
 			return nil, errs.ErrVersionNotFound
@@ -186,7 +271,7 @@ func (m *Mongo) GetLatestVersionStatic(ctx context.Context, datasetID, editionID
 	}
 
 	var version models.Version
-	err := m.Connection.Collection(m.ActualCollectionName(config.VersionsCollection)).FindOne(ctx, selector, &version, mongodriver.Sort(bson.M{"version": -1}))
+	err := m.Connection.Collection(m.ActualCollectionName(config.VersionsCollection)).FindOne(ctx, NotDeleted(selector), &version, mongodriver.Sort(bson.M{"version": -1}))
 	if err != nil {
 		if errors.Is(err, mongodriver.ErrNoDocumentFound) {
 			return nil, errs.ErrVersionNotFound
@@ -197,6 +282,26 @@ func (m *Mongo) GetLatestVersionStatic(ctx context.Context, datasetID, editionID
 	return &version, nil
 }
 
+// GetNextVersionStatic returns the number of the next version of an edition of a static dataset. Deleted versions are
+// included, so that the number of a deleted version is not reused while it can still be restored.
+func (m *Mongo) GetNextVersionStatic(ctx context.Context, datasetID, editionID string) (int, error) {
+	selector := bson.M{
+		"links.dataset.id": datasetID,
+		"links.edition.id": editionID,
+	}
+
+	var version models.Version
+	err := m.Connection.Collection(m.ActualCollectionName(config.VersionsCollection)).FindOne(ctx, selector, &version, mongodriver.Sort(bson.M{"version": -1}))
+	if err != nil {
+		if errors.Is(err, mongodriver.ErrNoDocumentFound) {
+			return 1, nil
+		}
+		return 0, err
+	}
+
+	return version.Version + 1, nil
+}
+
 // GetDatasetType retrieves the type of a dataset
 func (m *Mongo) GetDatasetType(ctx context.Context, datasetID string, authorised bool) (string, error) {
 	selector := bson.M{
@@ -210,7 +315,7 @@ func (m *Mongo) GetDatasetType(ctx context.Context, datasetID string, authorised
 	var d models.DatasetUpdate
 
 	if authorised {
-		if err := m.Connection.Collection(m.ActualCollectionName(config.DatasetsCollection)).FindOne(ctx, selector, &d, mongodriver.Projection(bson.M{"next.type": 1})); err != nil {
+		if err := m.Connection.Collection(m.ActualCollectionName(config.DatasetsCollection)).FindOne(ctx, NotDeleted(selector), &d, mongodriver.Projection(bson.M{"next.type": 1})); err != nil {
 			if errors.Is(err, mongodriver.ErrNoDocumentFound) {
 				return "", errs.ErrDatasetNotFound
 			}
@@ -219,7 +324,7 @@ func (m *Mongo) GetDatasetType(ctx context.Context, datasetID string, authorised
 		return d.Next.Type, nil
 	}
 
-	if err := m.Connection.Collection(m.ActualCollectionName(config.DatasetsCollection)).FindOne(ctx, selector, &d, mongodriver.Projection(bson.M{"current.type": 1})); err != nil {
+	if err := m.Connection.Collection(m.ActualCollectionName(config.DatasetsCollection)).FindOne(ctx, NotDeleted(selector), &d, mongodriver.Projection(bson.M{"current.type": 1})); err != nil {
 		if errors.Is(err, mongodriver.ErrNoDocumentFound) {
 			return "", errs.ErrDatasetNotFound
 		}
@@ -231,7 +336,7 @@ func (m *Mongo) GetDatasetType(ctx context.Context, datasetID string, authorised
 // UpdateVersionStatic updates an existing version document
 func (m *Mongo) UpdateVersionStatic(ctx context.Context, currentVersion, versionUpdate *models.Version, eTagSelector string) (newETag string, err error) {
 	// calculate the new eTag hash for the instance that would result from adding the event
-	newETag, err = newETagForVersionUpdate(currentVersion, versionUpdate)
+	newETag, err = NewETagForVersionUpdate(currentVersion, versionUpdate)
 	if err != nil {
 		return "", err
 	}
@@ -241,7 +346,12 @@ func (m *Mongo) UpdateVersionStatic(ctx context.Context, currentVersion, version
 		"version": currentVersion.Version,
 		"e_tag":   eTagSelector,
 	}
-	updates := createVersionUpdateQuery(versionUpdate, newETag)
+	updates := CreateVersionUpdateQuery(versionUpdate, newETag)
+
+	entry, err := m.recordRollback(ctx, m.ActualCollectionName(config.VersionsCollection), sel)
+	if err != nil {
+		return "", err
+	}
 
 	if _, err := m.Connection.Collection(m.ActualCollectionName(config.VersionsCollection)).Must().Update(ctx, sel, bson.M{"$set": updates}); err != nil {
 		if errors.Is(err, mongodriver.ErrNoDocumentFound) {
@@ -250,6 +360,10 @@ func (m *Mongo) UpdateVersionStatic(ctx context.Context, currentVersion, version
 		return "", err
 	}
 
+	if err := m.recordWrite(ctx, entry, nil); err != nil {
+		return "", err
+	}
+
 	return newETag, nil
 }
 
@@ -261,7 +375,7 @@ func (m *Mongo) GetAllStaticVersions(ctx context.Context, datasetID, state strin
 	}
 
 	results := []*models.Version{}
-	totalCount, err := m.Connection.Collection(m.ActualCollectionName(config.VersionsCollection)).Find(ctx, selector, &results,
+	totalCount, err := m.Connection.Collection(m.ActualCollectionName(config.VersionsCollection)).Find(ctx, NotDeleted(selector), &results,
 		mongodriver.Sort(bson.M{"release_date": -1}),
 		mongodriver.Offset(offset),
 		mongodriver.Limit(limit))
@@ -276,23 +390,15 @@ func (m *Mongo) GetAllStaticVersions(ctx context.Context, datasetID, state strin
 	return results, totalCount, nil
 }
 
-func (m *Mongo) DeleteStaticDatasetVersion(ctx context.Context, datasetID, editionID string, versionNumber int) (err error) {
-	// proceed to delete version
-	filter := bson.M{
+// DeleteStaticDatasetVersion marks a static version as deleted
+func (m *Mongo) DeleteStaticDatasetVersion(ctx context.Context, datasetID, editionID string, versionNumber int, deletion *models.Deletion) error {
+	selector := bson.M{
 		"links.dataset.id": datasetID,
 		"edition":          editionID,
 		"version":          versionNumber,
 	}
 
-	_, err = m.Connection.Collection(m.ActualCollectionName(config.VersionsCollection)).Must().Delete(ctx, filter)
-	if err != nil {
-		if errors.Is(err, mongodriver.ErrNoDocumentFound) {
-			return errs.ErrVersionNotFound
-		}
-		return err
-	}
-
-	return nil
+	return m.softDelete(ctx, config.VersionsCollection, selector, deletion, errs.ErrVersionNotFound)
 }
 
 func (m *Mongo) CheckEditionTitleExistsStatic(ctx context.Context, datasetID, editionTitle string) error {
@@ -302,7 +408,7 @@ func (m *Mongo) CheckEditionTitleExistsStatic(ctx context.Context, datasetID, ed
 		"edition_title":    editionTitle,
 	}
 	var d models.Version
-	if err := m.Connection.Collection(m.ActualCollectionName(config.VersionsCollection)).FindOne(ctx, queryByTitle, &d, mongodriver.Projection(bson.M{"_id": 1})); err == nil {
+	if err := m.Connection.Collection(m.ActualCollectionName(config.VersionsCollection)).FindOne(ctx, NotDeleted(queryByTitle), &d, mongodriver.Projection(bson.M{"_id": 1})); err == nil {
 		return errs.ErrEditionTitleAlreadyExists
 	} else if !errors.Is(err, mongodriver.ErrNoDocumentFound) {
 		return err
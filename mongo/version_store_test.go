@@ -3,6 +3,7 @@ package mongo
 import (
 	"context"
 	"testing"
+	"time"
 
 	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
 	"github.com/ONSdigital/dp-dataset-api/config"
@@ -208,13 +209,23 @@ func TestDeleteStaticDatasetVersion(t *testing.T) {
 			datasetToDelete := staticDatasetID
 			editionToDelete := "edition2"
 			versionToDelete := 2
-			err = mongoStore.DeleteStaticDatasetVersion(ctx, datasetToDelete, editionToDelete, versionToDelete)
+			err = mongoStore.DeleteStaticDatasetVersion(ctx, datasetToDelete, editionToDelete, versionToDelete, models.NewDeletion("user@ons.gov.uk"))
 
 			So(err, ShouldBeNil)
-			selector := bson.M{"links.dataset.id": staticDatasetID}
+			selector := NotDeleted(bson.M{"links.dataset.id": staticDatasetID})
 			totalCount, err := mongoStore.Connection.Collection(mongoStore.ActualCollectionName(config.VersionsCollection)).Count(ctx, selector)
 			So(err, ShouldBeNil)
 			So(totalCount, ShouldEqual, 1)
+
+			Convey("Then the version is kept as deleted, so that it can be restored until it is purged", func() {
+				deleted, err := mongoStore.GetDeletedStaticVersions(ctx, time.Now().Add(time.Minute), 10)
+				So(err, ShouldBeNil)
+				So(deleted, ShouldHaveLength, 1)
+				So(deleted[0].Edition, ShouldEqual, editionToDelete)
+
+				So(mongoStore.PurgeStaticVersion(ctx, deleted[0].ID), ShouldBeNil)
+				So(mongoStore.PurgeStaticVersion(ctx, deleted[0].ID), ShouldEqual, errs.ErrVersionNotFound)
+			})
 		})
 	})
 }
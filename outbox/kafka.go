@@ -0,0 +1,126 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ONSdigital/dp-healthcheck/healthcheck"
+	kafka "github.com/ONSdigital/dp-kafka/v4"
+	"github.com/ONSdigital/dp-kafka/v4/interfaces"
+	"github.com/Shopify/sarama"
+)
+
+// KafkaProducer sends the outbox messages of a topic to kafka, waiting for all the in-sync replicas to acknowledge each message.
+// It connects to kafka when the first message is sent, and again after a failed connection, so that the service can start while
+// kafka is unavailable.
+type KafkaProducer struct {
+	cfg             *kafka.ProducerConfig
+	newSyncProducer func(addrs []string, cfg *sarama.Config) (sarama.SyncProducer, error)
+	newBroker       interfaces.BrokerGenerator
+	mu              sync.Mutex
+	producer        sarama.SyncProducer
+	// brokers are the connections used to check the health of kafka, separate from the ones used to send messages
+	brokers []interfaces.SaramaBroker
+}
+
+// NewKafkaProducer creates a new KafkaProducer for the topic of the provided producer config
+func NewKafkaProducer(pConfig *kafka.ProducerConfig) *KafkaProducer {
+	return &KafkaProducer{
+		cfg:             pConfig,
+		newSyncProducer: sarama.NewSyncProducer,
+		newBroker:       kafka.SaramaNewBroker,
+	}
+}
+
+// SendMessage sends the payload to the kafka topic of the producer, returning once it was acknowledged or the context is done.
+// A message whose context is done may still be delivered.
+func (p *KafkaProducer) SendMessage(ctx context.Context, payload []byte) error {
+	producer, err := p.initialise()
+	if err != nil {
+		return fmt.Errorf("%w: %s", errProducerNotInitialised, err.Error())
+	}
+
+	sent := make(chan error, 1)
+	go func() {
+		_, _, err := producer.SendMessage(&sarama.ProducerMessage{Topic: p.cfg.Topic, Value: sarama.ByteEncoder(payload)})
+		sent <- err
+	}()
+
+	select {
+	case err := <-sent:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Checker reports whether enough kafka brokers are reachable and have the topic of the producer, in the same way as
+// the producers of dp-kafka
+func (p *KafkaProducer) Checker(ctx context.Context, state *healthcheck.CheckState) error {
+	saramaConfig, err := p.cfg.Get()
+	if err != nil {
+		return state.Update(healthcheck.StatusCritical, err.Error(), 0)
+	}
+
+	p.mu.Lock()
+	if p.brokers == nil {
+		for _, addr := range p.cfg.BrokerAddrs {
+			p.brokers = append(p.brokers, p.newBroker(addr))
+		}
+	}
+	brokers := p.brokers
+	p.mu.Unlock()
+
+	info := kafka.Healthcheck(ctx, brokers, p.cfg.Topic, saramaConfig)
+	if err := info.UpdateStatus(state, *p.cfg.MinBrokersHealthy, kafka.MsgHealthyProducer); err != nil {
+		return fmt.Errorf("error updating outbox producer healthcheck status: %w", err)
+	}
+	return nil
+}
+
+// Close closes the connections to kafka, if there are any
+func (p *KafkaProducer) Close(context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var errs []error
+	for _, broker := range p.brokers {
+		if connected, _ := broker.Connected(); connected {
+			errs = append(errs, broker.Close())
+		}
+	}
+	p.brokers = nil
+
+	if p.producer != nil {
+		errs = append(errs, p.producer.Close())
+		p.producer = nil
+	}
+
+	return errors.Join(errs...)
+}
+
+// initialise returns the sync producer, connecting to kafka if it is not connected yet
+func (p *KafkaProducer) initialise() (sarama.SyncProducer, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.producer != nil {
+		return p.producer, nil
+	}
+
+	saramaConfig, err := p.cfg.Get()
+	if err != nil {
+		return nil, err
+	}
+	saramaConfig.Producer.RequiredAcks = sarama.WaitForAll
+	saramaConfig.Producer.Return.Successes = true
+	saramaConfig.Producer.Return.Errors = true
+
+	if p.producer, err = p.newSyncProducer(p.cfg.BrokerAddrs, saramaConfig); err != nil {
+		return nil, err
+	}
+
+	return p.producer, nil
+}
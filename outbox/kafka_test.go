@@ -0,0 +1,128 @@
+package outbox
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ONSdigital/dp-healthcheck/healthcheck"
+	kafka "github.com/ONSdigital/dp-kafka/v4"
+	"github.com/ONSdigital/dp-kafka/v4/interfaces"
+	kafkaMock "github.com/ONSdigital/dp-kafka/v4/mock"
+	"github.com/Shopify/sarama"
+	"github.com/Shopify/sarama/mocks"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func newTestKafkaProducer(newSyncProducer func([]string, *sarama.Config) (sarama.SyncProducer, error)) *KafkaProducer {
+	minBrokersHealthy := 1
+	producer := NewKafkaProducer(&kafka.ProducerConfig{Topic: testTopic, BrokerAddrs: []string{"localhost:9092"}, MinBrokersHealthy: &minBrokersHealthy})
+	producer.newSyncProducer = newSyncProducer
+	return producer
+}
+
+func TestKafkaProducer(t *testing.T) {
+	Convey("Given a kafka producer connected to kafka", t, func() {
+		var saramaConfig *sarama.Config
+		syncProducer := mocks.NewSyncProducer(t, nil)
+		producer := newTestKafkaProducer(func(_ []string, cfg *sarama.Config) (sarama.SyncProducer, error) {
+			saramaConfig = cfg
+			return syncProducer, nil
+		})
+
+		Convey("When a message is acknowledged by kafka", func() {
+			syncProducer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(func(message *sarama.ProducerMessage) error {
+				if message.Topic != testTopic {
+					return errors.New("unexpected topic " + message.Topic)
+				}
+				return nil
+			})
+			err := producer.SendMessage(testContext, []byte("payload"))
+
+			Convey("Then it is sent to the topic of the producer, waiting for all the in-sync replicas", func() {
+				So(err, ShouldBeNil)
+				So(saramaConfig.Producer.RequiredAcks, ShouldEqual, sarama.WaitForAll)
+				So(saramaConfig.Producer.Return.Successes, ShouldBeTrue)
+				So(producer.Close(testContext), ShouldBeNil)
+			})
+		})
+
+		Convey("When a message is rejected by kafka", func() {
+			syncProducer.ExpectSendMessageAndFail(errors.New("kafka error"))
+			err := producer.SendMessage(testContext, []byte("payload"))
+
+			Convey("Then the error is returned", func() {
+				So(err, ShouldResemble, errors.New("kafka error"))
+				So(producer.Close(testContext), ShouldBeNil)
+			})
+		})
+	})
+
+	Convey("Given a kafka producer that cannot connect to kafka", t, func() {
+		connections := 0
+		producer := newTestKafkaProducer(func([]string, *sarama.Config) (sarama.SyncProducer, error) {
+			connections++
+			return nil, errors.New("connection refused")
+		})
+
+		Convey("When messages are sent", func() {
+			err := producer.SendMessage(testContext, []byte("payload"))
+			retryErr := producer.SendMessage(testContext, []byte("payload"))
+
+			Convey("Then the producer is not initialised and connects again for each message", func() {
+				So(errors.Is(err, errProducerNotInitialised), ShouldBeTrue)
+				So(errors.Is(retryErr, errProducerNotInitialised), ShouldBeTrue)
+				So(connections, ShouldEqual, 2)
+			})
+		})
+	})
+}
+
+func TestKafkaProducerChecker(t *testing.T) {
+	Convey("Given a kafka producer", t, func() {
+		producer := newTestKafkaProducer(nil)
+
+		connected := false
+		broker := &kafkaMock.SaramaBrokerMock{
+			AddrFunc:      func() string { return "localhost:9092" },
+			ConnectedFunc: func() (bool, error) { return connected, nil },
+			OpenFunc: func(*sarama.Config) error {
+				connected = true
+				return nil
+			},
+			CloseFunc: func() error {
+				connected = false
+				return nil
+			},
+		}
+		producer.newBroker = func(string) interfaces.SaramaBroker { return broker }
+
+		Convey("When the broker has the topic of the producer", func() {
+			broker.GetMetadataFunc = func(*sarama.MetadataRequest) (*sarama.MetadataResponse, error) {
+				return &sarama.MetadataResponse{Topics: []*sarama.TopicMetadata{{Name: testTopic}}}, nil
+			}
+			state := healthcheck.NewCheckState("outbox producer")
+			err := producer.Checker(testContext, state)
+
+			Convey("Then the producer is healthy and its connection to the broker is closed with the producer", func() {
+				So(err, ShouldBeNil)
+				So(state.Status(), ShouldEqual, healthcheck.StatusOK)
+				So(state.Message(), ShouldEqual, kafka.MsgHealthyProducer)
+				So(producer.Close(testContext), ShouldBeNil)
+				So(broker.CloseCalls(), ShouldHaveLength, 1)
+			})
+		})
+
+		Convey("When the broker cannot be reached", func() {
+			broker.GetMetadataFunc = func(*sarama.MetadataRequest) (*sarama.MetadataResponse, error) {
+				return nil, errors.New("connection refused")
+			}
+			state := healthcheck.NewCheckState("outbox producer")
+			err := producer.Checker(testContext, state)
+
+			Convey("Then the producer is reported as critical", func() {
+				So(err, ShouldBeNil)
+				So(state.Status(), ShouldEqual, healthcheck.StatusCritical)
+			})
+		})
+	})
+}
@@ -0,0 +1,43 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/ONSdigital/dp-dataset-api/models"
+)
+
+// Store represents the datastore methods required to store and deliver outbox messages
+type Store interface {
+	AddOutboxMessage(ctx context.Context, message *models.OutboxMessage) error
+	ClaimOutboxMessage(ctx context.Context, owner string, lease time.Duration, skipTopics []string) (*models.OutboxMessage, error)
+	UpdateOutboxMessage(ctx context.Context, message *models.OutboxMessage) error
+	CountOutboxMessages(ctx context.Context, state string) (int, error)
+	RequeueFailedOutboxMessages(ctx context.Context, now time.Time) (int, error)
+	PurgeSentOutboxMessages(ctx context.Context, before time.Time) (int, error)
+}
+
+// Writer stores the kafka messages for a topic in the outbox, so that they are delivered by the Relay.
+// When Write is called with a context created by store RunTransaction, the message is only stored if the transaction succeeds.
+type Writer struct {
+	Store Store
+	Topic string
+}
+
+// NewWriter creates a new outbox Writer for the provided topic
+func NewWriter(outboxStore Store, topic string) *Writer {
+	return &Writer{
+		Store: outboxStore,
+		Topic: topic,
+	}
+}
+
+// Write adds a message with the provided payload to the outbox
+func (w *Writer) Write(ctx context.Context, payload []byte) error {
+	message, err := models.NewOutboxMessage(w.Topic, payload)
+	if err != nil {
+		return err
+	}
+
+	return w.Store.AddOutboxMessage(ctx, message)
+}
@@ -0,0 +1,53 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ONSdigital/dp-dataset-api/models"
+	storetest "github.com/ONSdigital/dp-dataset-api/store/datastoretest"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const testTopic = "search-content-updated"
+
+var testContext = context.Background()
+
+func TestWriter(t *testing.T) {
+	Convey("Given an outbox writer for a topic", t, func() {
+		storeMock := &storetest.StorerMock{
+			AddOutboxMessageFunc: func(context.Context, *models.OutboxMessage) error {
+				return nil
+			},
+		}
+		writer := NewWriter(storeMock, testTopic)
+
+		Convey("When a message is written", func() {
+			err := writer.Write(testContext, []byte(`{"dataset_id":"123"}`))
+
+			Convey("Then a pending message for the topic is added to the outbox", func() {
+				So(err, ShouldBeNil)
+				So(storeMock.AddOutboxMessageCalls(), ShouldHaveLength, 1)
+
+				message := storeMock.AddOutboxMessageCalls()[0].Message
+				So(message.ID, ShouldNotBeEmpty)
+				So(message.Topic, ShouldEqual, testTopic)
+				So(message.Payload, ShouldResemble, []byte(`{"dataset_id":"123"}`))
+				So(message.State, ShouldEqual, models.OutboxMessagePending)
+				So(message.Attempts, ShouldEqual, 0)
+			})
+		})
+
+		Convey("When the outbox cannot be written to", func() {
+			storeMock.AddOutboxMessageFunc = func(context.Context, *models.OutboxMessage) error {
+				return errors.New("store error")
+			}
+			err := writer.Write(testContext, []byte(`{}`))
+
+			Convey("Then the error is returned", func() {
+				So(err, ShouldResemble, errors.New("store error"))
+			})
+		})
+	})
+}
@@ -0,0 +1,280 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	"github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/dp-healthcheck/healthcheck"
+	"github.com/ONSdigital/log.go/v2/log"
+	uuid "github.com/satori/go.uuid"
+)
+
+// maxRetryDelay is the longest time a failed message waits before it is sent again
+const maxRetryDelay = 5 * time.Minute
+
+// cleanupInterval is the time between the runs re-queueing the failed messages and purging the sent ones
+const cleanupInterval = time.Minute
+
+// Health check messages
+const (
+	MsgHealthy           = "outbox messages are being delivered"
+	MsgDeliveryFailing   = "outbox messages could not be delivered"
+	MsgUndeliveredFormat = "%d outbox message(s) exceeded the maximum number of delivery attempts"
+)
+
+var (
+	errProducerNotFound       = errors.New("no kafka producer available for topic")
+	errProducerNotInitialised = errors.New("kafka producer is not initialised")
+	errSendTimeout            = errors.New("timed out waiting for kafka to acknowledge message")
+)
+
+// Producer represents a kafka producer that only returns once the delivery of a message to its topic was acknowledged
+type Producer interface {
+	SendMessage(ctx context.Context, payload []byte) error
+}
+
+// Config contains the configuration of the outbox Relay. Failed messages are re-queued after FailedRetryDelay and sent
+// messages are purged after SentRetention, unless they are zero.
+type Config struct {
+	Interval         time.Duration
+	BatchSize        int
+	SendTimeout      time.Duration
+	MaxAttempts      int
+	Lease            time.Duration
+	FailedRetryDelay time.Duration
+	SentRetention    time.Duration
+}
+
+// Relay periodically delivers the pending outbox messages to the kafka producer of their topic,
+// retrying the messages that could not be sent with an exponential backoff. Messages that exceeded the maximum number of
+// attempts are re-queued after a delay, and the messages that were sent are purged after the retention period. Each message is claimed by a single relay
+// while it is delivered, so that several instances of the service can run their relays against the same outbox.
+type Relay struct {
+	id        string
+	store     Store
+	producers map[string]Producer
+	cfg       Config
+	cancel    context.CancelFunc
+	done      chan struct{}
+	mu        sync.RWMutex
+	lastErr   error
+}
+
+// NewRelay creates a new outbox Relay for the provided kafka producers, keyed by topic
+func NewRelay(outboxStore Store, producers map[string]Producer, cfg Config) (*Relay, error) {
+	id, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Relay{
+		id:        id.String(),
+		store:     outboxStore,
+		producers: producers,
+		cfg:       cfg,
+		done:      make(chan struct{}),
+	}, nil
+}
+
+// Start delivers the pending outbox messages at the configured interval, until the relay is closed
+func (r *Relay) Start(ctx context.Context) {
+	ctx, r.cancel = context.WithCancel(ctx)
+
+	go func() {
+		defer close(r.done)
+
+		ticker := time.NewTicker(r.cfg.Interval)
+		defer ticker.Stop()
+
+		cleanupTicker := time.NewTicker(cleanupInterval)
+		defer cleanupTicker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				r.setError(r.Deliver(ctx))
+			case <-cleanupTicker.C:
+				if err := r.Cleanup(ctx); err != nil {
+					log.Error(ctx, "failed to clean up the outbox", err)
+				}
+			}
+		}
+	}()
+}
+
+// Close stops the relay, waiting for any delivery in progress to finish or the context to be done
+func (r *Relay) Close(ctx context.Context) error {
+	if r.cancel == nil {
+		return nil
+	}
+	r.cancel()
+
+	select {
+	case <-r.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Deliver claims and sends up to a batch of pending outbox messages to kafka, and records the outcome of each of them.
+// A message is marked as sent once kafka acknowledged it. A message that is claimed but whose outcome cannot be recorded
+// is claimed again once its lease expires, so messages are delivered at least once. Once a message of a topic fails,
+// the remaining messages of that topic are left for the next delivery instead of waiting on the same failure.
+// The returned error describes the last delivery failure, if any.
+func (r *Relay) Deliver(ctx context.Context) error {
+	var deliveryErr error
+	blocked := []string{}
+
+	for i := 0; i < r.cfg.BatchSize; i++ {
+		message, err := r.store.ClaimOutboxMessage(ctx, r.id, r.cfg.Lease, blocked)
+		if errors.Is(err, errs.ErrOutboxMessageNotFound) {
+			break
+		}
+		if err != nil {
+			log.Error(ctx, "failed to claim outbox message", err)
+			return fmt.Errorf("failed to claim outbox message: %w", err)
+		}
+
+		logData := log.Data{"outbox_message_id": message.ID, "topic": message.Topic, "attempts": message.Attempts}
+
+		if err := r.send(ctx, message); err != nil {
+			blocked = append(blocked, message.Topic)
+			deliveryErr = fmt.Errorf("failed to deliver outbox message to topic %s: %w", message.Topic, err)
+			logData["err"] = err.Error()
+			log.Warn(ctx, "failed to deliver outbox message", logData)
+
+			if err := r.store.UpdateOutboxMessage(ctx, r.failed(message, err)); err != nil {
+				log.Error(ctx, "failed to record outbox message failure", err, logData)
+			}
+			continue
+		}
+
+		sentAt := time.Now().UTC()
+		message.State = models.OutboxMessageSent
+		message.Attempts++
+		message.LastError = ""
+		message.SentAt = &sentAt
+
+		if err := r.store.UpdateOutboxMessage(ctx, message); err != nil {
+			log.Error(ctx, "failed to mark outbox message as sent", err, logData)
+			deliveryErr = fmt.Errorf("failed to mark outbox message as sent: %w", err)
+		}
+	}
+
+	return deliveryErr
+}
+
+// Cleanup re-queues the failed messages whose retry delay passed, with a new set of attempts, and purges the messages that
+// were sent before the retention period
+func (r *Relay) Cleanup(ctx context.Context) error {
+	now := time.Now().UTC()
+
+	if r.cfg.FailedRetryDelay > 0 {
+		requeued, err := r.store.RequeueFailedOutboxMessages(ctx, now)
+		if err != nil {
+			return fmt.Errorf("failed to re-queue failed outbox messages: %w", err)
+		}
+		if requeued > 0 {
+			log.Info(ctx, "re-queued failed outbox messages", log.Data{"requeued": requeued})
+		}
+	}
+
+	if r.cfg.SentRetention > 0 {
+		purged, err := r.store.PurgeSentOutboxMessages(ctx, now.Add(-r.cfg.SentRetention))
+		if err != nil {
+			return fmt.Errorf("failed to purge sent outbox messages: %w", err)
+		}
+		if purged > 0 {
+			log.Info(ctx, "purged sent outbox messages", log.Data{"purged": purged, "retention": r.cfg.SentRetention.String()})
+		}
+	}
+
+	return nil
+}
+
+// send passes the message payload to the kafka producer of its topic, waiting for kafka to acknowledge it
+func (r *Relay) send(ctx context.Context, message *models.OutboxMessage) error {
+	producer, ok := r.producers[message.Topic]
+	if !ok {
+		return errProducerNotFound
+	}
+
+	sendCtx, cancel := context.WithTimeout(ctx, r.cfg.SendTimeout)
+	defer cancel()
+
+	err := producer.SendMessage(sendCtx, message.Payload)
+	if errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+		return errSendTimeout
+	}
+	return err
+}
+
+// failed records a failed delivery attempt, scheduling the next attempt or marking the message as failed if it reached
+// the maximum number of attempts, in which case it is re-queued once the failed retry delay passed.
+// A producer that could not connect to kafka does not count as an attempt for the message.
+func (r *Relay) failed(message *models.OutboxMessage, err error) *models.OutboxMessage {
+	message.State = models.OutboxMessagePending
+	message.LastError = err.Error()
+
+	if errors.Is(err, errProducerNotInitialised) {
+		message.NextAttemptAt = time.Now().UTC().Add(r.cfg.Interval)
+		return message
+	}
+
+	message.Attempts++
+	if message.Attempts >= r.cfg.MaxAttempts {
+		message.State = models.OutboxMessageFailed
+		message.NextAttemptAt = time.Now().UTC().Add(r.cfg.FailedRetryDelay)
+		return message
+	}
+
+	delay := r.cfg.Interval
+	for i := 1; i < message.Attempts && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxRetryDelay {
+		delay = maxRetryDelay
+	}
+	message.NextAttemptAt = time.Now().UTC().Add(delay)
+
+	return message
+}
+
+func (r *Relay) setError(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.lastErr = err
+}
+
+func (r *Relay) getError() error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.lastErr
+}
+
+// Checker reports the delivery status of the outbox: WARNING if the last delivery failed
+// or some messages exceeded the maximum number of attempts, OK otherwise
+func (r *Relay) Checker(ctx context.Context, state *healthcheck.CheckState) error {
+	if err := r.getError(); err != nil {
+		return state.Update(healthcheck.StatusWarning, fmt.Sprintf("%s: %s", MsgDeliveryFailing, err.Error()), 0)
+	}
+
+	failed, err := r.store.CountOutboxMessages(ctx, models.OutboxMessageFailed)
+	if err != nil {
+		return state.Update(healthcheck.StatusWarning, fmt.Sprintf("failed to count undelivered outbox messages: %s", err.Error()), 0)
+	}
+
+	if failed > 0 {
+		return state.Update(healthcheck.StatusWarning, fmt.Sprintf(MsgUndeliveredFormat, failed), 0)
+	}
+
+	return state.Update(healthcheck.StatusOK, MsgHealthy, 0)
+}
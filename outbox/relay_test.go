@@ -0,0 +1,276 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	"github.com/ONSdigital/dp-dataset-api/models"
+	storetest "github.com/ONSdigital/dp-dataset-api/store/datastoretest"
+	"github.com/ONSdigital/dp-healthcheck/healthcheck"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+var testConfig = Config{Interval: time.Second, BatchSize: 10, SendTimeout: 10 * time.Millisecond, MaxAttempts: 3, Lease: time.Minute}
+
+// producerFunc is a Producer that sends each message with the provided function
+type producerFunc func(ctx context.Context, payload []byte) error
+
+func (f producerFunc) SendMessage(ctx context.Context, payload []byte) error {
+	return f(ctx, payload)
+}
+
+// newStoreMock returns a store mock whose messages are claimed in order, skipping the messages of the topics to skip
+func newStoreMock(messages ...*models.OutboxMessage) *storetest.StorerMock {
+	claimed := map[string]bool{}
+
+	return &storetest.StorerMock{
+		ClaimOutboxMessageFunc: func(_ context.Context, owner string, _ time.Duration, skipTopics []string) (*models.OutboxMessage, error) {
+			for _, message := range messages {
+				if claimed[message.ID] || contains(skipTopics, message.Topic) {
+					continue
+				}
+				claimed[message.ID] = true
+				message.State = models.OutboxMessageDelivering
+				message.ClaimedBy = owner
+				return message, nil
+			}
+			return nil, errs.ErrOutboxMessageNotFound
+		},
+		UpdateOutboxMessageFunc: func(context.Context, *models.OutboxMessage) error {
+			return nil
+		},
+		CountOutboxMessagesFunc: func(context.Context, string) (int, error) {
+			return 0, nil
+		},
+	}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func newTestMessage(payload string) *models.OutboxMessage {
+	message, err := models.NewOutboxMessage(testTopic, []byte(payload))
+	So(err, ShouldBeNil)
+	return message
+}
+
+func newTestRelay(storeMock *storetest.StorerMock, producers map[string]Producer) *Relay {
+	relay, err := NewRelay(storeMock, producers, testConfig)
+	So(err, ShouldBeNil)
+	return relay
+}
+
+func TestRelayDeliver(t *testing.T) {
+	Convey("Given pending outbox messages", t, func() {
+		first, second := newTestMessage("first"), newTestMessage("second")
+		storeMock := newStoreMock(first, second)
+
+		Convey("When they are delivered to a producer whose messages are acknowledged", func() {
+			sent := [][]byte{}
+			relay := newTestRelay(storeMock, map[string]Producer{testTopic: producerFunc(func(_ context.Context, payload []byte) error {
+				sent = append(sent, payload)
+				return nil
+			})})
+			err := relay.Deliver(testContext)
+
+			Convey("Then they are claimed by the relay for the lease duration", func() {
+				So(err, ShouldBeNil)
+				So(storeMock.ClaimOutboxMessageCalls(), ShouldHaveLength, 3)
+				for _, call := range storeMock.ClaimOutboxMessageCalls() {
+					So(call.Owner, ShouldEqual, relay.id)
+					So(call.Lease, ShouldEqual, testConfig.Lease)
+				}
+			})
+
+			Convey("Then they are sent in order and marked as sent by the relay that claimed them", func() {
+				So(sent, ShouldResemble, [][]byte{[]byte("first"), []byte("second")})
+
+				So(storeMock.UpdateOutboxMessageCalls(), ShouldHaveLength, 2)
+				for _, call := range storeMock.UpdateOutboxMessageCalls() {
+					So(call.Message.State, ShouldEqual, models.OutboxMessageSent)
+					So(call.Message.ClaimedBy, ShouldEqual, relay.id)
+					So(call.Message.Attempts, ShouldEqual, 1)
+					So(call.Message.SentAt, ShouldNotBeNil)
+				}
+			})
+		})
+
+		Convey("When kafka does not acknowledge them in time", func() {
+			relay := newTestRelay(storeMock, map[string]Producer{testTopic: producerFunc(func(ctx context.Context, _ []byte) error {
+				<-ctx.Done()
+				return ctx.Err()
+			})})
+			err := relay.Deliver(testContext)
+
+			Convey("Then the failed attempt is recorded and retried later, skipping the rest of the topic", func() {
+				So(err, ShouldNotBeNil)
+				So(storeMock.ClaimOutboxMessageCalls(), ShouldHaveLength, 2)
+				So(storeMock.ClaimOutboxMessageCalls()[1].SkipTopics, ShouldResemble, []string{testTopic})
+				So(storeMock.UpdateOutboxMessageCalls(), ShouldHaveLength, 1)
+
+				message := storeMock.UpdateOutboxMessageCalls()[0].Message
+				So(message.ID, ShouldEqual, first.ID)
+				So(message.State, ShouldEqual, models.OutboxMessagePending)
+				So(message.Attempts, ShouldEqual, 1)
+				So(message.LastError, ShouldEqual, errSendTimeout.Error())
+				So(message.NextAttemptAt, ShouldHappenAfter, time.Now().UTC())
+			})
+		})
+
+		Convey("When kafka rejects them", func() {
+			relay := newTestRelay(storeMock, map[string]Producer{testTopic: producerFunc(func(context.Context, []byte) error {
+				return errors.New("kafka error")
+			})})
+			err := relay.Deliver(testContext)
+
+			Convey("Then they are not marked as sent", func() {
+				So(err, ShouldNotBeNil)
+				So(storeMock.UpdateOutboxMessageCalls(), ShouldHaveLength, 1)
+
+				message := storeMock.UpdateOutboxMessageCalls()[0].Message
+				So(message.State, ShouldEqual, models.OutboxMessagePending)
+				So(message.SentAt, ShouldBeNil)
+				So(message.LastError, ShouldEqual, "kafka error")
+			})
+		})
+
+		Convey("When a message fails on its last attempt", func() {
+			first.Attempts = testConfig.MaxAttempts - 1
+			relay := newTestRelay(storeMock, map[string]Producer{})
+			err := relay.Deliver(testContext)
+
+			Convey("Then it is marked as failed", func() {
+				So(err, ShouldNotBeNil)
+				So(storeMock.UpdateOutboxMessageCalls(), ShouldHaveLength, 1)
+
+				message := storeMock.UpdateOutboxMessageCalls()[0].Message
+				So(message.State, ShouldEqual, models.OutboxMessageFailed)
+				So(message.Attempts, ShouldEqual, testConfig.MaxAttempts)
+				So(message.LastError, ShouldEqual, errProducerNotFound.Error())
+			})
+		})
+
+		Convey("When the producer cannot connect to kafka", func() {
+			relay := newTestRelay(storeMock, map[string]Producer{testTopic: producerFunc(func(context.Context, []byte) error {
+				return fmt.Errorf("%w: connection refused", errProducerNotInitialised)
+			})})
+			err := relay.Deliver(testContext)
+
+			Convey("Then the claimed message is released without counting an attempt", func() {
+				So(err, ShouldNotBeNil)
+				So(storeMock.UpdateOutboxMessageCalls(), ShouldHaveLength, 1)
+
+				message := storeMock.UpdateOutboxMessageCalls()[0].Message
+				So(message.State, ShouldEqual, models.OutboxMessagePending)
+				So(message.Attempts, ShouldEqual, 0)
+			})
+		})
+
+		Convey("When the lease of a message expired before it was marked as sent", func() {
+			storeMock.UpdateOutboxMessageFunc = func(context.Context, *models.OutboxMessage) error {
+				return errs.ErrOutboxMessageNotFound
+			}
+			relay := newTestRelay(storeMock, map[string]Producer{testTopic: producerFunc(func(context.Context, []byte) error {
+				return nil
+			})})
+			err := relay.Deliver(testContext)
+
+			Convey("Then an error is returned", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, errs.ErrOutboxMessageNotFound.Error())
+			})
+		})
+	})
+
+	Convey("Given more pending outbox messages than the batch size", t, func() {
+		messages := []*models.OutboxMessage{}
+		for i := 0; i <= testConfig.BatchSize; i++ {
+			messages = append(messages, newTestMessage(fmt.Sprintf("message %d", i)))
+		}
+		storeMock := newStoreMock(messages...)
+		relay := newTestRelay(storeMock, map[string]Producer{testTopic: producerFunc(func(context.Context, []byte) error {
+			return nil
+		})})
+
+		Convey("When they are delivered", func() {
+			err := relay.Deliver(testContext)
+
+			Convey("Then only a batch of messages is claimed", func() {
+				So(err, ShouldBeNil)
+				So(storeMock.ClaimOutboxMessageCalls(), ShouldHaveLength, testConfig.BatchSize)
+				So(storeMock.UpdateOutboxMessageCalls(), ShouldHaveLength, testConfig.BatchSize)
+			})
+		})
+	})
+
+	Convey("Given the outbox messages cannot be claimed", t, func() {
+		storeMock := newStoreMock()
+		storeMock.ClaimOutboxMessageFunc = func(context.Context, string, time.Duration, []string) (*models.OutboxMessage, error) {
+			return nil, errors.New("store error")
+		}
+		relay := newTestRelay(storeMock, map[string]Producer{})
+
+		Convey("When they are delivered", func() {
+			err := relay.Deliver(testContext)
+
+			Convey("Then an error is returned", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "store error")
+			})
+		})
+	})
+}
+
+func TestRelayChecker(t *testing.T) {
+	Convey("Given an outbox relay", t, func() {
+		storeMock := newStoreMock()
+		relay, err := NewRelay(storeMock, map[string]Producer{}, testConfig)
+		So(err, ShouldBeNil)
+		state := healthcheck.NewCheckState("Outbox")
+
+		Convey("When messages are being delivered", func() {
+			err := relay.Checker(testContext, state)
+
+			Convey("Then the check is OK", func() {
+				So(err, ShouldBeNil)
+				So(state.Status(), ShouldEqual, healthcheck.StatusOK)
+				So(state.Message(), ShouldEqual, MsgHealthy)
+			})
+		})
+
+		Convey("When the last delivery failed", func() {
+			relay.setError(errSendTimeout)
+			err := relay.Checker(testContext, state)
+
+			Convey("Then the check is WARNING", func() {
+				So(err, ShouldBeNil)
+				So(state.Status(), ShouldEqual, healthcheck.StatusWarning)
+				So(state.Message(), ShouldContainSubstring, MsgDeliveryFailing)
+			})
+		})
+
+		Convey("When some messages could not be delivered", func() {
+			storeMock.CountOutboxMessagesFunc = func(context.Context, string) (int, error) {
+				return 2, nil
+			}
+			err := relay.Checker(testContext, state)
+
+			Convey("Then the check is WARNING", func() {
+				So(err, ShouldBeNil)
+				So(state.Status(), ShouldEqual, healthcheck.StatusWarning)
+				So(state.Message(), ShouldEqual, "2 outbox message(s) exceeded the maximum number of delivery attempts")
+				So(storeMock.CountOutboxMessagesCalls()[0].State, ShouldEqual, models.OutboxMessageFailed)
+			})
+		})
+	})
+}
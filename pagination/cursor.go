@@ -0,0 +1,44 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+var errInvalidCursor = errors.New("invalid cursor")
+
+// Cursor identifies the last item of a page by its sort keys, so that the next page can start right after it
+// regardless of the items that have been added or removed in the meantime. Clients only see it as an opaque string.
+type Cursor struct {
+	// ID is the unique key that the items are finally sorted by
+	ID string `json:"id"`
+	// Order and Time are the primary sort keys, for lists that are not only sorted by ID
+	Order *int       `json:"order,omitempty"`
+	Time  *time.Time `json:"time,omitempty"`
+}
+
+// Encode returns the opaque representation of the cursor
+func (c *Cursor) Encode() string {
+	b, err := json.Marshal(c)
+	if err != nil {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// DecodeCursor returns the cursor represented by the provided opaque string
+func DecodeCursor(s string) (*Cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, errInvalidCursor
+	}
+
+	cursor := &Cursor{}
+	if err := json.Unmarshal(b, cursor); err != nil || cursor.ID == "" {
+		return nil, errInvalidCursor
+	}
+
+	return cursor, nil
+}
@@ -13,12 +13,18 @@ import (
 // PaginatedHandler is a func type for an endpoint that returns a list of values that we want to paginate
 type PaginatedHandler func(w http.ResponseWriter, r *http.Request, limit int, offset int) (list interface{}, totalCount int, err error)
 
+// CursorPaginatedHandler is a func type for an endpoint that returns a list of values that can be paginated by offset or by cursor.
+// The cursor is only provided if it was requested, in which case the offset is zero. The returned nextCursor identifies the
+// last value of the list, and it is expected to be nil if there are no more values after it.
+type CursorPaginatedHandler func(w http.ResponseWriter, r *http.Request, limit, offset int, cursor *Cursor) (list interface{}, totalCount int, nextCursor *Cursor, err error)
+
 type page struct {
 	Items      interface{} `json:"items"`
 	Count      int         `json:"count"`
 	Offset     int         `json:"offset"`
 	Limit      int         `json:"limit"`
 	TotalCount int         `json:"total_count"`
+	NextCursor string      `json:"next_cursor,omitempty"`
 }
 
 type Paginator struct {
@@ -72,6 +78,30 @@ func (p *Paginator) getPaginationParameters(r *http.Request) (offset, limit int,
 	return offset, limit, err
 }
 
+// getCursor returns the cursor provided in the request query, if any. A cursor cannot be combined with an offset.
+func (p *Paginator) getCursor(r *http.Request) (*Cursor, error) {
+	cursorParameter := r.URL.Query().Get("cursor")
+	if cursorParameter == "" {
+		return nil, nil
+	}
+
+	logData := log.Data{"cursor": cursorParameter}
+
+	if r.URL.Query().Get("offset") != "" {
+		err := errors.New("invalid query parameter")
+		log.Error(r.Context(), "invalid query parameters: cursor cannot be combined with offset", err, logData)
+		return nil, err
+	}
+
+	cursor, err := DecodeCursor(cursorParameter)
+	if err != nil {
+		log.Error(r.Context(), "invalid query parameter: cursor", err, logData)
+		return nil, errors.New("invalid query parameter")
+	}
+
+	return cursor, nil
+}
+
 func renderPage(list interface{}, offset, limit, totalCount int) page {
 	return page{
 		Items:      list,
@@ -106,6 +136,38 @@ func (p *Paginator) Paginate(paginatedHandler PaginatedHandler) func(w http.Resp
 	}
 }
 
+// PaginateWithCursor wraps a http endpoint to return a paginated list from the list returned by the provided function,
+// which can be paginated by offset or by the cursor returned as next_cursor in a previous page
+func (p *Paginator) PaginateWithCursor(paginatedHandler CursorPaginatedHandler) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		offset, limit, err := p.getPaginationParameters(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		cursor, err := p.getCursor(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if cursor != nil {
+			offset = 0
+		}
+
+		list, totalCount, nextCursor, err := paginatedHandler(w, r, limit, offset, cursor)
+		if err != nil {
+			return
+		}
+
+		renderedPage := renderPage(list, offset, limit, totalCount)
+		if nextCursor != nil {
+			renderedPage.NextCursor = nextCursor.Encode()
+		}
+
+		returnPaginatedResults(w, r, renderedPage)
+	}
+}
+
 func returnPaginatedResults(w http.ResponseWriter, r *http.Request, list page) {
 	logData := log.Data{"path": r.URL.Path, "method": r.Method}
 
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -219,3 +220,112 @@ func TestPaginateFunctionReturnsListFuncImplementedHttpErrorIfListFuncReturnsAnE
 	assert.Equal(t, 500, w.Code)
 	assert.Equal(t, "internal error\n", string(content))
 }
+
+func TestGetCursorReturnsNilWhenNotProvided(t *testing.T) {
+	r := httptest.NewRequest("GET", "/test?offset=5", http.NoBody)
+	paginator := &Paginator{}
+
+	cursor, err := paginator.getCursor(r)
+
+	assert.Equal(t, nil, err)
+	assert.Nil(t, cursor)
+}
+
+func TestGetCursorReturnsDecodedCursorFromQuery(t *testing.T) {
+	expectedCursor := &Cursor{ID: "cpih01"}
+	r := httptest.NewRequest("GET", "/test?cursor="+expectedCursor.Encode(), http.NoBody)
+	paginator := &Paginator{}
+
+	cursor, err := paginator.getCursor(r)
+
+	assert.Equal(t, nil, err)
+	assert.Equal(t, expectedCursor, cursor)
+}
+
+func TestGetCursorReturnsErrorWhenCursorIsInvalid(t *testing.T) {
+	r := httptest.NewRequest("GET", "/test?cursor=not-a-cursor", http.NoBody)
+	paginator := &Paginator{}
+
+	cursor, err := paginator.getCursor(r)
+
+	assert.Equal(t, errors.New("invalid query parameter"), err)
+	assert.Nil(t, cursor)
+}
+
+func TestGetCursorReturnsErrorWhenCombinedWithOffset(t *testing.T) {
+	r := httptest.NewRequest("GET", "/test?offset=1&cursor="+(&Cursor{ID: "cpih01"}).Encode(), http.NoBody)
+	paginator := &Paginator{}
+
+	cursor, err := paginator.getCursor(r)
+
+	assert.Equal(t, errors.New("invalid query parameter"), err)
+	assert.Nil(t, cursor)
+}
+
+func TestCursorEncodeAndDecodeKeepsAllSortKeys(t *testing.T) {
+	order := 3
+	lastUpdated := time.Date(2024, 12, 31, 9, 30, 0, 0, time.UTC)
+	cursor := &Cursor{ID: "option", Order: &order, Time: &lastUpdated}
+
+	decodedCursor, err := DecodeCursor(cursor.Encode())
+
+	assert.Equal(t, nil, err)
+	assert.Equal(t, cursor.ID, decodedCursor.ID)
+	assert.Equal(t, order, *decodedCursor.Order)
+	assert.True(t, lastUpdated.Equal(*decodedCursor.Time))
+}
+
+func TestPaginateWithCursorFunctionPassesCursorDownAndReturnsNextCursor(t *testing.T) {
+	cursor := &Cursor{ID: "b"}
+	nextCursor := &Cursor{ID: "d"}
+	r := httptest.NewRequest("GET", "/test?limit=2&cursor="+cursor.Encode(), http.NoBody)
+	w := httptest.NewRecorder()
+
+	fetchListFunc := func(_ http.ResponseWriter, _ *http.Request, limit, offset int, c *Cursor) (interface{}, int, *Cursor, error) {
+		assert.Equal(t, 2, limit)
+		assert.Equal(t, 0, offset)
+		assert.Equal(t, cursor, c)
+		return []string{"c", "d"}, 10, nextCursor, nil
+	}
+
+	paginator := &Paginator{
+		DefaultLimit:    10,
+		DefaultOffset:   0,
+		DefaultMaxLimit: 100,
+	}
+
+	paginatedHandler := paginator.PaginateWithCursor(fetchListFunc)
+
+	expectedPage := page{
+		Items:      []string{"c", "d"},
+		Count:      2,
+		Offset:     0,
+		Limit:      2,
+		TotalCount: 10,
+		NextCursor: nextCursor.Encode(),
+	}
+
+	paginatedHandler(w, r)
+
+	content, _ := io.ReadAll(w.Body)
+	expectedContent, _ := json.Marshal(expectedPage)
+
+	assert.Equal(t, string(expectedContent), string(content))
+	assert.Equal(t, 200, w.Code)
+}
+
+func TestPaginateWithCursorFunctionReturnsBadRequestWhenCursorIsInvalid(t *testing.T) {
+	r := httptest.NewRequest("GET", "/test?cursor=not-a-cursor", http.NoBody)
+	w := httptest.NewRecorder()
+	fetchListFunc := func(http.ResponseWriter, *http.Request, int, int, *Cursor) (interface{}, int, *Cursor, error) {
+		return []int{}, 0, nil, nil
+	}
+
+	paginator := &Paginator{}
+	paginatedHandler := paginator.PaginateWithCursor(fetchListFunc)
+
+	paginatedHandler(w, r)
+	content, _ := io.ReadAll(w.Body)
+	assert.Equal(t, 400, w.Code)
+	assert.Equal(t, "invalid query parameter\n", string(content))
+}
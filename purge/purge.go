@@ -0,0 +1,141 @@
+package purge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ONSdigital/dp-dataset-api/models"
+	filesAPISDK "github.com/ONSdigital/dp-files-api/sdk"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// Store represents the datastore methods required to purge deleted resources
+type Store interface {
+	PurgeDeletedDatasets(ctx context.Context, before time.Time) (int, error)
+	GetDeletedStaticVersions(ctx context.Context, before time.Time, limit int) ([]*models.Version, error)
+	PurgeStaticVersion(ctx context.Context, id string) error
+}
+
+// Config contains the configuration of the purge Job
+type Config struct {
+	Interval         time.Duration
+	Retention        time.Duration
+	BatchSize        int
+	ServiceAuthToken string
+}
+
+// Job periodically and permanently removes the datasets, editions and static versions
+// that were deleted longer than the retention period ago, along with the files of the static versions
+type Job struct {
+	store          Store
+	filesAPIClient filesAPISDK.Clienter
+	cfg            Config
+	cancel         context.CancelFunc
+	done           chan struct{}
+}
+
+// NewJob creates a new purge Job
+func NewJob(purgeStore Store, filesAPIClient filesAPISDK.Clienter, cfg Config) *Job {
+	return &Job{
+		store:          purgeStore,
+		filesAPIClient: filesAPIClient,
+		cfg:            cfg,
+		done:           make(chan struct{}),
+	}
+}
+
+// Start purges the deleted resources at the configured interval, until the job is closed
+func (j *Job) Start(ctx context.Context) {
+	ctx, j.cancel = context.WithCancel(ctx)
+
+	go func() {
+		defer close(j.done)
+
+		ticker := time.NewTicker(j.cfg.Interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := j.Purge(ctx); err != nil {
+					log.Error(ctx, "failed to purge deleted resources", err)
+				}
+			}
+		}
+	}()
+}
+
+// Close stops the job, waiting for any purge in progress to finish or the context to be done
+func (j *Job) Close(ctx context.Context) error {
+	if j.cancel == nil {
+		return nil
+	}
+	j.cancel()
+
+	select {
+	case <-j.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Purge permanently removes a batch of static versions, and all the datasets and editions, that were deleted before the
+// retention period. A static version is only removed once the files of its distributions have been deleted, so that a
+// version whose files could not be deleted is purged by a later run. The returned error describes the last failure, if any.
+func (j *Job) Purge(ctx context.Context) error {
+	before := time.Now().UTC().Add(-j.cfg.Retention)
+	logData := log.Data{"deleted_before": before}
+
+	var purgeErr error
+
+	versions, err := j.store.GetDeletedStaticVersions(ctx, before, j.cfg.BatchSize)
+	if err != nil {
+		purgeErr = fmt.Errorf("failed to get deleted static versions: %w", err)
+	}
+
+	purgedVersions := 0
+	for _, version := range versions {
+		if err := j.purgeStaticVersion(ctx, version); err != nil {
+			purgeErr = fmt.Errorf("failed to purge static version %s: %w", version.ID, err)
+			log.Warn(ctx, "failed to purge static version", log.Data{"version_id": version.ID, "err": err.Error()})
+			continue
+		}
+		purgedVersions++
+	}
+	logData["purged_versions"] = purgedVersions
+
+	purgedDatasets, err := j.store.PurgeDeletedDatasets(ctx, before)
+	if err != nil {
+		purgeErr = fmt.Errorf("failed to purge deleted datasets: %w", err)
+	}
+	logData["purged_datasets_and_editions"] = purgedDatasets
+
+	if purgedVersions > 0 || purgedDatasets > 0 {
+		log.Info(ctx, "purged deleted resources", logData)
+	}
+
+	return purgeErr
+}
+
+// purgeStaticVersion deletes the files of the distributions of a deleted static version, then removes the version
+func (j *Job) purgeStaticVersion(ctx context.Context, version *models.Version) error {
+	if version.Distributions != nil {
+		for _, distribution := range *version.Distributions {
+			err := j.filesAPIClient.DeleteFile(ctx, distribution.DownloadURL, filesAPISDK.Headers{Authorization: j.cfg.ServiceAuthToken})
+
+			// a file that cannot be found has already been deleted by a previous run
+			var apiErr *filesAPISDK.APIError
+			if err != nil && !(errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound) {
+				return fmt.Errorf("failed to delete distribution file %s: %w", distribution.DownloadURL, err)
+			}
+		}
+	}
+
+	return j.store.PurgeStaticVersion(ctx, version.ID)
+}
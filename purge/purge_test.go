@@ -0,0 +1,134 @@
+package purge
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-dataset-api/models"
+	storetest "github.com/ONSdigital/dp-dataset-api/store/datastoretest"
+	filesAPISDK "github.com/ONSdigital/dp-files-api/sdk"
+	filesAPISDKMocks "github.com/ONSdigital/dp-files-api/sdk/mocks"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+var (
+	testContext = context.Background()
+	testConfig  = Config{Interval: time.Hour, Retention: 24 * time.Hour, BatchSize: 10, ServiceAuthToken: "service-token"}
+)
+
+func newStoreMock(versions ...*models.Version) *storetest.StorerMock {
+	return &storetest.StorerMock{
+		GetDeletedStaticVersionsFunc: func(context.Context, time.Time, int) ([]*models.Version, error) {
+			return versions, nil
+		},
+		PurgeStaticVersionFunc: func(context.Context, string) error {
+			return nil
+		},
+		PurgeDeletedDatasetsFunc: func(context.Context, time.Time) (int, error) {
+			return 2, nil
+		},
+	}
+}
+
+func newFilesAPIClientMock(err error) *filesAPISDKMocks.ClienterMock {
+	return &filesAPISDKMocks.ClienterMock{
+		DeleteFileFunc: func(context.Context, string, filesAPISDK.Headers) error {
+			return err
+		},
+	}
+}
+
+func newTestVersion(id string, downloadURLs ...string) *models.Version {
+	distributions := []models.Distribution{}
+	for _, url := range downloadURLs {
+		distributions = append(distributions, models.Distribution{DownloadURL: url})
+	}
+	return &models.Version{ID: id, Distributions: &distributions}
+}
+
+func TestPurge(t *testing.T) {
+	Convey("Given deleted static versions and datasets", t, func() {
+		storeMock := newStoreMock(newTestVersion("v1", "/a.csv", "/b.csv"), newTestVersion("v2"))
+
+		Convey("When they are purged", func() {
+			filesAPIClient := newFilesAPIClientMock(nil)
+			err := NewJob(storeMock, filesAPIClient, testConfig).Purge(testContext)
+
+			Convey("Then the resources deleted before the retention period are purged", func() {
+				So(err, ShouldBeNil)
+
+				before := storeMock.GetDeletedStaticVersionsCalls()[0].Before
+				So(before, ShouldHappenWithin, time.Minute, time.Now().Add(-testConfig.Retention))
+				So(storeMock.GetDeletedStaticVersionsCalls()[0].Limit, ShouldEqual, testConfig.BatchSize)
+				So(storeMock.PurgeDeletedDatasetsCalls()[0].Before, ShouldEqual, before)
+			})
+
+			Convey("And the files of the versions are deleted with the service auth token before the versions", func() {
+				So(filesAPIClient.DeleteFileCalls(), ShouldHaveLength, 2)
+				So(filesAPIClient.DeleteFileCalls()[0].FilePath, ShouldEqual, "/a.csv")
+				So(filesAPIClient.DeleteFileCalls()[0].Headers.Authorization, ShouldEqual, testConfig.ServiceAuthToken)
+
+				So(storeMock.PurgeStaticVersionCalls(), ShouldHaveLength, 2)
+				So(storeMock.PurgeStaticVersionCalls()[0].ID, ShouldEqual, "v1")
+				So(storeMock.PurgeStaticVersionCalls()[1].ID, ShouldEqual, "v2")
+			})
+		})
+
+		Convey("When their files have already been deleted", func() {
+			err := NewJob(storeMock, newFilesAPIClientMock(&filesAPISDK.APIError{StatusCode: http.StatusNotFound}), testConfig).Purge(testContext)
+
+			Convey("Then the versions are purged", func() {
+				So(err, ShouldBeNil)
+				So(storeMock.PurgeStaticVersionCalls(), ShouldHaveLength, 2)
+			})
+		})
+
+		Convey("When the files of a version cannot be deleted", func() {
+			err := NewJob(storeMock, newFilesAPIClientMock(&filesAPISDK.APIError{StatusCode: http.StatusInternalServerError}), testConfig).Purge(testContext)
+
+			Convey("Then the version is kept for a later run, and the other resources are purged", func() {
+				So(err, ShouldNotBeNil)
+				So(storeMock.PurgeStaticVersionCalls(), ShouldHaveLength, 1)
+				So(storeMock.PurgeStaticVersionCalls()[0].ID, ShouldEqual, "v2")
+				So(storeMock.PurgeDeletedDatasetsCalls(), ShouldHaveLength, 1)
+			})
+		})
+	})
+
+	Convey("Given the deleted static versions cannot be read", t, func() {
+		storeMock := newStoreMock()
+		storeMock.GetDeletedStaticVersionsFunc = func(context.Context, time.Time, int) ([]*models.Version, error) {
+			return nil, errors.New("mongo error")
+		}
+
+		Convey("When they are purged, then the error is returned and the datasets are still purged", func() {
+			err := NewJob(storeMock, newFilesAPIClientMock(nil), testConfig).Purge(testContext)
+			So(err, ShouldNotBeNil)
+			So(storeMock.PurgeDeletedDatasetsCalls(), ShouldHaveLength, 1)
+		})
+	})
+}
+
+func TestJobStartClose(t *testing.T) {
+	Convey("Given a started purge job", t, func() {
+		storeMock := newStoreMock()
+		job := NewJob(storeMock, newFilesAPIClientMock(nil), Config{Interval: 10 * time.Millisecond, BatchSize: 10})
+		job.Start(testContext)
+
+		Convey("Then it purges at the configured interval until it is closed", func() {
+			deadline := time.Now().Add(time.Second)
+			for len(storeMock.PurgeDeletedDatasetsCalls()) == 0 && time.Now().Before(deadline) {
+				time.Sleep(time.Millisecond)
+			}
+			So(storeMock.PurgeDeletedDatasetsCalls(), ShouldNotBeEmpty)
+			So(job.Close(testContext), ShouldBeNil)
+		})
+	})
+
+	Convey("A job that was not started can be closed", t, func() {
+		So(NewJob(newStoreMock(), nil, testConfig).Close(testContext), ShouldBeNil)
+	})
+}
@@ -0,0 +1,181 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	"github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// Store represents the datastore methods required to publish approved static versions at their release date
+type Store interface {
+	GetScheduledVersions(ctx context.Context, offset, limit int) ([]*models.Version, int, error)
+	LockScheduledPublishing(ctx context.Context) (lockID string, err error)
+	UnlockScheduledPublishing(ctx context.Context, lockID string)
+}
+
+// Publisher publishes a static version, with the same side effects as a request to publish it
+type Publisher interface {
+	PublishScheduledVersion(ctx context.Context, datasetID, edition string, version int) error
+}
+
+// Config contains the configuration of the scheduled publishing Job
+type Config struct {
+	Interval  time.Duration
+	BatchSize int
+}
+
+// Job periodically publishes the approved static versions whose release date has passed. Only the instance holding
+// the scheduled publishing lock publishes versions. The lock expires if a run takes longer than it is held for, so a
+// version is also only published if it is still approved: a version published by another instance is skipped.
+type Job struct {
+	store     Store
+	publisher Publisher
+	cfg       Config
+	cancel    context.CancelFunc
+	done      chan struct{}
+}
+
+// NewJob creates a new scheduled publishing Job
+func NewJob(schedulerStore Store, publisher Publisher, cfg Config) *Job {
+	return &Job{
+		store:     schedulerStore,
+		publisher: publisher,
+		cfg:       cfg,
+		done:      make(chan struct{}),
+	}
+}
+
+// Start publishes the versions that are due at the configured interval, until the job is closed
+func (j *Job) Start(ctx context.Context) {
+	ctx, j.cancel = context.WithCancel(ctx)
+
+	go func() {
+		defer close(j.done)
+
+		ticker := time.NewTicker(j.cfg.Interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := j.Run(ctx); err != nil {
+					log.Error(ctx, "failed to publish scheduled versions", err)
+				}
+			}
+		}
+	}()
+}
+
+// Close stops the job, waiting for any publishing in progress to finish or the context to be done
+func (j *Job) Close(ctx context.Context) error {
+	if j.cancel == nil {
+		return nil
+	}
+	j.cancel()
+
+	select {
+	case <-j.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Run publishes the approved static versions whose release date has passed, if no other instance is publishing them.
+// A version that fails to publish stays approved, so it is published by a later run. The returned error describes the
+// last failure, if any.
+func (j *Job) Run(ctx context.Context) error {
+	lockID, err := j.store.LockScheduledPublishing(ctx)
+	if err != nil {
+		log.Info(ctx, "scheduled versions are being published by another instance", log.Data{"err": err.Error()})
+		return nil
+	}
+	defer j.store.UnlockScheduledPublishing(ctx, lockID)
+
+	due, err := j.duePublishes(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get scheduled publishes: %w", err)
+	}
+
+	var runErr error
+	published := 0
+	for _, s := range due {
+		logData := log.Data{"dataset_id": s.DatasetID, "edition": s.Edition, "version": s.Version, "release_date": s.ReleaseDate}
+		err := j.publisher.PublishScheduledVersion(ctx, s.DatasetID, s.Edition, s.Version)
+		switch {
+		case errors.Is(err, errs.ErrExpectedResourceStateOfApproved):
+			log.Info(ctx, "scheduled version is no longer approved, so it has not been published", logData)
+			continue
+		case err != nil:
+			runErr = fmt.Errorf("failed to publish scheduled version %s: %w", s.VersionID, err)
+			logData["err"] = err.Error()
+			log.Warn(ctx, "failed to publish scheduled version", logData)
+			continue
+		}
+		log.Info(ctx, "published scheduled version", logData)
+		published++
+	}
+
+	if published > 0 {
+		log.Info(ctx, "published scheduled versions", log.Data{"published_versions": published})
+	}
+
+	return runErr
+}
+
+// duePublishes returns the scheduled publishes whose release date has passed, oldest first. All the scheduled versions
+// are read from the store in batches, as release dates in different formats or time zones are not stored in the order
+// they are released in.
+func (j *Job) duePublishes(ctx context.Context) ([]models.ScheduledPublish, error) {
+	due := []models.ScheduledPublish{}
+	now := time.Now().UTC()
+
+	for offset := 0; ; offset += j.cfg.BatchSize {
+		scheduled, totalCount, err := ScheduledPublishes(ctx, j.store, offset, j.cfg.BatchSize)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, s := range scheduled {
+			if s.ReleaseDate == nil {
+				log.Warn(ctx, "approved static version cannot be scheduled for publishing", log.Data{"version_id": s.VersionID, "err": s.Error})
+				continue
+			}
+			if !s.ReleaseDate.After(now) {
+				due = append(due, s)
+			}
+		}
+
+		if offset+j.cfg.BatchSize >= totalCount {
+			break
+		}
+	}
+
+	sort.SliceStable(due, func(i, k int) bool { return due[i].ReleaseDate.Before(*due[k].ReleaseDate) })
+	return due, nil
+}
+
+// ScheduledPublishes returns a page of the approved static versions that have a release date, ordered by their release
+// date as stored, along with the total number of them. Versions whose release date is not valid are returned with the
+// reason they cannot be scheduled, so that they can be published manually.
+func ScheduledPublishes(ctx context.Context, schedulerStore Store, offset, limit int) ([]models.ScheduledPublish, int, error) {
+	versions, totalCount, err := schedulerStore.GetScheduledVersions(ctx, offset, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	scheduled := make([]models.ScheduledPublish, 0, len(versions))
+	for _, version := range versions {
+		scheduled = append(scheduled, *models.NewScheduledPublish(version))
+	}
+
+	return scheduled, totalCount, nil
+}
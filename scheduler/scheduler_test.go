@@ -0,0 +1,191 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	"github.com/ONSdigital/dp-dataset-api/models"
+	storetest "github.com/ONSdigital/dp-dataset-api/store/datastoretest"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+var (
+	testContext = context.Background()
+	testConfig  = Config{Interval: time.Minute, BatchSize: 2}
+)
+
+type publisherMock struct {
+	published []string
+	err       error
+	// errs are the errors returned for particular datasets
+	errs map[string]error
+}
+
+func (p *publisherMock) PublishScheduledVersion(_ context.Context, datasetID, edition string, version int) error {
+	if p.err != nil {
+		return p.err
+	}
+	if err := p.errs[datasetID]; err != nil {
+		return err
+	}
+	p.published = append(p.published, datasetID+"/"+edition)
+	return nil
+}
+
+// newStoreMock returns a store of the provided scheduled versions, which must be ordered by release date as stored, as
+// they are by the store
+func newStoreMock(lockErr error, versions ...*models.Version) *storetest.StorerMock {
+	return &storetest.StorerMock{
+		GetScheduledVersionsFunc: func(_ context.Context, offset, limit int) ([]*models.Version, int, error) {
+			start := min(offset, len(versions))
+			end := min(offset+limit, len(versions))
+			return versions[start:end], len(versions), nil
+		},
+		LockScheduledPublishingFunc: func(context.Context) (string, error) {
+			return "lock-id", lockErr
+		},
+		UnlockScheduledPublishingFunc: func(context.Context, string) {},
+	}
+}
+
+func newTestVersion(datasetID, edition, releaseDate string) *models.Version {
+	return &models.Version{
+		ID:          datasetID + "-" + edition,
+		Edition:     edition,
+		Version:     1,
+		State:       models.ApprovedState,
+		ReleaseDate: releaseDate,
+		Links:       &models.VersionLinks{Dataset: &models.LinkObject{ID: datasetID}},
+	}
+}
+
+func TestRun(t *testing.T) {
+	past := time.Now().UTC().Add(-time.Hour).Format(time.RFC3339)
+	future := time.Now().UTC().Add(time.Hour).Format(time.RFC3339)
+
+	Convey("Given approved static versions released in the past and in the future", t, func() {
+		storeMock := newStoreMock(nil,
+			newTestVersion("yesterday", "2025", "2020-01-01"),
+			newTestVersion("invalid", "2025", "2020-13-01"),
+			newTestVersion("due", "2025", past),
+			newTestVersion("later", "2025", future),
+			newTestVersion("latest", "2025", future),
+		)
+		publisher := &publisherMock{}
+
+		Convey("When the job is run", func() {
+			err := NewJob(storeMock, publisher, testConfig).Run(testContext)
+
+			Convey("Then only the versions whose release date has passed are published, oldest first", func() {
+				So(err, ShouldBeNil)
+				So(publisher.published, ShouldResemble, []string{"yesterday/2025", "due/2025"})
+			})
+
+			Convey("Then all the versions are read in batches, and the lock is released", func() {
+				So(storeMock.GetScheduledVersionsCalls(), ShouldHaveLength, 3)
+				So(storeMock.GetScheduledVersionsCalls()[2].Offset, ShouldEqual, 4)
+				So(storeMock.UnlockScheduledPublishingCalls(), ShouldHaveLength, 1)
+				So(storeMock.UnlockScheduledPublishingCalls()[0].LockID, ShouldEqual, "lock-id")
+			})
+		})
+
+		Convey("When a version fails to publish", func() {
+			publisher.err = errors.New("files API unavailable")
+			err := NewJob(storeMock, publisher, testConfig).Run(testContext)
+
+			Convey("Then an error is returned", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "files API unavailable")
+			})
+		})
+
+		Convey("When a version has already been published by another instance", func() {
+			publisher.errs = map[string]error{"yesterday": errs.ErrExpectedResourceStateOfApproved}
+			err := NewJob(storeMock, publisher, testConfig).Run(testContext)
+
+			Convey("Then it is skipped without an error and the other versions are published", func() {
+				So(err, ShouldBeNil)
+				So(publisher.published, ShouldResemble, []string{"due/2025"})
+			})
+		})
+	})
+
+	Convey("Given approved static versions whose release dates are stored in different formats and time zones", t, func() {
+		now := time.Now().UTC()
+		storeMock := newStoreMock(nil,
+			// sorted as stored, a release date with an offset comes before a later one in UTC that is due first
+			newTestVersion("offset", "2025", now.Add(-time.Hour).In(time.FixedZone("BST", 3600)).Format(time.RFC3339)),
+			newTestVersion("utc", "2025", now.Add(-2*time.Hour).Format("2006-01-02T15:04:05.000Z07:00")),
+			newTestVersion("future", "2025", now.Add(time.Hour).Format(time.RFC3339)),
+			// sorted as stored, a release date in the future with an offset comes before a due one in UTC
+			newTestVersion("future-offset", "2025", now.Add(time.Hour).In(time.FixedZone("EST", -5*3600)).Format(time.RFC3339)),
+			newTestVersion("due", "2025", now.Add(-time.Minute).Format(time.RFC3339)),
+		)
+		publisher := &publisherMock{}
+
+		Convey("When the job is run", func() {
+			err := NewJob(storeMock, publisher, testConfig).Run(testContext)
+
+			Convey("Then all the versions whose release date has passed are published, in the order they were released", func() {
+				So(err, ShouldBeNil)
+				So(publisher.published, ShouldResemble, []string{"utc/2025", "offset/2025", "due/2025"})
+			})
+		})
+	})
+
+	Convey("Given another instance is publishing scheduled versions", t, func() {
+		storeMock := newStoreMock(errors.New("lock already held"), newTestVersion("due", "2025", past))
+		publisher := &publisherMock{}
+
+		Convey("When the job is run", func() {
+			err := NewJob(storeMock, publisher, testConfig).Run(testContext)
+
+			Convey("Then no versions are published", func() {
+				So(err, ShouldBeNil)
+				So(publisher.published, ShouldBeEmpty)
+				So(storeMock.GetScheduledVersionsCalls(), ShouldBeEmpty)
+				So(storeMock.UnlockScheduledPublishingCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestScheduledPublishes(t *testing.T) {
+	Convey("Given there are no approved static versions", t, func() {
+		storeMock := newStoreMock(nil)
+
+		Convey("When the scheduled publishes are listed", func() {
+			scheduled, totalCount, err := ScheduledPublishes(testContext, storeMock, 0, 10)
+
+			Convey("Then an empty list is returned", func() {
+				So(err, ShouldBeNil)
+				So(scheduled, ShouldBeEmpty)
+				So(totalCount, ShouldEqual, 0)
+			})
+		})
+	})
+
+	Convey("Given approved static versions with release dates", t, func() {
+		storeMock := newStoreMock(nil,
+			newTestVersion("first", "2025", "2025-01-01"),
+			newTestVersion("second", "2025", "2025-02-01T07:00:00.000Z"),
+			newTestVersion("third", "2025", "2025-03-01"),
+		)
+
+		Convey("When a page of the scheduled publishes is listed", func() {
+			scheduled, totalCount, err := ScheduledPublishes(testContext, storeMock, 1, 1)
+
+			Convey("Then the page is read from the store with its offset and limit", func() {
+				So(err, ShouldBeNil)
+				So(totalCount, ShouldEqual, 3)
+				So(scheduled, ShouldHaveLength, 1)
+				So(scheduled[0].DatasetID, ShouldEqual, "second")
+				So(storeMock.GetScheduledVersionsCalls()[0].Offset, ShouldEqual, 1)
+				So(storeMock.GetScheduledVersionsCalls()[0].Limit, ShouldEqual, 1)
+			})
+		})
+	})
+}
@@ -27,6 +27,22 @@ var generateCantabularDownloads = `{
   ]
 }`
 
+var auditEvent = `{
+  "type": "record",
+  "name": "dataset-audit-event",
+  "fields": [
+    {"name": "created_at",         "type": "string", "default": ""},
+    {"name": "requested_by_id",    "type": "string", "default": ""},
+    {"name": "requested_by_email", "type": "string", "default": ""},
+    {"name": "action",             "type": "string", "default": ""},
+    {"name": "resource",           "type": "string", "default": ""},
+    {"name": "sequence",           "type": "long",   "default": 0},
+    {"name": "previous_hash",      "type": "string", "default": ""},
+    {"name": "hash",               "type": "string", "default": ""},
+    {"name": "event",              "type": "string", "default": ""}
+  ]
+}`
+
 // GenerateCMDDownloadsEvent the Avro schema for FilterOutputSubmitted messages.
 var GenerateCMDDownloadsEvent = &avro.Schema{
 	Definition: generateCMDDownloads,
@@ -36,3 +52,9 @@ var GenerateCMDDownloadsEvent = &avro.Schema{
 var GenerateCantabularDownloadsEvent = &avro.Schema{
 	Definition: generateCantabularDownloads,
 }
+
+// AuditEvent the Avro schema for the audit events sent to kafka. The event field holds the JSON representation of the
+// full audit event, including the state of the resource and the fields changed.
+var AuditEvent = &avro.Schema{
+	Definition: auditEvent,
+}
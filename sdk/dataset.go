@@ -22,6 +22,7 @@ type DatasetsList struct {
 	Offset     int                    `json:"offset"`
 	Limit      int                    `json:"limit"`
 	TotalCount int                    `json:"total_count"`
+	NextCursor string                 `json:"next_cursor,omitempty"`
 }
 
 // DatasetsBatchProcessor is the type corresponding to a batch processing function for a dataset List.
@@ -292,7 +293,11 @@ func (c *Client) GetDatasets(ctx context.Context, headers Headers, q *QueryParam
 
 		// Add query parameters
 		query := url.Values{}
-		query.Add("offset", strconv.Itoa(q.Offset))
+		if q.Cursor != "" {
+			query.Add("cursor", q.Cursor)
+		} else {
+			query.Add("offset", strconv.Itoa(q.Offset))
+		}
 		query.Add("limit", strconv.Itoa(q.Limit))
 		if q.IsBasedOn != "" {
 			query.Add("is_based_on", q.IsBasedOn)
@@ -29,6 +29,7 @@ type QueryParams struct {
 	State     string
 	Limit     int
 	Offset    int
+	Cursor    string
 }
 
 // NewDatasetAPIResponse creates an error response, optionally adding body to e when status is 404
@@ -56,6 +57,9 @@ func (q *QueryParams) Validate() error {
 	if q.Limit < 0 || q.Offset < 0 {
 		return errors.New("negative offsets or limits are not allowed")
 	}
+	if q.Cursor != "" && q.Offset > 0 {
+		return errors.New("a cursor cannot be combined with an offset")
+	}
 	if len(q.IDs) > maxIDs {
 		return fmt.Errorf("too many query parameters have been provided. Maximum allowed: %d", maxIDs)
 	}
@@ -141,7 +145,8 @@ func (c *Client) GetVersionDimensions(ctx context.Context, headers Headers, data
 
 // VersionDimensionOptionsList represent a list of PublicDimensionOption
 type VersionDimensionOptionsList struct {
-	Items []models.PublicDimensionOption
+	Items      []models.PublicDimensionOption
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
 func (m VersionDimensionOptionsList) ToString() string {
@@ -183,7 +188,11 @@ func (c *Client) GetVersionDimensionOptions(ctx context.Context, headers Headers
 		// Add query parameters
 		query := uri.Query()
 		query.Set("limit", strconv.Itoa(queryParams.Limit))
-		query.Set("offset", strconv.Itoa(queryParams.Offset))
+		if queryParams.Cursor != "" {
+			query.Set("cursor", queryParams.Cursor)
+		} else {
+			query.Set("offset", strconv.Itoa(queryParams.Offset))
+		}
 		uri.RawQuery = query.Encode()
 	}
 
@@ -62,6 +62,13 @@ func TestQueryParamsValidate(t *testing.T) {
 			So(result, ShouldNotBeNil)
 			So(result.Error(), ShouldEqual, "negative offsets or limits are not allowed")
 		})
+		Convey("Test `Validate()` method raises error if `Cursor` is combined with `Offset`", func() {
+			// Update `queryParams` to add a `Cursor` to the positive `Offset`
+			queryParams.Cursor = "eyJpZCI6ImluY29tZSJ9"
+			result := queryParams.Validate()
+			So(result, ShouldNotBeNil)
+			So(result.Error(), ShouldEqual, "a cursor cannot be combined with an offset")
+		})
 		Convey("Test `Validate()` method raises error if `IDs` is too long", func() {
 			// Update `queryParams` to make `IDs` longer than `maxIDs` constant
 			iDsArray := make([]string, maxIDs+1)
@@ -290,6 +297,19 @@ func TestGetVersionDimensionOptions(t *testing.T) {
 			So(httpClient.DoCalls()[0].Req.URL.RequestURI(), ShouldResemble, expectedURI)
 		})
 	})
+	Convey("If input query params contain a cursor", t, func() {
+		httpClient := createHTTPClientMock(MockedHTTPResponse{http.StatusOK, nil, map[string]string{}})
+		datasetAPIClient := newDatasetAPIHealthcheckClient(t, httpClient)
+		queryParams := QueryParams{
+			Limit:  1,
+			Cursor: "eyJpZCI6Im9wMSJ9",
+		}
+		datasetAPIClient.GetVersionDimensionOptions(ctx, headers, datasetID, editionID, versionID, dimensionID, &queryParams)
+		Convey("Test that the request URI contains the cursor instead of the offset", func() {
+			expectedURI := fmt.Sprintf("/datasets/%s/editions/%s/versions/%s/dimensions/%s/options?cursor=eyJpZCI6Im9wMSJ9&limit=1", datasetID, editionID, versionID, dimensionID)
+			So(httpClient.DoCalls()[0].Req.URL.RequestURI(), ShouldResemble, expectedURI)
+		})
+	})
 	Convey("If requested dataset and edition is valid", t, func() {
 		requestedVersionDimensionOptions := VersionDimensionOptionsList{
 			Items: dimensionOptions,
@@ -8,7 +8,9 @@ import (
 	"github.com/ONSdigital/dp-dataset-api/cloudflare"
 	"github.com/ONSdigital/dp-dataset-api/config"
 	"github.com/ONSdigital/dp-dataset-api/mongo"
+	"github.com/ONSdigital/dp-dataset-api/outbox"
 	"github.com/ONSdigital/dp-dataset-api/store"
+	"github.com/ONSdigital/dp-dataset-api/store/memory"
 	filesAPISDK "github.com/ONSdigital/dp-files-api/sdk"
 	"github.com/ONSdigital/dp-graph/v2/graph"
 	"github.com/ONSdigital/dp-healthcheck/healthcheck"
@@ -55,14 +57,14 @@ func (e *ExternalServiceList) GetHealthCheck(cfg *config.Configuration, buildTim
 	return hc, nil
 }
 
-// GetProducer returns a kafka producer, which might not be initialised yet.
-func (e *ExternalServiceList) GetProducer(ctx context.Context, cfg *config.Configuration, topic string) (kafkaProducer kafka.IProducer, err error) {
-	kafkaProducer, err = e.Init.DoGetKafkaProducer(ctx, cfg, topic)
+// GetOutboxProducer returns a kafka producer used to deliver outbox messages, which connects to kafka when it is first used
+func (e *ExternalServiceList) GetOutboxProducer(ctx context.Context, cfg *config.Configuration, topic string) (OutboxProducer, error) {
+	producer, err := e.Init.DoGetOutboxProducer(ctx, cfg, topic)
 	if err != nil {
-		return
+		return nil, err
 	}
 	e.KafkaProducer = true
-	return
+	return producer, nil
 }
 
 // GetGraphDB returns a graphDB (only if observation and private endpoint are enabled)
@@ -133,8 +135,13 @@ func (e *Init) DoGetHealthCheck(cfg *config.Configuration, buildTime, gitCommit,
 	return &hc, nil
 }
 
-// DoGetKafkaProducer creates a new Kafka Producer
-func (e *Init) DoGetKafkaProducer(ctx context.Context, cfg *config.Configuration, topic string) (kafka.IProducer, error) {
+// DoGetOutboxProducer creates a new Kafka Producer that waits for kafka to acknowledge each outbox message
+func (e *Init) DoGetOutboxProducer(_ context.Context, cfg *config.Configuration, topic string) (OutboxProducer, error) {
+	return outbox.NewKafkaProducer(kafkaProducerConfig(cfg, topic)), nil
+}
+
+// kafkaProducerConfig returns the configuration of a kafka producer for the provided topic
+func kafkaProducerConfig(cfg *config.Configuration, topic string) *kafka.ProducerConfig {
 	pConfig := &kafka.ProducerConfig{
 		KafkaVersion:      &cfg.KafkaVersion,
 		Topic:             topic,
@@ -151,7 +158,7 @@ func (e *Init) DoGetKafkaProducer(ctx context.Context, cfg *config.Configuration
 		)
 	}
 
-	return kafka.NewProducer(ctx, pConfig)
+	return pConfig
 }
 
 // DoGetGraphDB creates a new GraphDB
@@ -168,6 +175,11 @@ func (e *Init) DoGetGraphDB(ctx context.Context) (store.GraphDB, Closer, error)
 
 // DoGetMongoDB returns a MongoDB
 func (e *Init) DoGetMongoDB(ctx context.Context, cfg config.MongoConfig) (store.MongoDB, error) {
+	if cfg.Datastore == config.MemoryDatastore {
+		log.Info(ctx, "using in-memory datastore")
+		return memory.New(cfg), nil
+	}
+
 	mongodb := &mongo.Mongo{
 		MongoConfig: cfg,
 	}
@@ -11,7 +11,6 @@ import (
 	"github.com/ONSdigital/dp-dataset-api/service"
 	"github.com/ONSdigital/dp-dataset-api/store"
 	filesAPISDK "github.com/ONSdigital/dp-files-api/sdk"
-	kafka "github.com/ONSdigital/dp-kafka/v4"
 	"net/http"
 	"sync"
 )
@@ -44,12 +43,12 @@ var _ service.Initialiser = &InitialiserMock{}
 //			DoGetHealthCheckFunc: func(cfg *config.Configuration, buildTime string, gitCommit string, version string) (service.HealthChecker, error) {
 //				panic("mock out the DoGetHealthCheck method")
 //			},
-//			DoGetKafkaProducerFunc: func(ctx context.Context, cfg *config.Configuration, topic string) (kafka.IProducer, error) {
-//				panic("mock out the DoGetKafkaProducer method")
-//			},
 //			DoGetMongoDBFunc: func(ctx context.Context, cfg config.MongoConfig) (store.MongoDB, error) {
 //				panic("mock out the DoGetMongoDB method")
 //			},
+//			DoGetOutboxProducerFunc: func(ctx context.Context, cfg *config.Configuration, topic string) (service.OutboxProducer, error) {
+//				panic("mock out the DoGetOutboxProducer method")
+//			},
 //		}
 //
 //		// use mockedInitialiser in code that requires service.Initialiser
@@ -75,12 +74,12 @@ type InitialiserMock struct {
 	// DoGetHealthCheckFunc mocks the DoGetHealthCheck method.
 	DoGetHealthCheckFunc func(cfg *config.Configuration, buildTime string, gitCommit string, version string) (service.HealthChecker, error)
 
-	// DoGetKafkaProducerFunc mocks the DoGetKafkaProducer method.
-	DoGetKafkaProducerFunc func(ctx context.Context, cfg *config.Configuration, topic string) (kafka.IProducer, error)
-
 	// DoGetMongoDBFunc mocks the DoGetMongoDB method.
 	DoGetMongoDBFunc func(ctx context.Context, cfg config.MongoConfig) (store.MongoDB, error)
 
+	// DoGetOutboxProducerFunc mocks the DoGetOutboxProducer method.
+	DoGetOutboxProducerFunc func(ctx context.Context, cfg *config.Configuration, topic string) (service.OutboxProducer, error)
+
 	// calls tracks calls to the methods.
 	calls struct {
 		// DoGetAuthorisationMiddleware holds details about calls to the DoGetAuthorisationMiddleware method.
@@ -127,21 +126,21 @@ type InitialiserMock struct {
 			// Version is the version argument value.
 			Version string
 		}
-		// DoGetKafkaProducer holds details about calls to the DoGetKafkaProducer method.
-		DoGetKafkaProducer []struct {
+		// DoGetMongoDB holds details about calls to the DoGetMongoDB method.
+		DoGetMongoDB []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 			// Cfg is the cfg argument value.
-			Cfg *config.Configuration
-			// Topic is the topic argument value.
-			Topic string
+			Cfg config.MongoConfig
 		}
-		// DoGetMongoDB holds details about calls to the DoGetMongoDB method.
-		DoGetMongoDB []struct {
+		// DoGetOutboxProducer holds details about calls to the DoGetOutboxProducer method.
+		DoGetOutboxProducer []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 			// Cfg is the cfg argument value.
-			Cfg config.MongoConfig
+			Cfg *config.Configuration
+			// Topic is the topic argument value.
+			Topic string
 		}
 	}
 	lockDoGetAuthorisationMiddleware sync.RWMutex
@@ -150,8 +149,8 @@ type InitialiserMock struct {
 	lockDoGetGraphDB                 sync.RWMutex
 	lockDoGetHTTPServer              sync.RWMutex
 	lockDoGetHealthCheck             sync.RWMutex
-	lockDoGetKafkaProducer           sync.RWMutex
 	lockDoGetMongoDB                 sync.RWMutex
+	lockDoGetOutboxProducer          sync.RWMutex
 }
 
 // DoGetAuthorisationMiddleware calls DoGetAuthorisationMiddlewareFunc.
@@ -374,46 +373,6 @@ func (mock *InitialiserMock) DoGetHealthCheckCalls() []struct {
 	return calls
 }
 
-// DoGetKafkaProducer calls DoGetKafkaProducerFunc.
-func (mock *InitialiserMock) DoGetKafkaProducer(ctx context.Context, cfg *config.Configuration, topic string) (kafka.IProducer, error) {
-	if mock.DoGetKafkaProducerFunc == nil {
-		panic("InitialiserMock.DoGetKafkaProducerFunc: method is nil but Initialiser.DoGetKafkaProducer was just called")
-	}
-	callInfo := struct {
-		Ctx   context.Context
-		Cfg   *config.Configuration
-		Topic string
-	}{
-		Ctx:   ctx,
-		Cfg:   cfg,
-		Topic: topic,
-	}
-	mock.lockDoGetKafkaProducer.Lock()
-	mock.calls.DoGetKafkaProducer = append(mock.calls.DoGetKafkaProducer, callInfo)
-	mock.lockDoGetKafkaProducer.Unlock()
-	return mock.DoGetKafkaProducerFunc(ctx, cfg, topic)
-}
-
-// DoGetKafkaProducerCalls gets all the calls that were made to DoGetKafkaProducer.
-// Check the length with:
-//
-//	len(mockedInitialiser.DoGetKafkaProducerCalls())
-func (mock *InitialiserMock) DoGetKafkaProducerCalls() []struct {
-	Ctx   context.Context
-	Cfg   *config.Configuration
-	Topic string
-} {
-	var calls []struct {
-		Ctx   context.Context
-		Cfg   *config.Configuration
-		Topic string
-	}
-	mock.lockDoGetKafkaProducer.RLock()
-	calls = mock.calls.DoGetKafkaProducer
-	mock.lockDoGetKafkaProducer.RUnlock()
-	return calls
-}
-
 // DoGetMongoDB calls DoGetMongoDBFunc.
 func (mock *InitialiserMock) DoGetMongoDB(ctx context.Context, cfg config.MongoConfig) (store.MongoDB, error) {
 	if mock.DoGetMongoDBFunc == nil {
@@ -449,3 +408,43 @@ func (mock *InitialiserMock) DoGetMongoDBCalls() []struct {
 	mock.lockDoGetMongoDB.RUnlock()
 	return calls
 }
+
+// DoGetOutboxProducer calls DoGetOutboxProducerFunc.
+func (mock *InitialiserMock) DoGetOutboxProducer(ctx context.Context, cfg *config.Configuration, topic string) (service.OutboxProducer, error) {
+	if mock.DoGetOutboxProducerFunc == nil {
+		panic("InitialiserMock.DoGetOutboxProducerFunc: method is nil but Initialiser.DoGetOutboxProducer was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Cfg   *config.Configuration
+		Topic string
+	}{
+		Ctx:   ctx,
+		Cfg:   cfg,
+		Topic: topic,
+	}
+	mock.lockDoGetOutboxProducer.Lock()
+	mock.calls.DoGetOutboxProducer = append(mock.calls.DoGetOutboxProducer, callInfo)
+	mock.lockDoGetOutboxProducer.Unlock()
+	return mock.DoGetOutboxProducerFunc(ctx, cfg, topic)
+}
+
+// DoGetOutboxProducerCalls gets all the calls that were made to DoGetOutboxProducer.
+// Check the length with:
+//
+//	len(mockedInitialiser.DoGetOutboxProducerCalls())
+func (mock *InitialiserMock) DoGetOutboxProducerCalls() []struct {
+	Ctx   context.Context
+	Cfg   *config.Configuration
+	Topic string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Cfg   *config.Configuration
+		Topic string
+	}
+	mock.lockDoGetOutboxProducer.RLock()
+	calls = mock.calls.DoGetOutboxProducer
+	mock.lockDoGetOutboxProducer.RUnlock()
+	return calls
+}
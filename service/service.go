@@ -16,14 +16,16 @@ import (
 	"github.com/ONSdigital/dp-dataset-api/cloudflare"
 	"github.com/ONSdigital/dp-dataset-api/config"
 	"github.com/ONSdigital/dp-dataset-api/download"
-	adapter "github.com/ONSdigital/dp-dataset-api/kafka"
+	"github.com/ONSdigital/dp-dataset-api/migrations"
 	"github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/dp-dataset-api/outbox"
+	"github.com/ONSdigital/dp-dataset-api/purge"
+	"github.com/ONSdigital/dp-dataset-api/scheduler"
 	"github.com/ONSdigital/dp-dataset-api/schema"
 	"github.com/ONSdigital/dp-dataset-api/store"
 	storetest "github.com/ONSdigital/dp-dataset-api/store/datastoretest"
 	"github.com/ONSdigital/dp-dataset-api/url"
 	filesAPISDK "github.com/ONSdigital/dp-files-api/sdk"
-	kafka "github.com/ONSdigital/dp-kafka/v4"
 	dphandlers "github.com/ONSdigital/dp-net/v3/handlers"
 	"github.com/ONSdigital/log.go/v2/log"
 	"github.com/gorilla/mux"
@@ -49,9 +51,14 @@ type Service struct {
 	graphDB                             store.GraphDB
 	graphDBErrorConsumer                Closer
 	mongoDB                             store.MongoDB
-	generateCMDDownloadsProducer        kafka.IProducer
-	generateCantabularDownloadsProducer kafka.IProducer
-	searchContentUpdatedKafkaProducer   kafka.IProducer
+	generateCMDDownloadsProducer        OutboxProducer
+	generateCantabularDownloadsProducer OutboxProducer
+	searchContentUpdatedKafkaProducer   OutboxProducer
+	searchContentDeletedKafkaProducer   OutboxProducer
+	auditEventsKafkaProducer            OutboxProducer
+	outboxRelay                         *outbox.Relay
+	purgeJob                            *purge.Job
+	schedulerJob                        *scheduler.Job
 	cloudflareClient                    cloudflare.Clienter
 	identityClient                      *clientsidentity.Client
 	filesAPIClient                      filesAPISDK.Clienter
@@ -64,119 +71,28 @@ type Service struct {
 }
 
 var stateMachine *application.StateMachine
+var stateMachineErr error
 var stateMachineInit sync.Once
 
-func GetListTransitions() []application.Transition {
-	publishedTransition := application.Transition{
-		Label:               "published",
-		TargetState:         application.Published,
-		AllowedSourceStates: []string{"created", "associated", "published"},
-		Type:                "v4",
-	}
-
-	associatedTransition := application.Transition{
-		Label:               "associated",
-		TargetState:         application.Associated,
-		AllowedSourceStates: []string{"edition-confirmed", "associated"},
-		Type:                "v4",
-	}
-
-	edconfirmedTransition := application.Transition{
-		Label:               "edition-confirmed",
-		TargetState:         application.EditionConfirmed,
-		AllowedSourceStates: []string{"completed", "edition-confirmed"},
-		Type:                "v4",
-	}
-
-	return []application.Transition{publishedTransition,
-		associatedTransition, edconfirmedTransition}
-}
-
-func GetListStaticTransitions() []application.Transition {
-	publishedTransition := application.Transition{
-		Label:               "published",
-		TargetState:         application.Published,
-		AllowedSourceStates: []string{"approved"},
-		Type:                "static",
-	}
-
-	associatedTransition := application.Transition{
-		Label:               "associated",
-		TargetState:         application.Associated,
-		AllowedSourceStates: []string{"created", "associated"},
-		Type:                "static",
-	}
-
-	approvedTransition := application.Transition{
-		Label:               "approved",
-		TargetState:         application.Approved,
-		AllowedSourceStates: []string{"associated"},
-		Type:                "static",
-	}
-
-	return []application.Transition{publishedTransition,
-		associatedTransition, approvedTransition}
-}
-
-func GetListCantabularTransitions() []application.Transition {
-	publishedTransition := application.Transition{
-		Label:               "published",
-		TargetState:         application.Published,
-		AllowedSourceStates: []string{"published", "associated", "edition-confirmed"},
-		Type:                "cantabular_flexible_table",
-	}
-
-	associatedTransition := application.Transition{
-		Label:               "associated",
-		TargetState:         application.Associated,
-		AllowedSourceStates: []string{"edition-confirmed", "associated"},
-		Type:                "cantabular_flexible_table",
-	}
-
-	edconfirmedTransition := application.Transition{
-		Label:               "edition-confirmed",
-		TargetState:         application.EditionConfirmed,
-		AllowedSourceStates: []string{"completed", "edition-confirmed"},
-		Type:                "cantabular_flexible_table",
-	}
-
-	return []application.Transition{publishedTransition,
-		associatedTransition, edconfirmedTransition}
-}
-
-func GetListMultivariateCantabularTransitions() []application.Transition {
-	publishedTransition := application.Transition{
-		Label:               "published",
-		TargetState:         application.Published,
-		AllowedSourceStates: []string{"associated", "edition-confirmed", "published"},
-		Type:                "cantabular_multivariate_table",
-	}
-
-	associatedTransition := application.Transition{
-		Label:               "associated",
-		TargetState:         application.Associated,
-		AllowedSourceStates: []string{"edition-confirmed", "associated"},
-		Type:                "cantabular_multivariate_table",
-	}
-
-	edconfirmedTransition := application.Transition{
-		Label:               "edition-confirmed",
-		TargetState:         application.EditionConfirmed,
-		AllowedSourceStates: []string{"completed", "edition-confirmed"},
-		Type:                "cantabular_multivariate_table",
-	}
-
-	return []application.Transition{publishedTransition,
-		associatedTransition, edconfirmedTransition}
-}
-
-func GetStateMachine(ctx context.Context, dataStore store.DataStore) *application.StateMachine {
+// GetStateMachine returns the state machine for the workflows in the provided file, or the default workflows if
+// workflowsFile is empty. The workflows are loaded and validated once, and an error is returned if they are invalid.
+func GetStateMachine(ctx context.Context, dataStore store.DataStore, workflowsFile string) (*application.StateMachine, error) {
 	stateMachineInit.Do(func() {
-		states := []application.State{application.Published, application.EditionConfirmed, application.Associated}
-		transitions := slices.Concat(GetListTransitions(), GetListCantabularTransitions(), GetListMultivariateCantabularTransitions(), GetListStaticTransitions())
+		transitions, err := application.LoadWorkflows(workflowsFile)
+		if err != nil {
+			stateMachineErr = err
+			return
+		}
+
+		var states []application.State
+		for _, transition := range transitions {
+			if !slices.ContainsFunc(states, func(state application.State) bool { return state.Name == transition.TargetState.Name }) {
+				states = append(states, transition.TargetState)
+			}
+		}
 		stateMachine = application.NewStateMachine(ctx, states, transitions, dataStore)
 	})
-	return stateMachine
+	return stateMachine, stateMachineErr
 }
 
 // New creates a new service
@@ -199,10 +115,20 @@ func (svc *Service) SetHealthCheck(healthCheck HealthChecker) {
 }
 
 // SetDownloadsProducer sets the downloads kafka producer for a service
-func (svc *Service) SetDownloadsProducer(producer kafka.IProducer) {
+func (svc *Service) SetDownloadsProducer(producer OutboxProducer) {
 	svc.generateCMDDownloadsProducer = producer
 }
 
+// SetOutboxRelay sets the outbox relay for a service
+func (svc *Service) SetOutboxRelay(relay *outbox.Relay) {
+	svc.outboxRelay = relay
+}
+
+// SetPurgeJob sets the job that purges deleted resources for a service
+func (svc *Service) SetPurgeJob(job *purge.Job) {
+	svc.purgeJob = job
+}
+
 // SetMongoDB sets the mongoDB connection for a service
 func (svc *Service) SetMongoDB(mongoDB store.MongoDB) {
 	svc.mongoDB = mongoDB
@@ -230,6 +156,10 @@ func (svc *Service) Run(ctx context.Context, buildTime, gitCommit, version strin
 		return err
 	}
 
+	if err := svc.runMigrations(ctx); err != nil {
+		return err
+	}
+
 	if err := svc.initGraphDB(ctx); err != nil {
 		return err
 	}
@@ -250,36 +180,80 @@ func (svc *Service) Run(ctx context.Context, buildTime, gitCommit, version strin
 			"EnablePrivateEndpoints": svc.config.EnablePrivateEndpoints,
 		})
 	} else {
-		svc.generateCMDDownloadsProducer, err = svc.serviceList.GetProducer(ctx, svc.config, svc.config.GenerateDownloadsTopic)
+		// kafka messages are written to the outbox along with the changes that caused them, and delivered by the relay
+		// through producers that wait for kafka to acknowledge each message
+		svc.generateCMDDownloadsProducer, err = svc.serviceList.GetOutboxProducer(ctx, svc.config, svc.config.GenerateDownloadsTopic)
 		if err != nil {
 			log.Fatal(ctx, "could not obtain generate downloads producer for CMD", err)
 			return err
 		}
-		svc.generateCantabularDownloadsProducer, err = svc.serviceList.GetProducer(ctx, svc.config, svc.config.CantabularExportStartTopic)
+		svc.generateCantabularDownloadsProducer, err = svc.serviceList.GetOutboxProducer(ctx, svc.config, svc.config.CantabularExportStartTopic)
 		if err != nil {
 			log.Fatal(ctx, "could not obtain generate downloads producer for cantabular", err)
 			return err
 		}
-		svc.searchContentUpdatedKafkaProducer, err = svc.serviceList.GetProducer(ctx, svc.config, svc.config.SearchContentUpdatedTopic)
+		svc.searchContentUpdatedKafkaProducer, err = svc.serviceList.GetOutboxProducer(ctx, svc.config, svc.config.SearchContentUpdatedTopic)
 		if err != nil {
 			log.Fatal(ctx, "could not obtain search content updated producer", err)
 			return err
 		}
+		svc.searchContentDeletedKafkaProducer, err = svc.serviceList.GetOutboxProducer(ctx, svc.config, svc.config.SearchContentDeletedTopic)
+		if err != nil {
+			log.Fatal(ctx, "could not obtain search content deleted producer", err)
+			return err
+		}
+
+		producers := map[string]outbox.Producer{
+			svc.config.GenerateDownloadsTopic:     svc.generateCMDDownloadsProducer,
+			svc.config.CantabularExportStartTopic: svc.generateCantabularDownloadsProducer,
+			svc.config.SearchContentUpdatedTopic:  svc.searchContentUpdatedKafkaProducer,
+			svc.config.SearchContentDeletedTopic:  svc.searchContentDeletedKafkaProducer,
+		}
+
+		if svc.config.EnableAuditEventsKafka {
+			svc.auditEventsKafkaProducer, err = svc.serviceList.GetOutboxProducer(ctx, svc.config, svc.config.AuditEventsTopic)
+			if err != nil {
+				log.Fatal(ctx, "could not obtain audit events producer", err)
+				return err
+			}
+			producers[svc.config.AuditEventsTopic] = svc.auditEventsKafkaProducer
+		}
+
+		svc.outboxRelay, err = outbox.NewRelay(ds.Backend, producers, outbox.Config{
+			Interval:         svc.config.OutboxRelayInterval,
+			BatchSize:        svc.config.OutboxRelayBatchSize,
+			SendTimeout:      svc.config.OutboxSendTimeout,
+			MaxAttempts:      svc.config.OutboxMaxAttempts,
+			Lease:            svc.config.OutboxLeaseDuration,
+			FailedRetryDelay: svc.config.OutboxFailedRetryDelay,
+			SentRetention:    svc.config.OutboxSentRetention,
+		})
+		if err != nil {
+			log.Fatal(ctx, "could not create outbox relay", err)
+			return err
+		}
+
+		// deleted resources are kept for the retention period so that they can be restored, then purged along with their files
+		svc.purgeJob = purge.NewJob(ds.Backend, svc.filesAPIClient, purge.Config{
+			Interval:         svc.config.PurgeInterval,
+			Retention:        svc.config.DeletedRetentionPeriod,
+			BatchSize:        svc.config.PurgeBatchSize,
+			ServiceAuthToken: svc.config.ServiceAuthToken,
+		})
 	}
 
 	downloadGeneratorCantabular := &download.CantabularGenerator{
-		Producer:   adapter.NewProducerAdapter(svc.generateCantabularDownloadsProducer),
+		Outbox:     outbox.NewWriter(ds.Backend, svc.config.CantabularExportStartTopic),
 		Marshaller: schema.GenerateCantabularDownloadsEvent,
 	}
 
 	downloadGeneratorCMD := &download.CMDGenerator{
-		Producer:   adapter.NewProducerAdapter(svc.generateCMDDownloadsProducer),
+		Outbox:     outbox.NewWriter(ds.Backend, svc.config.GenerateDownloadsTopic),
 		Marshaller: schema.GenerateCMDDownloadsEvent,
 	}
 
-	searchContentUpdatedProducer := &api.SearchContentUpdatedProducer{
-		Producer: adapter.NewProducerAdapter(svc.searchContentUpdatedKafkaProducer),
-	}
+	searchContentUpdatedOutbox := outbox.NewWriter(ds.Backend, svc.config.SearchContentUpdatedTopic)
+	searchContentDeletedOutbox := outbox.NewWriter(ds.Backend, svc.config.SearchContentDeletedTopic)
 
 	downloadGenerators := map[models.DatasetType]api.DownloadsGenerator{
 		models.CantabularBlob:              downloadGeneratorCantabular,
@@ -350,19 +324,27 @@ func (svc *Service) Run(ctx context.Context, buildTime, gitCommit, version strin
 		log.Info(ctx, "URL rewriting enabled")
 	}
 
-	// Log kafka producer errors in parallel go-routine
+	// Deliver the outbox messages and purge deleted resources in parallel go-routines
 	if svc.config.EnablePrivateEndpoints {
-		svc.generateCMDDownloadsProducer.LogErrors(ctx)
-		svc.generateCantabularDownloadsProducer.LogErrors(ctx)
-		svc.searchContentUpdatedKafkaProducer.LogErrors(ctx)
+		svc.outboxRelay.Start(ctx)
+		svc.purgeJob.Start(ctx)
 	}
 
-	sm := GetStateMachine(ctx, ds)
-	svc.smDS = application.Setup(ds, smDownloadGenerators, sm)
+	sm, err := GetStateMachine(ctx, ds, svc.config.WorkflowsFile)
+	if err != nil {
+		log.Fatal(ctx, "failed to load the state machine workflows", err, log.Data{"workflows_file": svc.config.WorkflowsFile})
+		return err
+	}
+	svc.smDS = application.Setup(ds, smDownloadGenerators, searchContentUpdatedOutbox, searchContentDeletedOutbox, sm)
 
+	// audit events are only sent to kafka when there is a producer to deliver them from the outbox
 	auditService := application.NewAuditService(ds)
+	if svc.auditEventsKafkaProducer != nil {
+		log.Info(ctx, "audit events will be sent to kafka", log.Data{"topic": svc.config.AuditEventsTopic})
+		auditService = application.NewKafkaAuditService(ds, outbox.NewWriter(ds.Backend, svc.config.AuditEventsTopic), schema.AuditEvent)
+	}
 
-	svc.api = api.Setup(ctx, svc.config, r, ds, urlBuilder, downloadGenerators, authorisation, enableURLRewriting, svc.smDS, auditService, permissionChecker, svc.identityClient, searchContentUpdatedProducer, svc.cloudflareClient)
+	svc.api = api.Setup(ctx, svc.config, r, ds, urlBuilder, downloadGenerators, authorisation, enableURLRewriting, svc.smDS, auditService, permissionChecker, svc.identityClient, svc.cloudflareClient)
 
 	// Set the files API client on the DatasetAPI after initialisation
 	if svc.config.EnablePrivateEndpoints && svc.filesAPIClient != nil {
@@ -370,6 +352,15 @@ func (svc *Service) Run(ctx context.Context, buildTime, gitCommit, version strin
 		log.Info(ctx, "files API client set on dataset API")
 	}
 
+	// approved static versions are published at their release date by the instance holding the scheduled publishing lock
+	if svc.config.EnablePrivateEndpoints && svc.config.EnableScheduledPublishing {
+		svc.schedulerJob = scheduler.NewJob(ds.Backend, svc.api, scheduler.Config{
+			Interval:  svc.config.ScheduledPublishingInterval,
+			BatchSize: svc.config.ScheduledPublishingBatchSize,
+		})
+		svc.schedulerJob.Start(ctx)
+	}
+
 	svc.healthCheck.Start(ctx)
 
 	// Run the http server in a new go-routine
@@ -391,6 +382,35 @@ func (svc *Service) initMongoDB(ctx context.Context) error {
 	return err
 }
 
+func (svc *Service) runMigrations(ctx context.Context) error {
+	if !svc.config.MigrateOnStartup {
+		log.Info(ctx, "skipping schema migrations, because they are disabled", log.Data{
+			"MigrateOnStartup": svc.config.MigrateOnStartup,
+		})
+		return nil
+	}
+
+	db, ok := svc.mongoDB.(migrations.Database)
+	if !ok {
+		log.Info(ctx, "skipping schema migrations, because they are not supported by the datastore", log.Data{
+			"Datastore": svc.config.Datastore,
+		})
+		return nil
+	}
+
+	migrator, err := migrations.New(db, migrations.All())
+	if err != nil {
+		log.Error(ctx, "invalid schema migrations", err)
+		return err
+	}
+
+	if _, err := migrator.Run(ctx, false); err != nil {
+		log.Error(ctx, "failed to run schema migrations", err)
+		return err
+	}
+	return nil
+}
+
 func (svc *Service) initGraphDB(ctx context.Context) error {
 	var err error
 	if !svc.config.EnablePrivateEndpoints || svc.config.DisableGraphDBDependency {
@@ -509,6 +529,30 @@ func (svc *Service) Close(ctx context.Context) error {
 			hasShutdownError = true
 		}
 
+		// Stop publishing scheduled versions before closing the datastore and the kafka producers used by the outbox relay
+		if svc.schedulerJob != nil {
+			if err := svc.schedulerJob.Close(shutdownContext); err != nil {
+				log.Error(shutdownContext, "failed to close scheduled publishing job", err)
+				hasShutdownError = true
+			}
+		}
+
+		// Stop purging deleted resources before closing the datastore
+		if svc.purgeJob != nil {
+			if err := svc.purgeJob.Close(shutdownContext); err != nil {
+				log.Error(shutdownContext, "failed to close purge job", err)
+				hasShutdownError = true
+			}
+		}
+
+		// Stop delivering outbox messages before closing the datastore and kafka producers used by the relay
+		if svc.outboxRelay != nil {
+			if err := svc.outboxRelay.Close(shutdownContext); err != nil {
+				log.Error(shutdownContext, "failed to close outbox relay", err)
+				hasShutdownError = true
+			}
+		}
+
 		// Close MongoDB (if it exists)
 		if svc.serviceList.MongoDB {
 			if err := svc.mongoDB.Close(shutdownContext); err != nil {
@@ -517,14 +561,15 @@ func (svc *Service) Close(ctx context.Context) error {
 			}
 		}
 
-		// Close KafkaProducer (if it exists)
+		// Close the kafka producers (if they exist), as each of them has its own connections to kafka
 		if svc.serviceList.KafkaProducer {
-			log.Info(shutdownContext, "closing generated downloads kafka producer", log.Data{"producer": "DimensionExtracted"})
-			// Only one kafka producer needs to be shutdown as they share the same underlying connections
-			if err := svc.generateCMDDownloadsProducer.Close(shutdownContext); err != nil {
-				log.Warn(shutdownContext, "error while closing generated downloads kafka producer", log.Data{"producer": "DimensionExtracted", "err": err.Error()})
+			for _, producer := range svc.kafkaProducers() {
+				log.Info(shutdownContext, "closing kafka producer", log.Data{"producer": producer.name})
+				if err := producer.Close(shutdownContext); err != nil {
+					log.Warn(shutdownContext, "error while closing kafka producer", log.Data{"producer": producer.name, "err": err.Error()})
+				}
+				log.Info(shutdownContext, "closed kafka producer", log.Data{"producer": producer.name})
 			}
-			log.Info(shutdownContext, "closed generated downloads kafka producer", log.Data{"producer": "DimensionExtracted"})
 		}
 
 		// Close GraphDB (if it exists)
@@ -561,6 +606,24 @@ func (svc *Service) Close(ctx context.Context) error {
 	return nil
 }
 
+// namedProducer is a kafka producer of the service along with the name it is logged with
+type namedProducer struct {
+	OutboxProducer
+	name string
+}
+
+// kafkaProducers returns the kafka producers that have been created for the service
+func (svc *Service) kafkaProducers() []namedProducer {
+	producers := []namedProducer{
+		{svc.generateCMDDownloadsProducer, "GenerateDownloads"},
+		{svc.generateCantabularDownloadsProducer, "CantabularExportStart"},
+		{svc.searchContentUpdatedKafkaProducer, "SearchContentUpdated"},
+		{svc.searchContentDeletedKafkaProducer, "SearchContentDeleted"},
+		{svc.auditEventsKafkaProducer, "AuditEvents"},
+	}
+	return slices.DeleteFunc(producers, func(producer namedProducer) bool { return producer.OutboxProducer == nil })
+}
+
 // registerCheckers adds the checkers for the provided clients to the health check object
 func (svc *Service) registerCheckers(ctx context.Context) (err error) {
 	hasErrors := false
@@ -588,6 +651,23 @@ func (svc *Service) registerCheckers(ctx context.Context) (err error) {
 			log.Error(ctx, "error adding check for search content updated kafka producer", err)
 		}
 
+		if err = svc.healthCheck.AddCheck("Kafka Search Content Deleted Producer", svc.searchContentDeletedKafkaProducer.Checker); err != nil {
+			hasErrors = true
+			log.Error(ctx, "error adding check for search content deleted kafka producer", err)
+		}
+
+		if svc.auditEventsKafkaProducer != nil {
+			if err = svc.healthCheck.AddCheck("Kafka Audit Events Producer", svc.auditEventsKafkaProducer.Checker); err != nil {
+				hasErrors = true
+				log.Error(ctx, "error adding check for audit events kafka producer", err)
+			}
+		}
+
+		if err = svc.healthCheck.AddCheck("Outbox", svc.outboxRelay.Checker); err != nil {
+			hasErrors = true
+			log.Error(ctx, "error adding check for outbox", err)
+		}
+
 		if err = svc.healthCheck.AddCheck("Files API Client", svc.filesAPIClient.Checker); err != nil {
 			hasErrors = true
 			log.Error(ctx, "error adding check for files api client", err)
@@ -6,13 +6,17 @@ import (
 	"net/http"
 	"sync"
 	"testing"
+	"time"
 
 	authorisationMock "github.com/ONSdigital/dp-authorisation/v2/authorisation/mock"
 
 	"github.com/ONSdigital/dp-authorisation/v2/authorisation"
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
 	"github.com/ONSdigital/dp-dataset-api/cloudflare"
 	cloudflareMocks "github.com/ONSdigital/dp-dataset-api/cloudflare/mocks"
 	"github.com/ONSdigital/dp-dataset-api/config"
+	"github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/dp-dataset-api/outbox"
 	"github.com/ONSdigital/dp-dataset-api/service"
 	serviceMock "github.com/ONSdigital/dp-dataset-api/service/mock"
 	"github.com/ONSdigital/dp-dataset-api/store"
@@ -20,8 +24,6 @@ import (
 	filesAPISDK "github.com/ONSdigital/dp-files-api/sdk"
 	filesAPISDKMocks "github.com/ONSdigital/dp-files-api/sdk/mocks"
 	"github.com/ONSdigital/dp-healthcheck/healthcheck"
-	kafka "github.com/ONSdigital/dp-kafka/v4"
-	"github.com/ONSdigital/dp-kafka/v4/kafkatest"
 	"github.com/pkg/errors"
 	. "github.com/smartystreets/goconvey/convey"
 )
@@ -59,7 +61,7 @@ var funcDoGetCloudflareClientErr = func(context.Context, *cloudflare.Config) (cl
 	return nil, errCloudflareClient
 }
 
-var funcDoGetKafkaProducerErr = func(context.Context, *config.Configuration, string) (kafka.IProducer, error) {
+var funcDoGetOutboxProducerErr = func(context.Context, *config.Configuration, string) (service.OutboxProducer, error) {
 	return nil, errKafka
 }
 
@@ -123,7 +125,11 @@ func TestRun(t *testing.T) {
 		}
 
 		funcDoGetMongoDBOk := func(context.Context, config.MongoConfig) (store.MongoDB, error) {
-			return &storeMock.MongoDBMock{}, nil
+			return &storeMock.MongoDBMock{
+				ClaimOutboxMessageFunc: func(context.Context, string, time.Duration, []string) (*models.OutboxMessage, error) {
+					return nil, errs.ErrOutboxMessageNotFound
+				},
+			}, nil
 		}
 
 		funcDoGetGraphDBOk := func(context.Context) (store.GraphDB, service.Closer, error) {
@@ -141,13 +147,16 @@ func TestRun(t *testing.T) {
 			return &cloudflareMocks.ClienterMock{}, nil
 		}
 
-		funcDoGetKafkaProducerOk := func(context.Context, *config.Configuration, string) (kafka.IProducer, error) {
-			return &kafkatest.IProducerMock{
-				ChannelsFunc: func() *kafka.ProducerChannels {
-					return &kafka.ProducerChannels{}
+		funcDoGetOutboxProducerOk := func(context.Context, *config.Configuration, string) (service.OutboxProducer, error) {
+			return &serviceMock.OutboxProducerMock{
+				SendMessageFunc: func(context.Context, []byte) error {
+					return nil
 				},
-				LogErrorsFunc: func(context.Context) {
-					// Do nothing
+				CheckerFunc: func(context.Context, *healthcheck.CheckState) error {
+					return nil
+				},
+				CloseFunc: func(context.Context) error {
+					return nil
 				},
 			}, nil
 		}
@@ -244,7 +253,7 @@ func TestRun(t *testing.T) {
 				DoGetGraphDBFunc:          funcDoGetGraphDBOk,
 				DoGetFilesAPIClientFunc:   funcDoGetFilesAPIClientOk,
 				DoGetCloudflareClientFunc: funcDoGetCloudflareClientOk,
-				DoGetKafkaProducerFunc:    funcDoGetKafkaProducerErr,
+				DoGetOutboxProducerFunc:   funcDoGetOutboxProducerErr,
 			}
 			svcErrors := make(chan error, 1)
 			svcList := service.NewServiceList(initMock)
@@ -268,7 +277,7 @@ func TestRun(t *testing.T) {
 				DoGetGraphDBFunc:                 funcDoGetGraphDBOk,
 				DoGetFilesAPIClientFunc:          funcDoGetFilesAPIClientOk,
 				DoGetCloudflareClientFunc:        funcDoGetCloudflareClientOk,
-				DoGetKafkaProducerFunc:           funcDoGetKafkaProducerOk,
+				DoGetOutboxProducerFunc:          funcDoGetOutboxProducerOk,
 				DoGetHealthCheckFunc:             funcDoGetHealthcheckErr,
 				DoGetAuthorisationMiddlewareFunc: funcDoGetAuthOk,
 			}
@@ -300,7 +309,7 @@ func TestRun(t *testing.T) {
 				DoGetGraphDBFunc:          funcDoGetGraphDBOk,
 				DoGetFilesAPIClientFunc:   funcDoGetFilesAPIClientOk,
 				DoGetCloudflareClientFunc: funcDoGetCloudflareClientOk,
-				DoGetKafkaProducerFunc:    funcDoGetKafkaProducerOk,
+				DoGetOutboxProducerFunc:   funcDoGetOutboxProducerOk,
 				DoGetHealthCheckFunc: func(*config.Configuration, string, string, string) (service.HealthChecker, error) {
 					return hcMockAddFail, nil
 				},
@@ -320,14 +329,16 @@ func TestRun(t *testing.T) {
 				So(svcList.FilesAPIClient, ShouldBeTrue)
 				So(svcList.CloudflareClient, ShouldBeTrue)
 				So(svcList.HealthCheck, ShouldBeTrue)
-				So(len(hcMockAddFail.AddCheckCalls()), ShouldEqual, 7)
+				So(len(hcMockAddFail.AddCheckCalls()), ShouldEqual, 9)
 				So(hcMockAddFail.AddCheckCalls()[0].Name, ShouldResemble, "Zebedee")
 				So(hcMockAddFail.AddCheckCalls()[1].Name, ShouldResemble, "Kafka Generate Downloads Producer")
 				So(hcMockAddFail.AddCheckCalls()[2].Name, ShouldResemble, "Kafka Generate Cantabular Downloads Producer")
 				So(hcMockAddFail.AddCheckCalls()[3].Name, ShouldResemble, "Kafka Search Content Updated Producer")
-				So(hcMockAddFail.AddCheckCalls()[4].Name, ShouldResemble, "Files API Client")
-				So(hcMockAddFail.AddCheckCalls()[5].Name, ShouldResemble, "Graph DB")
-				So(hcMockAddFail.AddCheckCalls()[6].Name, ShouldResemble, "Mongo DB")
+				So(hcMockAddFail.AddCheckCalls()[4].Name, ShouldResemble, "Kafka Search Content Deleted Producer")
+				So(hcMockAddFail.AddCheckCalls()[5].Name, ShouldResemble, "Outbox")
+				So(hcMockAddFail.AddCheckCalls()[6].Name, ShouldResemble, "Files API Client")
+				So(hcMockAddFail.AddCheckCalls()[7].Name, ShouldResemble, "Graph DB")
+				So(hcMockAddFail.AddCheckCalls()[8].Name, ShouldResemble, "Mongo DB")
 			})
 		})
 
@@ -337,7 +348,7 @@ func TestRun(t *testing.T) {
 				DoGetGraphDBFunc:                 funcDoGetGraphDBOk,
 				DoGetFilesAPIClientFunc:          funcDoGetFilesAPIClientOk,
 				DoGetCloudflareClientFunc:        funcDoGetCloudflareClientOk,
-				DoGetKafkaProducerFunc:           funcDoGetKafkaProducerOk,
+				DoGetOutboxProducerFunc:          funcDoGetOutboxProducerOk,
 				DoGetHealthCheckFunc:             funcDoGetHealthcheckOk,
 				DoGetHTTPServerFunc:              funcDoGetHTTPServer,
 				DoGetAuthorisationMiddlewareFunc: funcDoGetAuthOk,
@@ -359,14 +370,16 @@ func TestRun(t *testing.T) {
 			})
 
 			Convey("The checkers are registered and the healthcheck and http server started", func() {
-				So(len(hcMock.AddCheckCalls()), ShouldEqual, 7)
+				So(len(hcMock.AddCheckCalls()), ShouldEqual, 9)
 				So(hcMock.AddCheckCalls()[0].Name, ShouldResemble, "Zebedee")
 				So(hcMock.AddCheckCalls()[1].Name, ShouldResemble, "Kafka Generate Downloads Producer")
 				So(hcMock.AddCheckCalls()[2].Name, ShouldResemble, "Kafka Generate Cantabular Downloads Producer")
 				So(hcMock.AddCheckCalls()[3].Name, ShouldResemble, "Kafka Search Content Updated Producer")
-				So(hcMock.AddCheckCalls()[4].Name, ShouldResemble, "Files API Client")
-				So(hcMock.AddCheckCalls()[5].Name, ShouldResemble, "Graph DB")
-				So(hcMock.AddCheckCalls()[6].Name, ShouldResemble, "Mongo DB")
+				So(hcMock.AddCheckCalls()[4].Name, ShouldResemble, "Kafka Search Content Deleted Producer")
+				So(hcMock.AddCheckCalls()[5].Name, ShouldResemble, "Outbox")
+				So(hcMock.AddCheckCalls()[6].Name, ShouldResemble, "Files API Client")
+				So(hcMock.AddCheckCalls()[7].Name, ShouldResemble, "Graph DB")
+				So(hcMock.AddCheckCalls()[8].Name, ShouldResemble, "Mongo DB")
 				So(len(initMock.DoGetHTTPServerCalls()), ShouldEqual, 1)
 				So(initMock.DoGetHTTPServerCalls()[0].BindAddr, ShouldEqual, ":22000")
 				So(len(hcMock.StartCalls()), ShouldEqual, 1)
@@ -376,11 +389,43 @@ func TestRun(t *testing.T) {
 			})
 		})
 
+		Convey("Given that all dependencies are successfully initialised, and audit events are sent to kafka", func() {
+			cfg.EnableAuditEventsKafka = true
+			Reset(func() {
+				cfg.EnableAuditEventsKafka = false
+			})
+			initMock := &serviceMock.InitialiserMock{
+				DoGetMongoDBFunc:                 funcDoGetMongoDBOk,
+				DoGetGraphDBFunc:                 funcDoGetGraphDBOk,
+				DoGetFilesAPIClientFunc:          funcDoGetFilesAPIClientOk,
+				DoGetCloudflareClientFunc:        funcDoGetCloudflareClientOk,
+				DoGetOutboxProducerFunc:          funcDoGetOutboxProducerOk,
+				DoGetHealthCheckFunc:             funcDoGetHealthcheckOk,
+				DoGetHTTPServerFunc:              funcDoGetHTTPServer,
+				DoGetAuthorisationMiddlewareFunc: funcDoGetAuthOk,
+			}
+			svcErrors := make(chan error, 1)
+			svcList := service.NewServiceList(initMock)
+			svc := service.New(cfg, svcList)
+			serverWg.Add(1)
+			err := svc.Run(ctx, testBuildTime, testGitCommit, testVersion, svcErrors)
+
+			Convey("Then a kafka producer is obtained for the audit events topic, and its checker is registered", func() {
+				So(err, ShouldBeNil)
+				So(initMock.DoGetOutboxProducerCalls(), ShouldHaveLength, 5)
+				So(initMock.DoGetOutboxProducerCalls()[4].Topic, ShouldEqual, "dataset-audit-events")
+				So(len(hcMock.AddCheckCalls()), ShouldEqual, 10)
+				So(hcMock.AddCheckCalls()[5].Name, ShouldResemble, "Kafka Audit Events Producer")
+				So(hcMock.AddCheckCalls()[6].Name, ShouldResemble, "Outbox")
+				serverWg.Wait() // Wait for HTTP server go-routine to finish
+			})
+		})
+
 		Convey("Given that all dependencies are successfully initialised, private endpoints are disabled", func() {
 			cfg.EnablePrivateEndpoints = false
 			initMock := &serviceMock.InitialiserMock{
 				DoGetMongoDBFunc:          funcDoGetMongoDBOk,
-				DoGetKafkaProducerFunc:    funcDoGetKafkaProducerOk,
+				DoGetOutboxProducerFunc:   funcDoGetOutboxProducerOk,
 				DoGetCloudflareClientFunc: funcDoGetCloudflareClientOk,
 				DoGetHealthCheckFunc:      funcDoGetHealthcheckOk,
 				DoGetHTTPServerFunc:       funcDoGetHTTPServer,
@@ -418,7 +463,7 @@ func TestRun(t *testing.T) {
 				DoGetGraphDBFunc:                 funcDoGetGraphDBOk,
 				DoGetFilesAPIClientFunc:          funcDoGetFilesAPIClientOk,
 				DoGetCloudflareClientFunc:        funcDoGetCloudflareClientOk,
-				DoGetKafkaProducerFunc:           funcDoGetKafkaProducerOk,
+				DoGetOutboxProducerFunc:          funcDoGetOutboxProducerOk,
 				DoGetHealthCheckFunc:             funcDoGetHealthcheckOk,
 				DoGetHTTPServerFunc:              funcDoGetFailingHTTPServer,
 				DoGetAuthorisationMiddlewareFunc: funcDoGetAuthOk,
@@ -491,14 +536,8 @@ func TestClose(t *testing.T) {
 		}
 
 		// Kafka producer will fail if healthcheck or http server are not stopped
-		kafkaProducerMock := &kafkatest.IProducerMock{
-			ChannelsFunc: func() *kafka.ProducerChannels {
-				return &kafka.ProducerChannels{}
-			},
+		kafkaProducerMock := &serviceMock.OutboxProducerMock{
 			CloseFunc: funcClose,
-			LogErrorsFunc: func(context.Context) {
-				// Do nothing
-			},
 		}
 
 		Convey("Closing a service does not close uninitialised dependencies", func() {
@@ -523,10 +562,15 @@ func TestClose(t *testing.T) {
 		}
 
 		Convey("Closing the service results in all the initialised dependencies being closed in the expected order", func() {
+			relay, err := outbox.NewRelay(mongoMock, map[string]outbox.Producer{}, outbox.Config{Interval: time.Hour})
+			So(err, ShouldBeNil)
+			relay.Start(ctx)
+
 			svc := service.New(cfg, fullSvcList)
 			svc.SetServer(serverMock)
 			svc.SetHealthCheck(hcMock)
 			svc.SetDownloadsProducer(kafkaProducerMock)
+			svc.SetOutboxRelay(relay)
 			svc.SetMongoDB(mongoMock)
 			svc.SetGraphDB(graphMock)
 			svc.SetGraphDBErrorConsumer(graphErrorConsumerMock)
@@ -2,8 +2,10 @@ package store
 
 import (
 	"context"
+	"time"
 
 	"github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/dp-dataset-api/pagination"
 	"github.com/ONSdigital/dp-healthcheck/healthcheck"
 	"go.mongodb.org/mongo-driver/bson"
 )
@@ -30,26 +32,31 @@ type dataMongoDB interface {
 	CheckEditionTitleExistsStatic(ctx context.Context, datasetID, editionTitle string) error
 	CheckVersionExistsStatic(ctx context.Context, datasetID, editionID string, version int) (bool, error)
 	GetDataset(ctx context.Context, ID string) (*models.DatasetUpdate, error)
-	GetDatasets(ctx context.Context, offset, limit int, authorised bool) ([]*models.DatasetUpdate, int, error)
-	GetDatasetsByQueryParams(ctx context.Context, ID, datasetType, sortOrder, datasetID string, offset, limit int, authorised bool) ([]*models.DatasetUpdate, int, error)
+	GetDatasets(ctx context.Context, offset, limit int, cursor *pagination.Cursor, authorised bool) ([]*models.DatasetUpdate, int, *pagination.Cursor, error)
+	GetDatasetsByCollectionID(ctx context.Context, collectionID string, states []string) ([]*models.DatasetUpdate, error)
+	GetDatasetsByQueryParams(ctx context.Context, ID, datasetType, sortOrder, datasetID string, offset, limit int, cursor *pagination.Cursor, authorised bool) ([]*models.DatasetUpdate, int, *pagination.Cursor, error)
 	GetDatasetType(ctx context.Context, datasetID string, authorised bool) (string, error)
 	GetDimensionsFromInstance(ctx context.Context, ID string, offset, limit int) ([]*models.DimensionOption, int, error)
 	GetDimensions(ctx context.Context, versionID string) ([]bson.M, error)
-	GetDimensionOptions(ctx context.Context, version *models.Version, dimension string, offset, limit int) ([]*models.PublicDimensionOption, int, error)
+	GetDimensionOptions(ctx context.Context, version *models.Version, dimension string, offset, limit int, cursor *pagination.Cursor) ([]*models.PublicDimensionOption, int, *pagination.Cursor, error)
 	GetDimensionOptionsFromIDs(ctx context.Context, version *models.Version, dimension string, ids []string) ([]*models.PublicDimensionOption, int, error)
 	GetEdition(ctx context.Context, ID, editionID, state string) (*models.EditionUpdate, error)
 	GetEditions(ctx context.Context, ID, state string, offset, limit int, authorised bool) ([]*models.EditionUpdate, int, error)
 	GetStaticVersionsByState(ctx context.Context, state, publishedOnly string, offset, limit int) ([]*models.Version, int, error)
 	GetAllStaticVersions(ctx context.Context, ID, state string, offset, limit int) ([]*models.Version, int, error)
-	GetInstances(ctx context.Context, states []string, datasets []string, offset, limit int) ([]*models.Instance, int, error)
+	GetScheduledVersions(ctx context.Context, offset, limit int) ([]*models.Version, int, error)
+	GetInstances(ctx context.Context, states []string, datasets []string, offset, limit int, cursor *pagination.Cursor) ([]*models.Instance, int, *pagination.Cursor, error)
 	GetInstance(ctx context.Context, ID, eTagSelector string) (*models.Instance, error)
 	GetNextVersion(ctx context.Context, datasetID, editionID string) (int, error)
+	GetNextVersionStatic(ctx context.Context, datasetID, editionID string) (int, error)
 	GetVersion(ctx context.Context, datasetID, editionID string, version int, state string) (*models.Version, error)
 	GetVersionStatic(ctx context.Context, datasetID, editionID string, version int, state string) (*models.Version, error)
+	GetLatestVersion(ctx context.Context, datasetID, editionID string, state string) (*models.Version, error)
 	GetLatestVersionStatic(ctx context.Context, datasetID, editionID string, state string) (*models.Version, error)
 	GetUniqueDimensionAndOptions(ctx context.Context, ID, dimension string) ([]*string, int, error)
 	GetVersions(ctx context.Context, datasetID, editionID, state string, offset, limit int) ([]models.Version, int, error)
 	GetVersionsStatic(ctx context.Context, datasetID, edition, state string, offset, limit int) ([]models.Version, int, error)
+	GetVersionsByCollectionID(ctx context.Context, collectionID string, states []string) ([]*models.Version, error)
 	UpdateDataset(ctx context.Context, ID string, dataset *models.Dataset, currentState string) error
 	UpdateDatasetWithAssociation(ctx context.Context, ID, state string, version *models.Version) error
 	UpdateDimensionsNodeIDAndOrder(ctx context.Context, updates []*models.DimensionOption) error
@@ -67,16 +74,35 @@ type dataMongoDB interface {
 	UpsertEdition(ctx context.Context, datasetID, edition string, editionDoc *models.EditionUpdate) error
 	UpsertVersion(ctx context.Context, ID string, versionDoc *models.Version) error
 	UpsertVersionStatic(ctx context.Context, versionDoc *models.Version) error
-	DeleteDataset(ctx context.Context, ID string) error
-	DeleteEdition(ctx context.Context, ID string) error
+	DeleteDataset(ctx context.Context, ID string, deletion *models.Deletion) error
+	DeleteEdition(ctx context.Context, ID string, deletion *models.Deletion) error
+	RestoreDataset(ctx context.Context, ID string) error
+	RestoreEdition(ctx context.Context, datasetID, edition string) error
+	RestoreStaticVersion(ctx context.Context, datasetID, edition string, version int) error
+	PurgeDeletedDatasets(ctx context.Context, before time.Time) (int, error)
+	GetDeletedStaticVersions(ctx context.Context, before time.Time, limit int) ([]*models.Version, error)
+	PurgeStaticVersion(ctx context.Context, ID string) error
+	GetDatasetRevisions(ctx context.Context, datasetID string, offset, limit int) ([]*models.DatasetRevision, int, error)
+	GetDatasetRevision(ctx context.Context, datasetID string, revision int) (*models.DatasetRevision, error)
 	AcquireInstanceLock(ctx context.Context, instanceID string) (lockID string, err error)
 	UnlockInstance(ctx context.Context, lockID string)
 	AcquireVersionsLock(ctx context.Context, versionID string) (lockID string, err error)
 	UnlockVersions(ctx context.Context, lockID string)
+	LockScheduledPublishing(ctx context.Context) (lockID string, err error)
+	UnlockScheduledPublishing(ctx context.Context, lockID string)
 	RemoveDatasetVersionAndEditionLinks(ctx context.Context, id string) error
-	DeleteStaticDatasetVersion(ctx context.Context, datasetID, editionID string, version int) error
+	DeleteStaticDatasetVersion(ctx context.Context, datasetID, editionID string, version int, deletion *models.Deletion) error
 	IsStaticDataset(ctx context.Context, datasetID string) (bool, error)
 	CreateAuditEvent(ctx context.Context, event *models.AuditEvent) error
+	GetAuditEvents(ctx context.Context, filter *models.AuditEventFilter, offset, limit int) ([]*models.AuditEvent, int, error)
+	GetAuditEventChain(ctx context.Context, fromSequence int64, limit int) ([]*models.AuditEvent, error)
+	AddOutboxMessage(ctx context.Context, message *models.OutboxMessage) error
+	ClaimOutboxMessage(ctx context.Context, owner string, lease time.Duration, skipTopics []string) (*models.OutboxMessage, error)
+	UpdateOutboxMessage(ctx context.Context, message *models.OutboxMessage) error
+	CountOutboxMessages(ctx context.Context, state string) (int, error)
+	RequeueFailedOutboxMessages(ctx context.Context, now time.Time) (int, error)
+	PurgeSentOutboxMessages(ctx context.Context, before time.Time) (int, error)
+	RunTransaction(ctx context.Context, fn func(ctx context.Context) error) error
 }
 
 // MongoDB represents all the required methods from mongo DB
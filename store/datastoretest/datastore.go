@@ -6,9 +6,11 @@ package storetest
 import (
 	"context"
 	"github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/dp-dataset-api/pagination"
 	"github.com/ONSdigital/dp-dataset-api/store"
 	"go.mongodb.org/mongo-driver/bson"
 	"sync"
+	"time"
 )
 
 // Ensure, that StorerMock does implement store.Storer.
@@ -33,6 +35,9 @@ var _ store.Storer = &StorerMock{}
 //			AddInstanceFunc: func(ctx context.Context, instance *models.Instance) (*models.Instance, error) {
 //				panic("mock out the AddInstance method")
 //			},
+//			AddOutboxMessageFunc: func(ctx context.Context, message *models.OutboxMessage) error {
+//				panic("mock out the AddOutboxMessage method")
+//			},
 //			AddVersionDetailsToInstanceFunc: func(ctx context.Context, instanceID string, datasetID string, edition string, version int) error {
 //				panic("mock out the AddVersionDetailsToInstance method")
 //			},
@@ -57,34 +62,58 @@ var _ store.Storer = &StorerMock{}
 //			CheckVersionExistsStaticFunc: func(ctx context.Context, datasetID string, editionID string, version int) (bool, error) {
 //				panic("mock out the CheckVersionExistsStatic method")
 //			},
+//			ClaimOutboxMessageFunc: func(ctx context.Context, owner string, lease time.Duration, skipTopics []string) (*models.OutboxMessage, error) {
+//				panic("mock out the ClaimOutboxMessage method")
+//			},
+//			CountOutboxMessagesFunc: func(ctx context.Context, state string) (int, error) {
+//				panic("mock out the CountOutboxMessages method")
+//			},
 //			CreateAuditEventFunc: func(ctx context.Context, event *models.AuditEvent) error {
 //				panic("mock out the CreateAuditEvent method")
 //			},
-//			DeleteDatasetFunc: func(ctx context.Context, ID string) error {
+//			DeleteDatasetFunc: func(ctx context.Context, ID string, deletion *models.Deletion) error {
 //				panic("mock out the DeleteDataset method")
 //			},
-//			DeleteEditionFunc: func(ctx context.Context, ID string) error {
+//			DeleteEditionFunc: func(ctx context.Context, ID string, deletion *models.Deletion) error {
 //				panic("mock out the DeleteEdition method")
 //			},
-//			DeleteStaticDatasetVersionFunc: func(ctx context.Context, datasetID string, editionID string, version int) error {
+//			DeleteStaticDatasetVersionFunc: func(ctx context.Context, datasetID string, editionID string, version int, deletion *models.Deletion) error {
 //				panic("mock out the DeleteStaticDatasetVersion method")
 //			},
 //			GetAllStaticVersionsFunc: func(ctx context.Context, ID string, state string, offset int, limit int) ([]*models.Version, int, error) {
 //				panic("mock out the GetAllStaticVersions method")
 //			},
+//			GetAuditEventChainFunc: func(ctx context.Context, fromSequence int64, limit int) ([]*models.AuditEvent, error) {
+//				panic("mock out the GetAuditEventChain method")
+//			},
+//			GetAuditEventsFunc: func(ctx context.Context, filter *models.AuditEventFilter, offset int, limit int) ([]*models.AuditEvent, int, error) {
+//				panic("mock out the GetAuditEvents method")
+//			},
 //			GetDatasetFunc: func(ctx context.Context, ID string) (*models.DatasetUpdate, error) {
 //				panic("mock out the GetDataset method")
 //			},
+//			GetDatasetRevisionFunc: func(ctx context.Context, datasetID string, revision int) (*models.DatasetRevision, error) {
+//				panic("mock out the GetDatasetRevision method")
+//			},
+//			GetDatasetRevisionsFunc: func(ctx context.Context, datasetID string, offset int, limit int) ([]*models.DatasetRevision, int, error) {
+//				panic("mock out the GetDatasetRevisions method")
+//			},
 //			GetDatasetTypeFunc: func(ctx context.Context, datasetID string, authorised bool) (string, error) {
 //				panic("mock out the GetDatasetType method")
 //			},
-//			GetDatasetsFunc: func(ctx context.Context, offset int, limit int, authorised bool) ([]*models.DatasetUpdate, int, error) {
+//			GetDatasetsFunc: func(ctx context.Context, offset int, limit int, cursor *pagination.Cursor, authorised bool) ([]*models.DatasetUpdate, int, *pagination.Cursor, error) {
 //				panic("mock out the GetDatasets method")
 //			},
-//			GetDatasetsByQueryParamsFunc: func(ctx context.Context, ID string, datasetType string, sortOrder string, datasetID string, offset int, limit int, authorised bool) ([]*models.DatasetUpdate, int, error) {
+//			GetDatasetsByCollectionIDFunc: func(ctx context.Context, collectionID string, states []string) ([]*models.DatasetUpdate, error) {
+//				panic("mock out the GetDatasetsByCollectionID method")
+//			},
+//			GetDatasetsByQueryParamsFunc: func(ctx context.Context, ID string, datasetType string, sortOrder string, datasetID string, offset int, limit int, cursor *pagination.Cursor, authorised bool) ([]*models.DatasetUpdate, int, *pagination.Cursor, error) {
 //				panic("mock out the GetDatasetsByQueryParams method")
 //			},
-//			GetDimensionOptionsFunc: func(ctx context.Context, version *models.Version, dimension string, offset int, limit int) ([]*models.PublicDimensionOption, int, error) {
+//			GetDeletedStaticVersionsFunc: func(ctx context.Context, before time.Time, limit int) ([]*models.Version, error) {
+//				panic("mock out the GetDeletedStaticVersions method")
+//			},
+//			GetDimensionOptionsFunc: func(ctx context.Context, version *models.Version, dimension string, offset int, limit int, cursor *pagination.Cursor) ([]*models.PublicDimensionOption, int, *pagination.Cursor, error) {
 //				panic("mock out the GetDimensionOptions method")
 //			},
 //			GetDimensionOptionsFromIDsFunc: func(ctx context.Context, version *models.Version, dimension string, ids []string) ([]*models.PublicDimensionOption, int, error) {
@@ -105,15 +134,24 @@ var _ store.Storer = &StorerMock{}
 //			GetInstanceFunc: func(ctx context.Context, ID string, eTagSelector string) (*models.Instance, error) {
 //				panic("mock out the GetInstance method")
 //			},
-//			GetInstancesFunc: func(ctx context.Context, states []string, datasets []string, offset int, limit int) ([]*models.Instance, int, error) {
+//			GetInstancesFunc: func(ctx context.Context, states []string, datasets []string, offset int, limit int, cursor *pagination.Cursor) ([]*models.Instance, int, *pagination.Cursor, error) {
 //				panic("mock out the GetInstances method")
 //			},
+//			GetLatestVersionFunc: func(ctx context.Context, datasetID string, editionID string, state string) (*models.Version, error) {
+//				panic("mock out the GetLatestVersion method")
+//			},
 //			GetLatestVersionStaticFunc: func(ctx context.Context, datasetID string, editionID string, state string) (*models.Version, error) {
 //				panic("mock out the GetLatestVersionStatic method")
 //			},
 //			GetNextVersionFunc: func(ctx context.Context, datasetID string, editionID string) (int, error) {
 //				panic("mock out the GetNextVersion method")
 //			},
+//			GetNextVersionStaticFunc: func(ctx context.Context, datasetID string, editionID string) (int, error) {
+//				panic("mock out the GetNextVersionStatic method")
+//			},
+//			GetScheduledVersionsFunc: func(ctx context.Context, offset int, limit int) ([]*models.Version, int, error) {
+//				panic("mock out the GetScheduledVersions method")
+//			},
 //			GetStaticVersionsByStateFunc: func(ctx context.Context, state string, publishedOnly string, offset int, limit int) ([]*models.Version, int, error) {
 //				panic("mock out the GetStaticVersionsByState method")
 //			},
@@ -129,21 +167,54 @@ var _ store.Storer = &StorerMock{}
 //			GetVersionsFunc: func(ctx context.Context, datasetID string, editionID string, state string, offset int, limit int) ([]models.Version, int, error) {
 //				panic("mock out the GetVersions method")
 //			},
+//			GetVersionsByCollectionIDFunc: func(ctx context.Context, collectionID string, states []string) ([]*models.Version, error) {
+//				panic("mock out the GetVersionsByCollectionID method")
+//			},
 //			GetVersionsStaticFunc: func(ctx context.Context, datasetID string, edition string, state string, offset int, limit int) ([]models.Version, int, error) {
 //				panic("mock out the GetVersionsStatic method")
 //			},
 //			IsStaticDatasetFunc: func(ctx context.Context, datasetID string) (bool, error) {
 //				panic("mock out the IsStaticDataset method")
 //			},
+//			LockScheduledPublishingFunc: func(ctx context.Context) (string, error) {
+//				panic("mock out the LockScheduledPublishing method")
+//			},
+//			PurgeDeletedDatasetsFunc: func(ctx context.Context, before time.Time) (int, error) {
+//				panic("mock out the PurgeDeletedDatasets method")
+//			},
+//			PurgeSentOutboxMessagesFunc: func(ctx context.Context, before time.Time) (int, error) {
+//				panic("mock out the PurgeSentOutboxMessages method")
+//			},
+//			PurgeStaticVersionFunc: func(ctx context.Context, ID string) error {
+//				panic("mock out the PurgeStaticVersion method")
+//			},
 //			RemoveDatasetVersionAndEditionLinksFunc: func(ctx context.Context, id string) error {
 //				panic("mock out the RemoveDatasetVersionAndEditionLinks method")
 //			},
+//			RequeueFailedOutboxMessagesFunc: func(ctx context.Context, now time.Time) (int, error) {
+//				panic("mock out the RequeueFailedOutboxMessages method")
+//			},
+//			RestoreDatasetFunc: func(ctx context.Context, ID string) error {
+//				panic("mock out the RestoreDataset method")
+//			},
+//			RestoreEditionFunc: func(ctx context.Context, datasetID string, edition string) error {
+//				panic("mock out the RestoreEdition method")
+//			},
+//			RestoreStaticVersionFunc: func(ctx context.Context, datasetID string, edition string, version int) error {
+//				panic("mock out the RestoreStaticVersion method")
+//			},
+//			RunTransactionFunc: func(ctx context.Context, fn func(ctx context.Context) error) error {
+//				panic("mock out the RunTransaction method")
+//			},
 //			SetInstanceIsPublishedFunc: func(ctx context.Context, instanceID string) error {
 //				panic("mock out the SetInstanceIsPublished method")
 //			},
 //			UnlockInstanceFunc: func(ctx context.Context, lockID string)  {
 //				panic("mock out the UnlockInstance method")
 //			},
+//			UnlockScheduledPublishingFunc: func(ctx context.Context, lockID string)  {
+//				panic("mock out the UnlockScheduledPublishing method")
+//			},
 //			UnlockVersionsFunc: func(ctx context.Context, lockID string)  {
 //				panic("mock out the UnlockVersions method")
 //			},
@@ -177,6 +248,9 @@ var _ store.Storer = &StorerMock{}
 //			UpdateObservationInsertedFunc: func(ctx context.Context, currentInstance *models.Instance, observationInserted int64, eTagSelector string) (string, error) {
 //				panic("mock out the UpdateObservationInserted method")
 //			},
+//			UpdateOutboxMessageFunc: func(ctx context.Context, message *models.OutboxMessage) error {
+//				panic("mock out the UpdateOutboxMessage method")
+//			},
 //			UpdateVersionFunc: func(ctx context.Context, currentVersion *models.Version, version *models.Version, eTagSelector string) (string, error) {
 //				panic("mock out the UpdateVersion method")
 //			},
@@ -220,6 +294,9 @@ type StorerMock struct {
 	// AddInstanceFunc mocks the AddInstance method.
 	AddInstanceFunc func(ctx context.Context, instance *models.Instance) (*models.Instance, error)
 
+	// AddOutboxMessageFunc mocks the AddOutboxMessage method.
+	AddOutboxMessageFunc func(ctx context.Context, message *models.OutboxMessage) error
+
 	// AddVersionDetailsToInstanceFunc mocks the AddVersionDetailsToInstance method.
 	AddVersionDetailsToInstanceFunc func(ctx context.Context, instanceID string, datasetID string, edition string, version int) error
 
@@ -244,35 +321,59 @@ type StorerMock struct {
 	// CheckVersionExistsStaticFunc mocks the CheckVersionExistsStatic method.
 	CheckVersionExistsStaticFunc func(ctx context.Context, datasetID string, editionID string, version int) (bool, error)
 
+	// ClaimOutboxMessageFunc mocks the ClaimOutboxMessage method.
+	ClaimOutboxMessageFunc func(ctx context.Context, owner string, lease time.Duration, skipTopics []string) (*models.OutboxMessage, error)
+
+	// CountOutboxMessagesFunc mocks the CountOutboxMessages method.
+	CountOutboxMessagesFunc func(ctx context.Context, state string) (int, error)
+
 	// CreateAuditEventFunc mocks the CreateAuditEvent method.
 	CreateAuditEventFunc func(ctx context.Context, event *models.AuditEvent) error
 
 	// DeleteDatasetFunc mocks the DeleteDataset method.
-	DeleteDatasetFunc func(ctx context.Context, ID string) error
+	DeleteDatasetFunc func(ctx context.Context, ID string, deletion *models.Deletion) error
 
 	// DeleteEditionFunc mocks the DeleteEdition method.
-	DeleteEditionFunc func(ctx context.Context, ID string) error
+	DeleteEditionFunc func(ctx context.Context, ID string, deletion *models.Deletion) error
 
 	// DeleteStaticDatasetVersionFunc mocks the DeleteStaticDatasetVersion method.
-	DeleteStaticDatasetVersionFunc func(ctx context.Context, datasetID string, editionID string, version int) error
+	DeleteStaticDatasetVersionFunc func(ctx context.Context, datasetID string, editionID string, version int, deletion *models.Deletion) error
 
 	// GetAllStaticVersionsFunc mocks the GetAllStaticVersions method.
 	GetAllStaticVersionsFunc func(ctx context.Context, ID string, state string, offset int, limit int) ([]*models.Version, int, error)
 
+	// GetAuditEventChainFunc mocks the GetAuditEventChain method.
+	GetAuditEventChainFunc func(ctx context.Context, fromSequence int64, limit int) ([]*models.AuditEvent, error)
+
+	// GetAuditEventsFunc mocks the GetAuditEvents method.
+	GetAuditEventsFunc func(ctx context.Context, filter *models.AuditEventFilter, offset int, limit int) ([]*models.AuditEvent, int, error)
+
 	// GetDatasetFunc mocks the GetDataset method.
 	GetDatasetFunc func(ctx context.Context, ID string) (*models.DatasetUpdate, error)
 
+	// GetDatasetRevisionFunc mocks the GetDatasetRevision method.
+	GetDatasetRevisionFunc func(ctx context.Context, datasetID string, revision int) (*models.DatasetRevision, error)
+
+	// GetDatasetRevisionsFunc mocks the GetDatasetRevisions method.
+	GetDatasetRevisionsFunc func(ctx context.Context, datasetID string, offset int, limit int) ([]*models.DatasetRevision, int, error)
+
 	// GetDatasetTypeFunc mocks the GetDatasetType method.
 	GetDatasetTypeFunc func(ctx context.Context, datasetID string, authorised bool) (string, error)
 
 	// GetDatasetsFunc mocks the GetDatasets method.
-	GetDatasetsFunc func(ctx context.Context, offset int, limit int, authorised bool) ([]*models.DatasetUpdate, int, error)
+	GetDatasetsFunc func(ctx context.Context, offset int, limit int, cursor *pagination.Cursor, authorised bool) ([]*models.DatasetUpdate, int, *pagination.Cursor, error)
+
+	// GetDatasetsByCollectionIDFunc mocks the GetDatasetsByCollectionID method.
+	GetDatasetsByCollectionIDFunc func(ctx context.Context, collectionID string, states []string) ([]*models.DatasetUpdate, error)
 
 	// GetDatasetsByQueryParamsFunc mocks the GetDatasetsByQueryParams method.
-	GetDatasetsByQueryParamsFunc func(ctx context.Context, ID string, datasetType string, sortOrder string, datasetID string, offset int, limit int, authorised bool) ([]*models.DatasetUpdate, int, error)
+	GetDatasetsByQueryParamsFunc func(ctx context.Context, ID string, datasetType string, sortOrder string, datasetID string, offset int, limit int, cursor *pagination.Cursor, authorised bool) ([]*models.DatasetUpdate, int, *pagination.Cursor, error)
+
+	// GetDeletedStaticVersionsFunc mocks the GetDeletedStaticVersions method.
+	GetDeletedStaticVersionsFunc func(ctx context.Context, before time.Time, limit int) ([]*models.Version, error)
 
 	// GetDimensionOptionsFunc mocks the GetDimensionOptions method.
-	GetDimensionOptionsFunc func(ctx context.Context, version *models.Version, dimension string, offset int, limit int) ([]*models.PublicDimensionOption, int, error)
+	GetDimensionOptionsFunc func(ctx context.Context, version *models.Version, dimension string, offset int, limit int, cursor *pagination.Cursor) ([]*models.PublicDimensionOption, int, *pagination.Cursor, error)
 
 	// GetDimensionOptionsFromIDsFunc mocks the GetDimensionOptionsFromIDs method.
 	GetDimensionOptionsFromIDsFunc func(ctx context.Context, version *models.Version, dimension string, ids []string) ([]*models.PublicDimensionOption, int, error)
@@ -293,7 +394,10 @@ type StorerMock struct {
 	GetInstanceFunc func(ctx context.Context, ID string, eTagSelector string) (*models.Instance, error)
 
 	// GetInstancesFunc mocks the GetInstances method.
-	GetInstancesFunc func(ctx context.Context, states []string, datasets []string, offset int, limit int) ([]*models.Instance, int, error)
+	GetInstancesFunc func(ctx context.Context, states []string, datasets []string, offset int, limit int, cursor *pagination.Cursor) ([]*models.Instance, int, *pagination.Cursor, error)
+
+	// GetLatestVersionFunc mocks the GetLatestVersion method.
+	GetLatestVersionFunc func(ctx context.Context, datasetID string, editionID string, state string) (*models.Version, error)
 
 	// GetLatestVersionStaticFunc mocks the GetLatestVersionStatic method.
 	GetLatestVersionStaticFunc func(ctx context.Context, datasetID string, editionID string, state string) (*models.Version, error)
@@ -301,6 +405,12 @@ type StorerMock struct {
 	// GetNextVersionFunc mocks the GetNextVersion method.
 	GetNextVersionFunc func(ctx context.Context, datasetID string, editionID string) (int, error)
 
+	// GetNextVersionStaticFunc mocks the GetNextVersionStatic method.
+	GetNextVersionStaticFunc func(ctx context.Context, datasetID string, editionID string) (int, error)
+
+	// GetScheduledVersionsFunc mocks the GetScheduledVersions method.
+	GetScheduledVersionsFunc func(ctx context.Context, offset int, limit int) ([]*models.Version, int, error)
+
 	// GetStaticVersionsByStateFunc mocks the GetStaticVersionsByState method.
 	GetStaticVersionsByStateFunc func(ctx context.Context, state string, publishedOnly string, offset int, limit int) ([]*models.Version, int, error)
 
@@ -316,21 +426,54 @@ type StorerMock struct {
 	// GetVersionsFunc mocks the GetVersions method.
 	GetVersionsFunc func(ctx context.Context, datasetID string, editionID string, state string, offset int, limit int) ([]models.Version, int, error)
 
+	// GetVersionsByCollectionIDFunc mocks the GetVersionsByCollectionID method.
+	GetVersionsByCollectionIDFunc func(ctx context.Context, collectionID string, states []string) ([]*models.Version, error)
+
 	// GetVersionsStaticFunc mocks the GetVersionsStatic method.
 	GetVersionsStaticFunc func(ctx context.Context, datasetID string, edition string, state string, offset int, limit int) ([]models.Version, int, error)
 
 	// IsStaticDatasetFunc mocks the IsStaticDataset method.
 	IsStaticDatasetFunc func(ctx context.Context, datasetID string) (bool, error)
 
+	// LockScheduledPublishingFunc mocks the LockScheduledPublishing method.
+	LockScheduledPublishingFunc func(ctx context.Context) (string, error)
+
+	// PurgeDeletedDatasetsFunc mocks the PurgeDeletedDatasets method.
+	PurgeDeletedDatasetsFunc func(ctx context.Context, before time.Time) (int, error)
+
+	// PurgeSentOutboxMessagesFunc mocks the PurgeSentOutboxMessages method.
+	PurgeSentOutboxMessagesFunc func(ctx context.Context, before time.Time) (int, error)
+
+	// PurgeStaticVersionFunc mocks the PurgeStaticVersion method.
+	PurgeStaticVersionFunc func(ctx context.Context, ID string) error
+
 	// RemoveDatasetVersionAndEditionLinksFunc mocks the RemoveDatasetVersionAndEditionLinks method.
 	RemoveDatasetVersionAndEditionLinksFunc func(ctx context.Context, id string) error
 
+	// RequeueFailedOutboxMessagesFunc mocks the RequeueFailedOutboxMessages method.
+	RequeueFailedOutboxMessagesFunc func(ctx context.Context, now time.Time) (int, error)
+
+	// RestoreDatasetFunc mocks the RestoreDataset method.
+	RestoreDatasetFunc func(ctx context.Context, ID string) error
+
+	// RestoreEditionFunc mocks the RestoreEdition method.
+	RestoreEditionFunc func(ctx context.Context, datasetID string, edition string) error
+
+	// RestoreStaticVersionFunc mocks the RestoreStaticVersion method.
+	RestoreStaticVersionFunc func(ctx context.Context, datasetID string, edition string, version int) error
+
+	// RunTransactionFunc mocks the RunTransaction method.
+	RunTransactionFunc func(ctx context.Context, fn func(ctx context.Context) error) error
+
 	// SetInstanceIsPublishedFunc mocks the SetInstanceIsPublished method.
 	SetInstanceIsPublishedFunc func(ctx context.Context, instanceID string) error
 
 	// UnlockInstanceFunc mocks the UnlockInstance method.
 	UnlockInstanceFunc func(ctx context.Context, lockID string)
 
+	// UnlockScheduledPublishingFunc mocks the UnlockScheduledPublishing method.
+	UnlockScheduledPublishingFunc func(ctx context.Context, lockID string)
+
 	// UnlockVersionsFunc mocks the UnlockVersions method.
 	UnlockVersionsFunc func(ctx context.Context, lockID string)
 
@@ -364,6 +507,9 @@ type StorerMock struct {
 	// UpdateObservationInsertedFunc mocks the UpdateObservationInserted method.
 	UpdateObservationInsertedFunc func(ctx context.Context, currentInstance *models.Instance, observationInserted int64, eTagSelector string) (string, error)
 
+	// UpdateOutboxMessageFunc mocks the UpdateOutboxMessage method.
+	UpdateOutboxMessageFunc func(ctx context.Context, message *models.OutboxMessage) error
+
 	// UpdateVersionFunc mocks the UpdateVersion method.
 	UpdateVersionFunc func(ctx context.Context, currentVersion *models.Version, version *models.Version, eTagSelector string) (string, error)
 
@@ -422,6 +568,13 @@ type StorerMock struct {
 			// Instance is the instance argument value.
 			Instance *models.Instance
 		}
+		// AddOutboxMessage holds details about calls to the AddOutboxMessage method.
+		AddOutboxMessage []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Message is the message argument value.
+			Message *models.OutboxMessage
+		}
 		// AddVersionDetailsToInstance holds details about calls to the AddVersionDetailsToInstance method.
 		AddVersionDetailsToInstance []struct {
 			// Ctx is the ctx argument value.
@@ -500,6 +653,24 @@ type StorerMock struct {
 			// Version is the version argument value.
 			Version int
 		}
+		// ClaimOutboxMessage holds details about calls to the ClaimOutboxMessage method.
+		ClaimOutboxMessage []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Owner is the owner argument value.
+			Owner string
+			// Lease is the lease argument value.
+			Lease time.Duration
+			// SkipTopics is the skipTopics argument value.
+			SkipTopics []string
+		}
+		// CountOutboxMessages holds details about calls to the CountOutboxMessages method.
+		CountOutboxMessages []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// State is the state argument value.
+			State string
+		}
 		// CreateAuditEvent holds details about calls to the CreateAuditEvent method.
 		CreateAuditEvent []struct {
 			// Ctx is the ctx argument value.
@@ -513,6 +684,8 @@ type StorerMock struct {
 			Ctx context.Context
 			// ID is the ID argument value.
 			ID string
+			// Deletion is the deletion argument value.
+			Deletion *models.Deletion
 		}
 		// DeleteEdition holds details about calls to the DeleteEdition method.
 		DeleteEdition []struct {
@@ -520,6 +693,8 @@ type StorerMock struct {
 			Ctx context.Context
 			// ID is the ID argument value.
 			ID string
+			// Deletion is the deletion argument value.
+			Deletion *models.Deletion
 		}
 		// DeleteStaticDatasetVersion holds details about calls to the DeleteStaticDatasetVersion method.
 		DeleteStaticDatasetVersion []struct {
@@ -531,6 +706,8 @@ type StorerMock struct {
 			EditionID string
 			// Version is the version argument value.
 			Version int
+			// Deletion is the deletion argument value.
+			Deletion *models.Deletion
 		}
 		// GetAllStaticVersions holds details about calls to the GetAllStaticVersions method.
 		GetAllStaticVersions []struct {
@@ -545,6 +722,26 @@ type StorerMock struct {
 			// Limit is the limit argument value.
 			Limit int
 		}
+		// GetAuditEventChain holds details about calls to the GetAuditEventChain method.
+		GetAuditEventChain []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// FromSequence is the fromSequence argument value.
+			FromSequence int64
+			// Limit is the limit argument value.
+			Limit int
+		}
+		// GetAuditEvents holds details about calls to the GetAuditEvents method.
+		GetAuditEvents []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Filter is the filter argument value.
+			Filter *models.AuditEventFilter
+			// Offset is the offset argument value.
+			Offset int
+			// Limit is the limit argument value.
+			Limit int
+		}
 		// GetDataset holds details about calls to the GetDataset method.
 		GetDataset []struct {
 			// Ctx is the ctx argument value.
@@ -552,6 +749,26 @@ type StorerMock struct {
 			// ID is the ID argument value.
 			ID string
 		}
+		// GetDatasetRevision holds details about calls to the GetDatasetRevision method.
+		GetDatasetRevision []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// DatasetID is the datasetID argument value.
+			DatasetID string
+			// Revision is the revision argument value.
+			Revision int
+		}
+		// GetDatasetRevisions holds details about calls to the GetDatasetRevisions method.
+		GetDatasetRevisions []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// DatasetID is the datasetID argument value.
+			DatasetID string
+			// Offset is the offset argument value.
+			Offset int
+			// Limit is the limit argument value.
+			Limit int
+		}
 		// GetDatasetType holds details about calls to the GetDatasetType method.
 		GetDatasetType []struct {
 			// Ctx is the ctx argument value.
@@ -569,9 +786,20 @@ type StorerMock struct {
 			Offset int
 			// Limit is the limit argument value.
 			Limit int
+			// Cursor is the cursor argument value.
+			Cursor *pagination.Cursor
 			// Authorised is the authorised argument value.
 			Authorised bool
 		}
+		// GetDatasetsByCollectionID holds details about calls to the GetDatasetsByCollectionID method.
+		GetDatasetsByCollectionID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// CollectionID is the collectionID argument value.
+			CollectionID string
+			// States is the states argument value.
+			States []string
+		}
 		// GetDatasetsByQueryParams holds details about calls to the GetDatasetsByQueryParams method.
 		GetDatasetsByQueryParams []struct {
 			// Ctx is the ctx argument value.
@@ -588,9 +816,20 @@ type StorerMock struct {
 			Offset int
 			// Limit is the limit argument value.
 			Limit int
+			// Cursor is the cursor argument value.
+			Cursor *pagination.Cursor
 			// Authorised is the authorised argument value.
 			Authorised bool
 		}
+		// GetDeletedStaticVersions holds details about calls to the GetDeletedStaticVersions method.
+		GetDeletedStaticVersions []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Before is the before argument value.
+			Before time.Time
+			// Limit is the limit argument value.
+			Limit int
+		}
 		// GetDimensionOptions holds details about calls to the GetDimensionOptions method.
 		GetDimensionOptions []struct {
 			// Ctx is the ctx argument value.
@@ -603,6 +842,8 @@ type StorerMock struct {
 			Offset int
 			// Limit is the limit argument value.
 			Limit int
+			// Cursor is the cursor argument value.
+			Cursor *pagination.Cursor
 		}
 		// GetDimensionOptionsFromIDs holds details about calls to the GetDimensionOptionsFromIDs method.
 		GetDimensionOptionsFromIDs []struct {
@@ -680,6 +921,19 @@ type StorerMock struct {
 			Offset int
 			// Limit is the limit argument value.
 			Limit int
+			// Cursor is the cursor argument value.
+			Cursor *pagination.Cursor
+		}
+		// GetLatestVersion holds details about calls to the GetLatestVersion method.
+		GetLatestVersion []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// DatasetID is the datasetID argument value.
+			DatasetID string
+			// EditionID is the editionID argument value.
+			EditionID string
+			// State is the state argument value.
+			State string
 		}
 		// GetLatestVersionStatic holds details about calls to the GetLatestVersionStatic method.
 		GetLatestVersionStatic []struct {
@@ -701,6 +955,24 @@ type StorerMock struct {
 			// EditionID is the editionID argument value.
 			EditionID string
 		}
+		// GetNextVersionStatic holds details about calls to the GetNextVersionStatic method.
+		GetNextVersionStatic []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// DatasetID is the datasetID argument value.
+			DatasetID string
+			// EditionID is the editionID argument value.
+			EditionID string
+		}
+		// GetScheduledVersions holds details about calls to the GetScheduledVersions method.
+		GetScheduledVersions []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Offset is the offset argument value.
+			Offset int
+			// Limit is the limit argument value.
+			Limit int
+		}
 		// GetStaticVersionsByState holds details about calls to the GetStaticVersionsByState method.
 		GetStaticVersionsByState []struct {
 			// Ctx is the ctx argument value.
@@ -764,6 +1036,15 @@ type StorerMock struct {
 			// Limit is the limit argument value.
 			Limit int
 		}
+		// GetVersionsByCollectionID holds details about calls to the GetVersionsByCollectionID method.
+		GetVersionsByCollectionID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// CollectionID is the collectionID argument value.
+			CollectionID string
+			// States is the states argument value.
+			States []string
+		}
 		// GetVersionsStatic holds details about calls to the GetVersionsStatic method.
 		GetVersionsStatic []struct {
 			// Ctx is the ctx argument value.
@@ -786,6 +1067,32 @@ type StorerMock struct {
 			// DatasetID is the datasetID argument value.
 			DatasetID string
 		}
+		// LockScheduledPublishing holds details about calls to the LockScheduledPublishing method.
+		LockScheduledPublishing []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// PurgeDeletedDatasets holds details about calls to the PurgeDeletedDatasets method.
+		PurgeDeletedDatasets []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Before is the before argument value.
+			Before time.Time
+		}
+		// PurgeSentOutboxMessages holds details about calls to the PurgeSentOutboxMessages method.
+		PurgeSentOutboxMessages []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Before is the before argument value.
+			Before time.Time
+		}
+		// PurgeStaticVersion holds details about calls to the PurgeStaticVersion method.
+		PurgeStaticVersion []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the ID argument value.
+			ID string
+		}
 		// RemoveDatasetVersionAndEditionLinks holds details about calls to the RemoveDatasetVersionAndEditionLinks method.
 		RemoveDatasetVersionAndEditionLinks []struct {
 			// Ctx is the ctx argument value.
@@ -793,6 +1100,47 @@ type StorerMock struct {
 			// ID is the id argument value.
 			ID string
 		}
+		// RequeueFailedOutboxMessages holds details about calls to the RequeueFailedOutboxMessages method.
+		RequeueFailedOutboxMessages []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Now is the now argument value.
+			Now time.Time
+		}
+		// RestoreDataset holds details about calls to the RestoreDataset method.
+		RestoreDataset []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the ID argument value.
+			ID string
+		}
+		// RestoreEdition holds details about calls to the RestoreEdition method.
+		RestoreEdition []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// DatasetID is the datasetID argument value.
+			DatasetID string
+			// Edition is the edition argument value.
+			Edition string
+		}
+		// RestoreStaticVersion holds details about calls to the RestoreStaticVersion method.
+		RestoreStaticVersion []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// DatasetID is the datasetID argument value.
+			DatasetID string
+			// Edition is the edition argument value.
+			Edition string
+			// Version is the version argument value.
+			Version int
+		}
+		// RunTransaction holds details about calls to the RunTransaction method.
+		RunTransaction []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Fn is the fn argument value.
+			Fn func(ctx context.Context) error
+		}
 		// SetInstanceIsPublished holds details about calls to the SetInstanceIsPublished method.
 		SetInstanceIsPublished []struct {
 			// Ctx is the ctx argument value.
@@ -807,6 +1155,13 @@ type StorerMock struct {
 			// LockID is the lockID argument value.
 			LockID string
 		}
+		// UnlockScheduledPublishing holds details about calls to the UnlockScheduledPublishing method.
+		UnlockScheduledPublishing []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// LockID is the lockID argument value.
+			LockID string
+		}
 		// UnlockVersions holds details about calls to the UnlockVersions method.
 		UnlockVersions []struct {
 			// Ctx is the ctx argument value.
@@ -930,6 +1285,13 @@ type StorerMock struct {
 			// ETagSelector is the eTagSelector argument value.
 			ETagSelector string
 		}
+		// UpdateOutboxMessage holds details about calls to the UpdateOutboxMessage method.
+		UpdateOutboxMessage []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Message is the message argument value.
+			Message *models.OutboxMessage
+		}
 		// UpdateVersion holds details about calls to the UpdateVersion method.
 		UpdateVersion []struct {
 			// Ctx is the ctx argument value.
@@ -1009,6 +1371,7 @@ type StorerMock struct {
 	lockAcquireVersionsLock                 sync.RWMutex
 	lockAddEventToInstance                  sync.RWMutex
 	lockAddInstance                         sync.RWMutex
+	lockAddOutboxMessage                    sync.RWMutex
 	lockAddVersionDetailsToInstance         sync.RWMutex
 	lockAddVersionStatic                    sync.RWMutex
 	lockCheckDatasetExists                  sync.RWMutex
@@ -1017,15 +1380,23 @@ type StorerMock struct {
 	lockCheckEditionExistsStatic            sync.RWMutex
 	lockCheckEditionTitleExistsStatic       sync.RWMutex
 	lockCheckVersionExistsStatic            sync.RWMutex
+	lockClaimOutboxMessage                  sync.RWMutex
+	lockCountOutboxMessages                 sync.RWMutex
 	lockCreateAuditEvent                    sync.RWMutex
 	lockDeleteDataset                       sync.RWMutex
 	lockDeleteEdition                       sync.RWMutex
 	lockDeleteStaticDatasetVersion          sync.RWMutex
 	lockGetAllStaticVersions                sync.RWMutex
+	lockGetAuditEventChain                  sync.RWMutex
+	lockGetAuditEvents                      sync.RWMutex
 	lockGetDataset                          sync.RWMutex
+	lockGetDatasetRevision                  sync.RWMutex
+	lockGetDatasetRevisions                 sync.RWMutex
 	lockGetDatasetType                      sync.RWMutex
 	lockGetDatasets                         sync.RWMutex
+	lockGetDatasetsByCollectionID           sync.RWMutex
 	lockGetDatasetsByQueryParams            sync.RWMutex
+	lockGetDeletedStaticVersions            sync.RWMutex
 	lockGetDimensionOptions                 sync.RWMutex
 	lockGetDimensionOptionsFromIDs          sync.RWMutex
 	lockGetDimensions                       sync.RWMutex
@@ -1034,18 +1405,32 @@ type StorerMock struct {
 	lockGetEditions                         sync.RWMutex
 	lockGetInstance                         sync.RWMutex
 	lockGetInstances                        sync.RWMutex
+	lockGetLatestVersion                    sync.RWMutex
 	lockGetLatestVersionStatic              sync.RWMutex
 	lockGetNextVersion                      sync.RWMutex
+	lockGetNextVersionStatic                sync.RWMutex
+	lockGetScheduledVersions                sync.RWMutex
 	lockGetStaticVersionsByState            sync.RWMutex
 	lockGetUniqueDimensionAndOptions        sync.RWMutex
 	lockGetVersion                          sync.RWMutex
 	lockGetVersionStatic                    sync.RWMutex
 	lockGetVersions                         sync.RWMutex
+	lockGetVersionsByCollectionID           sync.RWMutex
 	lockGetVersionsStatic                   sync.RWMutex
 	lockIsStaticDataset                     sync.RWMutex
+	lockLockScheduledPublishing             sync.RWMutex
+	lockPurgeDeletedDatasets                sync.RWMutex
+	lockPurgeSentOutboxMessages             sync.RWMutex
+	lockPurgeStaticVersion                  sync.RWMutex
 	lockRemoveDatasetVersionAndEditionLinks sync.RWMutex
+	lockRequeueFailedOutboxMessages         sync.RWMutex
+	lockRestoreDataset                      sync.RWMutex
+	lockRestoreEdition                      sync.RWMutex
+	lockRestoreStaticVersion                sync.RWMutex
+	lockRunTransaction                      sync.RWMutex
 	lockSetInstanceIsPublished              sync.RWMutex
 	lockUnlockInstance                      sync.RWMutex
+	lockUnlockScheduledPublishing           sync.RWMutex
 	lockUnlockVersions                      sync.RWMutex
 	lockUpdateBuildHierarchyTaskState       sync.RWMutex
 	lockUpdateBuildSearchTaskState          sync.RWMutex
@@ -1057,6 +1442,7 @@ type StorerMock struct {
 	lockUpdateInstance                      sync.RWMutex
 	lockUpdateMetadata                      sync.RWMutex
 	lockUpdateObservationInserted           sync.RWMutex
+	lockUpdateOutboxMessage                 sync.RWMutex
 	lockUpdateVersion                       sync.RWMutex
 	lockUpdateVersionStatic                 sync.RWMutex
 	lockUpsertContact                       sync.RWMutex
@@ -1219,6 +1605,42 @@ func (mock *StorerMock) AddInstanceCalls() []struct {
 	return calls
 }
 
+// AddOutboxMessage calls AddOutboxMessageFunc.
+func (mock *StorerMock) AddOutboxMessage(ctx context.Context, message *models.OutboxMessage) error {
+	if mock.AddOutboxMessageFunc == nil {
+		panic("StorerMock.AddOutboxMessageFunc: method is nil but Storer.AddOutboxMessage was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Message *models.OutboxMessage
+	}{
+		Ctx:     ctx,
+		Message: message,
+	}
+	mock.lockAddOutboxMessage.Lock()
+	mock.calls.AddOutboxMessage = append(mock.calls.AddOutboxMessage, callInfo)
+	mock.lockAddOutboxMessage.Unlock()
+	return mock.AddOutboxMessageFunc(ctx, message)
+}
+
+// AddOutboxMessageCalls gets all the calls that were made to AddOutboxMessage.
+// Check the length with:
+//
+//	len(mockedStorer.AddOutboxMessageCalls())
+func (mock *StorerMock) AddOutboxMessageCalls() []struct {
+	Ctx     context.Context
+	Message *models.OutboxMessage
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Message *models.OutboxMessage
+	}
+	mock.lockAddOutboxMessage.RLock()
+	calls = mock.calls.AddOutboxMessage
+	mock.lockAddOutboxMessage.RUnlock()
+	return calls
+}
+
 // AddVersionDetailsToInstance calls AddVersionDetailsToInstanceFunc.
 func (mock *StorerMock) AddVersionDetailsToInstance(ctx context.Context, instanceID string, datasetID string, edition string, version int) error {
 	if mock.AddVersionDetailsToInstanceFunc == nil {
@@ -1551,6 +1973,86 @@ func (mock *StorerMock) CheckVersionExistsStaticCalls() []struct {
 	return calls
 }
 
+// ClaimOutboxMessage calls ClaimOutboxMessageFunc.
+func (mock *StorerMock) ClaimOutboxMessage(ctx context.Context, owner string, lease time.Duration, skipTopics []string) (*models.OutboxMessage, error) {
+	if mock.ClaimOutboxMessageFunc == nil {
+		panic("StorerMock.ClaimOutboxMessageFunc: method is nil but Storer.ClaimOutboxMessage was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		Owner      string
+		Lease      time.Duration
+		SkipTopics []string
+	}{
+		Ctx:        ctx,
+		Owner:      owner,
+		Lease:      lease,
+		SkipTopics: skipTopics,
+	}
+	mock.lockClaimOutboxMessage.Lock()
+	mock.calls.ClaimOutboxMessage = append(mock.calls.ClaimOutboxMessage, callInfo)
+	mock.lockClaimOutboxMessage.Unlock()
+	return mock.ClaimOutboxMessageFunc(ctx, owner, lease, skipTopics)
+}
+
+// ClaimOutboxMessageCalls gets all the calls that were made to ClaimOutboxMessage.
+// Check the length with:
+//
+//	len(mockedStorer.ClaimOutboxMessageCalls())
+func (mock *StorerMock) ClaimOutboxMessageCalls() []struct {
+	Ctx        context.Context
+	Owner      string
+	Lease      time.Duration
+	SkipTopics []string
+} {
+	var calls []struct {
+		Ctx        context.Context
+		Owner      string
+		Lease      time.Duration
+		SkipTopics []string
+	}
+	mock.lockClaimOutboxMessage.RLock()
+	calls = mock.calls.ClaimOutboxMessage
+	mock.lockClaimOutboxMessage.RUnlock()
+	return calls
+}
+
+// CountOutboxMessages calls CountOutboxMessagesFunc.
+func (mock *StorerMock) CountOutboxMessages(ctx context.Context, state string) (int, error) {
+	if mock.CountOutboxMessagesFunc == nil {
+		panic("StorerMock.CountOutboxMessagesFunc: method is nil but Storer.CountOutboxMessages was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		State string
+	}{
+		Ctx:   ctx,
+		State: state,
+	}
+	mock.lockCountOutboxMessages.Lock()
+	mock.calls.CountOutboxMessages = append(mock.calls.CountOutboxMessages, callInfo)
+	mock.lockCountOutboxMessages.Unlock()
+	return mock.CountOutboxMessagesFunc(ctx, state)
+}
+
+// CountOutboxMessagesCalls gets all the calls that were made to CountOutboxMessages.
+// Check the length with:
+//
+//	len(mockedStorer.CountOutboxMessagesCalls())
+func (mock *StorerMock) CountOutboxMessagesCalls() []struct {
+	Ctx   context.Context
+	State string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		State string
+	}
+	mock.lockCountOutboxMessages.RLock()
+	calls = mock.calls.CountOutboxMessages
+	mock.lockCountOutboxMessages.RUnlock()
+	return calls
+}
+
 // CreateAuditEvent calls CreateAuditEventFunc.
 func (mock *StorerMock) CreateAuditEvent(ctx context.Context, event *models.AuditEvent) error {
 	if mock.CreateAuditEventFunc == nil {
@@ -1588,21 +2090,23 @@ func (mock *StorerMock) CreateAuditEventCalls() []struct {
 }
 
 // DeleteDataset calls DeleteDatasetFunc.
-func (mock *StorerMock) DeleteDataset(ctx context.Context, ID string) error {
+func (mock *StorerMock) DeleteDataset(ctx context.Context, ID string, deletion *models.Deletion) error {
 	if mock.DeleteDatasetFunc == nil {
 		panic("StorerMock.DeleteDatasetFunc: method is nil but Storer.DeleteDataset was just called")
 	}
 	callInfo := struct {
-		Ctx context.Context
-		ID  string
+		Ctx      context.Context
+		ID       string
+		Deletion *models.Deletion
 	}{
-		Ctx: ctx,
-		ID:  ID,
+		Ctx:      ctx,
+		ID:       ID,
+		Deletion: deletion,
 	}
 	mock.lockDeleteDataset.Lock()
 	mock.calls.DeleteDataset = append(mock.calls.DeleteDataset, callInfo)
 	mock.lockDeleteDataset.Unlock()
-	return mock.DeleteDatasetFunc(ctx, ID)
+	return mock.DeleteDatasetFunc(ctx, ID, deletion)
 }
 
 // DeleteDatasetCalls gets all the calls that were made to DeleteDataset.
@@ -1610,12 +2114,14 @@ func (mock *StorerMock) DeleteDataset(ctx context.Context, ID string) error {
 //
 //	len(mockedStorer.DeleteDatasetCalls())
 func (mock *StorerMock) DeleteDatasetCalls() []struct {
-	Ctx context.Context
-	ID  string
+	Ctx      context.Context
+	ID       string
+	Deletion *models.Deletion
 } {
 	var calls []struct {
-		Ctx context.Context
-		ID  string
+		Ctx      context.Context
+		ID       string
+		Deletion *models.Deletion
 	}
 	mock.lockDeleteDataset.RLock()
 	calls = mock.calls.DeleteDataset
@@ -1624,21 +2130,23 @@ func (mock *StorerMock) DeleteDatasetCalls() []struct {
 }
 
 // DeleteEdition calls DeleteEditionFunc.
-func (mock *StorerMock) DeleteEdition(ctx context.Context, ID string) error {
+func (mock *StorerMock) DeleteEdition(ctx context.Context, ID string, deletion *models.Deletion) error {
 	if mock.DeleteEditionFunc == nil {
 		panic("StorerMock.DeleteEditionFunc: method is nil but Storer.DeleteEdition was just called")
 	}
 	callInfo := struct {
-		Ctx context.Context
-		ID  string
+		Ctx      context.Context
+		ID       string
+		Deletion *models.Deletion
 	}{
-		Ctx: ctx,
-		ID:  ID,
+		Ctx:      ctx,
+		ID:       ID,
+		Deletion: deletion,
 	}
 	mock.lockDeleteEdition.Lock()
 	mock.calls.DeleteEdition = append(mock.calls.DeleteEdition, callInfo)
 	mock.lockDeleteEdition.Unlock()
-	return mock.DeleteEditionFunc(ctx, ID)
+	return mock.DeleteEditionFunc(ctx, ID, deletion)
 }
 
 // DeleteEditionCalls gets all the calls that were made to DeleteEdition.
@@ -1646,12 +2154,14 @@ func (mock *StorerMock) DeleteEdition(ctx context.Context, ID string) error {
 //
 //	len(mockedStorer.DeleteEditionCalls())
 func (mock *StorerMock) DeleteEditionCalls() []struct {
-	Ctx context.Context
-	ID  string
+	Ctx      context.Context
+	ID       string
+	Deletion *models.Deletion
 } {
 	var calls []struct {
-		Ctx context.Context
-		ID  string
+		Ctx      context.Context
+		ID       string
+		Deletion *models.Deletion
 	}
 	mock.lockDeleteEdition.RLock()
 	calls = mock.calls.DeleteEdition
@@ -1660,7 +2170,7 @@ func (mock *StorerMock) DeleteEditionCalls() []struct {
 }
 
 // DeleteStaticDatasetVersion calls DeleteStaticDatasetVersionFunc.
-func (mock *StorerMock) DeleteStaticDatasetVersion(ctx context.Context, datasetID string, editionID string, version int) error {
+func (mock *StorerMock) DeleteStaticDatasetVersion(ctx context.Context, datasetID string, editionID string, version int, deletion *models.Deletion) error {
 	if mock.DeleteStaticDatasetVersionFunc == nil {
 		panic("StorerMock.DeleteStaticDatasetVersionFunc: method is nil but Storer.DeleteStaticDatasetVersion was just called")
 	}
@@ -1669,16 +2179,18 @@ func (mock *StorerMock) DeleteStaticDatasetVersion(ctx context.Context, datasetI
 		DatasetID string
 		EditionID string
 		Version   int
+		Deletion  *models.Deletion
 	}{
 		Ctx:       ctx,
 		DatasetID: datasetID,
 		EditionID: editionID,
 		Version:   version,
+		Deletion:  deletion,
 	}
 	mock.lockDeleteStaticDatasetVersion.Lock()
 	mock.calls.DeleteStaticDatasetVersion = append(mock.calls.DeleteStaticDatasetVersion, callInfo)
 	mock.lockDeleteStaticDatasetVersion.Unlock()
-	return mock.DeleteStaticDatasetVersionFunc(ctx, datasetID, editionID, version)
+	return mock.DeleteStaticDatasetVersionFunc(ctx, datasetID, editionID, version, deletion)
 }
 
 // DeleteStaticDatasetVersionCalls gets all the calls that were made to DeleteStaticDatasetVersion.
@@ -1690,12 +2202,14 @@ func (mock *StorerMock) DeleteStaticDatasetVersionCalls() []struct {
 	DatasetID string
 	EditionID string
 	Version   int
+	Deletion  *models.Deletion
 } {
 	var calls []struct {
 		Ctx       context.Context
 		DatasetID string
 		EditionID string
 		Version   int
+		Deletion  *models.Deletion
 	}
 	mock.lockDeleteStaticDatasetVersion.RLock()
 	calls = mock.calls.DeleteStaticDatasetVersion
@@ -1751,9 +2265,93 @@ func (mock *StorerMock) GetAllStaticVersionsCalls() []struct {
 	return calls
 }
 
-// GetDataset calls GetDatasetFunc.
-func (mock *StorerMock) GetDataset(ctx context.Context, ID string) (*models.DatasetUpdate, error) {
-	if mock.GetDatasetFunc == nil {
+// GetAuditEventChain calls GetAuditEventChainFunc.
+func (mock *StorerMock) GetAuditEventChain(ctx context.Context, fromSequence int64, limit int) ([]*models.AuditEvent, error) {
+	if mock.GetAuditEventChainFunc == nil {
+		panic("StorerMock.GetAuditEventChainFunc: method is nil but Storer.GetAuditEventChain was just called")
+	}
+	callInfo := struct {
+		Ctx          context.Context
+		FromSequence int64
+		Limit        int
+	}{
+		Ctx:          ctx,
+		FromSequence: fromSequence,
+		Limit:        limit,
+	}
+	mock.lockGetAuditEventChain.Lock()
+	mock.calls.GetAuditEventChain = append(mock.calls.GetAuditEventChain, callInfo)
+	mock.lockGetAuditEventChain.Unlock()
+	return mock.GetAuditEventChainFunc(ctx, fromSequence, limit)
+}
+
+// GetAuditEventChainCalls gets all the calls that were made to GetAuditEventChain.
+// Check the length with:
+//
+//	len(mockedStorer.GetAuditEventChainCalls())
+func (mock *StorerMock) GetAuditEventChainCalls() []struct {
+	Ctx          context.Context
+	FromSequence int64
+	Limit        int
+} {
+	var calls []struct {
+		Ctx          context.Context
+		FromSequence int64
+		Limit        int
+	}
+	mock.lockGetAuditEventChain.RLock()
+	calls = mock.calls.GetAuditEventChain
+	mock.lockGetAuditEventChain.RUnlock()
+	return calls
+}
+
+// GetAuditEvents calls GetAuditEventsFunc.
+func (mock *StorerMock) GetAuditEvents(ctx context.Context, filter *models.AuditEventFilter, offset int, limit int) ([]*models.AuditEvent, int, error) {
+	if mock.GetAuditEventsFunc == nil {
+		panic("StorerMock.GetAuditEventsFunc: method is nil but Storer.GetAuditEvents was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Filter *models.AuditEventFilter
+		Offset int
+		Limit  int
+	}{
+		Ctx:    ctx,
+		Filter: filter,
+		Offset: offset,
+		Limit:  limit,
+	}
+	mock.lockGetAuditEvents.Lock()
+	mock.calls.GetAuditEvents = append(mock.calls.GetAuditEvents, callInfo)
+	mock.lockGetAuditEvents.Unlock()
+	return mock.GetAuditEventsFunc(ctx, filter, offset, limit)
+}
+
+// GetAuditEventsCalls gets all the calls that were made to GetAuditEvents.
+// Check the length with:
+//
+//	len(mockedStorer.GetAuditEventsCalls())
+func (mock *StorerMock) GetAuditEventsCalls() []struct {
+	Ctx    context.Context
+	Filter *models.AuditEventFilter
+	Offset int
+	Limit  int
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Filter *models.AuditEventFilter
+		Offset int
+		Limit  int
+	}
+	mock.lockGetAuditEvents.RLock()
+	calls = mock.calls.GetAuditEvents
+	mock.lockGetAuditEvents.RUnlock()
+	return calls
+}
+
+// GetDataset calls GetDatasetFunc.
+func (mock *StorerMock) GetDataset(ctx context.Context, ID string) (*models.DatasetUpdate, error) {
+	if mock.GetDatasetFunc == nil {
 		panic("StorerMock.GetDatasetFunc: method is nil but Storer.GetDataset was just called")
 	}
 	callInfo := struct {
@@ -1787,6 +2385,90 @@ func (mock *StorerMock) GetDatasetCalls() []struct {
 	return calls
 }
 
+// GetDatasetRevision calls GetDatasetRevisionFunc.
+func (mock *StorerMock) GetDatasetRevision(ctx context.Context, datasetID string, revision int) (*models.DatasetRevision, error) {
+	if mock.GetDatasetRevisionFunc == nil {
+		panic("StorerMock.GetDatasetRevisionFunc: method is nil but Storer.GetDatasetRevision was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		DatasetID string
+		Revision  int
+	}{
+		Ctx:       ctx,
+		DatasetID: datasetID,
+		Revision:  revision,
+	}
+	mock.lockGetDatasetRevision.Lock()
+	mock.calls.GetDatasetRevision = append(mock.calls.GetDatasetRevision, callInfo)
+	mock.lockGetDatasetRevision.Unlock()
+	return mock.GetDatasetRevisionFunc(ctx, datasetID, revision)
+}
+
+// GetDatasetRevisionCalls gets all the calls that were made to GetDatasetRevision.
+// Check the length with:
+//
+//	len(mockedStorer.GetDatasetRevisionCalls())
+func (mock *StorerMock) GetDatasetRevisionCalls() []struct {
+	Ctx       context.Context
+	DatasetID string
+	Revision  int
+} {
+	var calls []struct {
+		Ctx       context.Context
+		DatasetID string
+		Revision  int
+	}
+	mock.lockGetDatasetRevision.RLock()
+	calls = mock.calls.GetDatasetRevision
+	mock.lockGetDatasetRevision.RUnlock()
+	return calls
+}
+
+// GetDatasetRevisions calls GetDatasetRevisionsFunc.
+func (mock *StorerMock) GetDatasetRevisions(ctx context.Context, datasetID string, offset int, limit int) ([]*models.DatasetRevision, int, error) {
+	if mock.GetDatasetRevisionsFunc == nil {
+		panic("StorerMock.GetDatasetRevisionsFunc: method is nil but Storer.GetDatasetRevisions was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		DatasetID string
+		Offset    int
+		Limit     int
+	}{
+		Ctx:       ctx,
+		DatasetID: datasetID,
+		Offset:    offset,
+		Limit:     limit,
+	}
+	mock.lockGetDatasetRevisions.Lock()
+	mock.calls.GetDatasetRevisions = append(mock.calls.GetDatasetRevisions, callInfo)
+	mock.lockGetDatasetRevisions.Unlock()
+	return mock.GetDatasetRevisionsFunc(ctx, datasetID, offset, limit)
+}
+
+// GetDatasetRevisionsCalls gets all the calls that were made to GetDatasetRevisions.
+// Check the length with:
+//
+//	len(mockedStorer.GetDatasetRevisionsCalls())
+func (mock *StorerMock) GetDatasetRevisionsCalls() []struct {
+	Ctx       context.Context
+	DatasetID string
+	Offset    int
+	Limit     int
+} {
+	var calls []struct {
+		Ctx       context.Context
+		DatasetID string
+		Offset    int
+		Limit     int
+	}
+	mock.lockGetDatasetRevisions.RLock()
+	calls = mock.calls.GetDatasetRevisions
+	mock.lockGetDatasetRevisions.RUnlock()
+	return calls
+}
+
 // GetDatasetType calls GetDatasetTypeFunc.
 func (mock *StorerMock) GetDatasetType(ctx context.Context, datasetID string, authorised bool) (string, error) {
 	if mock.GetDatasetTypeFunc == nil {
@@ -1828,7 +2510,7 @@ func (mock *StorerMock) GetDatasetTypeCalls() []struct {
 }
 
 // GetDatasets calls GetDatasetsFunc.
-func (mock *StorerMock) GetDatasets(ctx context.Context, offset int, limit int, authorised bool) ([]*models.DatasetUpdate, int, error) {
+func (mock *StorerMock) GetDatasets(ctx context.Context, offset int, limit int, cursor *pagination.Cursor, authorised bool) ([]*models.DatasetUpdate, int, *pagination.Cursor, error) {
 	if mock.GetDatasetsFunc == nil {
 		panic("StorerMock.GetDatasetsFunc: method is nil but Storer.GetDatasets was just called")
 	}
@@ -1836,17 +2518,19 @@ func (mock *StorerMock) GetDatasets(ctx context.Context, offset int, limit int,
 		Ctx        context.Context
 		Offset     int
 		Limit      int
+		Cursor     *pagination.Cursor
 		Authorised bool
 	}{
 		Ctx:        ctx,
 		Offset:     offset,
 		Limit:      limit,
+		Cursor:     cursor,
 		Authorised: authorised,
 	}
 	mock.lockGetDatasets.Lock()
 	mock.calls.GetDatasets = append(mock.calls.GetDatasets, callInfo)
 	mock.lockGetDatasets.Unlock()
-	return mock.GetDatasetsFunc(ctx, offset, limit, authorised)
+	return mock.GetDatasetsFunc(ctx, offset, limit, cursor, authorised)
 }
 
 // GetDatasetsCalls gets all the calls that were made to GetDatasets.
@@ -1857,12 +2541,14 @@ func (mock *StorerMock) GetDatasetsCalls() []struct {
 	Ctx        context.Context
 	Offset     int
 	Limit      int
+	Cursor     *pagination.Cursor
 	Authorised bool
 } {
 	var calls []struct {
 		Ctx        context.Context
 		Offset     int
 		Limit      int
+		Cursor     *pagination.Cursor
 		Authorised bool
 	}
 	mock.lockGetDatasets.RLock()
@@ -1871,8 +2557,48 @@ func (mock *StorerMock) GetDatasetsCalls() []struct {
 	return calls
 }
 
+// GetDatasetsByCollectionID calls GetDatasetsByCollectionIDFunc.
+func (mock *StorerMock) GetDatasetsByCollectionID(ctx context.Context, collectionID string, states []string) ([]*models.DatasetUpdate, error) {
+	if mock.GetDatasetsByCollectionIDFunc == nil {
+		panic("StorerMock.GetDatasetsByCollectionIDFunc: method is nil but Storer.GetDatasetsByCollectionID was just called")
+	}
+	callInfo := struct {
+		Ctx          context.Context
+		CollectionID string
+		States       []string
+	}{
+		Ctx:          ctx,
+		CollectionID: collectionID,
+		States:       states,
+	}
+	mock.lockGetDatasetsByCollectionID.Lock()
+	mock.calls.GetDatasetsByCollectionID = append(mock.calls.GetDatasetsByCollectionID, callInfo)
+	mock.lockGetDatasetsByCollectionID.Unlock()
+	return mock.GetDatasetsByCollectionIDFunc(ctx, collectionID, states)
+}
+
+// GetDatasetsByCollectionIDCalls gets all the calls that were made to GetDatasetsByCollectionID.
+// Check the length with:
+//
+//	len(mockedStorer.GetDatasetsByCollectionIDCalls())
+func (mock *StorerMock) GetDatasetsByCollectionIDCalls() []struct {
+	Ctx          context.Context
+	CollectionID string
+	States       []string
+} {
+	var calls []struct {
+		Ctx          context.Context
+		CollectionID string
+		States       []string
+	}
+	mock.lockGetDatasetsByCollectionID.RLock()
+	calls = mock.calls.GetDatasetsByCollectionID
+	mock.lockGetDatasetsByCollectionID.RUnlock()
+	return calls
+}
+
 // GetDatasetsByQueryParams calls GetDatasetsByQueryParamsFunc.
-func (mock *StorerMock) GetDatasetsByQueryParams(ctx context.Context, ID string, datasetType string, sortOrder string, datasetID string, offset int, limit int, authorised bool) ([]*models.DatasetUpdate, int, error) {
+func (mock *StorerMock) GetDatasetsByQueryParams(ctx context.Context, ID string, datasetType string, sortOrder string, datasetID string, offset int, limit int, cursor *pagination.Cursor, authorised bool) ([]*models.DatasetUpdate, int, *pagination.Cursor, error) {
 	if mock.GetDatasetsByQueryParamsFunc == nil {
 		panic("StorerMock.GetDatasetsByQueryParamsFunc: method is nil but Storer.GetDatasetsByQueryParams was just called")
 	}
@@ -1884,6 +2610,7 @@ func (mock *StorerMock) GetDatasetsByQueryParams(ctx context.Context, ID string,
 		DatasetID   string
 		Offset      int
 		Limit       int
+		Cursor      *pagination.Cursor
 		Authorised  bool
 	}{
 		Ctx:         ctx,
@@ -1893,12 +2620,13 @@ func (mock *StorerMock) GetDatasetsByQueryParams(ctx context.Context, ID string,
 		DatasetID:   datasetID,
 		Offset:      offset,
 		Limit:       limit,
+		Cursor:      cursor,
 		Authorised:  authorised,
 	}
 	mock.lockGetDatasetsByQueryParams.Lock()
 	mock.calls.GetDatasetsByQueryParams = append(mock.calls.GetDatasetsByQueryParams, callInfo)
 	mock.lockGetDatasetsByQueryParams.Unlock()
-	return mock.GetDatasetsByQueryParamsFunc(ctx, ID, datasetType, sortOrder, datasetID, offset, limit, authorised)
+	return mock.GetDatasetsByQueryParamsFunc(ctx, ID, datasetType, sortOrder, datasetID, offset, limit, cursor, authorised)
 }
 
 // GetDatasetsByQueryParamsCalls gets all the calls that were made to GetDatasetsByQueryParams.
@@ -1913,6 +2641,7 @@ func (mock *StorerMock) GetDatasetsByQueryParamsCalls() []struct {
 	DatasetID   string
 	Offset      int
 	Limit       int
+	Cursor      *pagination.Cursor
 	Authorised  bool
 } {
 	var calls []struct {
@@ -1923,6 +2652,7 @@ func (mock *StorerMock) GetDatasetsByQueryParamsCalls() []struct {
 		DatasetID   string
 		Offset      int
 		Limit       int
+		Cursor      *pagination.Cursor
 		Authorised  bool
 	}
 	mock.lockGetDatasetsByQueryParams.RLock()
@@ -1931,8 +2661,48 @@ func (mock *StorerMock) GetDatasetsByQueryParamsCalls() []struct {
 	return calls
 }
 
+// GetDeletedStaticVersions calls GetDeletedStaticVersionsFunc.
+func (mock *StorerMock) GetDeletedStaticVersions(ctx context.Context, before time.Time, limit int) ([]*models.Version, error) {
+	if mock.GetDeletedStaticVersionsFunc == nil {
+		panic("StorerMock.GetDeletedStaticVersionsFunc: method is nil but Storer.GetDeletedStaticVersions was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Before time.Time
+		Limit  int
+	}{
+		Ctx:    ctx,
+		Before: before,
+		Limit:  limit,
+	}
+	mock.lockGetDeletedStaticVersions.Lock()
+	mock.calls.GetDeletedStaticVersions = append(mock.calls.GetDeletedStaticVersions, callInfo)
+	mock.lockGetDeletedStaticVersions.Unlock()
+	return mock.GetDeletedStaticVersionsFunc(ctx, before, limit)
+}
+
+// GetDeletedStaticVersionsCalls gets all the calls that were made to GetDeletedStaticVersions.
+// Check the length with:
+//
+//	len(mockedStorer.GetDeletedStaticVersionsCalls())
+func (mock *StorerMock) GetDeletedStaticVersionsCalls() []struct {
+	Ctx    context.Context
+	Before time.Time
+	Limit  int
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Before time.Time
+		Limit  int
+	}
+	mock.lockGetDeletedStaticVersions.RLock()
+	calls = mock.calls.GetDeletedStaticVersions
+	mock.lockGetDeletedStaticVersions.RUnlock()
+	return calls
+}
+
 // GetDimensionOptions calls GetDimensionOptionsFunc.
-func (mock *StorerMock) GetDimensionOptions(ctx context.Context, version *models.Version, dimension string, offset int, limit int) ([]*models.PublicDimensionOption, int, error) {
+func (mock *StorerMock) GetDimensionOptions(ctx context.Context, version *models.Version, dimension string, offset int, limit int, cursor *pagination.Cursor) ([]*models.PublicDimensionOption, int, *pagination.Cursor, error) {
 	if mock.GetDimensionOptionsFunc == nil {
 		panic("StorerMock.GetDimensionOptionsFunc: method is nil but Storer.GetDimensionOptions was just called")
 	}
@@ -1942,17 +2712,19 @@ func (mock *StorerMock) GetDimensionOptions(ctx context.Context, version *models
 		Dimension string
 		Offset    int
 		Limit     int
+		Cursor    *pagination.Cursor
 	}{
 		Ctx:       ctx,
 		Version:   version,
 		Dimension: dimension,
 		Offset:    offset,
 		Limit:     limit,
+		Cursor:    cursor,
 	}
 	mock.lockGetDimensionOptions.Lock()
 	mock.calls.GetDimensionOptions = append(mock.calls.GetDimensionOptions, callInfo)
 	mock.lockGetDimensionOptions.Unlock()
-	return mock.GetDimensionOptionsFunc(ctx, version, dimension, offset, limit)
+	return mock.GetDimensionOptionsFunc(ctx, version, dimension, offset, limit, cursor)
 }
 
 // GetDimensionOptionsCalls gets all the calls that were made to GetDimensionOptions.
@@ -1965,6 +2737,7 @@ func (mock *StorerMock) GetDimensionOptionsCalls() []struct {
 	Dimension string
 	Offset    int
 	Limit     int
+	Cursor    *pagination.Cursor
 } {
 	var calls []struct {
 		Ctx       context.Context
@@ -1972,6 +2745,7 @@ func (mock *StorerMock) GetDimensionOptionsCalls() []struct {
 		Dimension string
 		Offset    int
 		Limit     int
+		Cursor    *pagination.Cursor
 	}
 	mock.lockGetDimensionOptions.RLock()
 	calls = mock.calls.GetDimensionOptions
@@ -2240,7 +3014,7 @@ func (mock *StorerMock) GetInstanceCalls() []struct {
 }
 
 // GetInstances calls GetInstancesFunc.
-func (mock *StorerMock) GetInstances(ctx context.Context, states []string, datasets []string, offset int, limit int) ([]*models.Instance, int, error) {
+func (mock *StorerMock) GetInstances(ctx context.Context, states []string, datasets []string, offset int, limit int, cursor *pagination.Cursor) ([]*models.Instance, int, *pagination.Cursor, error) {
 	if mock.GetInstancesFunc == nil {
 		panic("StorerMock.GetInstancesFunc: method is nil but Storer.GetInstances was just called")
 	}
@@ -2250,17 +3024,19 @@ func (mock *StorerMock) GetInstances(ctx context.Context, states []string, datas
 		Datasets []string
 		Offset   int
 		Limit    int
+		Cursor   *pagination.Cursor
 	}{
 		Ctx:      ctx,
 		States:   states,
 		Datasets: datasets,
 		Offset:   offset,
 		Limit:    limit,
+		Cursor:   cursor,
 	}
 	mock.lockGetInstances.Lock()
 	mock.calls.GetInstances = append(mock.calls.GetInstances, callInfo)
 	mock.lockGetInstances.Unlock()
-	return mock.GetInstancesFunc(ctx, states, datasets, offset, limit)
+	return mock.GetInstancesFunc(ctx, states, datasets, offset, limit, cursor)
 }
 
 // GetInstancesCalls gets all the calls that were made to GetInstances.
@@ -2273,6 +3049,7 @@ func (mock *StorerMock) GetInstancesCalls() []struct {
 	Datasets []string
 	Offset   int
 	Limit    int
+	Cursor   *pagination.Cursor
 } {
 	var calls []struct {
 		Ctx      context.Context
@@ -2280,6 +3057,7 @@ func (mock *StorerMock) GetInstancesCalls() []struct {
 		Datasets []string
 		Offset   int
 		Limit    int
+		Cursor   *pagination.Cursor
 	}
 	mock.lockGetInstances.RLock()
 	calls = mock.calls.GetInstances
@@ -2287,6 +3065,50 @@ func (mock *StorerMock) GetInstancesCalls() []struct {
 	return calls
 }
 
+// GetLatestVersion calls GetLatestVersionFunc.
+func (mock *StorerMock) GetLatestVersion(ctx context.Context, datasetID string, editionID string, state string) (*models.Version, error) {
+	if mock.GetLatestVersionFunc == nil {
+		panic("StorerMock.GetLatestVersionFunc: method is nil but Storer.GetLatestVersion was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		DatasetID string
+		EditionID string
+		State     string
+	}{
+		Ctx:       ctx,
+		DatasetID: datasetID,
+		EditionID: editionID,
+		State:     state,
+	}
+	mock.lockGetLatestVersion.Lock()
+	mock.calls.GetLatestVersion = append(mock.calls.GetLatestVersion, callInfo)
+	mock.lockGetLatestVersion.Unlock()
+	return mock.GetLatestVersionFunc(ctx, datasetID, editionID, state)
+}
+
+// GetLatestVersionCalls gets all the calls that were made to GetLatestVersion.
+// Check the length with:
+//
+//	len(mockedStorer.GetLatestVersionCalls())
+func (mock *StorerMock) GetLatestVersionCalls() []struct {
+	Ctx       context.Context
+	DatasetID string
+	EditionID string
+	State     string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		DatasetID string
+		EditionID string
+		State     string
+	}
+	mock.lockGetLatestVersion.RLock()
+	calls = mock.calls.GetLatestVersion
+	mock.lockGetLatestVersion.RUnlock()
+	return calls
+}
+
 // GetLatestVersionStatic calls GetLatestVersionStaticFunc.
 func (mock *StorerMock) GetLatestVersionStatic(ctx context.Context, datasetID string, editionID string, state string) (*models.Version, error) {
 	if mock.GetLatestVersionStaticFunc == nil {
@@ -2371,6 +3193,86 @@ func (mock *StorerMock) GetNextVersionCalls() []struct {
 	return calls
 }
 
+// GetNextVersionStatic calls GetNextVersionStaticFunc.
+func (mock *StorerMock) GetNextVersionStatic(ctx context.Context, datasetID string, editionID string) (int, error) {
+	if mock.GetNextVersionStaticFunc == nil {
+		panic("StorerMock.GetNextVersionStaticFunc: method is nil but Storer.GetNextVersionStatic was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		DatasetID string
+		EditionID string
+	}{
+		Ctx:       ctx,
+		DatasetID: datasetID,
+		EditionID: editionID,
+	}
+	mock.lockGetNextVersionStatic.Lock()
+	mock.calls.GetNextVersionStatic = append(mock.calls.GetNextVersionStatic, callInfo)
+	mock.lockGetNextVersionStatic.Unlock()
+	return mock.GetNextVersionStaticFunc(ctx, datasetID, editionID)
+}
+
+// GetNextVersionStaticCalls gets all the calls that were made to GetNextVersionStatic.
+// Check the length with:
+//
+//	len(mockedStorer.GetNextVersionStaticCalls())
+func (mock *StorerMock) GetNextVersionStaticCalls() []struct {
+	Ctx       context.Context
+	DatasetID string
+	EditionID string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		DatasetID string
+		EditionID string
+	}
+	mock.lockGetNextVersionStatic.RLock()
+	calls = mock.calls.GetNextVersionStatic
+	mock.lockGetNextVersionStatic.RUnlock()
+	return calls
+}
+
+// GetScheduledVersions calls GetScheduledVersionsFunc.
+func (mock *StorerMock) GetScheduledVersions(ctx context.Context, offset int, limit int) ([]*models.Version, int, error) {
+	if mock.GetScheduledVersionsFunc == nil {
+		panic("StorerMock.GetScheduledVersionsFunc: method is nil but Storer.GetScheduledVersions was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Offset int
+		Limit  int
+	}{
+		Ctx:    ctx,
+		Offset: offset,
+		Limit:  limit,
+	}
+	mock.lockGetScheduledVersions.Lock()
+	mock.calls.GetScheduledVersions = append(mock.calls.GetScheduledVersions, callInfo)
+	mock.lockGetScheduledVersions.Unlock()
+	return mock.GetScheduledVersionsFunc(ctx, offset, limit)
+}
+
+// GetScheduledVersionsCalls gets all the calls that were made to GetScheduledVersions.
+// Check the length with:
+//
+//	len(mockedStorer.GetScheduledVersionsCalls())
+func (mock *StorerMock) GetScheduledVersionsCalls() []struct {
+	Ctx    context.Context
+	Offset int
+	Limit  int
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Offset int
+		Limit  int
+	}
+	mock.lockGetScheduledVersions.RLock()
+	calls = mock.calls.GetScheduledVersions
+	mock.lockGetScheduledVersions.RUnlock()
+	return calls
+}
+
 // GetStaticVersionsByState calls GetStaticVersionsByStateFunc.
 func (mock *StorerMock) GetStaticVersionsByState(ctx context.Context, state string, publishedOnly string, offset int, limit int) ([]*models.Version, int, error) {
 	if mock.GetStaticVersionsByStateFunc == nil {
@@ -2607,6 +3509,46 @@ func (mock *StorerMock) GetVersionsCalls() []struct {
 	return calls
 }
 
+// GetVersionsByCollectionID calls GetVersionsByCollectionIDFunc.
+func (mock *StorerMock) GetVersionsByCollectionID(ctx context.Context, collectionID string, states []string) ([]*models.Version, error) {
+	if mock.GetVersionsByCollectionIDFunc == nil {
+		panic("StorerMock.GetVersionsByCollectionIDFunc: method is nil but Storer.GetVersionsByCollectionID was just called")
+	}
+	callInfo := struct {
+		Ctx          context.Context
+		CollectionID string
+		States       []string
+	}{
+		Ctx:          ctx,
+		CollectionID: collectionID,
+		States:       states,
+	}
+	mock.lockGetVersionsByCollectionID.Lock()
+	mock.calls.GetVersionsByCollectionID = append(mock.calls.GetVersionsByCollectionID, callInfo)
+	mock.lockGetVersionsByCollectionID.Unlock()
+	return mock.GetVersionsByCollectionIDFunc(ctx, collectionID, states)
+}
+
+// GetVersionsByCollectionIDCalls gets all the calls that were made to GetVersionsByCollectionID.
+// Check the length with:
+//
+//	len(mockedStorer.GetVersionsByCollectionIDCalls())
+func (mock *StorerMock) GetVersionsByCollectionIDCalls() []struct {
+	Ctx          context.Context
+	CollectionID string
+	States       []string
+} {
+	var calls []struct {
+		Ctx          context.Context
+		CollectionID string
+		States       []string
+	}
+	mock.lockGetVersionsByCollectionID.RLock()
+	calls = mock.calls.GetVersionsByCollectionID
+	mock.lockGetVersionsByCollectionID.RUnlock()
+	return calls
+}
+
 // GetVersionsStatic calls GetVersionsStaticFunc.
 func (mock *StorerMock) GetVersionsStatic(ctx context.Context, datasetID string, edition string, state string, offset int, limit int) ([]models.Version, int, error) {
 	if mock.GetVersionsStaticFunc == nil {
@@ -2695,6 +3637,146 @@ func (mock *StorerMock) IsStaticDatasetCalls() []struct {
 	return calls
 }
 
+// LockScheduledPublishing calls LockScheduledPublishingFunc.
+func (mock *StorerMock) LockScheduledPublishing(ctx context.Context) (string, error) {
+	if mock.LockScheduledPublishingFunc == nil {
+		panic("StorerMock.LockScheduledPublishingFunc: method is nil but Storer.LockScheduledPublishing was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockLockScheduledPublishing.Lock()
+	mock.calls.LockScheduledPublishing = append(mock.calls.LockScheduledPublishing, callInfo)
+	mock.lockLockScheduledPublishing.Unlock()
+	return mock.LockScheduledPublishingFunc(ctx)
+}
+
+// LockScheduledPublishingCalls gets all the calls that were made to LockScheduledPublishing.
+// Check the length with:
+//
+//	len(mockedStorer.LockScheduledPublishingCalls())
+func (mock *StorerMock) LockScheduledPublishingCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockLockScheduledPublishing.RLock()
+	calls = mock.calls.LockScheduledPublishing
+	mock.lockLockScheduledPublishing.RUnlock()
+	return calls
+}
+
+// PurgeDeletedDatasets calls PurgeDeletedDatasetsFunc.
+func (mock *StorerMock) PurgeDeletedDatasets(ctx context.Context, before time.Time) (int, error) {
+	if mock.PurgeDeletedDatasetsFunc == nil {
+		panic("StorerMock.PurgeDeletedDatasetsFunc: method is nil but Storer.PurgeDeletedDatasets was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Before time.Time
+	}{
+		Ctx:    ctx,
+		Before: before,
+	}
+	mock.lockPurgeDeletedDatasets.Lock()
+	mock.calls.PurgeDeletedDatasets = append(mock.calls.PurgeDeletedDatasets, callInfo)
+	mock.lockPurgeDeletedDatasets.Unlock()
+	return mock.PurgeDeletedDatasetsFunc(ctx, before)
+}
+
+// PurgeDeletedDatasetsCalls gets all the calls that were made to PurgeDeletedDatasets.
+// Check the length with:
+//
+//	len(mockedStorer.PurgeDeletedDatasetsCalls())
+func (mock *StorerMock) PurgeDeletedDatasetsCalls() []struct {
+	Ctx    context.Context
+	Before time.Time
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Before time.Time
+	}
+	mock.lockPurgeDeletedDatasets.RLock()
+	calls = mock.calls.PurgeDeletedDatasets
+	mock.lockPurgeDeletedDatasets.RUnlock()
+	return calls
+}
+
+// PurgeSentOutboxMessages calls PurgeSentOutboxMessagesFunc.
+func (mock *StorerMock) PurgeSentOutboxMessages(ctx context.Context, before time.Time) (int, error) {
+	if mock.PurgeSentOutboxMessagesFunc == nil {
+		panic("StorerMock.PurgeSentOutboxMessagesFunc: method is nil but Storer.PurgeSentOutboxMessages was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Before time.Time
+	}{
+		Ctx:    ctx,
+		Before: before,
+	}
+	mock.lockPurgeSentOutboxMessages.Lock()
+	mock.calls.PurgeSentOutboxMessages = append(mock.calls.PurgeSentOutboxMessages, callInfo)
+	mock.lockPurgeSentOutboxMessages.Unlock()
+	return mock.PurgeSentOutboxMessagesFunc(ctx, before)
+}
+
+// PurgeSentOutboxMessagesCalls gets all the calls that were made to PurgeSentOutboxMessages.
+// Check the length with:
+//
+//	len(mockedStorer.PurgeSentOutboxMessagesCalls())
+func (mock *StorerMock) PurgeSentOutboxMessagesCalls() []struct {
+	Ctx    context.Context
+	Before time.Time
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Before time.Time
+	}
+	mock.lockPurgeSentOutboxMessages.RLock()
+	calls = mock.calls.PurgeSentOutboxMessages
+	mock.lockPurgeSentOutboxMessages.RUnlock()
+	return calls
+}
+
+// PurgeStaticVersion calls PurgeStaticVersionFunc.
+func (mock *StorerMock) PurgeStaticVersion(ctx context.Context, ID string) error {
+	if mock.PurgeStaticVersionFunc == nil {
+		panic("StorerMock.PurgeStaticVersionFunc: method is nil but Storer.PurgeStaticVersion was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  string
+	}{
+		Ctx: ctx,
+		ID:  ID,
+	}
+	mock.lockPurgeStaticVersion.Lock()
+	mock.calls.PurgeStaticVersion = append(mock.calls.PurgeStaticVersion, callInfo)
+	mock.lockPurgeStaticVersion.Unlock()
+	return mock.PurgeStaticVersionFunc(ctx, ID)
+}
+
+// PurgeStaticVersionCalls gets all the calls that were made to PurgeStaticVersion.
+// Check the length with:
+//
+//	len(mockedStorer.PurgeStaticVersionCalls())
+func (mock *StorerMock) PurgeStaticVersionCalls() []struct {
+	Ctx context.Context
+	ID  string
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  string
+	}
+	mock.lockPurgeStaticVersion.RLock()
+	calls = mock.calls.PurgeStaticVersion
+	mock.lockPurgeStaticVersion.RUnlock()
+	return calls
+}
+
 // RemoveDatasetVersionAndEditionLinks calls RemoveDatasetVersionAndEditionLinksFunc.
 func (mock *StorerMock) RemoveDatasetVersionAndEditionLinks(ctx context.Context, id string) error {
 	if mock.RemoveDatasetVersionAndEditionLinksFunc == nil {
@@ -2731,6 +3813,198 @@ func (mock *StorerMock) RemoveDatasetVersionAndEditionLinksCalls() []struct {
 	return calls
 }
 
+// RequeueFailedOutboxMessages calls RequeueFailedOutboxMessagesFunc.
+func (mock *StorerMock) RequeueFailedOutboxMessages(ctx context.Context, now time.Time) (int, error) {
+	if mock.RequeueFailedOutboxMessagesFunc == nil {
+		panic("StorerMock.RequeueFailedOutboxMessagesFunc: method is nil but Storer.RequeueFailedOutboxMessages was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Now time.Time
+	}{
+		Ctx: ctx,
+		Now: now,
+	}
+	mock.lockRequeueFailedOutboxMessages.Lock()
+	mock.calls.RequeueFailedOutboxMessages = append(mock.calls.RequeueFailedOutboxMessages, callInfo)
+	mock.lockRequeueFailedOutboxMessages.Unlock()
+	return mock.RequeueFailedOutboxMessagesFunc(ctx, now)
+}
+
+// RequeueFailedOutboxMessagesCalls gets all the calls that were made to RequeueFailedOutboxMessages.
+// Check the length with:
+//
+//	len(mockedStorer.RequeueFailedOutboxMessagesCalls())
+func (mock *StorerMock) RequeueFailedOutboxMessagesCalls() []struct {
+	Ctx context.Context
+	Now time.Time
+} {
+	var calls []struct {
+		Ctx context.Context
+		Now time.Time
+	}
+	mock.lockRequeueFailedOutboxMessages.RLock()
+	calls = mock.calls.RequeueFailedOutboxMessages
+	mock.lockRequeueFailedOutboxMessages.RUnlock()
+	return calls
+}
+
+// RestoreDataset calls RestoreDatasetFunc.
+func (mock *StorerMock) RestoreDataset(ctx context.Context, ID string) error {
+	if mock.RestoreDatasetFunc == nil {
+		panic("StorerMock.RestoreDatasetFunc: method is nil but Storer.RestoreDataset was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  string
+	}{
+		Ctx: ctx,
+		ID:  ID,
+	}
+	mock.lockRestoreDataset.Lock()
+	mock.calls.RestoreDataset = append(mock.calls.RestoreDataset, callInfo)
+	mock.lockRestoreDataset.Unlock()
+	return mock.RestoreDatasetFunc(ctx, ID)
+}
+
+// RestoreDatasetCalls gets all the calls that were made to RestoreDataset.
+// Check the length with:
+//
+//	len(mockedStorer.RestoreDatasetCalls())
+func (mock *StorerMock) RestoreDatasetCalls() []struct {
+	Ctx context.Context
+	ID  string
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  string
+	}
+	mock.lockRestoreDataset.RLock()
+	calls = mock.calls.RestoreDataset
+	mock.lockRestoreDataset.RUnlock()
+	return calls
+}
+
+// RestoreEdition calls RestoreEditionFunc.
+func (mock *StorerMock) RestoreEdition(ctx context.Context, datasetID string, edition string) error {
+	if mock.RestoreEditionFunc == nil {
+		panic("StorerMock.RestoreEditionFunc: method is nil but Storer.RestoreEdition was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		DatasetID string
+		Edition   string
+	}{
+		Ctx:       ctx,
+		DatasetID: datasetID,
+		Edition:   edition,
+	}
+	mock.lockRestoreEdition.Lock()
+	mock.calls.RestoreEdition = append(mock.calls.RestoreEdition, callInfo)
+	mock.lockRestoreEdition.Unlock()
+	return mock.RestoreEditionFunc(ctx, datasetID, edition)
+}
+
+// RestoreEditionCalls gets all the calls that were made to RestoreEdition.
+// Check the length with:
+//
+//	len(mockedStorer.RestoreEditionCalls())
+func (mock *StorerMock) RestoreEditionCalls() []struct {
+	Ctx       context.Context
+	DatasetID string
+	Edition   string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		DatasetID string
+		Edition   string
+	}
+	mock.lockRestoreEdition.RLock()
+	calls = mock.calls.RestoreEdition
+	mock.lockRestoreEdition.RUnlock()
+	return calls
+}
+
+// RestoreStaticVersion calls RestoreStaticVersionFunc.
+func (mock *StorerMock) RestoreStaticVersion(ctx context.Context, datasetID string, edition string, version int) error {
+	if mock.RestoreStaticVersionFunc == nil {
+		panic("StorerMock.RestoreStaticVersionFunc: method is nil but Storer.RestoreStaticVersion was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		DatasetID string
+		Edition   string
+		Version   int
+	}{
+		Ctx:       ctx,
+		DatasetID: datasetID,
+		Edition:   edition,
+		Version:   version,
+	}
+	mock.lockRestoreStaticVersion.Lock()
+	mock.calls.RestoreStaticVersion = append(mock.calls.RestoreStaticVersion, callInfo)
+	mock.lockRestoreStaticVersion.Unlock()
+	return mock.RestoreStaticVersionFunc(ctx, datasetID, edition, version)
+}
+
+// RestoreStaticVersionCalls gets all the calls that were made to RestoreStaticVersion.
+// Check the length with:
+//
+//	len(mockedStorer.RestoreStaticVersionCalls())
+func (mock *StorerMock) RestoreStaticVersionCalls() []struct {
+	Ctx       context.Context
+	DatasetID string
+	Edition   string
+	Version   int
+} {
+	var calls []struct {
+		Ctx       context.Context
+		DatasetID string
+		Edition   string
+		Version   int
+	}
+	mock.lockRestoreStaticVersion.RLock()
+	calls = mock.calls.RestoreStaticVersion
+	mock.lockRestoreStaticVersion.RUnlock()
+	return calls
+}
+
+// RunTransaction calls RunTransactionFunc.
+func (mock *StorerMock) RunTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if mock.RunTransactionFunc == nil {
+		panic("StorerMock.RunTransactionFunc: method is nil but Storer.RunTransaction was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Fn  func(ctx context.Context) error
+	}{
+		Ctx: ctx,
+		Fn:  fn,
+	}
+	mock.lockRunTransaction.Lock()
+	mock.calls.RunTransaction = append(mock.calls.RunTransaction, callInfo)
+	mock.lockRunTransaction.Unlock()
+	return mock.RunTransactionFunc(ctx, fn)
+}
+
+// RunTransactionCalls gets all the calls that were made to RunTransaction.
+// Check the length with:
+//
+//	len(mockedStorer.RunTransactionCalls())
+func (mock *StorerMock) RunTransactionCalls() []struct {
+	Ctx context.Context
+	Fn  func(ctx context.Context) error
+} {
+	var calls []struct {
+		Ctx context.Context
+		Fn  func(ctx context.Context) error
+	}
+	mock.lockRunTransaction.RLock()
+	calls = mock.calls.RunTransaction
+	mock.lockRunTransaction.RUnlock()
+	return calls
+}
+
 // SetInstanceIsPublished calls SetInstanceIsPublishedFunc.
 func (mock *StorerMock) SetInstanceIsPublished(ctx context.Context, instanceID string) error {
 	if mock.SetInstanceIsPublishedFunc == nil {
@@ -2803,6 +4077,42 @@ func (mock *StorerMock) UnlockInstanceCalls() []struct {
 	return calls
 }
 
+// UnlockScheduledPublishing calls UnlockScheduledPublishingFunc.
+func (mock *StorerMock) UnlockScheduledPublishing(ctx context.Context, lockID string) {
+	if mock.UnlockScheduledPublishingFunc == nil {
+		panic("StorerMock.UnlockScheduledPublishingFunc: method is nil but Storer.UnlockScheduledPublishing was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		LockID string
+	}{
+		Ctx:    ctx,
+		LockID: lockID,
+	}
+	mock.lockUnlockScheduledPublishing.Lock()
+	mock.calls.UnlockScheduledPublishing = append(mock.calls.UnlockScheduledPublishing, callInfo)
+	mock.lockUnlockScheduledPublishing.Unlock()
+	mock.UnlockScheduledPublishingFunc(ctx, lockID)
+}
+
+// UnlockScheduledPublishingCalls gets all the calls that were made to UnlockScheduledPublishing.
+// Check the length with:
+//
+//	len(mockedStorer.UnlockScheduledPublishingCalls())
+func (mock *StorerMock) UnlockScheduledPublishingCalls() []struct {
+	Ctx    context.Context
+	LockID string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		LockID string
+	}
+	mock.lockUnlockScheduledPublishing.RLock()
+	calls = mock.calls.UnlockScheduledPublishing
+	mock.lockUnlockScheduledPublishing.RUnlock()
+	return calls
+}
+
 // UnlockVersions calls UnlockVersionsFunc.
 func (mock *StorerMock) UnlockVersions(ctx context.Context, lockID string) {
 	if mock.UnlockVersionsFunc == nil {
@@ -3291,6 +4601,42 @@ func (mock *StorerMock) UpdateObservationInsertedCalls() []struct {
 	return calls
 }
 
+// UpdateOutboxMessage calls UpdateOutboxMessageFunc.
+func (mock *StorerMock) UpdateOutboxMessage(ctx context.Context, message *models.OutboxMessage) error {
+	if mock.UpdateOutboxMessageFunc == nil {
+		panic("StorerMock.UpdateOutboxMessageFunc: method is nil but Storer.UpdateOutboxMessage was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Message *models.OutboxMessage
+	}{
+		Ctx:     ctx,
+		Message: message,
+	}
+	mock.lockUpdateOutboxMessage.Lock()
+	mock.calls.UpdateOutboxMessage = append(mock.calls.UpdateOutboxMessage, callInfo)
+	mock.lockUpdateOutboxMessage.Unlock()
+	return mock.UpdateOutboxMessageFunc(ctx, message)
+}
+
+// UpdateOutboxMessageCalls gets all the calls that were made to UpdateOutboxMessage.
+// Check the length with:
+//
+//	len(mockedStorer.UpdateOutboxMessageCalls())
+func (mock *StorerMock) UpdateOutboxMessageCalls() []struct {
+	Ctx     context.Context
+	Message *models.OutboxMessage
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Message *models.OutboxMessage
+	}
+	mock.lockUpdateOutboxMessage.RLock()
+	calls = mock.calls.UpdateOutboxMessage
+	mock.lockUpdateOutboxMessage.RUnlock()
+	return calls
+}
+
 // UpdateVersion calls UpdateVersionFunc.
 func (mock *StorerMock) UpdateVersion(ctx context.Context, currentVersion *models.Version, version *models.Version, eTagSelector string) (string, error) {
 	if mock.UpdateVersionFunc == nil {
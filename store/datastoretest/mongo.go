@@ -6,10 +6,12 @@ package storetest
 import (
 	"context"
 	"github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/dp-dataset-api/pagination"
 	"github.com/ONSdigital/dp-dataset-api/store"
 	"github.com/ONSdigital/dp-healthcheck/healthcheck"
 	"go.mongodb.org/mongo-driver/bson"
 	"sync"
+	"time"
 )
 
 // Ensure, that MongoDBMock does implement store.MongoDB.
@@ -34,6 +36,9 @@ var _ store.MongoDB = &MongoDBMock{}
 //			AddInstanceFunc: func(ctx context.Context, instance *models.Instance) (*models.Instance, error) {
 //				panic("mock out the AddInstance method")
 //			},
+//			AddOutboxMessageFunc: func(ctx context.Context, message *models.OutboxMessage) error {
+//				panic("mock out the AddOutboxMessage method")
+//			},
 //			AddVersionStaticFunc: func(ctx context.Context, version *models.Version) (*models.Version, error) {
 //				panic("mock out the AddVersionStatic method")
 //			},
@@ -58,37 +63,61 @@ var _ store.MongoDB = &MongoDBMock{}
 //			CheckerFunc: func(contextMoqParam context.Context, checkState *healthcheck.CheckState) error {
 //				panic("mock out the Checker method")
 //			},
+//			ClaimOutboxMessageFunc: func(ctx context.Context, owner string, lease time.Duration, skipTopics []string) (*models.OutboxMessage, error) {
+//				panic("mock out the ClaimOutboxMessage method")
+//			},
 //			CloseFunc: func(contextMoqParam context.Context) error {
 //				panic("mock out the Close method")
 //			},
+//			CountOutboxMessagesFunc: func(ctx context.Context, state string) (int, error) {
+//				panic("mock out the CountOutboxMessages method")
+//			},
 //			CreateAuditEventFunc: func(ctx context.Context, event *models.AuditEvent) error {
 //				panic("mock out the CreateAuditEvent method")
 //			},
-//			DeleteDatasetFunc: func(ctx context.Context, ID string) error {
+//			DeleteDatasetFunc: func(ctx context.Context, ID string, deletion *models.Deletion) error {
 //				panic("mock out the DeleteDataset method")
 //			},
-//			DeleteEditionFunc: func(ctx context.Context, ID string) error {
+//			DeleteEditionFunc: func(ctx context.Context, ID string, deletion *models.Deletion) error {
 //				panic("mock out the DeleteEdition method")
 //			},
-//			DeleteStaticDatasetVersionFunc: func(ctx context.Context, datasetID string, editionID string, version int) error {
+//			DeleteStaticDatasetVersionFunc: func(ctx context.Context, datasetID string, editionID string, version int, deletion *models.Deletion) error {
 //				panic("mock out the DeleteStaticDatasetVersion method")
 //			},
 //			GetAllStaticVersionsFunc: func(ctx context.Context, ID string, state string, offset int, limit int) ([]*models.Version, int, error) {
 //				panic("mock out the GetAllStaticVersions method")
 //			},
+//			GetAuditEventChainFunc: func(ctx context.Context, fromSequence int64, limit int) ([]*models.AuditEvent, error) {
+//				panic("mock out the GetAuditEventChain method")
+//			},
+//			GetAuditEventsFunc: func(ctx context.Context, filter *models.AuditEventFilter, offset int, limit int) ([]*models.AuditEvent, int, error) {
+//				panic("mock out the GetAuditEvents method")
+//			},
 //			GetDatasetFunc: func(ctx context.Context, ID string) (*models.DatasetUpdate, error) {
 //				panic("mock out the GetDataset method")
 //			},
+//			GetDatasetRevisionFunc: func(ctx context.Context, datasetID string, revision int) (*models.DatasetRevision, error) {
+//				panic("mock out the GetDatasetRevision method")
+//			},
+//			GetDatasetRevisionsFunc: func(ctx context.Context, datasetID string, offset int, limit int) ([]*models.DatasetRevision, int, error) {
+//				panic("mock out the GetDatasetRevisions method")
+//			},
 //			GetDatasetTypeFunc: func(ctx context.Context, datasetID string, authorised bool) (string, error) {
 //				panic("mock out the GetDatasetType method")
 //			},
-//			GetDatasetsFunc: func(ctx context.Context, offset int, limit int, authorised bool) ([]*models.DatasetUpdate, int, error) {
+//			GetDatasetsFunc: func(ctx context.Context, offset int, limit int, cursor *pagination.Cursor, authorised bool) ([]*models.DatasetUpdate, int, *pagination.Cursor, error) {
 //				panic("mock out the GetDatasets method")
 //			},
-//			GetDatasetsByQueryParamsFunc: func(ctx context.Context, ID string, datasetType string, sortOrder string, datasetID string, offset int, limit int, authorised bool) ([]*models.DatasetUpdate, int, error) {
+//			GetDatasetsByCollectionIDFunc: func(ctx context.Context, collectionID string, states []string) ([]*models.DatasetUpdate, error) {
+//				panic("mock out the GetDatasetsByCollectionID method")
+//			},
+//			GetDatasetsByQueryParamsFunc: func(ctx context.Context, ID string, datasetType string, sortOrder string, datasetID string, offset int, limit int, cursor *pagination.Cursor, authorised bool) ([]*models.DatasetUpdate, int, *pagination.Cursor, error) {
 //				panic("mock out the GetDatasetsByQueryParams method")
 //			},
-//			GetDimensionOptionsFunc: func(ctx context.Context, version *models.Version, dimension string, offset int, limit int) ([]*models.PublicDimensionOption, int, error) {
+//			GetDeletedStaticVersionsFunc: func(ctx context.Context, before time.Time, limit int) ([]*models.Version, error) {
+//				panic("mock out the GetDeletedStaticVersions method")
+//			},
+//			GetDimensionOptionsFunc: func(ctx context.Context, version *models.Version, dimension string, offset int, limit int, cursor *pagination.Cursor) ([]*models.PublicDimensionOption, int, *pagination.Cursor, error) {
 //				panic("mock out the GetDimensionOptions method")
 //			},
 //			GetDimensionOptionsFromIDsFunc: func(ctx context.Context, version *models.Version, dimension string, ids []string) ([]*models.PublicDimensionOption, int, error) {
@@ -109,15 +138,24 @@ var _ store.MongoDB = &MongoDBMock{}
 //			GetInstanceFunc: func(ctx context.Context, ID string, eTagSelector string) (*models.Instance, error) {
 //				panic("mock out the GetInstance method")
 //			},
-//			GetInstancesFunc: func(ctx context.Context, states []string, datasets []string, offset int, limit int) ([]*models.Instance, int, error) {
+//			GetInstancesFunc: func(ctx context.Context, states []string, datasets []string, offset int, limit int, cursor *pagination.Cursor) ([]*models.Instance, int, *pagination.Cursor, error) {
 //				panic("mock out the GetInstances method")
 //			},
+//			GetLatestVersionFunc: func(ctx context.Context, datasetID string, editionID string, state string) (*models.Version, error) {
+//				panic("mock out the GetLatestVersion method")
+//			},
 //			GetLatestVersionStaticFunc: func(ctx context.Context, datasetID string, editionID string, state string) (*models.Version, error) {
 //				panic("mock out the GetLatestVersionStatic method")
 //			},
 //			GetNextVersionFunc: func(ctx context.Context, datasetID string, editionID string) (int, error) {
 //				panic("mock out the GetNextVersion method")
 //			},
+//			GetNextVersionStaticFunc: func(ctx context.Context, datasetID string, editionID string) (int, error) {
+//				panic("mock out the GetNextVersionStatic method")
+//			},
+//			GetScheduledVersionsFunc: func(ctx context.Context, offset int, limit int) ([]*models.Version, int, error) {
+//				panic("mock out the GetScheduledVersions method")
+//			},
 //			GetStaticVersionsByStateFunc: func(ctx context.Context, state string, publishedOnly string, offset int, limit int) ([]*models.Version, int, error) {
 //				panic("mock out the GetStaticVersionsByState method")
 //			},
@@ -133,18 +171,51 @@ var _ store.MongoDB = &MongoDBMock{}
 //			GetVersionsFunc: func(ctx context.Context, datasetID string, editionID string, state string, offset int, limit int) ([]models.Version, int, error) {
 //				panic("mock out the GetVersions method")
 //			},
+//			GetVersionsByCollectionIDFunc: func(ctx context.Context, collectionID string, states []string) ([]*models.Version, error) {
+//				panic("mock out the GetVersionsByCollectionID method")
+//			},
 //			GetVersionsStaticFunc: func(ctx context.Context, datasetID string, edition string, state string, offset int, limit int) ([]models.Version, int, error) {
 //				panic("mock out the GetVersionsStatic method")
 //			},
 //			IsStaticDatasetFunc: func(ctx context.Context, datasetID string) (bool, error) {
 //				panic("mock out the IsStaticDataset method")
 //			},
+//			LockScheduledPublishingFunc: func(ctx context.Context) (string, error) {
+//				panic("mock out the LockScheduledPublishing method")
+//			},
+//			PurgeDeletedDatasetsFunc: func(ctx context.Context, before time.Time) (int, error) {
+//				panic("mock out the PurgeDeletedDatasets method")
+//			},
+//			PurgeSentOutboxMessagesFunc: func(ctx context.Context, before time.Time) (int, error) {
+//				panic("mock out the PurgeSentOutboxMessages method")
+//			},
+//			PurgeStaticVersionFunc: func(ctx context.Context, ID string) error {
+//				panic("mock out the PurgeStaticVersion method")
+//			},
 //			RemoveDatasetVersionAndEditionLinksFunc: func(ctx context.Context, id string) error {
 //				panic("mock out the RemoveDatasetVersionAndEditionLinks method")
 //			},
+//			RequeueFailedOutboxMessagesFunc: func(ctx context.Context, now time.Time) (int, error) {
+//				panic("mock out the RequeueFailedOutboxMessages method")
+//			},
+//			RestoreDatasetFunc: func(ctx context.Context, ID string) error {
+//				panic("mock out the RestoreDataset method")
+//			},
+//			RestoreEditionFunc: func(ctx context.Context, datasetID string, edition string) error {
+//				panic("mock out the RestoreEdition method")
+//			},
+//			RestoreStaticVersionFunc: func(ctx context.Context, datasetID string, edition string, version int) error {
+//				panic("mock out the RestoreStaticVersion method")
+//			},
+//			RunTransactionFunc: func(ctx context.Context, fn func(ctx context.Context) error) error {
+//				panic("mock out the RunTransaction method")
+//			},
 //			UnlockInstanceFunc: func(ctx context.Context, lockID string)  {
 //				panic("mock out the UnlockInstance method")
 //			},
+//			UnlockScheduledPublishingFunc: func(ctx context.Context, lockID string)  {
+//				panic("mock out the UnlockScheduledPublishing method")
+//			},
 //			UnlockVersionsFunc: func(ctx context.Context, lockID string)  {
 //				panic("mock out the UnlockVersions method")
 //			},
@@ -178,6 +249,9 @@ var _ store.MongoDB = &MongoDBMock{}
 //			UpdateObservationInsertedFunc: func(ctx context.Context, currentInstance *models.Instance, observationInserted int64, eTagSelector string) (string, error) {
 //				panic("mock out the UpdateObservationInserted method")
 //			},
+//			UpdateOutboxMessageFunc: func(ctx context.Context, message *models.OutboxMessage) error {
+//				panic("mock out the UpdateOutboxMessage method")
+//			},
 //			UpdateVersionFunc: func(ctx context.Context, currentVersion *models.Version, version *models.Version, eTagSelector string) (string, error) {
 //				panic("mock out the UpdateVersion method")
 //			},
@@ -221,6 +295,9 @@ type MongoDBMock struct {
 	// AddInstanceFunc mocks the AddInstance method.
 	AddInstanceFunc func(ctx context.Context, instance *models.Instance) (*models.Instance, error)
 
+	// AddOutboxMessageFunc mocks the AddOutboxMessage method.
+	AddOutboxMessageFunc func(ctx context.Context, message *models.OutboxMessage) error
+
 	// AddVersionStaticFunc mocks the AddVersionStatic method.
 	AddVersionStaticFunc func(ctx context.Context, version *models.Version) (*models.Version, error)
 
@@ -245,38 +322,62 @@ type MongoDBMock struct {
 	// CheckerFunc mocks the Checker method.
 	CheckerFunc func(contextMoqParam context.Context, checkState *healthcheck.CheckState) error
 
+	// ClaimOutboxMessageFunc mocks the ClaimOutboxMessage method.
+	ClaimOutboxMessageFunc func(ctx context.Context, owner string, lease time.Duration, skipTopics []string) (*models.OutboxMessage, error)
+
 	// CloseFunc mocks the Close method.
 	CloseFunc func(contextMoqParam context.Context) error
 
+	// CountOutboxMessagesFunc mocks the CountOutboxMessages method.
+	CountOutboxMessagesFunc func(ctx context.Context, state string) (int, error)
+
 	// CreateAuditEventFunc mocks the CreateAuditEvent method.
 	CreateAuditEventFunc func(ctx context.Context, event *models.AuditEvent) error
 
 	// DeleteDatasetFunc mocks the DeleteDataset method.
-	DeleteDatasetFunc func(ctx context.Context, ID string) error
+	DeleteDatasetFunc func(ctx context.Context, ID string, deletion *models.Deletion) error
 
 	// DeleteEditionFunc mocks the DeleteEdition method.
-	DeleteEditionFunc func(ctx context.Context, ID string) error
+	DeleteEditionFunc func(ctx context.Context, ID string, deletion *models.Deletion) error
 
 	// DeleteStaticDatasetVersionFunc mocks the DeleteStaticDatasetVersion method.
-	DeleteStaticDatasetVersionFunc func(ctx context.Context, datasetID string, editionID string, version int) error
+	DeleteStaticDatasetVersionFunc func(ctx context.Context, datasetID string, editionID string, version int, deletion *models.Deletion) error
 
 	// GetAllStaticVersionsFunc mocks the GetAllStaticVersions method.
 	GetAllStaticVersionsFunc func(ctx context.Context, ID string, state string, offset int, limit int) ([]*models.Version, int, error)
 
+	// GetAuditEventChainFunc mocks the GetAuditEventChain method.
+	GetAuditEventChainFunc func(ctx context.Context, fromSequence int64, limit int) ([]*models.AuditEvent, error)
+
+	// GetAuditEventsFunc mocks the GetAuditEvents method.
+	GetAuditEventsFunc func(ctx context.Context, filter *models.AuditEventFilter, offset int, limit int) ([]*models.AuditEvent, int, error)
+
 	// GetDatasetFunc mocks the GetDataset method.
 	GetDatasetFunc func(ctx context.Context, ID string) (*models.DatasetUpdate, error)
 
+	// GetDatasetRevisionFunc mocks the GetDatasetRevision method.
+	GetDatasetRevisionFunc func(ctx context.Context, datasetID string, revision int) (*models.DatasetRevision, error)
+
+	// GetDatasetRevisionsFunc mocks the GetDatasetRevisions method.
+	GetDatasetRevisionsFunc func(ctx context.Context, datasetID string, offset int, limit int) ([]*models.DatasetRevision, int, error)
+
 	// GetDatasetTypeFunc mocks the GetDatasetType method.
 	GetDatasetTypeFunc func(ctx context.Context, datasetID string, authorised bool) (string, error)
 
 	// GetDatasetsFunc mocks the GetDatasets method.
-	GetDatasetsFunc func(ctx context.Context, offset int, limit int, authorised bool) ([]*models.DatasetUpdate, int, error)
+	GetDatasetsFunc func(ctx context.Context, offset int, limit int, cursor *pagination.Cursor, authorised bool) ([]*models.DatasetUpdate, int, *pagination.Cursor, error)
+
+	// GetDatasetsByCollectionIDFunc mocks the GetDatasetsByCollectionID method.
+	GetDatasetsByCollectionIDFunc func(ctx context.Context, collectionID string, states []string) ([]*models.DatasetUpdate, error)
 
 	// GetDatasetsByQueryParamsFunc mocks the GetDatasetsByQueryParams method.
-	GetDatasetsByQueryParamsFunc func(ctx context.Context, ID string, datasetType string, sortOrder string, datasetID string, offset int, limit int, authorised bool) ([]*models.DatasetUpdate, int, error)
+	GetDatasetsByQueryParamsFunc func(ctx context.Context, ID string, datasetType string, sortOrder string, datasetID string, offset int, limit int, cursor *pagination.Cursor, authorised bool) ([]*models.DatasetUpdate, int, *pagination.Cursor, error)
+
+	// GetDeletedStaticVersionsFunc mocks the GetDeletedStaticVersions method.
+	GetDeletedStaticVersionsFunc func(ctx context.Context, before time.Time, limit int) ([]*models.Version, error)
 
 	// GetDimensionOptionsFunc mocks the GetDimensionOptions method.
-	GetDimensionOptionsFunc func(ctx context.Context, version *models.Version, dimension string, offset int, limit int) ([]*models.PublicDimensionOption, int, error)
+	GetDimensionOptionsFunc func(ctx context.Context, version *models.Version, dimension string, offset int, limit int, cursor *pagination.Cursor) ([]*models.PublicDimensionOption, int, *pagination.Cursor, error)
 
 	// GetDimensionOptionsFromIDsFunc mocks the GetDimensionOptionsFromIDs method.
 	GetDimensionOptionsFromIDsFunc func(ctx context.Context, version *models.Version, dimension string, ids []string) ([]*models.PublicDimensionOption, int, error)
@@ -297,7 +398,10 @@ type MongoDBMock struct {
 	GetInstanceFunc func(ctx context.Context, ID string, eTagSelector string) (*models.Instance, error)
 
 	// GetInstancesFunc mocks the GetInstances method.
-	GetInstancesFunc func(ctx context.Context, states []string, datasets []string, offset int, limit int) ([]*models.Instance, int, error)
+	GetInstancesFunc func(ctx context.Context, states []string, datasets []string, offset int, limit int, cursor *pagination.Cursor) ([]*models.Instance, int, *pagination.Cursor, error)
+
+	// GetLatestVersionFunc mocks the GetLatestVersion method.
+	GetLatestVersionFunc func(ctx context.Context, datasetID string, editionID string, state string) (*models.Version, error)
 
 	// GetLatestVersionStaticFunc mocks the GetLatestVersionStatic method.
 	GetLatestVersionStaticFunc func(ctx context.Context, datasetID string, editionID string, state string) (*models.Version, error)
@@ -305,6 +409,12 @@ type MongoDBMock struct {
 	// GetNextVersionFunc mocks the GetNextVersion method.
 	GetNextVersionFunc func(ctx context.Context, datasetID string, editionID string) (int, error)
 
+	// GetNextVersionStaticFunc mocks the GetNextVersionStatic method.
+	GetNextVersionStaticFunc func(ctx context.Context, datasetID string, editionID string) (int, error)
+
+	// GetScheduledVersionsFunc mocks the GetScheduledVersions method.
+	GetScheduledVersionsFunc func(ctx context.Context, offset int, limit int) ([]*models.Version, int, error)
+
 	// GetStaticVersionsByStateFunc mocks the GetStaticVersionsByState method.
 	GetStaticVersionsByStateFunc func(ctx context.Context, state string, publishedOnly string, offset int, limit int) ([]*models.Version, int, error)
 
@@ -320,18 +430,51 @@ type MongoDBMock struct {
 	// GetVersionsFunc mocks the GetVersions method.
 	GetVersionsFunc func(ctx context.Context, datasetID string, editionID string, state string, offset int, limit int) ([]models.Version, int, error)
 
+	// GetVersionsByCollectionIDFunc mocks the GetVersionsByCollectionID method.
+	GetVersionsByCollectionIDFunc func(ctx context.Context, collectionID string, states []string) ([]*models.Version, error)
+
 	// GetVersionsStaticFunc mocks the GetVersionsStatic method.
 	GetVersionsStaticFunc func(ctx context.Context, datasetID string, edition string, state string, offset int, limit int) ([]models.Version, int, error)
 
 	// IsStaticDatasetFunc mocks the IsStaticDataset method.
 	IsStaticDatasetFunc func(ctx context.Context, datasetID string) (bool, error)
 
+	// LockScheduledPublishingFunc mocks the LockScheduledPublishing method.
+	LockScheduledPublishingFunc func(ctx context.Context) (string, error)
+
+	// PurgeDeletedDatasetsFunc mocks the PurgeDeletedDatasets method.
+	PurgeDeletedDatasetsFunc func(ctx context.Context, before time.Time) (int, error)
+
+	// PurgeSentOutboxMessagesFunc mocks the PurgeSentOutboxMessages method.
+	PurgeSentOutboxMessagesFunc func(ctx context.Context, before time.Time) (int, error)
+
+	// PurgeStaticVersionFunc mocks the PurgeStaticVersion method.
+	PurgeStaticVersionFunc func(ctx context.Context, ID string) error
+
 	// RemoveDatasetVersionAndEditionLinksFunc mocks the RemoveDatasetVersionAndEditionLinks method.
 	RemoveDatasetVersionAndEditionLinksFunc func(ctx context.Context, id string) error
 
+	// RequeueFailedOutboxMessagesFunc mocks the RequeueFailedOutboxMessages method.
+	RequeueFailedOutboxMessagesFunc func(ctx context.Context, now time.Time) (int, error)
+
+	// RestoreDatasetFunc mocks the RestoreDataset method.
+	RestoreDatasetFunc func(ctx context.Context, ID string) error
+
+	// RestoreEditionFunc mocks the RestoreEdition method.
+	RestoreEditionFunc func(ctx context.Context, datasetID string, edition string) error
+
+	// RestoreStaticVersionFunc mocks the RestoreStaticVersion method.
+	RestoreStaticVersionFunc func(ctx context.Context, datasetID string, edition string, version int) error
+
+	// RunTransactionFunc mocks the RunTransaction method.
+	RunTransactionFunc func(ctx context.Context, fn func(ctx context.Context) error) error
+
 	// UnlockInstanceFunc mocks the UnlockInstance method.
 	UnlockInstanceFunc func(ctx context.Context, lockID string)
 
+	// UnlockScheduledPublishingFunc mocks the UnlockScheduledPublishing method.
+	UnlockScheduledPublishingFunc func(ctx context.Context, lockID string)
+
 	// UnlockVersionsFunc mocks the UnlockVersions method.
 	UnlockVersionsFunc func(ctx context.Context, lockID string)
 
@@ -365,6 +508,9 @@ type MongoDBMock struct {
 	// UpdateObservationInsertedFunc mocks the UpdateObservationInserted method.
 	UpdateObservationInsertedFunc func(ctx context.Context, currentInstance *models.Instance, observationInserted int64, eTagSelector string) (string, error)
 
+	// UpdateOutboxMessageFunc mocks the UpdateOutboxMessage method.
+	UpdateOutboxMessageFunc func(ctx context.Context, message *models.OutboxMessage) error
+
 	// UpdateVersionFunc mocks the UpdateVersion method.
 	UpdateVersionFunc func(ctx context.Context, currentVersion *models.Version, version *models.Version, eTagSelector string) (string, error)
 
@@ -423,6 +569,13 @@ type MongoDBMock struct {
 			// Instance is the instance argument value.
 			Instance *models.Instance
 		}
+		// AddOutboxMessage holds details about calls to the AddOutboxMessage method.
+		AddOutboxMessage []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Message is the message argument value.
+			Message *models.OutboxMessage
+		}
 		// AddVersionStatic holds details about calls to the AddVersionStatic method.
 		AddVersionStatic []struct {
 			// Ctx is the ctx argument value.
@@ -495,11 +648,29 @@ type MongoDBMock struct {
 			// CheckState is the checkState argument value.
 			CheckState *healthcheck.CheckState
 		}
+		// ClaimOutboxMessage holds details about calls to the ClaimOutboxMessage method.
+		ClaimOutboxMessage []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Owner is the owner argument value.
+			Owner string
+			// Lease is the lease argument value.
+			Lease time.Duration
+			// SkipTopics is the skipTopics argument value.
+			SkipTopics []string
+		}
 		// Close holds details about calls to the Close method.
 		Close []struct {
 			// ContextMoqParam is the contextMoqParam argument value.
 			ContextMoqParam context.Context
 		}
+		// CountOutboxMessages holds details about calls to the CountOutboxMessages method.
+		CountOutboxMessages []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// State is the state argument value.
+			State string
+		}
 		// CreateAuditEvent holds details about calls to the CreateAuditEvent method.
 		CreateAuditEvent []struct {
 			// Ctx is the ctx argument value.
@@ -513,6 +684,8 @@ type MongoDBMock struct {
 			Ctx context.Context
 			// ID is the ID argument value.
 			ID string
+			// Deletion is the deletion argument value.
+			Deletion *models.Deletion
 		}
 		// DeleteEdition holds details about calls to the DeleteEdition method.
 		DeleteEdition []struct {
@@ -520,6 +693,8 @@ type MongoDBMock struct {
 			Ctx context.Context
 			// ID is the ID argument value.
 			ID string
+			// Deletion is the deletion argument value.
+			Deletion *models.Deletion
 		}
 		// DeleteStaticDatasetVersion holds details about calls to the DeleteStaticDatasetVersion method.
 		DeleteStaticDatasetVersion []struct {
@@ -531,6 +706,8 @@ type MongoDBMock struct {
 			EditionID string
 			// Version is the version argument value.
 			Version int
+			// Deletion is the deletion argument value.
+			Deletion *models.Deletion
 		}
 		// GetAllStaticVersions holds details about calls to the GetAllStaticVersions method.
 		GetAllStaticVersions []struct {
@@ -545,6 +722,26 @@ type MongoDBMock struct {
 			// Limit is the limit argument value.
 			Limit int
 		}
+		// GetAuditEventChain holds details about calls to the GetAuditEventChain method.
+		GetAuditEventChain []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// FromSequence is the fromSequence argument value.
+			FromSequence int64
+			// Limit is the limit argument value.
+			Limit int
+		}
+		// GetAuditEvents holds details about calls to the GetAuditEvents method.
+		GetAuditEvents []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Filter is the filter argument value.
+			Filter *models.AuditEventFilter
+			// Offset is the offset argument value.
+			Offset int
+			// Limit is the limit argument value.
+			Limit int
+		}
 		// GetDataset holds details about calls to the GetDataset method.
 		GetDataset []struct {
 			// Ctx is the ctx argument value.
@@ -552,6 +749,26 @@ type MongoDBMock struct {
 			// ID is the ID argument value.
 			ID string
 		}
+		// GetDatasetRevision holds details about calls to the GetDatasetRevision method.
+		GetDatasetRevision []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// DatasetID is the datasetID argument value.
+			DatasetID string
+			// Revision is the revision argument value.
+			Revision int
+		}
+		// GetDatasetRevisions holds details about calls to the GetDatasetRevisions method.
+		GetDatasetRevisions []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// DatasetID is the datasetID argument value.
+			DatasetID string
+			// Offset is the offset argument value.
+			Offset int
+			// Limit is the limit argument value.
+			Limit int
+		}
 		// GetDatasetType holds details about calls to the GetDatasetType method.
 		GetDatasetType []struct {
 			// Ctx is the ctx argument value.
@@ -569,9 +786,20 @@ type MongoDBMock struct {
 			Offset int
 			// Limit is the limit argument value.
 			Limit int
+			// Cursor is the cursor argument value.
+			Cursor *pagination.Cursor
 			// Authorised is the authorised argument value.
 			Authorised bool
 		}
+		// GetDatasetsByCollectionID holds details about calls to the GetDatasetsByCollectionID method.
+		GetDatasetsByCollectionID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// CollectionID is the collectionID argument value.
+			CollectionID string
+			// States is the states argument value.
+			States []string
+		}
 		// GetDatasetsByQueryParams holds details about calls to the GetDatasetsByQueryParams method.
 		GetDatasetsByQueryParams []struct {
 			// Ctx is the ctx argument value.
@@ -588,9 +816,20 @@ type MongoDBMock struct {
 			Offset int
 			// Limit is the limit argument value.
 			Limit int
+			// Cursor is the cursor argument value.
+			Cursor *pagination.Cursor
 			// Authorised is the authorised argument value.
 			Authorised bool
 		}
+		// GetDeletedStaticVersions holds details about calls to the GetDeletedStaticVersions method.
+		GetDeletedStaticVersions []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Before is the before argument value.
+			Before time.Time
+			// Limit is the limit argument value.
+			Limit int
+		}
 		// GetDimensionOptions holds details about calls to the GetDimensionOptions method.
 		GetDimensionOptions []struct {
 			// Ctx is the ctx argument value.
@@ -603,6 +842,8 @@ type MongoDBMock struct {
 			Offset int
 			// Limit is the limit argument value.
 			Limit int
+			// Cursor is the cursor argument value.
+			Cursor *pagination.Cursor
 		}
 		// GetDimensionOptionsFromIDs holds details about calls to the GetDimensionOptionsFromIDs method.
 		GetDimensionOptionsFromIDs []struct {
@@ -680,6 +921,19 @@ type MongoDBMock struct {
 			Offset int
 			// Limit is the limit argument value.
 			Limit int
+			// Cursor is the cursor argument value.
+			Cursor *pagination.Cursor
+		}
+		// GetLatestVersion holds details about calls to the GetLatestVersion method.
+		GetLatestVersion []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// DatasetID is the datasetID argument value.
+			DatasetID string
+			// EditionID is the editionID argument value.
+			EditionID string
+			// State is the state argument value.
+			State string
 		}
 		// GetLatestVersionStatic holds details about calls to the GetLatestVersionStatic method.
 		GetLatestVersionStatic []struct {
@@ -701,6 +955,24 @@ type MongoDBMock struct {
 			// EditionID is the editionID argument value.
 			EditionID string
 		}
+		// GetNextVersionStatic holds details about calls to the GetNextVersionStatic method.
+		GetNextVersionStatic []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// DatasetID is the datasetID argument value.
+			DatasetID string
+			// EditionID is the editionID argument value.
+			EditionID string
+		}
+		// GetScheduledVersions holds details about calls to the GetScheduledVersions method.
+		GetScheduledVersions []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Offset is the offset argument value.
+			Offset int
+			// Limit is the limit argument value.
+			Limit int
+		}
 		// GetStaticVersionsByState holds details about calls to the GetStaticVersionsByState method.
 		GetStaticVersionsByState []struct {
 			// Ctx is the ctx argument value.
@@ -764,6 +1036,15 @@ type MongoDBMock struct {
 			// Limit is the limit argument value.
 			Limit int
 		}
+		// GetVersionsByCollectionID holds details about calls to the GetVersionsByCollectionID method.
+		GetVersionsByCollectionID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// CollectionID is the collectionID argument value.
+			CollectionID string
+			// States is the states argument value.
+			States []string
+		}
 		// GetVersionsStatic holds details about calls to the GetVersionsStatic method.
 		GetVersionsStatic []struct {
 			// Ctx is the ctx argument value.
@@ -786,6 +1067,32 @@ type MongoDBMock struct {
 			// DatasetID is the datasetID argument value.
 			DatasetID string
 		}
+		// LockScheduledPublishing holds details about calls to the LockScheduledPublishing method.
+		LockScheduledPublishing []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// PurgeDeletedDatasets holds details about calls to the PurgeDeletedDatasets method.
+		PurgeDeletedDatasets []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Before is the before argument value.
+			Before time.Time
+		}
+		// PurgeSentOutboxMessages holds details about calls to the PurgeSentOutboxMessages method.
+		PurgeSentOutboxMessages []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Before is the before argument value.
+			Before time.Time
+		}
+		// PurgeStaticVersion holds details about calls to the PurgeStaticVersion method.
+		PurgeStaticVersion []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the ID argument value.
+			ID string
+		}
 		// RemoveDatasetVersionAndEditionLinks holds details about calls to the RemoveDatasetVersionAndEditionLinks method.
 		RemoveDatasetVersionAndEditionLinks []struct {
 			// Ctx is the ctx argument value.
@@ -793,6 +1100,47 @@ type MongoDBMock struct {
 			// ID is the id argument value.
 			ID string
 		}
+		// RequeueFailedOutboxMessages holds details about calls to the RequeueFailedOutboxMessages method.
+		RequeueFailedOutboxMessages []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Now is the now argument value.
+			Now time.Time
+		}
+		// RestoreDataset holds details about calls to the RestoreDataset method.
+		RestoreDataset []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the ID argument value.
+			ID string
+		}
+		// RestoreEdition holds details about calls to the RestoreEdition method.
+		RestoreEdition []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// DatasetID is the datasetID argument value.
+			DatasetID string
+			// Edition is the edition argument value.
+			Edition string
+		}
+		// RestoreStaticVersion holds details about calls to the RestoreStaticVersion method.
+		RestoreStaticVersion []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// DatasetID is the datasetID argument value.
+			DatasetID string
+			// Edition is the edition argument value.
+			Edition string
+			// Version is the version argument value.
+			Version int
+		}
+		// RunTransaction holds details about calls to the RunTransaction method.
+		RunTransaction []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Fn is the fn argument value.
+			Fn func(ctx context.Context) error
+		}
 		// UnlockInstance holds details about calls to the UnlockInstance method.
 		UnlockInstance []struct {
 			// Ctx is the ctx argument value.
@@ -800,6 +1148,13 @@ type MongoDBMock struct {
 			// LockID is the lockID argument value.
 			LockID string
 		}
+		// UnlockScheduledPublishing holds details about calls to the UnlockScheduledPublishing method.
+		UnlockScheduledPublishing []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// LockID is the lockID argument value.
+			LockID string
+		}
 		// UnlockVersions holds details about calls to the UnlockVersions method.
 		UnlockVersions []struct {
 			// Ctx is the ctx argument value.
@@ -923,6 +1278,13 @@ type MongoDBMock struct {
 			// ETagSelector is the eTagSelector argument value.
 			ETagSelector string
 		}
+		// UpdateOutboxMessage holds details about calls to the UpdateOutboxMessage method.
+		UpdateOutboxMessage []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Message is the message argument value.
+			Message *models.OutboxMessage
+		}
 		// UpdateVersion holds details about calls to the UpdateVersion method.
 		UpdateVersion []struct {
 			// Ctx is the ctx argument value.
@@ -1002,6 +1364,7 @@ type MongoDBMock struct {
 	lockAcquireVersionsLock                 sync.RWMutex
 	lockAddEventToInstance                  sync.RWMutex
 	lockAddInstance                         sync.RWMutex
+	lockAddOutboxMessage                    sync.RWMutex
 	lockAddVersionStatic                    sync.RWMutex
 	lockCheckDatasetExists                  sync.RWMutex
 	lockCheckDatasetTitleExist              sync.RWMutex
@@ -1010,16 +1373,24 @@ type MongoDBMock struct {
 	lockCheckEditionTitleExistsStatic       sync.RWMutex
 	lockCheckVersionExistsStatic            sync.RWMutex
 	lockChecker                             sync.RWMutex
+	lockClaimOutboxMessage                  sync.RWMutex
 	lockClose                               sync.RWMutex
+	lockCountOutboxMessages                 sync.RWMutex
 	lockCreateAuditEvent                    sync.RWMutex
 	lockDeleteDataset                       sync.RWMutex
 	lockDeleteEdition                       sync.RWMutex
 	lockDeleteStaticDatasetVersion          sync.RWMutex
 	lockGetAllStaticVersions                sync.RWMutex
+	lockGetAuditEventChain                  sync.RWMutex
+	lockGetAuditEvents                      sync.RWMutex
 	lockGetDataset                          sync.RWMutex
+	lockGetDatasetRevision                  sync.RWMutex
+	lockGetDatasetRevisions                 sync.RWMutex
 	lockGetDatasetType                      sync.RWMutex
 	lockGetDatasets                         sync.RWMutex
+	lockGetDatasetsByCollectionID           sync.RWMutex
 	lockGetDatasetsByQueryParams            sync.RWMutex
+	lockGetDeletedStaticVersions            sync.RWMutex
 	lockGetDimensionOptions                 sync.RWMutex
 	lockGetDimensionOptionsFromIDs          sync.RWMutex
 	lockGetDimensions                       sync.RWMutex
@@ -1028,17 +1399,31 @@ type MongoDBMock struct {
 	lockGetEditions                         sync.RWMutex
 	lockGetInstance                         sync.RWMutex
 	lockGetInstances                        sync.RWMutex
+	lockGetLatestVersion                    sync.RWMutex
 	lockGetLatestVersionStatic              sync.RWMutex
 	lockGetNextVersion                      sync.RWMutex
+	lockGetNextVersionStatic                sync.RWMutex
+	lockGetScheduledVersions                sync.RWMutex
 	lockGetStaticVersionsByState            sync.RWMutex
 	lockGetUniqueDimensionAndOptions        sync.RWMutex
 	lockGetVersion                          sync.RWMutex
 	lockGetVersionStatic                    sync.RWMutex
 	lockGetVersions                         sync.RWMutex
+	lockGetVersionsByCollectionID           sync.RWMutex
 	lockGetVersionsStatic                   sync.RWMutex
 	lockIsStaticDataset                     sync.RWMutex
+	lockLockScheduledPublishing             sync.RWMutex
+	lockPurgeDeletedDatasets                sync.RWMutex
+	lockPurgeSentOutboxMessages             sync.RWMutex
+	lockPurgeStaticVersion                  sync.RWMutex
 	lockRemoveDatasetVersionAndEditionLinks sync.RWMutex
+	lockRequeueFailedOutboxMessages         sync.RWMutex
+	lockRestoreDataset                      sync.RWMutex
+	lockRestoreEdition                      sync.RWMutex
+	lockRestoreStaticVersion                sync.RWMutex
+	lockRunTransaction                      sync.RWMutex
 	lockUnlockInstance                      sync.RWMutex
+	lockUnlockScheduledPublishing           sync.RWMutex
 	lockUnlockVersions                      sync.RWMutex
 	lockUpdateBuildHierarchyTaskState       sync.RWMutex
 	lockUpdateBuildSearchTaskState          sync.RWMutex
@@ -1050,6 +1435,7 @@ type MongoDBMock struct {
 	lockUpdateInstance                      sync.RWMutex
 	lockUpdateMetadata                      sync.RWMutex
 	lockUpdateObservationInserted           sync.RWMutex
+	lockUpdateOutboxMessage                 sync.RWMutex
 	lockUpdateVersion                       sync.RWMutex
 	lockUpdateVersionStatic                 sync.RWMutex
 	lockUpsertContact                       sync.RWMutex
@@ -1212,6 +1598,42 @@ func (mock *MongoDBMock) AddInstanceCalls() []struct {
 	return calls
 }
 
+// AddOutboxMessage calls AddOutboxMessageFunc.
+func (mock *MongoDBMock) AddOutboxMessage(ctx context.Context, message *models.OutboxMessage) error {
+	if mock.AddOutboxMessageFunc == nil {
+		panic("MongoDBMock.AddOutboxMessageFunc: method is nil but MongoDB.AddOutboxMessage was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Message *models.OutboxMessage
+	}{
+		Ctx:     ctx,
+		Message: message,
+	}
+	mock.lockAddOutboxMessage.Lock()
+	mock.calls.AddOutboxMessage = append(mock.calls.AddOutboxMessage, callInfo)
+	mock.lockAddOutboxMessage.Unlock()
+	return mock.AddOutboxMessageFunc(ctx, message)
+}
+
+// AddOutboxMessageCalls gets all the calls that were made to AddOutboxMessage.
+// Check the length with:
+//
+//	len(mockedMongoDB.AddOutboxMessageCalls())
+func (mock *MongoDBMock) AddOutboxMessageCalls() []struct {
+	Ctx     context.Context
+	Message *models.OutboxMessage
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Message *models.OutboxMessage
+	}
+	mock.lockAddOutboxMessage.RLock()
+	calls = mock.calls.AddOutboxMessage
+	mock.lockAddOutboxMessage.RUnlock()
+	return calls
+}
+
 // AddVersionStatic calls AddVersionStaticFunc.
 func (mock *MongoDBMock) AddVersionStatic(ctx context.Context, version *models.Version) (*models.Version, error) {
 	if mock.AddVersionStaticFunc == nil {
@@ -1532,6 +1954,50 @@ func (mock *MongoDBMock) CheckerCalls() []struct {
 	return calls
 }
 
+// ClaimOutboxMessage calls ClaimOutboxMessageFunc.
+func (mock *MongoDBMock) ClaimOutboxMessage(ctx context.Context, owner string, lease time.Duration, skipTopics []string) (*models.OutboxMessage, error) {
+	if mock.ClaimOutboxMessageFunc == nil {
+		panic("MongoDBMock.ClaimOutboxMessageFunc: method is nil but MongoDB.ClaimOutboxMessage was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		Owner      string
+		Lease      time.Duration
+		SkipTopics []string
+	}{
+		Ctx:        ctx,
+		Owner:      owner,
+		Lease:      lease,
+		SkipTopics: skipTopics,
+	}
+	mock.lockClaimOutboxMessage.Lock()
+	mock.calls.ClaimOutboxMessage = append(mock.calls.ClaimOutboxMessage, callInfo)
+	mock.lockClaimOutboxMessage.Unlock()
+	return mock.ClaimOutboxMessageFunc(ctx, owner, lease, skipTopics)
+}
+
+// ClaimOutboxMessageCalls gets all the calls that were made to ClaimOutboxMessage.
+// Check the length with:
+//
+//	len(mockedMongoDB.ClaimOutboxMessageCalls())
+func (mock *MongoDBMock) ClaimOutboxMessageCalls() []struct {
+	Ctx        context.Context
+	Owner      string
+	Lease      time.Duration
+	SkipTopics []string
+} {
+	var calls []struct {
+		Ctx        context.Context
+		Owner      string
+		Lease      time.Duration
+		SkipTopics []string
+	}
+	mock.lockClaimOutboxMessage.RLock()
+	calls = mock.calls.ClaimOutboxMessage
+	mock.lockClaimOutboxMessage.RUnlock()
+	return calls
+}
+
 // Close calls CloseFunc.
 func (mock *MongoDBMock) Close(contextMoqParam context.Context) error {
 	if mock.CloseFunc == nil {
@@ -1564,6 +2030,42 @@ func (mock *MongoDBMock) CloseCalls() []struct {
 	return calls
 }
 
+// CountOutboxMessages calls CountOutboxMessagesFunc.
+func (mock *MongoDBMock) CountOutboxMessages(ctx context.Context, state string) (int, error) {
+	if mock.CountOutboxMessagesFunc == nil {
+		panic("MongoDBMock.CountOutboxMessagesFunc: method is nil but MongoDB.CountOutboxMessages was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		State string
+	}{
+		Ctx:   ctx,
+		State: state,
+	}
+	mock.lockCountOutboxMessages.Lock()
+	mock.calls.CountOutboxMessages = append(mock.calls.CountOutboxMessages, callInfo)
+	mock.lockCountOutboxMessages.Unlock()
+	return mock.CountOutboxMessagesFunc(ctx, state)
+}
+
+// CountOutboxMessagesCalls gets all the calls that were made to CountOutboxMessages.
+// Check the length with:
+//
+//	len(mockedMongoDB.CountOutboxMessagesCalls())
+func (mock *MongoDBMock) CountOutboxMessagesCalls() []struct {
+	Ctx   context.Context
+	State string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		State string
+	}
+	mock.lockCountOutboxMessages.RLock()
+	calls = mock.calls.CountOutboxMessages
+	mock.lockCountOutboxMessages.RUnlock()
+	return calls
+}
+
 // CreateAuditEvent calls CreateAuditEventFunc.
 func (mock *MongoDBMock) CreateAuditEvent(ctx context.Context, event *models.AuditEvent) error {
 	if mock.CreateAuditEventFunc == nil {
@@ -1601,21 +2103,23 @@ func (mock *MongoDBMock) CreateAuditEventCalls() []struct {
 }
 
 // DeleteDataset calls DeleteDatasetFunc.
-func (mock *MongoDBMock) DeleteDataset(ctx context.Context, ID string) error {
+func (mock *MongoDBMock) DeleteDataset(ctx context.Context, ID string, deletion *models.Deletion) error {
 	if mock.DeleteDatasetFunc == nil {
 		panic("MongoDBMock.DeleteDatasetFunc: method is nil but MongoDB.DeleteDataset was just called")
 	}
 	callInfo := struct {
-		Ctx context.Context
-		ID  string
+		Ctx      context.Context
+		ID       string
+		Deletion *models.Deletion
 	}{
-		Ctx: ctx,
-		ID:  ID,
+		Ctx:      ctx,
+		ID:       ID,
+		Deletion: deletion,
 	}
 	mock.lockDeleteDataset.Lock()
 	mock.calls.DeleteDataset = append(mock.calls.DeleteDataset, callInfo)
 	mock.lockDeleteDataset.Unlock()
-	return mock.DeleteDatasetFunc(ctx, ID)
+	return mock.DeleteDatasetFunc(ctx, ID, deletion)
 }
 
 // DeleteDatasetCalls gets all the calls that were made to DeleteDataset.
@@ -1623,12 +2127,14 @@ func (mock *MongoDBMock) DeleteDataset(ctx context.Context, ID string) error {
 //
 //	len(mockedMongoDB.DeleteDatasetCalls())
 func (mock *MongoDBMock) DeleteDatasetCalls() []struct {
-	Ctx context.Context
-	ID  string
+	Ctx      context.Context
+	ID       string
+	Deletion *models.Deletion
 } {
 	var calls []struct {
-		Ctx context.Context
-		ID  string
+		Ctx      context.Context
+		ID       string
+		Deletion *models.Deletion
 	}
 	mock.lockDeleteDataset.RLock()
 	calls = mock.calls.DeleteDataset
@@ -1637,21 +2143,23 @@ func (mock *MongoDBMock) DeleteDatasetCalls() []struct {
 }
 
 // DeleteEdition calls DeleteEditionFunc.
-func (mock *MongoDBMock) DeleteEdition(ctx context.Context, ID string) error {
+func (mock *MongoDBMock) DeleteEdition(ctx context.Context, ID string, deletion *models.Deletion) error {
 	if mock.DeleteEditionFunc == nil {
 		panic("MongoDBMock.DeleteEditionFunc: method is nil but MongoDB.DeleteEdition was just called")
 	}
 	callInfo := struct {
-		Ctx context.Context
-		ID  string
+		Ctx      context.Context
+		ID       string
+		Deletion *models.Deletion
 	}{
-		Ctx: ctx,
-		ID:  ID,
+		Ctx:      ctx,
+		ID:       ID,
+		Deletion: deletion,
 	}
 	mock.lockDeleteEdition.Lock()
 	mock.calls.DeleteEdition = append(mock.calls.DeleteEdition, callInfo)
 	mock.lockDeleteEdition.Unlock()
-	return mock.DeleteEditionFunc(ctx, ID)
+	return mock.DeleteEditionFunc(ctx, ID, deletion)
 }
 
 // DeleteEditionCalls gets all the calls that were made to DeleteEdition.
@@ -1659,12 +2167,14 @@ func (mock *MongoDBMock) DeleteEdition(ctx context.Context, ID string) error {
 //
 //	len(mockedMongoDB.DeleteEditionCalls())
 func (mock *MongoDBMock) DeleteEditionCalls() []struct {
-	Ctx context.Context
-	ID  string
+	Ctx      context.Context
+	ID       string
+	Deletion *models.Deletion
 } {
 	var calls []struct {
-		Ctx context.Context
-		ID  string
+		Ctx      context.Context
+		ID       string
+		Deletion *models.Deletion
 	}
 	mock.lockDeleteEdition.RLock()
 	calls = mock.calls.DeleteEdition
@@ -1673,7 +2183,7 @@ func (mock *MongoDBMock) DeleteEditionCalls() []struct {
 }
 
 // DeleteStaticDatasetVersion calls DeleteStaticDatasetVersionFunc.
-func (mock *MongoDBMock) DeleteStaticDatasetVersion(ctx context.Context, datasetID string, editionID string, version int) error {
+func (mock *MongoDBMock) DeleteStaticDatasetVersion(ctx context.Context, datasetID string, editionID string, version int, deletion *models.Deletion) error {
 	if mock.DeleteStaticDatasetVersionFunc == nil {
 		panic("MongoDBMock.DeleteStaticDatasetVersionFunc: method is nil but MongoDB.DeleteStaticDatasetVersion was just called")
 	}
@@ -1682,16 +2192,18 @@ func (mock *MongoDBMock) DeleteStaticDatasetVersion(ctx context.Context, dataset
 		DatasetID string
 		EditionID string
 		Version   int
+		Deletion  *models.Deletion
 	}{
 		Ctx:       ctx,
 		DatasetID: datasetID,
 		EditionID: editionID,
 		Version:   version,
+		Deletion:  deletion,
 	}
 	mock.lockDeleteStaticDatasetVersion.Lock()
 	mock.calls.DeleteStaticDatasetVersion = append(mock.calls.DeleteStaticDatasetVersion, callInfo)
 	mock.lockDeleteStaticDatasetVersion.Unlock()
-	return mock.DeleteStaticDatasetVersionFunc(ctx, datasetID, editionID, version)
+	return mock.DeleteStaticDatasetVersionFunc(ctx, datasetID, editionID, version, deletion)
 }
 
 // DeleteStaticDatasetVersionCalls gets all the calls that were made to DeleteStaticDatasetVersion.
@@ -1703,12 +2215,14 @@ func (mock *MongoDBMock) DeleteStaticDatasetVersionCalls() []struct {
 	DatasetID string
 	EditionID string
 	Version   int
+	Deletion  *models.Deletion
 } {
 	var calls []struct {
 		Ctx       context.Context
 		DatasetID string
 		EditionID string
 		Version   int
+		Deletion  *models.Deletion
 	}
 	mock.lockDeleteStaticDatasetVersion.RLock()
 	calls = mock.calls.DeleteStaticDatasetVersion
@@ -1764,9 +2278,93 @@ func (mock *MongoDBMock) GetAllStaticVersionsCalls() []struct {
 	return calls
 }
 
-// GetDataset calls GetDatasetFunc.
-func (mock *MongoDBMock) GetDataset(ctx context.Context, ID string) (*models.DatasetUpdate, error) {
-	if mock.GetDatasetFunc == nil {
+// GetAuditEventChain calls GetAuditEventChainFunc.
+func (mock *MongoDBMock) GetAuditEventChain(ctx context.Context, fromSequence int64, limit int) ([]*models.AuditEvent, error) {
+	if mock.GetAuditEventChainFunc == nil {
+		panic("MongoDBMock.GetAuditEventChainFunc: method is nil but MongoDB.GetAuditEventChain was just called")
+	}
+	callInfo := struct {
+		Ctx          context.Context
+		FromSequence int64
+		Limit        int
+	}{
+		Ctx:          ctx,
+		FromSequence: fromSequence,
+		Limit:        limit,
+	}
+	mock.lockGetAuditEventChain.Lock()
+	mock.calls.GetAuditEventChain = append(mock.calls.GetAuditEventChain, callInfo)
+	mock.lockGetAuditEventChain.Unlock()
+	return mock.GetAuditEventChainFunc(ctx, fromSequence, limit)
+}
+
+// GetAuditEventChainCalls gets all the calls that were made to GetAuditEventChain.
+// Check the length with:
+//
+//	len(mockedMongoDB.GetAuditEventChainCalls())
+func (mock *MongoDBMock) GetAuditEventChainCalls() []struct {
+	Ctx          context.Context
+	FromSequence int64
+	Limit        int
+} {
+	var calls []struct {
+		Ctx          context.Context
+		FromSequence int64
+		Limit        int
+	}
+	mock.lockGetAuditEventChain.RLock()
+	calls = mock.calls.GetAuditEventChain
+	mock.lockGetAuditEventChain.RUnlock()
+	return calls
+}
+
+// GetAuditEvents calls GetAuditEventsFunc.
+func (mock *MongoDBMock) GetAuditEvents(ctx context.Context, filter *models.AuditEventFilter, offset int, limit int) ([]*models.AuditEvent, int, error) {
+	if mock.GetAuditEventsFunc == nil {
+		panic("MongoDBMock.GetAuditEventsFunc: method is nil but MongoDB.GetAuditEvents was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Filter *models.AuditEventFilter
+		Offset int
+		Limit  int
+	}{
+		Ctx:    ctx,
+		Filter: filter,
+		Offset: offset,
+		Limit:  limit,
+	}
+	mock.lockGetAuditEvents.Lock()
+	mock.calls.GetAuditEvents = append(mock.calls.GetAuditEvents, callInfo)
+	mock.lockGetAuditEvents.Unlock()
+	return mock.GetAuditEventsFunc(ctx, filter, offset, limit)
+}
+
+// GetAuditEventsCalls gets all the calls that were made to GetAuditEvents.
+// Check the length with:
+//
+//	len(mockedMongoDB.GetAuditEventsCalls())
+func (mock *MongoDBMock) GetAuditEventsCalls() []struct {
+	Ctx    context.Context
+	Filter *models.AuditEventFilter
+	Offset int
+	Limit  int
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Filter *models.AuditEventFilter
+		Offset int
+		Limit  int
+	}
+	mock.lockGetAuditEvents.RLock()
+	calls = mock.calls.GetAuditEvents
+	mock.lockGetAuditEvents.RUnlock()
+	return calls
+}
+
+// GetDataset calls GetDatasetFunc.
+func (mock *MongoDBMock) GetDataset(ctx context.Context, ID string) (*models.DatasetUpdate, error) {
+	if mock.GetDatasetFunc == nil {
 		panic("MongoDBMock.GetDatasetFunc: method is nil but MongoDB.GetDataset was just called")
 	}
 	callInfo := struct {
@@ -1800,6 +2398,90 @@ func (mock *MongoDBMock) GetDatasetCalls() []struct {
 	return calls
 }
 
+// GetDatasetRevision calls GetDatasetRevisionFunc.
+func (mock *MongoDBMock) GetDatasetRevision(ctx context.Context, datasetID string, revision int) (*models.DatasetRevision, error) {
+	if mock.GetDatasetRevisionFunc == nil {
+		panic("MongoDBMock.GetDatasetRevisionFunc: method is nil but MongoDB.GetDatasetRevision was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		DatasetID string
+		Revision  int
+	}{
+		Ctx:       ctx,
+		DatasetID: datasetID,
+		Revision:  revision,
+	}
+	mock.lockGetDatasetRevision.Lock()
+	mock.calls.GetDatasetRevision = append(mock.calls.GetDatasetRevision, callInfo)
+	mock.lockGetDatasetRevision.Unlock()
+	return mock.GetDatasetRevisionFunc(ctx, datasetID, revision)
+}
+
+// GetDatasetRevisionCalls gets all the calls that were made to GetDatasetRevision.
+// Check the length with:
+//
+//	len(mockedMongoDB.GetDatasetRevisionCalls())
+func (mock *MongoDBMock) GetDatasetRevisionCalls() []struct {
+	Ctx       context.Context
+	DatasetID string
+	Revision  int
+} {
+	var calls []struct {
+		Ctx       context.Context
+		DatasetID string
+		Revision  int
+	}
+	mock.lockGetDatasetRevision.RLock()
+	calls = mock.calls.GetDatasetRevision
+	mock.lockGetDatasetRevision.RUnlock()
+	return calls
+}
+
+// GetDatasetRevisions calls GetDatasetRevisionsFunc.
+func (mock *MongoDBMock) GetDatasetRevisions(ctx context.Context, datasetID string, offset int, limit int) ([]*models.DatasetRevision, int, error) {
+	if mock.GetDatasetRevisionsFunc == nil {
+		panic("MongoDBMock.GetDatasetRevisionsFunc: method is nil but MongoDB.GetDatasetRevisions was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		DatasetID string
+		Offset    int
+		Limit     int
+	}{
+		Ctx:       ctx,
+		DatasetID: datasetID,
+		Offset:    offset,
+		Limit:     limit,
+	}
+	mock.lockGetDatasetRevisions.Lock()
+	mock.calls.GetDatasetRevisions = append(mock.calls.GetDatasetRevisions, callInfo)
+	mock.lockGetDatasetRevisions.Unlock()
+	return mock.GetDatasetRevisionsFunc(ctx, datasetID, offset, limit)
+}
+
+// GetDatasetRevisionsCalls gets all the calls that were made to GetDatasetRevisions.
+// Check the length with:
+//
+//	len(mockedMongoDB.GetDatasetRevisionsCalls())
+func (mock *MongoDBMock) GetDatasetRevisionsCalls() []struct {
+	Ctx       context.Context
+	DatasetID string
+	Offset    int
+	Limit     int
+} {
+	var calls []struct {
+		Ctx       context.Context
+		DatasetID string
+		Offset    int
+		Limit     int
+	}
+	mock.lockGetDatasetRevisions.RLock()
+	calls = mock.calls.GetDatasetRevisions
+	mock.lockGetDatasetRevisions.RUnlock()
+	return calls
+}
+
 // GetDatasetType calls GetDatasetTypeFunc.
 func (mock *MongoDBMock) GetDatasetType(ctx context.Context, datasetID string, authorised bool) (string, error) {
 	if mock.GetDatasetTypeFunc == nil {
@@ -1841,7 +2523,7 @@ func (mock *MongoDBMock) GetDatasetTypeCalls() []struct {
 }
 
 // GetDatasets calls GetDatasetsFunc.
-func (mock *MongoDBMock) GetDatasets(ctx context.Context, offset int, limit int, authorised bool) ([]*models.DatasetUpdate, int, error) {
+func (mock *MongoDBMock) GetDatasets(ctx context.Context, offset int, limit int, cursor *pagination.Cursor, authorised bool) ([]*models.DatasetUpdate, int, *pagination.Cursor, error) {
 	if mock.GetDatasetsFunc == nil {
 		panic("MongoDBMock.GetDatasetsFunc: method is nil but MongoDB.GetDatasets was just called")
 	}
@@ -1849,17 +2531,19 @@ func (mock *MongoDBMock) GetDatasets(ctx context.Context, offset int, limit int,
 		Ctx        context.Context
 		Offset     int
 		Limit      int
+		Cursor     *pagination.Cursor
 		Authorised bool
 	}{
 		Ctx:        ctx,
 		Offset:     offset,
 		Limit:      limit,
+		Cursor:     cursor,
 		Authorised: authorised,
 	}
 	mock.lockGetDatasets.Lock()
 	mock.calls.GetDatasets = append(mock.calls.GetDatasets, callInfo)
 	mock.lockGetDatasets.Unlock()
-	return mock.GetDatasetsFunc(ctx, offset, limit, authorised)
+	return mock.GetDatasetsFunc(ctx, offset, limit, cursor, authorised)
 }
 
 // GetDatasetsCalls gets all the calls that were made to GetDatasets.
@@ -1870,12 +2554,14 @@ func (mock *MongoDBMock) GetDatasetsCalls() []struct {
 	Ctx        context.Context
 	Offset     int
 	Limit      int
+	Cursor     *pagination.Cursor
 	Authorised bool
 } {
 	var calls []struct {
 		Ctx        context.Context
 		Offset     int
 		Limit      int
+		Cursor     *pagination.Cursor
 		Authorised bool
 	}
 	mock.lockGetDatasets.RLock()
@@ -1884,8 +2570,48 @@ func (mock *MongoDBMock) GetDatasetsCalls() []struct {
 	return calls
 }
 
+// GetDatasetsByCollectionID calls GetDatasetsByCollectionIDFunc.
+func (mock *MongoDBMock) GetDatasetsByCollectionID(ctx context.Context, collectionID string, states []string) ([]*models.DatasetUpdate, error) {
+	if mock.GetDatasetsByCollectionIDFunc == nil {
+		panic("MongoDBMock.GetDatasetsByCollectionIDFunc: method is nil but MongoDB.GetDatasetsByCollectionID was just called")
+	}
+	callInfo := struct {
+		Ctx          context.Context
+		CollectionID string
+		States       []string
+	}{
+		Ctx:          ctx,
+		CollectionID: collectionID,
+		States:       states,
+	}
+	mock.lockGetDatasetsByCollectionID.Lock()
+	mock.calls.GetDatasetsByCollectionID = append(mock.calls.GetDatasetsByCollectionID, callInfo)
+	mock.lockGetDatasetsByCollectionID.Unlock()
+	return mock.GetDatasetsByCollectionIDFunc(ctx, collectionID, states)
+}
+
+// GetDatasetsByCollectionIDCalls gets all the calls that were made to GetDatasetsByCollectionID.
+// Check the length with:
+//
+//	len(mockedMongoDB.GetDatasetsByCollectionIDCalls())
+func (mock *MongoDBMock) GetDatasetsByCollectionIDCalls() []struct {
+	Ctx          context.Context
+	CollectionID string
+	States       []string
+} {
+	var calls []struct {
+		Ctx          context.Context
+		CollectionID string
+		States       []string
+	}
+	mock.lockGetDatasetsByCollectionID.RLock()
+	calls = mock.calls.GetDatasetsByCollectionID
+	mock.lockGetDatasetsByCollectionID.RUnlock()
+	return calls
+}
+
 // GetDatasetsByQueryParams calls GetDatasetsByQueryParamsFunc.
-func (mock *MongoDBMock) GetDatasetsByQueryParams(ctx context.Context, ID string, datasetType string, sortOrder string, datasetID string, offset int, limit int, authorised bool) ([]*models.DatasetUpdate, int, error) {
+func (mock *MongoDBMock) GetDatasetsByQueryParams(ctx context.Context, ID string, datasetType string, sortOrder string, datasetID string, offset int, limit int, cursor *pagination.Cursor, authorised bool) ([]*models.DatasetUpdate, int, *pagination.Cursor, error) {
 	if mock.GetDatasetsByQueryParamsFunc == nil {
 		panic("MongoDBMock.GetDatasetsByQueryParamsFunc: method is nil but MongoDB.GetDatasetsByQueryParams was just called")
 	}
@@ -1897,6 +2623,7 @@ func (mock *MongoDBMock) GetDatasetsByQueryParams(ctx context.Context, ID string
 		DatasetID   string
 		Offset      int
 		Limit       int
+		Cursor      *pagination.Cursor
 		Authorised  bool
 	}{
 		Ctx:         ctx,
@@ -1906,12 +2633,13 @@ func (mock *MongoDBMock) GetDatasetsByQueryParams(ctx context.Context, ID string
 		DatasetID:   datasetID,
 		Offset:      offset,
 		Limit:       limit,
+		Cursor:      cursor,
 		Authorised:  authorised,
 	}
 	mock.lockGetDatasetsByQueryParams.Lock()
 	mock.calls.GetDatasetsByQueryParams = append(mock.calls.GetDatasetsByQueryParams, callInfo)
 	mock.lockGetDatasetsByQueryParams.Unlock()
-	return mock.GetDatasetsByQueryParamsFunc(ctx, ID, datasetType, sortOrder, datasetID, offset, limit, authorised)
+	return mock.GetDatasetsByQueryParamsFunc(ctx, ID, datasetType, sortOrder, datasetID, offset, limit, cursor, authorised)
 }
 
 // GetDatasetsByQueryParamsCalls gets all the calls that were made to GetDatasetsByQueryParams.
@@ -1926,6 +2654,7 @@ func (mock *MongoDBMock) GetDatasetsByQueryParamsCalls() []struct {
 	DatasetID   string
 	Offset      int
 	Limit       int
+	Cursor      *pagination.Cursor
 	Authorised  bool
 } {
 	var calls []struct {
@@ -1936,6 +2665,7 @@ func (mock *MongoDBMock) GetDatasetsByQueryParamsCalls() []struct {
 		DatasetID   string
 		Offset      int
 		Limit       int
+		Cursor      *pagination.Cursor
 		Authorised  bool
 	}
 	mock.lockGetDatasetsByQueryParams.RLock()
@@ -1944,8 +2674,48 @@ func (mock *MongoDBMock) GetDatasetsByQueryParamsCalls() []struct {
 	return calls
 }
 
+// GetDeletedStaticVersions calls GetDeletedStaticVersionsFunc.
+func (mock *MongoDBMock) GetDeletedStaticVersions(ctx context.Context, before time.Time, limit int) ([]*models.Version, error) {
+	if mock.GetDeletedStaticVersionsFunc == nil {
+		panic("MongoDBMock.GetDeletedStaticVersionsFunc: method is nil but MongoDB.GetDeletedStaticVersions was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Before time.Time
+		Limit  int
+	}{
+		Ctx:    ctx,
+		Before: before,
+		Limit:  limit,
+	}
+	mock.lockGetDeletedStaticVersions.Lock()
+	mock.calls.GetDeletedStaticVersions = append(mock.calls.GetDeletedStaticVersions, callInfo)
+	mock.lockGetDeletedStaticVersions.Unlock()
+	return mock.GetDeletedStaticVersionsFunc(ctx, before, limit)
+}
+
+// GetDeletedStaticVersionsCalls gets all the calls that were made to GetDeletedStaticVersions.
+// Check the length with:
+//
+//	len(mockedMongoDB.GetDeletedStaticVersionsCalls())
+func (mock *MongoDBMock) GetDeletedStaticVersionsCalls() []struct {
+	Ctx    context.Context
+	Before time.Time
+	Limit  int
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Before time.Time
+		Limit  int
+	}
+	mock.lockGetDeletedStaticVersions.RLock()
+	calls = mock.calls.GetDeletedStaticVersions
+	mock.lockGetDeletedStaticVersions.RUnlock()
+	return calls
+}
+
 // GetDimensionOptions calls GetDimensionOptionsFunc.
-func (mock *MongoDBMock) GetDimensionOptions(ctx context.Context, version *models.Version, dimension string, offset int, limit int) ([]*models.PublicDimensionOption, int, error) {
+func (mock *MongoDBMock) GetDimensionOptions(ctx context.Context, version *models.Version, dimension string, offset int, limit int, cursor *pagination.Cursor) ([]*models.PublicDimensionOption, int, *pagination.Cursor, error) {
 	if mock.GetDimensionOptionsFunc == nil {
 		panic("MongoDBMock.GetDimensionOptionsFunc: method is nil but MongoDB.GetDimensionOptions was just called")
 	}
@@ -1955,17 +2725,19 @@ func (mock *MongoDBMock) GetDimensionOptions(ctx context.Context, version *model
 		Dimension string
 		Offset    int
 		Limit     int
+		Cursor    *pagination.Cursor
 	}{
 		Ctx:       ctx,
 		Version:   version,
 		Dimension: dimension,
 		Offset:    offset,
 		Limit:     limit,
+		Cursor:    cursor,
 	}
 	mock.lockGetDimensionOptions.Lock()
 	mock.calls.GetDimensionOptions = append(mock.calls.GetDimensionOptions, callInfo)
 	mock.lockGetDimensionOptions.Unlock()
-	return mock.GetDimensionOptionsFunc(ctx, version, dimension, offset, limit)
+	return mock.GetDimensionOptionsFunc(ctx, version, dimension, offset, limit, cursor)
 }
 
 // GetDimensionOptionsCalls gets all the calls that were made to GetDimensionOptions.
@@ -1978,6 +2750,7 @@ func (mock *MongoDBMock) GetDimensionOptionsCalls() []struct {
 	Dimension string
 	Offset    int
 	Limit     int
+	Cursor    *pagination.Cursor
 } {
 	var calls []struct {
 		Ctx       context.Context
@@ -1985,6 +2758,7 @@ func (mock *MongoDBMock) GetDimensionOptionsCalls() []struct {
 		Dimension string
 		Offset    int
 		Limit     int
+		Cursor    *pagination.Cursor
 	}
 	mock.lockGetDimensionOptions.RLock()
 	calls = mock.calls.GetDimensionOptions
@@ -2253,7 +3027,7 @@ func (mock *MongoDBMock) GetInstanceCalls() []struct {
 }
 
 // GetInstances calls GetInstancesFunc.
-func (mock *MongoDBMock) GetInstances(ctx context.Context, states []string, datasets []string, offset int, limit int) ([]*models.Instance, int, error) {
+func (mock *MongoDBMock) GetInstances(ctx context.Context, states []string, datasets []string, offset int, limit int, cursor *pagination.Cursor) ([]*models.Instance, int, *pagination.Cursor, error) {
 	if mock.GetInstancesFunc == nil {
 		panic("MongoDBMock.GetInstancesFunc: method is nil but MongoDB.GetInstances was just called")
 	}
@@ -2263,17 +3037,19 @@ func (mock *MongoDBMock) GetInstances(ctx context.Context, states []string, data
 		Datasets []string
 		Offset   int
 		Limit    int
+		Cursor   *pagination.Cursor
 	}{
 		Ctx:      ctx,
 		States:   states,
 		Datasets: datasets,
 		Offset:   offset,
 		Limit:    limit,
+		Cursor:   cursor,
 	}
 	mock.lockGetInstances.Lock()
 	mock.calls.GetInstances = append(mock.calls.GetInstances, callInfo)
 	mock.lockGetInstances.Unlock()
-	return mock.GetInstancesFunc(ctx, states, datasets, offset, limit)
+	return mock.GetInstancesFunc(ctx, states, datasets, offset, limit, cursor)
 }
 
 // GetInstancesCalls gets all the calls that were made to GetInstances.
@@ -2286,6 +3062,7 @@ func (mock *MongoDBMock) GetInstancesCalls() []struct {
 	Datasets []string
 	Offset   int
 	Limit    int
+	Cursor   *pagination.Cursor
 } {
 	var calls []struct {
 		Ctx      context.Context
@@ -2293,6 +3070,7 @@ func (mock *MongoDBMock) GetInstancesCalls() []struct {
 		Datasets []string
 		Offset   int
 		Limit    int
+		Cursor   *pagination.Cursor
 	}
 	mock.lockGetInstances.RLock()
 	calls = mock.calls.GetInstances
@@ -2300,6 +3078,50 @@ func (mock *MongoDBMock) GetInstancesCalls() []struct {
 	return calls
 }
 
+// GetLatestVersion calls GetLatestVersionFunc.
+func (mock *MongoDBMock) GetLatestVersion(ctx context.Context, datasetID string, editionID string, state string) (*models.Version, error) {
+	if mock.GetLatestVersionFunc == nil {
+		panic("MongoDBMock.GetLatestVersionFunc: method is nil but MongoDB.GetLatestVersion was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		DatasetID string
+		EditionID string
+		State     string
+	}{
+		Ctx:       ctx,
+		DatasetID: datasetID,
+		EditionID: editionID,
+		State:     state,
+	}
+	mock.lockGetLatestVersion.Lock()
+	mock.calls.GetLatestVersion = append(mock.calls.GetLatestVersion, callInfo)
+	mock.lockGetLatestVersion.Unlock()
+	return mock.GetLatestVersionFunc(ctx, datasetID, editionID, state)
+}
+
+// GetLatestVersionCalls gets all the calls that were made to GetLatestVersion.
+// Check the length with:
+//
+//	len(mockedMongoDB.GetLatestVersionCalls())
+func (mock *MongoDBMock) GetLatestVersionCalls() []struct {
+	Ctx       context.Context
+	DatasetID string
+	EditionID string
+	State     string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		DatasetID string
+		EditionID string
+		State     string
+	}
+	mock.lockGetLatestVersion.RLock()
+	calls = mock.calls.GetLatestVersion
+	mock.lockGetLatestVersion.RUnlock()
+	return calls
+}
+
 // GetLatestVersionStatic calls GetLatestVersionStaticFunc.
 func (mock *MongoDBMock) GetLatestVersionStatic(ctx context.Context, datasetID string, editionID string, state string) (*models.Version, error) {
 	if mock.GetLatestVersionStaticFunc == nil {
@@ -2384,6 +3206,86 @@ func (mock *MongoDBMock) GetNextVersionCalls() []struct {
 	return calls
 }
 
+// GetNextVersionStatic calls GetNextVersionStaticFunc.
+func (mock *MongoDBMock) GetNextVersionStatic(ctx context.Context, datasetID string, editionID string) (int, error) {
+	if mock.GetNextVersionStaticFunc == nil {
+		panic("MongoDBMock.GetNextVersionStaticFunc: method is nil but MongoDB.GetNextVersionStatic was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		DatasetID string
+		EditionID string
+	}{
+		Ctx:       ctx,
+		DatasetID: datasetID,
+		EditionID: editionID,
+	}
+	mock.lockGetNextVersionStatic.Lock()
+	mock.calls.GetNextVersionStatic = append(mock.calls.GetNextVersionStatic, callInfo)
+	mock.lockGetNextVersionStatic.Unlock()
+	return mock.GetNextVersionStaticFunc(ctx, datasetID, editionID)
+}
+
+// GetNextVersionStaticCalls gets all the calls that were made to GetNextVersionStatic.
+// Check the length with:
+//
+//	len(mockedMongoDB.GetNextVersionStaticCalls())
+func (mock *MongoDBMock) GetNextVersionStaticCalls() []struct {
+	Ctx       context.Context
+	DatasetID string
+	EditionID string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		DatasetID string
+		EditionID string
+	}
+	mock.lockGetNextVersionStatic.RLock()
+	calls = mock.calls.GetNextVersionStatic
+	mock.lockGetNextVersionStatic.RUnlock()
+	return calls
+}
+
+// GetScheduledVersions calls GetScheduledVersionsFunc.
+func (mock *MongoDBMock) GetScheduledVersions(ctx context.Context, offset int, limit int) ([]*models.Version, int, error) {
+	if mock.GetScheduledVersionsFunc == nil {
+		panic("MongoDBMock.GetScheduledVersionsFunc: method is nil but MongoDB.GetScheduledVersions was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Offset int
+		Limit  int
+	}{
+		Ctx:    ctx,
+		Offset: offset,
+		Limit:  limit,
+	}
+	mock.lockGetScheduledVersions.Lock()
+	mock.calls.GetScheduledVersions = append(mock.calls.GetScheduledVersions, callInfo)
+	mock.lockGetScheduledVersions.Unlock()
+	return mock.GetScheduledVersionsFunc(ctx, offset, limit)
+}
+
+// GetScheduledVersionsCalls gets all the calls that were made to GetScheduledVersions.
+// Check the length with:
+//
+//	len(mockedMongoDB.GetScheduledVersionsCalls())
+func (mock *MongoDBMock) GetScheduledVersionsCalls() []struct {
+	Ctx    context.Context
+	Offset int
+	Limit  int
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Offset int
+		Limit  int
+	}
+	mock.lockGetScheduledVersions.RLock()
+	calls = mock.calls.GetScheduledVersions
+	mock.lockGetScheduledVersions.RUnlock()
+	return calls
+}
+
 // GetStaticVersionsByState calls GetStaticVersionsByStateFunc.
 func (mock *MongoDBMock) GetStaticVersionsByState(ctx context.Context, state string, publishedOnly string, offset int, limit int) ([]*models.Version, int, error) {
 	if mock.GetStaticVersionsByStateFunc == nil {
@@ -2620,6 +3522,46 @@ func (mock *MongoDBMock) GetVersionsCalls() []struct {
 	return calls
 }
 
+// GetVersionsByCollectionID calls GetVersionsByCollectionIDFunc.
+func (mock *MongoDBMock) GetVersionsByCollectionID(ctx context.Context, collectionID string, states []string) ([]*models.Version, error) {
+	if mock.GetVersionsByCollectionIDFunc == nil {
+		panic("MongoDBMock.GetVersionsByCollectionIDFunc: method is nil but MongoDB.GetVersionsByCollectionID was just called")
+	}
+	callInfo := struct {
+		Ctx          context.Context
+		CollectionID string
+		States       []string
+	}{
+		Ctx:          ctx,
+		CollectionID: collectionID,
+		States:       states,
+	}
+	mock.lockGetVersionsByCollectionID.Lock()
+	mock.calls.GetVersionsByCollectionID = append(mock.calls.GetVersionsByCollectionID, callInfo)
+	mock.lockGetVersionsByCollectionID.Unlock()
+	return mock.GetVersionsByCollectionIDFunc(ctx, collectionID, states)
+}
+
+// GetVersionsByCollectionIDCalls gets all the calls that were made to GetVersionsByCollectionID.
+// Check the length with:
+//
+//	len(mockedMongoDB.GetVersionsByCollectionIDCalls())
+func (mock *MongoDBMock) GetVersionsByCollectionIDCalls() []struct {
+	Ctx          context.Context
+	CollectionID string
+	States       []string
+} {
+	var calls []struct {
+		Ctx          context.Context
+		CollectionID string
+		States       []string
+	}
+	mock.lockGetVersionsByCollectionID.RLock()
+	calls = mock.calls.GetVersionsByCollectionID
+	mock.lockGetVersionsByCollectionID.RUnlock()
+	return calls
+}
+
 // GetVersionsStatic calls GetVersionsStaticFunc.
 func (mock *MongoDBMock) GetVersionsStatic(ctx context.Context, datasetID string, edition string, state string, offset int, limit int) ([]models.Version, int, error) {
 	if mock.GetVersionsStaticFunc == nil {
@@ -2708,6 +3650,146 @@ func (mock *MongoDBMock) IsStaticDatasetCalls() []struct {
 	return calls
 }
 
+// LockScheduledPublishing calls LockScheduledPublishingFunc.
+func (mock *MongoDBMock) LockScheduledPublishing(ctx context.Context) (string, error) {
+	if mock.LockScheduledPublishingFunc == nil {
+		panic("MongoDBMock.LockScheduledPublishingFunc: method is nil but MongoDB.LockScheduledPublishing was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockLockScheduledPublishing.Lock()
+	mock.calls.LockScheduledPublishing = append(mock.calls.LockScheduledPublishing, callInfo)
+	mock.lockLockScheduledPublishing.Unlock()
+	return mock.LockScheduledPublishingFunc(ctx)
+}
+
+// LockScheduledPublishingCalls gets all the calls that were made to LockScheduledPublishing.
+// Check the length with:
+//
+//	len(mockedMongoDB.LockScheduledPublishingCalls())
+func (mock *MongoDBMock) LockScheduledPublishingCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockLockScheduledPublishing.RLock()
+	calls = mock.calls.LockScheduledPublishing
+	mock.lockLockScheduledPublishing.RUnlock()
+	return calls
+}
+
+// PurgeDeletedDatasets calls PurgeDeletedDatasetsFunc.
+func (mock *MongoDBMock) PurgeDeletedDatasets(ctx context.Context, before time.Time) (int, error) {
+	if mock.PurgeDeletedDatasetsFunc == nil {
+		panic("MongoDBMock.PurgeDeletedDatasetsFunc: method is nil but MongoDB.PurgeDeletedDatasets was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Before time.Time
+	}{
+		Ctx:    ctx,
+		Before: before,
+	}
+	mock.lockPurgeDeletedDatasets.Lock()
+	mock.calls.PurgeDeletedDatasets = append(mock.calls.PurgeDeletedDatasets, callInfo)
+	mock.lockPurgeDeletedDatasets.Unlock()
+	return mock.PurgeDeletedDatasetsFunc(ctx, before)
+}
+
+// PurgeDeletedDatasetsCalls gets all the calls that were made to PurgeDeletedDatasets.
+// Check the length with:
+//
+//	len(mockedMongoDB.PurgeDeletedDatasetsCalls())
+func (mock *MongoDBMock) PurgeDeletedDatasetsCalls() []struct {
+	Ctx    context.Context
+	Before time.Time
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Before time.Time
+	}
+	mock.lockPurgeDeletedDatasets.RLock()
+	calls = mock.calls.PurgeDeletedDatasets
+	mock.lockPurgeDeletedDatasets.RUnlock()
+	return calls
+}
+
+// PurgeSentOutboxMessages calls PurgeSentOutboxMessagesFunc.
+func (mock *MongoDBMock) PurgeSentOutboxMessages(ctx context.Context, before time.Time) (int, error) {
+	if mock.PurgeSentOutboxMessagesFunc == nil {
+		panic("MongoDBMock.PurgeSentOutboxMessagesFunc: method is nil but MongoDB.PurgeSentOutboxMessages was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Before time.Time
+	}{
+		Ctx:    ctx,
+		Before: before,
+	}
+	mock.lockPurgeSentOutboxMessages.Lock()
+	mock.calls.PurgeSentOutboxMessages = append(mock.calls.PurgeSentOutboxMessages, callInfo)
+	mock.lockPurgeSentOutboxMessages.Unlock()
+	return mock.PurgeSentOutboxMessagesFunc(ctx, before)
+}
+
+// PurgeSentOutboxMessagesCalls gets all the calls that were made to PurgeSentOutboxMessages.
+// Check the length with:
+//
+//	len(mockedMongoDB.PurgeSentOutboxMessagesCalls())
+func (mock *MongoDBMock) PurgeSentOutboxMessagesCalls() []struct {
+	Ctx    context.Context
+	Before time.Time
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Before time.Time
+	}
+	mock.lockPurgeSentOutboxMessages.RLock()
+	calls = mock.calls.PurgeSentOutboxMessages
+	mock.lockPurgeSentOutboxMessages.RUnlock()
+	return calls
+}
+
+// PurgeStaticVersion calls PurgeStaticVersionFunc.
+func (mock *MongoDBMock) PurgeStaticVersion(ctx context.Context, ID string) error {
+	if mock.PurgeStaticVersionFunc == nil {
+		panic("MongoDBMock.PurgeStaticVersionFunc: method is nil but MongoDB.PurgeStaticVersion was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  string
+	}{
+		Ctx: ctx,
+		ID:  ID,
+	}
+	mock.lockPurgeStaticVersion.Lock()
+	mock.calls.PurgeStaticVersion = append(mock.calls.PurgeStaticVersion, callInfo)
+	mock.lockPurgeStaticVersion.Unlock()
+	return mock.PurgeStaticVersionFunc(ctx, ID)
+}
+
+// PurgeStaticVersionCalls gets all the calls that were made to PurgeStaticVersion.
+// Check the length with:
+//
+//	len(mockedMongoDB.PurgeStaticVersionCalls())
+func (mock *MongoDBMock) PurgeStaticVersionCalls() []struct {
+	Ctx context.Context
+	ID  string
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  string
+	}
+	mock.lockPurgeStaticVersion.RLock()
+	calls = mock.calls.PurgeStaticVersion
+	mock.lockPurgeStaticVersion.RUnlock()
+	return calls
+}
+
 // RemoveDatasetVersionAndEditionLinks calls RemoveDatasetVersionAndEditionLinksFunc.
 func (mock *MongoDBMock) RemoveDatasetVersionAndEditionLinks(ctx context.Context, id string) error {
 	if mock.RemoveDatasetVersionAndEditionLinksFunc == nil {
@@ -2744,6 +3826,198 @@ func (mock *MongoDBMock) RemoveDatasetVersionAndEditionLinksCalls() []struct {
 	return calls
 }
 
+// RequeueFailedOutboxMessages calls RequeueFailedOutboxMessagesFunc.
+func (mock *MongoDBMock) RequeueFailedOutboxMessages(ctx context.Context, now time.Time) (int, error) {
+	if mock.RequeueFailedOutboxMessagesFunc == nil {
+		panic("MongoDBMock.RequeueFailedOutboxMessagesFunc: method is nil but MongoDB.RequeueFailedOutboxMessages was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Now time.Time
+	}{
+		Ctx: ctx,
+		Now: now,
+	}
+	mock.lockRequeueFailedOutboxMessages.Lock()
+	mock.calls.RequeueFailedOutboxMessages = append(mock.calls.RequeueFailedOutboxMessages, callInfo)
+	mock.lockRequeueFailedOutboxMessages.Unlock()
+	return mock.RequeueFailedOutboxMessagesFunc(ctx, now)
+}
+
+// RequeueFailedOutboxMessagesCalls gets all the calls that were made to RequeueFailedOutboxMessages.
+// Check the length with:
+//
+//	len(mockedMongoDB.RequeueFailedOutboxMessagesCalls())
+func (mock *MongoDBMock) RequeueFailedOutboxMessagesCalls() []struct {
+	Ctx context.Context
+	Now time.Time
+} {
+	var calls []struct {
+		Ctx context.Context
+		Now time.Time
+	}
+	mock.lockRequeueFailedOutboxMessages.RLock()
+	calls = mock.calls.RequeueFailedOutboxMessages
+	mock.lockRequeueFailedOutboxMessages.RUnlock()
+	return calls
+}
+
+// RestoreDataset calls RestoreDatasetFunc.
+func (mock *MongoDBMock) RestoreDataset(ctx context.Context, ID string) error {
+	if mock.RestoreDatasetFunc == nil {
+		panic("MongoDBMock.RestoreDatasetFunc: method is nil but MongoDB.RestoreDataset was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  string
+	}{
+		Ctx: ctx,
+		ID:  ID,
+	}
+	mock.lockRestoreDataset.Lock()
+	mock.calls.RestoreDataset = append(mock.calls.RestoreDataset, callInfo)
+	mock.lockRestoreDataset.Unlock()
+	return mock.RestoreDatasetFunc(ctx, ID)
+}
+
+// RestoreDatasetCalls gets all the calls that were made to RestoreDataset.
+// Check the length with:
+//
+//	len(mockedMongoDB.RestoreDatasetCalls())
+func (mock *MongoDBMock) RestoreDatasetCalls() []struct {
+	Ctx context.Context
+	ID  string
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  string
+	}
+	mock.lockRestoreDataset.RLock()
+	calls = mock.calls.RestoreDataset
+	mock.lockRestoreDataset.RUnlock()
+	return calls
+}
+
+// RestoreEdition calls RestoreEditionFunc.
+func (mock *MongoDBMock) RestoreEdition(ctx context.Context, datasetID string, edition string) error {
+	if mock.RestoreEditionFunc == nil {
+		panic("MongoDBMock.RestoreEditionFunc: method is nil but MongoDB.RestoreEdition was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		DatasetID string
+		Edition   string
+	}{
+		Ctx:       ctx,
+		DatasetID: datasetID,
+		Edition:   edition,
+	}
+	mock.lockRestoreEdition.Lock()
+	mock.calls.RestoreEdition = append(mock.calls.RestoreEdition, callInfo)
+	mock.lockRestoreEdition.Unlock()
+	return mock.RestoreEditionFunc(ctx, datasetID, edition)
+}
+
+// RestoreEditionCalls gets all the calls that were made to RestoreEdition.
+// Check the length with:
+//
+//	len(mockedMongoDB.RestoreEditionCalls())
+func (mock *MongoDBMock) RestoreEditionCalls() []struct {
+	Ctx       context.Context
+	DatasetID string
+	Edition   string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		DatasetID string
+		Edition   string
+	}
+	mock.lockRestoreEdition.RLock()
+	calls = mock.calls.RestoreEdition
+	mock.lockRestoreEdition.RUnlock()
+	return calls
+}
+
+// RestoreStaticVersion calls RestoreStaticVersionFunc.
+func (mock *MongoDBMock) RestoreStaticVersion(ctx context.Context, datasetID string, edition string, version int) error {
+	if mock.RestoreStaticVersionFunc == nil {
+		panic("MongoDBMock.RestoreStaticVersionFunc: method is nil but MongoDB.RestoreStaticVersion was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		DatasetID string
+		Edition   string
+		Version   int
+	}{
+		Ctx:       ctx,
+		DatasetID: datasetID,
+		Edition:   edition,
+		Version:   version,
+	}
+	mock.lockRestoreStaticVersion.Lock()
+	mock.calls.RestoreStaticVersion = append(mock.calls.RestoreStaticVersion, callInfo)
+	mock.lockRestoreStaticVersion.Unlock()
+	return mock.RestoreStaticVersionFunc(ctx, datasetID, edition, version)
+}
+
+// RestoreStaticVersionCalls gets all the calls that were made to RestoreStaticVersion.
+// Check the length with:
+//
+//	len(mockedMongoDB.RestoreStaticVersionCalls())
+func (mock *MongoDBMock) RestoreStaticVersionCalls() []struct {
+	Ctx       context.Context
+	DatasetID string
+	Edition   string
+	Version   int
+} {
+	var calls []struct {
+		Ctx       context.Context
+		DatasetID string
+		Edition   string
+		Version   int
+	}
+	mock.lockRestoreStaticVersion.RLock()
+	calls = mock.calls.RestoreStaticVersion
+	mock.lockRestoreStaticVersion.RUnlock()
+	return calls
+}
+
+// RunTransaction calls RunTransactionFunc.
+func (mock *MongoDBMock) RunTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if mock.RunTransactionFunc == nil {
+		panic("MongoDBMock.RunTransactionFunc: method is nil but MongoDB.RunTransaction was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Fn  func(ctx context.Context) error
+	}{
+		Ctx: ctx,
+		Fn:  fn,
+	}
+	mock.lockRunTransaction.Lock()
+	mock.calls.RunTransaction = append(mock.calls.RunTransaction, callInfo)
+	mock.lockRunTransaction.Unlock()
+	return mock.RunTransactionFunc(ctx, fn)
+}
+
+// RunTransactionCalls gets all the calls that were made to RunTransaction.
+// Check the length with:
+//
+//	len(mockedMongoDB.RunTransactionCalls())
+func (mock *MongoDBMock) RunTransactionCalls() []struct {
+	Ctx context.Context
+	Fn  func(ctx context.Context) error
+} {
+	var calls []struct {
+		Ctx context.Context
+		Fn  func(ctx context.Context) error
+	}
+	mock.lockRunTransaction.RLock()
+	calls = mock.calls.RunTransaction
+	mock.lockRunTransaction.RUnlock()
+	return calls
+}
+
 // UnlockInstance calls UnlockInstanceFunc.
 func (mock *MongoDBMock) UnlockInstance(ctx context.Context, lockID string) {
 	if mock.UnlockInstanceFunc == nil {
@@ -2780,6 +4054,42 @@ func (mock *MongoDBMock) UnlockInstanceCalls() []struct {
 	return calls
 }
 
+// UnlockScheduledPublishing calls UnlockScheduledPublishingFunc.
+func (mock *MongoDBMock) UnlockScheduledPublishing(ctx context.Context, lockID string) {
+	if mock.UnlockScheduledPublishingFunc == nil {
+		panic("MongoDBMock.UnlockScheduledPublishingFunc: method is nil but MongoDB.UnlockScheduledPublishing was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		LockID string
+	}{
+		Ctx:    ctx,
+		LockID: lockID,
+	}
+	mock.lockUnlockScheduledPublishing.Lock()
+	mock.calls.UnlockScheduledPublishing = append(mock.calls.UnlockScheduledPublishing, callInfo)
+	mock.lockUnlockScheduledPublishing.Unlock()
+	mock.UnlockScheduledPublishingFunc(ctx, lockID)
+}
+
+// UnlockScheduledPublishingCalls gets all the calls that were made to UnlockScheduledPublishing.
+// Check the length with:
+//
+//	len(mockedMongoDB.UnlockScheduledPublishingCalls())
+func (mock *MongoDBMock) UnlockScheduledPublishingCalls() []struct {
+	Ctx    context.Context
+	LockID string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		LockID string
+	}
+	mock.lockUnlockScheduledPublishing.RLock()
+	calls = mock.calls.UnlockScheduledPublishing
+	mock.lockUnlockScheduledPublishing.RUnlock()
+	return calls
+}
+
 // UnlockVersions calls UnlockVersionsFunc.
 func (mock *MongoDBMock) UnlockVersions(ctx context.Context, lockID string) {
 	if mock.UnlockVersionsFunc == nil {
@@ -3268,6 +4578,42 @@ func (mock *MongoDBMock) UpdateObservationInsertedCalls() []struct {
 	return calls
 }
 
+// UpdateOutboxMessage calls UpdateOutboxMessageFunc.
+func (mock *MongoDBMock) UpdateOutboxMessage(ctx context.Context, message *models.OutboxMessage) error {
+	if mock.UpdateOutboxMessageFunc == nil {
+		panic("MongoDBMock.UpdateOutboxMessageFunc: method is nil but MongoDB.UpdateOutboxMessage was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Message *models.OutboxMessage
+	}{
+		Ctx:     ctx,
+		Message: message,
+	}
+	mock.lockUpdateOutboxMessage.Lock()
+	mock.calls.UpdateOutboxMessage = append(mock.calls.UpdateOutboxMessage, callInfo)
+	mock.lockUpdateOutboxMessage.Unlock()
+	return mock.UpdateOutboxMessageFunc(ctx, message)
+}
+
+// UpdateOutboxMessageCalls gets all the calls that were made to UpdateOutboxMessage.
+// Check the length with:
+//
+//	len(mockedMongoDB.UpdateOutboxMessageCalls())
+func (mock *MongoDBMock) UpdateOutboxMessageCalls() []struct {
+	Ctx     context.Context
+	Message *models.OutboxMessage
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Message *models.OutboxMessage
+	}
+	mock.lockUpdateOutboxMessage.RLock()
+	calls = mock.calls.UpdateOutboxMessage
+	mock.lockUpdateOutboxMessage.RUnlock()
+	return calls
+}
+
 // UpdateVersion calls UpdateVersionFunc.
 func (mock *MongoDBMock) UpdateVersion(ctx context.Context, currentVersion *models.Version, version *models.Version, eTagSelector string) (string, error) {
 	if mock.UpdateVersionFunc == nil {
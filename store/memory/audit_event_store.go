@@ -0,0 +1,64 @@
+package memory
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ONSdigital/dp-dataset-api/config"
+	"github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/dp-dataset-api/mongo"
+	mongodriver "github.com/ONSdigital/dp-mongodb/v3/mongodb"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// CreateAuditEvent chains a new audit event to the last one and inserts it into the dataset_events collection
+func (s *Store) CreateAuditEvent(ctx context.Context, event *models.AuditEvent) error {
+	s.auditMu.Lock()
+	defer s.auditMu.Unlock()
+
+	collection := s.collection(config.DatasetEventsCollection)
+
+	var last *models.AuditEvent
+	doc, err := collection.findOne(bson.M{"sequence": bson.M{"$exists": true}}, "sequence", -1)
+	switch {
+	case err == nil:
+		last = &models.AuditEvent{}
+		if err = decode(doc, last); err != nil {
+			return err
+		}
+	case !errors.Is(err, mongodriver.ErrNoDocumentFound):
+		return err
+	}
+
+	if err = event.ChainTo(last); err != nil {
+		return err
+	}
+
+	_, err = collection.insert(ctx, event)
+	return err
+}
+
+// GetAuditEventChain returns up to limit events of the audit chain, in sequence order, starting from fromSequence
+func (s *Store) GetAuditEventChain(_ context.Context, fromSequence int64, limit int) ([]*models.AuditEvent, error) {
+	docs, _, err := s.collection(config.DatasetEventsCollection).find(bson.M{"sequence": bson.M{"$gte": fromSequence}}, "sequence", 1, 0, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeAll[models.AuditEvent](docs)
+}
+
+// GetAuditEvents returns the audit events that match the filter, most recent first, along with the total number of matching events
+func (s *Store) GetAuditEvents(_ context.Context, filter *models.AuditEventFilter, offset, limit int) ([]*models.AuditEvent, int, error) {
+	docs, totalCount, err := s.collection(config.DatasetEventsCollection).find(mongo.BuildAuditEventsQuery(filter), "created_at", -1, offset, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	events, err := decodeAll[models.AuditEvent](docs)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return events, totalCount, nil
+}
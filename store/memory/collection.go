@@ -0,0 +1,370 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	mongodriver "github.com/ONSdigital/dp-mongodb/v3/mongodb"
+	"go.mongodb.org/mongo-driver/bson"
+	bsonprim "go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ErrDuplicateKey is returned when a document is inserted with an _id that already exists in the collection
+var ErrDuplicateKey = errors.New("duplicate key error")
+
+var timestampIncrement uint32
+
+// nextTimestamp returns a unique timestamp for the provided time, equivalent to the ones generated by mongoDB
+func nextTimestamp(t time.Time) bsonprim.Timestamp {
+	return bsonprim.Timestamp{T: uint32(t.Unix()), I: atomic.AddUint32(&timestampIncrement, 1)} //nolint:gosec // unix time fits in uint32 until 2106
+}
+
+// Collection is an in-memory collection of generic bson documents,
+// which supports the subset of mongoDB query and update operators used by the dataset API.
+type Collection struct {
+	mu   sync.RWMutex
+	docs []bson.M
+}
+
+func newCollection() *Collection {
+	return &Collection{docs: []bson.M{}}
+}
+
+// FindOne decodes the first document that satisfies the filter into result.
+// If no document could be found, an ErrNoDocumentFound error is returned
+func (c *Collection) FindOne(_ context.Context, filter bson.M, result interface{}) error {
+	doc, err := c.findOne(filter, "", 0)
+	if err != nil {
+		return err
+	}
+	return decode(doc, result)
+}
+
+// Count returns the number of documents in the collection that satisfy the filter
+func (c *Collection) Count(_ context.Context, filter bson.M) (int, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	count := 0
+	for _, doc := range c.docs {
+		ok, err := matches(doc, filter)
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// UpsertById creates or updates the document with the provided _id
+//
+//nolint:revive,stylecheck // named after the equivalent mongoDB collection method
+func (c *Collection) UpsertById(ctx context.Context, id interface{}, update bson.M) error {
+	_, err := c.updateOne(ctx, bson.M{"_id": id}, update, true)
+	return err
+}
+
+// UpdateById modifies the document with the provided _id.
+// If the document cannot be found, an ErrNoDocumentFound error is returned
+//
+//nolint:revive,stylecheck // named after the equivalent mongoDB collection method
+func (c *Collection) UpdateById(ctx context.Context, id interface{}, update bson.M) error {
+	matched, err := c.updateOne(ctx, bson.M{"_id": id}, update, false)
+	if err != nil {
+		return err
+	}
+	if matched == 0 {
+		return mongodriver.ErrNoDocumentFound
+	}
+	return nil
+}
+
+// Drop removes all the documents from the collection
+func (c *Collection) Drop(_ context.Context) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.docs = []bson.M{}
+}
+
+// find returns copies of the documents that satisfy the filter, sorted by sortBy (if provided) and paginated according to offset and limit,
+// along with the total number of documents that satisfy the filter. A limit lower or equal than zero only returns the total count.
+func (c *Collection) find(filter bson.M, sortBy string, sortDir, offset, limit int) ([]bson.M, int, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	found := []bson.M{}
+	for _, doc := range c.docs {
+		ok, err := matches(doc, filter)
+		if err != nil {
+			return nil, 0, err
+		}
+		if ok {
+			found = append(found, doc)
+		}
+	}
+
+	totalCount := len(found)
+	if limit <= 0 || offset >= totalCount {
+		return []bson.M{}, totalCount, nil
+	}
+
+	if sortBy == "" {
+		sortBy, sortDir = "_id", 1
+	}
+	sortDocuments(found, sortBy, sortDir)
+
+	end := offset + limit
+	if end > totalCount {
+		end = totalCount
+	}
+
+	page := make([]bson.M, 0, end-offset)
+	for _, doc := range found[offset:end] {
+		page = append(page, copyDocument(doc))
+	}
+
+	return page, totalCount, nil
+}
+
+// findAll returns copies of all the documents that satisfy the filter, sorted by sortBy if provided
+func (c *Collection) findAll(filter bson.M, sortBy string, sortDir int) ([]bson.M, error) {
+	c.mu.RLock()
+	size := len(c.docs)
+	c.mu.RUnlock()
+
+	docs, _, err := c.find(filter, sortBy, sortDir, 0, size)
+	return docs, err
+}
+
+// findOne returns a copy of the first document that satisfies the filter, according to the sort order if provided
+func (c *Collection) findOne(filter bson.M, sortBy string, sortDir int) (bson.M, error) {
+	docs, _, err := c.find(filter, sortBy, sortDir, 0, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(docs) == 0 {
+		return nil, mongodriver.ErrNoDocumentFound
+	}
+	return docs[0], nil
+}
+
+// distinct returns the distinct values of a field for the documents that satisfy the filter
+func (c *Collection) distinct(field string, filter bson.M) ([]interface{}, error) {
+	docs, err := c.findAll(filter, "", 0)
+	if err != nil {
+		return nil, err
+	}
+
+	values := []interface{}{}
+	for _, doc := range docs {
+		for _, v := range lookup(doc, field) {
+			if !containsValue(values, v) {
+				values = append(values, v)
+			}
+		}
+	}
+
+	sort.SliceStable(values, func(i, j int) bool { return compare(values[i], values[j]) < 0 })
+
+	return values, nil
+}
+
+// insert adds the provided document to the collection, generating an _id if it does not have one
+func (c *Collection) insert(ctx context.Context, document interface{}) (bson.M, error) {
+	doc, err := toDocument(document)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := doc["_id"]; !ok {
+		doc["_id"] = bsonprim.NewObjectID()
+	}
+
+	for _, existing := range c.docs {
+		if equal(existing["_id"], doc["_id"]) {
+			return nil, fmt.Errorf("%w: _id %v", ErrDuplicateKey, doc["_id"])
+		}
+	}
+
+	c.docs = append(c.docs, doc)
+	record(ctx, c, nil, doc)
+	return copyDocument(doc), nil
+}
+
+// updateOne applies the update to the first document that satisfies the filter, returning the number of matched documents.
+// If upsert is true and no document matches the filter, a new document is created from the filter and the update.
+func (c *Collection) updateOne(ctx context.Context, filter, update bson.M, upsert bool) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i, doc := range c.docs {
+		ok, err := matches(doc, filter)
+		if err != nil {
+			return 0, err
+		}
+		if !ok {
+			continue
+		}
+
+		// apply the update to a copy so that the document is left untouched if it fails
+		updated := copyDocument(doc)
+		if err := applyUpdate(updated, update, filter, false); err != nil {
+			return 0, err
+		}
+		c.docs[i] = updated
+		record(ctx, c, doc, updated)
+		return 1, nil
+	}
+
+	if !upsert {
+		return 0, nil
+	}
+
+	doc := bson.M{}
+	for k, v := range filter {
+		if _, isOperator := v.(bson.M); isOperator || k[0] == '$' {
+			continue
+		}
+		if err := setPath(doc, k, v, nil); err != nil {
+			return 0, err
+		}
+	}
+	if err := applyUpdate(doc, update, filter, true); err != nil {
+		return 0, err
+	}
+	if _, ok := doc["_id"]; !ok {
+		doc["_id"] = bsonprim.NewObjectID()
+	}
+
+	c.docs = append(c.docs, doc)
+	record(ctx, c, nil, doc)
+	return 0, nil
+}
+
+// findOneAndUpdate atomically applies the update to the first document that satisfies the filter, according to the sort order,
+// returning a copy of the updated document. If no document could be found, an ErrNoDocumentFound error is returned
+func (c *Collection) findOneAndUpdate(ctx context.Context, filter, update bson.M, sortBy string, sortDir int) (bson.M, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	found := []bson.M{}
+	for _, doc := range c.docs {
+		ok, err := matches(doc, filter)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			found = append(found, doc)
+		}
+	}
+	if len(found) == 0 {
+		return nil, mongodriver.ErrNoDocumentFound
+	}
+
+	if sortBy == "" {
+		sortBy, sortDir = "_id", 1
+	}
+	sortDocuments(found, sortBy, sortDir)
+
+	for i, doc := range c.docs {
+		if !equal(doc["_id"], found[0]["_id"]) {
+			continue
+		}
+
+		updated := copyDocument(doc)
+		if err := applyUpdate(updated, update, filter, false); err != nil {
+			return nil, err
+		}
+		c.docs[i] = updated
+		record(ctx, c, doc, updated)
+		return copyDocument(updated), nil
+	}
+
+	return nil, mongodriver.ErrNoDocumentFound
+}
+
+// deleteOne removes the first document that satisfies the filter, returning the number of deleted documents
+func (c *Collection) deleteOne(ctx context.Context, filter bson.M) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i, doc := range c.docs {
+		ok, err := matches(doc, filter)
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			c.docs = append(c.docs[:i], c.docs[i+1:]...)
+			record(ctx, c, doc, nil)
+			return 1, nil
+		}
+	}
+
+	return 0, nil
+}
+
+// updateMany applies the update to all the documents that satisfy the filter, returning the number of matched documents
+func (c *Collection) updateMany(ctx context.Context, filter, update bson.M) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	updated := make([]bson.M, len(c.docs))
+	matchedIndexes := []int{}
+	for i, doc := range c.docs {
+		ok, err := matches(doc, filter)
+		if err != nil {
+			return 0, err
+		}
+		if !ok {
+			updated[i] = doc
+			continue
+		}
+
+		// apply the updates to copies so that the documents are left untouched if any of them fails
+		updated[i] = copyDocument(doc)
+		if err := applyUpdate(updated[i], update, filter, false); err != nil {
+			return 0, err
+		}
+		matchedIndexes = append(matchedIndexes, i)
+	}
+
+	for _, i := range matchedIndexes {
+		record(ctx, c, c.docs[i], updated[i])
+	}
+
+	c.docs = updated
+	return len(matchedIndexes), nil
+}
+
+// deleteMany removes all the documents that satisfy the filter, returning the number of deleted documents
+func (c *Collection) deleteMany(ctx context.Context, filter bson.M) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	kept := make([]bson.M, 0, len(c.docs))
+	for _, doc := range c.docs {
+		ok, err := matches(doc, filter)
+		if err != nil {
+			return 0, err
+		}
+		if !ok {
+			kept = append(kept, doc)
+			continue
+		}
+		record(ctx, c, doc, nil)
+	}
+
+	deleted := len(c.docs) - len(kept)
+	c.docs = kept
+	return deleted, nil
+}
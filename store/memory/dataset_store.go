@@ -0,0 +1,550 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	"github.com/ONSdigital/dp-dataset-api/config"
+	"github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/dp-dataset-api/mongo"
+	"github.com/ONSdigital/dp-dataset-api/pagination"
+	mongodriver "github.com/ONSdigital/dp-mongodb/v3/mongodb"
+	"github.com/ONSdigital/log.go/v2/log"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// decodeAll decodes the provided generic documents into a slice of the required type
+func decodeAll[T any](docs []bson.M) ([]*T, error) {
+	values := make([]*T, 0, len(docs))
+	for _, doc := range docs {
+		var v T
+		if err := decode(doc, &v); err != nil {
+			return nil, err
+		}
+		values = append(values, &v)
+	}
+	return values, nil
+}
+
+// GetDatasetsByQueryParams retrieves the dataset documents that satisfy the provided query parameters
+func (s *Store) GetDatasetsByQueryParams(ctx context.Context, id, datasetType, sortOrder, datasetID string, offset, limit int, cursor *pagination.Cursor, authorised bool) ([]*models.DatasetUpdate, int, *pagination.Cursor, error) {
+	filter, err := mongo.BuildDatasetsQueryUsingParameters(id, datasetType, datasetID, authorised)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
+	sortDir := -1
+	if sortOrder == mongo.ASCOrder {
+		sortDir = 1
+	}
+
+	values, totalCount, nextCursor, err := s.findDatasets(ctx, filter, sortDir, offset, limit, cursor)
+	if err != nil {
+		return nil, 0, nil, fmt.Errorf("failed to retrieve datasets: %w", err)
+	}
+	if len(values) == 0 {
+		return nil, 0, nil, errs.ErrDatasetNotFound
+	}
+
+	return values, totalCount, nextCursor, nil
+}
+
+// GetDatasets retrieves all dataset documents
+func (s *Store) GetDatasets(ctx context.Context, offset, limit int, cursor *pagination.Cursor, authorised bool) ([]*models.DatasetUpdate, int, *pagination.Cursor, error) {
+	filter := bson.M{}
+	if !authorised {
+		filter["current"] = bson.M{"$exists": true}
+	}
+
+	return s.findDatasets(ctx, filter, -1, offset, limit, cursor)
+}
+
+// findDatasets retrieves the page of dataset documents that satisfy the filter, sorted by id in the provided direction,
+// starting at the offset or right after the cursor if provided, along with the total count and the cursor of the next page
+func (s *Store) findDatasets(ctx context.Context, filter bson.M, sortDir, offset, limit int, cursor *pagination.Cursor) ([]*models.DatasetUpdate, int, *pagination.Cursor, error) {
+	collection := s.collection(config.DatasetsCollection)
+
+	filter = mongo.NotDeleted(filter)
+
+	var (
+		docs       []bson.M
+		totalCount int
+		hasMore    bool
+		err        error
+	)
+	if cursor == nil {
+		docs, totalCount, err = collection.find(filter, "_id", sortDir, offset, limit)
+		hasMore = offset+len(docs) < totalCount
+	} else {
+		if totalCount, err = collection.Count(ctx, filter); err != nil {
+			return nil, 0, nil, err
+		}
+		var remaining int
+		docs, remaining, err = collection.find(mongo.AfterCursor(filter, "", nil, "_id", cursor.ID, sortDir), "_id", sortDir, 0, limit)
+		hasMore = len(docs) < remaining
+	}
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
+	values, err := decodeAll[models.DatasetUpdate](docs)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
+	return values, totalCount, mongo.NextCursor(values, hasMore, mongo.DatasetCursor), nil
+}
+
+// GetDataset retrieves a dataset document
+func (s *Store) GetDataset(_ context.Context, id string) (*models.DatasetUpdate, error) {
+	doc, err := s.collection(config.DatasetsCollection).findOne(mongo.NotDeleted(bson.M{"_id": id}), "", 0)
+	if err != nil {
+		if errors.Is(err, mongodriver.ErrNoDocumentFound) {
+			return nil, errs.ErrDatasetNotFound
+		}
+		return nil, err
+	}
+
+	var dataset models.DatasetUpdate
+	if err := decode(doc, &dataset); err != nil {
+		return nil, err
+	}
+
+	return &dataset, nil
+}
+
+// GetDatasetsByCollectionID retrieves the datasets whose next document belongs to a collection and is in one of the
+// provided states, sorted by id
+func (s *Store) GetDatasetsByCollectionID(_ context.Context, collectionID string, states []string) ([]*models.DatasetUpdate, error) {
+	docs, err := s.collection(config.DatasetsCollection).findAll(mongo.CollectionDatasetsSelector(collectionID, states), "_id", 1)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeAll[models.DatasetUpdate](docs)
+}
+
+// CheckDatasetTitleExist checks if a dataset with the provided title exists
+func (s *Store) CheckDatasetTitleExist(ctx context.Context, title string) (bool, error) {
+	titleFilter := bson.M{
+		"$or": bson.A{
+			bson.M{"current.title": title},
+			bson.M{"next.title": title},
+		},
+	}
+
+	count, err := s.collection(config.DatasetsCollection).Count(ctx, mongo.NotDeleted(titleFilter))
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// GetEditions retrieves all edition documents for a dataset
+func (s *Store) GetEditions(_ context.Context, id, state string, offset, limit int, authorised bool) ([]*models.EditionUpdate, int, error) {
+	selector := mongo.BuildEditionsQuery(id, state, authorised)
+
+	docs, totalCount, err := s.collection(config.EditionsCollection).find(mongo.NotDeleted(selector), "_id", 1, offset, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if totalCount < 1 {
+		return nil, 0, errs.ErrEditionNotFound
+	}
+
+	results, err := decodeAll[models.EditionUpdate](docs)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return results, totalCount, nil
+}
+
+// GetEdition retrieves an edition document for a dataset
+func (s *Store) GetEdition(_ context.Context, id, editionID, state string) (*models.EditionUpdate, error) {
+	selector := mongo.BuildEditionQuery(id, editionID, state)
+
+	doc, err := s.collection(config.EditionsCollection).findOne(mongo.NotDeleted(selector), "", 0)
+	if err != nil {
+		if errors.Is(err, mongodriver.ErrNoDocumentFound) {
+			return nil, errs.ErrEditionNotFound
+		}
+		return nil, err
+	}
+
+	var edition models.EditionUpdate
+	if err := decode(doc, &edition); err != nil {
+		return nil, err
+	}
+
+	return &edition, nil
+}
+
+// GetNextVersion retrieves the latest version for an edition of a dataset
+func (s *Store) GetNextVersion(_ context.Context, datasetID, edition string) (int, error) {
+	selector := bson.M{
+		"links.dataset.id": datasetID,
+		"edition":          edition,
+	}
+
+	doc, err := s.collection(config.InstanceCollection).findOne(selector, "version", -1)
+	if err != nil {
+		if errors.Is(err, mongodriver.ErrNoDocumentFound) {
+			return 1, nil
+		}
+		return 0, err
+	}
+
+	var version models.Version
+	if err := decode(doc, &version); err != nil {
+		return 0, err
+	}
+
+	return version.Version + 1, nil
+}
+
+// GetVersions retrieves all version documents for a dataset edition
+func (s *Store) GetVersions(_ context.Context, datasetID, editionID, state string, offset, limit int) ([]models.Version, int, error) {
+	return s.getVersions(config.InstanceCollection, datasetID, editionID, state, offset, limit)
+}
+
+func (s *Store) getVersions(collection, datasetID, editionID, state string, offset, limit int) ([]models.Version, int, error) {
+	selector := mongo.BuildVersionsQuery(datasetID, editionID, state)
+
+	docs, totalCount, err := s.collection(collection).find(mongo.NotDeleted(selector), "last_updated", -1, offset, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if totalCount < 1 {
+		return nil, 0, errs.ErrVersionNotFound
+	}
+
+	results := make([]models.Version, 0, len(docs))
+	for _, doc := range docs {
+		var v models.Version
+		if err := decode(doc, &v); err != nil {
+			return nil, 0, err
+		}
+		v.Links.Self.HRef = v.Links.Version.HRef
+		v.DatasetID = datasetID
+		results = append(results, v)
+	}
+
+	return results, totalCount, nil
+}
+
+// GetLatestVersion retrieves the most recently released version of a dataset, in the provided edition unless it is empty
+func (s *Store) GetLatestVersion(_ context.Context, datasetID, editionID, state string) (*models.Version, error) {
+	doc, err := s.collection(config.InstanceCollection).findOne(mongo.LatestVersionSelector(datasetID, editionID, state), "release_date", -1)
+	if err != nil {
+		if errors.Is(err, mongodriver.ErrNoDocumentFound) {
+			return nil, errs.ErrVersionNotFound
+		}
+		return nil, err
+	}
+
+	var version models.Version
+	if err := decode(doc, &version); err != nil {
+		return nil, err
+	}
+
+	return &version, nil
+}
+
+// GetVersion retrieves a version document for a dataset edition
+func (s *Store) GetVersion(_ context.Context, id, editionID string, versionID int, state string) (*models.Version, error) {
+	return s.getVersion(config.InstanceCollection, id, editionID, versionID, state)
+}
+
+func (s *Store) getVersion(collection, id, editionID string, versionID int, state string) (*models.Version, error) {
+	selector := mongo.BuildVersionQuery(id, editionID, state, versionID)
+
+	doc, err := s.collection(collection).findOne(mongo.NotDeleted(selector), "", 0)
+	if err != nil {
+		if errors.Is(err, mongodriver.ErrNoDocumentFound) {
+			return nil, errs.ErrVersionNotFound
+		}
+		return nil, err
+	}
+
+	var version models.Version
+	if err := decode(doc, &version); err != nil {
+		return nil, err
+	}
+
+	return &version, nil
+}
+
+// UpdateDataset updates an existing dataset document and stores the resulting metadata as a new revision
+func (s *Store) UpdateDataset(ctx context.Context, id string, dataset *models.Dataset, currentState string) error {
+	update := bson.M{"$set": mongo.CreateDatasetUpdateQuery(ctx, id, dataset, currentState)}
+
+	matched, err := s.collection(config.DatasetsCollection).updateOne(ctx, bson.M{"_id": id}, update, false)
+	if err != nil {
+		return err
+	}
+	if matched == 0 {
+		return errs.ErrDatasetNotFound
+	}
+
+	return s.addDatasetRevision(ctx, id)
+}
+
+// UpdateDatasetWithAssociation updates an existing dataset document with collection data
+func (s *Store) UpdateDatasetWithAssociation(ctx context.Context, id, state string, version *models.Version) error {
+	update := bson.M{
+		"$set": bson.M{
+			"next.state":                     state,
+			"next.collection_id":             version.CollectionID,
+			"next.links.latest_version.href": version.Links.Version.HRef,
+			"next.links.latest_version.id":   version.Links.Version.ID,
+			"next.last_updated":              time.Now(),
+		},
+	}
+
+	matched, err := s.collection(config.DatasetsCollection).updateOne(ctx, bson.M{"_id": id}, update, false)
+	if err != nil {
+		return err
+	}
+	if matched == 0 {
+		return errs.ErrDatasetNotFound
+	}
+
+	return s.addDatasetRevision(ctx, id)
+}
+
+// UpdateVersion updates an existing version document
+func (s *Store) UpdateVersion(ctx context.Context, currentVersion, versionUpdate *models.Version, eTagSelector string) (string, error) {
+	newETag, err := mongo.NewETagForVersionUpdate(currentVersion, versionUpdate)
+	if err != nil {
+		return "", err
+	}
+
+	sel := selector(currentVersion.ID, eTagSelector)
+	update := bson.M{"$set": mongo.CreateVersionUpdateQuery(versionUpdate, newETag)}
+
+	matched, err := s.collection(config.InstanceCollection).updateOne(ctx, sel, update, false)
+	if err != nil {
+		return "", err
+	}
+	if matched == 0 {
+		return "", errs.ErrDatasetNotFound
+	}
+
+	return newETag, nil
+}
+
+// UpdateMetadata updates the metadata fields of a dataset and its version, and stores a new revision of the dataset.
+// Both documents are checked before applying any change, so that the update is atomic.
+func (s *Store) UpdateMetadata(ctx context.Context, datasetID, versionID, versionEtag string, updatedDataset *models.Dataset, updatedVersion *models.Version) error {
+	updatedDataset.LastUpdated = time.Now()
+	datasetUpdate := bson.M{
+		"$set": bson.M{
+			"next":         updatedDataset,
+			"last_updated": updatedDataset.LastUpdated,
+		},
+	}
+
+	newETag, err := updatedVersion.Hash(nil)
+	if err != nil {
+		return err
+	}
+
+	versionSelector := selector(versionID, versionEtag)
+	versionUpdate := bson.M{
+		"$set": bson.M{
+			"alerts":         updatedVersion.Alerts,
+			"release_date":   updatedVersion.ReleaseDate,
+			"usage_notes":    updatedVersion.UsageNotes,
+			"latest_changes": updatedVersion.LatestChanges,
+			"dimensions":     updatedVersion.Dimensions,
+			"e_tag":          newETag,
+			"last_updated":   time.Now(),
+		},
+	}
+
+	datasets := s.collection(config.DatasetsCollection)
+	instances := s.collection(config.InstanceCollection)
+
+	if count, err := datasets.Count(ctx, bson.M{"_id": datasetID}); err != nil {
+		return err
+	} else if count == 0 {
+		return errs.ErrDatasetNotFound
+	}
+
+	if count, err := instances.Count(ctx, versionSelector); err != nil {
+		return err
+	} else if count == 0 {
+		return errs.ErrVersionNotFound
+	}
+
+	if _, err := datasets.updateOne(ctx, bson.M{"_id": datasetID}, datasetUpdate, false); err != nil {
+		return err
+	}
+
+	if _, err := instances.updateOne(ctx, versionSelector, versionUpdate, false); err != nil {
+		return err
+	}
+
+	return s.addDatasetRevision(ctx, datasetID)
+}
+
+// UpsertDataset adds or overrides an existing dataset document and stores the resulting metadata as a new revision
+func (s *Store) UpsertDataset(ctx context.Context, id string, datasetDoc *models.DatasetUpdate) error {
+	// a deleted dataset that is replaced by a new one is no longer deleted
+	update := bson.M{"$set": datasetDoc, "$unset": mongo.DeletionFields()}
+
+	if datasetDoc.Next.Type != models.Static.String() {
+		update["$setOnInsert"] = bson.M{"last_updated": time.Now()}
+	}
+
+	// nor is the published dataset that it replaces brought back with it
+	if datasetDoc.Current == nil {
+		if _, err := s.collection(config.DatasetsCollection).updateOne(ctx, mongo.Deleted(bson.M{"_id": id}), bson.M{"$unset": bson.M{"current": ""}}, false); err != nil {
+			return err
+		}
+	}
+
+	if _, err := s.collection(config.DatasetsCollection).updateOne(ctx, bson.M{"_id": id}, update, true); err != nil {
+		return err
+	}
+
+	return s.addDatasetRevision(ctx, id)
+}
+
+// RemoveDatasetVersionAndEditionLinks removes the editions and latest version links from the next sub-document of a dataset
+// and stores the resulting metadata as a new revision
+func (s *Store) RemoveDatasetVersionAndEditionLinks(ctx context.Context, id string) error {
+	update := bson.M{
+		"$unset": bson.M{
+			"next.links.editions":       "",
+			"next.links.latest_version": "",
+		},
+	}
+
+	matched, err := s.collection(config.DatasetsCollection).updateOne(ctx, bson.M{"_id": id}, update, false)
+	if err != nil {
+		return fmt.Errorf("failed in query to in-memory store: %w", err)
+	}
+	if matched == 0 {
+		return fmt.Errorf("failed in query to in-memory store: %w", mongodriver.ErrNoDocumentFound)
+	}
+
+	return s.addDatasetRevision(ctx, id)
+}
+
+// UpsertEdition adds or overrides an existing edition document
+func (s *Store) UpsertEdition(ctx context.Context, datasetID, edition string, editionDoc *models.EditionUpdate) error {
+	selector := bson.M{
+		"next.edition":          edition,
+		"next.links.dataset.id": datasetID,
+	}
+
+	editionDoc.Next.LastUpdated = time.Now()
+
+	_, err := s.collection(config.EditionsCollection).updateOne(ctx, selector, bson.M{"$set": editionDoc, "$unset": mongo.DeletionFields()}, true)
+	return err
+}
+
+// UpsertVersion adds or overrides an existing version document
+func (s *Store) UpsertVersion(ctx context.Context, id string, version *models.Version) error {
+	update := bson.M{
+		"$set": version,
+		"$setOnInsert": bson.M{
+			"last_updated": time.Now(),
+		},
+	}
+
+	_, err := s.collection(config.InstanceCollection).updateOne(ctx, bson.M{"id": id}, update, true)
+	return err
+}
+
+// UpsertContact adds or overrides an existing contact document
+func (s *Store) UpsertContact(ctx context.Context, id string, update interface{}) error {
+	doc, err := toDocument(update)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.collection(config.ContactsCollection).updateOne(ctx, bson.M{"_id": id}, doc, true)
+	return err
+}
+
+// CheckDatasetExists checks that the dataset exists
+func (s *Store) CheckDatasetExists(ctx context.Context, id, state string) error {
+	query := bson.M{"_id": id}
+	if state != "" {
+		query["current.state"] = state
+	}
+
+	count, err := s.collection(config.DatasetsCollection).Count(ctx, mongo.NotDeleted(query))
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return errs.ErrDatasetNotFound
+	}
+
+	return nil
+}
+
+// CheckEditionExists checks that the edition of a dataset exists
+func (s *Store) CheckEditionExists(ctx context.Context, id, editionID, state string) error {
+	var query bson.M
+	if state == "" {
+		query = bson.M{
+			"next.links.dataset.id": id,
+			"next.edition":          editionID,
+		}
+	} else {
+		query = bson.M{
+			"current.links.dataset.id": id,
+			"current.edition":          editionID,
+			"current.state":            state,
+		}
+	}
+
+	count, err := s.collection(config.EditionsCollection).Count(ctx, mongo.NotDeleted(query))
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return errs.ErrEditionNotFound
+	}
+
+	return nil
+}
+
+// DeleteDataset marks an existing dataset document as deleted
+func (s *Store) DeleteDataset(ctx context.Context, id string, deletion *models.Deletion) error {
+	return s.softDelete(ctx, config.DatasetsCollection, bson.M{"_id": id}, deletion, errs.ErrDatasetNotFound)
+}
+
+// DeleteEdition marks an existing edition document as deleted
+func (s *Store) DeleteEdition(ctx context.Context, id string, deletion *models.Deletion) error {
+	if err := s.softDelete(ctx, config.EditionsCollection, bson.M{"id": id}, deletion, errs.ErrEditionNotFound); err != nil {
+		return err
+	}
+
+	log.Info(ctx, "edition deleted", log.Data{"id": id})
+	return nil
+}
+
+// IsStaticDataset checks if the dataset with the provided ID is of static type
+func (s *Store) IsStaticDataset(ctx context.Context, datasetID string) (bool, error) {
+	dataset, err := s.GetDataset(ctx, datasetID)
+	if err != nil {
+		return false, err
+	}
+
+	isStatic := (dataset.Current != nil && dataset.Current.Type == models.Static.String()) ||
+		(dataset.Next != nil && dataset.Next.Type == models.Static.String())
+
+	return isStatic, nil
+}
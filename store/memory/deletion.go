@@ -0,0 +1,120 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	"github.com/ONSdigital/dp-dataset-api/config"
+	"github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/dp-dataset-api/mongo"
+	mongodriver "github.com/ONSdigital/dp-mongodb/v3/mongodb"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// softDelete marks the document matched by the selector as deleted, returning notFound if there is no such document
+func (s *Store) softDelete(ctx context.Context, collectionKey string, selector bson.M, deletion *models.Deletion, notFound error) error {
+	matched, err := s.collection(collectionKey).updateOne(ctx, mongo.NotDeleted(selector), mongo.DeleteUpdate(deletion), false)
+	if err != nil {
+		return err
+	}
+	if matched == 0 {
+		return notFound
+	}
+
+	return nil
+}
+
+// RestoreDataset restores a deleted dataset, along with the editions and static versions that were deleted with it.
+// The documents are restored in a single transaction, so that either all of them are restored or none are.
+func (s *Store) RestoreDataset(ctx context.Context, id string) error {
+	return s.RunTransaction(ctx, func(transactionCtx context.Context) error {
+		datasets := s.collection(config.DatasetsCollection)
+
+		var deleted models.Deletion
+		if err := datasets.FindOne(transactionCtx, bson.M{"_id": id, "deleted_at": bson.M{"$exists": true}}, &deleted); err != nil {
+			if errors.Is(err, mongodriver.ErrNoDocumentFound) {
+				return errs.ErrDatasetNotFound
+			}
+			return err
+		}
+
+		editionsSelector := bson.M{"next.links.dataset.id": id, "deleted_at": deleted.DeletedAt}
+		if _, err := s.collection(config.EditionsCollection).updateMany(transactionCtx, editionsSelector, mongo.RestoreUpdate()); err != nil {
+			return err
+		}
+
+		versionsSelector := bson.M{"links.dataset.id": id, "deleted_at": deleted.DeletedAt}
+		if _, err := s.collection(config.VersionsCollection).updateMany(transactionCtx, versionsSelector, mongo.RestoreUpdate()); err != nil {
+			return err
+		}
+
+		_, err := datasets.updateOne(transactionCtx, bson.M{"_id": id}, mongo.RestoreUpdate(), false)
+		return err
+	})
+}
+
+// RestoreEdition restores a deleted edition of a dataset
+func (s *Store) RestoreEdition(ctx context.Context, datasetID, edition string) error {
+	selector := bson.M{"next.links.dataset.id": datasetID, "next.edition": edition}
+	return s.restore(ctx, config.EditionsCollection, selector, errs.ErrEditionNotFound)
+}
+
+// RestoreStaticVersion restores a deleted static version of an edition of a dataset
+func (s *Store) RestoreStaticVersion(ctx context.Context, datasetID, edition string, version int) error {
+	selector := bson.M{"links.dataset.id": datasetID, "edition": edition, "version": version}
+	return s.restore(ctx, config.VersionsCollection, selector, errs.ErrVersionNotFound)
+}
+
+// restore removes the deletion of the deleted document matched by the selector, returning notFound if there is no such document
+func (s *Store) restore(ctx context.Context, collectionKey string, selector bson.M, notFound error) error {
+	matched, err := s.collection(collectionKey).updateOne(ctx, mongo.Deleted(selector), mongo.RestoreUpdate(), false)
+	if err != nil {
+		return err
+	}
+	if matched == 0 {
+		return notFound
+	}
+
+	return nil
+}
+
+// PurgeDeletedDatasets permanently removes the datasets and editions that were deleted before the provided time, along
+// with the revisions of the datasets, returning the number of documents removed
+func (s *Store) PurgeDeletedDatasets(ctx context.Context, before time.Time) (int, error) {
+	purged, err := s.purgeDatasetRevisions(ctx, before)
+	if err != nil {
+		return purged, err
+	}
+
+	for _, collection := range []string{config.EditionsCollection, config.DatasetsCollection} {
+		deleted, err := s.collection(collection).deleteMany(ctx, mongo.DeletedBefore(before))
+		if err != nil {
+			return purged, err
+		}
+		purged += deleted
+	}
+	return purged, nil
+}
+
+// GetDeletedStaticVersions returns up to limit static versions that were deleted before the provided time
+func (s *Store) GetDeletedStaticVersions(_ context.Context, before time.Time, limit int) ([]*models.Version, error) {
+	docs, _, err := s.collection(config.VersionsCollection).find(mongo.DeletedBefore(before), "deleted_at", 1, 0, limit)
+	if err != nil {
+		return nil, err
+	}
+	return decodeAll[models.Version](docs)
+}
+
+// PurgeStaticVersion permanently removes a deleted static version
+func (s *Store) PurgeStaticVersion(ctx context.Context, id string) error {
+	deleted, err := s.collection(config.VersionsCollection).deleteOne(ctx, bson.M{"id": id, "deleted_at": bson.M{"$exists": true}})
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return errs.ErrVersionNotFound
+	}
+	return nil
+}
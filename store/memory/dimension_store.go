@@ -0,0 +1,258 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	"github.com/ONSdigital/dp-dataset-api/config"
+	"github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/dp-dataset-api/mongo"
+	"github.com/ONSdigital/dp-dataset-api/pagination"
+	"github.com/ONSdigital/log.go/v2/log"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const maxIDs = 1000
+
+// GetDimensionsFromInstance returns a list of dimensions and their options for an instance resource.
+// Note that all dimension options for all dimensions are returned as high level items, hence there can be duplicate dimension names,
+// which correspond to different options.
+func (s *Store) GetDimensionsFromInstance(_ context.Context, id string, offset, limit int) ([]*models.DimensionOption, int, error) {
+	docs, totalCount, err := s.collection(config.DimensionOptionsCollection).find(bson.M{"instance_id": id}, "", 0, offset, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// apply the same projection as the mongoDB store
+	for _, doc := range docs {
+		delete(doc, "id")
+		delete(doc, "last_updated")
+		delete(doc, "instance_id")
+	}
+
+	dimensions, err := decodeAll[models.DimensionOption](docs)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return dimensions, totalCount, nil
+}
+
+// GetUniqueDimensionAndOptions returns a list of dimension options for an instance resource
+func (s *Store) GetUniqueDimensionAndOptions(_ context.Context, id, dimension string) ([]*string, int, error) {
+	vals, err := s.collection(config.DimensionOptionsCollection).distinct("option", bson.M{"instance_id": id, "name": dimension})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if len(vals) == 0 {
+		return nil, 0, errs.ErrDimensionNodeNotFound
+	}
+
+	values := []*string{}
+	for _, v := range vals {
+		if vs, ok := v.(string); ok {
+			values = append(values, &vs)
+		}
+	}
+
+	return values, len(values), nil
+}
+
+// UpsertDimensionsToInstance to the dimension collection
+func (s *Store) UpsertDimensionsToInstance(ctx context.Context, opts []*models.CachedDimensionOption) error {
+	now := time.Now().UTC()
+	for _, opt := range opts {
+		option := models.DimensionOption{InstanceID: opt.InstanceID, Option: opt.Option, Name: opt.Name, Label: opt.Label}
+		option.Order = opt.Order
+		option.Links.CodeList = models.LinkObject{ID: opt.CodeList, HRef: fmt.Sprintf("%s/code-lists/%s", s.CodeListAPIURL, opt.CodeList)}
+		option.Links.Code = models.LinkObject{ID: opt.Code, HRef: fmt.Sprintf("%s/code-lists/%s/codes/%s", s.CodeListAPIURL, opt.CodeList, opt.Code)}
+		option.LastUpdated = now
+		if _, err := s.collection(config.DimensionOptionsCollection).updateOne(ctx,
+			bson.M{"instance_id": option.InstanceID, "name": option.Name, "option": option.Option},
+			bson.M{"$set": option}, true); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetDimensions returns a list of all dimensions from a dataset, each one with the first of its options,
+// in the same format as the mongoDB aggregation: {"_id": <name>, "doc": <option document>}
+func (s *Store) GetDimensions(_ context.Context, versionID string) ([]bson.M, error) {
+	docs, err := s.collection(config.DimensionOptionsCollection).findAll(bson.M{"instance_id": versionID}, "", 0)
+	if err != nil {
+		return nil, err
+	}
+
+	results := []bson.M{}
+	seen := map[interface{}]bool{}
+	for _, doc := range docs {
+		name := doc["name"]
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		results = append(results, bson.M{"_id": name, "doc": doc})
+	}
+
+	if len(results) < 1 {
+		return nil, errs.ErrDimensionsNotFound
+	}
+
+	sortDocuments(results, "_id", 1)
+
+	return results, nil
+}
+
+// GetDimensionOptions returns dimension options for a dimensions within a dataset, according to the provided limit and offset,
+// or starting right after the cursor if provided. Offset and limit need to be positive or zero
+func (s *Store) GetDimensionOptions(ctx context.Context, version *models.Version, dimension string, offset, limit int, cursor *pagination.Cursor) ([]*models.PublicDimensionOption, int, *pagination.Cursor, error) {
+	selector := bson.M{"instance_id": version.ID, "name": dimension}
+
+	sortBy, err := s.sortOrder(ctx, selector)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	sortFields := sortBy
+	if sortBy == "order" {
+		sortFields = "order,option"
+	}
+
+	collection := s.collection(config.DimensionOptionsCollection)
+
+	var (
+		docs       []bson.M
+		totalCount int
+		hasMore    bool
+	)
+	if cursor == nil {
+		docs, totalCount, err = collection.find(selector, sortFields, 1, offset, limit)
+		hasMore = offset+len(docs) < totalCount
+	} else {
+		var after bson.M
+		if after, err = mongo.DimensionOptionsAfterCursor(selector, sortBy, cursor); err != nil {
+			return nil, 0, nil, err
+		}
+		if totalCount, err = collection.Count(ctx, selector); err != nil {
+			return nil, 0, nil, err
+		}
+		var remaining int
+		docs, remaining, err = collection.find(after, sortFields, 1, 0, limit)
+		hasMore = len(docs) < remaining
+	}
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
+	values, err := decodeAll[models.PublicDimensionOption](docs)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
+	for i := 0; i < len(values); i++ {
+		values[i].Links.Version = *version.Links.Self
+	}
+
+	return values, totalCount, mongo.NextCursor(values, hasMore, mongo.DimensionOptionCursor), nil
+}
+
+// GetDimensionOptionsFromIDs returns dimension options for a dimension within a dataset, whose IDs match the provided list of IDs
+func (s *Store) GetDimensionOptionsFromIDs(ctx context.Context, version *models.Version, dimension string, ids []string) ([]*models.PublicDimensionOption, int, error) {
+	if len(ids) > maxIDs {
+		return nil, 0, errors.New("too many IDs provided")
+	}
+
+	selectorAll := bson.M{"instance_id": version.ID, "name": dimension}
+	selectorInList := bson.M{"instance_id": version.ID, "name": dimension, "option": bson.M{"$in": ids}}
+
+	totalCount, err := s.collection(config.DimensionOptionsCollection).Count(ctx, selectorAll)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var values []*models.PublicDimensionOption
+	if totalCount > 0 {
+		sortBy, err := s.sortOrder(ctx, selectorInList)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		docs, err := s.collection(config.DimensionOptionsCollection).findAll(selectorInList, sortBy, 1)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		if values, err = decodeAll[models.PublicDimensionOption](docs); err != nil {
+			return nil, 0, err
+		}
+
+		for i := 0; i < len(values); i++ {
+			values[i].Links.Version = *version.Links.Self
+		}
+	}
+
+	return values, totalCount, nil
+}
+
+// UpdateDimensionsNodeIDAndOrder to cache the id and order (optional) for other import processes
+func (s *Store) UpdateDimensionsNodeIDAndOrder(ctx context.Context, dimensions []*models.DimensionOption) error {
+	// validate that there is something to update
+	isUpdate := false
+	for _, dim := range dimensions {
+		if dim.Order != nil || dim.NodeID != "" {
+			isUpdate = true
+			break
+		}
+	}
+	if !isUpdate {
+		return nil // nothing to update
+	}
+
+	now := time.Now().UTC()
+	for _, dimension := range dimensions {
+		update := bson.M{"last_updated": now}
+		if dimension.NodeID != "" {
+			update["node_id"] = dimension.NodeID
+		}
+		if dimension.Order != nil {
+			update["order"] = dimension.Order
+		}
+		matched, err := s.collection(config.DimensionOptionsCollection).updateOne(ctx,
+			bson.M{"instance_id": dimension.InstanceID, "name": dimension.Name, "option": dimension.Option},
+			bson.M{"$set": update}, false)
+		if err != nil {
+			return fmt.Errorf("error trying to update: %w", err)
+		}
+		if matched == 0 {
+			log.Warn(ctx, "failed to update dimension.options ", log.Data{"instance_id": dimension.InstanceID, "name": dimension.Name, "option": dimension.Option})
+		}
+	}
+
+	return nil
+}
+
+// sortOrder returns the field to sort the options matched by the provided selector:
+// if the order property exists, it will be used to determine the order
+// otherwise, the items will be sorted alphabetically by option
+func (s *Store) sortOrder(ctx context.Context, selector bson.M) (string, error) {
+	withOrder := bson.M{"order": bson.M{"$exists": true}}
+	for k, v := range selector {
+		withOrder[k] = v
+	}
+
+	orderCount, err := s.collection(config.DimensionOptionsCollection).Count(ctx, withOrder)
+	if err != nil {
+		return "", err
+	}
+
+	if orderCount > 0 {
+		return "order", nil
+	}
+
+	return "option", nil
+}
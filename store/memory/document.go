@@ -0,0 +1,618 @@
+package memory
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	bsonprim "go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// normalise converts the provided value to the generic bson representation that would be obtained
+// after storing it in mongoDB and reading it back (bson.M for documents, bson.A for arrays, etc.)
+func normalise(value interface{}) (interface{}, error) {
+	b, err := bson.Marshal(bson.M{"v": value})
+	if err != nil {
+		return nil, err
+	}
+
+	var doc bson.M
+	if err := bson.Unmarshal(b, &doc); err != nil {
+		return nil, err
+	}
+
+	return doc["v"], nil
+}
+
+// toDocument converts the provided struct or map to a generic bson document
+func toDocument(value interface{}) (bson.M, error) {
+	b, err := bson.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc bson.M
+	if err := bson.Unmarshal(b, &doc); err != nil {
+		return nil, err
+	}
+
+	return doc, nil
+}
+
+// decode unmarshals the provided generic document into result, which must be a non nil pointer
+func decode(doc bson.M, result interface{}) error {
+	b, err := bson.Marshal(doc)
+	if err != nil {
+		return err
+	}
+
+	return bson.Unmarshal(b, result)
+}
+
+// copyDocument returns a deep copy of the provided document
+func copyDocument(doc bson.M) bson.M {
+	cp := make(bson.M, len(doc))
+	for k, v := range doc {
+		cp[k] = copyValue(v)
+	}
+	return cp
+}
+
+func copyValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case bson.M:
+		return copyDocument(v)
+	case bson.A:
+		cp := make(bson.A, len(v))
+		for i := range v {
+			cp[i] = copyValue(v[i])
+		}
+		return cp
+	default:
+		return v
+	}
+}
+
+// lookup returns all the values found at the provided dot-notation path, traversing any arrays found on the way.
+func lookup(value interface{}, path string) []interface{} {
+	if path == "" {
+		return []interface{}{value}
+	}
+
+	key, rest, _ := strings.Cut(path, ".")
+
+	switch v := value.(type) {
+	case bson.M:
+		child, ok := v[key]
+		if !ok {
+			return nil
+		}
+		return lookup(child, rest)
+	case bson.A:
+		var values []interface{}
+		for _, item := range v {
+			values = append(values, lookup(item, path)...)
+		}
+		return values
+	default:
+		return nil
+	}
+}
+
+// matches returns true if the provided document satisfies the filter.
+// Only the subset of query operators used by the dataset API is supported.
+func matches(doc bson.M, filter bson.M) (bool, error) {
+	for key, condition := range filter {
+		var (
+			ok  bool
+			err error
+		)
+
+		switch key {
+		case "$or":
+			ok, err = matchesAny(doc, condition)
+		case "$and":
+			ok, err = matchesAll(doc, condition)
+		default:
+			ok, err = matchesField(lookup(doc, key), condition)
+		}
+
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+func subFilters(condition interface{}) ([]bson.M, error) {
+	switch c := condition.(type) {
+	case []bson.M:
+		return c, nil
+	case bson.A:
+		return toFilters(c)
+	case []interface{}:
+		return toFilters(c)
+	default:
+		return nil, fmt.Errorf("unsupported logical operator value: %T", condition)
+	}
+}
+
+func toFilters(values []interface{}) ([]bson.M, error) {
+	filters := make([]bson.M, 0, len(values))
+	for _, v := range values {
+		f, ok := v.(bson.M)
+		if !ok {
+			return nil, fmt.Errorf("unsupported logical operator value: %T", v)
+		}
+		filters = append(filters, f)
+	}
+	return filters, nil
+}
+
+func matchesAny(doc bson.M, condition interface{}) (bool, error) {
+	filters, err := subFilters(condition)
+	if err != nil {
+		return false, err
+	}
+
+	for _, f := range filters {
+		ok, err := matches(doc, f)
+		if err != nil || ok {
+			return ok, err
+		}
+	}
+
+	return false, nil
+}
+
+func matchesAll(doc bson.M, condition interface{}) (bool, error) {
+	filters, err := subFilters(condition)
+	if err != nil {
+		return false, err
+	}
+
+	for _, f := range filters {
+		ok, err := matches(doc, f)
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+//nolint:gocyclo // one case per supported operator
+func matchesField(values []interface{}, condition interface{}) (bool, error) {
+	operators, isOperator := condition.(bson.M)
+	if isOperator {
+		for k := range operators {
+			if !strings.HasPrefix(k, "$") {
+				isOperator = false
+				break
+			}
+		}
+	}
+
+	if !isOperator {
+		expected, err := normalise(condition)
+		if err != nil {
+			return false, err
+		}
+		return containsValue(values, expected), nil
+	}
+
+	for op, arg := range operators {
+		switch op {
+		case "$exists":
+			exists, _ := arg.(bool)
+			if (len(values) > 0) != exists {
+				return false, nil
+			}
+		case "$ne":
+			expected, err := normalise(arg)
+			if err != nil {
+				return false, err
+			}
+			if containsValue(values, expected) {
+				return false, nil
+			}
+		case "$in", "$nin":
+			candidates, err := normalise(arg)
+			if err != nil {
+				return false, err
+			}
+			list, ok := candidates.(bson.A)
+			if !ok {
+				return false, fmt.Errorf("%s requires an array, got %T", op, arg)
+			}
+			found := false
+			for _, c := range list {
+				if containsValue(values, c) {
+					found = true
+					break
+				}
+			}
+			if found != (op == "$in") {
+				return false, nil
+			}
+		case "$lt", "$lte", "$gt", "$gte":
+			bound, err := normalise(arg)
+			if err != nil {
+				return false, err
+			}
+			if !matchesRange(values, op, bound) {
+				return false, nil
+			}
+		case "$regex":
+			pattern, ok := arg.(string)
+			if !ok {
+				return false, fmt.Errorf("$regex requires a string, got %T", arg)
+			}
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return false, err
+			}
+			found := false
+			for _, v := range values {
+				if s, ok := v.(string); ok && re.MatchString(s) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return false, nil
+			}
+		default:
+			return false, fmt.Errorf("unsupported query operator: %s", op)
+		}
+	}
+
+	return true, nil
+}
+
+// matchesRange checks if any of the values satisfies the comparison operator against bound.
+// As in mongoDB, only values of the same type as the bound are compared.
+func matchesRange(values []interface{}, op string, bound interface{}) bool {
+	for _, v := range values {
+		if typeRank(v) != typeRank(bound) {
+			continue
+		}
+		c := compare(v, bound)
+		switch {
+		case op == "$lt" && c < 0,
+			op == "$lte" && c <= 0,
+			op == "$gt" && c > 0,
+			op == "$gte" && c >= 0:
+			return true
+		}
+	}
+	return false
+}
+
+// containsValue checks if any of the values (or any of their array items) is equal to expected.
+// A nil expected value matches missing fields, as it does in mongoDB.
+func containsValue(values []interface{}, expected interface{}) bool {
+	if expected == nil && len(values) == 0 {
+		return true
+	}
+
+	for _, v := range values {
+		if equal(v, expected) {
+			return true
+		}
+		if arr, ok := v.(bson.A); ok {
+			for _, item := range arr {
+				if equal(item, expected) {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+func equal(a, b interface{}) bool {
+	if fa, ok := toFloat(a); ok {
+		fb, ok := toFloat(b)
+		return ok && fa == fb
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case float64:
+		return v, true
+	default:
+		return 0, false
+	}
+}
+
+// compare returns -1, 0 or 1 depending on the relative order of a and b, roughly following mongoDB sort order
+func compare(a, b interface{}) int {
+	ra, rb := typeRank(a), typeRank(b)
+	if ra != rb {
+		return cmpInt(ra, rb)
+	}
+
+	switch va := a.(type) {
+	case string:
+		return strings.Compare(va, b.(string))
+	case bool:
+		vb := b.(bool)
+		switch {
+		case va == vb:
+			return 0
+		case !va:
+			return -1
+		default:
+			return 1
+		}
+	case bsonprim.DateTime:
+		return cmpInt(int64(va), int64(b.(bsonprim.DateTime)))
+	case bsonprim.Timestamp:
+		return bsonprim.CompareTimestamp(va, b.(bsonprim.Timestamp))
+	}
+
+	if fa, ok := toFloat(a); ok {
+		fb, _ := toFloat(b)
+		switch {
+		case fa < fb:
+			return -1
+		case fa > fb:
+			return 1
+		}
+	}
+
+	return 0
+}
+
+func typeRank(value interface{}) int {
+	switch value.(type) {
+	case nil:
+		return 0
+	case int32, int64, float64:
+		return 1
+	case string:
+		return 2
+	case bson.M:
+		return 3
+	case bson.A:
+		return 4
+	case bool:
+		return 5
+	case bsonprim.DateTime:
+		return 6
+	case bsonprim.Timestamp:
+		return 7
+	default:
+		return 8
+	}
+}
+
+func cmpInt[T int | int64](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// sortDocuments sorts the documents by the provided field, in ascending order if dir is positive or descending otherwise.
+// Several comma separated fields can be provided, in which case the ties of a field are sorted by the next one.
+// The sort is stable so that documents with equal keys keep their insertion order.
+func sortDocuments(docs []bson.M, field string, dir int) {
+	fields := strings.Split(field, ",")
+	sort.SliceStable(docs, func(i, j int) bool {
+		c := 0
+		for _, f := range fields {
+			if c = compare(sortValue(docs[i], f), sortValue(docs[j], f)); c != 0 {
+				break
+			}
+		}
+		if dir < 0 {
+			return c > 0
+		}
+		return c < 0
+	})
+}
+
+func sortValue(doc bson.M, field string) interface{} {
+	values := lookup(doc, field)
+	if len(values) == 0 {
+		return nil
+	}
+	return values[0]
+}
+
+// errPositionalNotMatched is returned when a positional update ('$') cannot be resolved from the query filter
+var errPositionalNotMatched = errors.New("the positional operator did not find the match needed from the query")
+
+// applyUpdate applies the provided update operators to doc.
+// The filter is required to resolve positional ('$') updates and inserting determines whether $setOnInsert applies.
+//
+//nolint:gocyclo // one case per supported operator
+func applyUpdate(doc bson.M, update bson.M, filter bson.M, inserting bool) error {
+	now := time.Now().UTC()
+
+	for op, arg := range update {
+		switch op {
+		case "$set", "$setOnInsert":
+			if op == "$setOnInsert" && !inserting {
+				continue
+			}
+			fields, err := toDocument(arg)
+			if err != nil {
+				return err
+			}
+			for path, value := range fields {
+				if err := setPath(doc, path, value, filter); err != nil {
+					return err
+				}
+			}
+		case "$unset":
+			fields, ok := arg.(bson.M)
+			if !ok {
+				return fmt.Errorf("unsupported $unset value: %T", arg)
+			}
+			for path := range fields {
+				unsetPath(doc, path)
+			}
+		case "$push":
+			fields, err := toDocument(arg)
+			if err != nil {
+				return err
+			}
+			for path, value := range fields {
+				var arr bson.A
+				if existing := lookup(doc, path); len(existing) > 0 {
+					if a, ok := existing[0].(bson.A); ok {
+						arr = a
+					}
+				}
+				if err := setPath(doc, path, append(copyValue(arr).(bson.A), value), filter); err != nil {
+					return err
+				}
+			}
+		case "$inc":
+			fields, err := toDocument(arg)
+			if err != nil {
+				return err
+			}
+			for path, value := range fields {
+				inc, _ := toFloat(value)
+				var current float64
+				if existing := lookup(doc, path); len(existing) > 0 {
+					current, _ = toFloat(existing[0])
+				}
+				if err := setPath(doc, path, int64(current+inc), filter); err != nil {
+					return err
+				}
+			}
+		case "$currentDate":
+			fields, ok := arg.(bson.M)
+			if !ok {
+				return fmt.Errorf("unsupported $currentDate value: %T", arg)
+			}
+			for path, spec := range fields {
+				var value interface{} = bsonprim.NewDateTimeFromTime(now)
+				if typeSpec, ok := spec.(bson.M); ok && typeSpec["$type"] == "timestamp" {
+					value = nextTimestamp(now)
+				}
+				if err := setPath(doc, path, value, filter); err != nil {
+					return err
+				}
+			}
+		default:
+			return fmt.Errorf("unsupported update operator: %s", op)
+		}
+	}
+
+	return nil
+}
+
+// setPath sets the value at the provided dot-notation path, creating any intermediate documents
+func setPath(doc bson.M, path string, value interface{}, filter bson.M) error {
+	normalised, err := normalise(value)
+	if err != nil {
+		return err
+	}
+
+	keys := strings.Split(path, ".")
+	var current interface{} = doc
+	for i, key := range keys {
+		last := i == len(keys)-1
+
+		switch c := current.(type) {
+		case bson.M:
+			if last {
+				c[key] = normalised
+				return nil
+			}
+			next, ok := c[key]
+			if !ok || next == nil {
+				next = bson.M{}
+				c[key] = next
+			}
+			current = next
+		case bson.A:
+			index, err := arrayIndex(c, strings.Join(keys[:i], "."), key, filter)
+			if err != nil {
+				return err
+			}
+			if last {
+				c[index] = normalised
+				return nil
+			}
+			current = c[index]
+		default:
+			return fmt.Errorf("cannot set field %q in a non-document value", path)
+		}
+	}
+
+	return nil
+}
+
+// arrayIndex resolves an array element key, which can be a numeric index or the positional operator
+func arrayIndex(arr bson.A, arrayPath, key string, filter bson.M) (int, error) {
+	if key != "$" {
+		var index int
+		if _, err := fmt.Sscanf(key, "%d", &index); err != nil || index < 0 || index >= len(arr) {
+			return 0, fmt.Errorf("invalid array index %q", key)
+		}
+		return index, nil
+	}
+
+	// build a sub-filter with the conditions that apply to the items of this array
+	prefix := arrayPath + "."
+	itemFilter := bson.M{}
+	for k, v := range filter {
+		if strings.HasPrefix(k, prefix) {
+			itemFilter[strings.TrimPrefix(k, prefix)] = v
+		}
+	}
+
+	for i, item := range arr {
+		itemDoc, ok := item.(bson.M)
+		if !ok {
+			continue
+		}
+		ok, err := matches(itemDoc, itemFilter)
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			return i, nil
+		}
+	}
+
+	return 0, errPositionalNotMatched
+}
+
+func unsetPath(doc bson.M, path string) {
+	i := strings.LastIndex(path, ".")
+	if i < 0 {
+		delete(doc, path)
+		return
+	}
+
+	if values := lookup(doc, path[:i]); len(values) > 0 {
+		if parent, ok := values[0].(bson.M); ok {
+			delete(parent, path[i+1:])
+		}
+	}
+}
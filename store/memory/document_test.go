@@ -0,0 +1,122 @@
+package memory
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestMatches(t *testing.T) {
+	Convey("Given a document with nested fields and arrays", t, func() {
+		doc := toTestDocument(bson.M{
+			"_id":   "123",
+			"state": "published",
+			"links": bson.M{"dataset": bson.M{"id": "cpih01"}},
+			"tasks": []bson.M{{"dimension_name": "geography", "state": "created"}},
+			"date":  time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		})
+
+		Convey("Then equality on a dotted path is matched", func() {
+			ok, err := matches(doc, bson.M{"links.dataset.id": "cpih01"})
+			So(err, ShouldBeNil)
+			So(ok, ShouldBeTrue)
+		})
+
+		Convey("Then fields inside arrays are matched", func() {
+			ok, err := matches(doc, bson.M{"tasks.dimension_name": "geography"})
+			So(err, ShouldBeNil)
+			So(ok, ShouldBeTrue)
+		})
+
+		Convey("Then $in, $ne and $exists operators are supported", func() {
+			ok, err := matches(doc, bson.M{
+				"state":   bson.M{"$in": []string{"edition-confirmed", "published"}},
+				"_id":     bson.M{"$ne": "456"},
+				"missing": bson.M{"$exists": false},
+			})
+			So(err, ShouldBeNil)
+			So(ok, ShouldBeTrue)
+		})
+
+		Convey("Then $lt, $lte, $gt and $gte compare values of the same type", func() {
+			ok, err := matches(doc, bson.M{
+				"date": bson.M{
+					"$gt":  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+					"$lte": time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+				},
+			})
+			So(err, ShouldBeNil)
+			So(ok, ShouldBeTrue)
+
+			ok, err = matches(doc, bson.M{"date": bson.M{"$lt": "2026-01-01"}})
+			So(err, ShouldBeNil)
+			So(ok, ShouldBeFalse)
+		})
+
+		Convey("Then $or matches when any of its conditions match", func() {
+			ok, err := matches(doc, bson.M{"$or": []bson.M{{"state": "created"}, {"state": "published"}}})
+			So(err, ShouldBeNil)
+			So(ok, ShouldBeTrue)
+		})
+
+		Convey("Then a filter with a non matching value is not matched", func() {
+			ok, err := matches(doc, bson.M{"state": "created"})
+			So(err, ShouldBeNil)
+			So(ok, ShouldBeFalse)
+		})
+	})
+}
+
+func TestApplyUpdate(t *testing.T) {
+	Convey("Given an existing document", t, func() {
+		doc := toTestDocument(bson.M{
+			"_id":   "123",
+			"count": int64(1),
+			"tasks": []bson.M{{"dimension_name": "geography", "state": "created"}},
+		})
+
+		Convey("When $set, $inc and $unset are applied", func() {
+			err := applyUpdate(doc, bson.M{
+				"$set":   bson.M{"next.state": "created"},
+				"$inc":   bson.M{"count": 2},
+				"$unset": bson.M{"tasks": ""},
+			}, bson.M{"_id": "123"}, false)
+
+			Convey("Then the document is updated accordingly", func() {
+				So(err, ShouldBeNil)
+				So(doc["next"], ShouldResemble, bson.M{"state": "created"})
+				So(doc["count"], ShouldEqual, int64(3))
+				So(doc, ShouldNotContainKey, "tasks")
+			})
+		})
+
+		Convey("When the positional operator is used with a filter on the array", func() {
+			err := applyUpdate(doc, bson.M{"$set": bson.M{"tasks.$.state": "completed"}},
+				bson.M{"_id": "123", "tasks.dimension_name": "geography"}, false)
+
+			Convey("Then the matched array item is updated", func() {
+				So(err, ShouldBeNil)
+				So(lookup(doc, "tasks.state"), ShouldResemble, []interface{}{"completed"})
+			})
+		})
+
+		Convey("When $setOnInsert is applied to an existing document", func() {
+			err := applyUpdate(doc, bson.M{"$setOnInsert": bson.M{"created": true}}, bson.M{"_id": "123"}, false)
+
+			Convey("Then the document is not modified", func() {
+				So(err, ShouldBeNil)
+				So(doc, ShouldNotContainKey, "created")
+			})
+		})
+	})
+}
+
+func toTestDocument(value interface{}) bson.M {
+	doc, err := toDocument(value)
+	if err != nil {
+		panic(err)
+	}
+	return doc
+}
@@ -0,0 +1,13 @@
+package memory
+
+import "context"
+
+// AddVersionDetailsToInstance is a no-op, as the in-memory store does not hold any graph data
+func (s *Store) AddVersionDetailsToInstance(context.Context, string, string, string, int) error {
+	return nil
+}
+
+// SetInstanceIsPublished is a no-op, as the in-memory store does not hold any graph data
+func (s *Store) SetInstanceIsPublished(context.Context, string) error {
+	return nil
+}
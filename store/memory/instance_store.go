@@ -0,0 +1,283 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	"github.com/ONSdigital/dp-dataset-api/config"
+	"github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/dp-dataset-api/mongo"
+	"github.com/ONSdigital/dp-dataset-api/pagination"
+	mongodriver "github.com/ONSdigital/dp-mongodb/v3/mongodb"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// AcquireInstanceLock tries to lock the provided instanceID.
+// If the instance is already locked, this function will block until it's released,
+// at which point we acquire the lock and return.
+func (s *Store) AcquireInstanceLock(ctx context.Context, instanceID string) (string, error) {
+	return s.lockClientInstance.Acquire(ctx, instanceID)
+}
+
+// UnlockInstance releases the lock for the provided lockID (if it exists)
+func (s *Store) UnlockInstance(ctx context.Context, lockID string) {
+	s.lockClientInstance.Unlock(ctx, lockID)
+}
+
+// GetInstances returns the instances that match the provided states and datasets, sorted by last_updated and id,
+// starting at the offset or right after the cursor if provided
+func (s *Store) GetInstances(ctx context.Context, states, datasets []string, offset, limit int, cursor *pagination.Cursor) ([]*models.Instance, int, *pagination.Cursor, error) {
+	selector := bson.M{}
+	if len(states) > 0 {
+		selector["state"] = bson.M{"$in": states}
+	}
+	if len(datasets) > 0 {
+		selector["links.dataset.id"] = bson.M{"$in": datasets}
+	}
+
+	collection := s.collection(config.InstanceCollection)
+
+	var (
+		docs       []bson.M
+		totalCount int
+		hasMore    bool
+		err        error
+	)
+	if cursor == nil {
+		docs, totalCount, err = collection.find(selector, "last_updated,id", -1, offset, limit)
+		hasMore = offset+len(docs) < totalCount
+	} else {
+		if cursor.Time == nil {
+			return nil, 0, nil, errs.ErrInvalidQueryParameter
+		}
+		if totalCount, err = collection.Count(ctx, selector); err != nil {
+			return nil, 0, nil, err
+		}
+		var remaining int
+		docs, remaining, err = collection.find(mongo.AfterCursor(selector, "last_updated", *cursor.Time, "id", cursor.ID, -1), "last_updated,id", -1, 0, limit)
+		hasMore = len(docs) < remaining
+	}
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
+	results, err := decodeAll[models.Instance](docs)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
+	return results, totalCount, mongo.NextCursor(results, hasMore, mongo.InstanceCursor), nil
+}
+
+// GetInstance returns a single instance from an ID
+func (s *Store) GetInstance(_ context.Context, id, eTagSelector string) (*models.Instance, error) {
+	doc, err := s.collection(config.InstanceCollection).findOne(bson.M{"id": id}, "", 0)
+	if err != nil {
+		if errors.Is(err, mongodriver.ErrNoDocumentFound) {
+			return nil, errs.ErrInstanceNotFound
+		}
+		return nil, err
+	}
+
+	var instance models.Instance
+	if err := decode(doc, &instance); err != nil {
+		return nil, err
+	}
+
+	// If eTag was provided and did not match, return the corresponding error
+	if eTagSelector != mongo.AnyETag && eTagSelector != instance.ETag {
+		return nil, errs.ErrInstanceConflict
+	}
+
+	return &instance, nil
+}
+
+// AddInstance to the instance collection
+func (s *Store) AddInstance(ctx context.Context, instance *models.Instance) (*models.Instance, error) {
+	instance.LastUpdated = time.Now().UTC()
+	instance.UniqueTimestamp = nextTimestamp(instance.LastUpdated)
+
+	var err error
+	instance.ETag, err = instance.Hash(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.collection(config.InstanceCollection).insert(ctx, instance); err != nil {
+		return nil, err
+	}
+
+	return instance, nil
+}
+
+// UpdateInstance with new properties
+func (s *Store) UpdateInstance(ctx context.Context, currentInstance, updatedInstance *models.Instance, eTagSelector string) (string, error) {
+	updatedInstance.LastUpdated = time.Now().UTC()
+
+	newETag, err := mongo.NewETagForUpdate(currentInstance, updatedInstance)
+	if err != nil {
+		return "", err
+	}
+	updatedInstance.ETag = newETag
+
+	updates := mongo.CreateInstanceUpdateQuery(ctx, currentInstance.InstanceID, updatedInstance)
+	update, err := mongodriver.WithUpdates(bson.M{"$set": updates})
+	if err != nil {
+		return "", err
+	}
+
+	sel := selector(currentInstance.InstanceID, eTagSelector)
+	if !updatedInstance.UniqueTimestamp.IsZero() {
+		sel[mongodriver.UniqueTimestampKey] = updatedInstance.UniqueTimestamp
+	}
+
+	matched, err := s.collection(config.InstanceCollection).updateOne(ctx, sel, update, false)
+	if err != nil {
+		return "", err
+	}
+	if matched == 0 {
+		return "", errs.ErrConflictUpdatingInstance
+	}
+
+	return newETag, nil
+}
+
+// AddEventToInstance to the instance collection
+func (s *Store) AddEventToInstance(ctx context.Context, currentInstance *models.Instance, event *models.Event, eTagSelector string) (string, error) {
+	newETag, err := mongo.NewETagForAddEvent(currentInstance, event)
+	if err != nil {
+		return "", err
+	}
+
+	update := bson.M{
+		"$push": bson.M{"events": event},
+		"$set": bson.M{
+			"last_updated": time.Now().UTC(),
+			"e_tag":        newETag,
+		},
+	}
+
+	return s.updateInstanceETag(ctx, selector(currentInstance.InstanceID, eTagSelector), update, newETag, errs.ErrInstanceNotFound)
+}
+
+// UpdateObservationInserted by incrementing the stored value
+func (s *Store) UpdateObservationInserted(ctx context.Context, currentInstance *models.Instance, observationInserted int64, eTagSelector string) (string, error) {
+	newETag, err := mongo.NewETagForObservationsInserted(currentInstance, observationInserted)
+	if err != nil {
+		return "", err
+	}
+
+	update := bson.M{
+		"$inc": bson.M{"import_tasks.import_observations.total_inserted_observations": observationInserted},
+		"$set": bson.M{
+			"last_updated": time.Now().UTC(),
+			"e_tag":        newETag,
+		},
+	}
+
+	return s.updateInstanceETag(ctx, selector(currentInstance.InstanceID, eTagSelector), update, newETag, errs.ErrInstanceNotFound)
+}
+
+// UpdateImportObservationsTaskState to the given state.
+func (s *Store) UpdateImportObservationsTaskState(ctx context.Context, currentInstance *models.Instance, state, eTagSelector string) (string, error) {
+	newETag, err := mongo.NewETagForStateUpdate(currentInstance, state)
+	if err != nil {
+		return "", err
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"import_tasks.import_observations.state": state,
+			"e_tag":                                  newETag,
+		},
+		"$currentDate": bson.M{"last_updated": true},
+	}
+
+	return s.updateInstanceETag(ctx, selector(currentInstance.InstanceID, eTagSelector), update, newETag, errs.ErrInstanceNotFound)
+}
+
+// UpdateBuildHierarchyTaskState updates the state of a build hierarchy task.
+func (s *Store) UpdateBuildHierarchyTaskState(ctx context.Context, currentInstance *models.Instance, dimension, state, eTagSelector string) (string, error) {
+	newETag, err := mongo.NewETagForHierarchyTaskStateUpdate(currentInstance, dimension, state)
+	if err != nil {
+		return "", err
+	}
+
+	sel := selector(currentInstance.InstanceID, eTagSelector)
+	sel["import_tasks.build_hierarchies.dimension_name"] = dimension
+
+	update := bson.M{
+		"$set": bson.M{
+			"import_tasks.build_hierarchies.$.state": state,
+			"e_tag":                                  newETag,
+		},
+		"$currentDate": bson.M{"last_updated": true},
+	}
+
+	return s.updateInstanceETag(ctx, sel, update, newETag, mongodriver.ErrNoDocumentFound)
+}
+
+// UpdateBuildSearchTaskState updates the state of a build search task.
+func (s *Store) UpdateBuildSearchTaskState(ctx context.Context, currentInstance *models.Instance, dimension, state, eTagSelector string) (string, error) {
+	newETag, err := mongo.NewETagForBuildSearchTaskStateUpdate(currentInstance, dimension, state)
+	if err != nil {
+		return "", err
+	}
+
+	sel := selector(currentInstance.InstanceID, eTagSelector)
+	sel["import_tasks.build_search_indexes.dimension_name"] = dimension
+
+	update := bson.M{
+		"$set": bson.M{
+			"import_tasks.build_search_indexes.$.state": state,
+			"e_tag": newETag,
+		},
+		"$currentDate": bson.M{"last_updated": true},
+	}
+
+	return s.updateInstanceETag(ctx, sel, update, newETag, mongodriver.ErrNoDocumentFound)
+}
+
+// UpdateETagForOptions updates the eTag value for an instance according to the provided dimension options upserts and updates
+func (s *Store) UpdateETagForOptions(ctx context.Context, currentInstance *models.Instance, upserts []*models.CachedDimensionOption, updates []*models.DimensionOption, eTagSelector string) (string, error) {
+	newETag, err := mongo.NewETagForOptions(currentInstance, upserts, updates)
+	if err != nil {
+		return "", err
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"e_tag": newETag,
+		},
+		"$currentDate": bson.M{"last_updated": true},
+	}
+
+	return s.updateInstanceETag(ctx, selector(currentInstance.InstanceID, eTagSelector), update, newETag, mongodriver.ErrNoDocumentFound)
+}
+
+// updateInstanceETag applies the update to the instance matched by the selector and returns the new eTag,
+// or notFoundErr if no instance matches the selector
+func (s *Store) updateInstanceETag(ctx context.Context, sel, update bson.M, newETag string, notFoundErr error) (string, error) {
+	matched, err := s.collection(config.InstanceCollection).updateOne(ctx, sel, update, false)
+	if err != nil {
+		return "", err
+	}
+	if matched == 0 {
+		return "", notFoundErr
+	}
+
+	return newETag, nil
+}
+
+// selector creates a select query with the provided parameters
+// - instanceID represents the ID of the instance document that we want to query. Required.
+// - eTagselector is a unique hash of an instance document to be matched to prevent race conditions. Optional.
+func selector(instanceID, eTagSelector string) bson.M {
+	selector := bson.M{"id": instanceID}
+	if eTagSelector != mongo.AnyETag {
+		selector["e_tag"] = eTagSelector
+	}
+	return selector
+}
@@ -0,0 +1,77 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// lockClient provides exclusive locks for resource IDs, equivalent to the mongoDB lock client
+type lockClient struct {
+	mu       sync.Mutex
+	sequence int
+	released map[string]chan struct{}
+	owners   map[string]string
+}
+
+func newLockClient() *lockClient {
+	return &lockClient{
+		released: map[string]chan struct{}{},
+		owners:   map[string]string{},
+	}
+}
+
+// Acquire locks the provided resource ID, blocking until it is released if it is already locked
+// or until the context is done.
+func (l *lockClient) Acquire(ctx context.Context, resourceID string) (string, error) {
+	for {
+		l.mu.Lock()
+		released, locked := l.released[resourceID]
+		if !locked {
+			l.sequence++
+			lockID := fmt.Sprintf("%s-%d", resourceID, l.sequence)
+			l.released[resourceID] = make(chan struct{})
+			l.owners[lockID] = resourceID
+			l.mu.Unlock()
+			return lockID, nil
+		}
+		l.mu.Unlock()
+
+		select {
+		case <-released:
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+	}
+}
+
+// Lock locks the provided resource ID without waiting, returning an error if it is already locked
+func (l *lockClient) Lock(_ context.Context, resourceID string) (string, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, locked := l.released[resourceID]; locked {
+		return "", fmt.Errorf("resource %s is already locked", resourceID)
+	}
+
+	l.sequence++
+	lockID := fmt.Sprintf("%s-%d", resourceID, l.sequence)
+	l.released[resourceID] = make(chan struct{})
+	l.owners[lockID] = resourceID
+	return lockID, nil
+}
+
+// Unlock releases the lock with the provided lock ID, if it exists
+func (l *lockClient) Unlock(_ context.Context, lockID string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	resourceID, ok := l.owners[lockID]
+	if !ok {
+		return
+	}
+
+	close(l.released[resourceID])
+	delete(l.released, resourceID)
+	delete(l.owners, lockID)
+}
@@ -0,0 +1,79 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ONSdigital/dp-dataset-api/config"
+	"github.com/ONSdigital/dp-dataset-api/store"
+	"github.com/ONSdigital/dp-healthcheck/healthcheck"
+)
+
+// Store is an in-memory implementation of store.Storer which mirrors the behaviour of the mongoDB store.
+// It is intended for local development and component testing, where a running mongoDB is not available.
+type Store struct {
+	config.MongoConfig
+
+	mu   sync.Mutex
+	txMu sync.Mutex
+	// auditMu serialises the chaining of audit events
+	auditMu            sync.Mutex
+	collections        map[string]*Collection
+	lockClientInstance *lockClient
+	lockClientVersions *lockClient
+}
+
+// Store satisfies the MongoDB and Storer interfaces
+var (
+	_ store.MongoDB = (*Store)(nil)
+	_ store.Storer  = (*Store)(nil)
+)
+
+// New returns an empty in-memory store for the provided configuration
+func New(cfg config.MongoConfig) *Store {
+	return &Store{
+		MongoConfig:        cfg,
+		collections:        map[string]*Collection{},
+		lockClientInstance: newLockClient(),
+		lockClientVersions: newLockClient(),
+	}
+}
+
+// Collection returns the in-memory collection with the provided (actual) name, creating it if it does not exist yet
+func (s *Store) Collection(name string) *Collection {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.collections[name]
+	if !ok {
+		c = newCollection()
+		s.collections[name] = c
+	}
+	return c
+}
+
+// collection returns the in-memory collection for the provided collection key (e.g. config.DatasetsCollection)
+func (s *Store) collection(key string) *Collection {
+	return s.Collection(s.ActualCollectionName(key))
+}
+
+// DropDatabase removes all the documents from all the collections
+func (s *Store) DropDatabase(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, c := range s.collections {
+		c.Drop(ctx)
+	}
+	return nil
+}
+
+// Close is a no-op, as there are no connections to close
+func (s *Store) Close(context.Context) error {
+	return nil
+}
+
+// Checker is called by the healthcheck library to check the health state of this in-memory store, which is always healthy
+func (s *Store) Checker(_ context.Context, state *healthcheck.CheckState) error {
+	return state.Update(healthcheck.StatusOK, "in-memory datastore is healthy", 0)
+}
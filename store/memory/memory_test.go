@@ -0,0 +1,804 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	"github.com/ONSdigital/dp-dataset-api/config"
+	"github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/dp-dataset-api/mongo"
+	"github.com/ONSdigital/dp-dataset-api/pagination"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+var testContext = context.Background()
+
+func newTestStore() *Store {
+	cfg, err := config.Get()
+	if err != nil {
+		panic(err)
+	}
+	return New(cfg.MongoConfig)
+}
+
+func TestDatasets(t *testing.T) {
+	Convey("Given an in-memory store with a dataset", t, func() {
+		s := newTestStore()
+		err := s.UpsertDataset(testContext, "cpih01", &models.DatasetUpdate{
+			ID:   "cpih01",
+			Next: &models.Dataset{ID: "cpih01", Title: "CPIH", State: models.CreatedState},
+		})
+		So(err, ShouldBeNil)
+
+		Convey("Then the dataset can be retrieved by ID", func() {
+			dataset, err := s.GetDataset(testContext, "cpih01")
+			So(err, ShouldBeNil)
+			So(dataset.Next.Title, ShouldEqual, "CPIH")
+			So(dataset.Current, ShouldBeNil)
+		})
+
+		Convey("Then it is only returned to authorised callers while unpublished", func() {
+			_, count, _, err := s.GetDatasets(testContext, 0, 10, nil, false)
+			So(err, ShouldBeNil)
+			So(count, ShouldEqual, 0)
+
+			datasets, count, _, err := s.GetDatasets(testContext, 0, 10, nil, true)
+			So(err, ShouldBeNil)
+			So(count, ShouldEqual, 1)
+			So(datasets, ShouldHaveLength, 1)
+		})
+
+		Convey("Then its title is found", func() {
+			exists, err := s.CheckDatasetTitleExist(testContext, "CPIH")
+			So(err, ShouldBeNil)
+			So(exists, ShouldBeTrue)
+		})
+
+		Convey("When the dataset is updated twice", func() {
+			So(s.UpdateDataset(testContext, "cpih01", &models.Dataset{Title: "CPIH v2"}, models.CreatedState), ShouldBeNil)
+			So(s.UpdateDataset(testContext, "cpih01", &models.Dataset{Title: "CPIH v3"}, models.CreatedState), ShouldBeNil)
+
+			Convey("Then a revision is stored for its creation and each update, most recent first", func() {
+				revisions, count, err := s.GetDatasetRevisions(testContext, "cpih01", 0, 10)
+				So(err, ShouldBeNil)
+				So(count, ShouldEqual, 3)
+				So(revisions[0].Revision, ShouldEqual, 3)
+				So(revisions[0].Dataset.Title, ShouldEqual, "CPIH v3")
+				So(revisions[1].Revision, ShouldEqual, 2)
+				So(revisions[2].Revision, ShouldEqual, 1)
+				So(revisions[2].Dataset.Title, ShouldEqual, "CPIH")
+
+				revision, err := s.GetDatasetRevision(testContext, "cpih01", 2)
+				So(err, ShouldBeNil)
+				So(revision.Dataset.Title, ShouldEqual, "CPIH v2")
+
+				_, err = s.GetDatasetRevision(testContext, "cpih01", 4)
+				So(err, ShouldEqual, errs.ErrRevisionNotFound)
+			})
+
+			Convey("Then concurrent updates store revisions with distinct numbers", func() {
+				var wg sync.WaitGroup
+				results := make(chan error, 10)
+				for i := 0; i < 10; i++ {
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						results <- s.UpdateDataset(testContext, "cpih01", &models.Dataset{Title: "CPIH concurrent"}, models.CreatedState)
+					}()
+				}
+				wg.Wait()
+				close(results)
+				for err := range results {
+					So(err, ShouldBeNil)
+				}
+
+				revisions, count, err := s.GetDatasetRevisions(testContext, "cpih01", 0, 20)
+				So(err, ShouldBeNil)
+				So(count, ShouldEqual, 13)
+				for i, revision := range revisions {
+					So(revision.Revision, ShouldEqual, 13-i)
+				}
+			})
+
+			Convey("Then the revisions are purged with the deleted dataset", func() {
+				So(s.DeleteDataset(testContext, "cpih01", models.NewDeletion("user@ons.gov.uk")), ShouldBeNil)
+
+				purged, err := s.PurgeDeletedDatasets(testContext, time.Now().Add(time.Minute))
+				So(err, ShouldBeNil)
+				So(purged, ShouldEqual, 4)
+
+				_, count, err := s.GetDatasetRevisions(testContext, "cpih01", 0, 10)
+				So(err, ShouldBeNil)
+				So(count, ShouldEqual, 0)
+			})
+		})
+
+		Convey("When the dataset is published", func() {
+			published := &models.Dataset{ID: "cpih01", Title: "CPIH", State: models.PublishedState}
+			So(s.UpsertDataset(testContext, "cpih01", &models.DatasetUpdate{ID: "cpih01", Current: published, Next: published}), ShouldBeNil)
+
+			Convey("Then its published state is kept when it is replaced by an unpublished dataset", func() {
+				So(s.UpsertDataset(testContext, "cpih01", &models.DatasetUpdate{ID: "cpih01", Next: &models.Dataset{ID: "cpih01", Title: "CPIH v2", State: models.CreatedState}}), ShouldBeNil)
+
+				dataset, err := s.GetDataset(testContext, "cpih01")
+				So(err, ShouldBeNil)
+				So(dataset.Current, ShouldNotBeNil)
+				So(dataset.Current.Title, ShouldEqual, "CPIH")
+			})
+
+			Convey("Then its published state is not brought back when it is deleted and created again with the same ID", func() {
+				So(s.DeleteDataset(testContext, "cpih01", models.NewDeletion("user@ons.gov.uk")), ShouldBeNil)
+				So(s.UpsertDataset(testContext, "cpih01", &models.DatasetUpdate{ID: "cpih01", Next: &models.Dataset{ID: "cpih01", Title: "New CPIH", State: models.CreatedState}}), ShouldBeNil)
+
+				dataset, err := s.GetDataset(testContext, "cpih01")
+				So(err, ShouldBeNil)
+				So(dataset.Next.Title, ShouldEqual, "New CPIH")
+				So(dataset.Current, ShouldBeNil)
+
+				_, count, _, err := s.GetDatasets(testContext, 0, 10, nil, false)
+				So(err, ShouldBeNil)
+				So(count, ShouldEqual, 0)
+			})
+		})
+
+		Convey("When the dataset is deleted", func() {
+			So(s.DeleteDataset(testContext, "cpih01", models.NewDeletion("user@ons.gov.uk")), ShouldBeNil)
+
+			Convey("Then it can no longer be found", func() {
+				_, err := s.GetDataset(testContext, "cpih01")
+				So(err, ShouldEqual, errs.ErrDatasetNotFound)
+				So(s.DeleteDataset(testContext, "cpih01", models.NewDeletion("user@ons.gov.uk")), ShouldEqual, errs.ErrDatasetNotFound)
+
+				_, count, _, err := s.GetDatasets(testContext, 0, 10, nil, true)
+				So(err, ShouldBeNil)
+				So(count, ShouldEqual, 0)
+			})
+
+			Convey("Then it can be restored", func() {
+				So(s.RestoreDataset(testContext, "cpih01"), ShouldBeNil)
+				_, err := s.GetDataset(testContext, "cpih01")
+				So(err, ShouldBeNil)
+				So(s.RestoreDataset(testContext, "cpih01"), ShouldEqual, errs.ErrDatasetNotFound)
+			})
+
+			Convey("Then it is only purged once the retention period has passed", func() {
+				purged, err := s.PurgeDeletedDatasets(testContext, time.Now().Add(-time.Hour))
+				So(err, ShouldBeNil)
+				So(purged, ShouldEqual, 0)
+
+				purged, err = s.PurgeDeletedDatasets(testContext, time.Now().Add(time.Minute))
+				So(err, ShouldBeNil)
+				So(purged, ShouldEqual, 2)
+				So(s.RestoreDataset(testContext, "cpih01"), ShouldEqual, errs.ErrDatasetNotFound)
+			})
+		})
+	})
+}
+
+func TestDatasetsCursorPagination(t *testing.T) {
+	Convey("Given an in-memory store with several datasets", t, func() {
+		s := newTestStore()
+		for _, id := range []string{"a", "b", "c"} {
+			So(s.UpsertDataset(testContext, id, &models.DatasetUpdate{ID: id, Next: &models.Dataset{ID: id}}), ShouldBeNil)
+		}
+
+		Convey("When the datasets are paginated by cursor", func() {
+			first, count, cursor, err := s.GetDatasets(testContext, 0, 2, nil, true)
+			So(err, ShouldBeNil)
+			So(count, ShouldEqual, 3)
+			So(cursor, ShouldResemble, &pagination.Cursor{ID: "b"})
+
+			Convey("Then the next page contains the remaining datasets, without a further cursor", func() {
+				So(first, ShouldHaveLength, 2)
+				So(first[0].ID, ShouldEqual, "c")
+
+				second, count, cursor, err := s.GetDatasets(testContext, 0, 2, cursor, true)
+				So(err, ShouldBeNil)
+				So(count, ShouldEqual, 3)
+				So(second, ShouldHaveLength, 1)
+				So(second[0].ID, ShouldEqual, "a")
+				So(cursor, ShouldBeNil)
+			})
+
+			Convey("Then a dataset added before the cursor is not returned in the next page", func() {
+				So(s.UpsertDataset(testContext, "d", &models.DatasetUpdate{ID: "d", Next: &models.Dataset{ID: "d"}}), ShouldBeNil)
+
+				second, count, _, err := s.GetDatasets(testContext, 0, 2, cursor, true)
+				So(err, ShouldBeNil)
+				So(count, ShouldEqual, 4)
+				So(second, ShouldHaveLength, 1)
+				So(second[0].ID, ShouldEqual, "a")
+			})
+		})
+	})
+}
+
+func TestInstances(t *testing.T) {
+	Convey("Given an in-memory store with an instance", t, func() {
+		s := newTestStore()
+		instance, err := s.AddInstance(testContext, &models.Instance{InstanceID: "123", State: models.CreatedState})
+		So(err, ShouldBeNil)
+		So(instance.ETag, ShouldNotBeEmpty)
+
+		Convey("When the instance is updated with the current eTag", func() {
+			newETag, err := s.UpdateInstance(testContext, instance, &models.Instance{State: models.SubmittedState}, instance.ETag)
+
+			Convey("Then the update is applied and a new eTag is returned", func() {
+				So(err, ShouldBeNil)
+				So(newETag, ShouldNotEqual, instance.ETag)
+
+				updated, err := s.GetInstance(testContext, "123", mongo.AnyETag)
+				So(err, ShouldBeNil)
+				So(updated.State, ShouldEqual, models.SubmittedState)
+				So(updated.ETag, ShouldEqual, newETag)
+			})
+		})
+
+		Convey("When the state of the instance is updated with its state history", func() {
+			stateChange := models.NewStateChange(models.CreatedState, models.SubmittedState, "publisher@ons.gov.uk", "")
+			_, err := s.UpdateInstance(testContext, instance, &models.Instance{State: models.SubmittedState, StateHistory: []models.StateChange{stateChange}}, instance.ETag)
+
+			Convey("Then the state history is stored with the instance", func() {
+				So(err, ShouldBeNil)
+
+				updated, err := s.GetInstance(testContext, "123", mongo.AnyETag)
+				So(err, ShouldBeNil)
+				So(updated.StateHistory, ShouldResemble, []models.StateChange{stateChange})
+			})
+		})
+
+		Convey("When the instance is updated with an outdated eTag", func() {
+			_, err := s.UpdateInstance(testContext, instance, &models.Instance{State: models.SubmittedState}, "outdated")
+
+			Convey("Then a conflict error is returned", func() {
+				So(err, ShouldEqual, errs.ErrConflictUpdatingInstance)
+			})
+		})
+
+		Convey("Then retrieving it with an outdated eTag returns a conflict error", func() {
+			_, err := s.GetInstance(testContext, "123", "outdated")
+			So(err, ShouldEqual, errs.ErrInstanceConflict)
+		})
+
+		Convey("Then an unknown instance is not found", func() {
+			_, err := s.GetInstance(testContext, "456", mongo.AnyETag)
+			So(err, ShouldEqual, errs.ErrInstanceNotFound)
+		})
+	})
+}
+
+func TestVersionsByCollectionID(t *testing.T) {
+	Convey("Given an in-memory store with the versions and instances of a collection", t, func() {
+		s := newTestStore()
+		_, err := s.AddInstance(testContext, &models.Instance{InstanceID: "123", CollectionID: "collection-1", State: models.AssociatedState})
+		So(err, ShouldBeNil)
+		_, err = s.AddInstance(testContext, &models.Instance{InstanceID: "456", CollectionID: "collection-2", State: models.AssociatedState})
+		So(err, ShouldBeNil)
+		for _, version := range []*models.Version{
+			{ID: "static-2", Version: 2, CollectionID: "collection-1", State: models.ApprovedState},
+			{ID: "static-1", Version: 1, CollectionID: "collection-1", State: models.PublishedState},
+		} {
+			_, err = s.AddVersionStatic(testContext, version)
+			So(err, ShouldBeNil)
+		}
+
+		Convey("Then the versions of the collection in the provided states are returned", func() {
+			versions, err := s.GetVersionsByCollectionID(testContext, "collection-1", []string{models.AssociatedState, models.ApprovedState})
+			So(err, ShouldBeNil)
+			So(versions, ShouldHaveLength, 2)
+			So(versions[0].ID, ShouldEqual, "123")
+			So(versions[1].ID, ShouldEqual, "static-2")
+		})
+
+		Convey("Then a collection without versions in the provided states is not found", func() {
+			_, err := s.GetVersionsByCollectionID(testContext, "collection-3", []string{models.AssociatedState})
+			So(err, ShouldEqual, errs.ErrVersionsNotFound)
+		})
+	})
+}
+
+func TestLatestVersion(t *testing.T) {
+	Convey("Given an in-memory store with the versions of a dataset in two editions", t, func() {
+		s := newTestStore()
+		for _, instance := range []*models.Instance{
+			{InstanceID: "1", Edition: "2021", Version: 1, ReleaseDate: "2021-06-01T00:00:00.000Z", State: models.PublishedState},
+			{InstanceID: "2", Edition: "2021", Version: 2, ReleaseDate: "2022-06-01T00:00:00.000Z", State: models.WithdrawnState},
+			{InstanceID: "3", Edition: "2020", Version: 1, ReleaseDate: "2020-06-01T00:00:00.000Z", State: models.PublishedState},
+		} {
+			instance.Links = &models.InstanceLinks{Dataset: &models.LinkObject{ID: "cpih01"}}
+			_, err := s.AddInstance(testContext, instance)
+			So(err, ShouldBeNil)
+		}
+
+		Convey("Then the most recently released version in the provided state is returned", func() {
+			version, err := s.GetLatestVersion(testContext, "cpih01", "", models.PublishedState)
+			So(err, ShouldBeNil)
+			So(version.ID, ShouldEqual, "1")
+
+			version, err = s.GetLatestVersion(testContext, "cpih01", "2020", models.PublishedState)
+			So(err, ShouldBeNil)
+			So(version.ID, ShouldEqual, "3")
+		})
+
+		Convey("Then a dataset without versions in the provided state has no latest version", func() {
+			_, err := s.GetLatestVersion(testContext, "cpih01", "2021", models.AssociatedState)
+			So(err, ShouldEqual, errs.ErrVersionNotFound)
+		})
+	})
+}
+
+func TestNextVersionStatic(t *testing.T) {
+	Convey("Given an in-memory store with an edition of a static dataset whose latest version has been deleted", t, func() {
+		s := newTestStore()
+		for _, version := range []*models.Version{
+			{ID: "static-1", Edition: "2025", Version: 1, State: models.PublishedState},
+			{ID: "static-2", Edition: "2025", Version: 2, State: models.AssociatedState},
+		} {
+			version.Links = &models.VersionLinks{Dataset: &models.LinkObject{ID: "cpih01"}, Edition: &models.LinkObject{ID: "2025"}}
+			_, err := s.AddVersionStatic(testContext, version)
+			So(err, ShouldBeNil)
+		}
+		So(s.DeleteStaticDatasetVersion(testContext, "cpih01", "2025", 2, models.NewDeletion("publisher@ons.gov.uk")), ShouldBeNil)
+
+		Convey("Then the next version number follows the deleted version", func() {
+			next, err := s.GetNextVersionStatic(testContext, "cpih01", "2025")
+			So(err, ShouldBeNil)
+			So(next, ShouldEqual, 3)
+		})
+
+		Convey("Then the first version of a new edition is version 1", func() {
+			next, err := s.GetNextVersionStatic(testContext, "cpih01", "2026")
+			So(err, ShouldBeNil)
+			So(next, ShouldEqual, 1)
+		})
+
+		Convey("Then the deleted version can be restored once", func() {
+			So(s.RestoreStaticVersion(testContext, "cpih01", "2025", 2), ShouldBeNil)
+			version, err := s.GetVersionStatic(testContext, "cpih01", "2025", 2, "")
+			So(err, ShouldBeNil)
+			So(version.ID, ShouldEqual, "static-2")
+			So(s.RestoreStaticVersion(testContext, "cpih01", "2025", 2), ShouldEqual, errs.ErrVersionNotFound)
+		})
+	})
+}
+
+func TestRestoreEdition(t *testing.T) {
+	Convey("Given an in-memory store with a deleted edition", t, func() {
+		s := newTestStore()
+		edition := &models.Edition{ID: "edition-1", Edition: "2021", Links: &models.EditionUpdateLinks{Dataset: &models.LinkObject{ID: "cpih01"}}}
+		So(s.UpsertEdition(testContext, "cpih01", "2021", &models.EditionUpdate{ID: "edition-1", Next: edition, Current: edition}), ShouldBeNil)
+		So(s.DeleteEdition(testContext, "edition-1", models.NewDeletion("publisher@ons.gov.uk")), ShouldBeNil)
+
+		Convey("Then it can be restored once", func() {
+			So(s.RestoreEdition(testContext, "cpih01", "2021"), ShouldBeNil)
+			_, err := s.GetEdition(testContext, "cpih01", "2021", "")
+			So(err, ShouldBeNil)
+			So(s.RestoreEdition(testContext, "cpih01", "2021"), ShouldEqual, errs.ErrEditionNotFound)
+		})
+
+		Convey("Then an edition that was not deleted cannot be restored", func() {
+			So(s.RestoreEdition(testContext, "cpih01", "2022"), ShouldEqual, errs.ErrEditionNotFound)
+		})
+	})
+}
+
+func TestDatasetsByCollectionID(t *testing.T) {
+	Convey("Given an in-memory store with the datasets of a collection", t, func() {
+		s := newTestStore()
+		for _, dataset := range []*models.Dataset{
+			{ID: "cpih01", CollectionID: "collection-1", State: models.ApprovedState},
+			{ID: "cpi", CollectionID: "collection-1", State: models.AssociatedState},
+			{ID: "mid-year-pop", CollectionID: "collection-1", State: models.CreatedState},
+			{ID: "suicides", CollectionID: "collection-2", State: models.AssociatedState},
+		} {
+			So(s.UpsertDataset(testContext, dataset.ID, &models.DatasetUpdate{ID: dataset.ID, Next: dataset}), ShouldBeNil)
+		}
+
+		Convey("Then the datasets of the collection in the provided states are returned", func() {
+			datasets, err := s.GetDatasetsByCollectionID(testContext, "collection-1", []string{models.AssociatedState, models.ApprovedState})
+			So(err, ShouldBeNil)
+			So(datasets, ShouldHaveLength, 2)
+			So(datasets[0].ID, ShouldEqual, "cpi")
+			So(datasets[1].ID, ShouldEqual, "cpih01")
+		})
+
+		Convey("Then a collection without datasets in the provided states has none", func() {
+			datasets, err := s.GetDatasetsByCollectionID(testContext, "collection-3", []string{models.AssociatedState})
+			So(err, ShouldBeNil)
+			So(datasets, ShouldBeEmpty)
+		})
+	})
+}
+
+func TestDimensionOptions(t *testing.T) {
+	Convey("Given an in-memory store with dimension options for a version", t, func() {
+		s := newTestStore()
+		err := s.UpsertDimensionsToInstance(testContext, []*models.CachedDimensionOption{
+			{InstanceID: "123", Name: "aggregate", Option: "cpih1dim1A0", Code: "cpih1dim1A0", CodeList: "cpih1dim1aggid"},
+			{InstanceID: "123", Name: "aggregate", Option: "cpih1dim1A1", Code: "cpih1dim1A1", CodeList: "cpih1dim1aggid"},
+			{InstanceID: "456", Name: "aggregate", Option: "cpih1dim1A2", Code: "cpih1dim1A2", CodeList: "cpih1dim1aggid"},
+		})
+		So(err, ShouldBeNil)
+
+		self := &models.LinkObject{HRef: "http://localhost:22000/datasets/cpih01/editions/time-series/versions/1"}
+		version := &models.Version{ID: "123", Links: &models.VersionLinks{Self: self}}
+
+		Convey("Then the options of the version are returned sorted by option, with the total count", func() {
+			options, count, nextCursor, err := s.GetDimensionOptions(testContext, version, "aggregate", 0, 1, nil)
+			So(err, ShouldBeNil)
+			So(count, ShouldEqual, 2)
+			So(options, ShouldHaveLength, 1)
+			So(options[0].Option, ShouldEqual, "cpih1dim1A0")
+			So(options[0].Links.Version, ShouldResemble, *self)
+
+			Convey("And the next page starts after the cursor of the first one", func() {
+				options, count, nextCursor, err := s.GetDimensionOptions(testContext, version, "aggregate", 0, 1, nextCursor)
+				So(err, ShouldBeNil)
+				So(count, ShouldEqual, 2)
+				So(options, ShouldHaveLength, 1)
+				So(options[0].Option, ShouldEqual, "cpih1dim1A1")
+				So(nextCursor, ShouldBeNil)
+			})
+		})
+
+		Convey("Then the unique options of the dimension are returned", func() {
+			values, count, err := s.GetUniqueDimensionAndOptions(testContext, "123", "aggregate")
+			So(err, ShouldBeNil)
+			So(count, ShouldEqual, 2)
+			So(values, ShouldHaveLength, 2)
+		})
+	})
+}
+
+func TestLocks(t *testing.T) {
+	Convey("Given an instance lock that has been acquired", t, func() {
+		s := newTestStore()
+		lockID, err := s.AcquireInstanceLock(testContext, "123")
+		So(err, ShouldBeNil)
+
+		Convey("When the same instance is locked again", func() {
+			ctx, cancel := context.WithTimeout(testContext, 50*time.Millisecond)
+			defer cancel()
+			_, err := s.AcquireInstanceLock(ctx, "123")
+
+			Convey("Then it blocks until the context is done", func() {
+				So(err, ShouldEqual, context.DeadlineExceeded)
+			})
+		})
+
+		Convey("When the lock is released", func() {
+			s.UnlockInstance(testContext, lockID)
+
+			Convey("Then the instance can be locked again", func() {
+				newLockID, err := s.AcquireInstanceLock(testContext, "123")
+				So(err, ShouldBeNil)
+				So(newLockID, ShouldNotEqual, lockID)
+			})
+		})
+	})
+}
+
+func TestRunTransaction(t *testing.T) {
+	Convey("Given an in-memory store with a dataset", t, func() {
+		s := newTestStore()
+		err := s.UpsertDataset(testContext, "cpih01", &models.DatasetUpdate{
+			ID:   "cpih01",
+			Next: &models.Dataset{ID: "cpih01", State: models.CreatedState},
+		})
+		So(err, ShouldBeNil)
+
+		Convey("When a transaction updates the dataset and then fails", func() {
+			err := s.RunTransaction(testContext, func(ctx context.Context) error {
+				if err := s.UpsertDataset(ctx, "cpih01", &models.DatasetUpdate{
+					ID:   "cpih01",
+					Next: &models.Dataset{ID: "cpih01", State: models.PublishedState},
+				}); err != nil {
+					return err
+				}
+				if err := s.UpsertEdition(ctx, "cpih01", "time-series", &models.EditionUpdate{
+					Next: &models.Edition{Edition: "time-series", Links: &models.EditionUpdateLinks{Dataset: &models.LinkObject{ID: "cpih01"}}},
+				}); err != nil {
+					return err
+				}
+				return errs.ErrInternalServer
+			})
+
+			Convey("Then the error is returned and none of the changes are kept", func() {
+				So(err, ShouldEqual, errs.ErrInternalServer)
+
+				dataset, err := s.GetDataset(testContext, "cpih01")
+				So(err, ShouldBeNil)
+				So(dataset.Next.State, ShouldEqual, models.CreatedState)
+
+				_, err = s.GetEdition(testContext, "cpih01", "time-series", "")
+				So(err, ShouldEqual, errs.ErrEditionNotFound)
+			})
+		})
+
+		Convey("When a transaction fails after other writers changed the store", func() {
+			err := s.RunTransaction(testContext, func(ctx context.Context) error {
+				if err := s.UpsertDataset(ctx, "cpih01", &models.DatasetUpdate{
+					ID:   "cpih01",
+					Next: &models.Dataset{ID: "cpih01", State: models.PublishedState},
+				}); err != nil {
+					return err
+				}
+
+				// writes made with a context outside of the transaction
+				if err := s.UpsertDataset(testContext, "cpih01", &models.DatasetUpdate{
+					ID:   "cpih01",
+					Next: &models.Dataset{ID: "cpih01", State: models.AssociatedState},
+				}); err != nil {
+					return err
+				}
+				if err := s.AddOutboxMessage(testContext, &models.OutboxMessage{ID: "message-1", Topic: "search-content-updated"}); err != nil {
+					return err
+				}
+				return errs.ErrInternalServer
+			})
+
+			Convey("Then the error is returned and the changes of the other writers are kept", func() {
+				So(err, ShouldEqual, errs.ErrInternalServer)
+
+				dataset, err := s.GetDataset(testContext, "cpih01")
+				So(err, ShouldBeNil)
+				So(dataset.Next.State, ShouldEqual, models.AssociatedState)
+
+				count, err := s.collection(config.OutboxCollection).Count(testContext, bson.M{"_id": "message-1"})
+				So(err, ShouldBeNil)
+				So(count, ShouldEqual, 1)
+			})
+		})
+
+		Convey("When a transaction updates the dataset successfully", func() {
+			err := s.RunTransaction(testContext, func(ctx context.Context) error {
+				return s.UpsertDataset(ctx, "cpih01", &models.DatasetUpdate{
+					ID:   "cpih01",
+					Next: &models.Dataset{ID: "cpih01", State: models.PublishedState},
+				})
+			})
+
+			Convey("Then the changes are kept", func() {
+				So(err, ShouldBeNil)
+
+				dataset, err := s.GetDataset(testContext, "cpih01")
+				So(err, ShouldBeNil)
+				So(dataset.Next.State, ShouldEqual, models.PublishedState)
+			})
+		})
+
+		Convey("When a nested transaction updates the dataset and the outer transaction then fails", func() {
+			err := s.RunTransaction(testContext, func(ctx context.Context) error {
+				if err := s.RunTransaction(ctx, func(ctx context.Context) error {
+					return s.UpsertDataset(ctx, "cpih01", &models.DatasetUpdate{
+						ID:   "cpih01",
+						Next: &models.Dataset{ID: "cpih01", State: models.PublishedState},
+					})
+				}); err != nil {
+					return err
+				}
+				return errs.ErrInternalServer
+			})
+
+			Convey("Then the nested transaction joins the outer one and none of the changes are kept", func() {
+				So(err, ShouldEqual, errs.ErrInternalServer)
+
+				dataset, err := s.GetDataset(testContext, "cpih01")
+				So(err, ShouldBeNil)
+				So(dataset.Next.State, ShouldEqual, models.CreatedState)
+			})
+		})
+	})
+}
+
+func TestAuditEvents(t *testing.T) {
+	Convey("Given an in-memory store with audit events", t, func() {
+		s := newTestStore()
+		start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		for i, resource := range []string{"/datasets/cpih01", "/datasets/cpih01/editions/2025/versions/1", "/datasets/cpih01-extra", "/datasets/other"} {
+			event := &models.AuditEvent{
+				CreatedAt:   start.Add(time.Duration(i) * time.Hour),
+				RequestedBy: models.RequestedBy{ID: "user-1"},
+				Action:      models.ActionUpdate,
+				Resource:    resource,
+				Dataset:     &models.Dataset{ID: "cpih01"},
+			}
+			if i == 3 {
+				event.Action = models.ActionCreate
+			}
+			So(s.CreateAuditEvent(testContext, event), ShouldBeNil)
+		}
+
+		resources := func(filter *models.AuditEventFilter) []string {
+			events, totalCount, err := s.GetAuditEvents(testContext, filter, 0, 10)
+			So(err, ShouldBeNil)
+			So(totalCount, ShouldEqual, len(events))
+
+			resources := []string{}
+			for _, event := range events {
+				resources = append(resources, event.Resource)
+			}
+			return resources
+		}
+
+		Convey("Then all of them are returned most recent first", func() {
+			So(resources(&models.AuditEventFilter{}), ShouldResemble, []string{
+				"/datasets/other", "/datasets/cpih01-extra", "/datasets/cpih01/editions/2025/versions/1", "/datasets/cpih01",
+			})
+		})
+
+		Convey("Then the events of a dataset include those of its editions and versions, but not of other datasets", func() {
+			So(resources(&models.AuditEventFilter{DatasetID: "cpih01"}), ShouldResemble, []string{
+				"/datasets/cpih01/editions/2025/versions/1", "/datasets/cpih01",
+			})
+		})
+
+		Convey("When the events of instances are recorded with their dataset", func() {
+			for i, datasetID := range []string{"cpih01", "other"} {
+				So(s.CreateAuditEvent(testContext, &models.AuditEvent{
+					CreatedAt:   start.Add(time.Duration(4+i) * time.Hour),
+					RequestedBy: models.RequestedBy{ID: "import-service"},
+					Action:      models.ActionUpdate,
+					Resource:    "/instances/instance-" + datasetID,
+					DatasetID:   datasetID,
+					Instance:    &models.Instance{InstanceID: "instance-" + datasetID},
+				}), ShouldBeNil)
+			}
+
+			Convey("Then the events of a dataset include those of its instances", func() {
+				So(resources(&models.AuditEventFilter{DatasetID: "cpih01"}), ShouldResemble, []string{
+					"/instances/instance-cpih01", "/datasets/cpih01/editions/2025/versions/1", "/datasets/cpih01",
+				})
+			})
+		})
+
+		Convey("Then they can be filtered by resource, action, requester and time range", func() {
+			So(resources(&models.AuditEventFilter{Resource: "/datasets/cpih01/editions/2025"}), ShouldResemble, []string{"/datasets/cpih01/editions/2025/versions/1"})
+			So(resources(&models.AuditEventFilter{Action: models.ActionCreate}), ShouldResemble, []string{"/datasets/other"})
+			So(resources(&models.AuditEventFilter{RequestedBy: "user-2"}), ShouldBeEmpty)
+
+			from, to := start.Add(time.Hour), start.Add(3*time.Hour)
+			So(resources(&models.AuditEventFilter{From: &from, To: &to}), ShouldResemble, []string{"/datasets/cpih01-extra", "/datasets/cpih01/editions/2025/versions/1"})
+		})
+
+		Convey("Then they are chained in the order they were created, and read back with the hashes they were chained with", func() {
+			events, err := s.GetAuditEventChain(testContext, 1, 10)
+			So(err, ShouldBeNil)
+			So(events, ShouldHaveLength, 4)
+
+			var previous *models.AuditEvent
+			for i, event := range events {
+				So(event.Sequence, ShouldEqual, i+1)
+				reason, err := event.CheckChainLink(previous)
+				So(err, ShouldBeNil)
+				So(reason, ShouldBeEmpty)
+				previous = event
+			}
+
+			events, err = s.GetAuditEventChain(testContext, 3, 1)
+			So(err, ShouldBeNil)
+			So(events, ShouldHaveLength, 1)
+			So(events[0].Resource, ShouldEqual, "/datasets/cpih01-extra")
+		})
+	})
+}
+
+func TestOutboxClaims(t *testing.T) {
+	Convey("Given an in-memory store with pending outbox messages of two topics", t, func() {
+		s := newTestStore()
+		first, err := models.NewOutboxMessage("topic-a", []byte("first"))
+		So(err, ShouldBeNil)
+		second, err := models.NewOutboxMessage("topic-b", []byte("second"))
+		So(err, ShouldBeNil)
+		second.CreatedAt = first.CreatedAt.Add(time.Millisecond)
+		So(s.AddOutboxMessage(testContext, first), ShouldBeNil)
+		So(s.AddOutboxMessage(testContext, second), ShouldBeNil)
+
+		Convey("When a relay claims a message", func() {
+			claimed, err := s.ClaimOutboxMessage(testContext, "relay-1", time.Minute, nil)
+			So(err, ShouldBeNil)
+
+			Convey("Then the oldest message is claimed by the relay until the lease expires", func() {
+				So(claimed.ID, ShouldEqual, first.ID)
+				So(claimed.State, ShouldEqual, models.OutboxMessageDelivering)
+				So(claimed.ClaimedBy, ShouldEqual, "relay-1")
+				So(*claimed.LeaseExpiresAt, ShouldHappenAfter, time.Now().UTC())
+			})
+
+			Convey("Then another relay claims the next message instead", func() {
+				next, err := s.ClaimOutboxMessage(testContext, "relay-2", time.Minute, nil)
+				So(err, ShouldBeNil)
+				So(next.ID, ShouldEqual, second.ID)
+
+				_, err = s.ClaimOutboxMessage(testContext, "relay-2", time.Minute, nil)
+				So(err, ShouldEqual, errs.ErrOutboxMessageNotFound)
+			})
+
+			Convey("Then its delivery can only be recorded by the relay that claimed it", func() {
+				claimed.State = models.OutboxMessageSent
+				claimed.ClaimedBy = "relay-2"
+				So(s.UpdateOutboxMessage(testContext, claimed), ShouldEqual, errs.ErrOutboxMessageNotFound)
+
+				claimed.ClaimedBy = "relay-1"
+				So(s.UpdateOutboxMessage(testContext, claimed), ShouldBeNil)
+
+				count, err := s.CountOutboxMessages(testContext, models.OutboxMessageSent)
+				So(err, ShouldBeNil)
+				So(count, ShouldEqual, 1)
+			})
+		})
+
+		Convey("When a relay claims a message with a lease that expires", func() {
+			_, err := s.ClaimOutboxMessage(testContext, "relay-1", -time.Second, nil)
+			So(err, ShouldBeNil)
+
+			Convey("Then the message can be claimed again by another relay", func() {
+				claimed, err := s.ClaimOutboxMessage(testContext, "relay-2", time.Minute, nil)
+				So(err, ShouldBeNil)
+				So(claimed.ID, ShouldEqual, first.ID)
+				So(claimed.ClaimedBy, ShouldEqual, "relay-2")
+			})
+		})
+
+		Convey("When a relay claims a message skipping a topic", func() {
+			claimed, err := s.ClaimOutboxMessage(testContext, "relay-1", time.Minute, []string{"topic-a"})
+
+			Convey("Then a message of another topic is claimed", func() {
+				So(err, ShouldBeNil)
+				So(claimed.ID, ShouldEqual, second.ID)
+			})
+		})
+
+		Convey("When a message is sent and the other one fails on its last attempt", func() {
+			sent, err := s.ClaimOutboxMessage(testContext, "relay-1", time.Minute, nil)
+			So(err, ShouldBeNil)
+			sentAt := time.Now().UTC().Add(-time.Hour)
+			sent.State = models.OutboxMessageSent
+			sent.SentAt = &sentAt
+			So(s.UpdateOutboxMessage(testContext, sent), ShouldBeNil)
+
+			failed, err := s.ClaimOutboxMessage(testContext, "relay-1", time.Minute, nil)
+			So(err, ShouldBeNil)
+			failed.State = models.OutboxMessageFailed
+			failed.Attempts = 10
+			failed.NextAttemptAt = time.Now().UTC().Add(-time.Minute)
+			So(s.UpdateOutboxMessage(testContext, failed), ShouldBeNil)
+
+			Convey("Then the failed message is only re-queued once its retry delay passed, with a new set of attempts", func() {
+				requeued, err := s.RequeueFailedOutboxMessages(testContext, time.Now().UTC().Add(-time.Hour))
+				So(err, ShouldBeNil)
+				So(requeued, ShouldEqual, 0)
+
+				requeued, err = s.RequeueFailedOutboxMessages(testContext, time.Now().UTC())
+				So(err, ShouldBeNil)
+				So(requeued, ShouldEqual, 1)
+
+				claimed, err := s.ClaimOutboxMessage(testContext, "relay-1", time.Minute, nil)
+				So(err, ShouldBeNil)
+				So(claimed.ID, ShouldEqual, second.ID)
+				So(claimed.Attempts, ShouldEqual, 0)
+			})
+
+			Convey("Then the sent message is only purged once it was sent before the retention period", func() {
+				purged, err := s.PurgeSentOutboxMessages(testContext, time.Now().UTC().Add(-2*time.Hour))
+				So(err, ShouldBeNil)
+				So(purged, ShouldEqual, 0)
+
+				purged, err = s.PurgeSentOutboxMessages(testContext, time.Now().UTC())
+				So(err, ShouldBeNil)
+				So(purged, ShouldEqual, 1)
+
+				count, err := s.CountOutboxMessages(testContext, models.OutboxMessageSent)
+				So(err, ShouldBeNil)
+				So(count, ShouldEqual, 0)
+				count, err = s.CountOutboxMessages(testContext, models.OutboxMessageFailed)
+				So(err, ShouldBeNil)
+				So(count, ShouldEqual, 1)
+			})
+		})
+	})
+}
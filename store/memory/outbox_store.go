@@ -0,0 +1,89 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	"github.com/ONSdigital/dp-dataset-api/config"
+	"github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/dp-dataset-api/mongo"
+	mongodriver "github.com/ONSdigital/dp-mongodb/v3/mongodb"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// AddOutboxMessage inserts a new message into the outbox collection
+func (s *Store) AddOutboxMessage(ctx context.Context, message *models.OutboxMessage) error {
+	_, err := s.collection(config.OutboxCollection).insert(ctx, message)
+	return err
+}
+
+// ClaimOutboxMessage atomically claims the oldest outbox message that is due to be sent, for the provided owner until the lease
+// expires. Messages that are pending, or whose previous claim expired, can be claimed, except the ones of the topics to skip.
+// If there are no messages to claim, an ErrOutboxMessageNotFound error is returned.
+func (s *Store) ClaimOutboxMessage(ctx context.Context, owner string, lease time.Duration, skipTopics []string) (*models.OutboxMessage, error) {
+	now := time.Now().UTC()
+
+	doc, err := s.collection(config.OutboxCollection).findOneAndUpdate(ctx, mongo.ClaimableOutboxMessageSelector(now, skipTopics), bson.M{
+		"$set": bson.M{
+			"state":            models.OutboxMessageDelivering,
+			"claimed_by":       owner,
+			"lease_expires_at": now.Add(lease),
+		},
+	}, "created_at", 1)
+	if err != nil {
+		if errors.Is(err, mongodriver.ErrNoDocumentFound) {
+			return nil, errs.ErrOutboxMessageNotFound
+		}
+		return nil, err
+	}
+
+	var message models.OutboxMessage
+	if err := decode(doc, &message); err != nil {
+		return nil, err
+	}
+	return &message, nil
+}
+
+// UpdateOutboxMessage stores the delivery state of an outbox message and releases its claim. If the message is no longer claimed
+// by the owner that delivered it (i.e. its lease expired and it was claimed again), an ErrOutboxMessageNotFound error is returned.
+func (s *Store) UpdateOutboxMessage(ctx context.Context, message *models.OutboxMessage) error {
+	matched, err := s.collection(config.OutboxCollection).updateOne(ctx, bson.M{"_id": message.ID, "claimed_by": message.ClaimedBy}, bson.M{
+		"$set": bson.M{
+			"state":           message.State,
+			"attempts":        message.Attempts,
+			"last_error":      message.LastError,
+			"next_attempt_at": message.NextAttemptAt,
+			"sent_at":         message.SentAt,
+		},
+		"$unset": bson.M{
+			"claimed_by":       "",
+			"lease_expires_at": "",
+		},
+	}, false)
+	if err != nil {
+		return err
+	}
+	if matched == 0 {
+		return errs.ErrOutboxMessageNotFound
+	}
+	return nil
+}
+
+// CountOutboxMessages returns the number of outbox messages in the provided state
+func (s *Store) CountOutboxMessages(ctx context.Context, state string) (int, error) {
+	return s.collection(config.OutboxCollection).Count(ctx, bson.M{"state": state})
+}
+
+// RequeueFailedOutboxMessages makes the failed outbox messages that are due to be retried at the provided time pending again,
+// with a new set of delivery attempts, returning the number of messages re-queued
+func (s *Store) RequeueFailedOutboxMessages(ctx context.Context, now time.Time) (int, error) {
+	return s.collection(config.OutboxCollection).updateMany(ctx, mongo.RequeueableOutboxMessageSelector(now), mongo.RequeueOutboxMessageUpdate(now))
+}
+
+// PurgeSentOutboxMessages permanently removes the outbox messages that were sent before the provided time,
+// returning the number of messages removed
+func (s *Store) PurgeSentOutboxMessages(ctx context.Context, before time.Time) (int, error) {
+	return s.collection(config.OutboxCollection).deleteMany(ctx, mongo.SentOutboxMessageSelector(before))
+}
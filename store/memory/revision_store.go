@@ -0,0 +1,77 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	"github.com/ONSdigital/dp-dataset-api/config"
+	"github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/dp-dataset-api/mongo"
+	mongodriver "github.com/ONSdigital/dp-mongodb/v3/mongodb"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// addDatasetRevision stores the current metadata of a dataset as its next revision
+func (s *Store) addDatasetRevision(ctx context.Context, datasetID string) error {
+	doc, err := s.collection(config.DatasetsCollection).findOneAndUpdate(ctx, bson.M{"_id": datasetID}, mongo.IncrementRevisionCount(), "", 0)
+	if err != nil {
+		if errors.Is(err, mongodriver.ErrNoDocumentFound) {
+			return errs.ErrDatasetNotFound
+		}
+		return err
+	}
+
+	var dataset mongo.RevisionCounter
+	if err := decode(doc, &dataset); err != nil {
+		return err
+	}
+
+	revision, err := models.NewDatasetRevision(datasetID, dataset.RevisionCount, dataset.Next)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.collection(config.DatasetRevisionsCollection).insert(ctx, revision)
+	return err
+}
+
+// GetDatasetRevisions returns the revisions of a dataset, most recent first, along with the total number of revisions
+func (s *Store) GetDatasetRevisions(_ context.Context, datasetID string, offset, limit int) ([]*models.DatasetRevision, int, error) {
+	docs, totalCount, err := s.collection(config.DatasetRevisionsCollection).find(bson.M{"dataset_id": datasetID}, "revision", -1, offset, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	revisions, err := decodeAll[models.DatasetRevision](docs)
+	if err != nil {
+		return nil, 0, err
+	}
+	return revisions, totalCount, nil
+}
+
+// GetDatasetRevision returns a revision of a dataset by its number
+func (s *Store) GetDatasetRevision(ctx context.Context, datasetID string, revision int) (*models.DatasetRevision, error) {
+	var result models.DatasetRevision
+	if err := s.collection(config.DatasetRevisionsCollection).FindOne(ctx, bson.M{"dataset_id": datasetID, "revision": revision}, &result); err != nil {
+		if errors.Is(err, mongodriver.ErrNoDocumentFound) {
+			return nil, errs.ErrRevisionNotFound
+		}
+		return nil, err
+	}
+	return &result, nil
+}
+
+// purgeDatasetRevisions permanently removes the revisions of the datasets that were deleted before the provided time
+func (s *Store) purgeDatasetRevisions(ctx context.Context, before time.Time) (int, error) {
+	ids, err := s.collection(config.DatasetsCollection).distinct("_id", mongo.DeletedBefore(before))
+	if err != nil {
+		return 0, err
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	return s.collection(config.DatasetRevisionsCollection).deleteMany(ctx, bson.M{"dataset_id": bson.M{"$in": ids}})
+}
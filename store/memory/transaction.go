@@ -0,0 +1,112 @@
+package memory
+
+import (
+	"context"
+	"reflect"
+	"sync"
+
+	"github.com/ONSdigital/log.go/v2/log"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// transactionKey marks the context of a RunTransaction call, holding the undo log of its writes
+type transactionKey struct{}
+
+// undoLog is the list of the documents written within a RunTransaction call
+type undoLog struct {
+	mu      sync.Mutex
+	entries []undoEntry
+}
+
+// undoEntry holds the state of a document before and after it was written within RunTransaction, so that the write can
+// be reverted if the transaction fails. A nil before means that the document was inserted, and a nil after that it was deleted.
+type undoEntry struct {
+	collection *Collection
+	id         interface{}
+	before     bson.M
+	after      bson.M
+}
+
+// RunTransaction executes fn so that either all of its writes are kept or none are: the documents it wrote are
+// reverted to their previous state if fn returns an error. Transactions are serialised with each other, but not with
+// writes made outside of a transaction, and a document changed by another writer since the transaction wrote it is left
+// as it is. A call made with the context of another RunTransaction call joins that transaction, so its writes are only
+// kept if the outer call succeeds.
+func (s *Store) RunTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if ctx.Value(transactionKey{}) != nil {
+		return fn(ctx)
+	}
+
+	s.txMu.Lock()
+	defer s.txMu.Unlock()
+
+	undo := &undoLog{}
+	if err := fn(context.WithValue(ctx, transactionKey{}, undo)); err != nil {
+		undo.revert(ctx)
+		return err
+	}
+
+	return nil
+}
+
+// record adds the write of a document to the undo log of the context, if it belongs to a RunTransaction call
+func record(ctx context.Context, c *Collection, before, after bson.M) {
+	undo, ok := ctx.Value(transactionKey{}).(*undoLog)
+	if !ok {
+		return
+	}
+
+	entry := undoEntry{collection: c}
+	if before != nil {
+		entry.id = before["_id"]
+		entry.before = copyDocument(before)
+	}
+	if after != nil {
+		entry.id = after["_id"]
+		entry.after = copyDocument(after)
+	}
+
+	undo.mu.Lock()
+	defer undo.mu.Unlock()
+	undo.entries = append(undo.entries, entry)
+}
+
+// revert restores the recorded documents in reverse order
+func (u *undoLog) revert(ctx context.Context) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	for i := len(u.entries) - 1; i >= 0; i-- {
+		u.entries[i].collection.revert(ctx, u.entries[i])
+	}
+}
+
+// revert restores the document of the entry to its state before it was written, unless it has been changed by another
+// writer since, so that the concurrent change is not lost
+func (c *Collection) revert(ctx context.Context, entry undoEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	index := -1
+	var current bson.M
+	for i, doc := range c.docs {
+		if equal(doc["_id"], entry.id) {
+			index, current = i, doc
+			break
+		}
+	}
+
+	if !reflect.DeepEqual(current, entry.after) {
+		log.Warn(ctx, "document written within the transaction has since been changed, so it is not rolled back", log.Data{"id": entry.id})
+		return
+	}
+
+	switch {
+	case entry.before == nil:
+		c.docs = append(c.docs[:index], c.docs[index+1:]...)
+	case index < 0:
+		c.docs = append(c.docs, entry.before)
+	default:
+		c.docs[index] = entry.before
+	}
+}
@@ -0,0 +1,349 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	"github.com/ONSdigital/dp-dataset-api/config"
+	"github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/dp-dataset-api/mongo"
+	mongodriver "github.com/ONSdigital/dp-mongodb/v3/mongodb"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// AcquireVersionsLock tries to lock the provided versionID.
+func (s *Store) AcquireVersionsLock(ctx context.Context, versionID string) (string, error) {
+	return s.lockClientVersions.Acquire(ctx, versionID)
+}
+
+// UnlockVersions releases the lock for the provided lockID (if it exists)
+func (s *Store) UnlockVersions(ctx context.Context, lockID string) {
+	s.lockClientVersions.Unlock(ctx, lockID)
+}
+
+// LockScheduledPublishing locks the publishing of scheduled versions without waiting, returning an error if it is
+// already locked
+func (s *Store) LockScheduledPublishing(ctx context.Context) (string, error) {
+	return s.lockClientVersions.Lock(ctx, "scheduled-publishing")
+}
+
+// UnlockScheduledPublishing releases the lock on the publishing of scheduled versions
+func (s *Store) UnlockScheduledPublishing(ctx context.Context, lockID string) {
+	s.lockClientVersions.Unlock(ctx, lockID)
+}
+
+// UpsertVersionStatic adds or overrides an existing static version document
+func (s *Store) UpsertVersionStatic(ctx context.Context, version *models.Version) error {
+	version.LastUpdated = time.Now()
+
+	sel := bson.M{
+		"edition": version.Edition,
+		"version": version.Version,
+		"e_tag":   version.ETag,
+	}
+
+	_, err := s.collection(config.VersionsCollection).updateOne(ctx, sel, bson.M{"$set": version}, true)
+	return err
+}
+
+// AddVersionStatic adds a version to the versions collection
+func (s *Store) AddVersionStatic(ctx context.Context, version *models.Version) (*models.Version, error) {
+	version.LastUpdated = time.Now().UTC()
+
+	var err error
+	version.ETag, err = version.Hash(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.collection(config.VersionsCollection).insert(ctx, version); err != nil {
+		return nil, err
+	}
+
+	return version, nil
+}
+
+// CheckEditionExistsStatic checks that the edition of a dataset exists in the versions collection
+func (s *Store) CheckEditionExistsStatic(ctx context.Context, datasetID, editionID, state string) error {
+	query := bson.M{
+		"links.dataset.id": datasetID,
+		"links.edition.id": editionID,
+	}
+	if state != "" {
+		query["state"] = state
+	}
+
+	count, err := s.collection(config.VersionsCollection).Count(ctx, mongo.NotDeleted(query))
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return errs.ErrEditionNotFound
+	}
+
+	return nil
+}
+
+// CheckVersionExistsStatic checks that the version of a dataset exists in the versions collection
+func (s *Store) CheckVersionExistsStatic(ctx context.Context, datasetID, editionID string, version int) (bool, error) {
+	query := bson.M{
+		"links.dataset.id": datasetID,
+		"links.edition.id": editionID,
+		"version":          version,
+	}
+
+	count, err := s.collection(config.VersionsCollection).Count(ctx, mongo.NotDeleted(query))
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// GetScheduledVersions retrieves the approved static versions that have a release date, soonest first
+func (s *Store) GetScheduledVersions(_ context.Context, offset, limit int) ([]*models.Version, int, error) {
+	docs, totalCount, err := s.collection(config.VersionsCollection).find(mongo.ScheduledVersionsSelector(), "release_date,_id", 1, offset, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	results, err := decodeAll[models.Version](docs)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return results, totalCount, nil
+}
+
+// GetStaticVersionsByState retrieves all versions that match the provided state
+// If state is empty, the search will include any state that is not "published"
+func (s *Store) GetStaticVersionsByState(_ context.Context, state, publishedOnly string, offset, limit int) ([]*models.Version, int, error) {
+	filter := bson.M{"type": models.Static.String()}
+
+	if state != "" {
+		filter["state"] = state
+	}
+
+	if publishedOnly != "" {
+		val, _ := strconv.ParseBool(strings.ToLower(publishedOnly))
+		if val {
+			filter["state"] = models.PublishedState
+		} else {
+			filter["state"] = bson.M{"$ne": models.PublishedState}
+		}
+	}
+
+	docs, totalCount, err := s.collection(config.VersionsCollection).find(mongo.NotDeleted(filter), "last_updated", -1, offset, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if totalCount == 0 {
+		return nil, 0, errs.ErrVersionsNotFound
+	}
+
+	results, err := decodeAll[models.Version](docs)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return results, totalCount, nil
+}
+
+// GetVersionsByCollectionID retrieves the versions and instances of a collection that are in one of the provided states,
+// sorted by dataset, edition and version
+func (s *Store) GetVersionsByCollectionID(_ context.Context, collectionID string, states []string) ([]*models.Version, error) {
+	results := []*models.Version{}
+	for _, collection := range []string{config.InstanceCollection, config.VersionsCollection} {
+		docs, err := s.collection(collection).findAll(mongo.CollectionVersionsSelector(collectionID, states), "links.dataset.id,edition,version", 1)
+		if err != nil {
+			return nil, err
+		}
+		versions, err := decodeAll[models.Version](docs)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, versions...)
+	}
+
+	if len(results) == 0 {
+		return nil, errs.ErrVersionsNotFound
+	}
+
+	return results, nil
+}
+
+// GetVersionsStatic retrieves all version documents for a dataset edition
+func (s *Store) GetVersionsStatic(_ context.Context, datasetID, edition, state string, offset, limit int) ([]models.Version, int, error) {
+	return s.getVersions(config.VersionsCollection, datasetID, edition, state, offset, limit)
+}
+
+// GetVersionStatic retrieves a version document for a dataset edition
+func (s *Store) GetVersionStatic(_ context.Context, id, editionID string, versionID int, state string) (*models.Version, error) {
+	return s.getVersion(config.VersionsCollection, id, editionID, versionID, state)
+}
+
+// GetLatestVersionStatic retrieves the latest version for an edition of a dataset
+func (s *Store) GetLatestVersionStatic(_ context.Context, datasetID, editionID, state string) (*models.Version, error) {
+	selector := bson.M{
+		"links.dataset.id": datasetID,
+		"links.edition.id": editionID,
+	}
+	if state != "" {
+		selector["state"] = state
+	}
+
+	doc, err := s.collection(config.VersionsCollection).findOne(mongo.NotDeleted(selector), "version", -1)
+	if err != nil {
+		if errors.Is(err, mongodriver.ErrNoDocumentFound) {
+			return nil, errs.ErrVersionNotFound
+		}
+		return nil, err
+	}
+
+	var version models.Version
+	if err := decode(doc, &version); err != nil {
+		return nil, err
+	}
+
+	return &version, nil
+}
+
+// GetNextVersionStatic returns the number of the next version of an edition of a static dataset, including deleted versions
+func (s *Store) GetNextVersionStatic(_ context.Context, datasetID, editionID string) (int, error) {
+	selector := bson.M{
+		"links.dataset.id": datasetID,
+		"links.edition.id": editionID,
+	}
+
+	doc, err := s.collection(config.VersionsCollection).findOne(selector, "version", -1)
+	if err != nil {
+		if errors.Is(err, mongodriver.ErrNoDocumentFound) {
+			return 1, nil
+		}
+		return 0, err
+	}
+
+	var version models.Version
+	if err := decode(doc, &version); err != nil {
+		return 0, err
+	}
+
+	return version.Version + 1, nil
+}
+
+// GetDatasetType retrieves the type of a dataset
+func (s *Store) GetDatasetType(_ context.Context, datasetID string, authorised bool) (string, error) {
+	selector := bson.M{"_id": datasetID}
+	if !authorised {
+		selector["current.state"] = models.PublishedState
+	}
+
+	doc, err := s.collection(config.DatasetsCollection).findOne(mongo.NotDeleted(selector), "", 0)
+	if err != nil {
+		if errors.Is(err, mongodriver.ErrNoDocumentFound) {
+			return "", errs.ErrDatasetNotFound
+		}
+		return "", err
+	}
+
+	var d models.DatasetUpdate
+	if err := decode(doc, &d); err != nil {
+		return "", err
+	}
+
+	if authorised {
+		if d.Next == nil {
+			return "", nil
+		}
+		return d.Next.Type, nil
+	}
+
+	if d.Current == nil {
+		return "", nil
+	}
+	return d.Current.Type, nil
+}
+
+// UpdateVersionStatic updates an existing static version document
+func (s *Store) UpdateVersionStatic(ctx context.Context, currentVersion, versionUpdate *models.Version, eTagSelector string) (string, error) {
+	newETag, err := mongo.NewETagForVersionUpdate(currentVersion, versionUpdate)
+	if err != nil {
+		return "", err
+	}
+
+	sel := bson.M{
+		"edition": currentVersion.Edition,
+		"version": currentVersion.Version,
+		"e_tag":   eTagSelector,
+	}
+	update := bson.M{"$set": mongo.CreateVersionUpdateQuery(versionUpdate, newETag)}
+
+	matched, err := s.collection(config.VersionsCollection).updateOne(ctx, sel, update, false)
+	if err != nil {
+		return "", err
+	}
+	if matched == 0 {
+		return "", errs.ErrVersionNotFound
+	}
+
+	return newETag, nil
+}
+
+// GetAllStaticVersions retrieves all the static versions of a dataset, optionally filtered by state.
+// NOTE: passing in limit as 0 will return the total count but no results
+func (s *Store) GetAllStaticVersions(_ context.Context, datasetID, state string, offset, limit int) ([]*models.Version, int, error) {
+	selector := bson.M{"links.dataset.id": datasetID}
+	if state != "" {
+		selector["state"] = state
+	}
+
+	docs, totalCount, err := s.collection(config.VersionsCollection).find(mongo.NotDeleted(selector), "release_date", -1, offset, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if totalCount < 1 {
+		return nil, 0, errs.ErrVersionsNotFound
+	}
+
+	results, err := decodeAll[models.Version](docs)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return results, totalCount, nil
+}
+
+// DeleteStaticDatasetVersion marks a static version document as deleted
+func (s *Store) DeleteStaticDatasetVersion(ctx context.Context, datasetID, editionID string, versionNumber int, deletion *models.Deletion) error {
+	filter := bson.M{
+		"links.dataset.id": datasetID,
+		"edition":          editionID,
+		"version":          versionNumber,
+	}
+
+	return s.softDelete(ctx, config.VersionsCollection, filter, deletion, errs.ErrVersionNotFound)
+}
+
+// CheckEditionTitleExistsStatic checks that no static version of the dataset has the provided edition title
+func (s *Store) CheckEditionTitleExistsStatic(ctx context.Context, datasetID, editionTitle string) error {
+	queryByTitle := bson.M{
+		"links.dataset.id": datasetID,
+		"edition_title":    editionTitle,
+	}
+
+	count, err := s.collection(config.VersionsCollection).Count(ctx, mongo.NotDeleted(queryByTitle))
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return errs.ErrEditionTitleAlreadyExists
+	}
+
+	return nil
+}
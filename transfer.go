@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/ONSdigital/dp-dataset-api/config"
+	"github.com/ONSdigital/dp-dataset-api/service"
+	"github.com/ONSdigital/dp-dataset-api/store"
+	"github.com/ONSdigital/dp-dataset-api/transfer"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+const (
+	exportCommand = "export"
+	importCommand = "import"
+)
+
+// The standard streams and the datastore of the export and import commands, which are replaced by the tests
+var (
+	stdin      io.Reader = os.Stdin
+	stdout     io.Writer = os.Stdout
+	getMongoDB           = func(ctx context.Context, cfg config.MongoConfig) (store.MongoDB, error) {
+		return (&service.Init{}).DoGetMongoDB(ctx, cfg)
+	}
+)
+
+// datasetIDs is a flag that can be repeated, or given a comma separated list of dataset IDs
+type datasetIDs []string
+
+func (d *datasetIDs) String() string {
+	return strings.Join(*d, ",")
+}
+
+func (d *datasetIDs) Set(value string) error {
+	for _, id := range strings.Split(value, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			*d = append(*d, id)
+		}
+	}
+	return nil
+}
+
+// exportDatasets writes the datasets, with their editions, versions, instances and dimension options, as NDJSON
+// to stdout or to the file provided by -file. Logs are written to stderr, so that they are not mixed with the export.
+func exportDatasets(ctx context.Context, args []string) (err error) {
+	log.SetDestination(os.Stderr, nil)
+
+	var ids datasetIDs
+	flags := flag.NewFlagSet(exportCommand, flag.ContinueOnError)
+	flags.Var(&ids, "dataset", "the ID of a dataset to export, which can be repeated or comma separated (default all datasets)")
+	file := flags.String("file", "", "the file to write to (default stdout)")
+	if err = flags.Parse(args); err != nil {
+		return err
+	}
+
+	w := stdout
+	if *file != "" {
+		f, err := os.Create(*file)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			err = errors.Join(err, f.Close())
+		}()
+		w = f
+	}
+
+	return withMongoDB(ctx, func(mongoDB store.MongoDB) error {
+		counts, err := transfer.Export(ctx, mongoDB, w, transfer.Options{DatasetIDs: ids})
+		log.Info(ctx, "exported records", log.Data{"records": counts})
+		return err
+	})
+}
+
+// importDatasets reads the NDJSON written by the export command from stdin or from the file provided by -file,
+// and writes it to the datastore. With -dry-run it reports what would be imported without writing anything.
+// The report is written to stdout and logs to stderr.
+func importDatasets(ctx context.Context, args []string) (err error) {
+	log.SetDestination(os.Stderr, nil)
+
+	var ids datasetIDs
+	flags := flag.NewFlagSet(importCommand, flag.ContinueOnError)
+	flags.Var(&ids, "dataset", "the ID of a dataset to import, which can be repeated or comma separated (default all datasets)")
+	file := flags.String("file", "", "the file to read from (default stdin)")
+	dryRun := flags.Bool("dry-run", false, "report what would be imported without writing anything")
+	conflict := flags.String("conflict", string(transfer.ConflictFail), "what to do with the resources that already exist: fail, skip or overwrite")
+	if err = flags.Parse(args); err != nil {
+		return err
+	}
+
+	conflictMode, err := transfer.ParseConflictMode(*conflict)
+	if err != nil {
+		return err
+	}
+
+	r := stdin
+	if *file != "" {
+		f, err := os.Open(*file)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			err = errors.Join(err, f.Close())
+		}()
+		r = f
+	}
+
+	return withMongoDB(ctx, func(mongoDB store.MongoDB) error {
+		report, err := transfer.Import(ctx, mongoDB, r, transfer.Options{DatasetIDs: ids, DryRun: *dryRun, Conflict: conflictMode})
+		if reportErr := transfer.WriteReport(stdout, report, *dryRun); reportErr != nil {
+			return errors.Join(err, reportErr)
+		}
+		return err
+	})
+}
+
+// withMongoDB calls fn with the configured datastore, which is closed once fn returns
+func withMongoDB(ctx context.Context, fn func(mongoDB store.MongoDB) error) (err error) {
+	cfg, err := config.Get()
+	if err != nil {
+		log.Error(ctx, "failed to retrieve configuration", err)
+		return err
+	}
+
+	mongoDB, err := getMongoDB(ctx, cfg.MongoConfig)
+	if err != nil {
+		log.Error(ctx, "failed to initialise mongo", err)
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, mongoDB.Close(ctx))
+	}()
+
+	return fn(mongoDB)
+}
@@ -0,0 +1,159 @@
+package transfer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	"github.com/ONSdigital/dp-dataset-api/models"
+)
+
+// Export writes the datasets, or the datasets in opts.DatasetIDs, to w as NDJSON. Each dataset is followed by its
+// editions and either its static versions or its instances, and each instance by its version and dimension options.
+// It returns the number of records written of each kind.
+func Export(ctx context.Context, store Store, w io.Writer, opts Options) (map[string]int, error) {
+	e := &exporter{store: store, enc: newEncoder(w), pageSize: opts.pageSize(), counts: map[string]int{}}
+
+	if len(opts.DatasetIDs) > 0 {
+		for _, id := range opts.DatasetIDs {
+			dataset, err := store.GetDataset(ctx, id)
+			if err != nil {
+				return e.counts, fmt.Errorf("failed to get dataset %q: %w", id, err)
+			}
+			if err := e.exportDataset(ctx, dataset); err != nil {
+				return e.counts, err
+			}
+		}
+		return e.counts, nil
+	}
+
+	for offset := 0; ; offset += e.pageSize {
+		datasets, totalCount, _, err := store.GetDatasets(ctx, offset, e.pageSize, nil, true)
+		if err != nil {
+			return e.counts, fmt.Errorf("failed to get datasets: %w", err)
+		}
+		for _, dataset := range datasets {
+			if err := e.exportDataset(ctx, dataset); err != nil {
+				return e.counts, err
+			}
+		}
+		if len(datasets) == 0 || offset+len(datasets) >= totalCount {
+			return e.counts, nil
+		}
+	}
+}
+
+type exporter struct {
+	store    Store
+	enc      *encoder
+	pageSize int
+	counts   map[string]int
+}
+
+func (e *exporter) encode(kind string, document interface{}) error {
+	if err := e.enc.encode(kind, document); err != nil {
+		return err
+	}
+	e.counts[kind]++
+	return nil
+}
+
+func (e *exporter) exportDataset(ctx context.Context, dataset *models.DatasetUpdate) error {
+	if err := e.encode(KindDataset, dataset); err != nil {
+		return err
+	}
+
+	for offset := 0; ; offset += e.pageSize {
+		editions, totalCount, err := e.store.GetEditions(ctx, dataset.ID, "", offset, e.pageSize, true)
+		if err != nil && !errors.Is(err, errs.ErrEditionNotFound) {
+			return fmt.Errorf("failed to get the editions of dataset %q: %w", dataset.ID, err)
+		}
+		for _, edition := range editions {
+			if err := e.encode(KindEdition, edition); err != nil {
+				return err
+			}
+		}
+		if len(editions) == 0 || offset+len(editions) >= totalCount {
+			break
+		}
+	}
+
+	if dataset.Next != nil && dataset.Next.Type == models.Static.String() {
+		return e.exportStaticVersions(ctx, dataset.ID)
+	}
+	return e.exportInstances(ctx, dataset.ID)
+}
+
+func (e *exporter) exportStaticVersions(ctx context.Context, datasetID string) error {
+	for offset := 0; ; offset += e.pageSize {
+		versions, totalCount, err := e.store.GetAllStaticVersions(ctx, datasetID, "", offset, e.pageSize)
+		if err != nil && !errors.Is(err, errs.ErrVersionsNotFound) {
+			return fmt.Errorf("failed to get the versions of dataset %q: %w", datasetID, err)
+		}
+		for _, version := range versions {
+			if err := e.encode(KindStaticVersion, version); err != nil {
+				return err
+			}
+		}
+		if len(versions) == 0 || offset+len(versions) >= totalCount {
+			return nil
+		}
+	}
+}
+
+func (e *exporter) exportInstances(ctx context.Context, datasetID string) error {
+	for offset := 0; ; offset += e.pageSize {
+		instances, totalCount, _, err := e.store.GetInstances(ctx, nil, []string{datasetID}, offset, e.pageSize, nil)
+		if err != nil {
+			return fmt.Errorf("failed to get the instances of dataset %q: %w", datasetID, err)
+		}
+		for _, instance := range instances {
+			if err := e.exportInstance(ctx, datasetID, instance); err != nil {
+				return err
+			}
+		}
+		if len(instances) == 0 || offset+len(instances) >= totalCount {
+			return nil
+		}
+	}
+}
+
+// exportInstance writes an instance followed by its version, as the fields of a version that are not part of
+// an instance are stored in the same document, and by its dimension options
+func (e *exporter) exportInstance(ctx context.Context, datasetID string, instance *models.Instance) error {
+	if err := e.encode(KindInstance, instance); err != nil {
+		return err
+	}
+
+	if instance.Version > 0 && instance.Edition != "" {
+		version, err := e.store.GetVersion(ctx, datasetID, instance.Edition, instance.Version, "")
+		switch {
+		case errors.Is(err, errs.ErrVersionNotFound):
+		case err != nil:
+			return fmt.Errorf("failed to get the version of instance %q: %w", instance.InstanceID, err)
+		default:
+			if err := e.encode(KindVersion, version); err != nil {
+				return err
+			}
+		}
+	}
+
+	for offset := 0; ; offset += e.pageSize {
+		options, totalCount, err := e.store.GetDimensionsFromInstance(ctx, instance.InstanceID, offset, e.pageSize)
+		if err != nil {
+			return fmt.Errorf("failed to get the dimension options of instance %q: %w", instance.InstanceID, err)
+		}
+		for _, option := range options {
+			// the instance ID is not returned by the store
+			option.InstanceID = instance.InstanceID
+			if err := e.encode(KindDimensionOption, option); err != nil {
+				return err
+			}
+		}
+		if len(options) == 0 || offset+len(options) >= totalCount {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,363 @@
+package transfer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	"github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/dp-dataset-api/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Counts holds the number of resources of a kind that were, or on a dry run would be, created, overwritten and skipped
+type Counts struct {
+	Created     int
+	Overwritten int
+	Skipped     int
+}
+
+// Report holds the counts of an import by record kind
+type Report map[string]*Counts
+
+// Import reads the NDJSON written by Export from r and writes it to the store. Resources that already exist are
+// skipped, overwritten or fail the import according to opts.Conflict. The versions and dimension options of an
+// instance that is written by the import are always written, as they are part of it.
+func Import(ctx context.Context, store Store, r io.Reader, opts Options) (Report, error) {
+	if opts.Conflict == "" {
+		opts.Conflict = ConflictFail
+	}
+
+	i := &importer{store: store, opts: opts, report: Report{}, instances: map[string]*instanceState{}}
+	dec := newDecoder(r)
+
+	for {
+		rec, err := dec.decode()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return i.report, err
+		}
+
+		if rec.Kind != KindDimensionOption {
+			if err := i.flushOptions(ctx); err != nil {
+				return i.report, err
+			}
+		}
+
+		if err := i.importRecord(ctx, rec); err != nil {
+			return i.report, fmt.Errorf("line %d: %w", dec.line, err)
+		}
+	}
+
+	return i.report, i.flushOptions(ctx)
+}
+
+// instanceState tracks an instance whose versions or dimension options are imported
+type instanceState struct {
+	datasetID string
+	exists    bool
+	written   bool
+}
+
+type importer struct {
+	store          Store
+	opts           Options
+	report         Report
+	instances      map[string]*instanceState
+	pendingOptions []*models.DimensionOption
+}
+
+func (i *importer) importRecord(ctx context.Context, rec *rawRecord) error {
+	switch rec.Kind {
+	case KindDataset:
+		var dataset models.DatasetUpdate
+		if err := bson.Unmarshal(rec.Document, &dataset); err != nil {
+			return err
+		}
+		return i.importDataset(ctx, &dataset)
+	case KindEdition:
+		var edition models.EditionUpdate
+		if err := bson.Unmarshal(rec.Document, &edition); err != nil {
+			return err
+		}
+		return i.importEdition(ctx, &edition)
+	case KindInstance:
+		var instance models.Instance
+		if err := bson.Unmarshal(rec.Document, &instance); err != nil {
+			return err
+		}
+		return i.importInstance(ctx, &instance)
+	case KindVersion:
+		var version models.Version
+		if err := bson.Unmarshal(rec.Document, &version); err != nil {
+			return err
+		}
+		return i.importVersion(ctx, &version)
+	case KindStaticVersion:
+		var version models.Version
+		if err := bson.Unmarshal(rec.Document, &version); err != nil {
+			return err
+		}
+		return i.importStaticVersion(ctx, &version)
+	case KindDimensionOption:
+		var option models.DimensionOption
+		if err := bson.Unmarshal(rec.Document, &option); err != nil {
+			return err
+		}
+		return i.importDimensionOption(ctx, &option)
+	default:
+		return fmt.Errorf("%w: %q", ErrUnknownKind, rec.Kind)
+	}
+}
+
+// resolve records the outcome of importing a resource and returns whether it is to be written, which on a dry
+// run it is not
+func (i *importer) resolve(kind, key string, exists bool) (bool, error) {
+	counts, ok := i.report[kind]
+	if !ok {
+		counts = &Counts{}
+		i.report[kind] = counts
+	}
+
+	if !exists {
+		counts.Created++
+		return true, nil
+	}
+
+	switch i.opts.Conflict {
+	case ConflictOverwrite:
+		counts.Overwritten++
+		return true, nil
+	case ConflictSkip:
+		counts.Skipped++
+		return false, nil
+	default:
+		return false, fmt.Errorf("%w: %s %s", ErrConflict, kind, key)
+	}
+}
+
+func (i *importer) importDataset(ctx context.Context, dataset *models.DatasetUpdate) error {
+	if !i.opts.includes(dataset.ID) {
+		return nil
+	}
+
+	_, err := i.store.GetDataset(ctx, dataset.ID)
+	if err != nil && !errors.Is(err, errs.ErrDatasetNotFound) {
+		return err
+	}
+
+	write, err := i.resolve(KindDataset, dataset.ID, err == nil)
+	if !write || i.opts.DryRun {
+		return err
+	}
+	if dataset.Next == nil {
+		dataset.Next = &models.Dataset{}
+	}
+	return i.store.UpsertDataset(ctx, dataset.ID, dataset)
+}
+
+func (i *importer) importEdition(ctx context.Context, edition *models.EditionUpdate) error {
+	if edition.Next == nil || edition.Next.Links == nil || edition.Next.Links.Dataset == nil {
+		return fmt.Errorf("edition %q has no dataset link", edition.ID)
+	}
+	datasetID := edition.Next.Links.Dataset.ID
+	if !i.opts.includes(datasetID) {
+		return nil
+	}
+
+	_, err := i.store.GetEdition(ctx, datasetID, edition.Next.Edition, "")
+	if err != nil && !errors.Is(err, errs.ErrEditionNotFound) {
+		return err
+	}
+
+	write, err := i.resolve(KindEdition, datasetID+"/"+edition.Next.Edition, err == nil)
+	if !write || i.opts.DryRun {
+		return err
+	}
+	return i.store.UpsertEdition(ctx, datasetID, edition.Next.Edition, edition)
+}
+
+func (i *importer) importInstance(ctx context.Context, instance *models.Instance) error {
+	if instance.Links == nil || instance.Links.Dataset == nil {
+		return fmt.Errorf("instance %q has no dataset link", instance.InstanceID)
+	}
+	if !i.opts.includes(instance.Links.Dataset.ID) {
+		return nil
+	}
+
+	existing, err := i.store.GetInstance(ctx, instance.InstanceID, mongo.AnyETag)
+	if err != nil && !errors.Is(err, errs.ErrInstanceNotFound) {
+		return err
+	}
+	state := &instanceState{datasetID: instance.Links.Dataset.ID, exists: existing != nil}
+	i.instances[instance.InstanceID] = state
+
+	write, err := i.resolve(KindInstance, instance.InstanceID, state.exists)
+	state.written = write
+	if !write || i.opts.DryRun {
+		return err
+	}
+
+	if existing == nil {
+		_, err = i.store.AddInstance(ctx, instance)
+		return err
+	}
+	// the unique timestamp of the exported instance would not match the existing one
+	instance.UniqueTimestamp = existing.UniqueTimestamp
+	_, err = i.store.UpdateInstance(ctx, existing, instance, mongo.AnyETag)
+	return err
+}
+
+// instance returns the state of an instance that was not necessarily imported
+func (i *importer) instance(ctx context.Context, id string) (*instanceState, error) {
+	if state, ok := i.instances[id]; ok {
+		return state, nil
+	}
+
+	state := &instanceState{}
+	existing, err := i.store.GetInstance(ctx, id, mongo.AnyETag)
+	switch {
+	case errors.Is(err, errs.ErrInstanceNotFound):
+	case err != nil:
+		return nil, err
+	default:
+		state.exists = true
+		if existing.Links != nil && existing.Links.Dataset != nil {
+			state.datasetID = existing.Links.Dataset.ID
+		}
+	}
+
+	i.instances[id] = state
+	return state, nil
+}
+
+func (i *importer) importVersion(ctx context.Context, version *models.Version) error {
+	if version.Links == nil || version.Links.Dataset == nil {
+		return fmt.Errorf("version %q has no dataset link", version.ID)
+	}
+	if !i.opts.includes(version.Links.Dataset.ID) {
+		return nil
+	}
+
+	state, err := i.instance(ctx, version.ID)
+	if err != nil {
+		return err
+	}
+
+	state.datasetID = version.Links.Dataset.ID
+
+	write, err := i.resolve(KindVersion, version.ID, state.exists && !state.written)
+	state.written = state.written || write
+	if !write || i.opts.DryRun {
+		return err
+	}
+	return i.store.UpsertVersion(ctx, version.ID, version)
+}
+
+func (i *importer) importStaticVersion(ctx context.Context, version *models.Version) error {
+	if version.Links == nil || version.Links.Dataset == nil {
+		return fmt.Errorf("version %q has no dataset link", version.ID)
+	}
+	datasetID := version.Links.Dataset.ID
+	if !i.opts.includes(datasetID) {
+		return nil
+	}
+
+	existing, err := i.store.GetVersionStatic(ctx, datasetID, version.Edition, version.Version, "")
+	if err != nil && !errors.Is(err, errs.ErrVersionNotFound) {
+		return err
+	}
+
+	write, err := i.resolve(KindStaticVersion, fmt.Sprintf("%s/%s/%d", datasetID, version.Edition, version.Version), existing != nil)
+	if !write || i.opts.DryRun {
+		return err
+	}
+
+	if existing == nil {
+		return i.store.UpsertVersionStatic(ctx, version)
+	}
+	_, err = i.store.UpdateVersionStatic(ctx, existing, version, existing.ETag)
+	return err
+}
+
+// importDimensionOption queues an option to be written in a batch. Options are part of their instance: they are
+// written along with an instance written by the import, and otherwise conflict with the existing instance.
+func (i *importer) importDimensionOption(ctx context.Context, option *models.DimensionOption) error {
+	state, err := i.instance(ctx, option.InstanceID)
+	if err != nil {
+		return err
+	}
+	if !i.opts.includes(state.datasetID) {
+		return nil
+	}
+
+	write, err := i.resolve(KindDimensionOption, fmt.Sprintf("%s/%s/%s", option.InstanceID, option.Name, option.Option), state.exists && !state.written)
+	state.written = state.written || write
+	if !write || i.opts.DryRun {
+		return err
+	}
+
+	i.pendingOptions = append(i.pendingOptions, option)
+	if len(i.pendingOptions) >= i.opts.pageSize() {
+		return i.flushOptions(ctx)
+	}
+	return nil
+}
+
+// flushOptions writes the queued dimension options, followed by their node IDs which are not written on insert
+func (i *importer) flushOptions(ctx context.Context) error {
+	if len(i.pendingOptions) == 0 {
+		return nil
+	}
+
+	cached := make([]*models.CachedDimensionOption, 0, len(i.pendingOptions))
+	for _, option := range i.pendingOptions {
+		cached = append(cached, &models.CachedDimensionOption{
+			Code:       option.Links.Code.ID,
+			CodeList:   option.Links.CodeList.ID,
+			InstanceID: option.InstanceID,
+			Label:      option.Label,
+			Name:       option.Name,
+			Option:     option.Option,
+			Order:      option.Order,
+		})
+	}
+
+	if err := i.store.UpsertDimensionsToInstance(ctx, cached); err != nil {
+		return err
+	}
+	if err := i.store.UpdateDimensionsNodeIDAndOrder(ctx, i.pendingOptions); err != nil {
+		return err
+	}
+
+	i.pendingOptions = nil
+	return nil
+}
+
+// WriteReport writes a line for each kind of record imported, describing what was, or on a dry run what would be, done
+func WriteReport(w io.Writer, report Report, dryRun bool) error {
+	if len(report) == 0 {
+		_, err := fmt.Fprintln(w, "nothing to import")
+		return err
+	}
+
+	format := "%s: %d created, %d overwritten, %d skipped\n"
+	if dryRun {
+		format = "%s: would create %d, overwrite %d, skip %d\n"
+	}
+
+	for _, kind := range Kinds {
+		counts, ok := report[kind]
+		if !ok {
+			continue
+		}
+		if _, err := fmt.Fprintf(w, format, kind, counts.Created, counts.Overwritten, counts.Skipped); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package transfer
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Kinds of the records that are exported and imported
+const (
+	KindDataset         = "dataset"
+	KindEdition         = "edition"
+	KindInstance        = "instance"
+	KindVersion         = "version"
+	KindStaticVersion   = "static_version"
+	KindDimensionOption = "dimension_option"
+)
+
+// Kinds lists the kinds of records in the order in which they are reported
+var Kinds = []string{KindDataset, KindEdition, KindInstance, KindVersion, KindStaticVersion, KindDimensionOption}
+
+// maxRecordSize is the maximum size of a line of the NDJSON stream
+const maxRecordSize = 16 * 1024 * 1024
+
+var (
+	// ErrConflict is returned when a record that already exists is imported with the fail conflict mode
+	ErrConflict = errors.New("resource already exists")
+	// ErrUnknownKind is returned when a record of an unknown kind is read
+	ErrUnknownKind = errors.New("unknown record kind")
+)
+
+// record is a line of the NDJSON stream. The document is written as relaxed extended JSON using the bson field
+// names, so that the fields stored in mongo but hidden from the API, such as e_tag, are kept.
+type record struct {
+	Kind     string      `bson:"kind"`
+	Document interface{} `bson:"document"`
+}
+
+// rawRecord is a record whose document is decoded once its kind is known
+type rawRecord struct {
+	Kind     string   `bson:"kind"`
+	Document bson.Raw `bson:"document"`
+}
+
+// encoder writes records to an NDJSON stream
+type encoder struct {
+	w io.Writer
+}
+
+// newEncoder returns an encoder that writes to w
+func newEncoder(w io.Writer) *encoder {
+	return &encoder{w: w}
+}
+
+// encode writes a record of the provided kind on a single line
+func (e *encoder) encode(kind string, document interface{}) error {
+	b, err := bson.MarshalExtJSON(record{Kind: kind, Document: document}, false, false)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", kind, err)
+	}
+
+	_, err = e.w.Write(append(b, '\n'))
+	return err
+}
+
+// decoder reads records from an NDJSON stream
+type decoder struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+// newDecoder returns a decoder that reads from r
+func newDecoder(r io.Reader) *decoder {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxRecordSize)
+	return &decoder{scanner: scanner}
+}
+
+// decode reads the next record, skipping blank lines. It returns io.EOF at the end of the stream.
+func (d *decoder) decode() (*rawRecord, error) {
+	for d.scanner.Scan() {
+		d.line++
+		if len(d.scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var rec rawRecord
+		if err := bson.UnmarshalExtJSON(d.scanner.Bytes(), false, &rec); err != nil {
+			return nil, fmt.Errorf("line %d: %w", d.line, err)
+		}
+		return &rec, nil
+	}
+
+	if err := d.scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, io.EOF
+}
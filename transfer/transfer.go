@@ -0,0 +1,82 @@
+package transfer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/dp-dataset-api/pagination"
+)
+
+// DefaultPageSize is the number of documents read, or dimension options written, at a time
+const DefaultPageSize = 500
+
+// Store represents the datastore methods required to export and import datasets
+type Store interface {
+	GetDataset(ctx context.Context, ID string) (*models.DatasetUpdate, error)
+	GetDatasets(ctx context.Context, offset, limit int, cursor *pagination.Cursor, authorised bool) ([]*models.DatasetUpdate, int, *pagination.Cursor, error)
+	GetEdition(ctx context.Context, ID, editionID, state string) (*models.EditionUpdate, error)
+	GetEditions(ctx context.Context, ID, state string, offset, limit int, authorised bool) ([]*models.EditionUpdate, int, error)
+	GetInstance(ctx context.Context, ID, eTagSelector string) (*models.Instance, error)
+	GetInstances(ctx context.Context, states []string, datasets []string, offset, limit int, cursor *pagination.Cursor) ([]*models.Instance, int, *pagination.Cursor, error)
+	GetVersion(ctx context.Context, datasetID, editionID string, version int, state string) (*models.Version, error)
+	GetVersionStatic(ctx context.Context, datasetID, editionID string, version int, state string) (*models.Version, error)
+	GetAllStaticVersions(ctx context.Context, ID, state string, offset, limit int) ([]*models.Version, int, error)
+	GetDimensionsFromInstance(ctx context.Context, ID string, offset, limit int) ([]*models.DimensionOption, int, error)
+	UpsertDataset(ctx context.Context, ID string, datasetDoc *models.DatasetUpdate) error
+	UpsertEdition(ctx context.Context, datasetID, edition string, editionDoc *models.EditionUpdate) error
+	AddInstance(ctx context.Context, instance *models.Instance) (*models.Instance, error)
+	UpdateInstance(ctx context.Context, currentInstance, updatedInstance *models.Instance, eTagSelector string) (newETag string, err error)
+	UpsertVersion(ctx context.Context, ID string, versionDoc *models.Version) error
+	UpsertVersionStatic(ctx context.Context, versionDoc *models.Version) error
+	UpdateVersionStatic(ctx context.Context, currentVersion, versionUpdate *models.Version, eTagSelector string) (newETag string, err error)
+	UpsertDimensionsToInstance(ctx context.Context, dimensions []*models.CachedDimensionOption) error
+	UpdateDimensionsNodeIDAndOrder(ctx context.Context, updates []*models.DimensionOption) error
+}
+
+// ConflictMode determines what happens when an imported resource already exists
+type ConflictMode string
+
+// Conflict modes
+const (
+	ConflictFail      ConflictMode = "fail"
+	ConflictSkip      ConflictMode = "skip"
+	ConflictOverwrite ConflictMode = "overwrite"
+)
+
+// ErrInvalidConflictMode is returned when parsing an unknown conflict mode
+var ErrInvalidConflictMode = errors.New("invalid conflict mode")
+
+// ParseConflictMode returns the conflict mode with the provided name
+func ParseConflictMode(name string) (ConflictMode, error) {
+	mode := ConflictMode(name)
+	if !slices.Contains([]ConflictMode{ConflictFail, ConflictSkip, ConflictOverwrite}, mode) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidConflictMode, name)
+	}
+	return mode, nil
+}
+
+// Options contains the options of an export or an import
+type Options struct {
+	// DatasetIDs limits the transfer to the datasets with these IDs, and the resources that belong to them
+	DatasetIDs []string
+	// DryRun reports what an import would do without writing anything
+	DryRun bool
+	// Conflict determines what an import does with the resources that already exist
+	Conflict ConflictMode
+	// PageSize is the number of documents read, or dimension options written, at a time
+	PageSize int
+}
+
+func (o Options) pageSize() int {
+	if o.PageSize < 1 {
+		return DefaultPageSize
+	}
+	return o.PageSize
+}
+
+func (o Options) includes(datasetID string) bool {
+	return len(o.DatasetIDs) == 0 || slices.Contains(o.DatasetIDs, datasetID)
+}
@@ -0,0 +1,196 @@
+package transfer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	"github.com/ONSdigital/dp-dataset-api/config"
+	"github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/dp-dataset-api/mongo"
+	"github.com/ONSdigital/dp-dataset-api/store/memory"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+var testContext = context.Background()
+
+func newTestStore() *memory.Store {
+	cfg, err := config.Get()
+	if err != nil {
+		panic(err)
+	}
+	return memory.New(cfg.MongoConfig)
+}
+
+// seed adds a cmd dataset with an instance of two dimension options, and a static dataset with a version
+func seed(s *memory.Store, title, releaseDate string) {
+	datasetLinks := func(id string) *models.LinkObject { return &models.LinkObject{ID: id} }
+	order := 1
+
+	So(s.UpsertDataset(testContext, "cpih01", &models.DatasetUpdate{ID: "cpih01", Next: &models.Dataset{ID: "cpih01", Title: title, Type: models.Filterable.String()}}), ShouldBeNil)
+	So(s.UpsertEdition(testContext, "cpih01", "time-series", &models.EditionUpdate{ID: "e1", Next: &models.Edition{
+		Edition: "time-series", State: models.PublishedState, Links: &models.EditionUpdateLinks{Dataset: datasetLinks("cpih01")},
+	}}), ShouldBeNil)
+	_, err := s.AddInstance(testContext, &models.Instance{
+		InstanceID: "instance-1", Edition: "time-series", Version: 1, State: models.PublishedState, ReleaseDate: releaseDate,
+		Links: &models.InstanceLinks{Dataset: datasetLinks("cpih01")},
+	})
+	So(err, ShouldBeNil)
+	So(s.UpsertVersion(testContext, "instance-1", &models.Version{ID: "instance-1", UsageNotes: &[]models.UsageNote{{Title: "Coverage"}}}), ShouldBeNil)
+	So(s.UpsertDimensionsToInstance(testContext, []*models.CachedDimensionOption{
+		{InstanceID: "instance-1", Name: "geography", Option: "E92000001", Code: "E92000001", CodeList: "uk-only", Order: &order},
+		{InstanceID: "instance-1", Name: "geography", Option: "W92000004", Code: "W92000004", CodeList: "uk-only"},
+	}), ShouldBeNil)
+	So(s.UpdateDimensionsNodeIDAndOrder(testContext, []*models.DimensionOption{{InstanceID: "instance-1", Name: "geography", Option: "E92000001", NodeID: "node-1"}}), ShouldBeNil)
+
+	So(s.UpsertDataset(testContext, "static", &models.DatasetUpdate{ID: "static", Next: &models.Dataset{ID: "static", Title: title, Type: models.Static.String()}}), ShouldBeNil)
+	_, err = s.AddVersionStatic(testContext, &models.Version{
+		ID: "static-1", Edition: "2025", Version: 1, Type: models.Static.String(), ReleaseDate: "2025-01-01", State: models.PublishedState,
+		Links: &models.VersionLinks{Dataset: datasetLinks("static"), Edition: datasetLinks("2025")},
+	})
+	So(err, ShouldBeNil)
+}
+
+func export(s *memory.Store, opts Options) string {
+	var b bytes.Buffer
+	_, err := Export(testContext, s, &b, opts)
+	So(err, ShouldBeNil)
+	return b.String()
+}
+
+func TestExportImport(t *testing.T) {
+	Convey("Given a store with a cmd and a static dataset", t, func() {
+		source := newTestStore()
+		seed(source, "Consumer prices", "2025-01-01")
+
+		Convey("When it is exported", func() {
+			var b bytes.Buffer
+			counts, err := Export(testContext, source, &b, Options{PageSize: 1})
+
+			Convey("Then a record is written for each resource, with its dimension options read a page at a time", func() {
+				So(err, ShouldBeNil)
+				So(counts, ShouldResemble, map[string]int{
+					KindDataset: 2, KindEdition: 1, KindInstance: 1, KindVersion: 1, KindDimensionOption: 2, KindStaticVersion: 1,
+				})
+				So(strings.Count(b.String(), "\n"), ShouldEqual, 8)
+				So(b.String(), ShouldContainSubstring, `"e_tag":`)
+			})
+
+			Convey("And imported into an empty store, then the datasets are cloned", func() {
+				target := newTestStore()
+				report, err := Import(testContext, target, &b, Options{})
+				So(err, ShouldBeNil)
+				So(report[KindDimensionOption], ShouldResemble, &Counts{Created: 2})
+
+				dataset, err := target.GetDataset(testContext, "cpih01")
+				So(err, ShouldBeNil)
+				So(dataset.Next.Title, ShouldEqual, "Consumer prices")
+
+				version, err := target.GetVersion(testContext, "cpih01", "time-series", 1, models.PublishedState)
+				So(err, ShouldBeNil)
+				So(*version.UsageNotes, ShouldResemble, []models.UsageNote{{Title: "Coverage"}})
+
+				options, _, err := target.GetDimensionsFromInstance(testContext, "instance-1", 0, 10)
+				So(err, ShouldBeNil)
+				So(options, ShouldHaveLength, 2)
+				So(options[0].NodeID, ShouldEqual, "node-1")
+				So(*options[0].Order, ShouldEqual, 1)
+
+				staticVersion, err := target.GetVersionStatic(testContext, "static", "2025", 1, "")
+				So(err, ShouldBeNil)
+				So(staticVersion.ReleaseDate, ShouldEqual, "2025-01-01")
+			})
+		})
+
+		Convey("When a single dataset is exported, then only its resources are written", func() {
+			ndjson := export(source, Options{DatasetIDs: []string{"static"}})
+			So(strings.Count(ndjson, "\n"), ShouldEqual, 2)
+			So(ndjson, ShouldNotContainSubstring, "cpih01")
+		})
+
+		Convey("When a dataset that does not exist is exported, then an error is returned", func() {
+			_, err := Export(testContext, source, &bytes.Buffer{}, Options{DatasetIDs: []string{"unknown"}})
+			So(errors.Is(err, errs.ErrDatasetNotFound), ShouldBeTrue)
+		})
+	})
+}
+
+func TestImportConflicts(t *testing.T) {
+	Convey("Given an export of datasets that already exist in the target store", t, func() {
+		source := newTestStore()
+		seed(source, "Updated title", "2025-02-01")
+		ndjson := export(source, Options{})
+
+		target := newTestStore()
+		seed(target, "Original title", "2025-01-01")
+
+		title := func() string {
+			dataset, err := target.GetDataset(testContext, "cpih01")
+			So(err, ShouldBeNil)
+			return dataset.Next.Title
+		}
+
+		Convey("When it is imported with the fail conflict mode, then the import stops at the first conflict", func() {
+			_, err := Import(testContext, target, strings.NewReader(ndjson), Options{Conflict: ConflictFail})
+			So(errors.Is(err, ErrConflict), ShouldBeTrue)
+			So(err.Error(), ShouldContainSubstring, "line 1")
+		})
+
+		Convey("When it is imported with the skip conflict mode, then nothing is changed", func() {
+			report, err := Import(testContext, target, strings.NewReader(ndjson), Options{Conflict: ConflictSkip})
+			So(err, ShouldBeNil)
+			So(report[KindDataset], ShouldResemble, &Counts{Skipped: 2})
+			So(report[KindDimensionOption], ShouldResemble, &Counts{Skipped: 2})
+			So(title(), ShouldEqual, "Original title")
+		})
+
+		Convey("When it is imported with the overwrite conflict mode, then the resources are replaced", func() {
+			report, err := Import(testContext, target, strings.NewReader(ndjson), Options{Conflict: ConflictOverwrite})
+			So(err, ShouldBeNil)
+			So(report[KindInstance], ShouldResemble, &Counts{Overwritten: 1})
+			So(title(), ShouldEqual, "Updated title")
+
+			instance, err := target.GetInstance(testContext, "instance-1", mongo.AnyETag)
+			So(err, ShouldBeNil)
+			So(instance.ReleaseDate, ShouldEqual, "2025-02-01")
+		})
+
+		Convey("When it is imported on a dry run, then what would be done is reported without writing", func() {
+			report, err := Import(testContext, target, strings.NewReader(ndjson), Options{Conflict: ConflictOverwrite, DryRun: true})
+			So(err, ShouldBeNil)
+			So(report[KindDataset], ShouldResemble, &Counts{Overwritten: 2})
+			So(title(), ShouldEqual, "Original title")
+
+			var b bytes.Buffer
+			So(WriteReport(&b, report, true), ShouldBeNil)
+			So(b.String(), ShouldStartWith, "dataset: would create 0, overwrite 2, skip 0\n")
+		})
+
+		Convey("When it is imported for a single dataset, then the other datasets are left alone", func() {
+			report, err := Import(testContext, target, strings.NewReader(ndjson), Options{Conflict: ConflictOverwrite, DatasetIDs: []string{"static"}})
+			So(err, ShouldBeNil)
+			So(report[KindDataset], ShouldResemble, &Counts{Overwritten: 1})
+			So(report, ShouldNotContainKey, KindDimensionOption)
+			So(title(), ShouldEqual, "Original title")
+		})
+	})
+
+	Convey("A record of an unknown kind fails the import", t, func() {
+		_, err := Import(testContext, newTestStore(), strings.NewReader(`{"kind": "unknown", "document": {}}`), Options{})
+		So(errors.Is(err, ErrUnknownKind), ShouldBeTrue)
+	})
+}
+
+func TestParseConflictMode(t *testing.T) {
+	Convey("The conflict modes are parsed by name", t, func() {
+		mode, err := ParseConflictMode("overwrite")
+		So(err, ShouldBeNil)
+		So(mode, ShouldEqual, ConflictOverwrite)
+
+		_, err = ParseConflictMode("merge")
+		So(errors.Is(err, ErrInvalidConflictMode), ShouldBeTrue)
+	})
+}
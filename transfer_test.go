@@ -0,0 +1,64 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/ONSdigital/dp-dataset-api/config"
+	"github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/dp-dataset-api/store"
+	"github.com/ONSdigital/dp-dataset-api/store/memory"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// useTransferStreams replaces the standard streams and the datastore of the export and import commands
+func useTransferStreams(in *bytes.Buffer, out *bytes.Buffer, datastore *memory.Store) {
+	stdin, stdout = in, out
+	getMongoDB = func(context.Context, config.MongoConfig) (store.MongoDB, error) {
+		return datastore, nil
+	}
+}
+
+func TestExportImportCommands(t *testing.T) {
+	ctx := context.Background()
+	cfg, err := config.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	Convey("Given a datastore with a dataset", t, func() {
+		source := memory.New(cfg.MongoConfig)
+		So(source.UpsertDataset(ctx, "cpih01", &models.DatasetUpdate{ID: "cpih01", Next: &models.Dataset{ID: "cpih01", Title: "Consumer prices"}}), ShouldBeNil)
+
+		Convey("When it is exported to stdout and imported from stdin into another datastore", func() {
+			var exported bytes.Buffer
+			useTransferStreams(&bytes.Buffer{}, &exported, source)
+			exportErr := exportDatasets(ctx, nil)
+
+			target := memory.New(cfg.MongoConfig)
+			var report bytes.Buffer
+			useTransferStreams(bytes.NewBuffer(exported.Bytes()), &report, target)
+			importErr := importDatasets(ctx, nil)
+
+			Convey("Then stdout only holds the exported records, without any log lines", func() {
+				So(exportErr, ShouldBeNil)
+				lines := strings.Split(strings.TrimSpace(exported.String()), "\n")
+				So(lines, ShouldHaveLength, 1)
+				var record map[string]interface{}
+				So(json.Unmarshal([]byte(lines[0]), &record), ShouldBeNil)
+				So(record["kind"], ShouldEqual, "dataset")
+			})
+
+			Convey("Then the dataset is imported and the report is written to stdout", func() {
+				So(importErr, ShouldBeNil)
+				So(report.String(), ShouldEqual, "dataset: 1 created, 0 overwritten, 0 skipped\n")
+				dataset, err := target.GetDataset(ctx, "cpih01")
+				So(err, ShouldBeNil)
+				So(dataset.Next.Title, ShouldEqual, "Consumer prices")
+			})
+		})
+	})
+}